@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"time"
 
 	_ "github.com/ncruces/go-sqlite3/embed"
 	"github.com/ncruces/go-sqlite3/gormlite"
@@ -40,10 +41,17 @@ func New(dbLoc string) (*DAO, error) {
 		&Probe{},
 		&ProbeResult{},
 		&Doc{},
+		&NotificationTarget{},
+		&Notification{},
 	); err != nil {
 		return nil, fmt.Errorf("failed to migrate schema: %w", err)
 	}
 
+	// Status pages summarize each probe's recent results.
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_probe_results_probe_created ON probe_results (probe_id, created_at)").Error; err != nil {
+		return nil, fmt.Errorf("failed to index probe results: %w", err)
+	}
+
 	db.Use(gormPrometheus.New(gormPrometheus.Config{
 		DBName: "hdrwtch",
 	}))
@@ -125,11 +133,32 @@ func (dao *DAO) CreateProbeResult(ctx context.Context, tx *gorm.DB, probe Probe,
 		return fmt.Errorf("failed to create probe result: %w", err)
 	}
 
+	prevID := probe.LastResultID
 	probe.LastResultID = result.ID
 	if err := tx.Save(&probe).WithContext(ctx).Error; err != nil {
 		return fmt.Errorf("failed to update probe: %w", err)
 	}
 
+	// Only the latest result's content is needed for the next diff.
+	if err := dao.dropContent(ctx, tx, prevID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// dropContent clears the stored content of a result that is no longer the
+// latest for its probe. The content hash stays, so it still shows whether
+// the page changed.
+func (dao *DAO) dropContent(ctx context.Context, tx *gorm.DB, resultID uint) error {
+	if resultID == 0 {
+		return nil
+	}
+
+	if err := tx.WithContext(ctx).Model(&ProbeResult{}).Where("id = ?", resultID).Update("content", "").Error; err != nil {
+		return fmt.Errorf("failed to drop old probe result content: %w", err)
+	}
+
 	return nil
 }
 
@@ -141,3 +170,141 @@ func (dao *DAO) GetDoc(slug string) (*Doc, error) {
 
 	return &doc, nil
 }
+
+func (dao *DAO) ListTargets(ctx context.Context, userID int64) ([]NotificationTarget, error) {
+	var targets []NotificationTarget
+	if err := dao.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&targets).Error; err != nil {
+		return nil, fmt.Errorf("failed to list notification targets: %w", err)
+	}
+
+	return targets, nil
+}
+
+func (dao *DAO) GetTarget(ctx context.Context, id string, userID int64) (*NotificationTarget, error) {
+	var target NotificationTarget
+
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target ID: %w", err)
+	}
+
+	if err := dao.db.WithContext(ctx).First(&target, idInt).Error; err != nil {
+		return nil, fmt.Errorf("failed to get notification target: %w", err)
+	}
+
+	if target.UserID != userID {
+		return nil, fmt.Errorf("notification target does not belong to user")
+	}
+
+	return &target, nil
+}
+
+// DeleteTarget deletes target and unlinks it from every probe.
+func (dao *DAO) DeleteTarget(ctx context.Context, target *NotificationTarget) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM probe_targets WHERE notification_target_id = ?", target.ID).Error; err != nil {
+			return fmt.Errorf("failed to unlink notification target: %w", err)
+		}
+
+		if err := tx.Delete(target).Error; err != nil {
+			return fmt.Errorf("failed to delete notification target: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ProbeTargets returns the notification targets selected for a probe.
+func (dao *DAO) ProbeTargets(ctx context.Context, probeID uint) ([]NotificationTarget, error) {
+	var targets []NotificationTarget
+	probe := Probe{Model: gorm.Model{ID: probeID}}
+	if err := dao.db.WithContext(ctx).Model(&probe).Association("Targets").Find(&targets); err != nil {
+		return nil, fmt.Errorf("failed to get probe targets: %w", err)
+	}
+
+	return targets, nil
+}
+
+// SetProbeTargets replaces the notification targets selected for a probe.
+// targets must already belong to the probe's owner.
+func (dao *DAO) SetProbeTargets(ctx context.Context, probe *Probe, targets []NotificationTarget) error {
+	if err := dao.db.WithContext(ctx).Model(probe).Association("Targets").Replace(targets); err != nil {
+		return fmt.Errorf("failed to set probe targets: %w", err)
+	}
+
+	return nil
+}
+
+func (dao *DAO) ListNotifications(ctx context.Context, userID int64, limit int) ([]Notification, error) {
+	var notifications []Notification
+	if err := dao.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&notifications).
+		Error; err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// WindowStats summarizes the runs of each of probeIDs since since. Probes
+// that haven't run since then are left out.
+//
+// Status pages can be public, so this is done by the database instead of by
+// loading every run.
+func (dao *DAO) WindowStats(ctx context.Context, probeIDs []uint, since time.Time) (map[uint]WindowStats, error) {
+	if len(probeIDs) == 0 {
+		return nil, nil
+	}
+
+	var counts []struct {
+		ProbeID       uint
+		Runs, Healthy int
+	}
+	if err := dao.db.WithContext(ctx).
+		Model(&ProbeResult{}).
+		Select("probe_id, COUNT(*) AS runs, SUM(CASE WHEN success AND NOT assertion_failed THEN 1 ELSE 0 END) AS healthy").
+		Where("probe_id IN ? AND created_at >= ?", probeIDs, since).
+		Group("probe_id").
+		Scan(&counts).
+		Error; err != nil {
+		return nil, fmt.Errorf("failed to count probe results: %w", err)
+	}
+
+	// Nearest-rank percentiles: the ceil(q*n/100)th fastest completed run.
+	var latencies []struct {
+		ProbeID       uint
+		P50, P95, P99 time.Duration
+	}
+	if err := dao.db.WithContext(ctx).Raw(`
+		SELECT probe_id,
+			MAX(CASE WHEN rn = (50 * n + 99) / 100 THEN duration END) AS p50,
+			MAX(CASE WHEN rn = (95 * n + 99) / 100 THEN duration END) AS p95,
+			MAX(CASE WHEN rn = (99 * n + 99) / 100 THEN duration END) AS p99
+		FROM (
+			SELECT probe_id, duration,
+				ROW_NUMBER() OVER (PARTITION BY probe_id ORDER BY duration) AS rn,
+				COUNT(*) OVER (PARTITION BY probe_id) AS n
+			FROM probe_results
+			WHERE probe_id IN ? AND created_at >= ? AND success AND deleted_at IS NULL
+		)
+		GROUP BY probe_id`, probeIDs, since).
+		Scan(&latencies).
+		Error; err != nil {
+		return nil, fmt.Errorf("failed to get probe latencies: %w", err)
+	}
+
+	result := make(map[uint]WindowStats, len(counts))
+	for _, c := range counts {
+		result[c.ProbeID] = WindowStats{Runs: c.Runs, Healthy: c.Healthy}
+	}
+	for _, l := range latencies {
+		ws := result[l.ProbeID]
+		ws.P50, ws.P95, ws.P99 = l.P50, l.P95, l.P99
+		result[l.ProbeID] = ws
+	}
+
+	return result, nil
+}
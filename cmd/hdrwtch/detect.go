@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xpath"
+	"github.com/aymanbagabas/go-udiff"
+	"golang.org/x/net/html"
+)
+
+// Change detection modes. A probe's mode decides which part of the response
+// is compared between runs.
+const (
+	ModeLastModified = "last-modified" // the Last-Modified header (default)
+	ModeETag         = "etag"          // the ETag header
+	ModeSHA256       = "sha256"        // a SHA-256 hash of the whole body
+	ModeCSS          = "css"           // text of the elements a CSS selector matches
+	ModeXPath        = "xpath"         // text of the nodes an XPath expression matches
+	ModeJSONPointer  = "json-pointer"  // the value an RFC 6901 JSON pointer refers to
+)
+
+// probeModes lists the modes in the order the UI offers them.
+var probeModes = []struct {
+	Value, Label string
+}{
+	{ModeLastModified, "Last-Modified header"},
+	{ModeETag, "ETag header"},
+	{ModeSHA256, "Body hash (SHA-256)"},
+	{ModeCSS, "CSS selector"},
+	{ModeXPath, "XPath expression"},
+	{ModeJSONPointer, "JSON pointer"},
+}
+
+const (
+	// maxBodySize is how much of a response body hdrwtch reads when a
+	// probe's mode needs the body.
+	maxBodySize = 4 << 20
+	// maxStoredContent is how much extracted content is kept on a probe's
+	// latest result for diffing against the next run.
+	maxStoredContent = 64 << 10
+	// maxDiffSize is how much of a diff goes into a notification.
+	maxDiffSize = 3000
+)
+
+// probeMode returns the probe's mode, treating probes created before modes
+// existed as Last-Modified probes.
+func probeMode(p Probe) string {
+	if p.Mode == "" {
+		return ModeLastModified
+	}
+	return p.Mode
+}
+
+// readsBody reports whether the mode needs the response body.
+func readsBody(mode string) bool {
+	switch mode {
+	case ModeSHA256, ModeCSS, ModeXPath, ModeJSONPointer:
+		return true
+	}
+	return false
+}
+
+// validateProbeMode checks that mode is known and that selector compiles for
+// it, so a typo is reported when the probe is saved instead of on every run.
+func validateProbeMode(mode, selector string) error {
+	switch mode {
+	case "", ModeLastModified, ModeETag, ModeSHA256:
+		return nil
+	case ModeCSS:
+		if _, err := cascadia.Compile(selector); err != nil {
+			return fmt.Errorf("invalid CSS selector: %w", err)
+		}
+	case ModeXPath:
+		if _, err := xpath.Compile(selector); err != nil {
+			return fmt.Errorf("invalid XPath expression: %w", err)
+		}
+	case ModeJSONPointer:
+		if _, err := parseJSONPointer(selector); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown change detection mode %q", mode)
+	}
+	return nil
+}
+
+// extractContent returns the part of body the mode compares, as text.
+func extractContent(mode, selector string, body []byte) (string, error) {
+	switch mode {
+	case ModeSHA256:
+		if !utf8.Valid(body) {
+			// Binary bodies are still hashed, but there is nothing useful
+			// to diff.
+			return "", nil
+		}
+		return string(body), nil
+	case ModeCSS:
+		sel, err := cascadia.Compile(selector)
+		if err != nil {
+			return "", fmt.Errorf("invalid CSS selector: %w", err)
+		}
+		doc, err := html.Parse(bytes.NewReader(body))
+		if err != nil {
+			return "", fmt.Errorf("can't parse HTML: %w", err)
+		}
+		var lines []string
+		for _, n := range cascadia.QueryAll(doc, sel) {
+			lines = append(lines, strings.TrimSpace(htmlquery.InnerText(n)))
+		}
+		return joinLines(lines), nil
+	case ModeXPath:
+		doc, err := htmlquery.Parse(bytes.NewReader(body))
+		if err != nil {
+			return "", fmt.Errorf("can't parse HTML: %w", err)
+		}
+		nodes, err := htmlquery.QueryAll(doc, selector)
+		if err != nil {
+			return "", fmt.Errorf("invalid XPath expression: %w", err)
+		}
+		var lines []string
+		for _, n := range nodes {
+			lines = append(lines, strings.TrimSpace(htmlquery.InnerText(n)))
+		}
+		return joinLines(lines), nil
+	case ModeJSONPointer:
+		var doc any
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return "", fmt.Errorf("can't parse JSON: %w", err)
+		}
+		val, err := resolveJSONPointer(doc, selector)
+		if err != nil {
+			return "", err
+		}
+		if s, ok := val.(string); ok {
+			return s + "\n", nil
+		}
+		out, err := json.MarshalIndent(val, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(out) + "\n", nil
+	}
+	return "", fmt.Errorf("mode %q does not extract content", mode)
+}
+
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func hashContent(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// truncateContent cuts s to at most n bytes on a rune boundary.
+func truncateContent(s string, n int) (string, bool) {
+	if len(s) <= n {
+		return s, false
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n], true
+}
+
+// parseJSONPointer splits an RFC 6901 pointer into unescaped reference
+// tokens. The empty pointer refers to the whole document.
+func parseJSONPointer(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(ptr, "/") {
+		return nil, errors.New("invalid JSON pointer: must be empty or start with /")
+	}
+	tokens := strings.Split(ptr[1:], "/")
+	for i, tok := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(tok)
+	}
+	return tokens, nil
+}
+
+func resolveJSONPointer(doc any, ptr string) (any, error) {
+	tokens, err := parseJSONPointer(ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	cur := doc
+	for _, tok := range tokens {
+		switch v := cur.(type) {
+		case map[string]any:
+			next, ok := v[tok]
+			if !ok {
+				return nil, fmt.Errorf("JSON pointer %s: no member %q", ptr, tok)
+			}
+			cur = next
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(v) || (len(tok) > 1 && tok[0] == '0') {
+				return nil, fmt.Errorf("JSON pointer %s: no index %q", ptr, tok)
+			}
+			cur = v[i]
+		default:
+			return nil, fmt.Errorf("JSON pointer %s: can't index into %T with %q", ptr, cur, tok)
+		}
+	}
+	return cur, nil
+}
+
+// resultSignal is the value a probe's mode compares between runs.
+func resultSignal(mode string, r ProbeResult) string {
+	switch mode {
+	case ModeLastModified:
+		return r.LastModified
+	case ModeETag:
+		return r.ETag
+	default:
+		return r.ContentHash
+	}
+}
+
+// resultSummary is a short, human-readable form of a result's signal for
+// tables and notifications.
+func resultSummary(mode string, r ProbeResult) string {
+	sig := resultSignal(mode, r)
+	if readsBody(mode) && len(sig) > 12 {
+		return "sha256:" + sig[:12]
+	}
+	return sig
+}
+
+// probeChanged reports whether cur differs from prev in what the probe
+// watches. A failed run has no signal, so failures and recoveries count as
+// changes too.
+func probeChanged(p Probe, prev, cur ProbeResult) bool {
+	mode := probeMode(p)
+	return resultSignal(mode, prev) != resultSignal(mode, cur)
+}
+
+// changeDiff renders a unified diff between two runs of a probe. Header
+// modes diff the header value; content modes diff the extracted content.
+func changeDiff(p Probe, prev, cur ProbeResult) string {
+	mode := probeMode(p)
+
+	var before, after string
+	if readsBody(mode) {
+		before, after = prev.Content, cur.Content
+	} else {
+		before, after = resultSignal(mode, prev), resultSignal(mode, cur)
+		if before != "" {
+			before += "\n"
+		}
+		if after != "" {
+			after += "\n"
+		}
+	}
+
+	diff := udiff.Unified("before", "after", before, after)
+	if diff, cut := truncateContent(diff, maxDiffSize); cut {
+		return diff + "\n[diff truncated]\n"
+	}
+	return diff
+}
+
+// changeMessage is the notification text for a probe whose result changed.
+func changeMessage(p Probe, prev, cur ProbeResult) string {
+	mode := probeMode(p)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%s*:\n\n", p.Name)
+	fmt.Fprintf(&sb, "Mode: %s\n", mode)
+	if mode == ModeLastModified {
+		fmt.Fprintf(&sb, "Last modified: %s\n", cur.LastModified)
+	} else {
+		fmt.Fprintf(&sb, "Value: %s\n", resultSummary(mode, cur))
+	}
+	fmt.Fprintf(&sb, "Region: %s\nStatus code: %d\nRemark: %s\n", cur.Region, cur.StatusCode, cur.Remark)
+
+	if diff := changeDiff(p, prev, cur); diff != "" {
+		fmt.Fprintf(&sb, "\n```\n%s```", diff)
+	}
+
+	return sb.String()
+}
+
+// probeModeLabel is the UI label for mode.
+func probeModeLabel(mode string) string {
+	for _, m := range probeModes {
+		if m.Value == mode {
+			return m.Label
+		}
+	}
+	return mode
+}
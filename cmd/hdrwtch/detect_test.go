@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExtractContent(t *testing.T) {
+	page := []byte(`<html><body><h1>Schedule</h1><ul id="times"><li>08:00</li><li>09:30</li></ul></body></html>`)
+	api := []byte(`{"routes":[{"id":"95","next":"08:12"}],"a/b":{"c~d":true}}`)
+
+	for _, tt := range []struct {
+		name, mode, selector string
+		body                 []byte
+		want                 string
+		wantErr              bool
+	}{
+		{name: "css", mode: ModeCSS, selector: "#times li", body: page, want: "08:00\n09:30\n"},
+		{name: "css no match", mode: ModeCSS, selector: "table", body: page, want: ""},
+		{name: "xpath", mode: ModeXPath, selector: "//h1", body: page, want: "Schedule\n"},
+		{name: "xpath invalid", mode: ModeXPath, selector: "//[", body: page, wantErr: true},
+		{name: "json string", mode: ModeJSONPointer, selector: "/routes/0/next", body: api, want: "08:12\n"},
+		{name: "json object", mode: ModeJSONPointer, selector: "/routes/0", body: api, want: "{\n  \"id\": \"95\",\n  \"next\": \"08:12\"\n}\n"},
+		{name: "json escapes", mode: ModeJSONPointer, selector: "/a~1b/c~0d", body: api, want: "true\n"},
+		{name: "json missing", mode: ModeJSONPointer, selector: "/routes/1", body: api, wantErr: true},
+		{name: "json leading zero", mode: ModeJSONPointer, selector: "/routes/00", body: api, wantErr: true},
+		{name: "sha256 keeps text", mode: ModeSHA256, body: []byte("hi\n"), want: "hi\n"},
+		{name: "sha256 drops binary", mode: ModeSHA256, body: []byte{0xff, 0xfe}, want: ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractContent(tt.mode, tt.selector, tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractContent err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("extractContent = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateProbeMode(t *testing.T) {
+	for _, tt := range []struct {
+		mode, selector string
+		ok             bool
+	}{
+		{"", "", true},
+		{ModeETag, "", true},
+		{ModeCSS, "div > p", true},
+		{ModeCSS, "div >", false},
+		{ModeXPath, "//p[@class='x']", true},
+		{ModeXPath, "//[", false},
+		{ModeJSONPointer, "", true},
+		{ModeJSONPointer, "routes", false},
+		{"telepathy", "", false},
+	} {
+		if err := validateProbeMode(tt.mode, tt.selector); (err == nil) != tt.ok {
+			t.Errorf("validateProbeMode(%q, %q) = %v, want ok=%v", tt.mode, tt.selector, err, tt.ok)
+		}
+	}
+}
+
+func TestCheckURLConditional(t *testing.T) {
+	body := `<p class="price">10</p>`
+	var conditionalHits int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` && body == `<p class="price">10</p>` {
+			conditionalHits++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	probe := Probe{Name: "price", URL: srv.URL, Mode: ModeCSS, Selector: ".price"}
+	ctx := context.Background()
+
+	first := checkURL(ctx, probe, ProbeResult{})
+	if !first.Success || first.Content != "10\n" || first.ETag != `"v1"` {
+		t.Fatalf("first run = %+v", first)
+	}
+	if !probeChanged(probe, ProbeResult{}, *first) {
+		t.Error("first run is not a change from nothing")
+	}
+
+	second := checkURL(ctx, probe, *first)
+	if conditionalHits != 1 || !second.NotModified {
+		t.Fatalf("second run was not a conditional hit: hits=%d result=%+v", conditionalHits, second)
+	}
+	if second.Content != first.Content || second.ContentHash != first.ContentHash {
+		t.Error("304 did not carry the previous content forward")
+	}
+	if probeChanged(probe, *first, *second) {
+		t.Error("304 counted as a change")
+	}
+
+	body = `<p class="price">12</p>`
+	third := checkURL(ctx, probe, *second)
+	if !probeChanged(probe, *second, *third) {
+		t.Fatal("price change not detected")
+	}
+
+	msg := changeMessage(probe, *second, *third)
+	for _, want := range []string{"-10", "+12", "Mode: css"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("change message lacks %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestChangeDiffHeaderModes(t *testing.T) {
+	probe := Probe{Name: "feed"}
+	prev := ProbeResult{LastModified: "Mon, 02 Jan 2006 15:04:05 GMT"}
+	cur := ProbeResult{LastModified: "Tue, 03 Jan 2006 15:04:05 GMT"}
+
+	diff := changeDiff(probe, prev, cur)
+	if !strings.Contains(diff, "-Mon, 02 Jan 2006") || !strings.Contains(diff, "+Tue, 03 Jan 2006") {
+		t.Errorf("diff does not show the header change:\n%s", diff)
+	}
+}
+
+func TestOnlyLatestResultKeepsContent(t *testing.T) {
+	s := testServer(t)
+	ctx := context.Background()
+
+	probe := Probe{Name: "price", URL: "https://example.com", Mode: ModeCSS, Selector: ".price"}
+	if err := s.dao.db.Create(&probe).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for _, content := range []string{"10\n", "12\n"} {
+		if err := s.dao.db.Preload("LastResult").First(&probe, probe.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		result := &ProbeResult{ProbeID: probe.ID, Success: true, ContentHash: hashContent([]byte(content)), Content: content}
+		if err := s.dao.CreateProbeResult(ctx, s.dao.db, probe, result); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var results []ProbeResult
+	if err := s.dao.db.Where("probe_id = ?", probe.ID).Order("id").Find(&results).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].Content != "" || results[0].ContentHash == "" {
+		t.Errorf("old result = %+v, want its hash without its content", results[0])
+	}
+	if err := s.dao.db.First(&probe, probe.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if probe.LastResultID != results[1].ID {
+		t.Errorf("last result = %d, want %d", probe.LastResultID, results[1].ID)
+	}
+	if results[1].Content != "12\n" {
+		t.Errorf("latest result content = %q, want %q", results[1].Content, "12\n")
+	}
+}
@@ -3,33 +3,84 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"runtime"
+	"slices"
+	"sync"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 	"within.website/x/web/useragent"
 )
 
-func checkURL(ctx context.Context, probe Probe) *ProbeResult {
+// runProbe runs probe, retrying up to probe.Retries more times while the
+// run is unhealthy, and checks the result against the probe's assertions.
+func runProbe(ctx context.Context, probe Probe, prev ProbeResult) *ProbeResult {
+	var result *ProbeResult
+	for attempt := 1; ; attempt++ {
+		result = checkURL(ctx, probe, prev)
+		checkAssertions(probe, result)
+		result.Attempts = attempt
+
+		if result.Healthy() || attempt > probe.Retries {
+			return result
+		}
+
+		select {
+		case <-ctx.Done():
+			return result
+		case <-time.After(time.Duration(attempt) * retryDelay):
+		}
+	}
+}
+
+// checkURL runs probe once. prev is the probe's previous result: when it
+// recorded what this probe's mode watches, the request is made conditional
+// on it so an unchanged page is not downloaded again, and a 304 Not Modified
+// response carries prev's values forward.
+func checkURL(ctx context.Context, probe Probe, prev ProbeResult) *ProbeResult {
 	result := &ProbeResult{
 		ProbeID: probe.ID,
 		Region:  *region,
 	}
 
+	mode := probeMode(probe)
 	userAgent := useragent.GenUserAgent("hdrwtch/1.0", fmt.Sprintf("https://%s/docs/why-in-logs", *domain))
 
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout(probe))
+	defer cancel()
+
 	start := time.Now()
-	req, err := http.NewRequestWithContext(ctx, "GET", probe.URL, nil)
+	req, err := http.NewRequestWithContext(ctx, probeMethod(probe), probe.URL, nil)
 	if err != nil {
 		result.Success = false
 		result.Remark = fmt.Sprintf("failed to create request: %v", err)
 		return result
 	}
 
+	headers, err := parseProbeHeaders(probe.Headers)
+	if err != nil {
+		result.Success = false
+		result.Remark = err.Error()
+		return result
+	}
+	for name, vals := range headers {
+		req.Header[name] = vals
+	}
 	req.Header.Set("User-Agent", userAgent)
 
+	conditional := prev.Success && resultSignal(mode, prev) != ""
+	if conditional {
+		if prev.ETag != "" {
+			req.Header.Set("If-None-Match", prev.ETag)
+		}
+		if prev.LastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.LastModified)
+		}
+	}
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		result.Success = false
@@ -40,14 +91,61 @@ func checkURL(ctx context.Context, probe Probe) *ProbeResult {
 
 	result.Success = true
 	result.StatusCode = resp.StatusCode
-	result.Duration = time.Since(start)
 	result.LastModified = resp.Header.Get("Last-Modified")
+	result.ETag = resp.Header.Get("ETag")
+
+	if conditional && resp.StatusCode == http.StatusNotModified {
+		result.Duration = time.Since(start)
+		result.NotModified = true
+		if result.LastModified == "" {
+			result.LastModified = prev.LastModified
+		}
+		if result.ETag == "" {
+			result.ETag = prev.ETag
+		}
+		result.ContentHash = prev.ContentHash
+		result.Content = prev.Content
+		return result
+	}
+
+	if readsBody(mode) {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+		if err != nil {
+			result.Success = false
+			result.Remark = fmt.Sprintf("failed to read body: %v", err)
+			return result
+		}
+
+		content, err := extractContent(mode, probe.Selector, body)
+		if err != nil {
+			result.Success = false
+			result.Remark = err.Error()
+			return result
+		}
+
+		if mode == ModeSHA256 {
+			result.ContentHash = hashContent(body)
+		} else {
+			result.ContentHash = hashContent([]byte(content))
+		}
+
+		if content, cut := truncateContent(content, maxStoredContent); cut {
+			result.Content = content
+			result.Remark = fmt.Sprintf("content truncated to %d bytes for diffing", maxStoredContent)
+		} else {
+			result.Content = content
+		}
+	}
+
+	result.Duration = time.Since(start)
 
 	return result
 }
 
 func (s *Server) cron() {
-	ctx, cancel := context.WithTimeout(context.Background(), 14*time.Minute) // 1 minute less than the cron interval
+	// Runs that overlap the next tick are skipped by the scheduler, so this
+	// only bounds runs that hang.
+	ctx, cancel := context.WithTimeout(context.Background(), 14*time.Minute)
 	defer cancel()
 
 	tx := s.dao.db.Begin().WithContext(ctx)
@@ -60,27 +158,33 @@ func (s *Server) cron() {
 		return
 	}
 
+	now := time.Now()
+	probes = slices.DeleteFunc(probes, func(p Probe) bool {
+		return !probeDue(p, now)
+	})
+
+	// Alerts are queued once the results are committed so that they can
+	// link to the results, and are sent by the notification workers so
+	// that slow targets hold up neither the transaction nor the next run.
+	var (
+		lock   sync.Mutex
+		alerts []alert
+	)
+
 	g, gCtx := errgroup.WithContext(ctx)
 	g.SetLimit(runtime.NumCPU())
 
 	for _, probe := range probes {
 
 		g.Go(func() error {
-			result := checkURL(gCtx, probe)
-
-			if result.LastModified != probe.LastResult.LastModified {
-				slog.InfoContext(gCtx, "probe result changed", "probe", probe.ID, "old", probe.LastResult, "new", result)
-
-				user, err := s.dao.GetUser(gCtx, probe.UserID)
-				if err != nil {
-					slog.ErrorContext(gCtx, "failed to get user", "err", err)
-					return err
-				}
-
-				if err := s.messageUser(user, fmt.Sprintf("*%s*:\n\nLast modified: %s\nRegion: %s\nStatus code: %d\nRemark: %s", probe.Name, result.LastModified, result.Region, result.StatusCode, result.Remark)); err != nil {
-					slog.ErrorContext(gCtx, "failed to message user", "err", err)
-					return err
-				}
+			prev := probe.LastResult
+			result := runProbe(gCtx, probe, prev)
+
+			failures := probe.ConsecutiveFailures
+			if result.Healthy() {
+				probe.ConsecutiveFailures = 0
+			} else {
+				probe.ConsecutiveFailures++
 			}
 
 			if err := s.dao.CreateProbeResult(gCtx, tx, probe, result); err != nil {
@@ -88,6 +192,30 @@ func (s *Server) cron() {
 				return err
 			}
 
+			var msgs []Message
+			switch {
+			case probe.AlertAfter > 0 && probe.ConsecutiveFailures == probe.AlertAfter:
+				msgs = append(msgs, downMessage(probe, *result))
+			case probe.AlertAfter > 0 && result.Healthy() && failures >= probe.AlertAfter:
+				msgs = append(msgs, upMessage(probe, *result, failures))
+			}
+
+			// With failure alerts on, going down and coming back up are
+			// reported by those instead of as changes.
+			failureTransition := !prev.Success || !result.Success
+			// A probe with no last result is taking its baseline.
+			baseline := probe.LastResultID == 0
+			if !baseline && probeChanged(probe, prev, *result) && !(probe.AlertAfter > 0 && failureTransition) {
+				slog.InfoContext(gCtx, "probe result changed", "probe", probe.ID, "old", prev, "new", result)
+				msgs = append(msgs, changeNotification(probe, prev, *result))
+			}
+
+			lock.Lock()
+			for _, msg := range msgs {
+				alerts = append(alerts, alert{probe, msg})
+			}
+			lock.Unlock()
+
 			return nil
 		})
 	}
@@ -98,7 +226,12 @@ func (s *Server) cron() {
 
 	if err := tx.Commit().Error; err != nil {
 		slog.ErrorContext(ctx, "failed to commit transaction", "err", err)
+		return
 	}
 
-	slog.InfoContext(ctx, "checked probes", "count", len(probes))
+	slog.InfoContext(ctx, "checked probes", "count", len(probes), "alerts", len(alerts))
+
+	for _, a := range alerts {
+		s.queueAlert(ctx, a)
+	}
 }
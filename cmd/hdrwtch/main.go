@@ -1,22 +1,28 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"flag"
 	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/smtp"
 	"os"
 	"time"
 
 	"github.com/a-h/templ"
+	"github.com/cenkalti/backoff/v4"
 	"github.com/gorilla/securecookie"
 	"github.com/gorilla/sessions"
+	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/mymmrac/telego"
 	"github.com/robfig/cron/v3"
 	"within.website/x/htmx"
 	"within.website/x/internal"
+	"within.website/x/web/mastodon"
 )
 
 //go:generate tailwindcss --input styles.css --output static/css/styles.css
@@ -31,6 +37,13 @@ var (
 	port         = flag.String("port", "8080", "Port to listen on")
 	region       = flag.String("fly-region", "yow-dev", "Region of this instance")
 
+	mastodonInstance = flag.String("mastodon-instance", "", "Mastodon instance URL of the bot that sends DM notifications (if unset, Mastodon notifications are disabled)")
+	mastodonToken    = flag.String("mastodon-token", "", "Mastodon access token of the bot that sends DM notifications")
+	smtpAddr         = flag.String("smtp-addr", "", "host:port of the SMTP relay for email notifications (if unset, email notifications are disabled)")
+	smtpFrom         = flag.String("smtp-from", "hdrwtch@xeserv.us", "From address of email notifications")
+	smtpUsername     = flag.String("smtp-username", "", "SMTP username, if the relay requires authentication")
+	smtpPassword     = flag.String("smtp-password", "", "SMTP password")
+
 	//dbURL        = flag.String("database-url", "", "Database URL")
 
 	//go:embed static
@@ -60,6 +73,23 @@ func main() {
 		store: sessions.NewCookieStore([]byte(*cookieSecret)),
 		dao:   dao,
 		tg:    bot,
+
+		statusCache: newStatusCache(),
+	}
+
+	if *mastodonInstance != "" {
+		s.mastodon, err = mastodon.Authenticated("hdrwtch", fmt.Sprintf("https://%s", *domain), *mastodonInstance, *mastodonToken)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *smtpAddr != "" {
+		s.mailer = &mailer{addr: *smtpAddr, from: *smtpFrom}
+		if *smtpUsername != "" {
+			host, _, _ := net.SplitHostPort(*smtpAddr)
+			s.mailer.auth = smtp.PlainAuth("", *smtpUsername, *smtpPassword, host)
+		}
 	}
 
 	if err := s.importDocs(); err != nil {
@@ -89,6 +119,20 @@ func main() {
 	mux.Handle("PUT /probe/{id}", s.loggedIn(s.probeUpdate))
 	mux.Handle("DELETE /probe/{id}", s.loggedIn(s.probeDelete))
 	mux.Handle("GET /probe/{id}/run/{result_id}", s.loggedIn(s.probeRunGet))
+	mux.Handle("PUT /probe/{id}/targets", s.loggedIn(s.probeTargetsUpdate))
+	mux.Handle("PUT /probe/{id}/settings", s.loggedIn(s.probeSettingsUpdate))
+
+	// status pages
+	mux.Handle("GET /status", s.loggedIn(s.statusGet))
+	mux.Handle("PUT /status/public", s.loggedIn(s.statusPublicUpdate))
+	mux.HandleFunc("GET /status/{id}", s.publicStatusGet)
+
+	// notification targets
+	mux.Handle("GET /notify", s.loggedIn(s.targetList))
+	mux.Handle("POST /notify", s.loggedIn(s.targetCreate))
+	mux.Handle("DELETE /notify/{id}", s.loggedIn(s.targetDelete))
+	mux.Handle("POST /notify/{id}/test", s.loggedIn(s.targetTest))
+	mux.Handle("GET /notify/history", s.loggedIn(s.notificationHistory))
 
 	mux.Handle("/", internal.UnchangingCache(
 		templ.Handler(
@@ -109,13 +153,12 @@ func main() {
 		fmt.Fprintln(w, val)
 	})
 
-	c := cron.New()
-	if *region == "yow-dev" {
-		c.AddFunc("@every 1m", s.cron)
-		slog.Info("running in dev mode", "cron-frequency", "1m")
-	} else {
-		c.AddFunc("@every 15m", s.cron)
-	}
+	// The scheduler ticks every minute and runs the probes that are due;
+	// see probeDue.
+	s.startNotifier(context.Background())
+
+	c := cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DefaultLogger)))
+	c.AddFunc("@every 1m", s.cron)
 	go c.Start()
 
 	slog.Info("listening", "on", "http://localhost:"+*port)
@@ -124,9 +167,21 @@ func main() {
 }
 
 type Server struct {
-	store *sessions.CookieStore
-	dao   *DAO
-	tg    *telego.Bot
+	store    *sessions.CookieStore
+	dao      *DAO
+	tg       *telego.Bot
+	mastodon *mastodon.Client // nil if Mastodon notifications are disabled
+	mailer   *mailer          // nil if email notifications are disabled
+
+	// statusCache holds rendered public status pages by user ID.
+	statusCache *expirable.LRU[int64, string]
+
+	// alerts feeds the notification workers; see startNotifier.
+	alerts chan alert
+
+	// client and backOff are overridable for tests.
+	client  *http.Client
+	backOff func() backoff.BackOff
 }
 
 func (s *Server) index(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"within.website/x/web"
+	"within.website/x/web/discordwebhook"
+	"within.website/x/web/mastodon"
+)
+
+const (
+	// webhookSignatureHeader carries the hex HMAC-SHA256 of the timestamp, a
+	// dot, and the request body, keyed with the target's secret.
+	webhookSignatureHeader = "X-Hdrwtch-Signature"
+	// webhookTimestampHeader is the Unix time the payload was signed at, so
+	// receivers can reject replays.
+	webhookTimestampHeader = "X-Hdrwtch-Timestamp"
+
+	// mastodonStatusLimit is the default status length limit on Mastodon.
+	mastodonStatusLimit = 500
+	// discordDescriptionLimit is the length limit of a Discord embed
+	// description.
+	discordDescriptionLimit = 4096
+)
+
+// webhookPayload is the JSON body POSTed to webhook targets.
+type webhookPayload struct {
+	Event  string         `json:"event"` // "probe.changed", "probe.down", "probe.up" or "test"
+	Title  string         `json:"title"`
+	Text   string         `json:"text"`
+	Diff   string         `json:"diff,omitempty"`
+	Probe  *webhookProbe  `json:"probe,omitempty"`
+	Result *webhookResult `json:"result,omitempty"`
+	SentAt time.Time      `json:"sent_at"`
+}
+
+type webhookProbe struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Mode     string `json:"mode"`
+	Selector string `json:"selector,omitempty"`
+}
+
+type webhookResult struct {
+	ID           uint      `json:"id"`
+	Success      bool      `json:"success"`
+	StatusCode   int       `json:"status_code"`
+	LastModified string    `json:"last_modified,omitempty"`
+	ETag         string    `json:"etag,omitempty"`
+	ContentHash  string    `json:"content_hash,omitempty"`
+	Region       string    `json:"region"`
+	Remark       string    `json:"remark,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func newWebhookPayload(msg Message, now time.Time) webhookPayload {
+	result := webhookPayload{
+		Event:  msg.Event,
+		Title:  msg.Title,
+		Text:   msg.Text,
+		Diff:   msg.Diff,
+		SentAt: now.UTC(),
+	}
+
+	if result.Event == "" {
+		result.Event = EventTest
+	}
+
+	if msg.Probe != nil {
+		result.Probe = &webhookProbe{
+			ID:       msg.Probe.ID,
+			Name:     msg.Probe.Name,
+			URL:      msg.Probe.URL,
+			Mode:     probeMode(*msg.Probe),
+			Selector: msg.Probe.Selector,
+		}
+	}
+
+	if msg.Result != nil {
+		result.Result = &webhookResult{
+			ID:           msg.Result.ID,
+			Success:      msg.Result.Success,
+			StatusCode:   msg.Result.StatusCode,
+			LastModified: msg.Result.LastModified,
+			ETag:         msg.Result.ETag,
+			ContentHash:  msg.Result.ContentHash,
+			Region:       msg.Result.Region,
+			Remark:       msg.Result.Remark,
+			CreatedAt:    msg.Result.CreatedAt.UTC(),
+		}
+	}
+
+	return result
+}
+
+// signWebhook returns the signature header value for body sent at ts.
+func signWebhook(secret, ts string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return "sha256=" + hex.EncodeToString(h.Sum(nil))
+}
+
+// webhookNotifier POSTs a signed JSON payload to a URL.
+type webhookNotifier struct {
+	client *http.Client
+	url    string
+	secret string
+}
+
+func (wn webhookNotifier) Notify(ctx context.Context, msg Message) error {
+	now := time.Now()
+
+	body, err := json.Marshal(newWebhookPayload(msg, now))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wn.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	ts := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookTimestampHeader, ts)
+	if wn.secret != "" {
+		req.Header.Set(webhookSignatureHeader, signWebhook(wn.secret, ts, body))
+	}
+
+	resp, err := wn.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return web.NewError(http.StatusOK, resp)
+	}
+
+	return nil
+}
+
+// discordNotifier posts an embed to a Discord webhook.
+type discordNotifier struct {
+	client *http.Client
+	url    string
+}
+
+func (dn discordNotifier) Notify(ctx context.Context, msg Message) error {
+	desc, _ := truncateContent(msg.Text, discordDescriptionLimit)
+
+	embed := discordwebhook.Embeds{
+		Title:       msg.Title,
+		Description: desc,
+		Footer:      &discordwebhook.EmbedFooter{Text: "hdrwtch"},
+	}
+	if msg.Probe != nil {
+		embed.URL = msg.Probe.URL
+	}
+
+	req := discordwebhook.Send(dn.url, discordwebhook.Webhook{
+		Username:        "hdrwtch",
+		Embeds:          []discordwebhook.Embeds{embed},
+		AllowedMentions: map[string][]string{"parse": {}},
+	})
+
+	resp, err := dn.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return discordwebhook.Validate(resp)
+}
+
+// mastodonNotifier sends a direct message from the server's bot account.
+type mastodonNotifier struct {
+	client *mastodon.Client
+	handle string
+}
+
+func (mn mastodonNotifier) Notify(ctx context.Context, msg Message) error {
+	handle := "@" + strings.TrimPrefix(mn.handle, "@")
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s", handle, msg.Title)
+	if msg.Probe != nil {
+		fmt.Fprintf(&sb, "\n\n%s", msg.Probe.URL)
+	}
+	if msg.Result != nil && msg.Probe != nil {
+		fmt.Fprintf(&sb, "\n\nValue: %s\nStatus code: %d", resultSummary(probeMode(*msg.Probe), *msg.Result), msg.Result.StatusCode)
+	}
+
+	status := sb.String()
+	if len([]rune(status)) > mastodonStatusLimit {
+		status = string([]rune(status)[:mastodonStatusLimit-1]) + "…"
+	}
+
+	_, err := mn.client.CreateStatus(ctx, mastodon.CreateStatusParams{
+		Status:     status,
+		Visibility: "direct",
+	})
+	return err
+}
+
+// ntfyNotifier publishes to an ntfy topic URL.
+type ntfyNotifier struct {
+	client *http.Client
+	url    string
+	token  string
+}
+
+func (nn ntfyNotifier) Notify(ctx context.Context, msg Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, nn.url, strings.NewReader(msg.Text))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Title", msg.Title)
+	req.Header.Set("Tags", "hdrwtch")
+	req.Header.Set("Markdown", "yes")
+	if msg.Probe != nil {
+		req.Header.Set("Click", msg.Probe.URL)
+	}
+	if nn.token != "" {
+		req.Header.Set("Authorization", "Bearer "+nn.token)
+	}
+
+	resp, err := nn.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return web.NewError(http.StatusOK, resp)
+	}
+
+	return nil
+}
+
+// mailer sends email through one SMTP relay.
+type mailer struct {
+	addr string // host:port of the relay
+	from string
+	auth smtp.Auth // nil to send without authenticating
+}
+
+// send delivers a plain text email. STARTTLS is used when the relay offers
+// it.
+func (m *mailer) send(ctx context.Context, to, subject, body string) error {
+	host, _, err := net.SplitHostPort(m.addr)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP address %q: %w", m.addr, err)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+
+	if m.auth != nil {
+		if err := c.Auth(m.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(m.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(buildEmail(m.from, to, subject, body, time.Now())); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// buildEmail renders a quoted-printable text/plain message.
+func buildEmail(from, to, subject, body string, date time.Time) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	qp.Close()
+
+	return buf.Bytes()
+}
+
+// emailNotifier emails an address through the server's SMTP relay.
+type emailNotifier struct {
+	mailer *mailer
+	to     string
+}
+
+func (en emailNotifier) Notify(ctx context.Context, msg Message) error {
+	return en.mailer.send(ctx, en.to, "hdrwtch: "+msg.Title, msg.Text)
+}
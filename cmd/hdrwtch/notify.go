@@ -1,21 +1,395 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/mymmrac/telego"
 	tu "github.com/mymmrac/telego/telegoutil"
+	"gorm.io/gorm"
+	"within.website/x/web"
 )
 
-func (s *Server) messageUser(user *TelegramUser, message string) error {
-	var sb strings.Builder
+// Notification target kinds.
+const (
+	TargetTelegram = "telegram"
+	TargetWebhook  = "webhook"
+	TargetDiscord  = "discord"
+	TargetMastodon = "mastodon"
+	TargetEmail    = "email"
+	TargetNtfy     = "ntfy"
+)
+
+// targetKinds lists the target kinds in the order the UI offers them, with
+// what the address field means for each.
+var targetKinds = []struct {
+	Value, Label, Address string
+}{
+	{TargetTelegram, "Telegram", "not needed, messages go to your Telegram account"},
+	{TargetWebhook, "Webhook", "URL to POST a signed JSON payload to"},
+	{TargetDiscord, "Discord", "Discord webhook URL"},
+	{TargetMastodon, "Mastodon DM", "your fediverse handle, such as @you@example.com"},
+	{TargetEmail, "Email", "email address"},
+	{TargetNtfy, "ntfy", "topic URL, such as https://ntfy.sh/my-topic"},
+}
+
+// Alert events, as sent to webhook targets.
+const (
+	EventProbeChanged = "probe.changed" // what the probe watches changed
+	EventProbeDown    = "probe.down"    // the probe failed AlertAfter runs in a row
+	EventProbeUp      = "probe.up"      // the probe recovered after a down alert
+	EventTest         = "test"          // sent with a target's Test button
+)
+
+// Notification delivery states.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+const (
+	// maxDeliveryAttempts is how many times a notification is tried before
+	// it is marked failed.
+	maxDeliveryAttempts = 5
+	// notifyTimeout bounds a single delivery attempt.
+	notifyTimeout = 30 * time.Second
+	// alertQueueSize is how many alerts can wait for a notification worker
+	// before new ones are dropped.
+	alertQueueSize = 1024
+	// notifyWorkers is how many alerts are delivered at once.
+	notifyWorkers = 8
+)
+
+// NotificationTarget is somewhere a user's alerts can be sent.
+type NotificationTarget struct {
+	gorm.Model
+	UserID  int64 `gorm:"index"`
+	Name    string
+	Kind    string // see TargetTelegram and friends
+	Address string // URL, email address or handle, depending on Kind
+	Secret  string // webhook signing secret or ntfy access token
+}
+
+// Notification is one alert sent (or attempted) to one target.
+type Notification struct {
+	gorm.Model
+	UserID        int64 `gorm:"index"`
+	ProbeID       uint  // zero for test notifications
+	ProbeResultID uint
+	TargetID      uint // zero for the default Telegram target
+	TargetName    string
+	Kind          string
+	Title         string
+	Status        string // see DeliveryPending and friends
+	Attempts      int
+	LastError     string
+	DeliveredAt   *time.Time
+}
+
+// Message is an alert, ready to be rendered by a notifier.
+type Message struct {
+	Event  string       // see EventProbeChanged and friends
+	Title  string       // one-line summary
+	Text   string       // Markdown body, including the diff
+	Diff   string       // unified diff of the change, if any
+	Probe  *Probe       // nil for test notifications
+	Result *ProbeResult // nil for test notifications
+}
+
+// Notifier delivers messages to one target.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// validateTarget checks a target's settings before it is saved.
+func (s *Server) validateTarget(t *NotificationTarget) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("target name is required")
+	}
+
+	switch t.Kind {
+	case TargetTelegram:
+		t.Address = ""
+	case TargetWebhook, TargetDiscord, TargetNtfy:
+		if !strings.HasPrefix(t.Address, "https://") && !strings.HasPrefix(t.Address, "http://") {
+			return fmt.Errorf("%s targets need an http or https URL", t.Kind)
+		}
+	case TargetMastodon:
+		if s.mastodon == nil {
+			return errors.New("Mastodon notifications are not enabled on this server")
+		}
+		if strings.Count(strings.TrimPrefix(t.Address, "@"), "@") != 1 {
+			return errors.New("Mastodon targets need a handle like @you@example.com")
+		}
+	case TargetEmail:
+		if s.mailer == nil {
+			return errors.New("email notifications are not enabled on this server")
+		}
+		if !strings.Contains(t.Address, "@") {
+			return errors.New("email targets need an email address")
+		}
+	default:
+		return fmt.Errorf("unknown notification target kind %q", t.Kind)
+	}
 
-	sb.WriteString(message)
+	return nil
+}
 
-	msg := tu.Message(tu.ID(user.ID), sb.String())
-	msg.ParseMode = telego.ModeMarkdown
+// notifierFor returns the notifier for target, which belongs to user.
+func (s *Server) notifierFor(user *TelegramUser, target NotificationTarget) (Notifier, error) {
+	switch target.Kind {
+	case TargetTelegram:
+		return telegramNotifier{client: s.httpClient(), apiURL: telegramAPI, token: s.tg.Token(), chatID: user.ID}, nil
+	case TargetWebhook:
+		return webhookNotifier{client: s.httpClient(), url: target.Address, secret: target.Secret}, nil
+	case TargetDiscord:
+		return discordNotifier{client: s.httpClient(), url: target.Address}, nil
+	case TargetMastodon:
+		if s.mastodon == nil {
+			return nil, errors.New("Mastodon notifications are not enabled on this server")
+		}
+		return mastodonNotifier{client: s.mastodon, handle: target.Address}, nil
+	case TargetEmail:
+		if s.mailer == nil {
+			return nil, errors.New("email notifications are not enabled on this server")
+		}
+		return emailNotifier{mailer: s.mailer, to: target.Address}, nil
+	case TargetNtfy:
+		return ntfyNotifier{client: s.httpClient(), url: target.Address, token: target.Secret}, nil
+	}
+
+	return nil, fmt.Errorf("unknown notification target kind %q", target.Kind)
+}
+
+func (s *Server) httpClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return http.DefaultClient
+}
+
+// defaultTarget is where alerts go for probes with no targets selected.
+func defaultTarget() NotificationTarget {
+	return NotificationTarget{Name: "Telegram", Kind: TargetTelegram}
+}
+
+// changeNotification builds the message for a probe whose result changed.
+func changeNotification(p Probe, prev, cur ProbeResult) Message {
+	return Message{
+		Event:  EventProbeChanged,
+		Title:  fmt.Sprintf("%s changed", p.Name),
+		Text:   changeMessage(p, prev, cur),
+		Diff:   changeDiff(p, prev, cur),
+		Probe:  &p,
+		Result: &cur,
+	}
+}
+
+// alert is a message about a probe waiting to be sent to its targets.
+type alert struct {
+	probe Probe
+	msg   Message
+}
+
+// startNotifier starts the workers that deliver queued alerts.
+func (s *Server) startNotifier(ctx context.Context) {
+	s.alerts = make(chan alert, alertQueueSize)
+	for range notifyWorkers {
+		go func() {
+			for a := range s.alerts {
+				s.notifyProbe(ctx, a.probe, a.msg)
+			}
+		}()
+	}
+}
+
+// queueAlert hands a to the notification workers without waiting for it to
+// be delivered. If the workers are that far behind, the alert is dropped.
+func (s *Server) queueAlert(ctx context.Context, a alert) {
+	select {
+	case s.alerts <- a:
+	default:
+		slog.ErrorContext(ctx, "notification queue is full, dropping alert", "probe", a.probe.ID, "title", a.msg.Title)
+	}
+}
+
+// notifyProbe sends an alert about probe to each of its targets, falling
+// back to Telegram when none are selected. Failures are recorded in the
+// notification history rather than returned.
+func (s *Server) notifyProbe(ctx context.Context, probe Probe, msg Message) {
+	user, err := s.dao.GetUser(ctx, probe.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get user", "err", err, "probe", probe.ID)
+		return
+	}
+
+	targets, err := s.dao.ProbeTargets(ctx, probe.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get notification targets", "err", err, "probe", probe.ID)
+		return
+	}
+	if len(targets) == 0 {
+		targets = []NotificationTarget{defaultTarget()}
+	}
+
+	for _, target := range targets {
+		s.deliver(ctx, user, target, msg, maxDeliveryAttempts)
+	}
+}
+
+// deliver sends msg to target, retrying with exponential backoff up to
+// attempts times, and records the outcome in the notification history.
+func (s *Server) deliver(ctx context.Context, user *TelegramUser, target NotificationTarget, msg Message, attempts int) (*Notification, error) {
+	n := &Notification{
+		UserID:     user.ID,
+		TargetID:   target.ID,
+		TargetName: target.Name,
+		Kind:       target.Kind,
+		Title:      msg.Title,
+		Status:     DeliveryPending,
+	}
+	if msg.Probe != nil {
+		n.ProbeID = msg.Probe.ID
+	}
+	if msg.Result != nil {
+		n.ProbeResultID = msg.Result.ID
+	}
+
+	if err := s.dao.db.WithContext(ctx).Create(n).Error; err != nil {
+		return nil, fmt.Errorf("failed to record notification: %w", err)
+	}
+
+	notifier, err := s.notifierFor(user, target)
+	if err == nil {
+		bo := s.notifyBackOff()
+		err = backoff.Retry(func() error {
+			n.Attempts++
+
+			ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+			defer cancel()
+
+			err := notifier.Notify(ctx, msg)
+			if err != nil && !retryable(err) {
+				return backoff.Permanent(err)
+			}
+			return err
+		}, backoff.WithContext(backoff.WithMaxRetries(bo, uint64(attempts-1)), ctx))
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to deliver notification", "err", err, "target", target.ID, "kind", target.Kind, "attempts", n.Attempts)
+		n.Status = DeliveryFailed
+		n.LastError = err.Error()
+	} else {
+		now := time.Now()
+		n.Status = DeliveryDelivered
+		n.DeliveredAt = &now
+	}
+
+	if err := s.dao.db.WithContext(context.WithoutCancel(ctx)).Save(n).Error; err != nil {
+		slog.ErrorContext(ctx, "failed to update notification", "err", err, "notification", n.ID)
+	}
+
+	if n.Status == DeliveryFailed {
+		return n, errors.New(n.LastError)
+	}
+
+	return n, nil
+}
+
+func (s *Server) notifyBackOff() backoff.BackOff {
+	if s.backOff != nil {
+		return s.backOff()
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = 2 * time.Second
+	bo.MaxInterval = time.Minute
+	return bo
+}
+
+// retryable reports whether a failed delivery is worth trying again. Client
+// errors other than timeouts and rate limits mean the target is
+// misconfigured, so retrying would only repeat them.
+func retryable(err error) bool {
+	var werr *web.Error
+	if !errors.As(err, &werr) {
+		return true
+	}
+
+	switch {
+	case werr.GotStatus == http.StatusRequestTimeout, werr.GotStatus == http.StatusTooManyRequests:
+		return true
+	case werr.GotStatus >= 400 && werr.GotStatus < 500:
+		return false
+	}
+	return true
+}
+
+// telegramAPI is the Telegram Bot API server.
+const telegramAPI = "https://api.telegram.org"
+
+// telegramNotifier messages a Telegram user through the hdrwtch bot. It
+// calls the Bot API itself rather than going through telego so that
+// deliveries can be cancelled.
+type telegramNotifier struct {
+	client *http.Client
+	apiURL string // telegramAPI outside of tests
+	token  string
+	chatID int64
+}
+
+// endpoint returns the sendMessage URL with token in it, so that errors can
+// show it with the real token left out.
+func (t telegramNotifier) endpoint(token string) string {
+	return t.apiURL + "/bot" + token + "/sendMessage"
+}
+
+func (t telegramNotifier) Notify(ctx context.Context, msg Message) error {
+	tmsg := tu.Message(tu.ID(t.chatID), msg.Text)
+	tmsg.ParseMode = telego.ModeMarkdown
+
+	body, err := json.Marshal(tmsg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint(t.token), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Errors end up in the notification history, so the bot token has to
+	// be taken out of the URLs in them.
+	redacted := t.endpoint("REDACTED")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			uerr.URL = redacted
+		}
+		return err
+	}
+	defer resp.Body.Close()
 
-	if _, err := s.tg.SendMessage(msg); err != nil {
+	if resp.StatusCode != http.StatusOK {
+		err := web.NewError(http.StatusOK, resp)
+		var werr *web.Error
+		if errors.As(err, &werr) {
+			werr.URL, _ = url.Parse(redacted)
+		}
 		return err
 	}
 
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/gorilla/sessions"
+	"gorm.io/gorm"
+	"within.website/x/web/discordwebhook"
+)
+
+func testServer(t *testing.T) *Server {
+	t.Helper()
+
+	dao, err := New(filepath.Join(t.TempDir(), "hdrwtch.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Server{
+		store:       sessions.NewCookieStore([]byte("test")),
+		dao:         dao,
+		statusCache: newStatusCache(),
+		backOff:     func() backoff.BackOff { return &backoff.ZeroBackOff{} },
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	const secret = "hunter2"
+
+	var (
+		calls   atomic.Int32
+		payload webhookPayload
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if got, want := r.Header.Get(webhookSignatureHeader), signWebhook(secret, r.Header.Get(webhookTimestampHeader), body); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+
+		// Fail twice to exercise retries.
+		if calls.Add(1) < 3 {
+			http.Error(w, "try again", http.StatusBadGateway)
+			return
+		}
+
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("can't decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := testServer(t)
+	user := &TelegramUser{ID: 42}
+	target := NotificationTarget{Model: gorm.Model{ID: 1}, UserID: 42, Name: "hook", Kind: TargetWebhook, Address: srv.URL, Secret: secret}
+
+	probe := Probe{Model: gorm.Model{ID: 7}, Name: "Bus schedule", URL: "https://example.com", Mode: ModeETag}
+	prev := ProbeResult{Success: true, ETag: `"a"`}
+	cur := ProbeResult{Model: gorm.Model{ID: 9}, Success: true, ETag: `"b"`, StatusCode: 200}
+
+	n, err := s.deliver(context.Background(), user, target, changeNotification(probe, prev, cur), maxDeliveryAttempts)
+	if err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+
+	if n.Status != DeliveryDelivered || n.Attempts != 3 || n.DeliveredAt == nil {
+		t.Errorf("notification = %+v, want delivered after 3 attempts", n)
+	}
+
+	if payload.Event != "probe.changed" || payload.Probe.ID != 7 || payload.Result.ETag != `"b"` {
+		t.Errorf("payload = %+v", payload)
+	}
+	if !strings.Contains(payload.Diff, `+"b"`) {
+		t.Errorf("payload diff = %q, want it to contain the new ETag", payload.Diff)
+	}
+
+	history, err := s.dao.ListNotifications(context.Background(), 42, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].Status != DeliveryDelivered || history[0].ProbeResultID != 9 {
+		t.Errorf("history = %+v", history)
+	}
+}
+
+func TestWebhookEvents(t *testing.T) {
+	probe := Probe{Model: gorm.Model{ID: 7}, Name: "Bus schedule", URL: "https://example.com", Mode: ModeETag, AlertAfter: 3, ConsecutiveFailures: 3}
+	down := ProbeResult{Success: false, Remark: "connection refused"}
+	up := ProbeResult{Success: true, StatusCode: 200, ETag: `"b"`}
+
+	for _, tt := range []struct {
+		name string
+		msg  Message
+		want string
+	}{
+		{"changed", changeNotification(probe, ProbeResult{Success: true, ETag: `"a"`}, up), EventProbeChanged},
+		{"down", downMessage(probe, down), EventProbeDown},
+		{"up", upMessage(probe, up, 3), EventProbeUp},
+		{"test", Message{Title: "Test notification"}, EventTest},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := newWebhookPayload(tt.msg, time.Now())
+			if payload.Event != tt.want {
+				t.Errorf("event = %q, want %q", payload.Event, tt.want)
+			}
+			if (payload.Probe != nil) != (tt.want != EventTest) {
+				t.Errorf("probe = %+v", payload.Probe)
+			}
+		})
+	}
+}
+
+func TestDeliveryGivesUp(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		status       int
+		wantAttempts int
+	}{
+		{"client error is permanent", http.StatusNotFound, 1},
+		{"rate limit is retried", http.StatusTooManyRequests, maxDeliveryAttempts},
+		{"server error is retried", http.StatusInternalServerError, maxDeliveryAttempts},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			s := testServer(t)
+			target := NotificationTarget{Name: "topic", Kind: TargetNtfy, Address: srv.URL}
+
+			n, err := s.deliver(context.Background(), &TelegramUser{ID: 1}, target, Message{Title: "Test"}, maxDeliveryAttempts)
+			if err == nil {
+				t.Fatal("deliver succeeded, want an error")
+			}
+
+			if n.Status != DeliveryFailed || n.Attempts != tt.wantAttempts || n.LastError == "" {
+				t.Errorf("notification = %+v, want failed after %d attempts", n, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestQueueAlertDoesNotBlock(t *testing.T) {
+	// No workers are running, so the second alert has nowhere to go.
+	s := &Server{alerts: make(chan alert, 1)}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.queueAlert(t.Context(), alert{msg: Message{Title: "first"}})
+		s.queueAlert(t.Context(), alert{msg: Message{Title: "second"}})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queueAlert blocked on a full queue")
+	}
+
+	if a := <-s.alerts; a.msg.Title != "first" {
+		t.Errorf("queued %q, want the first alert", a.msg.Title)
+	}
+}
+
+func TestTelegramNotifier(t *testing.T) {
+	var got map[string]any
+	status := http.StatusOK
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bot123:secret/sendMessage" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(status)
+		w.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	defer srv.Close()
+
+	tn := telegramNotifier{client: srv.Client(), apiURL: srv.URL, token: "123:secret", chatID: 42}
+
+	if err := tn.Notify(t.Context(), Message{Title: "Schedule changed", Text: "*Schedule*"}); err != nil {
+		t.Fatal(err)
+	}
+	if got["chat_id"] != float64(42) || got["text"] != "*Schedule*" || got["parse_mode"] != "Markdown" {
+		t.Errorf("sent %v", got)
+	}
+
+	status = http.StatusForbidden
+	err := tn.Notify(t.Context(), Message{Text: "hi"})
+	if err == nil || retryable(err) {
+		t.Errorf("got error %v, want one that isn't retried", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "secret") {
+		t.Errorf("error leaks the bot token: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	err = tn.Notify(ctx, Message{Text: "hi"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+	if err != nil && strings.Contains(err.Error(), "secret") {
+		t.Errorf("error leaks the bot token: %v", err)
+	}
+}
+
+func TestNtfyNotifier(t *testing.T) {
+	var got *http.Request
+	var body string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		got, body = r, string(data)
+	}))
+	defer srv.Close()
+
+	nn := ntfyNotifier{client: srv.Client(), url: srv.URL + "/alerts", token: "tk_secret"}
+	probe := Probe{URL: "https://example.com/schedule"}
+
+	if err := nn.Notify(context.Background(), Message{Title: "Schedule changed", Text: "*Schedule*", Probe: &probe}); err != nil {
+		t.Fatal(err)
+	}
+
+	for header, want := range map[string]string{
+		"Title":         "Schedule changed",
+		"Markdown":      "yes",
+		"Click":         "https://example.com/schedule",
+		"Authorization": "Bearer tk_secret",
+	} {
+		if v := got.Header.Get(header); v != want {
+			t.Errorf("%s = %q, want %q", header, v, want)
+		}
+	}
+	if got.URL.Path != "/alerts" || body != "*Schedule*" {
+		t.Errorf("got %s with body %q", got.URL.Path, body)
+	}
+}
+
+func TestDiscordNotifier(t *testing.T) {
+	var wh discordwebhook.Webhook
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&wh)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	dn := discordNotifier{client: srv.Client(), url: srv.URL}
+
+	if err := dn.Notify(context.Background(), Message{Title: "Schedule changed", Text: strings.Repeat("x", 5000)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(wh.Embeds) != 1 || wh.Embeds[0].Title != "Schedule changed" || len(wh.Embeds[0].Description) != discordDescriptionLimit {
+		t.Errorf("webhook = %+v", wh)
+	}
+}
+
+func TestBuildEmail(t *testing.T) {
+	msg := string(buildEmail("hdrwtch@example.com", "you@example.com", "hdrwtch: Fahrplan geändert", "line one\nline two", time.Unix(0, 0)))
+
+	for _, want := range []string{
+		"To: you@example.com\r\n",
+		"Subject: =?utf-8?q?hdrwtch:_Fahrplan_ge=C3=A4ndert?=\r\n",
+		"Content-Transfer-Encoding: quoted-printable\r\n",
+		"\r\n\r\nline one\r\nline two",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("email does not contain %q:\n%s", want, msg)
+		}
+	}
+}
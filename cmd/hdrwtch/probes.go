@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/a-h/templ"
@@ -16,8 +19,22 @@ type Probe struct {
 	UserID       int64
 	Name         string
 	URL          string
+	Mode         string // change detection mode, see ModeLastModified and friends
+	Selector     string // CSS selector, XPath expression or JSON pointer, depending on Mode
 	LastResultID uint
 	LastResult   ProbeResult
+	Targets      []NotificationTarget `gorm:"many2many:probe_targets;"` // where alerts go, Telegram if empty
+
+	Interval     time.Duration // how often to run, defaultInterval if zero
+	Timeout      time.Duration // per attempt, defaultTimeout if zero
+	Method       string        // GET if empty
+	Headers      string        // extra request headers, one "Name: value" per line
+	Retries      int           // extra attempts before a run counts as failed
+	ExpectStatus string        // accepted status codes, such as "200,300-399"; below 400 if empty
+	MaxLatency   time.Duration // slowest acceptable response, no limit if zero
+	AlertAfter   int           // consecutive failures before a down alert, never if zero
+
+	ConsecutiveFailures int
 }
 
 type ProbeResult struct {
@@ -25,10 +42,24 @@ type ProbeResult struct {
 	ProbeID      uint
 	Success      bool
 	LastModified string
+	ETag         string
+	ContentHash  string // hex SHA-256 of the body or of the extracted content
+	Content      string // extracted content for diffing, capped at maxStoredContent; only kept on the latest result
+	NotModified  bool   // the server answered a conditional request with 304
 	StatusCode   int
 	Region       string
 	Remark       string
 	Duration     time.Duration
+	// AssertionFailed is set when the request completed but broke the
+	// probe's status code or latency assertions.
+	AssertionFailed bool
+	Attempts        int
+}
+
+// Healthy reports whether the run passed: the request completed and met the
+// probe's assertions.
+func (r ProbeResult) Healthy() bool {
+	return r.Success && !r.AssertionFailed
 }
 
 func (s *Server) probeList(w http.ResponseWriter, r *http.Request) {
@@ -63,10 +94,30 @@ func (s *Server) probeCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	interval, err := parseOptionalDuration("interval", r.FormValue("interval"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	newProbe := &Probe{
-		UserID: tu.ID,
-		Name:   r.FormValue("name"),
-		URL:    r.FormValue("url"),
+		UserID:   tu.ID,
+		Name:     r.FormValue("name"),
+		URL:      r.FormValue("url"),
+		Mode:     r.FormValue("mode"),
+		Selector: r.FormValue("selector"),
+		Interval: interval,
+		Method:   r.FormValue("method"),
+	}
+
+	if err := validateProbeMode(newProbe.Mode, newProbe.Selector); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validateProbeSettings(*newProbe); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	if err := s.dao.db.Create(newProbe).Error; err != nil {
@@ -139,8 +190,22 @@ func (s *Server) probeGet(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		targets, err := s.dao.ListTargets(r.Context(), tu.ID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to get notification targets", "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		probe.Targets, err = s.dao.ProbeTargets(r.Context(), probe.ID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to get probe targets", "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
 		templ.Handler(
-			base(probe.Name, nil, authedNavBar(tu), probePage(*probe, results)),
+			base(probe.Name, nil, authedNavBar(tu), probePage(*probe, results, targets)),
 		).ServeHTTP(w, r)
 	}
 }
@@ -165,8 +230,33 @@ func (s *Server) probeUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := validateProbeMode(r.FormValue("mode"), r.FormValue("selector")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The last result was taken watching something else, so it can't be
+	// compared against or used for conditional requests. Dropping it makes
+	// the next run the new baseline.
+	if r.FormValue("url") != probe.URL || r.FormValue("mode") != probe.Mode || r.FormValue("selector") != probe.Selector {
+		if err := s.dao.dropContent(r.Context(), s.dao.db, probe.LastResultID); err != nil {
+			slog.ErrorContext(r.Context(), "failed to reset probe baseline", "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		probe.LastResultID = 0
+		probe.LastResult = ProbeResult{}
+	}
+
 	probe.Name = r.FormValue("name")
 	probe.URL = r.FormValue("url")
+	probe.Mode = r.FormValue("mode")
+	probe.Selector = r.FormValue("selector")
+
+	if err := validateProbeSettings(*probe); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	if err := s.dao.db.Save(probe).Error; err != nil {
 		slog.ErrorContext(r.Context(), "failed to update probe", "err", err)
@@ -243,3 +333,72 @@ func (s *Server) probeRunGet(w http.ResponseWriter, r *http.Request) {
 		base(probe.Name, nil, authedNavBar(tu), probeRunPage(*probe, result)),
 	).ServeHTTP(w, r)
 }
+
+func (s *Server) probeSettingsUpdate(w http.ResponseWriter, r *http.Request) {
+	tu, ok := getTelegramUser(r.Context())
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	probe, err := s.dao.GetProbe(r.Context(), r.PathValue("id"), tu.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get probe", "path", r.URL.Path, "err", err)
+		http.Error(w, "no probe data", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.ErrorContext(r.Context(), "failed to parse form", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var errs []error
+	duration := func(name, key string) time.Duration {
+		d, err := parseOptionalDuration(name, r.FormValue(key))
+		errs = append(errs, err)
+		return d
+	}
+	number := func(name, key string) int {
+		if r.FormValue(key) == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(r.FormValue(key))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s %q", name, r.FormValue(key)))
+		}
+		return n
+	}
+
+	probe.Interval = duration("interval", "interval")
+	probe.Timeout = duration("timeout", "timeout")
+	probe.MaxLatency = duration("latency limit", "max_latency")
+	probe.Method = r.FormValue("method")
+	probe.Headers = r.FormValue("headers")
+	probe.Retries = number("retries", "retries")
+	probe.ExpectStatus = strings.TrimSpace(r.FormValue("expect_status"))
+	probe.AlertAfter = number("failure alert threshold", "alert_after")
+
+	if err := errors.Join(errs...); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validateProbeSettings(*probe); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.dao.db.WithContext(r.Context()).Model(probe).Select(
+		"Interval", "Timeout", "MaxLatency", "Method", "Headers", "Retries", "ExpectStatus", "AlertAfter",
+	).Updates(probe).Error; err != nil {
+		slog.ErrorContext(r.Context(), "failed to update probe settings", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	templ.Handler(
+		probeSettingsForm(*probe, true),
+	).ServeHTTP(w, r)
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+const (
+	// defaultInterval is how often probes created before per-probe
+	// schedules existed are run.
+	defaultInterval = 15 * time.Minute
+	// minInterval is the shortest schedule a probe can have. The scheduler
+	// wakes up this often.
+	minInterval = time.Minute
+	maxInterval = 24 * time.Hour
+
+	defaultTimeout = 30 * time.Second
+	maxTimeout     = time.Minute
+
+	// maxRetries is how many extra attempts a failing run may make before
+	// it is recorded as a failure.
+	maxRetries = 3
+	// retryDelay is multiplied by the attempt number between retries.
+	retryDelay = 2 * time.Second
+
+	maxAlertAfter = 100
+)
+
+// probeIntervals are the schedules the UI offers.
+var probeIntervals = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+}
+
+// probeMethods are the HTTP methods a probe can use.
+var probeMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+
+func probeInterval(p Probe) time.Duration {
+	if p.Interval == 0 {
+		return defaultInterval
+	}
+	return p.Interval
+}
+
+func probeTimeout(p Probe) time.Duration {
+	if p.Timeout == 0 {
+		return defaultTimeout
+	}
+	return p.Timeout
+}
+
+func probeMethod(p Probe) string {
+	if p.Method == "" {
+		return http.MethodGet
+	}
+	return p.Method
+}
+
+// probeDue reports whether p should be run at now. Runs are allowed to
+// start a little early so a probe on a one minute schedule isn't skipped
+// when the scheduler ticks a few milliseconds before the minute is up.
+func probeDue(p Probe, now time.Time) bool {
+	if p.LastResultID == 0 {
+		return true
+	}
+	const slack = 5 * time.Second
+	return now.Sub(p.LastResult.CreatedAt) >= probeInterval(p)-slack
+}
+
+// parseProbeHeaders parses one "Name: value" header per line. Blank lines
+// are ignored.
+func parseProbeHeaders(text string) (http.Header, error) {
+	h := http.Header{}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || !httpguts.ValidHeaderFieldName(name) || !httpguts.ValidHeaderFieldValue(value) {
+			return nil, fmt.Errorf("header line %d: want Name: value", i+1)
+		}
+
+		switch http.CanonicalHeaderKey(name) {
+		case "Host", "Content-Length", "If-None-Match", "If-Modified-Since":
+			return nil, fmt.Errorf("header line %d: %s is set by hdrwtch", i+1, name)
+		}
+
+		h.Add(name, value)
+	}
+	return h, nil
+}
+
+// statusRange is an inclusive range of HTTP status codes.
+type statusRange struct{ lo, hi int }
+
+// parseStatusRanges parses a comma-separated list of status codes and
+// ranges, such as "200,204,300-399". The empty string accepts any status
+// below 400.
+func parseStatusRanges(expr string) ([]statusRange, error) {
+	if strings.TrimSpace(expr) == "" {
+		return []statusRange{{100, 399}}, nil
+	}
+
+	var result []statusRange
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		loStr, hiStr, isRange := strings.Cut(part, "-")
+		if !isRange {
+			hiStr = loStr
+		}
+
+		lo, err := strconv.Atoi(strings.TrimSpace(loStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid status code %q", part)
+		}
+		hi, err := strconv.Atoi(strings.TrimSpace(hiStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid status code %q", part)
+		}
+		if lo < 100 || hi > 599 || lo > hi {
+			return nil, fmt.Errorf("invalid status code range %q", part)
+		}
+
+		result = append(result, statusRange{lo, hi})
+	}
+	return result, nil
+}
+
+func statusMatches(ranges []statusRange, code int) bool {
+	return slices.ContainsFunc(ranges, func(r statusRange) bool {
+		return code >= r.lo && code <= r.hi
+	})
+}
+
+// validateProbeSettings checks a probe's schedule, request and assertion
+// settings before it is saved.
+func validateProbeSettings(p Probe) error {
+	if p.Interval != 0 && (p.Interval < minInterval || p.Interval > maxInterval) {
+		return fmt.Errorf("interval must be between %s and %s", minInterval, maxInterval)
+	}
+	if p.Timeout != 0 && (p.Timeout < time.Second || p.Timeout > maxTimeout) {
+		return fmt.Errorf("timeout must be between 1s and %s", maxTimeout)
+	}
+	if p.Method != "" && !slices.Contains(probeMethods, p.Method) {
+		return fmt.Errorf("method must be one of %s", strings.Join(probeMethods, ", "))
+	}
+	if p.Method == http.MethodHead && readsBody(probeMode(p)) {
+		return errors.New("HEAD requests have no body to watch, pick a header mode or another method")
+	}
+	if _, err := parseProbeHeaders(p.Headers); err != nil {
+		return err
+	}
+	if p.Retries < 0 || p.Retries > maxRetries {
+		return fmt.Errorf("retries must be between 0 and %d", maxRetries)
+	}
+	if _, err := parseStatusRanges(p.ExpectStatus); err != nil {
+		return err
+	}
+	if p.MaxLatency < 0 {
+		return errors.New("latency limit can't be negative")
+	}
+	if p.AlertAfter < 0 || p.AlertAfter > maxAlertAfter {
+		return fmt.Errorf("failure alert threshold must be between 0 and %d", maxAlertAfter)
+	}
+	return nil
+}
+
+// checkAssertions marks r as failed if it breaks p's status code or latency
+// assertions. Requests that didn't complete have nothing to assert on.
+func checkAssertions(p Probe, r *ProbeResult) {
+	if !r.Success {
+		return
+	}
+
+	ranges, err := parseStatusRanges(p.ExpectStatus)
+	if err != nil {
+		r.AssertionFailed = true
+		r.Remark = err.Error()
+		return
+	}
+
+	if !statusMatches(ranges, r.StatusCode) {
+		r.AssertionFailed = true
+		r.Remark = fmt.Sprintf("status code %d is not one of %s", r.StatusCode, expectStatusLabel(p))
+		return
+	}
+
+	if p.MaxLatency != 0 && r.Duration > p.MaxLatency {
+		r.AssertionFailed = true
+		r.Remark = fmt.Sprintf("response took %s, over the %s limit", r.Duration.Round(time.Millisecond), p.MaxLatency)
+	}
+}
+
+func expectStatusLabel(p Probe) string {
+	if p.ExpectStatus == "" {
+		return "100-399"
+	}
+	return p.ExpectStatus
+}
+
+// durationLabel formats d for the UI: whole units without trailing zeros.
+func durationLabel(d time.Duration) string {
+	switch {
+	case d == 0:
+		return "none"
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return d.String()
+}
+
+// parseOptionalDuration parses a form value, treating the empty string as
+// zero.
+func parseOptionalDuration(name, val string) (time.Duration, error) {
+	if strings.TrimSpace(val) == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(val))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q, want something like 30s or 5m", name, val)
+	}
+	return d, nil
+}
+
+// downMessage is the alert for a probe that has failed p.AlertAfter runs in
+// a row.
+func downMessage(p Probe, r ProbeResult) Message {
+	return Message{
+		Event:  EventProbeDown,
+		Title:  fmt.Sprintf("%s is down", p.Name),
+		Text:   fmt.Sprintf("*%s* is down:\n\nFailed checks in a row: %d\nRegion: %s\nStatus code: %d\nRemark: %s\n", p.Name, p.ConsecutiveFailures, r.Region, r.StatusCode, r.Remark),
+		Probe:  &p,
+		Result: &r,
+	}
+}
+
+// upMessage is the alert for a probe that recovered after a down alert.
+func upMessage(p Probe, r ProbeResult, failures int) Message {
+	return Message{
+		Event:  EventProbeUp,
+		Title:  fmt.Sprintf("%s is back up", p.Name),
+		Text:   fmt.Sprintf("*%s* is back up after %d failed checks:\n\nRegion: %s\nStatus code: %d\nLatency: %s\n", p.Name, failures, r.Region, r.StatusCode, r.Duration.Round(time.Millisecond)),
+		Probe:  &p,
+		Result: &r,
+	}
+}
+
+// durationValue formats an optional duration for a form field.
+func durationValue(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestParseStatusRanges(t *testing.T) {
+	for _, tt := range []struct {
+		expr    string
+		code    int
+		want    bool
+		wantErr bool
+	}{
+		{expr: "", code: 200, want: true},
+		{expr: "", code: 304, want: true},
+		{expr: "", code: 404, want: false},
+		{expr: "200", code: 200, want: true},
+		{expr: "200", code: 201, want: false},
+		{expr: "200, 300-399", code: 302, want: true},
+		{expr: "200,204", code: 204, want: true},
+		{expr: "500-599", code: 503, want: true},
+		{expr: "abc", wantErr: true},
+		{expr: "399-300", wantErr: true},
+		{expr: "200-700", wantErr: true},
+	} {
+		ranges, err := parseStatusRanges(tt.expr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseStatusRanges(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			continue
+		}
+		if err == nil && statusMatches(ranges, tt.code) != tt.want {
+			t.Errorf("statusMatches(%q, %d) = %v, want %v", tt.expr, tt.code, !tt.want, tt.want)
+		}
+	}
+}
+
+func TestParseProbeHeaders(t *testing.T) {
+	h, err := parseProbeHeaders("Authorization: Bearer hunter2\n\n  accept:  application/json  \n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Get("Authorization") != "Bearer hunter2" || h.Get("Accept") != "application/json" {
+		t.Errorf("headers = %v", h)
+	}
+
+	for _, bad := range []string{"no colon", "Bad Name: value", "Host: example.com"} {
+		if _, err := parseProbeHeaders(bad); err == nil {
+			t.Errorf("parseProbeHeaders(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestCheckAssertions(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		probe Probe
+		res   ProbeResult
+		want  bool
+	}{
+		{"ok by default", Probe{}, ProbeResult{Success: true, StatusCode: 200}, true},
+		{"server error by default", Probe{}, ProbeResult{Success: true, StatusCode: 500}, false},
+		{"expected error", Probe{ExpectStatus: "503"}, ProbeResult{Success: true, StatusCode: 503}, true},
+		{"too slow", Probe{MaxLatency: time.Second}, ProbeResult{Success: true, StatusCode: 200, Duration: 2 * time.Second}, false},
+		{"fast enough", Probe{MaxLatency: time.Second}, ProbeResult{Success: true, StatusCode: 200, Duration: time.Millisecond}, true},
+		{"request failed", Probe{}, ProbeResult{Success: false}, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			checkAssertions(tt.probe, &tt.res)
+			if got := tt.res.Healthy(); got != tt.want {
+				t.Errorf("Healthy() = %v, want %v (remark: %q)", got, tt.want, tt.res.Remark)
+			}
+		})
+	}
+}
+
+func TestProbeDue(t *testing.T) {
+	now := time.Now()
+	ran := func(ago time.Duration) ProbeResult {
+		return ProbeResult{Model: gorm.Model{ID: 1, CreatedAt: now.Add(-ago)}}
+	}
+
+	for _, tt := range []struct {
+		name  string
+		probe Probe
+		want  bool
+	}{
+		{"never run", Probe{}, true},
+		{"default interval not up", Probe{LastResultID: 1, LastResult: ran(10 * time.Minute)}, false},
+		{"default interval up", Probe{LastResultID: 1, LastResult: ran(15 * time.Minute)}, true},
+		{"every minute, tick slightly early", Probe{LastResultID: 1, LastResult: ran(59 * time.Second), Interval: time.Minute}, true},
+		{"hourly", Probe{LastResultID: 1, LastResult: ran(30 * time.Minute), Interval: time.Hour}, false},
+	} {
+		if got := probeDue(tt.probe, now); got != tt.want {
+			t.Errorf("%s: probeDue() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRunProbeRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead || r.Header.Get("X-Token") != "hunter2" {
+			t.Errorf("got %s with X-Token %q", r.Method, r.Header.Get("X-Token"))
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+	}))
+	defer srv.Close()
+
+	probe := Probe{URL: srv.URL, Method: http.MethodHead, Headers: "X-Token: hunter2", Retries: 2}
+
+	result := runProbe(context.Background(), probe, ProbeResult{})
+	if !result.Healthy() || result.Attempts != 2 || result.LastModified == "" {
+		t.Errorf("result = %+v, want healthy on the second attempt", result)
+	}
+}
+
+func TestUserStatus(t *testing.T) {
+	s := testServer(t)
+	now := time.Now()
+
+	probes := []Probe{{UserID: 1, Name: "a"}, {UserID: 1, Name: "b"}, {UserID: 2, Name: "c"}}
+	if err := s.dao.db.Create(&probes).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	result := func(probeID uint, ago time.Duration, healthy bool, latency time.Duration) ProbeResult {
+		return ProbeResult{
+			Model:           gorm.Model{CreatedAt: now.Add(-ago)},
+			ProbeID:         probeID,
+			Success:         true,
+			AssertionFailed: !healthy,
+			Duration:        latency,
+		}
+	}
+
+	var results []ProbeResult
+	for i := range 100 {
+		results = append(results, result(probes[0].ID, time.Duration(i)*time.Minute, i != 0, time.Duration(i+1)*time.Millisecond))
+	}
+	// Outside the 24 hour window, another user's result and a failed run
+	// with no latency.
+	results = append(results, result(probes[0].ID, 48*time.Hour, false, time.Second))
+	results = append(results, result(probes[2].ID, time.Minute, true, time.Millisecond))
+	results = append(results, ProbeResult{Model: gorm.Model{CreatedAt: now.Add(-time.Minute)}, ProbeID: probes[1].ID})
+	if err := s.dao.db.Create(&results).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err := s.userStatus(t.Context(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("got %d statuses, want 2", len(statuses))
+	}
+
+	day, week := statuses[0].Windows[0], statuses[0].Windows[1]
+	if day.Label != "24 hours" || day.Runs != 100 || day.Healthy != 99 || day.UptimeLabel() != "99.00%" {
+		t.Errorf("24h = %+v (%s)", day, day.UptimeLabel())
+	}
+	if day.P50 != 50*time.Millisecond || day.P95 != 95*time.Millisecond || day.P99 != 99*time.Millisecond {
+		t.Errorf("24h percentiles = %s/%s/%s", day.P50, day.P95, day.P99)
+	}
+	if week.Runs != 101 || week.UptimeLabel() != "98.01%" || week.P99 != 100*time.Millisecond {
+		t.Errorf("7d = %+v (%s)", week, week.UptimeLabel())
+	}
+
+	if down := statuses[1].Windows[0]; down.Runs != 1 || down.Healthy != 0 || down.P50 != 0 {
+		t.Errorf("failed probe = %+v", down)
+	}
+
+	if got := (WindowStats{}).UptimeLabel(); got != "n/a" {
+		t.Errorf("empty window uptime = %q", got)
+	}
+}
+
+func TestPublicStatusCached(t *testing.T) {
+	s := testServer(t)
+
+	user := &TelegramUser{ID: 1, FirstName: "Mimi", PublicStatus: true}
+	if err := s.dao.db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	probe := Probe{UserID: 1, Name: "Bus schedule"}
+	if err := s.dao.db.Create(&probe).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status/{id}", s.publicStatusGet)
+
+	get := func() string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status/1", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d", rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	if body := get(); !strings.Contains(body, "Bus schedule") {
+		t.Fatalf("status page lacks the probe:\n%s", body)
+	}
+
+	if err := s.dao.db.Model(&probe).Update("name", "Train schedule").Error; err != nil {
+		t.Fatal(err)
+	}
+	if body := get(); !strings.Contains(body, "Bus schedule") {
+		t.Error("status page was rendered again instead of coming from the cache")
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+const (
+	// statusCacheSize and statusCacheTTL bound the cache of rendered public
+	// status pages, which anyone can load as often as they like.
+	statusCacheSize = 1024
+	statusCacheTTL  = time.Minute
+)
+
+func newStatusCache() *expirable.LRU[int64, string] {
+	return expirable.NewLRU[int64, string](statusCacheSize, nil, statusCacheTTL)
+}
+
+// statusWindows are the periods the status page summarizes, shortest first.
+var statusWindows = []struct {
+	Label    string
+	Duration time.Duration
+}{
+	{"24 hours", 24 * time.Hour},
+	{"7 days", 7 * 24 * time.Hour},
+	{"30 days", 30 * 24 * time.Hour},
+}
+
+// WindowStats summarizes a probe's runs over one status window.
+type WindowStats struct {
+	Label         string
+	Runs, Healthy int
+	// Latency percentiles of the runs whose request completed.
+	P50, P95, P99 time.Duration
+}
+
+// UptimeLabel is the share of healthy runs as a percentage, or "n/a" if
+// there were no runs.
+func (ws WindowStats) UptimeLabel() string {
+	if ws.Runs == 0 {
+		return "n/a"
+	}
+	// Round down so that a single failure never shows as 100%.
+	pct := math.Floor(float64(ws.Healthy)/float64(ws.Runs)*10000) / 100
+	return strconv.FormatFloat(pct, 'f', 2, 64) + "%"
+}
+
+// ProbeStatus is a probe's row on the status page.
+type ProbeStatus struct {
+	Probe   Probe
+	Windows []WindowStats
+}
+
+// Up reports whether the probe's latest run was healthy.
+func (ps ProbeStatus) Up() bool {
+	return ps.Probe.LastResultID != 0 && ps.Probe.LastResult.Healthy()
+}
+
+// latencyLabel formats a percentile for the status page.
+func latencyLabel(d time.Duration) string {
+	if d == 0 {
+		return "n/a"
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// userStatus summarizes every probe the user owns.
+func (s *Server) userStatus(ctx context.Context, userID int64) ([]ProbeStatus, error) {
+	var probes []Probe
+	if err := s.dao.db.WithContext(ctx).Where("user_id = ?", userID).Preload("LastResult").Order("name").Find(&probes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get probes: %w", err)
+	}
+
+	ids := make([]uint, 0, len(probes))
+	for _, p := range probes {
+		ids = append(ids, p.ID)
+	}
+
+	statuses := make([]ProbeStatus, len(probes))
+	for i, p := range probes {
+		statuses[i].Probe = p
+	}
+
+	now := time.Now()
+	for _, w := range statusWindows {
+		stats, err := s.dao.WindowStats(ctx, ids, now.Add(-w.Duration))
+		if err != nil {
+			return nil, err
+		}
+
+		for i, p := range probes {
+			ws := stats[p.ID]
+			ws.Label = w.Label
+			statuses[i].Windows = append(statuses[i].Windows, ws)
+		}
+	}
+
+	return statuses, nil
+}
+
+func (s *Server) statusGet(w http.ResponseWriter, r *http.Request) {
+	tu, ok := getTelegramUser(r.Context())
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	statuses, err := s.userStatus(r.Context(), tu.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to summarize probes", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	templ.Handler(
+		base("Status", nil, authedNavBar(tu), statusPage(tu, statuses, false)),
+	).ServeHTTP(w, r)
+}
+
+func (s *Server) statusPublicUpdate(w http.ResponseWriter, r *http.Request) {
+	tu, ok := getTelegramUser(r.Context())
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	tu.PublicStatus = r.FormValue("public") == "on"
+
+	if err := s.dao.db.WithContext(r.Context()).Model(tu).Update("public_status", tu.PublicStatus).Error; err != nil {
+		slog.ErrorContext(r.Context(), "failed to update public status page setting", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	templ.Handler(
+		publicStatusToggle(tu),
+	).ServeHTTP(w, r)
+}
+
+// publicStatusGet serves a user's status page to anyone, if the user made
+// it public. It leaves out probe URLs.
+func (s *Server) publicStatusGet(w http.ResponseWriter, r *http.Request) {
+	notFound := templ.Handler(
+		base("Not Found", nil, anonNavBar(true), notFoundPage()),
+		templ.WithStatus(http.StatusNotFound),
+	)
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		notFound.ServeHTTP(w, r)
+		return
+	}
+
+	user, err := s.dao.GetUser(r.Context(), id)
+	if err != nil || !user.PublicStatus {
+		notFound.ServeHTTP(w, r)
+		return
+	}
+
+	page, ok := s.statusCache.Get(user.ID)
+	if !ok {
+		statuses, err := s.userStatus(r.Context(), user.ID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to summarize probes", "err", err)
+			http.Error(w, "can't load status", http.StatusInternalServerError)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := statusPage(user, statuses, true).Render(r.Context(), &buf); err != nil {
+			slog.ErrorContext(r.Context(), "failed to render status page", "err", err)
+			http.Error(w, "can't load status", http.StatusInternalServerError)
+			return
+		}
+		page = buf.String()
+		s.statusCache.Add(user.ID, page)
+	}
+
+	var navbar templ.Component
+	if tu, ok := s.getTelegramUserData(r); ok {
+		navbar = authedNavBar(tu)
+	} else {
+		navbar = anonNavBar(true)
+	}
+
+	templ.Handler(
+		base("Status", nil, navbar, templ.Raw(page)),
+	).ServeHTTP(w, r)
+}
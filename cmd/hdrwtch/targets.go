@@ -0,0 +1,226 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/a-h/templ"
+)
+
+// targetKindEnabled reports whether this server can deliver to kind.
+func (s *Server) targetKindEnabled(kind string) bool {
+	switch kind {
+	case TargetMastodon:
+		return s.mastodon != nil
+	case TargetEmail:
+		return s.mailer != nil
+	}
+	return true
+}
+
+func (s *Server) targetList(w http.ResponseWriter, r *http.Request) {
+	tu, ok := getTelegramUser(r.Context())
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	targets, err := s.dao.ListTargets(r.Context(), tu.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get notification targets", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	templ.Handler(
+		base("Notifications", nil, authedNavBar(tu), targetListPage(targets, s.targetKindEnabled)),
+	).ServeHTTP(w, r)
+}
+
+func (s *Server) targetCreate(w http.ResponseWriter, r *http.Request) {
+	tu, ok := getTelegramUser(r.Context())
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	target := &NotificationTarget{
+		UserID:  tu.ID,
+		Name:    r.FormValue("name"),
+		Kind:    r.FormValue("kind"),
+		Address: r.FormValue("address"),
+		Secret:  r.FormValue("secret"),
+	}
+
+	if err := s.validateTarget(target); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Webhooks are always signed; generate a secret if the user didn't
+	// bring their own.
+	if target.Kind == TargetWebhook && target.Secret == "" {
+		target.Secret = rand.Text()
+	}
+
+	if err := s.dao.db.WithContext(r.Context()).Create(target).Error; err != nil {
+		slog.ErrorContext(r.Context(), "failed to create notification target", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	targets, err := s.dao.ListTargets(r.Context(), tu.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get notification targets", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	templ.Handler(
+		targetListPage(targets, s.targetKindEnabled),
+	).ServeHTTP(w, r)
+}
+
+func (s *Server) targetDelete(w http.ResponseWriter, r *http.Request) {
+	tu, ok := getTelegramUser(r.Context())
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	target, err := s.dao.GetTarget(r.Context(), r.PathValue("id"), tu.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get notification target", "path", r.URL.Path, "err", err)
+		http.Error(w, "no target data", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.dao.DeleteTarget(r.Context(), target); err != nil {
+		slog.ErrorContext(r.Context(), "failed to delete notification target", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// An empty response swaps the row out of the table.
+}
+
+func (s *Server) targetTest(w http.ResponseWriter, r *http.Request) {
+	tu, ok := getTelegramUser(r.Context())
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	target, err := s.dao.GetTarget(r.Context(), r.PathValue("id"), tu.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get notification target", "path", r.URL.Path, "err", err)
+		http.Error(w, "no target data", http.StatusUnauthorized)
+		return
+	}
+
+	msg := Message{
+		Event: EventTest,
+		Title: "Test notification",
+		Text:  fmt.Sprintf("*Test notification*:\n\nThis is a test notification for the %q target. If you can read this, hdrwtch can reach you here.", target.Name),
+	}
+
+	// Tests are tried once so the user sees the result right away.
+	n, err := s.deliver(r.Context(), tu, *target, msg, 1)
+	if n == nil {
+		slog.ErrorContext(r.Context(), "failed to test notification target", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	templ.Handler(
+		deliveryStatus(*n),
+	).ServeHTTP(w, r)
+}
+
+func (s *Server) notificationHistory(w http.ResponseWriter, r *http.Request) {
+	tu, ok := getTelegramUser(r.Context())
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	notifications, err := s.dao.ListNotifications(r.Context(), tu.ID, 50)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get notifications", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	templ.Handler(
+		base("Notification history", nil, authedNavBar(tu), notificationHistoryPage(notifications)),
+	).ServeHTTP(w, r)
+}
+
+func (s *Server) probeTargetsUpdate(w http.ResponseWriter, r *http.Request) {
+	tu, ok := getTelegramUser(r.Context())
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	probe, err := s.dao.GetProbe(r.Context(), r.PathValue("id"), tu.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get probe", "path", r.URL.Path, "err", err)
+		http.Error(w, "no probe data", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.ErrorContext(r.Context(), "failed to parse form", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	targets, err := s.dao.ListTargets(r.Context(), tu.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get notification targets", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Only the user's own targets can be selected; unknown IDs are ignored.
+	var selected []NotificationTarget
+	for _, t := range targets {
+		if slices.Contains(r.Form["target"], strconv.FormatUint(uint64(t.ID), 10)) {
+			selected = append(selected, t)
+		}
+	}
+
+	if err := s.dao.SetProbeTargets(r.Context(), probe, selected); err != nil {
+		slog.ErrorContext(r.Context(), "failed to set probe targets", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	probe.Targets = selected
+
+	templ.Handler(
+		probeTargetsForm(*probe, targets, true),
+	).ServeHTTP(w, r)
+}
+
+// hasTarget reports whether target is selected for probe.
+func hasTarget(probe Probe, target NotificationTarget) bool {
+	return slices.ContainsFunc(probe.Targets, func(t NotificationTarget) bool {
+		return t.ID == target.ID
+	})
+}
+
+// targetKindLabel is the UI label for kind.
+func targetKindLabel(kind string) string {
+	for _, k := range targetKinds {
+		if k.Value == kind {
+			return k.Label
+		}
+	}
+	return kind
+}
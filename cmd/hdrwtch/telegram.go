@@ -22,6 +22,8 @@ type TelegramUser struct {
 	AuthDate   string `json:"auth_date" gorm:"not null"`
 	IsAdmin    bool   `json:"is_admin" gorm:"not null"`
 	ProbeLimit int    `json:"probe_limit" gorm:"not null"`
+
+	PublicStatus bool `json:"public_status" gorm:"not null;default:false"` // anyone can see /status/{id}
 }
 
 type ctxKey int
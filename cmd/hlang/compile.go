@@ -13,6 +13,7 @@ type CompiledProgram struct {
 	Source string `json:"src"`
 	Binary []byte `json:"bin"`
 	AST    string `json:"ast"`
+	WAT    string `json:"wat"`
 }
 
 func compile(source string) (*CompiledProgram, error) {
@@ -27,7 +28,12 @@ func compile(source string) (*CompiledProgram, error) {
 		return nil, err
 	}
 
-	wasmBytes, err := nguh.Compile(tree)
+	mod, err := nguh.Build(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	wasmBytes, err := mod.Binary()
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +42,7 @@ func compile(source string) (*CompiledProgram, error) {
 		Source: source,
 		AST:    sb.String(),
 		Binary: wasmBytes,
+		WAT:    mod.WAT(),
 	}
 
 	return &result, nil
@@ -5,12 +5,20 @@ import (
 )
 
 const (
-	_sep   int = 0
-	_space int = 1
-	_h     int = 2
-	_H     int = 3
+	_Program int = 0
+	_Stmt    int = 1
+	_H       int = 2
+	_String  int = 3
+	_Char    int = 4
+	_Print   int = 5
+	_Arg     int = 6
+	_Loop    int = 7
+	_Number  int = 8
+	_Sp      int = 9
+	_Space   int = 10
+	_EOF     int = 11
 
-	_N int = 4
+	_N int = 12
 )
 
 type _Parser struct {
@@ -156,70 +164,132 @@ func _leaf(parser *_Parser, start, end int) *peg.Node {
 // A no-op function to mark a variable as used.
 func use(interface{}) {}
 
-func _sepAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
-	if dp, de, ok := _memo(parser, _sep, start); ok {
+func _ProgramAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _Program, start); ok {
 		return dp, de
 	}
 	pos, perr := start, -1
-	// space+ h
-	// space+
-	// space
-	if !_accept(parser, _spaceAccepts, &pos, &perr) {
+	// Sp (Stmt (Sp Stmt)*)? Sp EOF
+	// Sp
+	if !_accept(parser, _SpAccepts, &pos, &perr) {
 		goto fail
 	}
-	for {
+	// (Stmt (Sp Stmt)*)?
+	{
 		pos2 := pos
-		// space
-		if !_accept(parser, _spaceAccepts, &pos, &perr) {
-			goto fail4
+		// (Stmt (Sp Stmt)*)
+		// Stmt (Sp Stmt)*
+		// Stmt
+		if !_accept(parser, _StmtAccepts, &pos, &perr) {
+			goto fail3
 		}
-		continue
-	fail4:
+		// (Sp Stmt)*
+		for {
+			pos6 := pos
+			// (Sp Stmt)
+			// Sp Stmt
+			// Sp
+			if !_accept(parser, _SpAccepts, &pos, &perr) {
+				goto fail8
+			}
+			// Stmt
+			if !_accept(parser, _StmtAccepts, &pos, &perr) {
+				goto fail8
+			}
+			continue
+		fail8:
+			pos = pos6
+			break
+		}
+		goto ok10
+	fail3:
 		pos = pos2
-		break
+	ok10:
 	}
-	// h
-	if !_accept(parser, _hAccepts, &pos, &perr) {
+	// Sp
+	if !_accept(parser, _SpAccepts, &pos, &perr) {
 		goto fail
 	}
-	return _memoize(parser, _sep, start, pos, perr)
+	// EOF
+	if !_accept(parser, _EOFAccepts, &pos, &perr) {
+		goto fail
+	}
+	return _memoize(parser, _Program, start, pos, perr)
 fail:
-	return _memoize(parser, _sep, start, -1, perr)
+	return _memoize(parser, _Program, start, -1, perr)
 }
 
-func _sepNode(parser *_Parser, start int) (int, *peg.Node) {
-	dp := parser.deltaPos[start][_sep]
+func _ProgramNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_Program]
 	if dp < 0 {
 		return -1, nil
 	}
-	key := _key{start: start, rule: _sep}
+	key := _key{start: start, rule: _Program}
 	node := parser.node[key]
 	if node != nil {
 		return start + int(dp-1), node
 	}
 	pos := start
-	node = &peg.Node{Name: "sep"}
-	// space+ h
-	// space+
-	// space
-	if !_node(parser, _spaceNode, node, &pos) {
+	node = &peg.Node{Name: "Program"}
+	// Sp (Stmt (Sp Stmt)*)? Sp EOF
+	// Sp
+	if !_node(parser, _SpNode, node, &pos) {
 		goto fail
 	}
-	for {
+	// (Stmt (Sp Stmt)*)?
+	{
 		nkids1 := len(node.Kids)
 		pos2 := pos
-		// space
-		if !_node(parser, _spaceNode, node, &pos) {
-			goto fail4
+		// (Stmt (Sp Stmt)*)
+		{
+			nkids4 := len(node.Kids)
+			pos05 := pos
+			// Stmt (Sp Stmt)*
+			// Stmt
+			if !_node(parser, _StmtNode, node, &pos) {
+				goto fail3
+			}
+			// (Sp Stmt)*
+			for {
+				nkids7 := len(node.Kids)
+				pos8 := pos
+				// (Sp Stmt)
+				{
+					nkids11 := len(node.Kids)
+					pos012 := pos
+					// Sp Stmt
+					// Sp
+					if !_node(parser, _SpNode, node, &pos) {
+						goto fail10
+					}
+					// Stmt
+					if !_node(parser, _StmtNode, node, &pos) {
+						goto fail10
+					}
+					sub := _sub(parser, pos012, pos, node.Kids[nkids11:])
+					node.Kids = append(node.Kids[:nkids11], sub)
+				}
+				continue
+			fail10:
+				node.Kids = node.Kids[:nkids7]
+				pos = pos8
+				break
+			}
+			sub := _sub(parser, pos05, pos, node.Kids[nkids4:])
+			node.Kids = append(node.Kids[:nkids4], sub)
 		}
-		continue
-	fail4:
+		goto ok14
+	fail3:
 		node.Kids = node.Kids[:nkids1]
 		pos = pos2
-		break
+	ok14:
+	}
+	// Sp
+	if !_node(parser, _SpNode, node, &pos) {
+		goto fail
 	}
-	// h
-	if !_node(parser, _hNode, node, &pos) {
+	// EOF
+	if !_node(parser, _EOFNode, node, &pos) {
 		goto fail
 	}
 	node.Text = parser.text[start:pos]
@@ -229,35 +299,59 @@ fail:
 	return -1, nil
 }
 
-func _sepFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
-	pos, failure := _failMemo(parser, _sep, start, errPos)
+func _ProgramFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _Program, start, errPos)
 	if failure != nil {
 		return pos, failure
 	}
 	failure = &peg.Fail{
-		Name: "sep",
+		Name: "Program",
 		Pos:  int(start),
 	}
-	key := _key{start: start, rule: _sep}
-	// space+ h
-	// space+
-	// space
-	if !_fail(parser, _spaceFail, errPos, failure, &pos) {
+	key := _key{start: start, rule: _Program}
+	// Sp (Stmt (Sp Stmt)*)? Sp EOF
+	// Sp
+	if !_fail(parser, _SpFail, errPos, failure, &pos) {
 		goto fail
 	}
-	for {
+	// (Stmt (Sp Stmt)*)?
+	{
 		pos2 := pos
-		// space
-		if !_fail(parser, _spaceFail, errPos, failure, &pos) {
-			goto fail4
+		// (Stmt (Sp Stmt)*)
+		// Stmt (Sp Stmt)*
+		// Stmt
+		if !_fail(parser, _StmtFail, errPos, failure, &pos) {
+			goto fail3
 		}
-		continue
-	fail4:
+		// (Sp Stmt)*
+		for {
+			pos6 := pos
+			// (Sp Stmt)
+			// Sp Stmt
+			// Sp
+			if !_fail(parser, _SpFail, errPos, failure, &pos) {
+				goto fail8
+			}
+			// Stmt
+			if !_fail(parser, _StmtFail, errPos, failure, &pos) {
+				goto fail8
+			}
+			continue
+		fail8:
+			pos = pos6
+			break
+		}
+		goto ok10
+	fail3:
 		pos = pos2
-		break
+	ok10:
+	}
+	// Sp
+	if !_fail(parser, _SpFail, errPos, failure, &pos) {
+		goto fail
 	}
-	// h
-	if !_fail(parser, _hFail, errPos, failure, &pos) {
+	// EOF
+	if !_fail(parser, _EOFFail, errPos, failure, &pos) {
 		goto fail
 	}
 	parser.fail[key] = failure
@@ -267,12 +361,12 @@ fail:
 	return -1, failure
 }
 
-func _sepAction(parser *_Parser, start int) (int, *string) {
-	dp := parser.deltaPos[start][_sep]
+func _ProgramAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_Program]
 	if dp < 0 {
 		return -1, nil
 	}
-	key := _key{start: start, rule: _sep}
+	key := _key{start: start, rule: _Program}
 	n := parser.act[key]
 	if n != nil {
 		n := n.(string)
@@ -280,40 +374,82 @@ func _sepAction(parser *_Parser, start int) (int, *string) {
 	}
 	var node string
 	pos := start
-	// space+ h
+	// Sp (Stmt (Sp Stmt)*)? Sp EOF
 	{
 		var node0 string
-		// space+
-		{
-			var node3 string
-			// space
-			if p, n := _spaceAction(parser, pos); n == nil {
-				goto fail
-			} else {
-				node3 = *n
-				pos = p
-			}
-			node0 += node3
+		// Sp
+		if p, n := _SpAction(parser, pos); n == nil {
+			goto fail
+		} else {
+			node0 = *n
+			pos = p
 		}
-		for {
+		node, node0 = node+node0, ""
+		// (Stmt (Sp Stmt)*)?
+		{
 			pos2 := pos
-			var node3 string
-			// space
-			if p, n := _spaceAction(parser, pos); n == nil {
-				goto fail4
-			} else {
-				node3 = *n
-				pos = p
+			// (Stmt (Sp Stmt)*)
+			// Stmt (Sp Stmt)*
+			{
+				var node4 string
+				// Stmt
+				if p, n := _StmtAction(parser, pos); n == nil {
+					goto fail3
+				} else {
+					node4 = *n
+					pos = p
+				}
+				node0, node4 = node0+node4, ""
+				// (Sp Stmt)*
+				for {
+					pos6 := pos
+					var node7 string
+					// (Sp Stmt)
+					// Sp Stmt
+					{
+						var node9 string
+						// Sp
+						if p, n := _SpAction(parser, pos); n == nil {
+							goto fail8
+						} else {
+							node9 = *n
+							pos = p
+						}
+						node7, node9 = node7+node9, ""
+						// Stmt
+						if p, n := _StmtAction(parser, pos); n == nil {
+							goto fail8
+						} else {
+							node9 = *n
+							pos = p
+						}
+						node7, node9 = node7+node9, ""
+					}
+					node4 += node7
+					continue
+				fail8:
+					pos = pos6
+					break
+				}
+				node0, node4 = node0+node4, ""
 			}
-			node0 += node3
-			continue
-		fail4:
+			goto ok10
+		fail3:
+			node0 = ""
 			pos = pos2
-			break
+		ok10:
 		}
 		node, node0 = node+node0, ""
-		// h
-		if p, n := _hAction(parser, pos); n == nil {
+		// Sp
+		if p, n := _SpAction(parser, pos); n == nil {
+			goto fail
+		} else {
+			node0 = *n
+			pos = p
+		}
+		node, node0 = node+node0, ""
+		// EOF
+		if p, n := _EOFAction(parser, pos); n == nil {
 			goto fail
 		} else {
 			node0 = *n
@@ -327,40 +463,101 @@ fail:
 	return -1, nil
 }
 
-func _spaceAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
-	if dp, de, ok := _memo(parser, _space, start); ok {
+func _StmtAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _Stmt, start); ok {
 		return dp, de
 	}
 	pos, perr := start, -1
-	// " "
-	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != " " {
-		perr = _max(perr, pos)
+	// Loop/Print/String/H
+	{
+		pos3 := pos
+		// Loop
+		if !_accept(parser, _LoopAccepts, &pos, &perr) {
+			goto fail4
+		}
+		goto ok0
+	fail4:
+		pos = pos3
+		// Print
+		if !_accept(parser, _PrintAccepts, &pos, &perr) {
+			goto fail5
+		}
+		goto ok0
+	fail5:
+		pos = pos3
+		// String
+		if !_accept(parser, _StringAccepts, &pos, &perr) {
+			goto fail6
+		}
+		goto ok0
+	fail6:
+		pos = pos3
+		// H
+		if !_accept(parser, _HAccepts, &pos, &perr) {
+			goto fail7
+		}
+		goto ok0
+	fail7:
+		pos = pos3
 		goto fail
+	ok0:
 	}
-	pos++
-	return _memoize(parser, _space, start, pos, perr)
+	return _memoize(parser, _Stmt, start, pos, perr)
 fail:
-	return _memoize(parser, _space, start, -1, perr)
+	return _memoize(parser, _Stmt, start, -1, perr)
 }
 
-func _spaceNode(parser *_Parser, start int) (int, *peg.Node) {
-	dp := parser.deltaPos[start][_space]
+func _StmtNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_Stmt]
 	if dp < 0 {
 		return -1, nil
 	}
-	key := _key{start: start, rule: _space}
+	key := _key{start: start, rule: _Stmt}
 	node := parser.node[key]
 	if node != nil {
 		return start + int(dp-1), node
 	}
 	pos := start
-	node = &peg.Node{Name: "space"}
-	// " "
-	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != " " {
+	node = &peg.Node{Name: "Stmt"}
+	// Loop/Print/String/H
+	{
+		pos3 := pos
+		nkids1 := len(node.Kids)
+		// Loop
+		if !_node(parser, _LoopNode, node, &pos) {
+			goto fail4
+		}
+		goto ok0
+	fail4:
+		node.Kids = node.Kids[:nkids1]
+		pos = pos3
+		// Print
+		if !_node(parser, _PrintNode, node, &pos) {
+			goto fail5
+		}
+		goto ok0
+	fail5:
+		node.Kids = node.Kids[:nkids1]
+		pos = pos3
+		// String
+		if !_node(parser, _StringNode, node, &pos) {
+			goto fail6
+		}
+		goto ok0
+	fail6:
+		node.Kids = node.Kids[:nkids1]
+		pos = pos3
+		// H
+		if !_node(parser, _HNode, node, &pos) {
+			goto fail7
+		}
+		goto ok0
+	fail7:
+		node.Kids = node.Kids[:nkids1]
+		pos = pos3
 		goto fail
+	ok0:
 	}
-	node.Kids = append(node.Kids, _leaf(parser, pos, pos+1))
-	pos++
 	node.Text = parser.text[start:pos]
 	parser.node[key] = node
 	return pos, node
@@ -368,27 +565,50 @@ fail:
 	return -1, nil
 }
 
-func _spaceFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
-	pos, failure := _failMemo(parser, _space, start, errPos)
+func _StmtFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _Stmt, start, errPos)
 	if failure != nil {
 		return pos, failure
 	}
 	failure = &peg.Fail{
-		Name: "space",
+		Name: "Stmt",
 		Pos:  int(start),
 	}
-	key := _key{start: start, rule: _space}
-	// " "
-	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != " " {
-		if pos >= errPos {
-			failure.Kids = append(failure.Kids, &peg.Fail{
-				Pos:  int(pos),
-				Want: "\" \"",
-			})
+	key := _key{start: start, rule: _Stmt}
+	// Loop/Print/String/H
+	{
+		pos3 := pos
+		// Loop
+		if !_fail(parser, _LoopFail, errPos, failure, &pos) {
+			goto fail4
+		}
+		goto ok0
+	fail4:
+		pos = pos3
+		// Print
+		if !_fail(parser, _PrintFail, errPos, failure, &pos) {
+			goto fail5
+		}
+		goto ok0
+	fail5:
+		pos = pos3
+		// String
+		if !_fail(parser, _StringFail, errPos, failure, &pos) {
+			goto fail6
+		}
+		goto ok0
+	fail6:
+		pos = pos3
+		// H
+		if !_fail(parser, _HFail, errPos, failure, &pos) {
+			goto fail7
 		}
+		goto ok0
+	fail7:
+		pos = pos3
 		goto fail
+	ok0:
 	}
-	pos++
 	parser.fail[key] = failure
 	return pos, failure
 fail:
@@ -396,12 +616,12 @@ fail:
 	return -1, failure
 }
 
-func _spaceAction(parser *_Parser, start int) (int, *string) {
-	dp := parser.deltaPos[start][_space]
+func _StmtAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_Stmt]
 	if dp < 0 {
 		return -1, nil
 	}
-	key := _key{start: start, rule: _space}
+	key := _key{start: start, rule: _Stmt}
 	n := parser.act[key]
 	if n != nil {
 		n := n.(string)
@@ -409,20 +629,65 @@ func _spaceAction(parser *_Parser, start int) (int, *string) {
 	}
 	var node string
 	pos := start
-	// " "
-	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != " " {
+	// Loop/Print/String/H
+	{
+		pos3 := pos
+		var node2 string
+		// Loop
+		if p, n := _LoopAction(parser, pos); n == nil {
+			goto fail4
+		} else {
+			node = *n
+			pos = p
+		}
+		goto ok0
+	fail4:
+		node = node2
+		pos = pos3
+		// Print
+		if p, n := _PrintAction(parser, pos); n == nil {
+			goto fail5
+		} else {
+			node = *n
+			pos = p
+		}
+		goto ok0
+	fail5:
+		node = node2
+		pos = pos3
+		// String
+		if p, n := _StringAction(parser, pos); n == nil {
+			goto fail6
+		} else {
+			node = *n
+			pos = p
+		}
+		goto ok0
+	fail6:
+		node = node2
+		pos = pos3
+		// H
+		if p, n := _HAction(parser, pos); n == nil {
+			goto fail7
+		} else {
+			node = *n
+			pos = p
+		}
+		goto ok0
+	fail7:
+		node = node2
+		pos = pos3
 		goto fail
+	ok0:
 	}
-	node = parser.text[pos : pos+1]
-	pos++
 	parser.act[key] = node
 	return pos, &node
 fail:
 	return -1, nil
 }
 
-func _hAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
-	if dp, de, ok := _memo(parser, _h, start); ok {
+func _HAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _H, start); ok {
 		return dp, de
 	}
 	pos, perr := start, -1
@@ -450,23 +715,23 @@ func _hAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
 		goto fail
 	ok0:
 	}
-	return _memoize(parser, _h, start, pos, perr)
+	return _memoize(parser, _H, start, pos, perr)
 fail:
-	return _memoize(parser, _h, start, -1, perr)
+	return _memoize(parser, _H, start, -1, perr)
 }
 
-func _hNode(parser *_Parser, start int) (int, *peg.Node) {
-	dp := parser.deltaPos[start][_h]
+func _HNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_H]
 	if dp < 0 {
 		return -1, nil
 	}
-	key := _key{start: start, rule: _h}
+	key := _key{start: start, rule: _H}
 	node := parser.node[key]
 	if node != nil {
 		return start + int(dp-1), node
 	}
 	pos := start
-	node = &peg.Node{Name: "h"}
+	node = &peg.Node{Name: "H"}
 	// "h"/"'"
 	{
 		pos3 := pos
@@ -501,16 +766,16 @@ fail:
 	return -1, nil
 }
 
-func _hFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
-	pos, failure := _failMemo(parser, _h, start, errPos)
+func _HFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _H, start, errPos)
 	if failure != nil {
 		return pos, failure
 	}
 	failure = &peg.Fail{
-		Name: "h",
+		Name: "H",
 		Pos:  int(start),
 	}
-	key := _key{start: start, rule: _h}
+	key := _key{start: start, rule: _H}
 	// "h"/"'"
 	{
 		pos3 := pos
@@ -552,12 +817,12 @@ fail:
 	return -1, failure
 }
 
-func _hAction(parser *_Parser, start int) (int, *string) {
-	dp := parser.deltaPos[start][_h]
+func _HAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_H]
 	if dp < 0 {
 		return -1, nil
 	}
-	key := _key{start: start, rule: _h}
+	key := _key{start: start, rule: _H}
 	n := parser.act[key]
 	if n != nil {
 		n := n.(string)
@@ -598,128 +863,318 @@ fail:
 	return -1, nil
 }
 
-func _HAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
-	if dp, de, ok := _memo(parser, _H, start); ok {
+func _StringAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _String, start); ok {
 		return dp, de
 	}
 	pos, perr := start, -1
-	// h+ sep+/h
-	{
-		pos3 := pos
-		// h+ sep+
-		// h+
-		// h
-		if !_accept(parser, _hAccepts, &pos, &perr) {
-			goto fail4
-		}
-		for {
-			pos7 := pos
-			// h
-			if !_accept(parser, _hAccepts, &pos, &perr) {
-				goto fail9
-			}
-			continue
-		fail9:
-			pos = pos7
-			break
-		}
-		// sep+
-		// sep
-		if !_accept(parser, _sepAccepts, &pos, &perr) {
+	// "\"" Char* "\""
+	// "\""
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\"" {
+		perr = _max(perr, pos)
+		goto fail
+	}
+	pos++
+	// Char*
+	for {
+		pos2 := pos
+		// Char
+		if !_accept(parser, _CharAccepts, &pos, &perr) {
 			goto fail4
 		}
-		for {
-			pos11 := pos
-			// sep
-			if !_accept(parser, _sepAccepts, &pos, &perr) {
-				goto fail13
-			}
-			continue
-		fail13:
-			pos = pos11
-			break
-		}
-		goto ok0
+		continue
 	fail4:
-		pos = pos3
-		// h
-		if !_accept(parser, _hAccepts, &pos, &perr) {
-			goto fail14
-		}
-		goto ok0
-	fail14:
-		pos = pos3
+		pos = pos2
+		break
+	}
+	// "\""
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\"" {
+		perr = _max(perr, pos)
 		goto fail
-	ok0:
 	}
-	return _memoize(parser, _H, start, pos, perr)
+	pos++
+	return _memoize(parser, _String, start, pos, perr)
 fail:
-	return _memoize(parser, _H, start, -1, perr)
+	return _memoize(parser, _String, start, -1, perr)
 }
 
-func _HNode(parser *_Parser, start int) (int, *peg.Node) {
-	dp := parser.deltaPos[start][_H]
+func _StringNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_String]
 	if dp < 0 {
 		return -1, nil
 	}
-	key := _key{start: start, rule: _H}
+	key := _key{start: start, rule: _String}
 	node := parser.node[key]
 	if node != nil {
 		return start + int(dp-1), node
 	}
 	pos := start
-	node = &peg.Node{Name: "H"}
-	// h+ sep+/h
-	{
-		pos3 := pos
+	node = &peg.Node{Name: "String"}
+	// "\"" Char* "\""
+	// "\""
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\"" {
+		goto fail
+	}
+	node.Kids = append(node.Kids, _leaf(parser, pos, pos+1))
+	pos++
+	// Char*
+	for {
 		nkids1 := len(node.Kids)
-		// h+ sep+
-		// h+
-		// h
-		if !_node(parser, _hNode, node, &pos) {
-			goto fail4
-		}
-		for {
-			nkids6 := len(node.Kids)
-			pos7 := pos
-			// h
-			if !_node(parser, _hNode, node, &pos) {
-				goto fail9
-			}
-			continue
-		fail9:
-			node.Kids = node.Kids[:nkids6]
-			pos = pos7
-			break
-		}
-		// sep+
-		// sep
-		if !_node(parser, _sepNode, node, &pos) {
+		pos2 := pos
+		// Char
+		if !_node(parser, _CharNode, node, &pos) {
 			goto fail4
 		}
-		for {
-			nkids10 := len(node.Kids)
-			pos11 := pos
-			// sep
-			if !_node(parser, _sepNode, node, &pos) {
-				goto fail13
+		continue
+	fail4:
+		node.Kids = node.Kids[:nkids1]
+		pos = pos2
+		break
+	}
+	// "\""
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\"" {
+		goto fail
+	}
+	node.Kids = append(node.Kids, _leaf(parser, pos, pos+1))
+	pos++
+	node.Text = parser.text[start:pos]
+	parser.node[key] = node
+	return pos, node
+fail:
+	return -1, nil
+}
+
+func _StringFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _String, start, errPos)
+	if failure != nil {
+		return pos, failure
+	}
+	failure = &peg.Fail{
+		Name: "String",
+		Pos:  int(start),
+	}
+	key := _key{start: start, rule: _String}
+	// "\"" Char* "\""
+	// "\""
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\"" {
+		if pos >= errPos {
+			failure.Kids = append(failure.Kids, &peg.Fail{
+				Pos:  int(pos),
+				Want: "\"\\\"\"",
+			})
+		}
+		goto fail
+	}
+	pos++
+	// Char*
+	for {
+		pos2 := pos
+		// Char
+		if !_fail(parser, _CharFail, errPos, failure, &pos) {
+			goto fail4
+		}
+		continue
+	fail4:
+		pos = pos2
+		break
+	}
+	// "\""
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\"" {
+		if pos >= errPos {
+			failure.Kids = append(failure.Kids, &peg.Fail{
+				Pos:  int(pos),
+				Want: "\"\\\"\"",
+			})
+		}
+		goto fail
+	}
+	pos++
+	parser.fail[key] = failure
+	return pos, failure
+fail:
+	parser.fail[key] = failure
+	return -1, failure
+}
+
+func _StringAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_String]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _String}
+	n := parser.act[key]
+	if n != nil {
+		n := n.(string)
+		return start + int(dp-1), &n
+	}
+	var node string
+	pos := start
+	// "\"" Char* "\""
+	{
+		var node0 string
+		// "\""
+		if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\"" {
+			goto fail
+		}
+		node0 = parser.text[pos : pos+1]
+		pos++
+		node, node0 = node+node0, ""
+		// Char*
+		for {
+			pos2 := pos
+			var node3 string
+			// Char
+			if p, n := _CharAction(parser, pos); n == nil {
+				goto fail4
+			} else {
+				node3 = *n
+				pos = p
 			}
+			node0 += node3
 			continue
-		fail13:
-			node.Kids = node.Kids[:nkids10]
-			pos = pos11
+		fail4:
+			pos = pos2
 			break
 		}
+		node, node0 = node+node0, ""
+		// "\""
+		if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\"" {
+			goto fail
+		}
+		node0 = parser.text[pos : pos+1]
+		pos++
+		node, node0 = node+node0, ""
+	}
+	parser.act[key] = node
+	return pos, &node
+fail:
+	return -1, nil
+}
+
+func _CharAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _Char, start); ok {
+		return dp, de
+	}
+	pos, perr := start, -1
+	// "\\" ["\\n]/!["\\\n] .
+	{
+		pos3 := pos
+		// "\\" ["\\n]
+		// "\\"
+		if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\\" {
+			perr = _max(perr, pos)
+			goto fail4
+		}
+		pos++
+		// ["\\n]
+		if r, w := _next(parser, pos); r != '"' && r != '\\' && r != 'n' {
+			perr = _max(perr, pos)
+			goto fail4
+		} else {
+			pos += w
+		}
+		goto ok0
+	fail4:
+		pos = pos3
+		// !["\\\n] .
+		// !["\\\n]
+		{
+			pos9 := pos
+			perr11 := perr
+			// ["\\\n]
+			if r, w := _next(parser, pos); r != '"' && r != '\\' && r != '\n' {
+				perr = _max(perr, pos)
+				goto ok8
+			} else {
+				pos += w
+			}
+			pos = pos9
+			perr = _max(perr11, pos)
+			goto fail6
+		ok8:
+			pos = pos9
+			perr = perr11
+		}
+		// .
+		if r, w := _next(parser, pos); w == 0 || r == '\uFFFD' {
+			perr = _max(perr, pos)
+			goto fail6
+		} else {
+			pos += w
+		}
+		goto ok0
+	fail6:
+		pos = pos3
+		goto fail
+	ok0:
+	}
+	return _memoize(parser, _Char, start, pos, perr)
+fail:
+	return _memoize(parser, _Char, start, -1, perr)
+}
+
+func _CharNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_Char]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Char}
+	node := parser.node[key]
+	if node != nil {
+		return start + int(dp-1), node
+	}
+	pos := start
+	node = &peg.Node{Name: "Char"}
+	// "\\" ["\\n]/!["\\\n] .
+	{
+		pos3 := pos
+		nkids1 := len(node.Kids)
+		// "\\" ["\\n]
+		// "\\"
+		if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\\" {
+			goto fail4
+		}
+		node.Kids = append(node.Kids, _leaf(parser, pos, pos+1))
+		pos++
+		// ["\\n]
+		if r, w := _next(parser, pos); r != '"' && r != '\\' && r != 'n' {
+			goto fail4
+		} else {
+			node.Kids = append(node.Kids, _leaf(parser, pos, pos+w))
+			pos += w
+		}
 		goto ok0
 	fail4:
 		node.Kids = node.Kids[:nkids1]
 		pos = pos3
-		// h
-		if !_node(parser, _hNode, node, &pos) {
-			goto fail14
+		// !["\\\n] .
+		// !["\\\n]
+		{
+			pos9 := pos
+			nkids10 := len(node.Kids)
+			// ["\\\n]
+			if r, w := _next(parser, pos); r != '"' && r != '\\' && r != '\n' {
+				goto ok8
+			} else {
+				node.Kids = append(node.Kids, _leaf(parser, pos, pos+w))
+				pos += w
+			}
+			pos = pos9
+			node.Kids = node.Kids[:nkids10]
+			goto fail6
+		ok8:
+			pos = pos9
+			node.Kids = node.Kids[:nkids10]
+		}
+		// .
+		if r, w := _next(parser, pos); w == 0 || r == '\uFFFD' {
+			goto fail6
+		} else {
+			node.Kids = append(node.Kids, _leaf(parser, pos, pos+w))
+			pos += w
 		}
 		goto ok0
-	fail14:
+	fail6:
 		node.Kids = node.Kids[:nkids1]
 		pos = pos3
 		goto fail
@@ -732,61 +1187,90 @@ fail:
 	return -1, nil
 }
 
-func _HFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
-	pos, failure := _failMemo(parser, _H, start, errPos)
+func _CharFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _Char, start, errPos)
 	if failure != nil {
 		return pos, failure
 	}
 	failure = &peg.Fail{
-		Name: "H",
+		Name: "Char",
 		Pos:  int(start),
 	}
-	key := _key{start: start, rule: _H}
-	// h+ sep+/h
+	key := _key{start: start, rule: _Char}
+	// "\\" ["\\n]/!["\\\n] .
 	{
 		pos3 := pos
-		// h+ sep+
-		// h+
-		// h
-		if !_fail(parser, _hFail, errPos, failure, &pos) {
-			goto fail4
-		}
-		for {
-			pos7 := pos
-			// h
-			if !_fail(parser, _hFail, errPos, failure, &pos) {
-				goto fail9
+		// "\\" ["\\n]
+		// "\\"
+		if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\\" {
+			if pos >= errPos {
+				failure.Kids = append(failure.Kids, &peg.Fail{
+					Pos:  int(pos),
+					Want: "\"\\\\\"",
+				})
 			}
-			continue
-		fail9:
-			pos = pos7
-			break
-		}
-		// sep+
-		// sep
-		if !_fail(parser, _sepFail, errPos, failure, &pos) {
 			goto fail4
 		}
-		for {
-			pos11 := pos
-			// sep
-			if !_fail(parser, _sepFail, errPos, failure, &pos) {
-				goto fail13
+		pos++
+		// ["\\n]
+		if r, w := _next(parser, pos); r != '"' && r != '\\' && r != 'n' {
+			if pos >= errPos {
+				failure.Kids = append(failure.Kids, &peg.Fail{
+					Pos:  int(pos),
+					Want: "[\"\\\\n]",
+				})
 			}
-			continue
-		fail13:
-			pos = pos11
-			break
+			goto fail4
+		} else {
+			pos += w
 		}
 		goto ok0
 	fail4:
 		pos = pos3
-		// h
-		if !_fail(parser, _hFail, errPos, failure, &pos) {
-			goto fail14
+		// !["\\\n] .
+		// !["\\\n]
+		{
+			pos9 := pos
+			nkids10 := len(failure.Kids)
+			// ["\\\n]
+			if r, w := _next(parser, pos); r != '"' && r != '\\' && r != '\n' {
+				if pos >= errPos {
+					failure.Kids = append(failure.Kids, &peg.Fail{
+						Pos:  int(pos),
+						Want: "[\"\\\\\\n]",
+					})
+				}
+				goto ok8
+			} else {
+				pos += w
+			}
+			pos = pos9
+			failure.Kids = failure.Kids[:nkids10]
+			if pos >= errPos {
+				failure.Kids = append(failure.Kids, &peg.Fail{
+					Pos:  int(pos),
+					Want: "![\"\\\\\\n]",
+				})
+			}
+			goto fail6
+		ok8:
+			pos = pos9
+			failure.Kids = failure.Kids[:nkids10]
+		}
+		// .
+		if r, w := _next(parser, pos); w == 0 || r == '\uFFFD' {
+			if pos >= errPos {
+				failure.Kids = append(failure.Kids, &peg.Fail{
+					Pos:  int(pos),
+					Want: ".",
+				})
+			}
+			goto fail6
+		} else {
+			pos += w
 		}
 		goto ok0
-	fail14:
+	fail6:
 		pos = pos3
 		goto fail
 	ok0:
@@ -798,12 +1282,12 @@ fail:
 	return -1, failure
 }
 
-func _HAction(parser *_Parser, start int) (int, *string) {
-	dp := parser.deltaPos[start][_H]
+func _CharAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_Char]
 	if dp < 0 {
 		return -1, nil
 	}
-	key := _key{start: start, rule: _H}
+	key := _key{start: start, rule: _Char}
 	n := parser.act[key]
 	if n != nil {
 		n := n.(string)
@@ -811,69 +1295,26 @@ func _HAction(parser *_Parser, start int) (int, *string) {
 	}
 	var node string
 	pos := start
-	// h+ sep+/h
+	// "\\" ["\\n]/!["\\\n] .
 	{
 		pos3 := pos
 		var node2 string
-		// h+ sep+
+		// "\\" ["\\n]
 		{
 			var node5 string
-			// h+
-			{
-				var node8 string
-				// h
-				if p, n := _hAction(parser, pos); n == nil {
-					goto fail4
-				} else {
-					node8 = *n
-					pos = p
-				}
-				node5 += node8
-			}
-			for {
-				pos7 := pos
-				var node8 string
-				// h
-				if p, n := _hAction(parser, pos); n == nil {
-					goto fail9
-				} else {
-					node8 = *n
-					pos = p
-				}
-				node5 += node8
-				continue
-			fail9:
-				pos = pos7
-				break
+			// "\\"
+			if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "\\" {
+				goto fail4
 			}
+			node5 = parser.text[pos : pos+1]
+			pos++
 			node, node5 = node+node5, ""
-			// sep+
-			{
-				var node12 string
-				// sep
-				if p, n := _sepAction(parser, pos); n == nil {
-					goto fail4
-				} else {
-					node12 = *n
-					pos = p
-				}
-				node5 += node12
-			}
-			for {
-				pos11 := pos
-				var node12 string
-				// sep
-				if p, n := _sepAction(parser, pos); n == nil {
-					goto fail13
-				} else {
-					node12 = *n
-					pos = p
-				}
-				node5 += node12
-				continue
-			fail13:
-				pos = pos11
-				break
+			// ["\\n]
+			if r, w := _next(parser, pos); r != '"' && r != '\\' && r != 'n' {
+				goto fail4
+			} else {
+				node5 = parser.text[pos : pos+w]
+				pos += w
 			}
 			node, node5 = node+node5, ""
 		}
@@ -881,15 +1322,36 @@ func _HAction(parser *_Parser, start int) (int, *string) {
 	fail4:
 		node = node2
 		pos = pos3
-		// h
-		if p, n := _hAction(parser, pos); n == nil {
-			goto fail14
-		} else {
-			node = *n
-			pos = p
+		// !["\\\n] .
+		{
+			var node7 string
+			// !["\\\n]
+			{
+				pos9 := pos
+				// ["\\\n]
+				if r, w := _next(parser, pos); r != '"' && r != '\\' && r != '\n' {
+					goto ok8
+				} else {
+					pos += w
+				}
+				pos = pos9
+				goto fail6
+			ok8:
+				pos = pos9
+				node7 = ""
+			}
+			node, node7 = node+node7, ""
+			// .
+			if r, w := _next(parser, pos); w == 0 || r == '\uFFFD' {
+				goto fail6
+			} else {
+				node7 = parser.text[pos : pos+w]
+				pos += w
+			}
+			node, node7 = node+node7, ""
 		}
 		goto ok0
-	fail14:
+	fail6:
 		node = node2
 		pos = pos3
 		goto fail
@@ -900,3 +1362,1562 @@ func _HAction(parser *_Parser, start int) (int, *string) {
 fail:
 	return -1, nil
 }
+
+func _PrintAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _Print, start); ok {
+		return dp, de
+	}
+	pos, perr := start, -1
+	// "print" Sp "(" Sp (Arg (Sp Arg)*)? Sp ")"
+	// "print"
+	if len(parser.text[pos:]) < 5 || parser.text[pos:pos+5] != "print" {
+		perr = _max(perr, pos)
+		goto fail
+	}
+	pos += 5
+	// Sp
+	if !_accept(parser, _SpAccepts, &pos, &perr) {
+		goto fail
+	}
+	// "("
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "(" {
+		perr = _max(perr, pos)
+		goto fail
+	}
+	pos++
+	// Sp
+	if !_accept(parser, _SpAccepts, &pos, &perr) {
+		goto fail
+	}
+	// (Arg (Sp Arg)*)?
+	{
+		pos2 := pos
+		// (Arg (Sp Arg)*)
+		// Arg (Sp Arg)*
+		// Arg
+		if !_accept(parser, _ArgAccepts, &pos, &perr) {
+			goto fail3
+		}
+		// (Sp Arg)*
+		for {
+			pos6 := pos
+			// (Sp Arg)
+			// Sp Arg
+			// Sp
+			if !_accept(parser, _SpAccepts, &pos, &perr) {
+				goto fail8
+			}
+			// Arg
+			if !_accept(parser, _ArgAccepts, &pos, &perr) {
+				goto fail8
+			}
+			continue
+		fail8:
+			pos = pos6
+			break
+		}
+		goto ok10
+	fail3:
+		pos = pos2
+	ok10:
+	}
+	// Sp
+	if !_accept(parser, _SpAccepts, &pos, &perr) {
+		goto fail
+	}
+	// ")"
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != ")" {
+		perr = _max(perr, pos)
+		goto fail
+	}
+	pos++
+	return _memoize(parser, _Print, start, pos, perr)
+fail:
+	return _memoize(parser, _Print, start, -1, perr)
+}
+
+func _PrintNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_Print]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Print}
+	node := parser.node[key]
+	if node != nil {
+		return start + int(dp-1), node
+	}
+	pos := start
+	node = &peg.Node{Name: "Print"}
+	// "print" Sp "(" Sp (Arg (Sp Arg)*)? Sp ")"
+	// "print"
+	if len(parser.text[pos:]) < 5 || parser.text[pos:pos+5] != "print" {
+		goto fail
+	}
+	node.Kids = append(node.Kids, _leaf(parser, pos, pos+5))
+	pos += 5
+	// Sp
+	if !_node(parser, _SpNode, node, &pos) {
+		goto fail
+	}
+	// "("
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "(" {
+		goto fail
+	}
+	node.Kids = append(node.Kids, _leaf(parser, pos, pos+1))
+	pos++
+	// Sp
+	if !_node(parser, _SpNode, node, &pos) {
+		goto fail
+	}
+	// (Arg (Sp Arg)*)?
+	{
+		nkids1 := len(node.Kids)
+		pos2 := pos
+		// (Arg (Sp Arg)*)
+		{
+			nkids4 := len(node.Kids)
+			pos05 := pos
+			// Arg (Sp Arg)*
+			// Arg
+			if !_node(parser, _ArgNode, node, &pos) {
+				goto fail3
+			}
+			// (Sp Arg)*
+			for {
+				nkids7 := len(node.Kids)
+				pos8 := pos
+				// (Sp Arg)
+				{
+					nkids11 := len(node.Kids)
+					pos012 := pos
+					// Sp Arg
+					// Sp
+					if !_node(parser, _SpNode, node, &pos) {
+						goto fail10
+					}
+					// Arg
+					if !_node(parser, _ArgNode, node, &pos) {
+						goto fail10
+					}
+					sub := _sub(parser, pos012, pos, node.Kids[nkids11:])
+					node.Kids = append(node.Kids[:nkids11], sub)
+				}
+				continue
+			fail10:
+				node.Kids = node.Kids[:nkids7]
+				pos = pos8
+				break
+			}
+			sub := _sub(parser, pos05, pos, node.Kids[nkids4:])
+			node.Kids = append(node.Kids[:nkids4], sub)
+		}
+		goto ok14
+	fail3:
+		node.Kids = node.Kids[:nkids1]
+		pos = pos2
+	ok14:
+	}
+	// Sp
+	if !_node(parser, _SpNode, node, &pos) {
+		goto fail
+	}
+	// ")"
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != ")" {
+		goto fail
+	}
+	node.Kids = append(node.Kids, _leaf(parser, pos, pos+1))
+	pos++
+	node.Text = parser.text[start:pos]
+	parser.node[key] = node
+	return pos, node
+fail:
+	return -1, nil
+}
+
+func _PrintFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _Print, start, errPos)
+	if failure != nil {
+		return pos, failure
+	}
+	failure = &peg.Fail{
+		Name: "Print",
+		Pos:  int(start),
+	}
+	key := _key{start: start, rule: _Print}
+	// "print" Sp "(" Sp (Arg (Sp Arg)*)? Sp ")"
+	// "print"
+	if len(parser.text[pos:]) < 5 || parser.text[pos:pos+5] != "print" {
+		if pos >= errPos {
+			failure.Kids = append(failure.Kids, &peg.Fail{
+				Pos:  int(pos),
+				Want: "\"print\"",
+			})
+		}
+		goto fail
+	}
+	pos += 5
+	// Sp
+	if !_fail(parser, _SpFail, errPos, failure, &pos) {
+		goto fail
+	}
+	// "("
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "(" {
+		if pos >= errPos {
+			failure.Kids = append(failure.Kids, &peg.Fail{
+				Pos:  int(pos),
+				Want: "\"(\"",
+			})
+		}
+		goto fail
+	}
+	pos++
+	// Sp
+	if !_fail(parser, _SpFail, errPos, failure, &pos) {
+		goto fail
+	}
+	// (Arg (Sp Arg)*)?
+	{
+		pos2 := pos
+		// (Arg (Sp Arg)*)
+		// Arg (Sp Arg)*
+		// Arg
+		if !_fail(parser, _ArgFail, errPos, failure, &pos) {
+			goto fail3
+		}
+		// (Sp Arg)*
+		for {
+			pos6 := pos
+			// (Sp Arg)
+			// Sp Arg
+			// Sp
+			if !_fail(parser, _SpFail, errPos, failure, &pos) {
+				goto fail8
+			}
+			// Arg
+			if !_fail(parser, _ArgFail, errPos, failure, &pos) {
+				goto fail8
+			}
+			continue
+		fail8:
+			pos = pos6
+			break
+		}
+		goto ok10
+	fail3:
+		pos = pos2
+	ok10:
+	}
+	// Sp
+	if !_fail(parser, _SpFail, errPos, failure, &pos) {
+		goto fail
+	}
+	// ")"
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != ")" {
+		if pos >= errPos {
+			failure.Kids = append(failure.Kids, &peg.Fail{
+				Pos:  int(pos),
+				Want: "\")\"",
+			})
+		}
+		goto fail
+	}
+	pos++
+	parser.fail[key] = failure
+	return pos, failure
+fail:
+	parser.fail[key] = failure
+	return -1, failure
+}
+
+func _PrintAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_Print]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Print}
+	n := parser.act[key]
+	if n != nil {
+		n := n.(string)
+		return start + int(dp-1), &n
+	}
+	var node string
+	pos := start
+	// "print" Sp "(" Sp (Arg (Sp Arg)*)? Sp ")"
+	{
+		var node0 string
+		// "print"
+		if len(parser.text[pos:]) < 5 || parser.text[pos:pos+5] != "print" {
+			goto fail
+		}
+		node0 = parser.text[pos : pos+5]
+		pos += 5
+		node, node0 = node+node0, ""
+		// Sp
+		if p, n := _SpAction(parser, pos); n == nil {
+			goto fail
+		} else {
+			node0 = *n
+			pos = p
+		}
+		node, node0 = node+node0, ""
+		// "("
+		if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "(" {
+			goto fail
+		}
+		node0 = parser.text[pos : pos+1]
+		pos++
+		node, node0 = node+node0, ""
+		// Sp
+		if p, n := _SpAction(parser, pos); n == nil {
+			goto fail
+		} else {
+			node0 = *n
+			pos = p
+		}
+		node, node0 = node+node0, ""
+		// (Arg (Sp Arg)*)?
+		{
+			pos2 := pos
+			// (Arg (Sp Arg)*)
+			// Arg (Sp Arg)*
+			{
+				var node4 string
+				// Arg
+				if p, n := _ArgAction(parser, pos); n == nil {
+					goto fail3
+				} else {
+					node4 = *n
+					pos = p
+				}
+				node0, node4 = node0+node4, ""
+				// (Sp Arg)*
+				for {
+					pos6 := pos
+					var node7 string
+					// (Sp Arg)
+					// Sp Arg
+					{
+						var node9 string
+						// Sp
+						if p, n := _SpAction(parser, pos); n == nil {
+							goto fail8
+						} else {
+							node9 = *n
+							pos = p
+						}
+						node7, node9 = node7+node9, ""
+						// Arg
+						if p, n := _ArgAction(parser, pos); n == nil {
+							goto fail8
+						} else {
+							node9 = *n
+							pos = p
+						}
+						node7, node9 = node7+node9, ""
+					}
+					node4 += node7
+					continue
+				fail8:
+					pos = pos6
+					break
+				}
+				node0, node4 = node0+node4, ""
+			}
+			goto ok10
+		fail3:
+			node0 = ""
+			pos = pos2
+		ok10:
+		}
+		node, node0 = node+node0, ""
+		// Sp
+		if p, n := _SpAction(parser, pos); n == nil {
+			goto fail
+		} else {
+			node0 = *n
+			pos = p
+		}
+		node, node0 = node+node0, ""
+		// ")"
+		if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != ")" {
+			goto fail
+		}
+		node0 = parser.text[pos : pos+1]
+		pos++
+		node, node0 = node+node0, ""
+	}
+	parser.act[key] = node
+	return pos, &node
+fail:
+	return -1, nil
+}
+
+func _ArgAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _Arg, start); ok {
+		return dp, de
+	}
+	pos, perr := start, -1
+	// String/H
+	{
+		pos3 := pos
+		// String
+		if !_accept(parser, _StringAccepts, &pos, &perr) {
+			goto fail4
+		}
+		goto ok0
+	fail4:
+		pos = pos3
+		// H
+		if !_accept(parser, _HAccepts, &pos, &perr) {
+			goto fail5
+		}
+		goto ok0
+	fail5:
+		pos = pos3
+		goto fail
+	ok0:
+	}
+	return _memoize(parser, _Arg, start, pos, perr)
+fail:
+	return _memoize(parser, _Arg, start, -1, perr)
+}
+
+func _ArgNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_Arg]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Arg}
+	node := parser.node[key]
+	if node != nil {
+		return start + int(dp-1), node
+	}
+	pos := start
+	node = &peg.Node{Name: "Arg"}
+	// String/H
+	{
+		pos3 := pos
+		nkids1 := len(node.Kids)
+		// String
+		if !_node(parser, _StringNode, node, &pos) {
+			goto fail4
+		}
+		goto ok0
+	fail4:
+		node.Kids = node.Kids[:nkids1]
+		pos = pos3
+		// H
+		if !_node(parser, _HNode, node, &pos) {
+			goto fail5
+		}
+		goto ok0
+	fail5:
+		node.Kids = node.Kids[:nkids1]
+		pos = pos3
+		goto fail
+	ok0:
+	}
+	node.Text = parser.text[start:pos]
+	parser.node[key] = node
+	return pos, node
+fail:
+	return -1, nil
+}
+
+func _ArgFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _Arg, start, errPos)
+	if failure != nil {
+		return pos, failure
+	}
+	failure = &peg.Fail{
+		Name: "Arg",
+		Pos:  int(start),
+	}
+	key := _key{start: start, rule: _Arg}
+	// String/H
+	{
+		pos3 := pos
+		// String
+		if !_fail(parser, _StringFail, errPos, failure, &pos) {
+			goto fail4
+		}
+		goto ok0
+	fail4:
+		pos = pos3
+		// H
+		if !_fail(parser, _HFail, errPos, failure, &pos) {
+			goto fail5
+		}
+		goto ok0
+	fail5:
+		pos = pos3
+		goto fail
+	ok0:
+	}
+	parser.fail[key] = failure
+	return pos, failure
+fail:
+	parser.fail[key] = failure
+	return -1, failure
+}
+
+func _ArgAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_Arg]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Arg}
+	n := parser.act[key]
+	if n != nil {
+		n := n.(string)
+		return start + int(dp-1), &n
+	}
+	var node string
+	pos := start
+	// String/H
+	{
+		pos3 := pos
+		var node2 string
+		// String
+		if p, n := _StringAction(parser, pos); n == nil {
+			goto fail4
+		} else {
+			node = *n
+			pos = p
+		}
+		goto ok0
+	fail4:
+		node = node2
+		pos = pos3
+		// H
+		if p, n := _HAction(parser, pos); n == nil {
+			goto fail5
+		} else {
+			node = *n
+			pos = p
+		}
+		goto ok0
+	fail5:
+		node = node2
+		pos = pos3
+		goto fail
+	ok0:
+	}
+	parser.act[key] = node
+	return pos, &node
+fail:
+	return -1, nil
+}
+
+func _LoopAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _Loop, start); ok {
+		return dp, de
+	}
+	pos, perr := start, -1
+	// "loop" Space+ Number Sp "{" Sp (Stmt (Sp Stmt)*)? Sp "}"
+	// "loop"
+	if len(parser.text[pos:]) < 4 || parser.text[pos:pos+4] != "loop" {
+		perr = _max(perr, pos)
+		goto fail
+	}
+	pos += 4
+	// Space+
+	// Space
+	if !_accept(parser, _SpaceAccepts, &pos, &perr) {
+		goto fail
+	}
+	for {
+		pos2 := pos
+		// Space
+		if !_accept(parser, _SpaceAccepts, &pos, &perr) {
+			goto fail4
+		}
+		continue
+	fail4:
+		pos = pos2
+		break
+	}
+	// Number
+	if !_accept(parser, _NumberAccepts, &pos, &perr) {
+		goto fail
+	}
+	// Sp
+	if !_accept(parser, _SpAccepts, &pos, &perr) {
+		goto fail
+	}
+	// "{"
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "{" {
+		perr = _max(perr, pos)
+		goto fail
+	}
+	pos++
+	// Sp
+	if !_accept(parser, _SpAccepts, &pos, &perr) {
+		goto fail
+	}
+	// (Stmt (Sp Stmt)*)?
+	{
+		pos6 := pos
+		// (Stmt (Sp Stmt)*)
+		// Stmt (Sp Stmt)*
+		// Stmt
+		if !_accept(parser, _StmtAccepts, &pos, &perr) {
+			goto fail7
+		}
+		// (Sp Stmt)*
+		for {
+			pos10 := pos
+			// (Sp Stmt)
+			// Sp Stmt
+			// Sp
+			if !_accept(parser, _SpAccepts, &pos, &perr) {
+				goto fail12
+			}
+			// Stmt
+			if !_accept(parser, _StmtAccepts, &pos, &perr) {
+				goto fail12
+			}
+			continue
+		fail12:
+			pos = pos10
+			break
+		}
+		goto ok14
+	fail7:
+		pos = pos6
+	ok14:
+	}
+	// Sp
+	if !_accept(parser, _SpAccepts, &pos, &perr) {
+		goto fail
+	}
+	// "}"
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "}" {
+		perr = _max(perr, pos)
+		goto fail
+	}
+	pos++
+	return _memoize(parser, _Loop, start, pos, perr)
+fail:
+	return _memoize(parser, _Loop, start, -1, perr)
+}
+
+func _LoopNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_Loop]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Loop}
+	node := parser.node[key]
+	if node != nil {
+		return start + int(dp-1), node
+	}
+	pos := start
+	node = &peg.Node{Name: "Loop"}
+	// "loop" Space+ Number Sp "{" Sp (Stmt (Sp Stmt)*)? Sp "}"
+	// "loop"
+	if len(parser.text[pos:]) < 4 || parser.text[pos:pos+4] != "loop" {
+		goto fail
+	}
+	node.Kids = append(node.Kids, _leaf(parser, pos, pos+4))
+	pos += 4
+	// Space+
+	// Space
+	if !_node(parser, _SpaceNode, node, &pos) {
+		goto fail
+	}
+	for {
+		nkids1 := len(node.Kids)
+		pos2 := pos
+		// Space
+		if !_node(parser, _SpaceNode, node, &pos) {
+			goto fail4
+		}
+		continue
+	fail4:
+		node.Kids = node.Kids[:nkids1]
+		pos = pos2
+		break
+	}
+	// Number
+	if !_node(parser, _NumberNode, node, &pos) {
+		goto fail
+	}
+	// Sp
+	if !_node(parser, _SpNode, node, &pos) {
+		goto fail
+	}
+	// "{"
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "{" {
+		goto fail
+	}
+	node.Kids = append(node.Kids, _leaf(parser, pos, pos+1))
+	pos++
+	// Sp
+	if !_node(parser, _SpNode, node, &pos) {
+		goto fail
+	}
+	// (Stmt (Sp Stmt)*)?
+	{
+		nkids5 := len(node.Kids)
+		pos6 := pos
+		// (Stmt (Sp Stmt)*)
+		{
+			nkids8 := len(node.Kids)
+			pos09 := pos
+			// Stmt (Sp Stmt)*
+			// Stmt
+			if !_node(parser, _StmtNode, node, &pos) {
+				goto fail7
+			}
+			// (Sp Stmt)*
+			for {
+				nkids11 := len(node.Kids)
+				pos12 := pos
+				// (Sp Stmt)
+				{
+					nkids15 := len(node.Kids)
+					pos016 := pos
+					// Sp Stmt
+					// Sp
+					if !_node(parser, _SpNode, node, &pos) {
+						goto fail14
+					}
+					// Stmt
+					if !_node(parser, _StmtNode, node, &pos) {
+						goto fail14
+					}
+					sub := _sub(parser, pos016, pos, node.Kids[nkids15:])
+					node.Kids = append(node.Kids[:nkids15], sub)
+				}
+				continue
+			fail14:
+				node.Kids = node.Kids[:nkids11]
+				pos = pos12
+				break
+			}
+			sub := _sub(parser, pos09, pos, node.Kids[nkids8:])
+			node.Kids = append(node.Kids[:nkids8], sub)
+		}
+		goto ok18
+	fail7:
+		node.Kids = node.Kids[:nkids5]
+		pos = pos6
+	ok18:
+	}
+	// Sp
+	if !_node(parser, _SpNode, node, &pos) {
+		goto fail
+	}
+	// "}"
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "}" {
+		goto fail
+	}
+	node.Kids = append(node.Kids, _leaf(parser, pos, pos+1))
+	pos++
+	node.Text = parser.text[start:pos]
+	parser.node[key] = node
+	return pos, node
+fail:
+	return -1, nil
+}
+
+func _LoopFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _Loop, start, errPos)
+	if failure != nil {
+		return pos, failure
+	}
+	failure = &peg.Fail{
+		Name: "Loop",
+		Pos:  int(start),
+	}
+	key := _key{start: start, rule: _Loop}
+	// "loop" Space+ Number Sp "{" Sp (Stmt (Sp Stmt)*)? Sp "}"
+	// "loop"
+	if len(parser.text[pos:]) < 4 || parser.text[pos:pos+4] != "loop" {
+		if pos >= errPos {
+			failure.Kids = append(failure.Kids, &peg.Fail{
+				Pos:  int(pos),
+				Want: "\"loop\"",
+			})
+		}
+		goto fail
+	}
+	pos += 4
+	// Space+
+	// Space
+	if !_fail(parser, _SpaceFail, errPos, failure, &pos) {
+		goto fail
+	}
+	for {
+		pos2 := pos
+		// Space
+		if !_fail(parser, _SpaceFail, errPos, failure, &pos) {
+			goto fail4
+		}
+		continue
+	fail4:
+		pos = pos2
+		break
+	}
+	// Number
+	if !_fail(parser, _NumberFail, errPos, failure, &pos) {
+		goto fail
+	}
+	// Sp
+	if !_fail(parser, _SpFail, errPos, failure, &pos) {
+		goto fail
+	}
+	// "{"
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "{" {
+		if pos >= errPos {
+			failure.Kids = append(failure.Kids, &peg.Fail{
+				Pos:  int(pos),
+				Want: "\"{\"",
+			})
+		}
+		goto fail
+	}
+	pos++
+	// Sp
+	if !_fail(parser, _SpFail, errPos, failure, &pos) {
+		goto fail
+	}
+	// (Stmt (Sp Stmt)*)?
+	{
+		pos6 := pos
+		// (Stmt (Sp Stmt)*)
+		// Stmt (Sp Stmt)*
+		// Stmt
+		if !_fail(parser, _StmtFail, errPos, failure, &pos) {
+			goto fail7
+		}
+		// (Sp Stmt)*
+		for {
+			pos10 := pos
+			// (Sp Stmt)
+			// Sp Stmt
+			// Sp
+			if !_fail(parser, _SpFail, errPos, failure, &pos) {
+				goto fail12
+			}
+			// Stmt
+			if !_fail(parser, _StmtFail, errPos, failure, &pos) {
+				goto fail12
+			}
+			continue
+		fail12:
+			pos = pos10
+			break
+		}
+		goto ok14
+	fail7:
+		pos = pos6
+	ok14:
+	}
+	// Sp
+	if !_fail(parser, _SpFail, errPos, failure, &pos) {
+		goto fail
+	}
+	// "}"
+	if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "}" {
+		if pos >= errPos {
+			failure.Kids = append(failure.Kids, &peg.Fail{
+				Pos:  int(pos),
+				Want: "\"}\"",
+			})
+		}
+		goto fail
+	}
+	pos++
+	parser.fail[key] = failure
+	return pos, failure
+fail:
+	parser.fail[key] = failure
+	return -1, failure
+}
+
+func _LoopAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_Loop]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Loop}
+	n := parser.act[key]
+	if n != nil {
+		n := n.(string)
+		return start + int(dp-1), &n
+	}
+	var node string
+	pos := start
+	// "loop" Space+ Number Sp "{" Sp (Stmt (Sp Stmt)*)? Sp "}"
+	{
+		var node0 string
+		// "loop"
+		if len(parser.text[pos:]) < 4 || parser.text[pos:pos+4] != "loop" {
+			goto fail
+		}
+		node0 = parser.text[pos : pos+4]
+		pos += 4
+		node, node0 = node+node0, ""
+		// Space+
+		{
+			var node3 string
+			// Space
+			if p, n := _SpaceAction(parser, pos); n == nil {
+				goto fail
+			} else {
+				node3 = *n
+				pos = p
+			}
+			node0 += node3
+		}
+		for {
+			pos2 := pos
+			var node3 string
+			// Space
+			if p, n := _SpaceAction(parser, pos); n == nil {
+				goto fail4
+			} else {
+				node3 = *n
+				pos = p
+			}
+			node0 += node3
+			continue
+		fail4:
+			pos = pos2
+			break
+		}
+		node, node0 = node+node0, ""
+		// Number
+		if p, n := _NumberAction(parser, pos); n == nil {
+			goto fail
+		} else {
+			node0 = *n
+			pos = p
+		}
+		node, node0 = node+node0, ""
+		// Sp
+		if p, n := _SpAction(parser, pos); n == nil {
+			goto fail
+		} else {
+			node0 = *n
+			pos = p
+		}
+		node, node0 = node+node0, ""
+		// "{"
+		if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "{" {
+			goto fail
+		}
+		node0 = parser.text[pos : pos+1]
+		pos++
+		node, node0 = node+node0, ""
+		// Sp
+		if p, n := _SpAction(parser, pos); n == nil {
+			goto fail
+		} else {
+			node0 = *n
+			pos = p
+		}
+		node, node0 = node+node0, ""
+		// (Stmt (Sp Stmt)*)?
+		{
+			pos6 := pos
+			// (Stmt (Sp Stmt)*)
+			// Stmt (Sp Stmt)*
+			{
+				var node8 string
+				// Stmt
+				if p, n := _StmtAction(parser, pos); n == nil {
+					goto fail7
+				} else {
+					node8 = *n
+					pos = p
+				}
+				node0, node8 = node0+node8, ""
+				// (Sp Stmt)*
+				for {
+					pos10 := pos
+					var node11 string
+					// (Sp Stmt)
+					// Sp Stmt
+					{
+						var node13 string
+						// Sp
+						if p, n := _SpAction(parser, pos); n == nil {
+							goto fail12
+						} else {
+							node13 = *n
+							pos = p
+						}
+						node11, node13 = node11+node13, ""
+						// Stmt
+						if p, n := _StmtAction(parser, pos); n == nil {
+							goto fail12
+						} else {
+							node13 = *n
+							pos = p
+						}
+						node11, node13 = node11+node13, ""
+					}
+					node8 += node11
+					continue
+				fail12:
+					pos = pos10
+					break
+				}
+				node0, node8 = node0+node8, ""
+			}
+			goto ok14
+		fail7:
+			node0 = ""
+			pos = pos6
+		ok14:
+		}
+		node, node0 = node+node0, ""
+		// Sp
+		if p, n := _SpAction(parser, pos); n == nil {
+			goto fail
+		} else {
+			node0 = *n
+			pos = p
+		}
+		node, node0 = node+node0, ""
+		// "}"
+		if len(parser.text[pos:]) < 1 || parser.text[pos:pos+1] != "}" {
+			goto fail
+		}
+		node0 = parser.text[pos : pos+1]
+		pos++
+		node, node0 = node+node0, ""
+	}
+	parser.act[key] = node
+	return pos, &node
+fail:
+	return -1, nil
+}
+
+func _NumberAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _Number, start); ok {
+		return dp, de
+	}
+	pos, perr := start, -1
+	// [0-9]+
+	// [0-9]
+	if r, w := _next(parser, pos); r < '0' || r > '9' {
+		perr = _max(perr, pos)
+		goto fail
+	} else {
+		pos += w
+	}
+	for {
+		pos1 := pos
+		// [0-9]
+		if r, w := _next(parser, pos); r < '0' || r > '9' {
+			perr = _max(perr, pos)
+			goto fail3
+		} else {
+			pos += w
+		}
+		continue
+	fail3:
+		pos = pos1
+		break
+	}
+	return _memoize(parser, _Number, start, pos, perr)
+fail:
+	return _memoize(parser, _Number, start, -1, perr)
+}
+
+func _NumberNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_Number]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Number}
+	node := parser.node[key]
+	if node != nil {
+		return start + int(dp-1), node
+	}
+	pos := start
+	node = &peg.Node{Name: "Number"}
+	// [0-9]+
+	// [0-9]
+	if r, w := _next(parser, pos); r < '0' || r > '9' {
+		goto fail
+	} else {
+		node.Kids = append(node.Kids, _leaf(parser, pos, pos+w))
+		pos += w
+	}
+	for {
+		nkids0 := len(node.Kids)
+		pos1 := pos
+		// [0-9]
+		if r, w := _next(parser, pos); r < '0' || r > '9' {
+			goto fail3
+		} else {
+			node.Kids = append(node.Kids, _leaf(parser, pos, pos+w))
+			pos += w
+		}
+		continue
+	fail3:
+		node.Kids = node.Kids[:nkids0]
+		pos = pos1
+		break
+	}
+	node.Text = parser.text[start:pos]
+	parser.node[key] = node
+	return pos, node
+fail:
+	return -1, nil
+}
+
+func _NumberFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _Number, start, errPos)
+	if failure != nil {
+		return pos, failure
+	}
+	failure = &peg.Fail{
+		Name: "Number",
+		Pos:  int(start),
+	}
+	key := _key{start: start, rule: _Number}
+	// [0-9]+
+	// [0-9]
+	if r, w := _next(parser, pos); r < '0' || r > '9' {
+		if pos >= errPos {
+			failure.Kids = append(failure.Kids, &peg.Fail{
+				Pos:  int(pos),
+				Want: "[0-9]",
+			})
+		}
+		goto fail
+	} else {
+		pos += w
+	}
+	for {
+		pos1 := pos
+		// [0-9]
+		if r, w := _next(parser, pos); r < '0' || r > '9' {
+			if pos >= errPos {
+				failure.Kids = append(failure.Kids, &peg.Fail{
+					Pos:  int(pos),
+					Want: "[0-9]",
+				})
+			}
+			goto fail3
+		} else {
+			pos += w
+		}
+		continue
+	fail3:
+		pos = pos1
+		break
+	}
+	parser.fail[key] = failure
+	return pos, failure
+fail:
+	parser.fail[key] = failure
+	return -1, failure
+}
+
+func _NumberAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_Number]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Number}
+	n := parser.act[key]
+	if n != nil {
+		n := n.(string)
+		return start + int(dp-1), &n
+	}
+	var node string
+	pos := start
+	// [0-9]+
+	{
+		var node2 string
+		// [0-9]
+		if r, w := _next(parser, pos); r < '0' || r > '9' {
+			goto fail
+		} else {
+			node2 = parser.text[pos : pos+w]
+			pos += w
+		}
+		node += node2
+	}
+	for {
+		pos1 := pos
+		var node2 string
+		// [0-9]
+		if r, w := _next(parser, pos); r < '0' || r > '9' {
+			goto fail3
+		} else {
+			node2 = parser.text[pos : pos+w]
+			pos += w
+		}
+		node += node2
+		continue
+	fail3:
+		pos = pos1
+		break
+	}
+	parser.act[key] = node
+	return pos, &node
+fail:
+	return -1, nil
+}
+
+func _SpAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _Sp, start); ok {
+		return dp, de
+	}
+	pos, perr := start, -1
+	// Space*
+	for {
+		pos1 := pos
+		// Space
+		if !_accept(parser, _SpaceAccepts, &pos, &perr) {
+			goto fail3
+		}
+		continue
+	fail3:
+		pos = pos1
+		break
+	}
+	return _memoize(parser, _Sp, start, pos, perr)
+}
+
+func _SpNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_Sp]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Sp}
+	node := parser.node[key]
+	if node != nil {
+		return start + int(dp-1), node
+	}
+	pos := start
+	node = &peg.Node{Name: "Sp"}
+	// Space*
+	for {
+		nkids0 := len(node.Kids)
+		pos1 := pos
+		// Space
+		if !_node(parser, _SpaceNode, node, &pos) {
+			goto fail3
+		}
+		continue
+	fail3:
+		node.Kids = node.Kids[:nkids0]
+		pos = pos1
+		break
+	}
+	node.Text = parser.text[start:pos]
+	parser.node[key] = node
+	return pos, node
+}
+
+func _SpFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _Sp, start, errPos)
+	if failure != nil {
+		return pos, failure
+	}
+	failure = &peg.Fail{
+		Name: "Sp",
+		Pos:  int(start),
+	}
+	key := _key{start: start, rule: _Sp}
+	// Space*
+	for {
+		pos1 := pos
+		// Space
+		if !_fail(parser, _SpaceFail, errPos, failure, &pos) {
+			goto fail3
+		}
+		continue
+	fail3:
+		pos = pos1
+		break
+	}
+	parser.fail[key] = failure
+	return pos, failure
+}
+
+func _SpAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_Sp]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Sp}
+	n := parser.act[key]
+	if n != nil {
+		n := n.(string)
+		return start + int(dp-1), &n
+	}
+	var node string
+	pos := start
+	// Space*
+	for {
+		pos1 := pos
+		var node2 string
+		// Space
+		if p, n := _SpaceAction(parser, pos); n == nil {
+			goto fail3
+		} else {
+			node2 = *n
+			pos = p
+		}
+		node += node2
+		continue
+	fail3:
+		pos = pos1
+		break
+	}
+	parser.act[key] = node
+	return pos, &node
+}
+
+func _SpaceAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _Space, start); ok {
+		return dp, de
+	}
+	pos, perr := start, -1
+	// [ \t\r\n]
+	if r, w := _next(parser, pos); r != ' ' && r != '\t' && r != '\r' && r != '\n' {
+		perr = _max(perr, pos)
+		goto fail
+	} else {
+		pos += w
+	}
+	return _memoize(parser, _Space, start, pos, perr)
+fail:
+	return _memoize(parser, _Space, start, -1, perr)
+}
+
+func _SpaceNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_Space]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Space}
+	node := parser.node[key]
+	if node != nil {
+		return start + int(dp-1), node
+	}
+	pos := start
+	node = &peg.Node{Name: "Space"}
+	// [ \t\r\n]
+	if r, w := _next(parser, pos); r != ' ' && r != '\t' && r != '\r' && r != '\n' {
+		goto fail
+	} else {
+		node.Kids = append(node.Kids, _leaf(parser, pos, pos+w))
+		pos += w
+	}
+	node.Text = parser.text[start:pos]
+	parser.node[key] = node
+	return pos, node
+fail:
+	return -1, nil
+}
+
+func _SpaceFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _Space, start, errPos)
+	if failure != nil {
+		return pos, failure
+	}
+	failure = &peg.Fail{
+		Name: "Space",
+		Pos:  int(start),
+	}
+	key := _key{start: start, rule: _Space}
+	// [ \t\r\n]
+	if r, w := _next(parser, pos); r != ' ' && r != '\t' && r != '\r' && r != '\n' {
+		if pos >= errPos {
+			failure.Kids = append(failure.Kids, &peg.Fail{
+				Pos:  int(pos),
+				Want: "[ \\t\\r\\n]",
+			})
+		}
+		goto fail
+	} else {
+		pos += w
+	}
+	parser.fail[key] = failure
+	return pos, failure
+fail:
+	parser.fail[key] = failure
+	return -1, failure
+}
+
+func _SpaceAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_Space]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _Space}
+	n := parser.act[key]
+	if n != nil {
+		n := n.(string)
+		return start + int(dp-1), &n
+	}
+	var node string
+	pos := start
+	// [ \t\r\n]
+	if r, w := _next(parser, pos); r != ' ' && r != '\t' && r != '\r' && r != '\n' {
+		goto fail
+	} else {
+		node = parser.text[pos : pos+w]
+		pos += w
+	}
+	parser.act[key] = node
+	return pos, &node
+fail:
+	return -1, nil
+}
+
+func _EOFAccepts(parser *_Parser, start int) (deltaPos, deltaErr int) {
+	if dp, de, ok := _memo(parser, _EOF, start); ok {
+		return dp, de
+	}
+	pos, perr := start, -1
+	// !.
+	{
+		pos1 := pos
+		perr3 := perr
+		// .
+		if r, w := _next(parser, pos); w == 0 || r == '\uFFFD' {
+			perr = _max(perr, pos)
+			goto ok0
+		} else {
+			pos += w
+		}
+		pos = pos1
+		perr = _max(perr3, pos)
+		goto fail
+	ok0:
+		pos = pos1
+		perr = perr3
+	}
+	return _memoize(parser, _EOF, start, pos, perr)
+fail:
+	return _memoize(parser, _EOF, start, -1, perr)
+}
+
+func _EOFNode(parser *_Parser, start int) (int, *peg.Node) {
+	dp := parser.deltaPos[start][_EOF]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _EOF}
+	node := parser.node[key]
+	if node != nil {
+		return start + int(dp-1), node
+	}
+	pos := start
+	node = &peg.Node{Name: "EOF"}
+	// !.
+	{
+		pos1 := pos
+		nkids2 := len(node.Kids)
+		// .
+		if r, w := _next(parser, pos); w == 0 || r == '\uFFFD' {
+			goto ok0
+		} else {
+			node.Kids = append(node.Kids, _leaf(parser, pos, pos+w))
+			pos += w
+		}
+		pos = pos1
+		node.Kids = node.Kids[:nkids2]
+		goto fail
+	ok0:
+		pos = pos1
+		node.Kids = node.Kids[:nkids2]
+	}
+	node.Text = parser.text[start:pos]
+	parser.node[key] = node
+	return pos, node
+fail:
+	return -1, nil
+}
+
+func _EOFFail(parser *_Parser, start, errPos int) (int, *peg.Fail) {
+	pos, failure := _failMemo(parser, _EOF, start, errPos)
+	if failure != nil {
+		return pos, failure
+	}
+	failure = &peg.Fail{
+		Name: "EOF",
+		Pos:  int(start),
+	}
+	key := _key{start: start, rule: _EOF}
+	// !.
+	{
+		pos1 := pos
+		nkids2 := len(failure.Kids)
+		// .
+		if r, w := _next(parser, pos); w == 0 || r == '\uFFFD' {
+			if pos >= errPos {
+				failure.Kids = append(failure.Kids, &peg.Fail{
+					Pos:  int(pos),
+					Want: ".",
+				})
+			}
+			goto ok0
+		} else {
+			pos += w
+		}
+		pos = pos1
+		failure.Kids = failure.Kids[:nkids2]
+		if pos >= errPos {
+			failure.Kids = append(failure.Kids, &peg.Fail{
+				Pos:  int(pos),
+				Want: "!.",
+			})
+		}
+		goto fail
+	ok0:
+		pos = pos1
+		failure.Kids = failure.Kids[:nkids2]
+	}
+	parser.fail[key] = failure
+	return pos, failure
+fail:
+	parser.fail[key] = failure
+	return -1, failure
+}
+
+func _EOFAction(parser *_Parser, start int) (int, *string) {
+	dp := parser.deltaPos[start][_EOF]
+	if dp < 0 {
+		return -1, nil
+	}
+	key := _key{start: start, rule: _EOF}
+	n := parser.act[key]
+	if n != nil {
+		n := n.(string)
+		return start + int(dp-1), &n
+	}
+	var node string
+	pos := start
+	// !.
+	{
+		pos1 := pos
+		// .
+		if r, w := _next(parser, pos); w == 0 || r == '\uFFFD' {
+			goto ok0
+		} else {
+			pos += w
+		}
+		pos = pos1
+		goto fail
+	ok0:
+		pos = pos1
+		node = ""
+	}
+	parser.act[key] = node
+	return pos, &node
+fail:
+	return -1, nil
+}
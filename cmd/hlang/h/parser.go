@@ -16,18 +16,18 @@ var (
 )
 
 func (p *_Parser) Parse() (int, bool) {
-	pos, perr := _HAccepts(p, 0)
+	pos, perr := _ProgramAccepts(p, 0)
 	return perr, pos >= 0
 }
 
 func (p *_Parser) ErrorTree(minPos int) *peg.Fail {
 	p.fail = make(map[_key]*peg.Fail) // reset fail memo table
-	_, tree := _HFail(p, 0, minPos)
+	_, tree := _ProgramFail(p, 0, minPos)
 	return tree
 }
 
 func (p *_Parser) ParseTree() *peg.Node {
-	_, tree := _HNode(p, 0)
+	_, tree := _ProgramNode(p, 0)
 	return tree
 }
 
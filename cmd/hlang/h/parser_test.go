@@ -8,6 +8,45 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParseSyntax(t *testing.T) {
+	for _, src := range []string{
+		"",
+		"h",
+		"' h\th\n'",
+		`"hello, world\n"`,
+		`"escapes: \" \\ \n"`,
+		`print()`,
+		`print("h" h ')`,
+		"loop 3 { h }",
+		"loop 0 {}",
+		"loop 2 {\n\tloop 2 { print(h) }\n}",
+	} {
+		if _, err := Parse(src); err != nil {
+			t.Errorf("Parse(%q): %v", src, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{
+		"a",
+		`"unterminated`,
+		"\"new\nline\"",
+		`"\t"`,
+		"print(",
+		"print(loop 1 { h })",
+		"loop { h }",
+		"loop -1 { h }",
+		"loop3 { h }",
+		"loop 3 { h",
+		"h }",
+	} {
+		if _, err := Parse(src); err == nil {
+			t.Errorf("Parse(%q): want an error", src)
+		}
+	}
+}
+
 func BenchmarkParse(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		if _, err := Parse("h h h"); err != nil {
@@ -27,7 +27,7 @@ func removeSpace(n *peg.Node) bool {
 }
 
 // SpaceChars is the string of all whitespace characters.
-const SpaceChars = "\x20"
+const SpaceChars = "\x20\t\r\n"
 
 func whitespace(s string) bool {
 	for _, r := range s {
@@ -38,22 +38,22 @@ func whitespace(s string) bool {
 	return true
 }
 
-// CollapseLists collapses chains of single-kid nodes.
+// CollapseLists flattens anonymous groups into their parents and collapses
+// chains of single-kid nodes, such as Stmt{H{"h"}} into H{"h"}. Named nodes
+// are never collapsed into anonymous ones, so that every statement keeps the
+// name of the rule that matched it.
 func CollapseLists(n *peg.Node) {
-	if collapseLists(n) == 1 {
-		n.Kids = n.Kids[0].Kids
-	}
-}
-
-func collapseLists(n *peg.Node) int {
 	var kids []*peg.Node
 	for _, k := range n.Kids {
-		if gk := collapseLists(k); gk == 1 {
+		CollapseLists(k)
+		switch {
+		case k.Name == "" && len(k.Kids) > 0:
+			kids = append(kids, k.Kids...)
+		case len(k.Kids) == 1 && k.Kids[0].Name != "":
 			kids = append(kids, k.Kids[0])
-		} else {
+		default:
 			kids = append(kids, k)
 		}
 	}
 	n.Kids = kids
-	return len(n.Kids)
 }
@@ -147,20 +147,33 @@ func faqPage() templ.Component {
 			templ_7745c5c3_Var5 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<h2>What are the instructions of h?</h2><p>h supports the following instructions:</p><ul><li><code>h</code></li><li><code>'</code></li></ul><p>All valid h instructions must be separated by a space (<code>\\0x20</code> or the spacebar on your computer). No other forms of whitespace are permitted. Any other characters will render your program <a href=\"http://jbovlaste.lojban.org/dict/gentoldra\">gentoldra</a>.</p><h2>How do I install and use h?</h2><p>With any computer running <a href=\"https://golang.org\">Go</a> 1.11 or higher:</p><code><pre>go get -u -v within.website/x/cmd/hlang</pre></code> Usage is simple: <code><pre>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<h2>What are the instructions of h?</h2><p>h supports the following instructions:</p><ul><li><code>h</code></li><li><code>'</code></li><li><code>\"strings\"</code>, which may contain <code>\\n</code>, <code>\\\"</code> and <code>\\\\</code></li><li><code>print(\"h\" ')</code>, which writes its arguments and a newline</li><li><code>loop 3 &#123; h &#125;</code>, which runs its body that many times</li></ul><p>Instructions may be separated by spaces, tabs or newlines. Any other characters will render your program <a href=\"http://jbovlaste.lojban.org/dict/gentoldra\">gentoldra</a>.</p><h2>Is there an API?</h2><p>POST a JSON object like <code>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var6 string
-		templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(usage)
+		templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(`{"src": "h"}`)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `hlang.templ`, Line: 98, Col: 19}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `hlang.templ`, Line: 98, Col: 49}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</pre></code><h2>What version is h?</h2><p>Version 1.0.1, this will hopefully be the only release.</p><h2>What is the h koan?</h2><p>And Jesus said unto the theologians, \"Who do you say that I am?\"</p><p>They replied: \"You are the eschatological manifestation of the ground of our being, the kerygma of which we find the ultimate meaning in our interpersonal relationships.\"</p><p>And Jesus said \"...What?\"</p><p>Some time passed and one of them spoke \"h\".</p><p>Jesus was enlightened.</p><h2>Why?</h2><p>That's a good question. The following blogposts may help you understand this more:</p><ul><li><a href=\"https://xeiaso.net/blog/the-origin-of-h-2015-12-14\">The Origin of h</a></li><li><a href=\"https://xeiaso.net/blog/formal-grammar-of-h-2019-05-19\">A Formal Grammar of h</a></li></ul><h2>Who wrote h?</h2><p><a href=\"https://xeiaso.net\">Within</a></p>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</code> to <code>/api/v1/play</code>. You get back the compiled WebAssembly (base64 in <code>wasm</code>), its text format in <code>wat</code>, the program's output in <code>out</code> and how long compiling and running took in nanoseconds.</p><h2>How do I install and use h?</h2><p>With any computer running <a href=\"https://golang.org\">Go</a> 1.11 or higher:</p><code><pre>go get -u -v within.website/x/cmd/hlang</pre></code> Usage is simple: <code><pre>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var7 string
+		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(usage)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `hlang.templ`, Line: 103, Col: 19}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</pre></code><h2>What version is h?</h2><p>Version 1.0.1, this will hopefully be the only release.</p><h2>What is the h koan?</h2><p>And Jesus said unto the theologians, \"Who do you say that I am?\"</p><p>They replied: \"You are the eschatological manifestation of the ground of our being, the kerygma of which we find the ultimate meaning in our interpersonal relationships.\"</p><p>And Jesus said \"...What?\"</p><p>Some time passed and one of them spoke \"h\".</p><p>Jesus was enlightened.</p><h2>Why?</h2><p>That's a good question. The following blogposts may help you understand this more:</p><ul><li><a href=\"https://xeiaso.net/blog/the-origin-of-h-2015-12-14\">The Origin of h</a></li><li><a href=\"https://xeiaso.net/blog/formal-grammar-of-h-2019-05-19\">A Formal Grammar of h</a></li></ul><h2>Who wrote h?</h2><p><a href=\"https://xeiaso.net\">Within</a></p>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -184,12 +197,12 @@ func playgroundPage() templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var7 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var7 == nil {
-			templ_7745c5c3_Var7 = templ.NopComponent
+		templ_7745c5c3_Var8 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var8 == nil {
+			templ_7745c5c3_Var8 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "<p><small>Unfortunately, Javascript is required to use this page, sorry.</small></p><h2>Program</h2><input id=\"program\" type=\"text\" value=\"h\"> <input onClick=\"runProgram()\" type=\"button\" value=\"Run\"><p id=\"status\"></p><h3>Output</h3><code><pre id=\"output\"></pre></code><h4>AST</h4><code><pre id=\"ast_box\"></pre></code><p>Execution time (nanoseconds): <span id=\"exec_time\"></span></p><script>\n      function runProgram() {\n        const programData = document.getElementById(\"program\").value;\n        const output = document.getElementById(\"output\");\n        const astBox = document.getElementById(\"ast_box\");\n        const execTime = document.getElementById(\"exec_time\");\n        const status = document.getElementById(\"status\");\n\n        status.innerHTML = \"submitting to the server...\";\n\n        postData(\"/api/playground\", programData)\n          .then(function(data) {\n             if (data.err != null) {\n               status.innerHTML = data.err;\n               return;\n             }\n\n             status.innerHTML = \"success\";\n             astBox.innerHTML = data.prog.ast;\n             output.innerHTML = data.res.out;\n             execTime.innerHTML = data.res.exec_duration;\n          })\n          .catch(function(error) {\n             console.log(error);\n             status.innerHTML = error + \". Please try again later?\";\n          });\n      }\n\n      function postData(url = \"\", data = \"h\") {\n        return fetch(url, {\n          method: \"POST\",\n          mode: \"cors\",\n          cache: \"no-cache\",\n          headers: {\n            \"Content-Type\": \"text/plain\",\n          },\n          referrer: \"no-referrer\",\n          body: data,\n        }).then(response => response.json());\n      }\n      </script>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "<p><small>Unfortunately, Javascript is required to use this page, sorry.</small></p><h2>Program</h2><input id=\"program\" type=\"text\" value=\"h\"> <input onClick=\"runProgram()\" type=\"button\" value=\"Run\"><p id=\"status\"></p><h3>Output</h3><code><pre id=\"output\"></pre></code><h4>AST</h4><code><pre id=\"ast_box\"></pre></code><p>Execution time (nanoseconds): <span id=\"exec_time\"></span></p><script>\n      function runProgram() {\n        const programData = document.getElementById(\"program\").value;\n        const output = document.getElementById(\"output\");\n        const astBox = document.getElementById(\"ast_box\");\n        const execTime = document.getElementById(\"exec_time\");\n        const status = document.getElementById(\"status\");\n\n        status.innerHTML = \"submitting to the server...\";\n\n        postData(\"/api/playground\", programData)\n          .then(function(data) {\n             if (data.err != null) {\n               status.innerHTML = data.err;\n               return;\n             }\n\n             status.innerHTML = \"success\";\n             astBox.innerHTML = data.prog.ast;\n             output.innerHTML = data.res.out;\n             execTime.innerHTML = data.res.exec_duration;\n          })\n          .catch(function(error) {\n             console.log(error);\n             status.innerHTML = error + \". Please try again later?\";\n          });\n      }\n\n      function postData(url = \"\", data = \"h\") {\n        return fetch(url, {\n          method: \"POST\",\n          mode: \"cors\",\n          cache: \"no-cache\",\n          headers: {\n            \"Content-Type\": \"text/plain\",\n          },\n          referrer: \"no-referrer\",\n          body: data,\n        }).then(response => response.json());\n      }\n      </script>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -2,12 +2,14 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/a-h/templ"
 	"github.com/rs/cors"
@@ -19,15 +21,30 @@ import (
 //go:generate go tool templ generate
 
 var (
-	maxBytes = flag.Int64("max-playground-bytes", 75, "how many bytes of data should users be allowed to post to the playground?")
+	maxBytes       = flag.Int64("max-playground-bytes", 75, "how many bytes of data should users be allowed to post to the playground?")
+	maxExecTime    = flag.Duration("max-exec-time", time.Second, "how long may playground programs run for?")
+	maxOutputBytes = flag.Int("max-output-bytes", 4096, "how many bytes of output may playground programs write?")
 )
 
+// maxMemoryPages is how much memory playground programs may have. One page
+// is far more than any h program needs.
+const maxMemoryPages = 1
+
+func playgroundLimits() run.Limits {
+	return run.Limits{
+		Timeout:        *maxExecTime,
+		MaxOutput:      *maxOutputBytes,
+		MaxMemoryPages: maxMemoryPages,
+	}
+}
+
 func doHTTP() error {
 	http.Handle("/{$}", templ.Handler(xess.Base("The h Programming Language", nil, navbar(), homePage(), footer())))
 	http.Handle("/docs", templ.Handler(xess.Base("Documentation", nil, navbar(), docsPage(), footer())))
 	http.Handle("/faq", templ.Handler(xess.Base("FAQ", nil, navbar(), faqPage(), footer())))
 	http.Handle("/play", templ.Handler(xess.Base("Playground", nil, navbar(), playgroundPage(), footer())))
 	http.HandleFunc("/api/playground", runPlayground)
+	http.HandleFunc("POST /api/v1/play", apiPlay)
 
 	http.Handle("/grammar/", http.StripPrefix("/grammar/", http.FileServer(http.FS(h.Grammar))))
 
@@ -82,7 +99,7 @@ func runPlayground(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	er, err := run.Run(comp.Binary)
+	er, err := run.RunContext(r.Context(), comp.Binary, playgroundLimits())
 	if err != nil {
 		httpError(w, fmt.Errorf("runtime error: %v", err), http.StatusInternalServerError)
 		return
@@ -98,6 +115,73 @@ func runPlayground(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// PlayRequest is the body of a request to /api/v1/play.
+type PlayRequest struct {
+	Source string `json:"src"`
+}
+
+// PlayResponse is the result of compiling and running a program with
+// /api/v1/play. Durations are in nanoseconds.
+type PlayResponse struct {
+	Source      string        `json:"src"`
+	AST         string        `json:"ast"`
+	WASM        []byte        `json:"wasm"`
+	WAT         string        `json:"wat"`
+	Size        int           `json:"size"`
+	Output      string        `json:"out"`
+	Truncated   bool          `json:"truncated"`
+	CompileTime time.Duration `json:"compile_time"`
+	ExecTime    time.Duration `json:"exec_time"`
+}
+
+func apiPlay(w http.ResponseWriter, r *http.Request) {
+	// JSON escaping can make each byte of source up to six bytes long.
+	rc := http.MaxBytesReader(w, r.Body, *maxBytes*6+1024)
+	defer rc.Close()
+
+	var req PlayRequest
+	if err := json.NewDecoder(rc).Decode(&req); err != nil {
+		httpError(w, fmt.Errorf("can't parse request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if int64(len(req.Source)) > *maxBytes {
+		httpError(w, fmt.Errorf("program is longer than %d bytes", *maxBytes), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	t0 := time.Now()
+	comp, err := compile(req.Source)
+	if err != nil {
+		httpError(w, fmt.Errorf("compilation error: %v", err), http.StatusBadRequest)
+		return
+	}
+	compileTime := time.Since(t0)
+
+	er, err := run.RunContext(r.Context(), comp.Binary, playgroundLimits())
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, run.ErrTimeout) {
+			code = http.StatusUnprocessableEntity
+		}
+		httpError(w, fmt.Errorf("runtime error: %v", err), code)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PlayResponse{
+		Source:      comp.Source,
+		AST:         comp.AST,
+		WASM:        comp.Binary,
+		WAT:         comp.WAT,
+		Size:        len(comp.Binary),
+		Output:      er.Output,
+		Truncated:   er.Truncated,
+		CompileTime: compileTime,
+		ExecTime:    er.ExecTime,
+	})
+}
+
 const usage = `Usage of hlang:
 -config string
       configuration file, if set (see flagconfyg(4))
@@ -107,6 +191,10 @@ const usage = `Usage of hlang:
       show software licenses?
 -manpage
       generate a manpage template?
+-max-exec-time duration
+      how long may playground programs run for? (default 1s)
+-max-output-bytes int
+      how many bytes of output may playground programs write? (default 4096)
 -max-playground-bytes int
       how many bytes of data should users be allowed to post to the playground? (default 75)
 -o string
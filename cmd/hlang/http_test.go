@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func play(t *testing.T, body string) (int, map[string]any) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	apiPlay(w, httptest.NewRequest(http.MethodPost, "/api/v1/play", strings.NewReader(body)))
+
+	var result map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+		t.Fatalf("can't decode response: %v", err)
+	}
+
+	return w.Code, result
+}
+
+func TestAPIPlay(t *testing.T) {
+	code, resp := play(t, `{"src": "print(h \"i\")"}`)
+	if code != http.StatusOK {
+		t.Fatalf("got status %d: %v", code, resp)
+	}
+
+	if resp["out"] != "hi\n\n" {
+		t.Errorf("got output %q", resp["out"])
+	}
+	if !strings.HasPrefix(resp["wat"].(string), "(module") {
+		t.Errorf("got bad WAT: %v", resp["wat"])
+	}
+	if resp["wasm"] == "" || resp["size"].(float64) == 0 {
+		t.Errorf("got no WebAssembly: %v", resp)
+	}
+}
+
+func TestAPIPlayLimits(t *testing.T) {
+	code, resp := play(t, `{"src": "loop 100000 { h h h }"}`)
+	if code != http.StatusOK {
+		t.Fatalf("got status %d: %v", code, resp)
+	}
+	if resp["truncated"] != true || len(resp["out"].(string)) != *maxOutputBytes {
+		t.Errorf("want output truncated to %d bytes, got %d", *maxOutputBytes, len(resp["out"].(string)))
+	}
+
+	if code, resp := play(t, `{"src": "`+strings.Repeat("h ", int(*maxBytes))+`"}`); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got status %d for a long program: %v", code, resp)
+	}
+
+	if code, resp := play(t, `{"src": "hello"}`); code != http.StatusBadRequest {
+		t.Errorf("got status %d for a bad program: %v", code, resp)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/eaburns/peggy/peg"
 	"github.com/go-interpreter/wagon/wasm/leb128"
@@ -21,8 +22,10 @@ const (
 	SectionTypeType   = 0x01
 	SectionTypeImport = 0x02
 	SectionTypeFunc   = 0x03
+	SectionTypeMemory = 0x05
 	SectionTypeExport = 0x07
 	SectionTypeCode   = 0x0a
+	SectionTypeData   = 0x0b
 )
 
 // Commit turns a section into WebAssembly bytecode
@@ -50,19 +53,220 @@ const (
 	WASMi32Type = 0x7f
 )
 
+// Compile compiles an h parse tree to a WebAssembly module.
 func Compile(tree *peg.Node) ([]byte, error) {
+	m, err := Build(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Binary()
+}
+
+// Module is a compiled h program that can be written as WebAssembly or as
+// its text format.
+type Module struct {
+	// main is the body of the exported function h.
+	main []instr
+	// locals is how many i32 locals main has.
+	locals int
+	// data is the contents of linear memory, used by the write helper. If
+	// it is empty the module has no memory or helper.
+	data []byte
+}
+
+// Build compiles an h parse tree. Folded writes are either inlined as a
+// call to h per byte or stored in memory and written with a helper
+// function, whichever makes the smaller module.
+func Build(tree *peg.Node) (*Module, error) {
+	ops, err := lower(tree)
+	if err != nil {
+		return nil, err
+	}
+	ops = fold(ops)
+
+	result := codegen(ops, false)
+
+	withData := codegen(ops, true)
+	if len(withData.data) != 0 {
+		a, err := result.Binary()
+		if err != nil {
+			return nil, err
+		}
+		b, err := withData.Binary()
+		if err != nil {
+			return nil, err
+		}
+		if len(b) < len(a) {
+			result = withData
+		}
+	}
+
+	return result, nil
+}
+
+// Locals of main. Loop counters come after these.
+const (
+	localNewline = 0
+	localH       = 1
+	localQuote   = 2
+	localLoops   = 3
+)
+
+// Functions in the module.
+const (
+	funcPutchar = 0 // imported from h.h
+	funcMain    = 1 // exported as h
+	funcWrite   = 2 // writes a string from memory
+)
+
+// minDataWrite is the shortest write that is stored in memory when the
+// module has memory.
+const minDataWrite = 4
+
+func codegen(ops []op, useData bool) *Module {
+	m := &Module{locals: localLoops + depth(ops)}
+
+	m.emit(
+		instr{op: opI32Const, arg: '\n'},
+		instr{op: opLocalSet, arg: localNewline},
+		instr{op: opI32Const, arg: 'h'},
+		instr{op: opLocalSet, arg: localH},
+		instr{op: opI32Const, arg: '\''},
+		instr{op: opLocalSet, arg: localQuote},
+	)
+
+	m.ops(ops, 0, useData)
+
+	// finally print newline
+	m.emit(
+		instr{op: opLocalGet, arg: localNewline},
+		instr{op: opCall, arg: funcPutchar},
+		// two padding NOPs to get a single `h` binary to be 69 bytes.
+		instr{op: opNop},
+		instr{op: opNop},
+	)
+
+	return m
+}
+
+func (m *Module) emit(is ...instr) {
+	m.main = append(m.main, is...)
+}
+
+func (m *Module) ops(ops []op, loopDepth int, useData bool) {
+	for _, o := range ops {
+		if o.isLoop() {
+			counter := int32(localLoops + loopDepth)
+			m.emit(
+				instr{op: opI32Const, arg: o.count},
+				instr{op: opLocalSet, arg: counter},
+				instr{op: opBlock},
+				instr{op: opLoop},
+				instr{op: opLocalGet, arg: counter},
+				instr{op: opI32Eqz},
+				instr{op: opBrIf, arg: 1},
+			)
+			m.ops(o.body, loopDepth+1, useData)
+			m.emit(
+				instr{op: opLocalGet, arg: counter},
+				instr{op: opI32Const, arg: 1},
+				instr{op: opI32Sub},
+				instr{op: opLocalSet, arg: counter},
+				instr{op: opBr, arg: 0},
+				instr{op: opEnd},
+				instr{op: opEnd},
+			)
+			continue
+		}
+
+		if useData && len(o.write) >= minDataWrite {
+			m.emit(
+				instr{op: opI32Const, arg: m.store(o.write)},
+				instr{op: opI32Const, arg: int32(len(o.write))},
+				instr{op: opCall, arg: funcWrite},
+			)
+			continue
+		}
+
+		for _, b := range o.write {
+			switch b {
+			case '\n':
+				m.emit(instr{op: opLocalGet, arg: localNewline})
+			case 'h':
+				m.emit(instr{op: opLocalGet, arg: localH})
+			case '\'':
+				m.emit(instr{op: opLocalGet, arg: localQuote})
+			default:
+				m.emit(instr{op: opI32Const, arg: int32(b)})
+			}
+			m.emit(instr{op: opCall, arg: funcPutchar})
+		}
+	}
+}
+
+// store puts a string in memory and returns its address. Strings that are
+// already in memory are reused.
+func (m *Module) store(s []byte) int32 {
+	if i := bytes.Index(m.data, s); i >= 0 {
+		return int32(i)
+	}
+
+	m.data = append(m.data, s...)
+	return int32(len(m.data) - len(s))
+}
+
+// writeHelper is the body of the function that writes len bytes of memory
+// starting at ptr, its parameters 0 and 1.
+var writeHelper = []instr{
+	{op: opBlock},
+	{op: opLoop},
+	{op: opLocalGet, arg: 1},
+	{op: opI32Eqz},
+	{op: opBrIf, arg: 1},
+	{op: opLocalGet, arg: 0},
+	{op: opI32Load8U},
+	{op: opCall, arg: funcPutchar},
+	{op: opLocalGet, arg: 0},
+	{op: opI32Const, arg: 1},
+	{op: opI32Add},
+	{op: opLocalSet, arg: 0},
+	{op: opLocalGet, arg: 1},
+	{op: opI32Const, arg: 1},
+	{op: opI32Sub},
+	{op: opLocalSet, arg: 1},
+	{op: opBr, arg: 0},
+	{op: opEnd},
+	{op: opEnd},
+}
+
+const wasmPageSize = 65536
+
+// Binary returns the module as WebAssembly.
+func (m *Module) Binary() ([]byte, error) {
 	out := bytes.NewBuffer([]byte{
 		// WebAssembly binary header / magic numbers
 		0x00, 0x61, 0x73, 0x6d, // \0asm wasm magic number
 		0x01, 0x00, 0x00, 0x00, // version 1
 	})
 
+	hasData := len(m.data) != 0
+
 	typeS := &Section{Kind: SectionTypeType}
+	if hasData {
+		typeS.Data.WriteByte(0x03) // 3 entries
+	} else {
+		typeS.Data.WriteByte(0x02) // 2 entries
+	}
 	typeS.Data.Write([]byte{
-		0x02,                                // 2 entries
 		ExportFunc, 0x01, WASMi32Type, 0x00, // function type 0, 1 i32 param, 0 return
 		ExportFunc, 0x00, 0x00, // function type 1, 0 param, 0 return
 	})
+	if hasData {
+		typeS.Data.Write([]byte{
+			ExportFunc, 0x02, WASMi32Type, WASMi32Type, 0x00, // function type 2, 2 i32 params, 0 return
+		})
+	}
 
 	if err := typeS.Commit(out); err != nil {
 		return nil, fmt.Errorf("can't write type section: %v", err)
@@ -82,15 +286,36 @@ func Compile(tree *peg.Node) ([]byte, error) {
 	}
 
 	funcS := &Section{Kind: SectionTypeFunc}
-	funcS.Data.Write([]byte{
-		0x01, // function 1
-		0x01, // type 1
-	})
+	if hasData {
+		funcS.Data.Write([]byte{
+			0x02, // 2 functions
+			0x01, // function 1 is type 1
+			0x02, // function 2 is type 2
+		})
+	} else {
+		funcS.Data.Write([]byte{
+			0x01, // function 1
+			0x01, // type 1
+		})
+	}
 
 	if err := funcS.Commit(out); err != nil {
 		return nil, fmt.Errorf("can't write func section: %v", err)
 	}
 
+	if hasData {
+		memS := &Section{Kind: SectionTypeMemory}
+		memS.Data.Write([]byte{
+			0x01, // 1 entry
+			0x00, // no maximum
+		})
+		leb128.WriteVarUint32(&memS.Data, uint32(pages(len(m.data))))
+
+		if err := memS.Commit(out); err != nil {
+			return nil, fmt.Errorf("can't write memory section: %v", err)
+		}
+	}
+
 	exportS := &Section{Kind: SectionTypeExport}
 	exportS.Data.Write([]byte{
 		0x01,       // 1 entry
@@ -103,76 +328,138 @@ func Compile(tree *peg.Node) ([]byte, error) {
 	}
 
 	codeS := &Section{Kind: SectionTypeCode}
-	codeS.Data.Write([]byte{
-		0x01, // 1 entry
-	})
+	if hasData {
+		codeS.Data.WriteByte(0x02) // 2 entries
+	} else {
+		codeS.Data.WriteByte(0x01) // 1 entry
+	}
 
 	funcBuf := bytes.NewBuffer([]byte{
-		0x01,       // 1 local declaration
-		0x03, 0x7f, // 3 i32 values - (local i32 i32 i32)
-		0x41, 0x0a, // i32.const 10 '\n'
-		0x21, 0x00, // local.set 0
-		0x41, 0xe8, 0x00, // i32.const 104 - 'h'
-		0x21, 0x01, // local.set 1
-		0x41, 0x27, // i32.const 39 '
-		0x21, 0x02, // local.set 2
+		0x01, // 1 local declaration
 	})
+	leb128.WriteVarUint32(funcBuf, uint32(m.locals)) // i32 values, the first three are '\n', 'h' and '
+	funcBuf.WriteByte(WASMi32Type)
+	for _, i := range m.main {
+		i.encode(funcBuf)
+	}
+	funcBuf.WriteByte(byte(opEnd)) // end of function
+
+	if err := writeFunc(&codeS.Data, funcBuf); err != nil {
+		return nil, err
+	}
 
-	// compile AST to wasm
-	if len(tree.Kids) == 0 {
-		if err := compileOneNode(funcBuf, tree); err != nil {
+	if hasData {
+		helperBuf := bytes.NewBuffer([]byte{
+			0x00, // no locals
+		})
+		for _, i := range writeHelper {
+			i.encode(helperBuf)
+		}
+		helperBuf.WriteByte(byte(opEnd)) // end of function
+
+		if err := writeFunc(&codeS.Data, helperBuf); err != nil {
 			return nil, err
 		}
-	} else {
-		for _, node := range tree.Kids {
-			if err := compileOneNode(funcBuf, node); err != nil {
-				return nil, err
-			}
+	}
+
+	if err := codeS.Commit(out); err != nil {
+		return nil, fmt.Errorf("can't write code section: %v", err)
+	}
+
+	if hasData {
+		dataS := &Section{Kind: SectionTypeData}
+		dataS.Data.Write([]byte{
+			0x01,       // 1 entry
+			0x00,       // active, memory 0
+			0x41, 0x00, // i32.const 0
+			0x0b, // end of offset
+		})
+		leb128.WriteVarUint32(&dataS.Data, uint32(len(m.data)))
+		dataS.Data.Write(m.data)
+
+		if err := dataS.Commit(out); err != nil {
+			return nil, fmt.Errorf("can't write data section: %v", err)
 		}
 	}
 
-	// finally print newline
-	funcBuf.Write([]byte{
-		0x20, 0x00, // local.get 0
-		0x10, 0x00, // call 0
-		0x01, 0x01, // two padding NOPs to get a single `h` binary to be 69 bytes.
-		0x0b, // end of function
-	})
+	return out.Bytes(), nil
+}
 
-	if _, err := leb128.WriteVarUint32(&codeS.Data, uint32(funcBuf.Len())); err != nil {
-		return nil, err
+// writeFunc writes a function body prefixed with its length.
+func writeFunc(out *bytes.Buffer, body *bytes.Buffer) error {
+	if _, err := leb128.WriteVarUint32(out, uint32(body.Len())); err != nil {
+		return err
 	}
 
-	if _, err := io.Copy(&codeS.Data, funcBuf); err != nil {
-		return nil, err
+	_, err := io.Copy(out, body)
+	return err
+}
+
+func pages(n int) int {
+	return max(1, (n+wasmPageSize-1)/wasmPageSize)
+}
+
+// WAT returns the module in the WebAssembly text format.
+func (m *Module) WAT() string {
+	var sb strings.Builder
+
+	sb.WriteString("(module\n")
+	sb.WriteString("  (type (;0;) (func (param i32)))\n")
+	sb.WriteString("  (type (;1;) (func))\n")
+	if len(m.data) != 0 {
+		sb.WriteString("  (type (;2;) (func (param i32 i32)))\n")
 	}
+	sb.WriteString("  (import \"h\" \"h\" (func (;0;) (type 0)))\n")
 
-	if err := codeS.Commit(out); err != nil {
-		return nil, fmt.Errorf("can't write code section: %v", err)
+	sb.WriteString("  (func (;1;) (type 1)\n")
+	sb.WriteString("    (local" + strings.Repeat(" i32", m.locals) + ")\n")
+	writeInstrs(&sb, m.main)
+	sb.WriteString("  )\n")
+
+	if len(m.data) != 0 {
+		sb.WriteString("  (func (;2;) (type 2) (param i32 i32)\n")
+		writeInstrs(&sb, writeHelper)
+		sb.WriteString("  )\n")
+		fmt.Fprintf(&sb, "  (memory (;0;) %d)\n", pages(len(m.data)))
 	}
 
-	return out.Bytes(), nil
+	sb.WriteString("  (export \"h\" (func 1))\n")
+
+	if len(m.data) != 0 {
+		fmt.Fprintf(&sb, "  (data (;0;) (i32.const 0) %s)\n", watString(m.data))
+	}
+
+	sb.WriteString(")\n")
+
+	return sb.String()
 }
 
-func compileOneNode(out io.Writer, node *peg.Node) error {
-	switch node.Text {
-	case "h":
-		if _, err := out.Write([]byte{
-			0x20, 0x01, // local.get 1
-			0x10, 0x00, // call 0
-		}); err != nil {
-			return err
+func writeInstrs(sb *strings.Builder, is []instr) {
+	indent := 2
+	for _, i := range is {
+		if i.op == opEnd {
+			indent--
 		}
-	case "'":
-		if _, err := out.Write([]byte{
-			0x20, 0x02, // local.get 2
-			0x10, 0x00, // call 0
-		}); err != nil {
-			return err
+		sb.WriteString(strings.Repeat("  ", indent))
+		sb.WriteString(i.String())
+		sb.WriteByte('\n')
+		if i.op == opBlock || i.op == opLoop {
+			indent++
 		}
-	default:
-		return fmt.Errorf("h: le vi lerfu zo %q cu gentoldra", node.Text)
 	}
+}
 
-	return nil
+// watString quotes data as a WebAssembly text format string.
+func watString(data []byte) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, b := range data {
+		if b >= 0x20 && b < 0x7f && b != '"' && b != '\\' {
+			sb.WriteByte(b)
+		} else {
+			fmt.Fprintf(&sb, "\\%02x", b)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
 }
@@ -0,0 +1,94 @@
+package nguh
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"within.website/x/cmd/hlang/h"
+	"within.website/x/cmd/hlang/run"
+)
+
+var updateGoldenFiles = flag.Bool("update", false, "update golden files in testdata/")
+
+// TestGolden compiles each program in testdata and checks the module, its
+// text format and what it writes against the golden files next to it.
+func TestGolden(t *testing.T) {
+	fnames, err := filepath.Glob(filepath.Join("testdata", "*.h"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fname := range fnames {
+		base := strings.TrimSuffix(fname, ".h")
+
+		t.Run(filepath.Base(base), func(t *testing.T) {
+			src, err := os.ReadFile(fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tree, err := h.Parse(string(src))
+			if err != nil {
+				t.Fatalf("can't parse: %v", err)
+			}
+
+			m, err := Build(tree)
+			if err != nil {
+				t.Fatalf("can't build: %v", err)
+			}
+
+			bin, err := m.Binary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			er, err := run.Run(bin)
+			if err != nil {
+				t.Fatalf("can't run: %v", err)
+			}
+
+			golden(t, base+".wasm", bin)
+			golden(t, base+".wat", []byte(m.WAT()))
+			golden(t, base+".out", []byte(er.Output))
+		})
+	}
+}
+
+func golden(t *testing.T, fname string, got []byte) {
+	t.Helper()
+
+	if *updateGoldenFiles {
+		if err := os.WriteFile(fname, got, 0644); err != nil {
+			t.Fatalf("unable to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("error loading golden file: %v", err)
+	}
+
+	if !bytes.Equal(want, got) {
+		t.Errorf("%s doesn't match:\nwant: %q\ngot:  %q", fname, want, got)
+	}
+}
+
+func TestSingleH(t *testing.T) {
+	tree, err := h.Parse("h")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bin, err := Compile(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bin) != 69 {
+		t.Errorf("h compiled to %d bytes, want 69", len(bin))
+	}
+}
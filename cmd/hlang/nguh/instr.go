@@ -0,0 +1,88 @@
+package nguh
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/go-interpreter/wagon/wasm/leb128"
+)
+
+// opcode is a WebAssembly instruction opcode.
+type opcode byte
+
+// The instructions nguh uses.
+const (
+	opNop       opcode = 0x01
+	opBlock     opcode = 0x02
+	opLoop      opcode = 0x03
+	opEnd       opcode = 0x0b
+	opBr        opcode = 0x0c
+	opBrIf      opcode = 0x0d
+	opCall      opcode = 0x10
+	opLocalGet  opcode = 0x20
+	opLocalSet  opcode = 0x21
+	opI32Load8U opcode = 0x2d
+	opI32Const  opcode = 0x41
+	opI32Eqz    opcode = 0x45
+	opI32Add    opcode = 0x6a
+	opI32Sub    opcode = 0x6b
+)
+
+const blockTypeEmpty = 0x40
+
+var opNames = map[opcode]string{
+	opNop:       "nop",
+	opBlock:     "block",
+	opLoop:      "loop",
+	opEnd:       "end",
+	opBr:        "br",
+	opBrIf:      "br_if",
+	opCall:      "call",
+	opLocalGet:  "local.get",
+	opLocalSet:  "local.set",
+	opI32Load8U: "i32.load8_u",
+	opI32Const:  "i32.const",
+	opI32Eqz:    "i32.eqz",
+	opI32Add:    "i32.add",
+	opI32Sub:    "i32.sub",
+}
+
+// instr is a WebAssembly instruction with its immediate argument, if it has
+// one.
+type instr struct {
+	op  opcode
+	arg int32
+}
+
+func (i instr) hasArg() bool {
+	switch i.op {
+	case opBr, opBrIf, opCall, opLocalGet, opLocalSet, opI32Const:
+		return true
+	}
+	return false
+}
+
+func (i instr) encode(out *bytes.Buffer) {
+	out.WriteByte(byte(i.op))
+
+	switch i.op {
+	case opBlock, opLoop:
+		out.WriteByte(blockTypeEmpty)
+	case opI32Load8U:
+		out.Write([]byte{0x00, 0x00}) // align 1, offset 0
+	case opI32Const:
+		leb128.WriteVarint64(out, int64(i.arg))
+	default:
+		if i.hasArg() {
+			leb128.WriteVarUint32(out, uint32(i.arg))
+		}
+	}
+}
+
+func (i instr) String() string {
+	if i.hasArg() {
+		return fmt.Sprintf("%s %d", opNames[i.op], i.arg)
+	}
+
+	return opNames[i.op]
+}
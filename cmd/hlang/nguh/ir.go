@@ -0,0 +1,193 @@
+package nguh
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/eaburns/peggy/peg"
+)
+
+// maxUnroll is the most output a loop can make and still be folded into a
+// single write.
+const maxUnroll = 256
+
+// op is an operation of the intermediate representation between the parse
+// tree and WebAssembly. It is either a write or a loop.
+type op struct {
+	// write is written to the output if this isn't a loop.
+	write []byte
+
+	// count is how many times body is run if this is a loop.
+	count int32
+	body  []op
+}
+
+func (o op) isLoop() bool { return o.body != nil }
+
+// lower turns a parse tree into operations.
+func lower(node *peg.Node) ([]op, error) {
+	switch node.Name {
+	case "String":
+		s, err := unquote(node.Text)
+		if err != nil {
+			return nil, err
+		}
+		return []op{{write: []byte(s)}}, nil
+	case "Print":
+		// print writes its arguments followed by a newline.
+		result, err := lowerKids(node.Kids, "print", "(", ")")
+		if err != nil {
+			return nil, err
+		}
+		return append(result, op{write: []byte{'\n'}}), nil
+	case "Loop":
+		return lowerLoop(node)
+	}
+
+	if len(node.Kids) != 0 {
+		return lowerKids(node.Kids)
+	}
+
+	switch node.Text {
+	case "h", "'":
+		return []op{{write: []byte(node.Text)}}, nil
+	case "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("h: le vi lerfu zo %q cu gentoldra", node.Text)
+	}
+}
+
+// lowerKids lowers each kid in turn, skipping tokens that are only syntax.
+func lowerKids(kids []*peg.Node, syntax ...string) ([]op, error) {
+	var result []op
+
+	for _, k := range kids {
+		if k.Name == "" && len(k.Kids) == 0 && slices.Contains(syntax, k.Text) {
+			continue
+		}
+
+		ops, err := lower(k)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ops...)
+	}
+
+	return result, nil
+}
+
+func lowerLoop(node *peg.Node) ([]op, error) {
+	var count int32
+	var rest []*peg.Node
+
+	for i, k := range node.Kids {
+		if k.Name == "Number" {
+			n, err := strconv.ParseInt(k.Text, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("nguh: loop count %s is too big", k.Text)
+			}
+			count = int32(n)
+			rest = node.Kids[i+1:]
+			break
+		}
+	}
+
+	body, err := lowerKids(rest, "{", "}")
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		body = []op{}
+	}
+
+	return []op{{count: count, body: body}}, nil
+}
+
+// unquote decodes a string literal, including its quotes.
+func unquote(lit string) (string, error) {
+	if len(lit) < 2 || lit[0] != '"' || lit[len(lit)-1] != '"' {
+		return "", fmt.Errorf("nguh: %s isn't a string literal", lit)
+	}
+
+	var sb strings.Builder
+	escaped := false
+	for _, r := range lit[1 : len(lit)-1] {
+		switch {
+		case escaped:
+			switch r {
+			case 'n':
+				sb.WriteByte('\n')
+			case '"', '\\':
+				sb.WriteRune(r)
+			default:
+				return "", fmt.Errorf("nguh: unknown escape \\%c", r)
+			}
+			escaped = false
+		case r == '\\':
+			escaped = true
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// fold does constant folding: consecutive writes become one write, loops
+// that don't make much output are unrolled into a write, and loops that do
+// nothing are removed.
+func fold(ops []op) []op {
+	var result []op
+
+	emit := func(o op) {
+		if !o.isLoop() && len(result) != 0 && !result[len(result)-1].isLoop() {
+			last := &result[len(result)-1]
+			last.write = append(bytes.Clone(last.write), o.write...)
+			return
+		}
+		result = append(result, o)
+	}
+
+	for _, o := range ops {
+		if !o.isLoop() {
+			if len(o.write) != 0 {
+				emit(o)
+			}
+			continue
+		}
+
+		body := fold(o.body)
+		switch {
+		case o.count == 0 || len(body) == 0:
+			continue
+		case o.count == 1:
+			for _, b := range body {
+				emit(b)
+			}
+			continue
+		case len(body) == 1 && !body[0].isLoop() && int(o.count)*len(body[0].write) <= maxUnroll:
+			emit(op{write: bytes.Repeat(body[0].write, int(o.count))})
+			continue
+		}
+
+		emit(op{count: o.count, body: body})
+	}
+
+	return result
+}
+
+// depth returns how deeply loops are nested in ops.
+func depth(ops []op) int {
+	result := 0
+	for _, o := range ops {
+		if o.isLoop() {
+			result = max(result, 1+depth(o.body))
+		}
+	}
+	return result
+}
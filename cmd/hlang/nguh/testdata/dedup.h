@@ -0,0 +1,2 @@
+loop 30 { "the h koan\n" }
+print("h koan")
@@ -0,0 +1,63 @@
+(module
+  (type (;0;) (func (param i32)))
+  (type (;1;) (func))
+  (type (;2;) (func (param i32 i32)))
+  (import "h" "h" (func (;0;) (type 0)))
+  (func (;1;) (type 1)
+    (local i32 i32 i32 i32)
+    i32.const 10
+    local.set 0
+    i32.const 104
+    local.set 1
+    i32.const 39
+    local.set 2
+    i32.const 30
+    local.set 3
+    block
+      loop
+        local.get 3
+        i32.eqz
+        br_if 1
+        i32.const 0
+        i32.const 11
+        call 2
+        local.get 3
+        i32.const 1
+        i32.sub
+        local.set 3
+        br 0
+      end
+    end
+    i32.const 4
+    i32.const 7
+    call 2
+    local.get 0
+    call 0
+    nop
+    nop
+  )
+  (func (;2;) (type 2) (param i32 i32)
+    block
+      loop
+        local.get 1
+        i32.eqz
+        br_if 1
+        local.get 0
+        i32.load8_u
+        call 0
+        local.get 0
+        i32.const 1
+        i32.add
+        local.set 0
+        local.get 1
+        i32.const 1
+        i32.sub
+        local.set 1
+        br 0
+      end
+    end
+  )
+  (memory (;0;) 1)
+  (export "h" (func 1))
+  (data (;0;) (i32.const 0) "the h koan\0a")
+)
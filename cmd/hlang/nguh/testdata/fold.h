@@ -0,0 +1,3 @@
+loop 3 { h }
+loop 0 { h }
+loop 1 { print(h) }
@@ -0,0 +1 @@
+"hello, world\n"
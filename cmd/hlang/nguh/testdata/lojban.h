@@ -0,0 +1 @@
+h ' h '
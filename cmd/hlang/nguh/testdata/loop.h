@@ -0,0 +1,4 @@
+loop 60 {
+	loop 2 { h }
+	"\"\\\n"
+}
@@ -0,0 +1,43 @@
+(module
+  (type (;0;) (func (param i32)))
+  (type (;1;) (func))
+  (import "h" "h" (func (;0;) (type 0)))
+  (func (;1;) (type 1)
+    (local i32 i32 i32 i32)
+    i32.const 10
+    local.set 0
+    i32.const 104
+    local.set 1
+    i32.const 39
+    local.set 2
+    i32.const 60
+    local.set 3
+    block
+      loop
+        local.get 3
+        i32.eqz
+        br_if 1
+        local.get 1
+        call 0
+        local.get 1
+        call 0
+        i32.const 34
+        call 0
+        i32.const 92
+        call 0
+        local.get 0
+        call 0
+        local.get 3
+        i32.const 1
+        i32.sub
+        local.set 3
+        br 0
+      end
+    end
+    local.get 0
+    call 0
+    nop
+    nop
+  )
+  (export "h" (func 1))
+)
@@ -2,14 +2,39 @@ package run
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/tetratelabs/wazero"
 	"github.com/tetratelabs/wazero/api"
 )
 
+var (
+	ErrTimeout     = errors.New("run: program took too long")
+	ErrOutputLimit = errors.New("run: program wrote too much output")
+)
+
+// Limits bound how much a program can do. Zero values mean no limit.
+type Limits struct {
+	// Timeout is how long the program may run for, including compiling it.
+	Timeout time.Duration
+
+	// MaxOutput is how many bytes the program may write. Output past this
+	// is dropped and the program is stopped.
+	MaxOutput int
+
+	// MaxMemoryPages is how many 64 KiB pages of memory the program may
+	// have.
+	MaxMemoryPages uint32
+}
+
 type Process struct {
 	Output []byte
+
+	// maxOutput and stop end the program when it writes too much.
+	maxOutput int
+	stop      context.CancelCauseFunc
 }
 
 func (p *Process) Putchar(ctx context.Context, stack []uint64) {
@@ -18,15 +43,44 @@ func (p *Process) Putchar(ctx context.Context, stack []uint64) {
 }
 
 func (p *Process) putchar(char int32) {
+	if p.maxOutput != 0 && len(p.Output) >= p.maxOutput {
+		p.stop(ErrOutputLimit)
+		return
+	}
+
 	p.Output = append(p.Output, byte(char))
 }
 
+// Run runs a compiled h program without any limits.
 func Run(bin []byte) (*ExecResult, error) {
-	ctx := context.Background()
-	r := wazero.NewRuntime(ctx)
-	defer r.Close(ctx)
+	return RunContext(context.Background(), bin, Limits{})
+}
+
+// RunContext runs a compiled h program within the given limits. If the
+// program writes too much, the output it wrote up to the limit is returned
+// with Truncated set.
+func RunContext(ctx context.Context, bin []byte, lim Limits) (*ExecResult, error) {
+	ctx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+
+	if lim.Timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, lim.Timeout, ErrTimeout)
+		defer cancel()
+	}
 
-	p := &Process{}
+	cfg := wazero.NewRuntimeConfig().WithCloseOnContextDone(true)
+	if lim.MaxMemoryPages != 0 {
+		cfg = cfg.WithMemoryLimitPages(lim.MaxMemoryPages)
+	}
+
+	r := wazero.NewRuntimeWithConfig(ctx, cfg)
+	defer r.Close(context.Background())
+
+	p := &Process{
+		maxOutput: lim.MaxOutput,
+		stop:      stop,
+	}
 
 	env, err := r.NewHostModuleBuilder("h").
 		NewFunctionBuilder().
@@ -36,7 +90,7 @@ func Run(bin []byte) (*ExecResult, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer env.Close(ctx)
+	defer env.Close(context.Background())
 
 	code, err := r.CompileModule(ctx, bin)
 	if err != nil {
@@ -47,21 +101,39 @@ func Run(bin []byte) (*ExecResult, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer mod.Close(ctx)
+	defer mod.Close(context.Background())
 
-	t0 := time.Now()
-	if _, err = mod.ExportedFunction("h").Call(ctx); err != nil {
-		return nil, err
+	h := mod.ExportedFunction("h")
+	if h == nil {
+		return nil, errors.New("run: module doesn't export h")
 	}
+
+	t0 := time.Now()
+	_, err = h.Call(ctx)
 	runTime := time.Since(t0)
 
-	return &ExecResult{
+	result := &ExecResult{
 		Output:   string(p.Output),
 		ExecTime: runTime,
-	}, nil
+	}
+
+	if err != nil {
+		switch cause := context.Cause(ctx); {
+		case errors.Is(cause, ErrOutputLimit):
+			result.Truncated = true
+			return result, nil
+		case cause != nil:
+			return nil, fmt.Errorf("%w after %s", cause, runTime.Round(time.Millisecond))
+		default:
+			return nil, err
+		}
+	}
+
+	return result, nil
 }
 
 type ExecResult struct {
-	Output   string        `json:"out"`
-	ExecTime time.Duration `json:"exec_duration"`
+	Output    string        `json:"out"`
+	ExecTime  time.Duration `json:"exec_duration"`
+	Truncated bool          `json:"truncated,omitempty"`
 }
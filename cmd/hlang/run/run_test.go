@@ -2,7 +2,12 @@ package run
 
 import (
 	_ "embed"
+	"errors"
 	"testing"
+	"time"
+
+	"within.website/x/cmd/hlang/h"
+	"within.website/x/cmd/hlang/nguh"
 )
 
 //go:embed testdata/h.wasm
@@ -21,3 +26,44 @@ func BenchmarkRun(b *testing.B) {
 		}
 	}
 }
+
+func compile(t *testing.T, src string) []byte {
+	t.Helper()
+
+	tree, err := h.Parse(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bin, err := nguh.Compile(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return bin
+}
+
+func TestRunOutputLimit(t *testing.T) {
+	bin := compile(t, `loop 1000000 { h "'" }`)
+
+	er, err := RunContext(t.Context(), bin, Limits{MaxOutput: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !er.Truncated {
+		t.Error("want output to be truncated")
+	}
+	if er.Output != "h'h'h'h'h'" {
+		t.Errorf("got output %q", er.Output)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	bin := compile(t, `loop 2147483647 { loop 2147483647 { h } }`)
+
+	_, err := RunContext(t.Context(), bin, Limits{Timeout: 50 * time.Millisecond})
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("want ErrTimeout, got: %v", err)
+	}
+}
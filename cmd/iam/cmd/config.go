@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsimple"
@@ -15,6 +16,10 @@ type Config struct {
 	Region          string `hcl:"region"`
 	AccessKeyID     string `hcl:"access_key_id"`
 	SecretAccessKey string `hcl:"secret_access_key"`
+
+	// Expiration is set (RFC 3339) when the credentials came from `iam
+	// login` and stop working at that instant.
+	Expiration string `hcl:"expiration,optional"`
 }
 
 func loadConfig(fname string) (*Config, error) {
@@ -24,9 +29,44 @@ func loadConfig(fname string) (*Config, error) {
 		return nil, err
 	}
 
+	if err := result.checkExpiration(); err != nil {
+		return nil, err
+	}
+
 	return &result, nil
 }
 
+// checkExpiration fails if the credentials came from `iam login` and have
+// expired, so commands fail with a useful message instead of a signature
+// rejection.
+func (c *Config) checkExpiration() error {
+	if c.Expiration != "" {
+		exp, err := time.Parse(time.RFC3339, c.Expiration)
+		if err != nil {
+			return fmt.Errorf("parsing expiration: %w", err)
+		}
+		if !time.Now().Before(exp) {
+			return fmt.Errorf("credentials expired at %s, run `iam login` again", c.Expiration)
+		}
+	}
+
+	return nil
+}
+
+func writeConfig(fname string, c *Config) error {
+	f := hclwrite.NewEmptyFile()
+	gohcl.EncodeIntoBody(c, f.Body())
+	if c.Expiration == "" {
+		f.Body().RemoveAttribute("expiration")
+	}
+
+	if err := os.WriteFile(fname, f.Bytes(), 0600); err != nil {
+		return fmt.Errorf("writing config to %s: %w", fname, err)
+	}
+
+	return nil
+}
+
 var (
 	configCmd = &cobra.Command{
 		Use:     "config <save|show>",
@@ -45,14 +85,7 @@ var (
 				SecretAccessKey: configSaveSecretAccessKey,
 			}
 
-			f := hclwrite.NewEmptyFile()
-			gohcl.EncodeIntoBody(&c, f.Body())
-
-			if err := os.WriteFile(cfgFile, f.Bytes(), 0600); err != nil {
-				return fmt.Errorf("writing config to %s: %w", cfgFile, err)
-			}
-
-			return nil
+			return writeConfig(cfgFile, &c)
 		},
 	}
 
@@ -71,6 +104,9 @@ var (
 			fmt.Printf("region:            %s\n", c.Region)
 			fmt.Printf("access_key_id:     %s\n", c.AccessKeyID)
 			fmt.Printf("secret_access_key: %s\n", c.SecretAccessKey)
+			if c.Expiration != "" {
+				fmt.Printf("expiration:        %s\n", c.Expiration)
+			}
 			return nil
 		},
 	}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/cli/browser"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
+	"within.website/x/cmd/iamd/pub/iam"
+	stsv1 "within.website/x/gen/within/website/x/iam/sts/v1"
+)
+
+var (
+	loginEndpoint, loginRegion string
+	loginDuration              time.Duration
+	loginCallbackPort          int
+	loginNoBrowser             bool
+
+	loginCmd = &cobra.Command{
+		Use:   "login [--endpoint=] [--region=] [--duration=]",
+		Short: "Log in with the IAM service's OIDC provider and save short-lived credentials",
+		Long: `Log in with the OIDC provider the IAM service trusts and save the
+short-lived credentials it mints into the config file.
+
+The login runs in your browser: iam listens on a loopback address for the
+provider's redirect (RFC 8252), so the OIDC client must allow
+http://127.0.0.1/callback on any port, or on --callback-port.
+
+The endpoint and region default to the ones in the existing config file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			// An expired config is still a fine source of defaults, so read
+			// it without loadConfig's expiry check.
+			var prev Config
+			if _, err := os.Stat(cfgFile); err == nil {
+				if err := hclsimple.DecodeFile(cfgFile, nil, &prev); err != nil {
+					return fmt.Errorf("loading config from %s: %w", cfgFile, err)
+				}
+			}
+
+			endpoint := loginEndpoint
+			if endpoint == "" {
+				endpoint = prev.Endpoint
+			}
+			if endpoint == "" {
+				return errors.New("no endpoint configured, pass --endpoint")
+			}
+
+			region := loginRegion
+			if region == "" {
+				region = prev.Region
+			}
+			if region == "" {
+				region = "yow"
+			}
+
+			wi := iam.NewWebIdentity(endpoint)
+
+			lc, err := wi.GetLoginConfig(ctx, &stsv1.GetLoginConfigRequest{})
+			if err != nil {
+				return fmt.Errorf("can't get login config from %s: %w", endpoint, err)
+			}
+
+			open := func(u string) error { return browser.OpenURL(u) }
+			if loginNoBrowser {
+				open = nil
+			}
+
+			idToken, err := loopbackLogin(ctx, lc, loginCallbackPort, open)
+			if err != nil {
+				return err
+			}
+
+			creds, err := wi.AssumeRoleWithWebIdentity(ctx, &stsv1.AssumeRoleWithWebIdentityRequest{
+				IdToken:         idToken,
+				DurationSeconds: int64(loginDuration / time.Second),
+			})
+			if err != nil {
+				return fmt.Errorf("can't exchange ID token for credentials: %w", err)
+			}
+
+			if err := writeConfig(cfgFile, &Config{
+				Endpoint:        endpoint,
+				Region:          region,
+				AccessKeyID:     creds.GetAccessKeyId(),
+				SecretAccessKey: creds.GetSecretAccessKey(),
+				Expiration:      creds.GetExpiration().AsTime().Format(time.RFC3339),
+			}); err != nil {
+				return err
+			}
+
+			fmt.Printf("Logged in as %s (%s)\n", creds.GetIdentity().GetDisplayName(), creds.GetIdentity().GetPrincipalId())
+			fmt.Printf("Credentials expire at %s\n", creds.GetExpiration().AsTime().Local().Format(time.RFC1123))
+
+			return nil
+		},
+	}
+)
+
+// loopbackLogin runs the OAuth2 authorization code flow with PKCE against the
+// issuer in lc, receiving the redirect on a loopback listener, and returns the
+// verified raw ID token. open is called with the authorization URL; if it is
+// nil or fails, the user is asked to open the URL themselves.
+func loopbackLogin(ctx context.Context, lc *stsv1.GetLoginConfigResponse, port int, open func(string) error) (string, error) {
+	provider, err := oidc.NewProvider(ctx, lc.GetIssuer())
+	if err != nil {
+		return "", fmt.Errorf("can't discover OIDC issuer %s: %w", lc.GetIssuer(), err)
+	}
+
+	ln, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return "", fmt.Errorf("can't listen for the login redirect: %w", err)
+	}
+	defer ln.Close()
+
+	conf := oauth2.Config{
+		ClientID:    lc.GetClientId(),
+		Endpoint:    provider.Endpoint(),
+		RedirectURL: "http://" + ln.Addr().String() + "/callback",
+		Scopes:      lc.GetScopes(),
+	}
+	verifier := provider.Verifier(&oidc.Config{ClientID: lc.GetClientId()})
+
+	state := rand.Text()
+	nonce := rand.Text()
+	pkce := oauth2.GenerateVerifier()
+
+	type result struct {
+		idToken string
+		err     error
+	}
+	done := make(chan result, 1)
+	finish := func(w http.ResponseWriter, res result) {
+		if res.err != nil {
+			http.Error(w, "Login failed: "+res.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Logged in. You can close this window and return to your terminal.")
+		}
+		select {
+		case done <- res:
+		default:
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /callback", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != state {
+			// Not our redirect; leave the flow running.
+			http.Error(w, "state did not match", http.StatusBadRequest)
+			return
+		}
+		if e := q.Get("error"); e != "" {
+			finish(w, result{err: fmt.Errorf("identity provider returned %s: %s", e, q.Get("error_description"))})
+			return
+		}
+
+		tok, err := conf.Exchange(r.Context(), q.Get("code"), oauth2.VerifierOption(pkce))
+		if err != nil {
+			finish(w, result{err: fmt.Errorf("can't exchange authorization code: %w", err)})
+			return
+		}
+
+		raw, ok := tok.Extra("id_token").(string)
+		if !ok {
+			finish(w, result{err: errors.New("token response has no id_token")})
+			return
+		}
+
+		idToken, err := verifier.Verify(r.Context(), raw)
+		if err != nil {
+			finish(w, result{err: fmt.Errorf("can't verify id_token: %w", err)})
+			return
+		}
+		if idToken.Nonce != nonce {
+			finish(w, result{err: errors.New("id_token nonce did not match")})
+			return
+		}
+
+		finish(w, result{idToken: raw})
+	})
+
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	authURL := conf.AuthCodeURL(state, oauth2.S256ChallengeOption(pkce), oidc.Nonce(nonce))
+
+	fmt.Fprintf(os.Stderr, "Open this URL to log in:\n\n\t%s\n\n", authURL)
+	if open != nil {
+		if err := open(authURL); err != nil {
+			fmt.Fprintf(os.Stderr, "can't open a browser (%v), open the URL yourself\n", err)
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case res := <-done:
+		return res.idToken, res.err
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+
+	lf := loginCmd.Flags()
+	lf.StringVar(&loginEndpoint, "endpoint", "", "endpoint URL for the IAM service (default: from the config file)")
+	lf.StringVar(&loginRegion, "region", "", "region for the IAM service (default: from the config file, else yow)")
+	lf.DurationVar(&loginDuration, "duration", 0, "requested credential lifetime, at least 15m (default: the server's maximum)")
+	lf.IntVar(&loginCallbackPort, "callback-port", 0, "loopback port to receive the login redirect on (default: any free port)")
+	lf.BoolVar(&loginNoBrowser, "no-browser", false, "if true, print the login URL instead of opening a browser")
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	stsv1 "within.website/x/gen/within/website/x/iam/sts/v1"
+	"within.website/x/internal/fakeoidc"
+)
+
+func TestLoopbackLogin(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	idp, err := fakeoidc.New("iam-cli", fakeoidc.Identity{Subject: "alice-sub", PreferredUsername: "alice"})
+	if err != nil {
+		t.Fatalf("fakeoidc.New: %v", err)
+	}
+	defer idp.Close()
+
+	lc := &stsv1.GetLoginConfigResponse{
+		Issuer:   idp.Issuer,
+		ClientId: "iam-cli",
+		Scopes:   []string{oidc.ScopeOpenID, "groups"},
+	}
+
+	// Stand in for the browser: follow the authorization redirect back to
+	// the loopback listener.
+	open := func(u string) error {
+		resp, err := http.Get(u)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("callback status = %d: %s", resp.StatusCode, body)
+		}
+		return nil
+	}
+
+	raw, err := loopbackLogin(ctx, lc, 0, open)
+	if err != nil {
+		t.Fatalf("loopbackLogin: %v", err)
+	}
+
+	provider, err := oidc.NewProvider(ctx, idp.Issuer)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	tok, err := provider.Verifier(&oidc.Config{ClientID: "iam-cli"}).Verify(ctx, raw)
+	if err != nil {
+		t.Fatalf("returned ID token does not verify: %v", err)
+	}
+	if tok.Subject != "alice-sub" {
+		t.Errorf("subject = %q, want alice-sub", tok.Subject)
+	}
+}
+
+func TestConfigExpiration(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "iam.hcl")
+
+	for _, tt := range []struct {
+		name       string
+		expiration string
+		wantErr    string
+	}{
+		{name: "static credentials", expiration: ""},
+		{name: "unexpired login", expiration: time.Now().Add(time.Hour).Format(time.RFC3339)},
+		{name: "expired login", expiration: time.Now().Add(-time.Hour).Format(time.RFC3339), wantErr: "iam login"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			want := Config{
+				Endpoint:        "https://iam.example",
+				Region:          "yow",
+				AccessKeyID:     "AKID",
+				SecretAccessKey: "secret",
+				Expiration:      tt.expiration,
+			}
+			if err := writeConfig(fname, &want); err != nil {
+				t.Fatalf("writeConfig: %v", err)
+			}
+
+			got, err := loadConfig(fname)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("loadConfig err = %v, want one mentioning %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadConfig: %v", err)
+			}
+			if *got != want {
+				t.Errorf("loadConfig = %+v, want %+v", *got, want)
+			}
+		})
+	}
+}
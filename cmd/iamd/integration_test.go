@@ -10,7 +10,10 @@ import (
 	"time"
 
 	"within.website/x/cmd/iamd/models"
+	"within.website/x/cmd/iamd/services/iam/sts"
+	stsv1 "within.website/x/gen/within/website/x/iam/sts/v1"
 	iamv1 "within.website/x/gen/within/website/x/iam/v1"
+	"within.website/x/internal/fakeoidc"
 	"within.website/x/web/middleware/sigv4/iamsts"
 	"within.website/x/web/middleware/sigv4/sigv4client"
 	"within.website/x/web/middleware/sigv4a"
@@ -121,7 +124,7 @@ func TestIntegration_SignedTwirpRoundTrip(t *testing.T) {
 	}
 
 	authMW := newDualVerifier(dao, intRegion, intService, 1<<20)
-	mux := newMux(quietLogger(), dao, authMW, 5*time.Minute)
+	mux := newMux(quietLogger(), dao, authMW, 5*time.Minute, nil)
 	srv := httptest.NewServer(mux)
 	defer srv.Close()
 
@@ -252,7 +255,7 @@ func TestIntegration_SigningKeyVerifierChain(t *testing.T) {
 	}
 
 	authMW := newDualVerifier(dao, intRegion, intService, 1<<20)
-	iamd := httptest.NewServer(newMux(quietLogger(), dao, authMW, 5*time.Minute))
+	iamd := httptest.NewServer(newMux(quietLogger(), dao, authMW, 5*time.Minute, nil))
 	defer iamd.Close()
 
 	// The downstream service: fetches signing keys from iamd (signing those
@@ -315,3 +318,64 @@ func TestIntegration_SigningKeyVerifierChain(t *testing.T) {
 		}
 	})
 }
+
+// TestIntegration_WebIdentityLogin trades an ID token from a fake OIDC
+// provider for credentials over the unsigned WebIdentityService route, then
+// uses those credentials on a signed route.
+func TestIntegration_WebIdentityLogin(t *testing.T) {
+	ctx := context.Background()
+	dao := newDAO(t)
+
+	idp, err := fakeoidc.New("iam-cli", fakeoidc.Identity{})
+	if err != nil {
+		t.Fatalf("fakeoidc.New: %v", err)
+	}
+	defer idp.Close()
+
+	wi, err := sts.NewWebIdentity(ctx, dao, sts.WebIdentityConfig{
+		Issuer:      idp.Issuer,
+		ClientID:    "iam-cli",
+		AdminGroup:  "admins",
+		MaxDuration: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewWebIdentity: %v", err)
+	}
+
+	authMW := newDualVerifier(dao, intRegion, intService, 1<<20)
+	srv := httptest.NewServer(newMux(quietLogger(), dao, authMW, 5*time.Minute, wi))
+	defer srv.Close()
+
+	anon := stsv1.NewWebIdentityServiceProtobufClient(srv.URL, srv.Client())
+
+	lc, err := anon.GetLoginConfig(ctx, &stsv1.GetLoginConfigRequest{})
+	if err != nil {
+		t.Fatalf("GetLoginConfig: %v", err)
+	}
+	if lc.GetIssuer() != idp.Issuer || lc.GetClientId() != "iam-cli" {
+		t.Errorf("login config = %v, want issuer %s and client iam-cli", lc, idp.Issuer)
+	}
+
+	tok, err := idp.IDToken(fakeoidc.Identity{Subject: "root-sub", PreferredUsername: "root", Groups: []string{"admins"}}, "")
+	if err != nil {
+		t.Fatalf("IDToken: %v", err)
+	}
+	creds, err := anon.AssumeRoleWithWebIdentity(ctx, &stsv1.AssumeRoleWithWebIdentityRequest{IdToken: tok})
+	if err != nil {
+		t.Fatalf("AssumeRoleWithWebIdentity: %v", err)
+	}
+
+	for _, algorithm := range []string{"sigv4a", "sigv4"} {
+		t.Run(algorithm, func(t *testing.T) {
+			hc := &http.Client{Transport: transportFor(t, algorithm, creds.GetAccessKeyId(), creds.GetSecretAccessKey())}
+			users := iamv1.NewUserServiceProtobufClient(srv.URL, hc)
+			resp, err := users.ListUsers(ctx, &iamv1.ListUsersReq{Count: 10})
+			if err != nil {
+				t.Fatalf("ListUsers with federated credentials: %v", err)
+			}
+			if len(resp.GetUsers()) != 1 || resp.GetUsers()[0].GetName() != "root" {
+				t.Errorf("ListUsers = %v, want only the federated user root", resp.GetUsers())
+			}
+		})
+	}
+}
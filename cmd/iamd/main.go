@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -33,6 +34,13 @@ var (
 	bootstrapUser = flag.String("bootstrap-username", "", "if set and the DB has no users, create an admin user and signing key with this name at startup and log the credentials")
 
 	signingKeyCacheTTL = flag.Duration("signing-key-cache-ttl", 5*time.Minute, "how long downstream verifiers may cache a derived signing key before re-fetching; bounds revocation latency")
+
+	oidcIssuer          = flag.String("oidc-issuer", "", "if set, enable web identity federation: ID tokens from this OIDC issuer can be traded for short-lived credentials")
+	oidcClientID        = flag.String("oidc-client-id", "", "OAuth2 client id ID tokens must be issued for; the iam CLI logs in as this public client")
+	oidcScopes          = flag.String("oidc-scopes", "openid,profile,email,groups", "comma-separated scopes the iam CLI requests when logging in")
+	oidcAdminGroup      = flag.String("oidc-admin-group", "", "members of this OIDC group are iamd administrators, recomputed on every login")
+	oidcRequiredGroup   = flag.String("oidc-required-group", "", "if set, only members of this OIDC group may log in")
+	oidcSessionDuration = flag.Duration("oidc-session-duration", 12*time.Hour, "maximum lifetime of credentials minted by web identity logins")
 )
 
 func main() {
@@ -56,7 +64,11 @@ func main() {
 // authMW per request), then resolve the caller to its DAO user (available
 // downstream via sigv4a.User). The SigningKeyService route's callers are
 // downstream verifiers authenticating with their own IAM credential.
-func newMux(lg *slog.Logger, dao *models.DAO, authMW func(http.Handler) http.Handler, signingKeyCacheTTL time.Duration) *http.ServeMux {
+//
+// The WebIdentityService route is the one exception: its callers hold an
+// OIDC ID token instead of a credential, so it is mounted without the
+// signature pipeline. It is only mounted when wi is non-nil.
+func newMux(lg *slog.Logger, dao *models.DAO, authMW func(http.Handler) http.Handler, signingKeyCacheTTL time.Duration, wi *sts.WebIdentity) *http.ServeMux {
 	mux := http.NewServeMux()
 
 	// Verify the signature, then annotate the request with the caller's user
@@ -72,6 +84,10 @@ func newMux(lg *slog.Logger, dao *models.DAO, authMW func(http.Handler) http.Han
 	sk := sts.NewSigningKeys(dao, signingKeyCacheTTL)
 	mux.Handle(stsv1.SigningKeyServicePathPrefix, stack(stsv1.NewSigningKeyServiceServer(sk, twirp.WithServerInterceptors(twirpslog.Interceptor(lg)))))
 
+	if wi != nil {
+		mux.Handle(stsv1.WebIdentityServicePathPrefix, stsv1.NewWebIdentityServiceServer(wi, twirp.WithServerInterceptors(twirpslog.Interceptor(lg))))
+	}
+
 	return mux
 }
 
@@ -111,7 +127,24 @@ func run(ctx context.Context, lg *slog.Logger) error {
 	// The route middleware is the dual verifier's only consumer: it
 	// authenticates every caller to iamd, including SigningKeyService.
 	authMW := newDualVerifier(dao, *region, *service, *maxBodySize)
-	mux := newMux(lg, dao, authMW, *signingKeyCacheTTL)
+
+	var wi *sts.WebIdentity
+	if *oidcIssuer != "" {
+		wi, err = sts.NewWebIdentity(ctx, dao, sts.WebIdentityConfig{
+			Issuer:        *oidcIssuer,
+			ClientID:      *oidcClientID,
+			Scopes:        strings.Split(*oidcScopes, ","),
+			AdminGroup:    *oidcAdminGroup,
+			RequiredGroup: *oidcRequiredGroup,
+			MaxDuration:   *oidcSessionDuration,
+		})
+		if err != nil {
+			return fmt.Errorf("web identity federation: %w", err)
+		}
+		lg.InfoContext(ctx, "web identity federation enabled", "issuer", *oidcIssuer, "client-id", *oidcClientID)
+	}
+
+	mux := newMux(lg, dao, authMW, *signingKeyCacheTTL, wi)
 
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -3,6 +3,7 @@ package models
 import (
 	"context"
 	"fmt"
+	"time"
 
 	_ "github.com/ncruces/go-sqlite3/embed"
 	"github.com/ncruces/go-sqlite3/gormlite"
@@ -12,9 +13,20 @@ import (
 )
 
 type DAO struct {
-	db    *gorm.DB
-	keys  gorm.Interface[Key]
-	users gorm.Interface[User]
+	db         *gorm.DB
+	keys       gorm.Interface[Key]
+	users      gorm.Interface[User]
+	identities gorm.Interface[Identity]
+
+	// Now is overridable for tests. Defaults to time.Now.
+	Now func() time.Time
+}
+
+func (d *DAO) now() time.Time {
+	if d.Now != nil {
+		return d.Now()
+	}
+	return time.Now()
 }
 
 func (d *DAO) DB() *gorm.DB {
@@ -63,13 +75,15 @@ func Open(dbLoc string) (*DAO, error) {
 	if err := db.AutoMigrate(
 		&User{},
 		&Key{},
+		&Identity{},
 	); err != nil {
 		return nil, fmt.Errorf("failed to migrate schema: %w", err)
 	}
 
 	return &DAO{
-		db:    db,
-		keys:  gorm.G[Key](db),
-		users: gorm.G[User](db),
+		db:         db,
+		keys:       gorm.G[Key](db),
+		users:      gorm.G[User](db),
+		identities: gorm.G[Identity](db),
 	}, nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -318,3 +319,112 @@ func TestGetKeyWithUser(t *testing.T) {
 		}
 	})
 }
+
+func TestExpiringKey(t *testing.T) {
+	ctx := context.Background()
+	d := openTestDAO(t)
+	now := time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)
+	d.Now = func() time.Time { return now }
+
+	u := mustCreateUser(t, d, "federated")
+	k, err := d.CreateExpiringKey(ctx, u, "login", now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("CreateExpiringKey: %v", err)
+	}
+
+	if _, err := d.SecretFor(ctx, k.AccessKeyID); err != nil {
+		t.Fatalf("SecretFor before expiry: %v", err)
+	}
+	if _, err := d.GetUserByAccessKeyID(ctx, k.AccessKeyID); err != nil {
+		t.Fatalf("GetUserByAccessKeyID before expiry: %v", err)
+	}
+
+	now = now.Add(time.Hour)
+
+	if _, err := d.SecretFor(ctx, k.AccessKeyID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("SecretFor at expiry err = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+	if _, err := d.GetUserByAccessKeyID(ctx, k.AccessKeyID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetUserByAccessKeyID at expiry err = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+
+	got, err := d.GetKeyWithUser(ctx, k.AccessKeyID)
+	if err != nil {
+		t.Fatalf("GetKeyWithUser: %v", err)
+	}
+	if !got.Expired(now) {
+		t.Error("GetKeyWithUser returned a key that does not report Expired")
+	}
+
+	permanent := mustCreateKey(t, d, u, "permanent")
+	if err := d.DisableExpiredKeys(ctx, u); err != nil {
+		t.Fatalf("DisableExpiredKeys: %v", err)
+	}
+	keys, err := d.ListKeys(ctx, 10, 0, u.UUID)
+	if err != nil {
+		t.Fatalf("ListKeys: %v", err)
+	}
+	if len(keys) != 1 || keys[0].AccessKeyID != permanent.AccessKeyID {
+		t.Errorf("ListKeys after DisableExpiredKeys = %v, want only %s", keys, permanent.AccessKeyID)
+	}
+}
+
+func TestFederateUser(t *testing.T) {
+	ctx := context.Background()
+	const iss = "https://idp.example"
+
+	t.Run("first login provisions, later logins reuse", func(t *testing.T) {
+		d := openTestDAO(t)
+
+		first, err := d.FederateUser(ctx, iss, "sub-1", "alice", false)
+		if err != nil {
+			t.Fatalf("first FederateUser: %v", err)
+		}
+		second, err := d.FederateUser(ctx, iss, "sub-1", "alice2", true)
+		if err != nil {
+			t.Fatalf("second FederateUser: %v", err)
+		}
+		if first.UUID != second.UUID {
+			t.Errorf("second login mapped to %s, want %s", second.UUID, first.UUID)
+		}
+
+		got, err := d.GetUser(ctx, first.UUID)
+		if err != nil {
+			t.Fatalf("GetUser: %v", err)
+		}
+		if got.Name != "alice2" || !got.IsAdmin {
+			t.Errorf("user = {%q admin=%v}, want {alice2 admin=true}", got.Name, got.IsAdmin)
+		}
+	})
+
+	t.Run("subject is scoped to its issuer", func(t *testing.T) {
+		d := openTestDAO(t)
+
+		a, err := d.FederateUser(ctx, iss, "sub-1", "alice", false)
+		if err != nil {
+			t.Fatalf("FederateUser: %v", err)
+		}
+		b, err := d.FederateUser(ctx, "https://other.example", "sub-1", "mallory", false)
+		if err != nil {
+			t.Fatalf("FederateUser: %v", err)
+		}
+		if a.UUID == b.UUID {
+			t.Error("same subject from different issuers mapped to one user")
+		}
+	})
+
+	t.Run("disabled user stays disabled", func(t *testing.T) {
+		d := openTestDAO(t)
+
+		u, err := d.FederateUser(ctx, iss, "sub-1", "alice", false)
+		if err != nil {
+			t.Fatalf("FederateUser: %v", err)
+		}
+		if err := d.DisableUser(ctx, u.UUID, "test"); err != nil {
+			t.Fatalf("DisableUser: %v", err)
+		}
+		if _, err := d.FederateUser(ctx, iss, "sub-1", "alice", false); !errors.Is(err, ErrUserDisabled) {
+			t.Errorf("FederateUser err = %v, want %v", err, ErrUserDisabled)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrUserDisabled is returned by FederateUser when the identity maps to a
+// user that has been disabled. Federation never re-enables a user.
+var ErrUserDisabled = errors.New("models: user is disabled")
+
+// Identity links an external OpenID Connect subject to the iamd user it logs
+// in as. The (issuer, subject) pair is the stable identifier OIDC guarantees;
+// names and emails can change underneath it.
+type Identity struct {
+	gorm.Model // adds CreatedAt, UpdatedAt, DeletedAt
+
+	Issuer  string `gorm:"uniqueIndex:idx_identities_issuer_subject"`
+	Subject string `gorm:"uniqueIndex:idx_identities_issuer_subject"`
+	UserID  uint   // User the identity logs in as
+	User    *User  `gorm:"foreignKey:UserID"`
+}
+
+// FederateUser resolves the user linked to (issuer, subject), creating both
+// the user and the link on first login. The issuer is authoritative for the
+// user's display name and admin bit, so both are overwritten on every login.
+// It returns ErrUserDisabled if the linked user has been disabled.
+func (d *DAO) FederateUser(ctx context.Context, issuer, subject, name string, isAdmin bool) (*User, error) {
+	var result *User
+
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ident, err := gorm.G[Identity](tx).
+			Where("issuer = ? AND subject = ?", issuer, subject).
+			First(ctx)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			u, err := createFederatedUser(ctx, tx, issuer, subject, name, isAdmin)
+			if err != nil {
+				return err
+			}
+			result = u
+			return nil
+		case err != nil:
+			return err
+		}
+
+		u, err := gorm.G[User](tx.Unscoped()).Where("id = ?", ident.UserID).First(ctx)
+		if err != nil {
+			return fmt.Errorf("load user for identity %d: %w", ident.ID, err)
+		}
+		if u.DeletedAt.Valid {
+			return ErrUserDisabled
+		}
+
+		if u.Name != name || u.IsAdmin != isAdmin {
+			if err := tx.Model(&u).Updates(map[string]any{
+				"name":     name,
+				"is_admin": isAdmin,
+			}).Error; err != nil {
+				return fmt.Errorf("update federated user: %w", err)
+			}
+			u.Name = name
+			u.IsAdmin = isAdmin
+		}
+
+		result = &u
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func createFederatedUser(ctx context.Context, tx *gorm.DB, issuer, subject, name string, isAdmin bool) (*User, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	u := User{
+		UUID:    id.String(),
+		Name:    name,
+		IsAdmin: isAdmin,
+	}
+
+	if err := gorm.G[User](tx).Create(ctx, &u); err != nil {
+		return nil, fmt.Errorf("create federated user: %w", err)
+	}
+
+	ident := Identity{
+		Issuer:  issuer,
+		Subject: subject,
+		UserID:  u.Model.ID,
+	}
+
+	if err := gorm.G[Identity](tx).Create(ctx, &ident); err != nil {
+		return nil, fmt.Errorf("link identity: %w", err)
+	}
+
+	return &u, nil
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"context"
+	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
@@ -18,6 +19,15 @@ type Key struct {
 	DisableReason   *string
 	UserID          uint  // User that owns the key
 	User            *User `gorm:"foreignKey:UserID"`
+
+	// ExpiresAt is set on short-lived keys minted by web identity
+	// federation. Nil means the key never expires.
+	ExpiresAt *time.Time `gorm:"index"`
+}
+
+// Expired reports whether the key has an expiry at or before now.
+func (k *Key) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
 }
 
 func (k *Key) AsProto() *iamv1.Key {
@@ -38,6 +48,16 @@ func (k *Key) AsProto() *iamv1.Key {
 }
 
 func (d *DAO) CreateKey(ctx context.Context, user *User, comment string) (*Key, error) {
+	return d.createKey(ctx, user, comment, nil)
+}
+
+// CreateExpiringKey creates a key for user that stops verifying at expiresAt.
+// SecretFor and GetUserByAccessKeyID treat it as unknown from then on.
+func (d *DAO) CreateExpiringKey(ctx context.Context, user *User, comment string, expiresAt time.Time) (*Key, error) {
+	return d.createKey(ctx, user, comment, &expiresAt)
+}
+
+func (d *DAO) createKey(ctx context.Context, user *User, comment string, expiresAt *time.Time) (*Key, error) {
 	ak, sk := sigv4keygen.Next()
 
 	k := Key{
@@ -45,6 +65,7 @@ func (d *DAO) CreateKey(ctx context.Context, user *User, comment string) (*Key,
 		SecretAccessKey: sk,
 		Comment:         comment,
 		UserID:          user.Model.ID,
+		ExpiresAt:       expiresAt,
 	}
 
 	if err := d.keys.Create(ctx, &k); err != nil {
@@ -110,10 +131,11 @@ func (d *DAO) ListKeys(ctx context.Context, count, page int, userID string) ([]K
 
 // SecretFor returns the secret access key for the given access key id. It is the
 // lookup the sigv4 Verifier uses to recompute signatures. A disabled (soft-
-// deleted) key is excluded by GORM's default scope, so it surfaces as
-// gorm.ErrRecordNotFound — which callers map to "unknown key".
+// deleted) key is excluded by GORM's default scope and an expired key by
+// its expiry, so both surface as gorm.ErrRecordNotFound — which callers map
+// to "unknown key".
 func (d *DAO) SecretFor(ctx context.Context, accessKeyID string) (string, error) {
-	k, err := d.keys.Where("access_key_id = ?", accessKeyID).First(ctx)
+	k, err := d.keys.Where("access_key_id = ? AND (expires_at IS NULL OR expires_at > ?)", accessKeyID, d.now()).First(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -124,7 +146,8 @@ func (d *DAO) SecretFor(ctx context.Context, accessKeyID string) (string, error)
 // user, including soft-deleted (disabled) rows for both — disabled state is
 // visible on DeletedAt rather than hidden by GORM's default scope. It exists
 // for the signing-key issuer, which must distinguish "no such key" from "key
-// or user disabled". Use SecretFor when disabled keys must not load at all.
+// or user disabled". Expired keys load too; check Key.Expired. Use SecretFor
+// when disabled or expired keys must not load at all.
 func (d *DAO) GetKeyWithUser(ctx context.Context, accessKeyID string) (*Key, error) {
 	var k Key
 	// PropagateUnscoped is set on the session, so Unscoped applies to the
@@ -137,3 +160,19 @@ func (d *DAO) GetKeyWithUser(ctx context.Context, accessKeyID string) (*Key, err
 	}
 	return &k, nil
 }
+
+// DisableExpiredKeys soft-deletes every key owned by user whose expiry has
+// passed, so federated logins do not accumulate dead rows in key listings.
+func (d *DAO) DisableExpiredKeys(ctx context.Context, user *User) error {
+	q := d.keys.Where("user_id = ? AND expires_at IS NOT NULL AND expires_at <= ?", user.Model.ID, d.now())
+	if _, err := q.Update(ctx, "disable_reason", "expired"); err != nil {
+		return err
+	}
+
+	q = d.keys.Where("user_id = ? AND expires_at IS NOT NULL AND expires_at <= ?", user.Model.ID, d.now())
+	if _, err := q.Delete(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -113,9 +113,9 @@ func (d *DAO) GetUser(ctx context.Context, userID string) (*User, error) {
 // accessKeyID is the value the sigv4 middleware stores in the request context
 // (see web/middleware/sigv4.KeyID). It returns an error wrapping
 // gorm.ErrRecordNotFound if the key or its user does not exist, including when
-// either has been soft-deleted (disabled).
+// either has been soft-deleted (disabled) or the key has expired.
 func (d *DAO) GetUserByAccessKeyID(ctx context.Context, accessKeyID string) (*User, error) {
-	k, err := d.keys.Where("access_key_id = ?", accessKeyID).First(ctx)
+	k, err := d.keys.Where("access_key_id = ? AND (expires_at IS NULL OR expires_at > ?)", accessKeyID, d.now()).First(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	stsv1 "within.website/x/gen/within/website/x/iam/sts/v1"
 	iamv1 "within.website/x/gen/within/website/x/iam/v1"
 	"within.website/x/web/middleware/sigv4a/sigv4aclient"
 	"within.website/x/web/useragent"
@@ -39,3 +40,13 @@ func New(ctx context.Context, endpoint, region, accessKeyID, secretAccessKey str
 		Users: users,
 	}, nil
 }
+
+// NewWebIdentity returns a client for iamd's WebIdentityService. Its requests
+// are not signed: callers use it to obtain credentials in the first place.
+func NewWebIdentity(endpoint string) stsv1.WebIdentityService {
+	hc := &http.Client{
+		Transport: useragent.Transport("within.website/x/cmd/iamd/pub/iam", "https://xeiaso.net/contact", http.DefaultTransport),
+	}
+
+	return stsv1.NewWebIdentityServiceProtobufClient(endpoint, hc)
+}
@@ -39,6 +39,14 @@ func (s *SigningKeys) GetPublicKey(ctx context.Context, req *stsv1.GetPublicKeyR
 	if k.User == nil || k.User.DeletedAt.Valid {
 		return nil, twirp.NewError(twirp.PermissionDenied, "owning user is disabled")
 	}
+	if k.Expired(s.now()) {
+		return nil, twirp.NewError(twirp.PermissionDenied, "access key has expired")
+	}
+
+	cacheUntil := s.now().Add(s.cacheTTL)
+	if k.ExpiresAt != nil && cacheUntil.After(*k.ExpiresAt) {
+		cacheUntil = *k.ExpiresAt
+	}
 
 	priv, err := sigv4a.DeriveKeyPair(k.AccessKeyID, k.SecretAccessKey)
 	if err != nil {
@@ -56,6 +64,6 @@ func (s *SigningKeys) GetPublicKey(ctx context.Context, req *stsv1.GetPublicKeyR
 			PrincipalId: k.User.UUID,
 			DisplayName: k.User.Name,
 		},
-		CacheUntil: timestamppb.New(s.now().Add(s.cacheTTL)),
+		CacheUntil: timestamppb.New(cacheUntil),
 	}, nil
 }
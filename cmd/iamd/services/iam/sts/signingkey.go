@@ -1,6 +1,7 @@
 // Package sts hosts iamd's security-token-service surface: the
 // SigningKeyService that distributes SigV4 derived signing keys to
-// downstream verifiers.
+// downstream verifiers, and the WebIdentityService that trades OIDC ID
+// tokens for short-lived credentials.
 package sts
 
 import (
@@ -89,12 +90,18 @@ func (s *SigningKeys) GetSigningKey(ctx context.Context, req *stsv1.GetSigningKe
 	if k.User == nil || k.User.DeletedAt.Valid {
 		return nil, twirp.NewError(twirp.PermissionDenied, "owning user is disabled")
 	}
+	if k.Expired(s.now()) {
+		return nil, twirp.NewError(twirp.PermissionDenied, "access key has expired")
+	}
 
 	notValidAfter := day.AddDate(0, 0, 1).Add(maxClockSkew)
 	cacheUntil := now.Add(s.cacheTTL)
 	if cacheUntil.After(notValidAfter) {
 		cacheUntil = notValidAfter
 	}
+	if k.ExpiresAt != nil && cacheUntil.After(*k.ExpiresAt) {
+		cacheUntil = *k.ExpiresAt
+	}
 
 	return &stsv1.GetSigningKeyResponse{
 		SigningKey: sigv4.DeriveSigningKey(k.SecretAccessKey, req.GetDate(), req.GetRegion(), req.GetService()),
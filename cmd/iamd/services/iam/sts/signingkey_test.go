@@ -151,6 +151,40 @@ func TestGetSigningKey(t *testing.T) {
 		wantTwirpCode(t, err, twirp.PermissionDenied)
 	})
 
+	t.Run("cache_until clamped to key expiry", func(t *testing.T) {
+		s, dao, _, _ := newSigningKeysTest(t)
+		u, err := dao.FederateUser(ctx, "https://idp.example", "sub", "federated", false)
+		if err != nil {
+			t.Fatalf("FederateUser: %v", err)
+		}
+		expires := fixedNow.Add(2 * time.Minute)
+		k, err := dao.CreateExpiringKey(ctx, u, "login", expires)
+		if err != nil {
+			t.Fatalf("CreateExpiringKey: %v", err)
+		}
+		resp, err := s.GetSigningKey(ctx, validReq(k.AccessKeyID))
+		if err != nil {
+			t.Fatalf("GetSigningKey: %v", err)
+		}
+		if got := resp.GetCacheUntil().AsTime(); !got.Equal(expires) {
+			t.Errorf("cache_until = %v, want clamped to %v", got, expires)
+		}
+	})
+
+	t.Run("expired key is PERMISSION_DENIED", func(t *testing.T) {
+		s, dao, _, _ := newSigningKeysTest(t)
+		u, err := dao.FederateUser(ctx, "https://idp.example", "sub", "federated", false)
+		if err != nil {
+			t.Fatalf("FederateUser: %v", err)
+		}
+		k, err := dao.CreateExpiringKey(ctx, u, "login", fixedNow)
+		if err != nil {
+			t.Fatalf("CreateExpiringKey: %v", err)
+		}
+		_, err = s.GetSigningKey(ctx, validReq(k.AccessKeyID))
+		wantTwirpCode(t, err, twirp.PermissionDenied)
+	})
+
 	t.Run("date outside issuance window is PERMISSION_DENIED", func(t *testing.T) {
 		s, _, akid, _ := newSigningKeysTest(t)
 		req := validReq(akid)
@@ -0,0 +1,178 @@
+package sts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"within.website/x/cmd/iamd/models"
+	stsv1 "within.website/x/gen/within/website/x/iam/sts/v1"
+)
+
+// MinWebIdentityDuration is the shortest credential lifetime a caller may
+// request. It matches AWS STS's floor for AssumeRoleWithWebIdentity.
+const MinWebIdentityDuration = 15 * time.Minute
+
+// WebIdentityConfig describes the OIDC issuer iamd trusts and how its claims
+// map onto iamd users.
+type WebIdentityConfig struct {
+	// Issuer is the OIDC issuer URL. Endpoints and signing keys are
+	// discovered from it.
+	Issuer string
+	// ClientID is the audience ID tokens must be issued for.
+	ClientID string
+	// Scopes are advertised to clients by GetLoginConfig. "openid" is always
+	// included.
+	Scopes []string
+	// AdminGroup, if set, makes members of this group administrators. The
+	// admin bit is recomputed on every login, so removing someone from the
+	// group demotes them at their next login.
+	AdminGroup string
+	// RequiredGroup, if set, rejects tokens whose subject is not a member.
+	RequiredGroup string
+	// MaxDuration caps the lifetime of minted keys.
+	MaxDuration time.Duration
+}
+
+// WebIdentity implements stsv1.WebIdentityService: it trades ID tokens from
+// the configured issuer for short-lived iamd keys, provisioning the user on
+// first login.
+type WebIdentity struct {
+	dao      *models.DAO
+	cfg      WebIdentityConfig
+	verifier *oidc.IDTokenVerifier
+
+	// Now is overridable for tests. Defaults to time.Now.
+	Now func() time.Time
+
+	stsv1.UnimplementedWebIdentityServiceServer
+}
+
+// NewWebIdentity discovers cfg.Issuer and returns a WebIdentity server. It
+// fails if the issuer's discovery document cannot be fetched, so iamd does
+// not start with federation silently broken.
+func NewWebIdentity(ctx context.Context, dao *models.DAO, cfg WebIdentityConfig) (*WebIdentity, error) {
+	if cfg.ClientID == "" {
+		return nil, errors.New("sts: web identity client id is required")
+	}
+	if cfg.MaxDuration < MinWebIdentityDuration {
+		return nil, fmt.Errorf("sts: web identity max duration must be at least %s, got %s", MinWebIdentityDuration, cfg.MaxDuration)
+	}
+	if !slices.Contains(cfg.Scopes, oidc.ScopeOpenID) {
+		cfg.Scopes = append([]string{oidc.ScopeOpenID}, cfg.Scopes...)
+	}
+
+	provider, err := oidc.NewProvider(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("sts: discover issuer %s: %w", cfg.Issuer, err)
+	}
+
+	s := &WebIdentity{dao: dao, cfg: cfg}
+	s.verifier = provider.Verifier(&oidc.Config{
+		ClientID: cfg.ClientID,
+		Now:      s.now,
+	})
+
+	return s, nil
+}
+
+// GetLoginConfig tells clients which issuer and client id to log in with.
+func (s *WebIdentity) GetLoginConfig(ctx context.Context, req *stsv1.GetLoginConfigRequest) (*stsv1.GetLoginConfigResponse, error) {
+	return &stsv1.GetLoginConfigResponse{
+		Issuer:   s.cfg.Issuer,
+		ClientId: s.cfg.ClientID,
+		Scopes:   s.cfg.Scopes,
+	}, nil
+}
+
+// idTokenClaims are the claims iamd reads beyond the registered ones the
+// verifier already checked.
+type idTokenClaims struct {
+	PreferredUsername string   `json:"preferred_username"`
+	Email             string   `json:"email"`
+	Groups            []string `json:"groups"`
+}
+
+// AssumeRoleWithWebIdentity verifies the ID token, maps it to a user, and
+// mints a key expiring after the requested (or maximum) duration. The
+// secret is returned once and never logged.
+func (s *WebIdentity) AssumeRoleWithWebIdentity(ctx context.Context, req *stsv1.AssumeRoleWithWebIdentityRequest) (*stsv1.AssumeRoleWithWebIdentityResponse, error) {
+	if req.GetIdToken() == "" {
+		return nil, twirp.RequiredArgumentError("id_token")
+	}
+
+	duration := s.cfg.MaxDuration
+	if ds := req.GetDurationSeconds(); ds != 0 {
+		d := time.Duration(ds) * time.Second
+		if ds < 0 || d < MinWebIdentityDuration {
+			return nil, twirp.InvalidArgumentError("duration_seconds", fmt.Sprintf("must be at least %d", int64(MinWebIdentityDuration/time.Second)))
+		}
+		duration = min(d, s.cfg.MaxDuration)
+	}
+
+	tok, err := s.verifier.Verify(ctx, req.GetIdToken())
+	if err != nil {
+		return nil, twirp.NewError(twirp.Unauthenticated, "id token did not verify")
+	}
+
+	var claims idTokenClaims
+	if err := tok.Claims(&claims); err != nil {
+		return nil, twirp.NewError(twirp.Unauthenticated, "id token claims are malformed")
+	}
+
+	if s.cfg.RequiredGroup != "" && !slices.Contains(claims.Groups, s.cfg.RequiredGroup) {
+		return nil, twirp.NewError(twirp.PermissionDenied, "identity is not a member of the required group")
+	}
+	isAdmin := s.cfg.AdminGroup != "" && slices.Contains(claims.Groups, s.cfg.AdminGroup)
+
+	name := claims.PreferredUsername
+	if name == "" {
+		name = claims.Email
+	}
+	if name == "" {
+		name = tok.Subject
+	}
+
+	u, err := s.dao.FederateUser(ctx, tok.Issuer, tok.Subject, name, isAdmin)
+	if err != nil {
+		if errors.Is(err, models.ErrUserDisabled) {
+			return nil, twirp.NewError(twirp.PermissionDenied, "user is disabled")
+		}
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	if err := s.dao.DisableExpiredKeys(ctx, u); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	expiration := s.now().Add(duration)
+	k, err := s.dao.CreateExpiringKey(ctx, u, "web identity login", expiration)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &stsv1.AssumeRoleWithWebIdentityResponse{
+		AccessKeyId:     k.AccessKeyID,
+		SecretAccessKey: k.SecretAccessKey,
+		Expiration:      timestamppb.New(expiration),
+		Identity: &stsv1.TokenIdentity{
+			AccessKeyId: k.AccessKeyID,
+			PrincipalId: u.UUID,
+			DisplayName: u.Name,
+		},
+		IsAdmin: u.IsAdmin,
+	}, nil
+}
+
+func (s *WebIdentity) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
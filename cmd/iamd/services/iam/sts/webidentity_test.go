@@ -0,0 +1,200 @@
+package sts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	stsv1 "within.website/x/gen/within/website/x/iam/sts/v1"
+	"within.website/x/internal/fakeoidc"
+)
+
+const wiClientID = "iam-cli"
+
+func newWebIdentityTest(t *testing.T, cfg WebIdentityConfig) (*WebIdentity, *fakeoidc.Server) {
+	t.Helper()
+
+	idp, err := fakeoidc.New(wiClientID, fakeoidc.Identity{})
+	if err != nil {
+		t.Fatalf("fakeoidc.New: %v", err)
+	}
+	t.Cleanup(idp.Close)
+
+	cfg.Issuer = idp.Issuer
+	cfg.ClientID = wiClientID
+	if cfg.MaxDuration == 0 {
+		cfg.MaxDuration = 12 * time.Hour
+	}
+
+	s, err := NewWebIdentity(context.Background(), newDAO(t), cfg)
+	if err != nil {
+		t.Fatalf("NewWebIdentity: %v", err)
+	}
+	return s, idp
+}
+
+func mustIDToken(t *testing.T, idp *fakeoidc.Server, who fakeoidc.Identity) string {
+	t.Helper()
+	tok, err := idp.IDToken(who, "")
+	if err != nil {
+		t.Fatalf("IDToken: %v", err)
+	}
+	return tok
+}
+
+func TestAssumeRoleWithWebIdentity(t *testing.T) {
+	ctx := context.Background()
+	alice := fakeoidc.Identity{Subject: "alice-sub", PreferredUsername: "alice", Groups: []string{"staff"}}
+
+	t.Run("first login provisions a user and mints an expiring key", func(t *testing.T) {
+		s, idp := newWebIdentityTest(t, WebIdentityConfig{})
+		before := time.Now()
+
+		resp, err := s.AssumeRoleWithWebIdentity(ctx, &stsv1.AssumeRoleWithWebIdentityRequest{
+			IdToken: mustIDToken(t, idp, alice),
+		})
+		if err != nil {
+			t.Fatalf("AssumeRoleWithWebIdentity: %v", err)
+		}
+		if resp.GetAccessKeyId() == "" || resp.GetSecretAccessKey() == "" {
+			t.Fatal("response is missing credentials")
+		}
+		if got := resp.GetIdentity().GetDisplayName(); got != "alice" {
+			t.Errorf("display_name = %q, want alice", got)
+		}
+		if resp.GetIsAdmin() {
+			t.Error("is_admin = true with no admin group configured")
+		}
+		if exp := resp.GetExpiration().AsTime(); exp.Before(before.Add(12*time.Hour)) || exp.After(time.Now().Add(12*time.Hour)) {
+			t.Errorf("expiration = %v, want about now+12h", exp)
+		}
+
+		secret, err := s.dao.SecretFor(ctx, resp.GetAccessKeyId())
+		if err != nil {
+			t.Fatalf("SecretFor minted key: %v", err)
+		}
+		if secret != resp.GetSecretAccessKey() {
+			t.Error("stored secret does not match the returned one")
+		}
+
+		again, err := s.AssumeRoleWithWebIdentity(ctx, &stsv1.AssumeRoleWithWebIdentityRequest{
+			IdToken: mustIDToken(t, idp, alice),
+		})
+		if err != nil {
+			t.Fatalf("second AssumeRoleWithWebIdentity: %v", err)
+		}
+		if again.GetIdentity().GetPrincipalId() != resp.GetIdentity().GetPrincipalId() {
+			t.Error("second login provisioned a different user")
+		}
+	})
+
+	t.Run("admin group maps to is_admin", func(t *testing.T) {
+		s, idp := newWebIdentityTest(t, WebIdentityConfig{AdminGroup: "staff"})
+		resp, err := s.AssumeRoleWithWebIdentity(ctx, &stsv1.AssumeRoleWithWebIdentityRequest{
+			IdToken: mustIDToken(t, idp, alice),
+		})
+		if err != nil {
+			t.Fatalf("AssumeRoleWithWebIdentity: %v", err)
+		}
+		if !resp.GetIsAdmin() {
+			t.Error("member of admin group is not admin")
+		}
+	})
+
+	t.Run("requested duration is honored and clamped", func(t *testing.T) {
+		s, idp := newWebIdentityTest(t, WebIdentityConfig{MaxDuration: time.Hour})
+
+		for _, tt := range []struct {
+			seconds int64
+			want    time.Duration
+		}{
+			{seconds: 0, want: time.Hour},
+			{seconds: 1800, want: 30 * time.Minute},
+			{seconds: 86400, want: time.Hour},
+		} {
+			resp, err := s.AssumeRoleWithWebIdentity(ctx, &stsv1.AssumeRoleWithWebIdentityRequest{
+				IdToken:         mustIDToken(t, idp, alice),
+				DurationSeconds: tt.seconds,
+			})
+			if err != nil {
+				t.Fatalf("duration %d: %v", tt.seconds, err)
+			}
+			got := time.Until(resp.GetExpiration().AsTime())
+			if d := got - tt.want; d > time.Minute || d < -time.Minute {
+				t.Errorf("duration %d: credential lives %s, want %s", tt.seconds, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("too-short duration is INVALID_ARGUMENT", func(t *testing.T) {
+		s, idp := newWebIdentityTest(t, WebIdentityConfig{})
+		_, err := s.AssumeRoleWithWebIdentity(ctx, &stsv1.AssumeRoleWithWebIdentityRequest{
+			IdToken:         mustIDToken(t, idp, alice),
+			DurationSeconds: 60,
+		})
+		wantTwirpCode(t, err, twirp.InvalidArgument)
+	})
+
+	t.Run("missing required group is PERMISSION_DENIED", func(t *testing.T) {
+		s, idp := newWebIdentityTest(t, WebIdentityConfig{RequiredGroup: "iam-users"})
+		_, err := s.AssumeRoleWithWebIdentity(ctx, &stsv1.AssumeRoleWithWebIdentityRequest{
+			IdToken: mustIDToken(t, idp, alice),
+		})
+		wantTwirpCode(t, err, twirp.PermissionDenied)
+	})
+
+	t.Run("disabled user is PERMISSION_DENIED", func(t *testing.T) {
+		s, idp := newWebIdentityTest(t, WebIdentityConfig{})
+		resp, err := s.AssumeRoleWithWebIdentity(ctx, &stsv1.AssumeRoleWithWebIdentityRequest{
+			IdToken: mustIDToken(t, idp, alice),
+		})
+		if err != nil {
+			t.Fatalf("AssumeRoleWithWebIdentity: %v", err)
+		}
+		if err := s.dao.DisableUser(ctx, resp.GetIdentity().GetPrincipalId(), "test"); err != nil {
+			t.Fatalf("DisableUser: %v", err)
+		}
+		_, err = s.AssumeRoleWithWebIdentity(ctx, &stsv1.AssumeRoleWithWebIdentityRequest{
+			IdToken: mustIDToken(t, idp, alice),
+		})
+		wantTwirpCode(t, err, twirp.PermissionDenied)
+	})
+
+	t.Run("untrusted tokens are UNAUTHENTICATED", func(t *testing.T) {
+		s, idp := newWebIdentityTest(t, WebIdentityConfig{})
+
+		other, err := fakeoidc.New(wiClientID, fakeoidc.Identity{})
+		if err != nil {
+			t.Fatalf("fakeoidc.New: %v", err)
+		}
+		defer other.Close()
+
+		wrongAudience, err := idp.IDToken(alice, "some-other-client")
+		if err != nil {
+			t.Fatalf("IDToken: %v", err)
+		}
+		expired, err := idp.ExpiredIDToken(alice)
+		if err != nil {
+			t.Fatalf("ExpiredIDToken: %v", err)
+		}
+		otherIssuer, err := other.IDToken(alice, "")
+		if err != nil {
+			t.Fatalf("IDToken: %v", err)
+		}
+
+		for name, tok := range map[string]string{
+			"wrong audience": wrongAudience,
+			"expired":        expired,
+			"other issuer":   otherIssuer,
+			"garbage":        "not.a.jwt",
+		} {
+			_, err := s.AssumeRoleWithWebIdentity(ctx, &stsv1.AssumeRoleWithWebIdentityRequest{IdToken: tok})
+			if err == nil {
+				t.Fatalf("%s: token accepted", name)
+			}
+			wantTwirpCode(t, err, twirp.Unauthenticated)
+		}
+	})
+}
@@ -15,8 +15,10 @@ import (
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"within.website/x/cmd/mi/models"
 	"within.website/x/cmd/mi/services/events"
+	"within.website/x/cmd/mi/services/exporter"
 	"within.website/x/cmd/mi/services/glance"
 	"within.website/x/cmd/mi/services/homefrontshim"
 	"within.website/x/cmd/mi/services/importer"
@@ -24,6 +26,7 @@ import (
 	"within.website/x/cmd/mi/services/posse"
 	"within.website/x/cmd/mi/services/switchtracker"
 	"within.website/x/cmd/mi/services/twitchevents"
+	"within.website/x/cmd/mi/services/webhooks"
 	pb "within.website/x/gen/within/website/x/mi/v1"
 	announcev1 "within.website/x/gen/within/website/x/mimi/announce/v1"
 	"within.website/x/internal"
@@ -39,6 +42,10 @@ var (
 	// Events flags
 	flyghtTrackerURL = flag.String("flyght-tracker-url", "", "Flyght Tracker URL")
 
+	// MCP flags
+	mcpCredentials = flag.String("mcp-credentials", "", "JSON file with the credentials MCP clients can use, if unset the MCP server is unauthenticated")
+	mcpRegion      = flag.String("mcp-region", "us-east-1", "region MCP clients using SigV4 must sign for")
+
 	// POSSE flags
 	blueskyAuthkey      = flag.String("bsky-authkey", "", "Bluesky authkey")
 	blueskyHandle       = flag.String("bsky-handle", "", "Bluesky handle")
@@ -47,6 +54,9 @@ var (
 	mastodonURL         = flag.String("mastodon-url", "", "Mastodon URL")
 	mastodonUsername    = flag.String("mastodon-username", "", "Mastodon username")
 	mimiAnnounceURL     = flag.String("mimi-announce-url", "", "Mimi announce URL")
+	posseFeedURL        = flag.String("posse-feed-url", "", "Blog JSON Feed URL, checked for edited and removed blogposts")
+	posseBlueskyTmpl    = flag.String("posse-bluesky-template", "", "text/template for Bluesky announcements (empty means the default)")
+	posseMastodonTmpl   = flag.String("posse-mastodon-template", "", "text/template for Mastodon announcements (empty means the default)")
 	twitchClientID      = flag.String("twitch-client-id", "", "twitch.tv client ID")
 	twitchClientSecret  = flag.String("twitch-client-secret", "", "twitch.tv client secret")
 	twitchUserID        = flag.Int("twitch-user-id", 105794391, "twitch.tv user ID")
@@ -81,6 +91,8 @@ func main() {
 
 	mux := http.NewServeMux()
 
+	bus := webhooks.NewBus(dao)
+
 	ann, err := posse.New(ctx, dao, posse.Config{
 		BlueskyAuthkey:  *blueskyAuthkey,
 		BlueskyHandle:   *blueskyHandle,
@@ -88,6 +100,12 @@ func main() {
 		MastodonToken:   *mastodonToken,
 		MastodonURL:     *mastodonURL,
 		MimiAnnounceURL: *mimiAnnounceURL,
+		FeedURL:         *posseFeedURL,
+		Templates: map[string]string{
+			"bluesky":  *posseBlueskyTmpl,
+			"mastodon": *posseMastodonTmpl,
+		},
+		Publisher: bus,
 	})
 	if err != nil {
 		slog.Error("failed to create announcer", "err", err)
@@ -116,8 +134,10 @@ func main() {
 		mux.Handle("/twitch", te)
 	}
 
-	st := switchtracker.New(dao)
-	es := events.New(dao, *flyghtTrackerURL)
+	st := switchtracker.New(dao, bus)
+	es := events.New(dao, bus, *flyghtTrackerURL)
+	wh := webhooks.New(dao, bus)
+	ps := posse.NewServer(ann)
 
 	gs := grpc.NewServer()
 
@@ -126,6 +146,8 @@ func main() {
 	announcev1.RegisterAnnounceServer(gs, ann)
 	pb.RegisterSwitchTrackerServer(gs, st)
 	pb.RegisterEventsServer(gs, es)
+	pb.RegisterWebhooksServer(gs, wh)
+	pb.RegisterPOSSEServer(gs, ps)
 
 	mux.Handle(announcev1.AnnouncePathPrefix, announcev1.NewAnnounceServer(ann))
 	mux.Handle(pb.SwitchTrackerPathPrefix, pb.NewSwitchTrackerServer(st))
@@ -144,13 +166,37 @@ func main() {
 		pb.NewEventsServer(es).ServeHTTP(w, r)
 	})
 
+	mux.Handle(pb.WebhooksPathPrefix, pb.NewWebhooksServer(wh))
+	mux.Handle(pb.POSSEPathPrefix, pb.NewPOSSEServer(ps))
+
 	mux.Handle("/front", homefrontshim.New(dao))
 	mux.Handle("/glance", glance.New(dao))
-	mux.Handle("/mcp", mcp.New(st, es))
+	var mcpCreds []mcp.Credential
+	if *mcpCredentials != "" {
+		mcpCreds, err = mcp.LoadCredentials(*mcpCredentials)
+		if err != nil {
+			slog.Error("failed to load mcp credentials", "err", err)
+			os.Exit(1)
+		}
+	}
+
+	mcpHandler, err := mcp.New(st, es, mcp.Options{
+		Credentials: mcpCreds,
+		Region:      *mcpRegion,
+		Auditor:     dao,
+	})
+	if err != nil {
+		slog.Error("failed to create mcp server", "err", err)
+		os.Exit(1)
+	}
+	mux.Handle("/mcp", mcpHandler)
 
 	i := importer.New(dao)
 	i.Mount(mux)
 
+	ex := exporter.New(dao)
+	ex.Mount(mux)
+
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
 		if err := dao.Ping(r.Context()); err != nil {
 			slog.ErrorContext(r.Context(), "database not healthy", "err", err)
@@ -189,11 +235,25 @@ func main() {
 		return ctx.Err()
 	})
 
+	g.Go(func() error {
+		bus.Run(ctx)
+		return nil
+	})
+
 	g.Go(func() error {
 		c := cron.New()
 		if _, err := c.AddFunc("@every 1h", dao.Backup); err != nil {
 			return fmt.Errorf("failed to add cron job: %w", err)
 		}
+		if *posseFeedURL != "" {
+			if _, err := c.AddFunc("@every 30m", func() {
+				if _, err := ps.RefreshBlog(ctx, &emptypb.Empty{}); err != nil {
+					slog.ErrorContext(ctx, "can't refresh blog", "err", err)
+				}
+			}); err != nil {
+				return fmt.Errorf("failed to add cron job: %w", err)
+			}
+		}
 		c.Start()
 		<-ctx.Done()
 		c.Stop()
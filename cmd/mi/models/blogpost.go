@@ -45,3 +45,89 @@ func (d *DAO) InsertBlogpost(ctx context.Context, post *jsonfeedv1.Item) (*Blogp
 
 	return bp, nil
 }
+
+// UpdateBlogpost updates the stored copy of a blogpost that was edited.
+func (d *DAO) UpdateBlogpost(ctx context.Context, post *jsonfeedv1.Item) error {
+	return d.db.WithContext(ctx).
+		Model(&Blogpost{}).
+		Where("url = ?", post.GetUrl()).
+		Updates(map[string]any{
+			"title":     post.GetTitle(),
+			"body_html": post.GetContentHtml(),
+		}).Error
+}
+
+// BlogpostsPublishedSince returns the blogposts published at or after t.
+func (d *DAO) BlogpostsPublishedSince(ctx context.Context, t time.Time) ([]Blogpost, error) {
+	var result []Blogpost
+	if err := d.db.WithContext(ctx).Where("published_at >= ?", t).Order("published_at").Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteBlogpost forgets about a blogpost and the posts made for it.
+func (d *DAO) DeleteBlogpost(ctx context.Context, postURL string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(&Blogpost{}, "url = ?", postURL).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&SyndicatedPost{}, "blogpost_url = ?", postURL).Error
+	})
+}
+
+// SyndicatedPost is a post on a social network made by the Announcer for a
+// blogpost. Long announcements are split into threads, with one
+// SyndicatedPost per post in the thread.
+//
+// These are tracked so that edits and removals of blogposts can be synced
+// to the social networks.
+type SyndicatedPost struct {
+	ID          uint `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	BlogpostURL string `gorm:"index"` // URL of the blogpost
+	Network     string `gorm:"index"` // social network, such as "bluesky" or "mastodon"
+	Position    int    // position in the thread, starting at 0
+	RemoteID    string // Mastodon status ID or Bluesky at:// URI
+	CID         string // Bluesky record CID
+	URL         string // URL of the post on the web
+	Text        string // text of the post
+}
+
+// SyndicatedPosts returns the thread made for a blogpost on a social network,
+// in order.
+func (d *DAO) SyndicatedPosts(ctx context.Context, postURL, network string) ([]SyndicatedPost, error) {
+	var result []SyndicatedPost
+	if err := d.db.WithContext(ctx).
+		Where("blogpost_url = ? AND network = ?", postURL, network).
+		Order("position").
+		Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReplaceSyndicatedPosts replaces the thread recorded for a blogpost on a
+// social network.
+func (d *DAO) ReplaceSyndicatedPosts(ctx context.Context, postURL, network string, posts []SyndicatedPost) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&SyndicatedPost{}, "blogpost_url = ? AND network = ?", postURL, network).Error; err != nil {
+			return err
+		}
+
+		if len(posts) == 0 {
+			return nil
+		}
+
+		return tx.Create(&posts).Error
+	})
+}
+
+// UpdateSyndicatedPost saves a post that was edited.
+func (d *DAO) UpdateSyndicatedPost(ctx context.Context, sp *SyndicatedPost) error {
+	return d.db.WithContext(ctx).Save(sp).Error
+}
@@ -53,7 +53,12 @@ func New(dbLoc, backupDBLoc string) (*DAO, error) {
 		&Member{},
 		&Switch{},
 		&Blogpost{},
+		&SyndicatedPost{},
 		&Event{},
+		&WebhookSubscription{},
+		&WebhookDelivery{},
+		&WebhookDeadLetter{},
+		&MCPCall{},
 	); err != nil {
 		return nil, fmt.Errorf("failed to migrate schema: %w", err)
 	}
@@ -175,6 +180,19 @@ func (d *DAO) ListSwitches(ctx context.Context, count, page int) ([]Switch, erro
 	return switches, nil
 }
 
+// AllSwitches returns the whole switch history, oldest first.
+func (d *DAO) AllSwitches(ctx context.Context) ([]Switch, error) {
+	var switches []Switch
+	if err := d.db.WithContext(ctx).
+		Joins("Member").
+		Order("created_at").
+		Find(&switches).Error; err != nil {
+		return nil, err
+	}
+
+	return switches, nil
+}
+
 func (d *DAO) Backup() {
 	slog.Info("starting backup")
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
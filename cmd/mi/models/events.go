@@ -2,6 +2,7 @@ package models
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -19,6 +20,7 @@ type Event struct {
 	EndDate     time.Time
 	Location    string `gorm:"index"`
 	Description string
+	Recurrence  string // RFC 5545 recurrence rule, see Recurrence
 }
 
 func (e *Event) AsProto() *pb.Event {
@@ -30,9 +32,33 @@ func (e *Event) AsProto() *pb.Event {
 		EndDate:     timestamppb.New(e.EndDate),
 		Location:    e.Location,
 		Description: e.Description,
+		Recurrence:  e.Recurrence,
 	}
 }
 
+// NextOccurrence returns a copy of the event moved to its first occurrence
+// that hasn't ended by now. It returns false if the event is over.
+func (e Event) NextOccurrence(now time.Time) (Event, bool) {
+	if e.Recurrence == "" {
+		return e, !e.EndDate.Before(now)
+	}
+
+	rule, err := ParseRecurrence(e.Recurrence)
+	if err != nil {
+		return e, !e.EndDate.Before(now)
+	}
+
+	length := e.EndDate.Sub(e.StartDate)
+	start, ok := rule.Next(e.StartDate, length, now)
+	if !ok {
+		return e, false
+	}
+
+	e.StartDate = start
+	e.EndDate = start.Add(length)
+	return e, true
+}
+
 func (d *DAO) CreateEvent(ctx context.Context, event *Event) (*Event, error) {
 	return event, d.db.WithContext(ctx).Create(event).Error
 }
@@ -46,17 +72,46 @@ func (d *DAO) GetEvent(ctx context.Context, id int) (*Event, error) {
 	return &event, nil
 }
 
+// UpcomingEvents returns the next count events that haven't ended yet.
+// Recurring events are moved to their next occurrence.
 func (d *DAO) UpcomingEvents(ctx context.Context, count int) ([]Event, error) {
+	now := time.Now()
+
 	var events []Event
 	if err := d.db.
 		WithContext(ctx).
-		Where("end_date >= ?", time.Now()).
-		Limit(count).
+		Where("end_date >= ? OR recurrence <> ''", now).
 		Order("start_date").
 		Find(&events).Error; err != nil {
 		return nil, err
 	}
 
+	var result []Event
+	for _, event := range events {
+		if next, ok := event.NextOccurrence(now); ok {
+			result = append(result, next)
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b Event) int {
+		return a.StartDate.Compare(b.StartDate)
+	})
+
+	if len(result) > count {
+		result = result[:count]
+	}
+
+	return result, nil
+}
+
+// AllEvents returns every event, including ones that are over, in the order
+// they were added.
+func (d *DAO) AllEvents(ctx context.Context) ([]Event, error) {
+	var events []Event
+	if err := d.db.WithContext(ctx).Order("id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+
 	return events, nil
 }
 
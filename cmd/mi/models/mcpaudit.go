@@ -0,0 +1,36 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// MCPCall is an audit record of a tool invocation made over MCP.
+type MCPCall struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	Caller    string `gorm:"index"` // name of the credential that made the call
+	Tool      string `gorm:"index"`
+	Arguments string // raw JSON arguments
+	Allowed   bool   // false if the caller isn't allowed to use the tool
+	Error     string
+	Duration  time.Duration
+}
+
+// RecordMCPCall saves an audit record of a tool invocation.
+func (d *DAO) RecordMCPCall(ctx context.Context, call *MCPCall) error {
+	return d.db.WithContext(ctx).Create(call).Error
+}
+
+// MCPCalls returns the count most recent tool invocations, newest first.
+func (d *DAO) MCPCalls(ctx context.Context, count int) ([]MCPCall, error) {
+	var result []MCPCall
+	if err := d.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(count).
+		Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrBadRecurrence = errors.New("models: bad recurrence rule")
+
+// maxOccurrences bounds how far a recurrence is followed looking for an
+// occurrence, so that an unbounded rule can't loop forever.
+const maxOccurrences = 100_000
+
+// Recurrence is the subset of an RFC 5545 recurrence rule that mi
+// understands: FREQ, INTERVAL, COUNT and UNTIL. It's enough for "every
+// year" or "every other week until June" and is passed through to
+// calendars as-is.
+type Recurrence struct {
+	Freq     string    // DAILY, WEEKLY, MONTHLY or YEARLY
+	Interval int       // repeat every Interval periods, at least 1
+	Count    int       // number of occurrences, 0 means no limit
+	Until    time.Time // last day an occurrence may start on, zero means no limit
+}
+
+// ParseRecurrence parses a recurrence rule such as "FREQ=YEARLY;COUNT=5".
+// An "RRULE:" prefix is allowed.
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	r := &Recurrence{Interval: 1}
+
+	for part := range strings.SplitSeq(rule, ";") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q isn't a KEY=VALUE pair", ErrBadRecurrence, part)
+		}
+
+		switch strings.ToUpper(k) {
+		case "FREQ":
+			switch v = strings.ToUpper(v); v {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				r.Freq = v
+			default:
+				return nil, fmt.Errorf("%w: unsupported FREQ %q", ErrBadRecurrence, v)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: INTERVAL must be a positive number", ErrBadRecurrence)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: COUNT must be a positive number", ErrBadRecurrence)
+			}
+			r.Count = n
+		case "UNTIL":
+			t, err := parseUntil(v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: bad UNTIL: %w", ErrBadRecurrence, err)
+			}
+			r.Until = t
+		default:
+			return nil, fmt.Errorf("%w: unsupported rule part %s", ErrBadRecurrence, k)
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrBadRecurrence)
+	}
+	if r.Count != 0 && !r.Until.IsZero() {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL can't both be set", ErrBadRecurrence)
+	}
+
+	return r, nil
+}
+
+func parseUntil(v string) (time.Time, error) {
+	if t, err := time.Parse("20060102", v); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("20060102T150405Z", v)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.Truncate(24 * time.Hour), nil
+}
+
+// String returns the rule in canonical form, without an "RRULE:" prefix.
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if r.Count != 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Occurrences calls yield with the start of each occurrence of something
+// that first starts at start, in order, until yield returns false or the
+// rule runs out. Like RFC 5545, dates that don't exist (such as February
+// 30th) are skipped and don't count towards COUNT.
+func (r Recurrence) Occurrences(start time.Time, yield func(time.Time) bool) {
+	count := 0
+
+	for i := range maxOccurrences {
+		n := i * r.Interval
+		var t time.Time
+		var byMonth bool
+
+		switch r.Freq {
+		case "DAILY":
+			t = start.AddDate(0, 0, n)
+		case "WEEKLY":
+			t = start.AddDate(0, 0, 7*n)
+		case "MONTHLY":
+			t, byMonth = start.AddDate(0, n, 0), true
+		case "YEARLY":
+			t, byMonth = start.AddDate(n, 0, 0), true
+		default:
+			return
+		}
+
+		// AddDate normalizes dates that don't exist, such as January 31st
+		// plus one month.
+		if byMonth && t.Day() != start.Day() {
+			continue
+		}
+
+		if !r.Until.IsZero() && t.Truncate(24*time.Hour).After(r.Until) {
+			return
+		}
+
+		if !yield(t) {
+			return
+		}
+
+		count++
+		if r.Count != 0 && count >= r.Count {
+			return
+		}
+	}
+}
+
+// Next returns the start of the first occurrence that hasn't ended by t,
+// where each occurrence lasts for d.
+func (r Recurrence) Next(start time.Time, d time.Duration, t time.Time) (time.Time, bool) {
+	var result time.Time
+	var found bool
+
+	r.Occurrences(start, func(occ time.Time) bool {
+		if occ.Add(d).Before(t) {
+			return true
+		}
+
+		result, found = occ, true
+		return false
+	})
+
+	return result, found
+}
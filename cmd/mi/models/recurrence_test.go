@@ -0,0 +1,122 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseRecurrence(t *testing.T) {
+	for _, tt := range []struct {
+		name, rule, want string
+		err              bool
+	}{
+		{name: "yearly", rule: "FREQ=YEARLY", want: "FREQ=YEARLY"},
+		{name: "prefix and case", rule: "RRULE:freq=weekly;interval=2", want: "FREQ=WEEKLY;INTERVAL=2"},
+		{name: "count", rule: "FREQ=MONTHLY;COUNT=3", want: "FREQ=MONTHLY;COUNT=3"},
+		{name: "until datetime", rule: "FREQ=DAILY;UNTIL=20250601T120000Z", want: "FREQ=DAILY;UNTIL=20250601"},
+		{name: "no freq", rule: "COUNT=3", err: true},
+		{name: "bad freq", rule: "FREQ=HOURLY", err: true},
+		{name: "bad interval", rule: "FREQ=DAILY;INTERVAL=0", err: true},
+		{name: "count and until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20250601", err: true},
+		{name: "unsupported part", rule: "FREQ=WEEKLY;BYDAY=MO", err: true},
+		{name: "empty", rule: "", err: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			if tt.err {
+				if !errors.Is(err, ErrBadRecurrence) {
+					t.Fatalf("want ErrBadRecurrence, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := r.String(); got != tt.want {
+				t.Errorf("got: %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		rule  string
+		start time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "weekly count",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			start: date(2025, time.January, 1),
+			want:  []time.Time{date(2025, time.January, 1), date(2025, time.January, 15), date(2025, time.January, 29)},
+		},
+		{
+			name:  "monthly skips missing days",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			start: date(2025, time.January, 31),
+			want:  []time.Time{date(2025, time.January, 31), date(2025, time.March, 31), date(2025, time.May, 31)},
+		},
+		{
+			name:  "yearly until",
+			rule:  "FREQ=YEARLY;UNTIL=20270301",
+			start: date(2025, time.March, 1),
+			want:  []time.Time{date(2025, time.March, 1), date(2026, time.March, 1), date(2027, time.March, 1)},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []time.Time
+			r.Occurrences(tt.start, func(occ time.Time) bool {
+				got = append(got, occ)
+				return len(got) < 10
+			})
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d: got: %v, want: %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEventNextOccurrence(t *testing.T) {
+	ev := Event{
+		Name:       "Anniversary",
+		StartDate:  date(2023, time.June, 10),
+		EndDate:    date(2023, time.June, 11),
+		Recurrence: "FREQ=YEARLY;COUNT=4",
+	}
+
+	next, ok := ev.NextOccurrence(date(2025, time.June, 11))
+	if !ok {
+		t.Fatal("want an occurrence")
+	}
+	if !next.StartDate.Equal(date(2025, time.June, 10)) || !next.EndDate.Equal(date(2025, time.June, 11)) {
+		t.Errorf("got %v to %v, want the 2025 occurrence", next.StartDate, next.EndDate)
+	}
+
+	if _, ok := ev.NextOccurrence(date(2026, time.June, 12)); ok {
+		t.Error("want no occurrences after the last one")
+	}
+
+	ev.Recurrence = ""
+	if _, ok := ev.NextOccurrence(date(2024, time.January, 1)); ok {
+		t.Error("want a one-off event in the past to be over")
+	}
+}
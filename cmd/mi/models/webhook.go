@@ -0,0 +1,270 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+	pb "within.website/x/gen/within/website/x/mi/v1"
+)
+
+// WebhookSubscription is an endpoint that mi sends its events to.
+type WebhookSubscription struct {
+	ID          string `gorm:"primaryKey"` // ULID
+	CreatedAt   time.Time
+	Kind        pb.SubscriptionKind
+	URL         string
+	Secret      string // HMAC-SHA256 key for payload signatures
+	EventTypes  string // comma-separated list of event types, empty means all
+	Description string
+}
+
+// Wants reports whether events of the given type should be sent to the
+// subscription.
+func (ws *WebhookSubscription) Wants(eventType string) bool {
+	if ws.EventTypes == "" {
+		return true
+	}
+
+	return slices.Contains(strings.Split(ws.EventTypes, ","), eventType)
+}
+
+// AsProto converts a WebhookSubscription to its protobuf representation.
+// The secret is left out.
+func (ws *WebhookSubscription) AsProto() *pb.Subscription {
+	result := &pb.Subscription{
+		Id:          ws.ID,
+		Kind:        ws.Kind,
+		Url:         ws.URL,
+		CreatedAt:   timestamppb.New(ws.CreatedAt),
+		Description: ws.Description,
+	}
+
+	if ws.EventTypes != "" {
+		result.EventTypes = strings.Split(ws.EventTypes, ",")
+	}
+
+	return result
+}
+
+// WebhookDelivery is an event waiting to be sent to a subscription.
+type WebhookDelivery struct {
+	ID             string `gorm:"primaryKey"` // ULID
+	CreatedAt      time.Time
+	SubscriptionID string `gorm:"index"`
+	EventID        string
+	EventType      string
+	Payload        []byte    // JSON payload, signed when it is sent
+	Attempts       int       // failed attempts so far
+	NextAttemptAt  time.Time `gorm:"index"`
+	LastError      string
+}
+
+// WebhookDeadLetter is a delivery that failed too many times.
+type WebhookDeadLetter struct {
+	ID             string `gorm:"primaryKey"` // ID of the delivery
+	FailedAt       time.Time
+	SubscriptionID string `gorm:"index"`
+	EventID        string
+	EventType      string
+	Payload        []byte
+	Attempts       int
+	LastError      string
+}
+
+// AsProto converts a WebhookDeadLetter to its protobuf representation.
+func (wdl *WebhookDeadLetter) AsProto() *pb.DeadLetter {
+	return &pb.DeadLetter{
+		Id:             wdl.ID,
+		SubscriptionId: wdl.SubscriptionID,
+		EventId:        wdl.EventID,
+		EventType:      wdl.EventType,
+		Attempts:       int32(wdl.Attempts),
+		LastError:      wdl.LastError,
+		FailedAt:       timestamppb.New(wdl.FailedAt),
+		Payload:        string(wdl.Payload),
+	}
+}
+
+func (d *DAO) CreateWebhookSubscription(ctx context.Context, ws *WebhookSubscription) (*WebhookSubscription, error) {
+	if ws.ID == "" {
+		ws.ID = ulid.MustNew(ulid.Now(), rand.Reader).String()
+	}
+
+	return ws, d.db.WithContext(ctx).Create(ws).Error
+}
+
+func (d *DAO) GetWebhookSubscription(ctx context.Context, id string) (*WebhookSubscription, error) {
+	var ws WebhookSubscription
+	if err := d.db.WithContext(ctx).Where("id = ?", id).First(&ws).Error; err != nil {
+		return nil, err
+	}
+
+	return &ws, nil
+}
+
+func (d *DAO) ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error) {
+	var result []WebhookSubscription
+	if err := d.db.WithContext(ctx).Order("created_at").Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteWebhookSubscription removes a subscription along with its pending
+// deliveries. Its dead letters are kept for inspection.
+func (d *DAO) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&WebhookSubscription{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Delete(&WebhookDelivery{}, "subscription_id = ?", id).Error
+	})
+}
+
+// EnqueueWebhookDeliveries queues an event for every subscription that
+// wants it, due at now, and returns how many deliveries were queued.
+func (d *DAO) EnqueueWebhookDeliveries(ctx context.Context, now time.Time, eventID, eventType string, payload []byte) (int, error) {
+	subs, err := d.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var deliveries []WebhookDelivery
+	for _, sub := range subs {
+		if !sub.Wants(eventType) {
+			continue
+		}
+
+		deliveries = append(deliveries, WebhookDelivery{
+			ID:             ulid.MustNew(ulid.Now(), rand.Reader).String(),
+			SubscriptionID: sub.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        payload,
+			NextAttemptAt:  now,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	if err := d.db.WithContext(ctx).Create(&deliveries).Error; err != nil {
+		return 0, err
+	}
+
+	return len(deliveries), nil
+}
+
+// DueWebhookDeliveries returns up to count deliveries that should be
+// attempted now, oldest first.
+func (d *DAO) DueWebhookDeliveries(ctx context.Context, now time.Time, count int) ([]WebhookDelivery, error) {
+	var result []WebhookDelivery
+	if err := d.db.WithContext(ctx).
+		Where("next_attempt_at <= ?", now).
+		Order("next_attempt_at").
+		Limit(count).
+		Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// WebhookDelivered removes a delivery that was sent.
+func (d *DAO) WebhookDelivered(ctx context.Context, id string) error {
+	return d.db.WithContext(ctx).Delete(&WebhookDelivery{}, "id = ?", id).Error
+}
+
+// WebhookDeliveryFailed records a failed attempt and when to try again.
+func (d *DAO) WebhookDeliveryFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error {
+	return d.db.WithContext(ctx).
+		Model(&WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}).Error
+}
+
+// DeadLetterWebhookDelivery moves a delivery that failed for the last
+// time to the dead-letter table.
+func (d *DAO) DeadLetterWebhookDelivery(ctx context.Context, wd *WebhookDelivery, lastError string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&WebhookDeadLetter{
+			ID:             wd.ID,
+			FailedAt:       time.Now(),
+			SubscriptionID: wd.SubscriptionID,
+			EventID:        wd.EventID,
+			EventType:      wd.EventType,
+			Payload:        wd.Payload,
+			Attempts:       wd.Attempts + 1,
+			LastError:      lastError,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&WebhookDelivery{}, "id = ?", wd.ID).Error
+	})
+}
+
+// ListWebhookDeadLetters lists the newest dead letters, optionally only
+// those of one subscription.
+func (d *DAO) ListWebhookDeadLetters(ctx context.Context, subscriptionID string, count int) ([]WebhookDeadLetter, error) {
+	q := d.db.WithContext(ctx).Order("failed_at DESC").Limit(count)
+	if subscriptionID != "" {
+		q = q.Where("subscription_id = ?", subscriptionID)
+	}
+
+	var result []WebhookDeadLetter
+	if err := q.Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RetryWebhookDeadLetter moves a dead letter back to the delivery queue
+// with a fresh set of attempts.
+func (d *DAO) RetryWebhookDeadLetter(ctx context.Context, id string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var wdl WebhookDeadLetter
+		if err := tx.Where("id = ?", id).First(&wdl).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&WebhookSubscription{}).Where("id = ?", wdl.SubscriptionID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Create(&WebhookDelivery{
+			ID:             wdl.ID,
+			SubscriptionID: wdl.SubscriptionID,
+			EventID:        wdl.EventID,
+			EventType:      wdl.EventType,
+			Payload:        wdl.Payload,
+			NextAttemptAt:  time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&wdl).Error
+	})
+}
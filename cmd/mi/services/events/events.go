@@ -10,21 +10,25 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"gorm.io/gorm"
 	"within.website/x/cmd/mi/models"
+	"within.website/x/cmd/mi/services/webhooks"
 	pb "within.website/x/gen/within/website/x/mi/v1"
 )
 
 type Events struct {
 	dao *models.DAO
+	pub webhooks.Publisher
 
 	pb.UnimplementedEventsServer
 }
 
 var _ pb.Events = &Events{}
 
-// New creates a new Events service.
-func New(dao *models.DAO, flyghtTrackerURL string) *Events {
+// New creates a new Events service. Added and removed events are published
+// to pub if it is not nil.
+func New(dao *models.DAO, pub webhooks.Publisher, flyghtTrackerURL string) *Events {
 	result := &Events{
 		dao: dao,
+		pub: pub,
 	}
 
 	return result
@@ -57,6 +61,15 @@ func (e *Events) Get(ctx context.Context, _ *emptypb.Empty) (*pb.EventFeed, erro
 
 // Add adds a new event to the database.
 func (e *Events) Add(ctx context.Context, ev *pb.Event) (*emptypb.Empty, error) {
+	var recurrence string
+	if ev.GetRecurrence() != "" {
+		rule, err := models.ParseRecurrence(ev.GetRecurrence())
+		if err != nil {
+			return nil, twirp.InvalidArgumentError("recurrence", err.Error())
+		}
+		recurrence = rule.String()
+	}
+
 	event := &models.Event{
 		Name:        ev.Name,
 		URL:         ev.Url,
@@ -64,6 +77,7 @@ func (e *Events) Add(ctx context.Context, ev *pb.Event) (*emptypb.Empty, error)
 		EndDate:     ev.EndDate.AsTime(),
 		Location:    ev.Location,
 		Description: ev.Description,
+		Recurrence:  recurrence,
 	}
 
 	_, err := e.dao.CreateEvent(ctx, event)
@@ -73,14 +87,40 @@ func (e *Events) Add(ctx context.Context, ev *pb.Event) (*emptypb.Empty, error)
 
 	slog.InfoContext(ctx, "tracking new event", "event", event)
 
+	e.publish(ctx, webhooks.TypeEventAdded, "New event: ", event.AsProto())
+
 	return &emptypb.Empty{}, nil
 }
 
 func (e *Events) Remove(ctx context.Context, req *pb.Event) (*emptypb.Empty, error) {
+	removed := req
+	if event, err := e.dao.GetEvent(ctx, int(req.Id)); err == nil {
+		removed = event.AsProto()
+	}
+
 	err := e.dao.RemoveEvent(ctx, int(req.Id))
 	if err != nil {
 		return nil, err
 	}
 
+	e.publish(ctx, webhooks.TypeEventRemoved, "Event removed: ", removed)
+
 	return &emptypb.Empty{}, nil
 }
+
+// publish tells subscribers about a change to the events. Failing to do so
+// doesn't fail the change.
+func (e *Events) publish(ctx context.Context, eventType, titlePrefix string, ev *pb.Event) {
+	if e.pub == nil {
+		return
+	}
+
+	if err := e.pub.Publish(ctx, webhooks.Event{
+		Type:  eventType,
+		Title: titlePrefix + ev.GetName(),
+		URL:   ev.GetUrl(),
+		Data:  ev,
+	}); err != nil {
+		slog.ErrorContext(ctx, "can't publish event change", "type", eventType, "id", ev.GetId(), "err", err)
+	}
+}
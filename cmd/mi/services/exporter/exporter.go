@@ -0,0 +1,326 @@
+// Package exporter serves mi's data as backups and feeds.
+//
+// Exports use the same formats as the importer, so a backup can be restored
+// into a fresh database by importing members, then switches, then events.
+// The events and switch history are also available as iCalendar and JSON
+// Feed subscriptions.
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"within.website/x/cmd/mi/models"
+	pb "within.website/x/gen/within/website/x/mi/v1"
+)
+
+// timeLayout is the layout the importer expects for times in switch CSV.
+const timeLayout = "2006-01-02 15:04:05"
+
+const defaultFeedCount = 50
+
+func New(dao *models.DAO) *Exporter {
+	return &Exporter{
+		dao: dao,
+		now: time.Now,
+	}
+}
+
+type Exporter struct {
+	dao *models.DAO
+	now func() time.Time
+}
+
+func (e *Exporter) Mount(mux *http.ServeMux) {
+	mux.HandleFunc("GET /.within/mi/export/members", e.exportMembers)
+	mux.HandleFunc("GET /.within/mi/export/switches", e.exportSwitches)
+	mux.HandleFunc("GET /.within/mi/export/events", e.exportEvents)
+
+	mux.HandleFunc("GET /.within/mi/feeds/events.ics", e.eventsCalendar)
+	mux.HandleFunc("GET /.within/mi/feeds/events.json", e.eventsFeed)
+	mux.HandleFunc("GET /.within/mi/feeds/switches.ics", e.switchesCalendar)
+	mux.HandleFunc("GET /.within/mi/feeds/switches.json", e.switchesFeed)
+}
+
+// format returns the format asked for with the format query parameter, or
+// def if there isn't one.
+func format(r *http.Request, def string) string {
+	if f := r.URL.Query().Get("format"); f != "" {
+		return f
+	}
+
+	return def
+}
+
+// exportMembers exports all members as a JSON array of Member messages, or
+// as CSV with ?format=csv. Only the CSV format has aliases.
+func (e *Exporter) exportMembers(w http.ResponseWriter, r *http.Request) {
+	members, err := e.dao.Members(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "can't fetch members", "err", err)
+		http.Error(w, "can't fetch members", http.StatusInternalServerError)
+		return
+	}
+
+	switch format(r, "json") {
+	case "json":
+		result := make([]*pb.Member, 0, len(members))
+		for _, m := range members {
+			result = append(result, m.AsProto())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="members.json"`)
+		json.NewEncoder(w).Encode(result)
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="members.csv"`)
+
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"id", "name", "avatar_url", "birthday", "aliases"})
+		for _, m := range members {
+			var birthday string
+			if m.Birthday != nil {
+				birthday = m.Birthday.UTC().Format(time.RFC3339)
+			}
+
+			cw.Write([]string{strconv.Itoa(m.ID), m.Name, m.AvatarURL, birthday, m.Aliases})
+		}
+		cw.Flush()
+
+		if err := cw.Error(); err != nil {
+			slog.ErrorContext(r.Context(), "can't write members", "err", err)
+		}
+	default:
+		http.Error(w, "format must be json or csv", http.StatusBadRequest)
+	}
+}
+
+// exportSwitches exports the switch history, oldest first, as CSV rows the
+// importer reads, or as a JSON array of Switch messages with ?format=json.
+// There is no header row so the CSV can be imported as-is.
+func (e *Exporter) exportSwitches(w http.ResponseWriter, r *http.Request) {
+	switches, err := e.dao.AllSwitches(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "can't fetch switches", "err", err)
+		http.Error(w, "can't fetch switches", http.StatusInternalServerError)
+		return
+	}
+
+	switch format(r, "csv") {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="switches.csv"`)
+
+		cw := csv.NewWriter(w)
+		for _, sw := range switches {
+			var endedAt string
+			if sw.EndedAt != nil {
+				endedAt = sw.EndedAt.UTC().Format(timeLayout)
+			}
+
+			cw.Write([]string{sw.ID, strconv.Itoa(sw.MemberID), sw.CreatedAt.UTC().Format(timeLayout), endedAt})
+		}
+		cw.Flush()
+
+		if err := cw.Error(); err != nil {
+			slog.ErrorContext(r.Context(), "can't write switches", "err", err)
+		}
+	case "json":
+		result := make([]*pb.Switch, 0, len(switches))
+		for _, sw := range switches {
+			result = append(result, sw.AsProto())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="switches.json"`)
+		json.NewEncoder(w).Encode(result)
+	default:
+		http.Error(w, "format must be csv or json", http.StatusBadRequest)
+	}
+}
+
+// exportEvents exports every event, including ones that are over, as an
+// EventFeed in protojson form.
+func (e *Exporter) exportEvents(w http.ResponseWriter, r *http.Request) {
+	events, err := e.dao.AllEvents(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "can't fetch events", "err", err)
+		http.Error(w, "can't fetch events", http.StatusInternalServerError)
+		return
+	}
+
+	feed := &pb.EventFeed{}
+	for _, ev := range events {
+		feed.Events = append(feed.Events, ev.AsProto())
+	}
+
+	data, err := protojson.Marshal(feed)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "can't encode events", "err", err)
+		http.Error(w, "can't encode events", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="events.json"`)
+	w.Write(data)
+}
+
+// eventsCalendar serves every event as an iCalendar subscription.
+// Recurring events keep their recurrence rule so calendar apps show every
+// occurrence.
+func (e *Exporter) eventsCalendar(w http.ResponseWriter, r *http.Request) {
+	events, err := e.dao.AllEvents(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "can't fetch events", "err", err)
+		http.Error(w, "can't fetch events", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+
+	cal := newCalendar(w, "Xe's events")
+	for _, ev := range events {
+		cal.event(ev)
+	}
+	if err := cal.close(); err != nil {
+		slog.ErrorContext(r.Context(), "can't write events calendar", "err", err)
+	}
+}
+
+// switchesCalendar serves the switch history as an iCalendar subscription.
+func (e *Exporter) switchesCalendar(w http.ResponseWriter, r *http.Request) {
+	switches, err := e.dao.AllSwitches(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "can't fetch switches", "err", err)
+		http.Error(w, "can't fetch switches", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+
+	now := e.now()
+	cal := newCalendar(w, "Front history")
+	for _, sw := range switches {
+		cal.frontSwitch(sw, now)
+	}
+	if err := cal.close(); err != nil {
+		slog.ErrorContext(r.Context(), "can't write switches calendar", "err", err)
+	}
+}
+
+// jsonFeed is a JSON Feed 1.1 document.
+type jsonFeed struct {
+	Version string         `json:"version"`
+	Title   string         `json:"title"`
+	FeedURL string         `json:"feed_url,omitempty"`
+	Items   []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string    `json:"id"`
+	URL           string    `json:"url,omitempty"`
+	Title         string    `json:"title"`
+	ContentText   string    `json:"content_text"`
+	DatePublished time.Time `json:"date_published"`
+	DateModified  time.Time `json:"date_modified"`
+	// Event has the details of an event item, as a JSON Feed extension.
+	Event json.RawMessage `json:"_event,omitempty"`
+	// Switch has the details of a switch item, as a JSON Feed extension.
+	Switch json.RawMessage `json:"_switch,omitempty"`
+}
+
+// extension encodes a message for a JSON Feed extension.
+func extension(m proto.Message) json.RawMessage {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// feedCount returns how many items a feed should have, from the count query
+// parameter.
+func feedCount(r *http.Request) int {
+	n, err := strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil || n < 1 {
+		return defaultFeedCount
+	}
+
+	return n
+}
+
+func writeFeed(w http.ResponseWriter, r *http.Request, feed jsonFeed) {
+	w.Header().Set("Content-Type", "application/feed+json")
+	if err := json.NewEncoder(w).Encode(feed); err != nil {
+		slog.ErrorContext(r.Context(), "can't write feed", "title", feed.Title, "err", err)
+	}
+}
+
+// eventsFeed serves upcoming events as a JSON Feed, soonest first.
+func (e *Exporter) eventsFeed(w http.ResponseWriter, r *http.Request) {
+	events, err := e.dao.UpcomingEvents(r.Context(), feedCount(r))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "can't fetch events", "err", err)
+		http.Error(w, "can't fetch events", http.StatusInternalServerError)
+		return
+	}
+
+	feed := jsonFeed{
+		Version: "https://jsonfeed.org/version/1.1",
+		Title:   "Xe's events",
+		Items:   []jsonFeedItem{},
+	}
+
+	for _, ev := range events {
+		feed.Items = append(feed.Items, jsonFeedItem{
+			ID:            fmt.Sprintf("event-%d-%s", ev.ID, ev.StartDate.UTC().Format(icalDate)),
+			URL:           ev.URL,
+			Title:         ev.Name,
+			ContentText:   ev.Description,
+			DatePublished: ev.CreatedAt,
+			DateModified:  ev.UpdatedAt,
+			Event:         extension(ev.AsProto()),
+		})
+	}
+
+	writeFeed(w, r, feed)
+}
+
+// switchesFeed serves the latest switches as a JSON Feed, newest first.
+func (e *Exporter) switchesFeed(w http.ResponseWriter, r *http.Request) {
+	switches, err := e.dao.ListSwitches(r.Context(), feedCount(r), 0)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "can't fetch switches", "err", err)
+		http.Error(w, "can't fetch switches", http.StatusInternalServerError)
+		return
+	}
+
+	feed := jsonFeed{
+		Version: "https://jsonfeed.org/version/1.1",
+		Title:   "Front history",
+		Items:   []jsonFeedItem{},
+	}
+
+	for _, sw := range switches {
+		feed.Items = append(feed.Items, jsonFeedItem{
+			ID:            "switch-" + sw.ID,
+			Title:         sw.Member.Name + " in front",
+			ContentText:   sw.Member.Name + " is now in front.",
+			DatePublished: sw.CreatedAt,
+			DateModified:  sw.UpdatedAt,
+			Switch:        extension(sw.AsProto()),
+		})
+	}
+
+	writeFeed(w, r, feed)
+}
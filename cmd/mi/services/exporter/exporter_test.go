@@ -0,0 +1,294 @@
+package exporter
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"within.website/x/cmd/mi/models"
+	"within.website/x/cmd/mi/services/importer"
+)
+
+var updateGoldenFiles = flag.Bool("update", false, "update golden files in testdata/")
+
+func newDAO(t *testing.T) *models.DAO {
+	t.Helper()
+
+	dir := t.TempDir()
+	dao, err := models.New(filepath.Join(dir, "test.db"), filepath.Join(dir, "backup.db"))
+	if err != nil {
+		t.Fatalf("failed to create dao: %v", err)
+	}
+
+	return dao
+}
+
+func seed(t *testing.T, dao *models.DAO) {
+	t.Helper()
+
+	birthday := time.Date(2000, time.April, 1, 0, 0, 0, 0, time.UTC)
+	members := []models.Member{
+		{ID: 1, Name: "Cadey", AvatarURL: "https://example.com/cadey.png", Birthday: &birthday, Aliases: "xe,cadey-ratio"},
+		{ID: 2, Name: "Nicole", AvatarURL: "https://example.com/nicole.png"},
+	}
+	for _, m := range members {
+		if err := dao.DB().Create(&m).Error; err != nil {
+			t.Fatalf("failed to create member: %v", err)
+		}
+	}
+
+	start := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	ended := start.Add(3 * time.Hour)
+	switches := []models.Switch{
+		{ID: "01JGQ4Z7B0000000000000000A", MemberID: 1, Model: gorm.Model{CreatedAt: start}, EndedAt: &ended},
+		{ID: "01JGQ4Z7B0000000000000000B", MemberID: 2, Model: gorm.Model{CreatedAt: ended}},
+	}
+	for _, sw := range switches {
+		if err := dao.DB().Create(&sw).Error; err != nil {
+			t.Fatalf("failed to create switch: %v", err)
+		}
+	}
+
+	events := []models.Event{
+		{
+			ID:          1,
+			Name:        "Conference, with commas",
+			URL:         "https://example.com/conf",
+			StartDate:   time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC),
+			EndDate:     time.Date(2025, time.June, 12, 0, 0, 0, 0, time.UTC),
+			Location:    "Ottawa",
+			Description: "A talk; probably about AI.",
+		},
+		{
+			ID:          2,
+			Name:        "Anniversary",
+			URL:         "https://example.com/anniversary",
+			StartDate:   time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:     time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Location:    "Home",
+			Description: "Every year.",
+			Recurrence:  "FREQ=YEARLY",
+		},
+	}
+	for _, ev := range events {
+		if err := dao.DB().Create(&ev).Error; err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+	}
+}
+
+func get(t *testing.T, h http.Handler, path string) []byte {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET %s: status %d: %s", path, w.Code, w.Body.String())
+	}
+
+	return w.Body.Bytes()
+}
+
+func post(t *testing.T, h http.Handler, path, contentType string, body []byte) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST %s: status %d: %s", path, w.Code, w.Body.String())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		membersFormat string
+		membersType   string
+		switchFormat  string
+		switchType    string
+	}{
+		{name: "csv", membersFormat: "csv", membersType: "text/csv", switchFormat: "csv", switchType: "text/csv"},
+		{name: "json", membersFormat: "json", membersType: "application/json", switchFormat: "json", switchType: "application/json"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			src := newDAO(t)
+			seed(t, src)
+
+			srcMux := http.NewServeMux()
+			New(src).Mount(srcMux)
+
+			dst := newDAO(t)
+			dstMux := http.NewServeMux()
+			importer.New(dst).Mount(dstMux)
+
+			// Members have to be restored before the switches that refer to them.
+			post(t, dstMux, "/.within/mi/import/members", tt.membersType, get(t, srcMux, "/.within/mi/export/members?format="+tt.membersFormat))
+			post(t, dstMux, "/.within/mi/import/switches", tt.switchType, get(t, srcMux, "/.within/mi/export/switches?format="+tt.switchFormat))
+			post(t, dstMux, "/.within/mi/import/events", "application/json", get(t, srcMux, "/.within/mi/export/events"))
+
+			dstExporter := http.NewServeMux()
+			New(dst).Mount(dstExporter)
+
+			for _, path := range []string{
+				"/.within/mi/export/switches?format=csv",
+				"/.within/mi/export/events",
+				"/.within/mi/export/members?format=json",
+			} {
+				want, got := get(t, srcMux, path), get(t, dstExporter, path)
+				if !bytes.Equal(want, got) {
+					t.Errorf("%s differs after restoring:\nwant: %s\ngot:  %s", path, want, got)
+				}
+			}
+
+			// Only CSV carries aliases.
+			if tt.membersFormat == "csv" {
+				path := "/.within/mi/export/members?format=csv"
+				if want, got := get(t, srcMux, path), get(t, dstExporter, path); !bytes.Equal(want, got) {
+					t.Errorf("%s differs after restoring:\nwant: %s\ngot:  %s", path, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestReimportKeepsBirthday(t *testing.T) {
+	dao := newDAO(t)
+	seed(t, dao)
+
+	mux := http.NewServeMux()
+	importer.New(dao).Mount(mux)
+
+	post(t, mux, "/.within/mi/import/members", "application/json", []byte(`[{"id": 1, "name": "Cadey", "avatar_url": "https://example.com/cadey2.png"}]`))
+
+	m, err := dao.MemberByName(t.Context(), "Cadey")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.AvatarURL != "https://example.com/cadey2.png" {
+		t.Errorf("avatar URL wasn't updated: %q", m.AvatarURL)
+	}
+
+	want := time.Date(2000, time.April, 1, 0, 0, 0, 0, time.UTC)
+	if m.Birthday == nil || !m.Birthday.Equal(want) {
+		t.Errorf("birthday = %v, want %v", m.Birthday, want)
+	}
+}
+
+func TestEventsCalendar(t *testing.T) {
+	dao := newDAO(t)
+	seed(t, dao)
+
+	events, err := dao.AllEvents(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// DTSTAMP comes from when the event was last updated, so pin it.
+	for i := range events {
+		events[i].UpdatedAt = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	var buf bytes.Buffer
+	cal := newCalendar(&buf, "Xe's events")
+	for _, ev := range events {
+		cal.event(ev)
+	}
+	if err := cal.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	goldenFilename := filepath.Join("testdata", "events.ics")
+
+	if *updateGoldenFiles {
+		if err := os.WriteFile(goldenFilename, buf.Bytes(), 0644); err != nil {
+			t.Fatalf("unable to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(goldenFilename)
+	if err != nil {
+		t.Fatalf("error loading golden file: %v", err)
+	}
+
+	if !bytes.Equal(want, buf.Bytes()) {
+		t.Errorf("calendar doesn't match %s:\n%s", goldenFilename, buf.String())
+	}
+}
+
+func TestFoldLine(t *testing.T) {
+	var buf bytes.Buffer
+	cal := &calendar{w: &buf}
+	cal.line("DESCRIPTION", strings.Repeat("é", 100))
+
+	data, err := io.ReadAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("want the line to be folded, got %d lines", len(lines))
+	}
+
+	var unfolded strings.Builder
+	for i, line := range lines {
+		if len(line) > maxLineLength {
+			t.Errorf("line %d is %d octets long", i, len(line))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Fatalf("continuation line %d doesn't start with a space", i)
+			}
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+
+	if want := "DESCRIPTION:" + strings.Repeat("é", 100); unfolded.String() != want {
+		t.Errorf("unfolding doesn't give the original line back: %q", unfolded.String())
+	}
+}
+
+func TestFeeds(t *testing.T) {
+	dao := newDAO(t)
+	seed(t, dao)
+
+	mux := http.NewServeMux()
+	ex := New(dao)
+	ex.now = func() time.Time { return time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC) }
+	ex.Mount(mux)
+
+	cal := string(get(t, mux, "/.within/mi/feeds/switches.ics"))
+	for _, want := range []string{
+		"UID:switch-01JGQ4Z7B0000000000000000A@mi.within.website",
+		"DTSTART:20250101T120000Z\r\nDTEND:20250101T150000Z",
+		"SUMMARY:Nicole in front",
+		"DTEND:20250102T000000Z",
+	} {
+		if !strings.Contains(cal, want) {
+			t.Errorf("switches calendar doesn't have %q:\n%s", want, cal)
+		}
+	}
+
+	feed := string(get(t, mux, "/.within/mi/feeds/switches.json"))
+	if !strings.Contains(feed, `"version":"https://jsonfeed.org/version/1.1"`) || !strings.Contains(feed, `"id":"switch-01JGQ4Z7B0000000000000000B"`) {
+		t.Errorf("bad switches feed: %s", feed)
+	}
+
+	// The anniversary recurs forever, so it is always upcoming.
+	feed = string(get(t, mux, "/.within/mi/feeds/events.json"))
+	if !strings.Contains(feed, `"title":"Anniversary"`) {
+		t.Errorf("events feed doesn't have the recurring event: %s", feed)
+	}
+}
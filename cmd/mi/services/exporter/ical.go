@@ -0,0 +1,129 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"within.website/x/cmd/mi/models"
+)
+
+const (
+	icalDate     = "20060102"
+	icalDateTime = "20060102T150405Z"
+
+	// maxLineLength is the longest a content line may be in octets before it
+	// has to be folded, not counting the line break.
+	maxLineLength = 75
+)
+
+// calendar writes an RFC 5545 iCalendar object one content line at a time.
+// The first write error is kept and every later write is skipped.
+type calendar struct {
+	w   io.Writer
+	err error
+}
+
+func newCalendar(w io.Writer, name string) *calendar {
+	c := &calendar{w: w}
+	c.line("BEGIN", "VCALENDAR")
+	c.line("VERSION", "2.0")
+	c.line("PRODID", "-//Within//mi//EN")
+	c.line("CALSCALE", "GREGORIAN")
+	c.line("X-WR-CALNAME", escapeText(name))
+	return c
+}
+
+// close ends the calendar and returns the first error that happened while
+// writing it.
+func (c *calendar) close() error {
+	c.line("END", "VCALENDAR")
+	return c.err
+}
+
+// line writes a content line, folding it so that no line is longer than
+// maxLineLength octets. Lines are only folded between UTF-8 sequences.
+func (c *calendar) line(name, value string) {
+	if c.err != nil {
+		return
+	}
+
+	s := name + ":" + value
+
+	var sb strings.Builder
+	limit := maxLineLength
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		sb.WriteString(s[:cut])
+		sb.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space.
+		limit = maxLineLength - 1
+	}
+	sb.WriteString(s)
+	sb.WriteString("\r\n")
+
+	_, c.err = io.WriteString(c.w, sb.String())
+}
+
+// event writes an all-day VEVENT for an event. DTEND is exclusive, so it is
+// the day after the event ends.
+func (c *calendar) event(ev models.Event) {
+	c.line("BEGIN", "VEVENT")
+	c.line("UID", fmt.Sprintf("event-%d@mi.within.website", ev.ID))
+	c.line("DTSTAMP", ev.UpdatedAt.UTC().Format(icalDateTime))
+	c.line("DTSTART;VALUE=DATE", ev.StartDate.UTC().Format(icalDate))
+	c.line("DTEND;VALUE=DATE", ev.EndDate.UTC().AddDate(0, 0, 1).Format(icalDate))
+	c.line("SUMMARY", escapeText(ev.Name))
+	if ev.Location != "" {
+		c.line("LOCATION", escapeText(ev.Location))
+	}
+	if ev.Description != "" {
+		c.line("DESCRIPTION", escapeText(ev.Description))
+	}
+	if ev.URL != "" {
+		c.line("URL", ev.URL)
+	}
+	if ev.Recurrence != "" {
+		if rule, err := models.ParseRecurrence(ev.Recurrence); err == nil {
+			c.line("RRULE", rule.String())
+		}
+	}
+	c.line("END", "VEVENT")
+}
+
+// frontSwitch writes a VEVENT for the time a member was in front. Switches
+// that haven't ended yet last until now.
+func (c *calendar) frontSwitch(sw models.Switch, now time.Time) {
+	end := now
+	if sw.EndedAt != nil {
+		end = *sw.EndedAt
+	}
+
+	c.line("BEGIN", "VEVENT")
+	c.line("UID", fmt.Sprintf("switch-%s@mi.within.website", sw.ID))
+	c.line("DTSTAMP", sw.UpdatedAt.UTC().Format(icalDateTime))
+	c.line("DTSTART", sw.CreatedAt.UTC().Format(icalDateTime))
+	c.line("DTEND", end.UTC().Format(icalDateTime))
+	c.line("SUMMARY", escapeText(sw.Member.Name+" in front"))
+	c.line("TRANSP", "TRANSPARENT")
+	c.line("END", "VEVENT")
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// escapeText escapes a TEXT property value.
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
@@ -3,11 +3,15 @@ package importer
 import (
 	"encoding/csv"
 	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"strconv"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"gorm.io/gorm"
 	"within.website/x/cmd/mi/models"
 	pb "within.website/x/gen/within/website/x/mi/v1"
@@ -26,53 +30,52 @@ type Importer struct {
 func (i *Importer) Mount(mux *http.ServeMux) {
 	mux.HandleFunc("/.within/mi/import/switches", i.importSwitches)
 	mux.HandleFunc("/.within/mi/import/members", i.importMembers)
+	mux.HandleFunc("/.within/mi/import/events", i.importEvents)
 }
 
+// importSwitches imports switches as CSV rows of id, member ID, started at
+// and ended at, or as a JSON array of Switch messages when the request has a
+// JSON content type. Members must be imported first.
 func (i *Importer) importSwitches(w http.ResponseWriter, r *http.Request) {
-	rdr := csv.NewReader(r.Body)
 	defer r.Body.Close()
 
-	tx := i.db.Begin()
-
-	for {
-		row, err := rdr.Read()
-		if err != nil {
-			break
-		}
+	var switches []*pb.Switch
+	var layout string
 
-		if len(row) != 4 {
-			slog.ErrorContext(r.Context(), "invalid row", "row", row)
-			continue
+	if isJSON(r) {
+		if err := json.NewDecoder(r.Body).Decode(&switches); err != nil {
+			slog.ErrorContext(r.Context(), "failed to decode switches", "err", err)
+			http.Error(w, "failed to decode switches", http.StatusBadRequest)
+			return
 		}
+		layout = time.RFC3339
+	} else {
+		switches = readSwitchesCSV(r)
+		layout = timeLayout
+	}
 
-		id := row[0]
-		memberIDStr := row[1]
-		startedAtStr := row[2]
-		endedAtStr := row[3]
-
-		memberID, err := strconv.Atoi(memberIDStr)
-		if err != nil {
-			slog.ErrorContext(r.Context(), "failed to parse member ID", "err", err)
-			continue
-		}
+	tx := i.db.Begin()
 
-		startedAt, err := time.Parse(timeLayout, startedAtStr)
+	for _, s := range switches {
+		startedAt, err := time.Parse(layout, s.GetStartedAt())
 		if err != nil {
 			slog.ErrorContext(r.Context(), "failed to parse started at", "err", err)
 			continue
 		}
 
 		var endedAt *time.Time
-		if endedAtStr != "" {
-			endedAtTime, err := time.Parse(timeLayout, endedAtStr)
+		if s.GetEndedAt() != "" {
+			endedAtTime, err := time.Parse(layout, s.GetEndedAt())
 			if err != nil {
-				slog.ErrorContext(r.Context(), "failed to parse ended at", "err", err, "endedAtStr", endedAtStr)
+				slog.ErrorContext(r.Context(), "failed to parse ended at", "err", err, "endedAtStr", s.GetEndedAt())
 				continue
 			}
 
 			endedAt = &endedAtTime
 		}
 
+		memberID := int(s.GetMemberId())
+
 		var member models.Member
 		if err := tx.Where("id = ?", memberID).First(&member).Error; err != nil {
 			slog.ErrorContext(r.Context(), "failed to find member", "err", err, "memberID", memberID)
@@ -80,7 +83,7 @@ func (i *Importer) importSwitches(w http.ResponseWriter, r *http.Request) {
 		}
 
 		sw := models.Switch{
-			ID:       id,
+			ID:       s.GetId(),
 			MemberID: memberID,
 			Model: gorm.Model{
 				CreatedAt: startedAt,
@@ -103,33 +106,102 @@ func (i *Importer) importSwitches(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-func (i *Importer) importMembers(w http.ResponseWriter, r *http.Request) {
-	// Decode the incoming JSON payload into a slice of protobuf Member structs.
-	// The generated protobuf type contains an internal mutex, so iterating over the
-	// slice by value would copy that mutex and trigger a vet warning. Instead we
-	// iterate by index and work with pointers to avoid copying the lock.
-	var members []pb.Member
-	if err := json.NewDecoder(r.Body).Decode(&members); err != nil {
-		slog.ErrorContext(r.Context(), "failed to decode members", "err", err)
-		http.Error(w, "failed to decode members", http.StatusBadRequest)
-		return
+// readSwitchesCSV reads switch rows from the request body. Rows that can't
+// be parsed, such as a header row, are logged and skipped.
+func readSwitchesCSV(r *http.Request) []*pb.Switch {
+	rdr := csv.NewReader(r.Body)
+	rdr.FieldsPerRecord = -1
+
+	var result []*pb.Switch
+
+	for {
+		row, err := rdr.Read()
+		if err != nil {
+			break
+		}
+
+		if len(row) != 4 {
+			slog.ErrorContext(r.Context(), "invalid row", "row", row)
+			continue
+		}
+
+		memberID, err := strconv.Atoi(row[1])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to parse member ID", "err", err)
+			continue
+		}
+
+		result = append(result, &pb.Switch{
+			Id:        row[0],
+			MemberId:  int32(memberID),
+			StartedAt: row[2],
+			EndedAt:   row[3],
+		})
 	}
+
+	return result
+}
+
+// importMembers imports members as a JSON array of Member messages, or as
+// CSV rows of id, name, avatar URL, birthday and aliases when the request has
+// a CSV content type. Existing members are updated.
+func (i *Importer) importMembers(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
+	var members []models.Member
+
+	if isCSV(r) {
+		var err error
+		members, err = readMembersCSV(r)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to read members", "err", err)
+			http.Error(w, "failed to read members", http.StatusBadRequest)
+			return
+		}
+	} else {
+		// Decode the incoming JSON payload into a slice of protobuf Member structs.
+		// The generated protobuf type contains an internal mutex, so iterating over the
+		// slice by value would copy that mutex and trigger a vet warning. Instead we
+		// iterate by index and work with pointers to avoid copying the lock.
+		var pbMembers []pb.Member
+		if err := json.NewDecoder(r.Body).Decode(&pbMembers); err != nil {
+			slog.ErrorContext(r.Context(), "failed to decode members", "err", err)
+			http.Error(w, "failed to decode members", http.StatusBadRequest)
+			return
+		}
+
+		for idx := range pbMembers {
+			m := &pbMembers[idx]
+			member := models.Member{
+				ID:        int(m.Id),
+				Name:      m.Name,
+				AvatarURL: m.AvatarUrl,
+			}
+
+			if m.Birthday != nil {
+				birthday := m.Birthday.AsTime()
+				member.Birthday = &birthday
+			}
+
+			members = append(members, member)
+		}
+	}
+
 	tx := i.db.Begin()
 
-	for idx := range members {
-		m := &members[idx]
-		member := models.Member{
-			ID:        int(m.Id),
-			Name:      m.Name,
-			AvatarURL: m.AvatarUrl,
+	for _, member := range members {
+		// Aliases aren't part of the JSON format, so they are only replaced
+		// when importing CSV. A missing birthday keeps the one already stored.
+		query := "INSERT INTO members (id, name, avatar_url, birthday, aliases) VALUES (?, ?, ?, ?, ?) ON CONFLICT (id) DO UPDATE SET name = excluded.name, avatar_url = excluded.avatar_url, birthday = COALESCE(excluded.birthday, members.birthday)"
+		if isCSV(r) {
+			query += ", aliases = excluded.aliases"
 		}
 
 		if err := tx.Exec(
-			"INSERT INTO members (id, name, avatar_url) VALUES (?, ?, ?) ON CONFLICT (id) DO UPDATE SET name = ?, avatar_url = ?",
-			member.ID, member.Name, member.AvatarURL, member.Name, member.AvatarURL,
+			query,
+			member.ID, member.Name, member.AvatarURL, member.Birthday, member.Aliases,
 		).Error; err != nil {
+			tx.Rollback()
 			slog.ErrorContext(r.Context(), "failed to save member", "err", err)
 			http.Error(w, "failed to save member", http.StatusInternalServerError)
 			return
@@ -142,3 +214,112 @@ func (i *Importer) importMembers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func readMembersCSV(r *http.Request) ([]models.Member, error) {
+	rows, err := csv.NewReader(r.Body).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.Member
+
+	for _, row := range rows {
+		if len(row) != 5 {
+			return nil, fmt.Errorf("importer: member rows need 5 columns, got %d", len(row))
+		}
+
+		id, err := strconv.Atoi(row[0])
+		if err != nil {
+			// header row
+			continue
+		}
+
+		member := models.Member{
+			ID:        id,
+			Name:      row[1],
+			AvatarURL: row[2],
+			Aliases:   row[4],
+		}
+
+		if row[3] != "" {
+			birthday, err := time.Parse(time.RFC3339, row[3])
+			if err != nil {
+				return nil, fmt.Errorf("importer: bad birthday for %s: %w", member.Name, err)
+			}
+			member.Birthday = &birthday
+		}
+
+		result = append(result, member)
+	}
+
+	return result, nil
+}
+
+// importEvents imports events as an EventFeed in protojson form. Existing
+// events are updated.
+func (i *Importer) importEvents(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read events", http.StatusBadRequest)
+		return
+	}
+
+	var feed pb.EventFeed
+	if err := protojson.Unmarshal(data, &feed); err != nil {
+		slog.ErrorContext(r.Context(), "failed to decode events", "err", err)
+		http.Error(w, "failed to decode events", http.StatusBadRequest)
+		return
+	}
+
+	tx := i.db.Begin()
+
+	for _, ev := range feed.GetEvents() {
+		event := models.Event{
+			ID:          int(ev.GetId()),
+			Name:        ev.GetName(),
+			URL:         ev.GetUrl(),
+			StartDate:   ev.GetStartDate().AsTime(),
+			EndDate:     ev.GetEndDate().AsTime(),
+			Location:    ev.GetLocation(),
+			Description: ev.GetDescription(),
+			Recurrence:  ev.GetRecurrence(),
+		}
+
+		if event.Recurrence != "" {
+			if _, err := models.ParseRecurrence(event.Recurrence); err != nil {
+				slog.ErrorContext(r.Context(), "skipping event with bad recurrence", "id", event.ID, "err", err)
+				continue
+			}
+		}
+
+		var existing models.Event
+		if err := tx.Where("id = ?", event.ID).First(&existing).Error; err == nil {
+			event.Model = existing.Model
+		}
+
+		if err := tx.Save(&event).Error; err != nil {
+			tx.Rollback()
+			slog.ErrorContext(r.Context(), "failed to save event", "err", err)
+			http.Error(w, "failed to save event", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		slog.ErrorContext(r.Context(), "failed to commit transaction", "err", err)
+		http.Error(w, "failed to commit transaction", http.StatusInternalServerError)
+		return
+	}
+}
+
+func isJSON(r *http.Request) bool {
+	mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mt == "application/json"
+}
+
+func isCSV(r *http.Request) bool {
+	mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mt == "text/csv"
+}
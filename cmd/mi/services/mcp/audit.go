@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"within.website/x/cmd/mi/models"
+)
+
+// anonymous is the caller name used when authentication is turned off.
+const anonymous = "anonymous"
+
+var ErrToolNotAllowed = errors.New("mcp: tool not allowed")
+
+// Auditor records tool invocations. *models.DAO is an Auditor.
+type Auditor interface {
+	RecordMCPCall(ctx context.Context, call *models.MCPCall) error
+}
+
+// caller returns the credential a request was made with. It returns nil when
+// authentication is turned off, which allows everything.
+func caller(req mcp.Request) *Credential {
+	extra := req.GetExtra()
+	if extra == nil {
+		return nil
+	}
+
+	c, _ := credentialFrom(extra.TokenInfo)
+	return c
+}
+
+// guard is MCP middleware that hides the tools a caller isn't allowed to use,
+// refuses calls to them, and audits every tool call.
+func (s *Server) guard(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		cred := caller(req)
+		if s.authRequired && cred == nil {
+			return nil, fmt.Errorf("%w: unauthenticated", ErrToolNotAllowed)
+		}
+
+		switch method {
+		case "tools/list":
+			result, err := next(ctx, method, req)
+			if err != nil || cred == nil {
+				return result, err
+			}
+
+			if ltr, ok := result.(*mcp.ListToolsResult); ok {
+				ltr.Tools = slices.DeleteFunc(slices.Clone(ltr.Tools), func(t *mcp.Tool) bool {
+					return !cred.Allows(t.Name)
+				})
+			}
+
+			return result, nil
+		case "tools/call":
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			call := &models.MCPCall{
+				CreatedAt: time.Now(),
+				Caller:    anonymous,
+				Tool:      params.Name,
+				Arguments: string(params.Arguments),
+				Allowed:   cred == nil || cred.Allows(params.Name),
+			}
+			if cred != nil {
+				call.Caller = cred.Name
+			}
+
+			var result mcp.Result
+			var err error
+			if call.Allowed {
+				result, err = next(ctx, method, req)
+			} else {
+				err = fmt.Errorf("%w: %s can't call %s", ErrToolNotAllowed, call.Caller, params.Name)
+			}
+			call.Duration = time.Since(call.CreatedAt)
+			call.Error = callError(result, err)
+
+			s.audit(ctx, call)
+
+			return result, err
+		}
+
+		return next(ctx, method, req)
+	}
+}
+
+// callError returns what went wrong with a tool call. Tool handler errors are
+// reported to the client in the result, not as an error.
+func callError(result mcp.Result, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+
+	ctr, ok := result.(*mcp.CallToolResult)
+	if !ok || !ctr.IsError {
+		return ""
+	}
+
+	var msgs []string
+	for _, c := range ctr.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			msgs = append(msgs, tc.Text)
+		}
+	}
+	if len(msgs) == 0 {
+		return "tool failed"
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+func (s *Server) audit(ctx context.Context, call *models.MCPCall) {
+	slog.InfoContext(ctx, "mcp tool call",
+		"caller", call.Caller,
+		"tool", call.Tool,
+		"allowed", call.Allowed,
+		"duration", call.Duration,
+		"err", call.Error,
+	)
+
+	if s.auditor == nil {
+		return
+	}
+
+	// The call is recorded even if the client went away.
+	if err := s.auditor.RecordMCPCall(context.WithoutCancel(ctx), call); err != nil {
+		slog.ErrorContext(ctx, "can't record mcp tool call", "caller", call.Caller, "tool", call.Tool, "err", err)
+	}
+}
@@ -0,0 +1,223 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"within.website/x/web/middleware/authctx"
+	"within.website/x/web/middleware/sigv4"
+	"within.website/x/web/middleware/sigv4a"
+	"within.website/x/web/middleware/sigv4any"
+)
+
+const (
+	// sigv4Service is the service name SigV4 clients must sign for.
+	sigv4Service = "mi"
+
+	// signedPlaceholder stands in for a bearer token once a request's SigV4
+	// signature is verified, so that every caller reaches the MCP server
+	// through the same token verifier. It is only honoured when the
+	// signature verifier has put an access key ID in the request context.
+	signedPlaceholder = "sigv4-verified"
+
+	// maxSignedBodySize is how much of a request body is buffered to verify
+	// its signature.
+	maxSignedBodySize = 1 << 20
+
+	allTools = "*"
+)
+
+var ErrBadCredential = errors.New("mcp: bad credential")
+
+// Credential lets an MCP client in. Clients authenticate with either a
+// bearer token or a SigV4 or SigV4A signature made with an access key pair.
+type Credential struct {
+	// Name identifies the client in audit logs.
+	Name string `json:"name"`
+
+	Token           string `json:"token,omitempty"`
+	AccessKeyID     string `json:"access_key_id,omitempty"`
+	SecretAccessKey string `json:"secret_access_key,omitempty"`
+
+	// Tools is the list of tools the client may call, or "*" for all of
+	// them. Resources and prompts are available to every client.
+	Tools []string `json:"tools"`
+}
+
+// Valid checks that the credential has a name and exactly one way to
+// authenticate.
+func (c Credential) Valid() error {
+	if c.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrBadCredential)
+	}
+
+	hasToken := c.Token != ""
+	hasKey := c.AccessKeyID != "" || c.SecretAccessKey != ""
+	switch {
+	case hasToken && hasKey:
+		return fmt.Errorf("%w: %s has both a token and an access key", ErrBadCredential, c.Name)
+	case !hasToken && !hasKey:
+		return fmt.Errorf("%w: %s needs a token or an access key", ErrBadCredential, c.Name)
+	case hasKey && (c.AccessKeyID == "" || c.SecretAccessKey == ""):
+		return fmt.Errorf("%w: %s needs both an access key ID and a secret access key", ErrBadCredential, c.Name)
+	}
+
+	return nil
+}
+
+// Allows reports whether the credential may call a tool.
+func (c Credential) Allows(tool string) bool {
+	return slices.Contains(c.Tools, allTools) || slices.Contains(c.Tools, tool)
+}
+
+// LoadCredentials reads a JSON array of credentials from a file.
+func LoadCredentials(fname string) ([]Credential, error) {
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Credential
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("mcp: can't parse credentials in %s: %w", fname, err)
+	}
+
+	for _, c := range result {
+		if err := c.Valid(); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// authenticator checks that requests come from a known credential and puts
+// that credential in the request's auth.TokenInfo.
+type authenticator struct {
+	byToken map[[sha256.Size]byte]*Credential
+	byKeyID map[string]*Credential
+	signed  *sigv4any.Verifier
+}
+
+func newAuthenticator(creds []Credential, region string) (*authenticator, error) {
+	a := &authenticator{
+		byToken: map[[sha256.Size]byte]*Credential{},
+		byKeyID: map[string]*Credential{},
+	}
+
+	for i := range creds {
+		c := &creds[i]
+		if err := c.Valid(); err != nil {
+			return nil, err
+		}
+
+		if c.Token != "" {
+			// Tokens are looked up by hash so the lookup doesn't leak how
+			// much of a token matched.
+			a.byToken[sha256.Sum256([]byte(c.Token))] = c
+		} else {
+			a.byKeyID[c.AccessKeyID] = c
+		}
+	}
+
+	a.signed = &sigv4any.Verifier{
+		V4: &sigv4.Verifier{
+			Region:      region,
+			Service:     sigv4Service,
+			MaxBodySize: maxSignedBodySize,
+			Lookup: sigv4.LookuperFunc(func(accessKeyID string) (string, error) {
+				c, ok := a.byKeyID[accessKeyID]
+				if !ok {
+					return "", sigv4.ErrUnknownKey
+				}
+				return c.SecretAccessKey, nil
+			}),
+		},
+		V4A: &sigv4a.Verifier{
+			Region:      region,
+			Service:     sigv4Service,
+			MaxBodySize: maxSignedBodySize,
+			Lookup: sigv4a.LookuperFunc(func(accessKeyID string) (string, error) {
+				c, ok := a.byKeyID[accessKeyID]
+				if !ok {
+					return "", sigv4a.ErrUnknownKey
+				}
+				return c.SecretAccessKey, nil
+			}),
+		},
+	}
+
+	return a, nil
+}
+
+// Middleware rejects requests that aren't signed or don't have a known
+// bearer token.
+func (a *authenticator) Middleware(next http.Handler) http.Handler {
+	bearer := auth.RequireBearerToken(a.verify, nil)(next)
+
+	signed := a.signed.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.Clone(r.Context())
+		r.Header.Set("Authorization", "Bearer "+signedPlaceholder)
+		bearer.ServeHTTP(w, r)
+	}))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-") {
+			signed.ServeHTTP(w, r)
+			return
+		}
+
+		bearer.ServeHTTP(w, r)
+	})
+}
+
+func (a *authenticator) verify(ctx context.Context, token string, r *http.Request) (*auth.TokenInfo, error) {
+	var cred *Credential
+
+	if token == signedPlaceholder {
+		keyID, ok := authctx.KeyID(r.Context())
+		if !ok {
+			return nil, fmt.Errorf("%w: missing signature", auth.ErrInvalidToken)
+		}
+		cred = a.byKeyID[keyID]
+	} else {
+		cred = a.byToken[sha256.Sum256([]byte(token))]
+	}
+
+	if cred == nil {
+		return nil, fmt.Errorf("%w: unknown credential", auth.ErrInvalidToken)
+	}
+
+	return credentialInfo(cred), nil
+}
+
+// credentialInfo wraps a credential in a TokenInfo. The expiry only covers
+// the request being verified, as every request is verified again.
+func credentialInfo(c *Credential) *auth.TokenInfo {
+	return &auth.TokenInfo{
+		UserID:     c.Name,
+		Expiration: time.Now().Add(time.Hour),
+		Extra:      map[string]any{credentialKey: c},
+	}
+}
+
+const credentialKey = "credential"
+
+// credentialFrom returns the credential a request was made with, if any.
+func credentialFrom(ti *auth.TokenInfo) (*Credential, bool) {
+	if ti == nil {
+		return nil, false
+	}
+
+	c, ok := ti.Extra[credentialKey].(*Credential)
+	return c, ok
+}
@@ -3,6 +3,7 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -16,6 +17,21 @@ import (
 type Server struct {
 	st miv1.SwitchTracker
 	es miv1.Events
+
+	auditor      Auditor
+	authRequired bool
+}
+
+// Options configure the MCP server.
+type Options struct {
+	// Credentials are the clients allowed to connect. If there are none,
+	// the server is unauthenticated and every tool can be called.
+	Credentials []Credential
+	// Region is the region SigV4 clients must sign for.
+	Region string
+	// Auditor records every tool call if it is set. Tool calls are always
+	// logged.
+	Auditor Auditor
 }
 
 type switchReq struct {
@@ -108,6 +124,19 @@ type EventItem struct {
 	EndDate     string `json:"endDate"`
 	Location    string `json:"location"`
 	Description string `json:"description"`
+	Recurrence  string `json:"recurrence,omitempty"`
+}
+
+func eventItem(ev *miv1.Event) EventItem {
+	return EventItem{
+		Name:        ev.GetName(),
+		URL:         ev.GetUrl(),
+		StartDate:   ev.GetStartDate().AsTime().Format(dateLayout),
+		EndDate:     ev.GetEndDate().AsTime().Format(dateLayout),
+		Location:    ev.GetLocation(),
+		Description: ev.GetDescription(),
+		Recurrence:  ev.GetRecurrence(),
+	}
 }
 
 func (s *Server) listEvents(ctx context.Context, req *mcp.CallToolRequest, _ listEventsReq) (*mcp.CallToolResult, *listEventsResp, error) {
@@ -118,14 +147,7 @@ func (s *Server) listEvents(ctx context.Context, req *mcp.CallToolRequest, _ lis
 
 	result := &listEventsResp{}
 	for _, ev := range resp.Events {
-		result.Events = append(result.Events, EventItem{
-			Name:        ev.Name,
-			URL:         ev.Url,
-			StartDate:   ev.StartDate.AsTime().Format(dateLayout),
-			EndDate:     ev.EndDate.AsTime().Format(dateLayout),
-			Location:    ev.Location,
-			Description: ev.Description,
-		})
+		result.Events = append(result.Events, eventItem(ev))
 	}
 
 	return nil, result, nil
@@ -138,6 +160,7 @@ type addEventReq struct {
 	EndDate     string `json:"endDate,omitempty" jsonschema:"End date in YYYY-MM-DD format, defaults to start date"`
 	Location    string `json:"location" jsonschema:"Location of the event"`
 	Description string `json:"description" jsonschema:"Description of the event"`
+	Recurrence  string `json:"recurrence,omitempty" jsonschema:"How the event repeats as an RFC 5545 RRULE such as FREQ=YEARLY, if it does"`
 }
 
 type addEventResp struct {
@@ -165,6 +188,7 @@ func (s *Server) addEvent(ctx context.Context, req *mcp.CallToolRequest, ae addE
 		EndDate:     timestamppb.New(endTime),
 		Location:    ae.Location,
 		Description: ae.Description,
+		Recurrence:  ae.Recurrence,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -190,10 +214,14 @@ func (s *Server) removeEvent(ctx context.Context, req *mcp.CallToolRequest, re r
 	return nil, &removeEventResp{Message: "Event removed successfully"}, nil
 }
 
-func New(st miv1.SwitchTracker, es miv1.Events) http.Handler {
+// New creates the MCP handler. Clients authenticate with the credentials
+// in opts, and can only see and call the tools their credential allows.
+func New(st miv1.SwitchTracker, es miv1.Events, opts Options) (http.Handler, error) {
 	s := &Server{
-		st: st,
-		es: es,
+		st:           st,
+		es:           es,
+		auditor:      opts.Auditor,
+		authRequired: len(opts.Credentials) != 0,
 	}
 
 	srv := mcp.NewServer(&mcp.Implementation{
@@ -232,7 +260,22 @@ func New(st miv1.SwitchTracker, es miv1.Events) http.Handler {
 		Description: "Remove an event from the feed",
 	}, s.removeEvent)
 
+	addResources(srv, s)
+	addPrompts(srv, s)
+
+	srv.AddReceivingMiddleware(s.guard)
+
 	handler := mcp.NewStreamableHTTPHandler(func(req *http.Request) *mcp.Server { return srv }, nil)
 
-	return handler
+	if !s.authRequired {
+		slog.Warn("mcp server has no credentials, anyone can call every tool")
+		return handler, nil
+	}
+
+	a, err := newAuthenticator(opts.Credentials, opts.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Middleware(handler), nil
 }
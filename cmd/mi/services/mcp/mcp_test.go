@@ -0,0 +1,270 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"within.website/x/cmd/mi/models"
+	miv1 "within.website/x/gen/within/website/x/mi/v1"
+	"within.website/x/web/middleware/sigv4/sigv4client"
+)
+
+type fakeSwitchTracker struct {
+	miv1.SwitchTracker
+}
+
+func (fakeSwitchTracker) WhoIsFront(context.Context, *emptypb.Empty) (*miv1.FrontChange, error) {
+	return &miv1.FrontChange{
+		Member: &miv1.Member{Id: 1, Name: "Cadey"},
+		Switch: &miv1.Switch{Id: "01JGQ4Z7B0000000000000000A", StartedAt: "2025-01-01T12:00:00Z"},
+	}, nil
+}
+
+func (fakeSwitchTracker) Members(context.Context, *emptypb.Empty) (*miv1.MembersResp, error) {
+	return &miv1.MembersResp{Members: []*miv1.Member{{Id: 1, Name: "Cadey"}, {Id: 2, Name: "Nicole"}}}, nil
+}
+
+type fakeEvents struct {
+	miv1.Events
+}
+
+func (fakeEvents) Get(context.Context, *emptypb.Empty) (*miv1.EventFeed, error) {
+	return nil, twirp.NotFoundError("can't find any events")
+}
+
+type fakeAuditor struct {
+	lock  sync.Mutex
+	calls []models.MCPCall
+}
+
+func (fa *fakeAuditor) RecordMCPCall(_ context.Context, call *models.MCPCall) error {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+	fa.calls = append(fa.calls, *call)
+	return nil
+}
+
+// headerTransport adds a bearer token to every request.
+type headerTransport struct {
+	token string
+}
+
+func (ht headerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+ht.token)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+var testCredentials = []Credential{
+	{Name: "reader", Token: "hunter2", Tools: []string{"who-is-front"}},
+	{Name: "admin", Token: "correct-horse", Tools: []string{"*"}},
+	{Name: "signer", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", Tools: []string{"list-system-members"}},
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, *fakeAuditor) {
+	t.Helper()
+
+	fa := &fakeAuditor{}
+	h, err := New(fakeSwitchTracker{}, fakeEvents{}, Options{
+		Credentials: testCredentials,
+		Region:      "us-east-1",
+		Auditor:     fa,
+	})
+	if err != nil {
+		t.Fatalf("can't create server: %v", err)
+	}
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	return srv, fa
+}
+
+func connect(t *testing.T, endpoint string, rt http.RoundTripper) *mcp.ClientSession {
+	t.Helper()
+
+	cli := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v0.0.1"}, nil)
+	cs, err := cli.Connect(t.Context(), &mcp.StreamableClientTransport{
+		Endpoint:             endpoint,
+		HTTPClient:           &http.Client{Transport: rt},
+		DisableStandaloneSSE: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("can't connect: %v", err)
+	}
+	t.Cleanup(func() { cs.Close() })
+
+	return cs
+}
+
+func toolNames(t *testing.T, cs *mcp.ClientSession) []string {
+	t.Helper()
+
+	res, err := cs.ListTools(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("can't list tools: %v", err)
+	}
+
+	var result []string
+	for _, tool := range res.Tools {
+		result = append(result, tool.Name)
+	}
+
+	return result
+}
+
+func TestUnauthenticated(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	for _, auth := range []string{"", "Bearer wrong", "Bearer " + signedPlaceholder} {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, srv.URL, strings.NewReader(`{}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: got status %d, want %d", auth, resp.StatusCode, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestToolAllowlist(t *testing.T) {
+	srv, fa := newTestServer(t)
+
+	cs := connect(t, srv.URL, headerTransport{token: "hunter2"})
+
+	if got := toolNames(t, cs); len(got) != 1 || got[0] != "who-is-front" {
+		t.Errorf("reader sees tools %v, want only who-is-front", got)
+	}
+
+	res, err := cs.CallTool(t.Context(), &mcp.CallToolParams{Name: "who-is-front"})
+	if err != nil {
+		t.Fatalf("can't call who-is-front: %v", err)
+	}
+	if res.IsError {
+		t.Fatalf("who-is-front failed: %v", res.Content)
+	}
+
+	_, err = cs.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "remove-event",
+		Arguments: map[string]any{"id": 1},
+	})
+	if err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("want remove-event to be refused, got: %v", err)
+	}
+
+	admin := connect(t, srv.URL, headerTransport{token: "correct-horse"})
+	if got := toolNames(t, admin); len(got) != 6 {
+		t.Errorf("admin sees tools %v, want all 6", got)
+	}
+
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+
+	if len(fa.calls) != 2 {
+		t.Fatalf("got %d audit records, want 2: %+v", len(fa.calls), fa.calls)
+	}
+
+	if c := fa.calls[0]; c.Caller != "reader" || c.Tool != "who-is-front" || !c.Allowed || c.Error != "" {
+		t.Errorf("bad audit record for allowed call: %+v", c)
+	}
+	if c := fa.calls[1]; c.Caller != "reader" || c.Tool != "remove-event" || c.Allowed || c.Arguments != `{"id":1}` {
+		t.Errorf("bad audit record for refused call: %+v", c)
+	}
+}
+
+func TestSigV4(t *testing.T) {
+	srv, fa := newTestServer(t)
+
+	rt, err := sigv4client.NewSigV4RoundTripper(&sigv4client.Config{
+		Region:      "us-east-1",
+		AccessKey:   "AKIDEXAMPLE",
+		SecretKey:   "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		ServiceName: sigv4Service,
+	}, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs := connect(t, srv.URL, rt)
+
+	if got := toolNames(t, cs); len(got) != 1 || got[0] != "list-system-members" {
+		t.Errorf("signer sees tools %v, want only list-system-members", got)
+	}
+
+	if _, err := cs.CallTool(t.Context(), &mcp.CallToolParams{Name: "list-system-members"}); err != nil {
+		t.Fatalf("can't call list-system-members: %v", err)
+	}
+
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+	if len(fa.calls) != 1 || fa.calls[0].Caller != "signer" {
+		t.Errorf("want one call audited for signer, got: %+v", fa.calls)
+	}
+}
+
+func TestResourcesAndPrompts(t *testing.T) {
+	srv, _ := newTestServer(t)
+	cs := connect(t, srv.URL, headerTransport{token: "hunter2"})
+
+	res, err := cs.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: frontURI})
+	if err != nil {
+		t.Fatalf("can't read %s: %v", frontURI, err)
+	}
+	if !strings.Contains(res.Contents[0].Text, `"name": "Cadey"`) {
+		t.Errorf("front resource doesn't have the member: %s", res.Contents[0].Text)
+	}
+
+	res, err = cs.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: eventsURI})
+	if err != nil {
+		t.Fatalf("can't read %s: %v", eventsURI, err)
+	}
+	if got := strings.TrimSpace(res.Contents[0].Text); got != "[]" {
+		t.Errorf("want no upcoming events, got: %s", got)
+	}
+
+	prompt, err := cs.GetPrompt(t.Context(), &mcp.GetPromptParams{Name: "front-check-in"})
+	if err != nil {
+		t.Fatalf("can't get front-check-in: %v", err)
+	}
+	if len(prompt.Messages) != 3 {
+		t.Fatalf("got %d messages, want 3", len(prompt.Messages))
+	}
+	if er, ok := prompt.Messages[0].Content.(*mcp.EmbeddedResource); !ok || er.Resource.URI != frontURI {
+		t.Errorf("want the front resource embedded first, got: %#v", prompt.Messages[0].Content)
+	}
+
+	if _, err := cs.GetPrompt(t.Context(), &mcp.GetPromptParams{Name: "add-event"}); err == nil {
+		t.Error("want add-event without details to fail")
+	}
+}
+
+func TestCredentialValid(t *testing.T) {
+	for _, c := range []Credential{
+		{Token: "x"},
+		{Name: "none"},
+		{Name: "both", Token: "x", AccessKeyID: "a", SecretAccessKey: "b"},
+		{Name: "half", AccessKeyID: "a"},
+	} {
+		if err := c.Valid(); !errors.Is(err, ErrBadCredential) {
+			t.Errorf("%+v: want ErrBadCredential, got: %v", c, err)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	frontURI   = "mi://front"
+	membersURI = "mi://members"
+	eventsURI  = "mi://events/upcoming"
+)
+
+// jsonResource reads a resource by encoding what get returns as JSON.
+func jsonResource[T any](get func(context.Context) (T, error)) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		val, err := get(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.MarshalIndent(val, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			}},
+		}, nil
+	}
+}
+
+func (s *Server) front(ctx context.Context) (*whoIsFrontResp, error) {
+	resp, err := s.st.WhoIsFront(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &whoIsFrontResp{
+		Name:      resp.GetMember().GetName(),
+		AvatarURL: resp.GetMember().GetAvatarUrl(),
+		StartedAt: resp.GetSwitch().GetStartedAt(),
+	}, nil
+}
+
+type memberItem struct {
+	ID        int32  `json:"id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatarURL"`
+}
+
+func (s *Server) members(ctx context.Context) ([]memberItem, error) {
+	resp, err := s.st.Members(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []memberItem{}
+	for _, m := range resp.GetMembers() {
+		result = append(result, memberItem{ID: m.GetId(), Name: m.GetName(), AvatarURL: m.GetAvatarUrl()})
+	}
+
+	return result, nil
+}
+
+func (s *Server) upcomingEvents(ctx context.Context) ([]EventItem, error) {
+	resp, err := s.es.Get(ctx, &emptypb.Empty{})
+	if err != nil {
+		// No upcoming events isn't a failure for a resource.
+		var terr twirp.Error
+		if errors.As(err, &terr) && terr.Code() == twirp.NotFound {
+			return []EventItem{}, nil
+		}
+		return nil, err
+	}
+
+	result := []EventItem{}
+	for _, ev := range resp.GetEvents() {
+		result = append(result, eventItem(ev))
+	}
+
+	return result, nil
+}
+
+func addResources(srv *mcp.Server, s *Server) {
+	srv.AddResource(&mcp.Resource{
+		URI:         frontURI,
+		Name:        "front",
+		Title:       "Who is front",
+		Description: "The member that is currently in front and when they switched in",
+		MIMEType:    "application/json",
+	}, jsonResource(s.front))
+
+	srv.AddResource(&mcp.Resource{
+		URI:         membersURI,
+		Name:        "members",
+		Title:       "System members",
+		Description: "Every member of the system",
+		MIMEType:    "application/json",
+	}, jsonResource(s.members))
+
+	srv.AddResource(&mcp.Resource{
+		URI:         eventsURI,
+		Name:        "upcoming-events",
+		Title:       "Upcoming events",
+		Description: "Events that haven't ended yet, soonest first",
+		MIMEType:    "application/json",
+	}, jsonResource(s.upcomingEvents))
+}
+
+// resourceMessage is a prompt message that embeds a resource.
+func (s *Server) resourceMessage(ctx context.Context, uri string, get mcp.ResourceHandler) (*mcp.PromptMessage, error) {
+	res, err := get(ctx, &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: uri}})
+	if err != nil {
+		return nil, fmt.Errorf("can't read %s: %w", uri, err)
+	}
+
+	return &mcp.PromptMessage{
+		Role:    "user",
+		Content: &mcp.EmbeddedResource{Resource: res.Contents[0]},
+	}, nil
+}
+
+func addPrompts(srv *mcp.Server, s *Server) {
+	srv.AddPrompt(&mcp.Prompt{
+		Name:        "add-event",
+		Title:       "Add an event",
+		Description: "Turn a description of an event into a call to the add-event tool",
+		Arguments: []*mcp.PromptArgument{{
+			Name:        "details",
+			Description: "Whatever is known about the event, such as an announcement or a link",
+			Required:    true,
+		}},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		details := req.Params.Arguments["details"]
+		if details == "" {
+			return nil, errors.New("details is required")
+		}
+
+		return &mcp.GetPromptResult{
+			Description: "Add an event",
+			Messages: []*mcp.PromptMessage{{
+				Role: "user",
+				Content: &mcp.TextContent{Text: "Add this event with the add-event tool. " +
+					"Work out its name, URL, start and end dates (YYYY-MM-DD), location and a one sentence description. " +
+					"Ask me about anything that is missing instead of guessing.\n\n" + details},
+			}},
+		}, nil
+	})
+
+	srv.AddPrompt(&mcp.Prompt{
+		Name:        "front-check-in",
+		Title:       "Front check-in",
+		Description: "Check in with whoever is front and offer to record a switch",
+	}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		front, err := s.resourceMessage(ctx, frontURI, jsonResource(s.front))
+		if err != nil {
+			return nil, err
+		}
+		members, err := s.resourceMessage(ctx, membersURI, jsonResource(s.members))
+		if err != nil {
+			return nil, err
+		}
+
+		return &mcp.GetPromptResult{
+			Description: "Front check-in",
+			Messages: []*mcp.PromptMessage{
+				front,
+				members,
+				{
+					Role: "user",
+					Content: &mcp.TextContent{Text: "Greet whoever is front by name and mention how long they have been front. " +
+						"Ask if someone else is front now. If so, record the switch with the switch-front tool using one of the member names above."},
+				},
+			},
+		}, nil
+	})
+
+	srv.AddPrompt(&mcp.Prompt{
+		Name:        "upcoming-events",
+		Title:       "Summarize upcoming events",
+		Description: "Summarize the events coming up soon",
+	}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		events, err := s.resourceMessage(ctx, eventsURI, jsonResource(s.upcomingEvents))
+		if err != nil {
+			return nil, err
+		}
+
+		return &mcp.GetPromptResult{
+			Description: "Summarize upcoming events",
+			Messages: []*mcp.PromptMessage{
+				events,
+				{
+					Role:    "user",
+					Content: &mcp.TextContent{Text: "Summarize these upcoming events in a short list, soonest first, with where and when each one is."},
+				},
+			},
+		}, nil
+	})
+}
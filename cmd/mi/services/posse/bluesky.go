@@ -0,0 +1,265 @@
+package posse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bluesky-social/indigo/api/atproto"
+	appbsky "github.com/bluesky-social/indigo/api/bsky"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	lexutil "github.com/bluesky-social/indigo/lex/util"
+	"github.com/bluesky-social/indigo/util"
+	"github.com/bluesky-social/indigo/xrpc"
+	"within.website/x/cmd/mi/models"
+	jsonfeedv1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
+	"within.website/x/web/bskybot"
+)
+
+const (
+	blueskyLimit      = 300 // graphemes
+	blueskyCollection = "app.bsky.feed.post"
+	maxThumbSize      = 1000 * 1000
+)
+
+// Bluesky posts threads with link facets and a link card for the blogpost on
+// the first post. Bluesky doesn't show edits, so changed threads are
+// replaced.
+type Bluesky struct {
+	pds, handle, authkey string
+
+	lock  sync.Mutex
+	agent *bskybot.BskyAgent
+}
+
+var _ Network = &Bluesky{}
+
+func NewBluesky(pds, handle, authkey string) *Bluesky {
+	return &Bluesky{
+		pds:     pds,
+		handle:  handle,
+		authkey: authkey,
+	}
+}
+
+func (*Bluesky) Name() string { return "bluesky" }
+
+func (*Bluesky) Measure(context.Context) (int, func(string) int) {
+	return blueskyLimit, graphemeLength
+}
+
+// client connects to the PDS the first time it is called. The agent keeps
+// its session fresh after that.
+func (b *Bluesky) client(ctx context.Context) (*xrpc.Client, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.agent != nil {
+		return b.agent.Client(), nil
+	}
+
+	agent := bskybot.NewAgent(ctx, b.pds, b.handle, b.authkey)
+	if err := agent.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("posse: can't connect to bluesky: %w", err)
+	}
+	b.agent = &agent
+
+	return b.agent.Client(), nil
+}
+
+func (b *Bluesky) Post(ctx context.Context, it *jsonfeedv1.Item, thread []string) ([]models.SyndicatedPost, error) {
+	cli, err := b.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.SyndicatedPost
+	var root, parent *atproto.RepoStrongRef
+
+	for i, text := range thread {
+		post := blueskyPost(text, time.Now())
+
+		if i == 0 {
+			post.Embed = &appbsky.FeedPost_Embed{
+				EmbedExternal: b.linkCard(ctx, cli, it),
+			}
+		} else {
+			post.Reply = &appbsky.FeedPost_ReplyRef{Root: root, Parent: parent}
+		}
+
+		resp, err := atproto.RepoCreateRecord(ctx, cli, &atproto.RepoCreateRecord_Input{
+			Collection: blueskyCollection,
+			Repo:       cli.Auth.Did,
+			Record:     &lexutil.LexiconTypeDecoder{Val: post},
+		})
+		if err != nil {
+			return result, fmt.Errorf("posse: can't post to bluesky: %w", err)
+		}
+
+		ref := &atproto.RepoStrongRef{Uri: resp.Uri, Cid: resp.Cid}
+		if root == nil {
+			root = ref
+		}
+		parent = ref
+
+		result = append(result, models.SyndicatedPost{
+			BlogpostURL: it.GetUrl(),
+			Network:     b.Name(),
+			Position:    i,
+			RemoteID:    resp.Uri,
+			CID:         resp.Cid,
+			URL:         blueskyWebURL(resp.Uri),
+			Text:        text,
+		})
+	}
+
+	return result, nil
+}
+
+func (b *Bluesky) Delete(ctx context.Context, sp *models.SyndicatedPost) error {
+	cli, err := b.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	uri, err := syntax.ParseATURI(sp.RemoteID)
+	if err != nil {
+		return fmt.Errorf("posse: bad bluesky post URI %q: %w", sp.RemoteID, err)
+	}
+
+	return atproto.RepoDeleteRecord(ctx, cli, &atproto.RepoDeleteRecord_Input{
+		Collection: blueskyCollection,
+		Repo:       uri.Authority().String(),
+		Rkey:       uri.RecordKey().String(),
+	})
+}
+
+// linkCard makes the link card for a blogpost. The blogpost's image is used
+// as the thumbnail if it can be uploaded.
+func (b *Bluesky) linkCard(ctx context.Context, cli *xrpc.Client, it *jsonfeedv1.Item) *appbsky.EmbedExternal {
+	desc := "The newest post on Xe Iaso's blog"
+	if it.GetSummary() != "" {
+		desc = it.GetSummary()
+	}
+
+	result := &appbsky.EmbedExternal{
+		LexiconTypeID: "app.bsky.embed.external",
+		External: &appbsky.EmbedExternal_External{
+			Title:       it.GetTitle(),
+			Uri:         withUTM(it.GetUrl(), b.Name()),
+			Description: desc,
+		},
+	}
+
+	if it.GetImage() != "" {
+		thumb, err := uploadThumb(ctx, cli, it.GetImage())
+		if err != nil {
+			slog.ErrorContext(ctx, "can't upload link card thumbnail", "image", it.GetImage(), "err", err)
+		} else {
+			result.External.Thumb = thumb
+		}
+	}
+
+	return result
+}
+
+func uploadThumb(ctx context.Context, cli *xrpc.Client, imageURL string) (*lexutil.LexBlob, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("posse: %s returned status %d", imageURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxThumbSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxThumbSize {
+		return nil, fmt.Errorf("posse: %s is too big for a thumbnail", imageURL)
+	}
+
+	out, err := atproto.RepoUploadBlob(ctx, cli, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Blob, nil
+}
+
+// blueskyPost makes a post with facets for the links and hashtags in text.
+func blueskyPost(text string, now time.Time) *appbsky.FeedPost {
+	return &appbsky.FeedPost{
+		LexiconTypeID: blueskyCollection,
+		Text:          text,
+		CreatedAt:     now.UTC().Format(util.ISO8601),
+		Facets:        facets(text),
+	}
+}
+
+var hashtagPattern = regexp.MustCompile(`(?:^|\s)(#[\p{L}\p{N}_]+)`)
+
+// facets finds the links and hashtags in text. Facet indexes are byte
+// offsets into the UTF-8 text.
+func facets(text string) []*appbsky.RichtextFacet {
+	var result []*appbsky.RichtextFacet
+
+	for _, loc := range urlPattern.FindAllStringIndex(text, -1) {
+		u := strings.TrimRight(text[loc[0]:loc[1]], ".,;:!?)\"'")
+		result = append(result, &appbsky.RichtextFacet{
+			Index: &appbsky.RichtextFacet_ByteSlice{
+				ByteStart: int64(loc[0]),
+				ByteEnd:   int64(loc[0] + len(u)),
+			},
+			Features: []*appbsky.RichtextFacet_Features_Elem{{
+				RichtextFacet_Link: &appbsky.RichtextFacet_Link{
+					LexiconTypeID: "app.bsky.richtext.facet#link",
+					Uri:           u,
+				},
+			}},
+		})
+	}
+
+	for _, loc := range hashtagPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[2], loc[3]
+		result = append(result, &appbsky.RichtextFacet{
+			Index: &appbsky.RichtextFacet_ByteSlice{
+				ByteStart: int64(start),
+				ByteEnd:   int64(end),
+			},
+			Features: []*appbsky.RichtextFacet_Features_Elem{{
+				RichtextFacet_Tag: &appbsky.RichtextFacet_Tag{
+					LexiconTypeID: "app.bsky.richtext.facet#tag",
+					Tag:           text[start+1 : end],
+				},
+			}},
+		})
+	}
+
+	return result
+}
+
+// blueskyWebURL turns the at:// URI of a post into its bsky.app URL.
+func blueskyWebURL(atURI string) string {
+	uri, err := syntax.ParseATURI(atURI)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("https://bsky.app/profile/%s/post/%s", uri.Authority(), uri.RecordKey())
+}
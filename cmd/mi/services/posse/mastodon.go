@@ -0,0 +1,111 @@
+package posse
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"within.website/x/cmd/mi/models"
+	jsonfeedv1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
+	"within.website/x/web"
+	"within.website/x/web/mastodon"
+)
+
+// Mastodon's defaults, used when the instance doesn't say.
+const (
+	mastodonDefaultLimit     = 500
+	mastodonDefaultURLLength = 23
+)
+
+// Mastodon posts threads of statuses. Statuses are edited in place.
+type Mastodon struct {
+	cli *mastodon.Client
+
+	once      sync.Once
+	limit     int
+	urlLength int
+}
+
+var (
+	_ Network = &Mastodon{}
+	_ Editor  = &Mastodon{}
+)
+
+func NewMastodon(cli *mastodon.Client) *Mastodon {
+	return &Mastodon{cli: cli}
+}
+
+func (*Mastodon) Name() string { return "mastodon" }
+
+// Measure uses the status length limits of the instance, fetched once.
+func (m *Mastodon) Measure(ctx context.Context) (int, func(string) int) {
+	m.once.Do(func() {
+		m.limit = mastodonDefaultLimit
+		m.urlLength = mastodonDefaultURLLength
+
+		inst, err := m.cli.Instance(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "can't fetch mastodon instance limits, using defaults", "err", err)
+			return
+		}
+
+		if n := inst.Configuration.Statuses.MaxCharacters; n > 0 {
+			m.limit = n
+		}
+		if n := inst.Configuration.Statuses.CharactersReservedPerURL; n > 0 {
+			m.urlLength = n
+		}
+	})
+
+	return m.limit, mastodonLength(m.urlLength)
+}
+
+func (m *Mastodon) Post(ctx context.Context, it *jsonfeedv1.Item, thread []string) ([]models.SyndicatedPost, error) {
+	var result []models.SyndicatedPost
+	var inReplyTo string
+
+	for i, text := range thread {
+		st, err := m.cli.CreateStatus(ctx, mastodon.CreateStatusParams{
+			Status:    text,
+			InReplyTo: inReplyTo,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result = append(result, models.SyndicatedPost{
+			BlogpostURL: it.GetUrl(),
+			Network:     m.Name(),
+			Position:    i,
+			RemoteID:    st.ID,
+			URL:         st.URL,
+			Text:        text,
+		})
+		inReplyTo = st.ID
+	}
+
+	return result, nil
+}
+
+func (m *Mastodon) Edit(ctx context.Context, sp *models.SyndicatedPost, text string) error {
+	if _, err := m.cli.EditStatus(ctx, sp.RemoteID, mastodon.EditStatusParams{Status: text}); err != nil {
+		return err
+	}
+
+	sp.Text = text
+	return nil
+}
+
+func (m *Mastodon) Delete(ctx context.Context, sp *models.SyndicatedPost) error {
+	err := m.cli.DeleteStatus(ctx, sp.RemoteID)
+
+	// Someone already deleted it by hand.
+	var werr *web.Error
+	if errors.As(err, &werr) && werr.GotStatus == http.StatusNotFound {
+		return nil
+	}
+
+	return err
+}
@@ -1,22 +1,30 @@
+// Package posse announces new blogposts on social networks and keeps those
+// announcements in sync when blogposts are edited or removed.
 package posse
 
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
-	"strings"
+	"slices"
+	"text/template"
+	"time"
 
-	bsky "github.com/danrusei/gobot-bsky"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/twitchtv/twirp"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"within.website/x/cmd/mi/models"
+	"within.website/x/cmd/mi/services/webhooks"
 	jsonfeedv1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
+	pb "within.website/x/gen/within/website/x/mi/v1"
 	announcev1 "within.website/x/gen/within/website/x/mimi/announce/v1"
+	"within.website/x/web"
 	"within.website/x/web/mastodon"
 )
 
@@ -26,17 +34,44 @@ var (
 		Help: "Number of posts sent to social networks.",
 	}, []string{"service"})
 
+	posseEdits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mi_posse_edits",
+		Help: "Number of posts edited or deleted on social networks.",
+	}, []string{"service", "action"})
+
 	posseErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "mi_posse_errors",
 		Help: "Number of errors encountered while sending posts to social networks.",
 	}, []string{"service"})
 )
 
+// Network is a social network that blogposts are announced on.
+type Network interface {
+	// Name identifies the network in templates, the database and metrics.
+	Name() string
+	// Measure returns how long a post can be and how the network measures
+	// the length of a post.
+	Measure(ctx context.Context) (limit int, length func(string) int)
+	// Post posts a thread for a blogpost and returns the posts that were
+	// made, in order. If posting fails partway through, the posts that were
+	// made are returned with the error.
+	Post(ctx context.Context, it *jsonfeedv1.Item, thread []string) ([]models.SyndicatedPost, error)
+	// Delete deletes a post made by Post.
+	Delete(ctx context.Context, sp *models.SyndicatedPost) error
+}
+
+// Editor is a Network that can change the text of a post in place. Threads
+// on networks that can't edit posts are deleted and posted again.
+type Editor interface {
+	Edit(ctx context.Context, sp *models.SyndicatedPost, text string) error
+}
+
 type Announcer struct {
-	dao      *models.DAO
-	mastodon *mastodon.Client
-	mimi     announcev1.Announce
-	cfg      Config
+	dao       *models.DAO
+	networks  []Network
+	templates map[string]*template.Template
+	mimi      announcev1.Announce
+	cfg       Config
 
 	announcev1.UnimplementedAnnounceServer
 }
@@ -48,20 +83,58 @@ type Config struct {
 	MastodonToken   string
 	MastodonURL     string
 	MimiAnnounceURL string
+	// FeedURL is the blog's JSON Feed, used to find edited and removed
+	// blogposts.
+	FeedURL string
+	// Templates are text/template sources for announcements by network
+	// name, overriding DefaultTemplates.
+	Templates map[string]string
+	// Publisher, if set, is told about new blogposts.
+	Publisher webhooks.Publisher
 }
 
 func New(ctx context.Context, dao *models.DAO, cfg Config) (*Announcer, error) {
-	mas, err := mastodon.Authenticated("mi_irl", "https://xeiaso.net", cfg.MastodonURL, cfg.MastodonToken)
+	var networks []Network
+
+	if cfg.MastodonToken != "" {
+		mas, err := mastodon.Authenticated("mi_irl", "https://xeiaso.net", cfg.MastodonURL, cfg.MastodonToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to authenticate to mastodon: %w", err)
+		}
+		networks = append(networks, NewMastodon(mas))
+	}
+
+	if cfg.BlueskyAuthkey != "" {
+		networks = append(networks, NewBluesky(cfg.BlueskyPDS, cfg.BlueskyHandle, cfg.BlueskyAuthkey))
+	}
+
+	return newAnnouncer(dao, cfg, networks...)
+}
+
+func newAnnouncer(dao *models.DAO, cfg Config, networks ...Network) (*Announcer, error) {
+	templates, err := parseTemplates(cfg.Templates)
 	if err != nil {
-		return nil, fmt.Errorf("failed to authenticate to mastodon: %w", err)
+		return nil, err
+	}
+
+	for _, n := range networks {
+		if _, ok := templates[n.Name()]; !ok {
+			return nil, fmt.Errorf("posse: no template for network %s", n.Name())
+		}
+	}
+
+	a := &Announcer{
+		dao:       dao,
+		networks:  networks,
+		templates: templates,
+		cfg:       cfg,
 	}
 
-	return &Announcer{
-		dao:      dao,
-		mastodon: mas,
-		mimi:     announcev1.NewAnnounceProtobufClient(cfg.MimiAnnounceURL, &http.Client{}),
-		cfg:      cfg,
-	}, nil
+	if cfg.MimiAnnounceURL != "" {
+		a.mimi = announcev1.NewAnnounceProtobufClient(cfg.MimiAnnounceURL, &http.Client{})
+	}
+
+	return a, nil
 }
 
 func (a *Announcer) Announce(ctx context.Context, it *jsonfeedv1.Item) (*emptypb.Empty, error) {
@@ -75,100 +148,327 @@ func (a *Announcer) Announce(ctx context.Context, it *jsonfeedv1.Item) (*emptypb
 		slog.InfoContext(ctx, "skipping announcement", "url", it.GetUrl(), "reason", "non-prod URLs")
 		return &emptypb.Empty{}, nil
 	}
-	if has, err := a.dao.HasBlogpost(ctx, it.GetUrl()); err != nil {
-		return nil, err
-	} else if has {
-		return &emptypb.Empty{}, nil
+
+	known, err := a.dao.HasBlogpost(ctx, it.GetUrl())
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
 	}
 
-	var sb strings.Builder
-	fmt.Fprintf(&sb, "%s\n\n%s", it.GetTitle(), it.GetUrl())
+	if known {
+		if err := a.update(ctx, it); err != nil {
+			return nil, twirp.InternalErrorWith(err)
+		}
+		return &emptypb.Empty{}, nil
+	}
 
 	if _, err := a.dao.InsertBlogpost(ctx, it); err != nil {
 		return nil, twirp.InternalErrorWith(err)
 	}
 
-	// announce to bluesky and mastodon
+	if a.cfg.Publisher != nil {
+		if err := a.cfg.Publisher.Publish(ctx, webhooks.Event{
+			Type:  webhooks.TypeBlogpostPublished,
+			Title: it.GetTitle(),
+			URL:   it.GetUrl(),
+			Data:  it,
+		}); err != nil {
+			slog.ErrorContext(ctx, "can't publish blogpost", "url", it.GetUrl(), "err", err)
+		}
+	}
+
+	plans, err := a.plan(ctx, it, false)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
 	g, gCtx := errgroup.WithContext(ctx)
+	for _, p := range plans {
+		g.Go(func() error { return a.apply(gCtx, it, p) })
+	}
+
 	g.Go(func() error {
-		post, err := a.mastodon.CreateStatus(gCtx, mastodon.CreateStatusParams{
-			Status: sb.String() + "?utm_campaign=mi_irl&utm_medium=social&utm_source=mastodon",
-		})
-		if err != nil {
-			posseErrors.WithLabelValues("mastodon").Inc()
-			slog.ErrorContext(gCtx, "failed to announce to mastodon", "err", err)
-			return err
+		if a.mimi == nil {
+			return nil
+		}
+		if _, err := a.mimi.Announce(gCtx, it); err != nil {
+			slog.ErrorContext(gCtx, "failed to announce to mimi", "err", err)
+			return nil
 		}
-		possePosts.WithLabelValues("mastodon").Inc()
-		slog.InfoContext(gCtx, "posted to mastodon", "blogpost_url", it.GetUrl(), "mastodon_url", post.URL)
+		possePosts.WithLabelValues("irc").Inc()
 		return nil
 	})
 
-	g.Go(func() error {
-		bluesky := bsky.NewAgent(gCtx, a.cfg.BlueskyPDS, a.cfg.BlueskyHandle, a.cfg.BlueskyAuthkey)
-		if err := bluesky.Connect(gCtx); err != nil {
-			posseErrors.WithLabelValues("bluesky").Inc()
-			slog.ErrorContext(gCtx, "failed to connect to bluesky", "err", err)
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// update syncs the threads of a blogpost that was already announced with
+// its current contents.
+func (a *Announcer) update(ctx context.Context, it *jsonfeedv1.Item) error {
+	if err := a.dao.UpdateBlogpost(ctx, it); err != nil {
+		return err
+	}
+
+	plans, err := a.plan(ctx, it, true)
+	if err != nil {
+		return err
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	for _, p := range plans {
+		g.Go(func() error { return a.apply(gCtx, it, p) })
+	}
+
+	return g.Wait()
+}
+
+// retract deletes the threads of a blogpost that was removed and forgets
+// about it.
+func (a *Announcer) retract(ctx context.Context, postURL string) error {
+	for _, n := range a.networks {
+		existing, err := a.dao.SyndicatedPosts(ctx, postURL, n.Name())
+		if err != nil {
 			return err
 		}
 
-		if err := bluesky.Connect(gCtx); err != nil {
-			posseErrors.WithLabelValues("bluesky").Inc()
-			slog.ErrorContext(gCtx, "failed to connect to bluesky", "err", err)
-			return err
+		for _, sp := range existing {
+			if err := n.Delete(ctx, &sp); err != nil {
+				posseErrors.WithLabelValues(n.Name()).Inc()
+				return fmt.Errorf("posse: can't delete %s post %s: %w", n.Name(), sp.URL, err)
+			}
+			posseEdits.WithLabelValues(n.Name(), "delete").Inc()
 		}
+	}
 
-		u, err := url.Parse(it.GetUrl())
+	slog.InfoContext(ctx, "retracted blogpost", "url", postURL)
+	return a.dao.DeleteBlogpost(ctx, postURL)
+}
+
+// threadPlan is what announcing a blogpost does on one network.
+type threadPlan struct {
+	network  Network
+	action   pb.POSSEAction
+	limit    int
+	posts    []string
+	existing []models.SyndicatedPost
+}
+
+func (tp threadPlan) AsProto() *pb.POSSEThread {
+	result := &pb.POSSEThread{
+		Network: tp.network.Name(),
+		Action:  tp.action,
+		Limit:   int32(tp.limit),
+		Posts:   tp.posts,
+	}
+
+	for _, sp := range tp.existing {
+		result.Existing = append(result.Existing, sp.Text)
+	}
+
+	return result
+}
+
+// plan renders a blogpost for every network and works out what to do with
+// the threads that were already posted. Threads are only created for new
+// blogposts, so blogposts announced before threads were recorded are left
+// alone.
+func (a *Announcer) plan(ctx context.Context, it *jsonfeedv1.Item, known bool) ([]threadPlan, error) {
+	var result []threadPlan
+
+	for _, n := range a.networks {
+		text, err := render(a.templates[n.Name()], n.Name(), it)
 		if err != nil {
-			posseErrors.WithLabelValues("bluesky").Inc()
-			slog.ErrorContext(gCtx, "failed to parse url", "err", err)
-			return err
+			return nil, err
+		}
+
+		limit, length := n.Measure(ctx)
+		tp := threadPlan{
+			network: n,
+			limit:   limit,
+			posts:   splitThread(text, limit, length),
+		}
+
+		tp.existing, err = a.dao.SyndicatedPosts(ctx, it.GetUrl(), n.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		_, canEdit := n.(Editor)
+
+		switch {
+		case len(tp.existing) == 0 && known:
+			tp.action = pb.POSSEAction_POSSE_ACTION_NONE
+		case len(tp.existing) == 0:
+			tp.action = pb.POSSEAction_POSSE_ACTION_CREATE
+		case slices.Equal(tp.posts, texts(tp.existing)):
+			tp.action = pb.POSSEAction_POSSE_ACTION_NONE
+		case canEdit && len(tp.posts) == len(tp.existing):
+			tp.action = pb.POSSEAction_POSSE_ACTION_EDIT
+		default:
+			tp.action = pb.POSSEAction_POSSE_ACTION_REPLACE
+		}
+
+		result = append(result, tp)
+	}
+
+	return result, nil
+}
+
+func texts(sps []models.SyndicatedPost) []string {
+	var result []string
+	for _, sp := range sps {
+		result = append(result, sp.Text)
+	}
+	return result
+}
+
+// apply carries out a plan and records the posts that were made.
+func (a *Announcer) apply(ctx context.Context, it *jsonfeedv1.Item, tp threadPlan) error {
+	n := tp.network
+	lg := slog.With("network", n.Name(), "blogpost_url", it.GetUrl(), "action", tp.action)
+
+	switch tp.action {
+	case pb.POSSEAction_POSSE_ACTION_EDIT:
+		for i := range tp.existing {
+			sp := &tp.existing[i]
+			if sp.Text == tp.posts[i] {
+				continue
+			}
+
+			if err := n.(Editor).Edit(ctx, sp, tp.posts[i]); err != nil {
+				posseErrors.WithLabelValues(n.Name()).Inc()
+				lg.ErrorContext(ctx, "can't edit post", "post_url", sp.URL, "err", err)
+				return err
+			}
+			posseEdits.WithLabelValues(n.Name(), "edit").Inc()
+
+			if err := a.dao.UpdateSyndicatedPost(ctx, sp); err != nil {
+				return err
+			}
+		}
+
+		lg.InfoContext(ctx, "edited thread")
+		return nil
+
+	case pb.POSSEAction_POSSE_ACTION_REPLACE:
+		for _, sp := range tp.existing {
+			if err := n.Delete(ctx, &sp); err != nil {
+				posseErrors.WithLabelValues(n.Name()).Inc()
+				lg.ErrorContext(ctx, "can't delete post", "post_url", sp.URL, "err", err)
+				return err
+			}
+			posseEdits.WithLabelValues(n.Name(), "delete").Inc()
 		}
 
-		q := u.Query()
-		q.Set("utm_campaign", "mi_irl")
-		q.Set("utm_medium", "social")
-		q.Set("utm_source", "bluesky")
-		u.RawQuery = q.Encode()
+		fallthrough
 
-		desc := "The newest post on Xe Iaso's blog"
-		if it.GetSummary() != "" {
-			desc = it.GetSummary()
+	case pb.POSSEAction_POSSE_ACTION_CREATE:
+		posted, err := n.Post(ctx, it, tp.posts)
+		possePosts.WithLabelValues(n.Name()).Add(float64(len(posted)))
+
+		if rerr := a.dao.ReplaceSyndicatedPosts(ctx, it.GetUrl(), n.Name(), posted); rerr != nil {
+			lg.ErrorContext(ctx, "can't record posts", "err", rerr)
+			if err == nil {
+				err = rerr
+			}
 		}
-		post, err := bsky.NewPostBuilder(sb.String()).
-			WithExternalLink(it.GetTitle(), *u, desc).
-			WithFacet(bsky.Facet_Link, it.GetUrl(), it.GetUrl()).
-			Build()
+
 		if err != nil {
-			posseErrors.WithLabelValues("bluesky").Inc()
-			slog.ErrorContext(gCtx, "failed to build bluesky post", "err", err)
+			posseErrors.WithLabelValues(n.Name()).Inc()
+			lg.ErrorContext(ctx, "failed to post thread", "posted", len(posted), "err", err)
 			return err
 		}
 
-		cid, uri, err := bluesky.PostToFeed(ctx, post)
+		if len(posted) != 0 {
+			lg.InfoContext(ctx, "posted thread", "url", posted[0].URL, "posts", len(posted))
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// Sync updates the threads of every blogpost in feed that was edited and
+// retracts blogposts that were removed from it. Only blogposts published
+// since the oldest one in the feed can be noticed as removed, so a feed
+// that only has the newest posts doesn't retract the rest.
+func (a *Announcer) Sync(ctx context.Context, feed *jsonfeedv1.Feed) error {
+	inFeed := map[string]bool{}
+	var oldest time.Time
+
+	for _, it := range feed.GetItems() {
+		inFeed[it.GetUrl()] = true
+
+		if dp := it.GetDatePublished(); dp != nil && (oldest.IsZero() || dp.AsTime().Before(oldest)) {
+			oldest = dp.AsTime()
+		}
+
+		known, err := a.dao.HasBlogpost(ctx, it.GetUrl())
 		if err != nil {
-			posseErrors.WithLabelValues("bluesky").Inc()
-			slog.ErrorContext(ctx, "failed to post to bluesky", "err", err)
 			return err
 		}
+		if !known {
+			continue
+		}
+
+		if err := a.update(ctx, it); err != nil {
+			return fmt.Errorf("posse: can't update %s: %w", it.GetUrl(), err)
+		}
+	}
 
-		possePosts.WithLabelValues("bluesky").Inc()
-		slog.InfoContext(ctx, "posted to bluesky", "blogpost_url", it.GetUrl(), "bluesky_cid", cid, "bluesky_uri", uri)
+	if oldest.IsZero() {
 		return nil
-	})
+	}
 
-	g.Go(func() error {
-		if _, err := a.mimi.Announce(gCtx, it); err != nil {
-			slog.ErrorContext(gCtx, "failed to announce to mimi", "err", err)
-			return nil
+	bps, err := a.dao.BlogpostsPublishedSince(ctx, oldest)
+	if err != nil {
+		return err
+	}
+
+	for _, bp := range bps {
+		if inFeed[bp.URL] {
+			continue
 		}
-		possePosts.WithLabelValues("irc").Inc()
-		return nil
-	})
 
-	if err := g.Wait(); err != nil {
+		if err := a.retract(ctx, bp.URL); err != nil {
+			return fmt.Errorf("posse: can't retract %s: %w", bp.URL, err)
+		}
+	}
+
+	return nil
+}
+
+// FetchFeed fetches a JSON Feed.
+func FetchFeed(ctx context.Context, feedURL string) (*jsonfeedv1.Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
 		return nil, err
 	}
+	req.Header.Set("Accept", "application/feed+json, application/json")
 
-	return &emptypb.Empty{}, nil
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, web.NewError(http.StatusOK, resp)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024*1024))
+	if err != nil {
+		return nil, err
+	}
+
+	var result jsonfeedv1.Feed
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("posse: can't parse feed: %w", err)
+	}
+
+	return &result, nil
 }
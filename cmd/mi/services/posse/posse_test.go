@@ -0,0 +1,416 @@
+package posse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"within.website/x/cmd/mi/models"
+	"within.website/x/cmd/mi/services/webhooks"
+	jsonfeedv1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
+	pb "within.website/x/gen/within/website/x/mi/v1"
+	"within.website/x/web/mastodon"
+)
+
+// fakeNetwork keeps its posts in memory.
+type fakeNetwork struct {
+	name  string
+	limit int
+
+	lock    sync.Mutex
+	nextID  int
+	posts   map[string]string // remote ID -> text
+	deleted []string
+}
+
+func newFakeNetwork(name string, limit int) *fakeNetwork {
+	return &fakeNetwork{name: name, limit: limit, posts: map[string]string{}}
+}
+
+func (fn *fakeNetwork) Name() string { return fn.name }
+
+func (fn *fakeNetwork) Measure(context.Context) (int, func(string) int) {
+	return fn.limit, utf8.RuneCountInString
+}
+
+func (fn *fakeNetwork) Post(ctx context.Context, it *jsonfeedv1.Item, thread []string) ([]models.SyndicatedPost, error) {
+	fn.lock.Lock()
+	defer fn.lock.Unlock()
+
+	var result []models.SyndicatedPost
+	for i, text := range thread {
+		fn.nextID++
+		id := fmt.Sprint(fn.nextID)
+		fn.posts[id] = text
+		result = append(result, models.SyndicatedPost{
+			BlogpostURL: it.GetUrl(),
+			Network:     fn.name,
+			Position:    i,
+			RemoteID:    id,
+			URL:         "https://" + fn.name + ".example/" + id,
+			Text:        text,
+		})
+	}
+
+	return result, nil
+}
+
+func (fn *fakeNetwork) Delete(ctx context.Context, sp *models.SyndicatedPost) error {
+	fn.lock.Lock()
+	defer fn.lock.Unlock()
+
+	delete(fn.posts, sp.RemoteID)
+	fn.deleted = append(fn.deleted, sp.RemoteID)
+	return nil
+}
+
+// fakeEditor is a fakeNetwork that can edit posts.
+type fakeEditor struct {
+	*fakeNetwork
+	edits int
+}
+
+func (fe *fakeEditor) Edit(ctx context.Context, sp *models.SyndicatedPost, text string) error {
+	fe.lock.Lock()
+	defer fe.lock.Unlock()
+
+	fe.posts[sp.RemoteID] = text
+	fe.edits++
+	sp.Text = text
+	return nil
+}
+
+type fakePublisher struct {
+	events []webhooks.Event
+}
+
+func (fp *fakePublisher) Publish(ctx context.Context, ev webhooks.Event) error {
+	fp.events = append(fp.events, ev)
+	return nil
+}
+
+func newTestDAO(t *testing.T) *models.DAO {
+	t.Helper()
+
+	dir := t.TempDir()
+	dao, err := models.New(filepath.Join(dir, "test.db"), filepath.Join(dir, "backup.db"))
+	if err != nil {
+		t.Fatalf("failed to create dao: %v", err)
+	}
+
+	return dao
+}
+
+func TestAnnouncer(t *testing.T) {
+	ctx := t.Context()
+	dao := newTestDAO(t)
+
+	bsky := newFakeNetwork("bluesky", 300)
+	mast := &fakeEditor{fakeNetwork: newFakeNetwork("mastodon", 60)}
+	pub := &fakePublisher{}
+
+	ann, err := newAnnouncer(dao, Config{Publisher: pub}, bsky, mast)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewServer(ann)
+
+	it := &jsonfeedv1.Item{
+		Id:            "https://xeiaso.net/blog/2025/test",
+		Url:           "https://xeiaso.net/blog/2025/test",
+		Title:         "Test post",
+		Summary:       "This summary is long enough that the Mastodon announcement has to be a thread.",
+		DatePublished: timestamppb.New(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	preview, err := srv.Preview(ctx, it)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Known || len(preview.Threads) != 2 {
+		t.Fatalf("wrong preview of new post: %v", preview)
+	}
+	for _, th := range preview.Threads {
+		if th.Action != pb.POSSEAction_POSSE_ACTION_CREATE {
+			t.Errorf("new post should be created on %s, got: %s", th.Network, th.Action)
+		}
+	}
+	if len(bsky.posts) != 0 || len(mast.posts) != 0 {
+		t.Fatal("preview posted something")
+	}
+
+	if _, err := ann.Announce(ctx, it); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pub.events) != 1 || pub.events[0].Type != webhooks.TypeBlogpostPublished {
+		t.Errorf("blogpost wasn't published: %v", pub.events)
+	}
+	if len(bsky.posts) != 1 {
+		t.Errorf("wanted one bluesky post, got: %d", len(bsky.posts))
+	}
+	if len(mast.posts) < 2 {
+		t.Errorf("wanted a mastodon thread, got: %d posts", len(mast.posts))
+	}
+
+	sps, err := dao.SyndicatedPosts(ctx, it.Url, "mastodon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sps) != len(mast.posts) {
+		t.Fatalf("wanted %d recorded mastodon posts, got: %d", len(mast.posts), len(sps))
+	}
+	for i, sp := range sps {
+		if sp.Position != i || mast.posts[sp.RemoteID] != sp.Text {
+			t.Errorf("recorded post %d doesn't match what was posted: %+v", i, sp)
+		}
+	}
+
+	// Announcing it again does nothing.
+	if _, err := ann.Announce(ctx, it); err != nil {
+		t.Fatal(err)
+	}
+	if len(pub.events) != 1 || len(bsky.deleted) != 0 || mast.edits != 0 {
+		t.Error("announcing an unchanged post did something")
+	}
+
+	// Edit the title.
+	it.Title = "Edited post"
+
+	preview, err = srv.Preview(ctx, it)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !preview.Known {
+		t.Error("preview should know about the post")
+	}
+	wantActions := map[string]pb.POSSEAction{
+		"bluesky":  pb.POSSEAction_POSSE_ACTION_REPLACE,
+		"mastodon": pb.POSSEAction_POSSE_ACTION_EDIT,
+	}
+	for _, th := range preview.Threads {
+		if th.Action != wantActions[th.Network] {
+			t.Errorf("wrong action for %s: wanted %s, got: %s", th.Network, wantActions[th.Network], th.Action)
+		}
+		if len(th.Existing) == 0 {
+			t.Errorf("preview for %s should have the existing thread", th.Network)
+		}
+	}
+
+	if _, err := ann.Announce(ctx, it); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bsky.deleted) != 1 || len(bsky.posts) != 1 {
+		t.Errorf("bluesky thread should have been replaced, deleted: %v, posts: %v", bsky.deleted, bsky.posts)
+	}
+	if mast.edits != 1 || len(mast.deleted) != 0 {
+		t.Errorf("only the first mastodon post should be edited, edits: %d, deleted: %v", mast.edits, mast.deleted)
+	}
+
+	sps, err = dao.SyndicatedPosts(ctx, it.Url, "bluesky")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sps) != 1 || bsky.posts[sps[0].RemoteID] != sps[0].Text {
+		t.Errorf("replaced bluesky post wasn't recorded: %+v", sps)
+	}
+
+	// Removing the post from the feed retracts it, but only if it's newer
+	// than the oldest post in the feed.
+	older := &jsonfeedv1.Item{
+		Url:           "https://xeiaso.net/blog/2024/older",
+		Title:         "Older post",
+		DatePublished: timestamppb.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	if err := ann.Sync(ctx, &jsonfeedv1.Feed{}); err != nil {
+		t.Fatal(err)
+	}
+	if has, _ := dao.HasBlogpost(ctx, it.Url); !has {
+		t.Fatal("empty feed retracted a blogpost")
+	}
+
+	if err := ann.Sync(ctx, &jsonfeedv1.Feed{Items: []*jsonfeedv1.Item{older}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if has, _ := dao.HasBlogpost(ctx, it.Url); has {
+		t.Error("removed blogpost should be forgotten")
+	}
+	if len(bsky.posts) != 0 || len(mast.posts) != 0 {
+		t.Errorf("removed blogpost's posts should be deleted, bluesky: %v, mastodon: %v", bsky.posts, mast.posts)
+	}
+	for _, network := range []string{"bluesky", "mastodon"} {
+		sps, err := dao.SyndicatedPosts(ctx, it.Url, network)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sps) != 0 {
+			t.Errorf("%s posts of removed blogpost are still recorded", network)
+		}
+	}
+}
+
+func TestAnnouncerLeavesUnrecordedPostsAlone(t *testing.T) {
+	ctx := t.Context()
+	dao := newTestDAO(t)
+
+	bsky := newFakeNetwork("bluesky", 300)
+	ann, err := newAnnouncer(dao, Config{}, bsky)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// This was announced before posts were recorded.
+	it := &jsonfeedv1.Item{Url: "https://xeiaso.net/blog/2020/old", Title: "Old post"}
+	if _, err := dao.InsertBlogpost(ctx, it); err != nil {
+		t.Fatal(err)
+	}
+
+	it.Title = "Old post, edited"
+	if err := ann.Sync(ctx, &jsonfeedv1.Feed{Items: []*jsonfeedv1.Item{it}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bsky.posts) != 0 {
+		t.Error("old blogpost was announced again")
+	}
+}
+
+func TestAnnounceSkipsNonProd(t *testing.T) {
+	bsky := newFakeNetwork("bluesky", 300)
+	ann, err := newAnnouncer(newTestDAO(t), Config{}, bsky)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ann.Announce(t.Context(), &jsonfeedv1.Item{Url: "http://localhost:3000/blog/test", Title: "Test"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bsky.posts) != 0 {
+		t.Error("non-prod blogpost was announced")
+	}
+}
+
+func TestMastodon(t *testing.T) {
+	var lock sync.Mutex
+	var inReplyTo, edited []string
+	var deleted int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/instance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"uri":"pony.social","configuration":{"statuses":{"max_characters":1000,"characters_reserved_per_url":20}}}`)
+	})
+	mux.HandleFunc("POST /api/v1/statuses", func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		inReplyTo = append(inReplyTo, r.FormValue("in_reply_to_id"))
+		fmt.Fprintf(w, `{"id":"%d","url":"https://pony.social/@cadey/%d"}`, len(inReplyTo), len(inReplyTo))
+	})
+	mux.HandleFunc("PUT /api/v1/statuses/{id}", func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		edited = append(edited, r.PathValue("id")+":"+r.FormValue("status"))
+		fmt.Fprintf(w, `{"id":"%s"}`, r.PathValue("id"))
+	})
+	mux.HandleFunc("DELETE /api/v1/statuses/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "404" {
+			http.NotFound(w, r)
+			return
+		}
+		deleted++
+		fmt.Fprint(w, `{}`)
+	})
+
+	hs := httptest.NewServer(mux)
+	defer hs.Close()
+
+	cli, err := mastodon.Authenticated("test", "https://xeiaso.net", hs.URL, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMastodon(cli)
+	ctx := t.Context()
+
+	limit, length := m.Measure(ctx)
+	if limit != 1000 {
+		t.Errorf("wanted the instance's limit, got: %d", limit)
+	}
+	if n := length("see https://xeiaso.net/blog/2025/a-long-url"); n != 24 {
+		t.Errorf("URLs should count as 20 characters, got length %d", n)
+	}
+
+	it := &jsonfeedv1.Item{Url: "https://xeiaso.net/blog/test"}
+	sps, err := m.Post(ctx, it, []string{"one", "two", "three"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(inReplyTo, []string{"", "1", "2"}) {
+		t.Errorf("thread should reply to the previous post, got: %q", inReplyTo)
+	}
+	if len(sps) != 3 || sps[2].RemoteID != "3" || sps[2].URL != "https://pony.social/@cadey/3" || sps[2].Position != 2 {
+		t.Errorf("wrong posts: %+v", sps)
+	}
+
+	if err := m.Edit(ctx, &sps[1], "deux"); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(edited, []string{"2:deux"}) || sps[1].Text != "deux" {
+		t.Errorf("wrong edit: %q", edited)
+	}
+
+	if err := m.Delete(ctx, &sps[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Delete(ctx, &models.SyndicatedPost{RemoteID: "404"}); err != nil {
+		t.Errorf("deleting a post that's already gone should work: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("wanted one deletion, got: %d", deleted)
+	}
+}
+
+func TestFetchFeed(t *testing.T) {
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/feed+json")
+		fmt.Fprint(w, `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "Xe Iaso's blog",
+  "items": [
+    {
+      "id": "https://xeiaso.net/blog/2025/test",
+      "url": "https://xeiaso.net/blog/2025/test",
+      "title": "Test post",
+      "date_published": "2025-06-01T00:00:00Z",
+      "tags": ["test"],
+      "_xesite_frontmatter": {"series": "test"}
+    }
+  ]
+}`)
+	}))
+	defer hs.Close()
+
+	feed, err := FetchFeed(t.Context(), hs.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Items) != 1 {
+		t.Fatalf("wanted one item, got: %d", len(feed.Items))
+	}
+
+	it := feed.Items[0]
+	if it.Title != "Test post" || !it.DatePublished.AsTime().Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("wrong item: %v", it)
+	}
+}
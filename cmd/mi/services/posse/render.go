@@ -0,0 +1,222 @@
+package posse
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	jsonfeedv1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
+)
+
+// DefaultTemplates are the text/template sources used to announce blogposts
+// on each network when Config.Templates doesn't have one. Bluesky posts get
+// a link card with the summary, so it is left out of the text.
+var DefaultTemplates = map[string]string{
+	"bluesky":  "{{.Title}}\n\n{{.URL}}",
+	"mastodon": "{{.Title}}{{with .Summary}}\n\n{{.}}{{end}}\n\n{{.URL}}{{with .Hashtags}}\n\n{{.}}{{end}}",
+}
+
+// TemplateData is what announcement templates are executed with.
+type TemplateData struct {
+	Title   string
+	Summary string
+	// URL is the URL of the blogpost with tracking parameters for the
+	// network.
+	URL string
+	// Tags are the tags of the blogpost, and Hashtags are those tags as
+	// space-separated hashtags.
+	Tags     []string
+	Hashtags string
+}
+
+func parseTemplates(sources map[string]string) (map[string]*template.Template, error) {
+	result := map[string]*template.Template{}
+
+	for name, src := range DefaultTemplates {
+		if override, ok := sources[name]; ok && override != "" {
+			src = override
+		}
+
+		tmpl, err := template.New(name).Parse(src)
+		if err != nil {
+			return nil, fmt.Errorf("posse: can't parse %s template: %w", name, err)
+		}
+
+		result[name] = tmpl
+	}
+
+	return result, nil
+}
+
+// render executes the template for a network with a blogpost.
+func render(tmpl *template.Template, network string, it *jsonfeedv1.Item) (string, error) {
+	var hashtags []string
+	for _, tag := range it.GetTags() {
+		if ht := hashtag(tag); ht != "" {
+			hashtags = append(hashtags, ht)
+		}
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, TemplateData{
+		Title:    it.GetTitle(),
+		Summary:  it.GetSummary(),
+		URL:      withUTM(it.GetUrl(), network),
+		Tags:     it.GetTags(),
+		Hashtags: strings.Join(hashtags, " "),
+	}); err != nil {
+		return "", fmt.Errorf("posse: can't render %s template: %w", network, err)
+	}
+
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// withUTM adds tracking parameters for a network to a blogpost URL.
+func withUTM(postURL, network string) string {
+	u, err := url.Parse(postURL)
+	if err != nil {
+		return postURL
+	}
+
+	q := u.Query()
+	q.Set("utm_campaign", "mi_irl")
+	q.Set("utm_medium", "social")
+	q.Set("utm_source", network)
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+// hashtag turns a tag such as "large language models" into a hashtag such
+// as "#LargeLanguageModels".
+func hashtag(tag string) string {
+	var sb strings.Builder
+	for word := range strings.FieldsFuncSeq(tag, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) }) {
+		r, size := utf8.DecodeRuneInString(word)
+		sb.WriteRune(unicode.ToUpper(r))
+		sb.WriteString(word[size:])
+	}
+
+	if sb.Len() == 0 {
+		return ""
+	}
+
+	return "#" + sb.String()
+}
+
+// counterRoom is how much room is left at the end of each post in a thread
+// for its counter.
+const counterRoom = len(" (99/99)")
+
+// splitThread splits text into posts no longer than limit, as measured by
+// length. Text is split between paragraphs when possible, then between
+// words. When there is more than one post, each one ends with a counter such
+// as "(1/3)".
+func splitThread(text string, limit int, length func(string) int) []string {
+	text = strings.TrimSpace(text)
+	if length(text) <= limit {
+		return []string{text}
+	}
+
+	budget := limit - counterRoom
+	var posts []string
+	var cur string
+
+	add := func(piece, sep string) {
+		switch {
+		case cur == "":
+			cur = piece
+		case length(cur+sep+piece) <= budget:
+			cur += sep + piece
+		default:
+			posts = append(posts, cur)
+			cur = piece
+		}
+	}
+
+	for para := range strings.SplitSeq(text, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+
+		if length(para) <= budget {
+			add(para, "\n\n")
+			continue
+		}
+
+		// The paragraph doesn't fit in a post, so split it between words.
+		sep := "\n\n"
+		for _, word := range wordPattern.FindAllString(para, -1) {
+			ws := strings.TrimRightFunc(word, unicode.IsSpace)
+			for _, piece := range splitWord(ws, budget, length) {
+				add(piece, sep)
+				sep = " "
+			}
+			if strings.Contains(word[len(ws):], "\n") {
+				sep = "\n"
+			}
+		}
+	}
+
+	if cur != "" {
+		posts = append(posts, cur)
+	}
+
+	for i := range posts {
+		posts[i] = fmt.Sprintf("%s (%d/%d)", posts[i], i+1, len(posts))
+	}
+
+	return posts
+}
+
+var wordPattern = regexp.MustCompile(`\S+\s*`)
+
+// splitWord splits a word that is too long for a post between graphemes.
+// URLs are never split, because half a link is useless.
+func splitWord(word string, budget int, length func(string) int) []string {
+	if length(word) <= budget || urlPattern.MatchString(word) {
+		return []string{word}
+	}
+
+	var result []string
+	var cur string
+	gr := uniseg.NewGraphemes(word)
+	for gr.Next() {
+		if cur != "" && length(cur+gr.Str()) > budget {
+			result = append(result, cur)
+			cur = ""
+		}
+		cur += gr.Str()
+	}
+
+	if cur != "" {
+		result = append(result, cur)
+	}
+
+	return result
+}
+
+// graphemeLength measures text the way Bluesky does.
+func graphemeLength(text string) int {
+	return uniseg.GraphemeClusterCount(text)
+}
+
+var urlPattern = regexp.MustCompile(`https?://[^\s]+`)
+
+// mastodonLength returns a function that measures text the way Mastodon
+// does: in code points, with every URL counting as urlLength.
+func mastodonLength(urlLength int) func(string) int {
+	return func(text string) int {
+		n := utf8.RuneCountInString(text)
+		for _, u := range urlPattern.FindAllString(text, -1) {
+			n += urlLength - utf8.RuneCountInString(u)
+		}
+		return n
+	}
+}
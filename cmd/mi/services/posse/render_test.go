@@ -0,0 +1,154 @@
+package posse
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	jsonfeedv1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
+)
+
+func TestRender(t *testing.T) {
+	templates, err := parseTemplates(map[string]string{
+		"bluesky": "{{.Title}} {{.URL}}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it := &jsonfeedv1.Item{
+		Title:   "Anubis works",
+		Url:     "https://xeiaso.net/blog/2025/anubis",
+		Summary: "It does.",
+		Tags:    []string{"anubis", "large language models", "!!!"},
+	}
+
+	got, err := render(templates["bluesky"], "bluesky", it)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Anubis works https://xeiaso.net/blog/2025/anubis?utm_campaign=mi_irl&utm_medium=social&utm_source=bluesky"; got != want {
+		t.Errorf("wrong bluesky text:\nwant: %q\ngot:  %q", want, got)
+	}
+
+	got, err = render(templates["mastodon"], "mastodon", it)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Anubis works\n\nIt does.\n\nhttps://xeiaso.net/blog/2025/anubis?utm_campaign=mi_irl&utm_medium=social&utm_source=mastodon\n\n#Anubis #LargeLanguageModels"
+	if got != want {
+		t.Errorf("wrong mastodon text:\nwant: %q\ngot:  %q", want, got)
+	}
+
+	if _, err := parseTemplates(map[string]string{"mastodon": "{{.Title"}); err == nil {
+		t.Error("bad template was accepted")
+	}
+}
+
+func TestSplitThread(t *testing.T) {
+	runes := utf8.RuneCountInString
+
+	for _, tt := range []struct {
+		name   string
+		text   string
+		limit  int
+		length func(string) int
+		want   []string
+	}{
+		{
+			name:   "fits",
+			text:   "hello world",
+			limit:  20,
+			length: runes,
+			want:   []string{"hello world"},
+		},
+		{
+			name:   "paragraphs",
+			text:   "first paragraph\n\nsecond paragraph\n\nthird",
+			limit:  30,
+			length: runes,
+			want:   []string{"first paragraph (1/3)", "second paragraph (2/3)", "third (3/3)"},
+		},
+		{
+			name:   "words",
+			text:   "the quick brown fox jumps over the lazy dog",
+			limit:  24,
+			length: runes,
+			want:   []string{"the quick brown (1/3)", "fox jumps over (2/3)", "the lazy dog (3/3)"},
+		},
+		{
+			name:   "long word",
+			text:   "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			limit:  20,
+			length: runes,
+			want:   []string{"aaaaaaaaaaaa (1/3)", "aaaaaaaaaaaa (2/3)", "aaaaaa (3/3)"},
+		},
+		{
+			name:   "graphemes",
+			text:   strings.Repeat("🏳️‍🌈 ", 12),
+			limit:  20,
+			length: graphemeLength,
+			want:   []string{"🏳️‍🌈 🏳️‍🌈 🏳️‍🌈 🏳️‍🌈 🏳️‍🌈 🏳️‍🌈 (1/2)", "🏳️‍🌈 🏳️‍🌈 🏳️‍🌈 🏳️‍🌈 🏳️‍🌈 🏳️‍🌈 (2/2)"},
+		},
+		{
+			name:   "mastodon urls",
+			text:   "read https://xeiaso.net/blog/a-very-long-slug-that-goes-on-and-on-and-on-forever now",
+			limit:  32,
+			length: mastodonLength(23),
+			want:   []string{"read https://xeiaso.net/blog/a-very-long-slug-that-goes-on-and-on-and-on-forever now"},
+		},
+		{
+			name:   "urls aren't split",
+			text:   "read https://xeiaso.net/blog/a-very-long-slug-that-goes-on-and-on-and-on-forever now",
+			limit:  31,
+			length: mastodonLength(23),
+			want:   []string{"read (1/3)", "https://xeiaso.net/blog/a-very-long-slug-that-goes-on-and-on-and-on-forever (2/3)", "now (3/3)"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitThread(tt.text, tt.limit, tt.length)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("wrong thread:\nwant: %q\ngot:  %q", tt.want, got)
+			}
+
+			for _, post := range got {
+				if n := tt.length(post); n > tt.limit {
+					t.Errorf("post is too long (%d > %d): %q", n, tt.limit, post)
+				}
+			}
+		})
+	}
+}
+
+func TestFacets(t *testing.T) {
+	text := "New post: ünïcode https://xeiaso.net/blog/x. #Anubis #Go"
+	fs := facets(text)
+	if len(fs) != 3 {
+		t.Fatalf("wanted 3 facets, got: %d", len(fs))
+	}
+
+	link := fs[0]
+	if got := text[link.Index.ByteStart:link.Index.ByteEnd]; got != "https://xeiaso.net/blog/x" {
+		t.Errorf("link facet covers %q", got)
+	}
+	if link.Features[0].RichtextFacet_Link.Uri != "https://xeiaso.net/blog/x" {
+		t.Errorf("wrong link: %q", link.Features[0].RichtextFacet_Link.Uri)
+	}
+
+	for i, want := range []string{"Anubis", "Go"} {
+		tag := fs[i+1]
+		if got := text[tag.Index.ByteStart:tag.Index.ByteEnd]; got != "#"+want {
+			t.Errorf("tag facet covers %q", got)
+		}
+		if tag.Features[0].RichtextFacet_Tag.Tag != want {
+			t.Errorf("wrong tag: %q", tag.Features[0].RichtextFacet_Tag.Tag)
+		}
+	}
+}
+
+func TestBlueskyWebURL(t *testing.T) {
+	got := blueskyWebURL("at://did:plc:e5nncqf5oz5mfxrvcdbbtyxl/app.bsky.feed.post/3lbnxhjm3n22k")
+	if want := "https://bsky.app/profile/did:plc:e5nncqf5oz5mfxrvcdbbtyxl/post/3lbnxhjm3n22k"; got != want {
+		t.Errorf("wanted %q, got: %q", want, got)
+	}
+}
@@ -0,0 +1,67 @@
+package posse
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/emptypb"
+	jsonfeedv1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
+	pb "within.website/x/gen/within/website/x/mi/v1"
+)
+
+// Server is the POSSE service.
+type Server struct {
+	ann *Announcer
+
+	pb.UnimplementedPOSSEServer
+}
+
+var _ pb.POSSE = &Server{}
+
+func NewServer(ann *Announcer) *Server {
+	return &Server{ann: ann}
+}
+
+func (s *Server) RefreshBlog(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	if s.ann.cfg.FeedURL == "" {
+		return nil, twirp.NewError(twirp.FailedPrecondition, "no blog feed URL is configured")
+	}
+
+	feed, err := FetchFeed(ctx, s.ann.cfg.FeedURL)
+	if err != nil {
+		slog.ErrorContext(ctx, "can't fetch blog feed", "url", s.ann.cfg.FeedURL, "err", err)
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	if err := s.ann.Sync(ctx, feed); err != nil {
+		slog.ErrorContext(ctx, "can't sync blog feed", "url", s.ann.cfg.FeedURL, "err", err)
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) Preview(ctx context.Context, it *jsonfeedv1.Item) (*pb.POSSEPreview, error) {
+	if it.GetUrl() == "" {
+		return nil, twirp.RequiredArgumentError("url")
+	}
+
+	known, err := s.ann.dao.HasBlogpost(ctx, it.GetUrl())
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	plans, err := s.ann.plan(ctx, it, known)
+	if err != nil {
+		slog.ErrorContext(ctx, "can't plan announcement", "url", it.GetUrl(), "err", err)
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	result := &pb.POSSEPreview{Known: known}
+	for _, tp := range plans {
+		result.Threads = append(result.Threads, tp.AsProto())
+	}
+
+	return result, nil
+}
@@ -10,18 +10,23 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"gorm.io/gorm"
 	"within.website/x/cmd/mi/models"
+	"within.website/x/cmd/mi/services/webhooks"
 	pb "within.website/x/gen/within/website/x/mi/v1"
 )
 
 type Server struct {
 	dao *models.DAO
+	pub webhooks.Publisher
 
 	pb.UnimplementedSwitchTrackerServer
 }
 
-func New(dao *models.DAO) *Server {
+// New creates the switch tracker. Switches are published to pub if it is
+// not nil.
+func New(dao *models.DAO, pub webhooks.Publisher) *Server {
 	return &Server{
 		dao: dao,
+		pub: pub,
 	}
 }
 
@@ -75,12 +80,39 @@ func (s *Server) Switch(ctx context.Context, req *pb.SwitchReq) (*pb.SwitchResp,
 		}
 	}
 
+	s.publish(ctx, old, new)
+
 	return &pb.SwitchResp{
 		Old:     old.AsProto(),
 		Current: new.AsProto(),
 	}, nil
 }
 
+// publish tells subscribers about a switch. Failing to do so doesn't fail
+// the switch.
+func (s *Server) publish(ctx context.Context, old, new *models.Switch) {
+	if s.pub == nil {
+		return
+	}
+
+	current, err := s.dao.GetSwitch(ctx, new.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "can't load new switch to publish it", "id", new.ID, "err", err)
+		return
+	}
+
+	if err := s.pub.Publish(ctx, webhooks.Event{
+		Type:  webhooks.TypeSwitch,
+		Title: current.Member.Name + " is now front",
+		Data: &pb.SwitchEvent{
+			Old:     old.AsFrontChange(),
+			Current: current.AsFrontChange(),
+		},
+	}); err != nil {
+		slog.ErrorContext(ctx, "can't publish switch", "id", new.ID, "err", err)
+	}
+}
+
 func (s *Server) GetSwitch(ctx context.Context, req *pb.GetSwitchReq) (*pb.FrontChange, error) {
 	if err := protovalidate.Validate(req); err != nil {
 		slog.ErrorContext(ctx, "can't get switch by ID", "req", req, "err", err)
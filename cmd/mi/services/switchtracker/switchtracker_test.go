@@ -28,7 +28,7 @@ func TestSwitch(t *testing.T) {
 		t.Fatalf("failed to create dao: %v", err)
 	}
 
-	st := switchtracker.New(dao)
+	st := switchtracker.New(dao, nil)
 
 	// Import members and create root switch
 
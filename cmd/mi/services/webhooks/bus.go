@@ -0,0 +1,290 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+	"within.website/x/cmd/mi/models"
+	jsonfeedv1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
+	pb "within.website/x/gen/within/website/x/mi/v1"
+	announcev1 "within.website/x/gen/within/website/x/mimi/announce/v1"
+	"within.website/x/web/useragent"
+)
+
+// Event types.
+const (
+	TypeSwitch            = "switch"
+	TypeEventAdded        = "event.added"
+	TypeEventRemoved      = "event.removed"
+	TypeBlogpostPublished = "blogpost.published"
+)
+
+const (
+	DefaultMaxAttempts = 8
+	batchSize          = 50
+	pollInterval       = 30 * time.Second
+	deliveryTimeout    = 15 * time.Second
+)
+
+var (
+	webhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mi_webhook_deliveries",
+		Help: "Number of webhook delivery attempts by result.",
+	}, []string{"result"})
+)
+
+// Publisher sends events to the subscriptions that want them.
+type Publisher interface {
+	Publish(ctx context.Context, ev Event) error
+}
+
+// Event is something that happened in mi.
+type Event struct {
+	Type string
+	// Title and URL describe the event to people, such as in the JSON Feed
+	// items sent to Announce subscriptions.
+	Title string
+	URL   string
+	Data  proto.Message
+}
+
+// Payload is the JSON body sent to webhook subscriptions.
+type Payload struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	URL       string          `json:"url,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Bus queues events for subscriptions and delivers them, retrying failed
+// deliveries with exponential backoff until MaxAttempts is reached.
+type Bus struct {
+	// MaxAttempts is how many times a delivery is tried before it is
+	// dead-lettered.
+	MaxAttempts int
+
+	dao       *models.DAO
+	transport http.RoundTripper
+	now       func() time.Time
+	wake      chan struct{}
+}
+
+var _ Publisher = &Bus{}
+
+func NewBus(dao *models.DAO) *Bus {
+	return &Bus{
+		MaxAttempts: DefaultMaxAttempts,
+
+		dao:       dao,
+		transport: useragent.Transport("mi-webhooks", "https://within.website/.x.botinfo", http.DefaultTransport),
+		now:       time.Now,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Publish queues ev for every subscription that wants it. Delivery happens
+// in the background.
+func (b *Bus) Publish(ctx context.Context, ev Event) error {
+	data, err := protojson.Marshal(ev.Data)
+	if err != nil {
+		return fmt.Errorf("webhooks: can't encode event data: %w", err)
+	}
+
+	p := Payload{
+		ID:        ulid.MustNew(ulid.Now(), rand.Reader).String(),
+		Type:      ev.Type,
+		Title:     ev.Title,
+		URL:       ev.URL,
+		CreatedAt: b.now().UTC(),
+		Data:      data,
+	}
+
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("webhooks: can't encode payload: %w", err)
+	}
+
+	n, err := b.dao.EnqueueWebhookDeliveries(ctx, p.CreatedAt, p.ID, p.Type, payload)
+	if err != nil {
+		return fmt.Errorf("webhooks: can't queue deliveries: %w", err)
+	}
+
+	slog.InfoContext(ctx, "published event", "event_id", p.ID, "type", p.Type, "deliveries", n)
+
+	if n != 0 {
+		b.poke()
+	}
+
+	return nil
+}
+
+// poke wakes up Run to deliver new deliveries now.
+func (b *Bus) poke() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers queued events until ctx is done.
+func (b *Bus) Run(ctx context.Context) {
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+
+	for {
+		if err := b.deliverDue(ctx); err != nil {
+			slog.ErrorContext(ctx, "can't deliver webhooks", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		case <-b.wake:
+		}
+	}
+}
+
+// deliverDue attempts every delivery that is due.
+func (b *Bus) deliverDue(ctx context.Context) error {
+	for {
+		deliveries, err := b.dao.DueWebhookDeliveries(ctx, b.now(), batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range deliveries {
+			if err := b.attempt(ctx, &d); err != nil {
+				return err
+			}
+		}
+
+		if len(deliveries) < batchSize {
+			return nil
+		}
+	}
+}
+
+// attempt tries a delivery once and records the result. Only database
+// errors are returned.
+func (b *Bus) attempt(ctx context.Context, d *models.WebhookDelivery) error {
+	sub, err := b.dao.GetWebhookSubscription(ctx, d.SubscriptionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return b.dao.WebhookDelivered(ctx, d.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = b.deliver(ctx, sub, d)
+	if err == nil {
+		webhookDeliveries.WithLabelValues("success").Inc()
+		return b.dao.WebhookDelivered(ctx, d.ID)
+	}
+
+	lg := slog.With("delivery_id", d.ID, "subscription_id", sub.ID, "url", sub.URL, "attempt", d.Attempts+1, "err", err)
+
+	if d.Attempts+1 >= b.MaxAttempts {
+		webhookDeliveries.WithLabelValues("dead_letter").Inc()
+		lg.ErrorContext(ctx, "webhook delivery failed for the last time, dead-lettering it")
+		return b.dao.DeadLetterWebhookDelivery(ctx, d, err.Error())
+	}
+
+	webhookDeliveries.WithLabelValues("failure").Inc()
+	next := b.now().Add(backoff(d.Attempts + 1))
+	lg.WarnContext(ctx, "webhook delivery failed, will retry", "next_attempt_at", next)
+	return b.dao.WebhookDeliveryFailed(ctx, d.ID, err.Error(), next)
+}
+
+// backoff is how long to wait after a delivery's nth failed attempt:
+// 30 seconds, doubling each time up to six hours.
+func backoff(n int) time.Duration {
+	d := 30 * time.Second
+	for range n - 1 {
+		d *= 2
+		if d >= 6*time.Hour {
+			return 6 * time.Hour
+		}
+	}
+	return d
+}
+
+// deliver sends a delivery to its subscription.
+func (b *Bus) deliver(ctx context.Context, sub *models.WebhookSubscription, d *models.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	cli := &http.Client{
+		Transport: signingTransport{
+			secret:     sub.Secret,
+			eventType:  d.EventType,
+			deliveryID: d.ID,
+			now:        b.now,
+			next:       b.transport,
+		},
+	}
+
+	switch sub.Kind {
+	case pb.SubscriptionKind_SUBSCRIPTION_KIND_WEBHOOK:
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := cli.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+		if resp.StatusCode/100 != 2 {
+			return fmt.Errorf("webhooks: %s returned status %d", sub.URL, resp.StatusCode)
+		}
+
+		return nil
+
+	case pb.SubscriptionKind_SUBSCRIPTION_KIND_ANNOUNCE:
+		var p Payload
+		if err := json.Unmarshal(d.Payload, &p); err != nil {
+			return fmt.Errorf("webhooks: can't decode payload: %w", err)
+		}
+
+		_, err := announcev1.NewAnnounceJSONClient(sub.URL, cli).Announce(ctx, p.AsItem())
+		return err
+
+	default:
+		return fmt.Errorf("webhooks: unknown subscription kind %s", sub.Kind)
+	}
+}
+
+// AsItem converts the payload to the JSON Feed item sent to Announce
+// subscriptions. The item's text is the event data.
+func (p *Payload) AsItem() *jsonfeedv1.Item {
+	return &jsonfeedv1.Item{
+		Id:            p.ID,
+		Url:           p.URL,
+		Title:         p.Title,
+		ContentText:   string(p.Data),
+		Tags:          []string{p.Type},
+		DatePublished: timestamppb.New(p.CreatedAt),
+	}
+}
@@ -0,0 +1,137 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader holds the signature of a payload, in the form
+	// "t=<unix timestamp>,v1=<hex HMAC-SHA256>".
+	SignatureHeader = "X-Mi-Signature"
+	// EventHeader holds the type of the event in a payload.
+	EventHeader = "X-Mi-Event"
+	// DeliveryHeader holds the ID of the delivery. It stays the same when a
+	// delivery is retried, so receivers can drop duplicates.
+	DeliveryHeader = "X-Mi-Delivery"
+
+	// MaxBodySize is the largest body VerifyRequest will read. Payloads are
+	// a single event, so anything bigger is not from mi.
+	MaxBodySize = 1 << 20
+)
+
+var (
+	ErrBadSignature     = errors.New("webhooks: signature doesn't match")
+	ErrSignatureExpired = errors.New("webhooks: signature is too old")
+)
+
+func mac(secret string, ts int64, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(h, "%d.", ts)
+	h.Write(body)
+	return h.Sum(nil)
+}
+
+// Sign signs body with secret at time t, returning the value of
+// SignatureHeader. The timestamp is signed along with the body so that
+// old payloads can't be replayed.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := t.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", ts, hex.EncodeToString(mac(secret, ts, body)))
+}
+
+// Verify checks a SignatureHeader value made by Sign. Signatures made more
+// than tolerance away from now are rejected.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts int64
+	var sigs [][]byte
+	for part := range strings.SplitSeq(header, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return fmt.Errorf("%w: malformed header", ErrBadSignature)
+		}
+
+		switch k {
+		case "t":
+			var err error
+			if ts, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return fmt.Errorf("%w: bad timestamp: %w", ErrBadSignature, err)
+			}
+		case "v1":
+			sig, err := hex.DecodeString(v)
+			if err != nil {
+				return fmt.Errorf("%w: bad signature: %w", ErrBadSignature, err)
+			}
+			sigs = append(sigs, sig)
+		}
+	}
+
+	if ts == 0 || len(sigs) == 0 {
+		return fmt.Errorf("%w: missing timestamp or signature", ErrBadSignature)
+	}
+
+	if d := now.Sub(time.Unix(ts, 0)); d > tolerance || d < -tolerance {
+		return ErrSignatureExpired
+	}
+
+	want := mac(secret, ts, body)
+	for _, sig := range sigs {
+		if hmac.Equal(sig, want) {
+			return nil
+		}
+	}
+
+	return ErrBadSignature
+}
+
+// VerifyRequest checks the signature of a request sent by mi. The body is
+// read and replaced so the handler can still read it. Bodies larger than
+// MaxBodySize are rejected without being read in full.
+func VerifyRequest(r *http.Request, secret string, tolerance time.Duration) error {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, MaxBodySize))
+	if err != nil {
+		return fmt.Errorf("%w: can't read body: %w", ErrBadSignature, err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return Verify(secret, r.Header.Get(SignatureHeader), body, time.Now(), tolerance)
+}
+
+// signingTransport signs the body of every request it sends.
+type signingTransport struct {
+	secret     string
+	eventType  string
+	deliveryID string
+	now        func() time.Time
+	next       http.RoundTripper
+}
+
+func (st signingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r = r.Clone(r.Context())
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Set(SignatureHeader, Sign(st.secret, st.now(), body))
+	r.Header.Set(EventHeader, st.eventType)
+	r.Header.Set(DeliveryHeader, st.deliveryID)
+
+	return st.next.RoundTrip(r)
+}
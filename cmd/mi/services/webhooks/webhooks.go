@@ -0,0 +1,132 @@
+// Package webhooks sends mi's events to subscribers and lets them manage
+// their subscriptions.
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+	"within.website/x/cmd/mi/models"
+	pb "within.website/x/gen/within/website/x/mi/v1"
+)
+
+// Server is the Webhooks service.
+type Server struct {
+	dao *models.DAO
+	bus *Bus
+
+	pb.UnimplementedWebhooksServer
+}
+
+var _ pb.Webhooks = &Server{}
+
+func New(dao *models.DAO, bus *Bus) *Server {
+	return &Server{
+		dao: dao,
+		bus: bus,
+	}
+}
+
+func (s *Server) Subscribe(ctx context.Context, req *pb.SubscribeReq) (*pb.Subscription, error) {
+	if err := protovalidate.Validate(req); err != nil {
+		return nil, twirp.InvalidArgumentError("req", err.Error())
+	}
+
+	ws, err := s.dao.CreateWebhookSubscription(ctx, &models.WebhookSubscription{
+		Kind:        req.GetKind(),
+		URL:         req.GetUrl(),
+		Secret:      rand.Text(),
+		EventTypes:  strings.Join(req.GetEventTypes(), ","),
+		Description: req.GetDescription(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "can't create subscription", "req", req, "err", err)
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	slog.InfoContext(ctx, "new webhook subscription", "id", ws.ID, "url", ws.URL, "kind", ws.Kind)
+
+	result := ws.AsProto()
+	result.Secret = ws.Secret
+	return result, nil
+}
+
+func (s *Server) ListSubscriptions(ctx context.Context, _ *emptypb.Empty) (*pb.ListSubscriptionsResp, error) {
+	subs, err := s.dao.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "can't list subscriptions", "err", err)
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	var resp pb.ListSubscriptionsResp
+	for _, sub := range subs {
+		resp.Subscriptions = append(resp.Subscriptions, sub.AsProto())
+	}
+
+	return &resp, nil
+}
+
+func (s *Server) Unsubscribe(ctx context.Context, req *pb.UnsubscribeReq) (*emptypb.Empty, error) {
+	if err := protovalidate.Validate(req); err != nil {
+		return nil, twirp.InvalidArgumentError("id", err.Error())
+	}
+
+	if err := s.dao.DeleteWebhookSubscription(ctx, req.GetId()); err != nil {
+		slog.ErrorContext(ctx, "can't delete subscription", "req", req, "err", err)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, twirp.NotFoundError("can't find subscription").WithMeta("id", req.GetId())
+		default:
+			return nil, twirp.InternalErrorWith(err)
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersReq) (*pb.ListDeadLettersResp, error) {
+	count := int(req.GetCount())
+	if count <= 0 {
+		count = 30
+	}
+
+	dls, err := s.dao.ListWebhookDeadLetters(ctx, req.GetSubscriptionId(), count)
+	if err != nil {
+		slog.ErrorContext(ctx, "can't list dead letters", "req", req, "err", err)
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	var resp pb.ListDeadLettersResp
+	for _, dl := range dls {
+		resp.DeadLetters = append(resp.DeadLetters, dl.AsProto())
+	}
+
+	return &resp, nil
+}
+
+func (s *Server) RetryDeadLetter(ctx context.Context, req *pb.RetryDeadLetterReq) (*emptypb.Empty, error) {
+	if err := protovalidate.Validate(req); err != nil {
+		return nil, twirp.InvalidArgumentError("id", err.Error())
+	}
+
+	if err := s.dao.RetryWebhookDeadLetter(ctx, req.GetId()); err != nil {
+		slog.ErrorContext(ctx, "can't retry dead letter", "req", req, "err", err)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, twirp.NotFoundError("can't find dead letter or its subscription").WithMeta("id", req.GetId())
+		default:
+			return nil, twirp.InternalErrorWith(err)
+		}
+	}
+
+	s.bus.poke()
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,344 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+	"within.website/x/cmd/mi/models"
+	jsonfeedv1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
+	pb "within.website/x/gen/within/website/x/mi/v1"
+	announcev1 "within.website/x/gen/within/website/x/mimi/announce/v1"
+)
+
+func TestSignature(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"hello":"world"}`)
+	sig := Sign("hunter2", now, body)
+
+	if err := Verify("hunter2", sig, body, now, time.Minute); err != nil {
+		t.Errorf("valid signature was rejected: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		secret string
+		header string
+		body   string
+		now    time.Time
+		want   error
+	}{
+		{"wrong secret", "hunter3", sig, string(body), now, ErrBadSignature},
+		{"tampered body", "hunter2", sig, `{"hello":"there"}`, now, ErrBadSignature},
+		{"too old", "hunter2", sig, string(body), now.Add(time.Hour), ErrSignatureExpired},
+		{"empty header", "hunter2", "", string(body), now, ErrBadSignature},
+		{"garbage", "hunter2", "t=now,v1=zz", string(body), now, ErrBadSignature},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.secret, tt.header, []byte(tt.body), tt.now, time.Minute); !errors.Is(err, tt.want) {
+				t.Errorf("wanted %v, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestVerifyRequestBodyLimit(t *testing.T) {
+	now := time.Now()
+
+	for _, tt := range []struct {
+		name string
+		size int
+		ok   bool
+	}{
+		{"at limit", MaxBodySize, true},
+		{"over limit", MaxBodySize + 1, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			body := strings.Repeat("a", tt.size)
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			r.Header.Set(SignatureHeader, Sign("hunter2", now, []byte(body)))
+
+			err := VerifyRequest(r, "hunter2", time.Minute)
+			if tt.ok && err != nil {
+				t.Errorf("body was rejected: %v", err)
+			}
+			if !tt.ok {
+				var mbe *http.MaxBytesError
+				if !errors.Is(err, ErrBadSignature) || !errors.As(err, &mbe) {
+					t.Errorf("got error %v, want one for the body being too large", err)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for n, want := range map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		5:  8 * time.Minute,
+		20: 6 * time.Hour,
+	} {
+		if got := backoff(n); got != want {
+			t.Errorf("backoff(%d): wanted %s, got: %s", n, want, got)
+		}
+	}
+}
+
+func newTestServer(t *testing.T) (*models.DAO, *Bus, *Server) {
+	t.Helper()
+
+	dir := t.TempDir()
+	dao, err := models.New(filepath.Join(dir, "test.db"), filepath.Join(dir, "backup.db"))
+	if err != nil {
+		t.Fatalf("failed to create dao: %v", err)
+	}
+
+	bus := NewBus(dao)
+	bus.transport = http.DefaultTransport
+	return dao, bus, New(dao, bus)
+}
+
+type receiver struct {
+	lock     sync.Mutex
+	secret   string
+	status   int
+	now      func() time.Time
+	payloads []Payload
+	errs     []error
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		rc.errs = append(rc.errs, err)
+		return
+	}
+
+	if err := Verify(rc.secret, r.Header.Get(SignatureHeader), body, rc.now(), time.Minute); err != nil {
+		rc.errs = append(rc.errs, err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if rc.status != http.StatusOK {
+		http.Error(w, "nope", rc.status)
+		return
+	}
+
+	var p Payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		rc.errs = append(rc.errs, err)
+		return
+	}
+
+	if r.Header.Get(EventHeader) != p.Type || r.Header.Get(DeliveryHeader) == "" {
+		rc.errs = append(rc.errs, errors.New("event and delivery headers weren't set"))
+	}
+
+	rc.payloads = append(rc.payloads, p)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	ctx := t.Context()
+	dao, bus, srv := newTestServer(t)
+	bus.MaxAttempts = 2
+
+	now := time.Now()
+	bus.now = func() time.Time { return now }
+
+	rc := &receiver{status: http.StatusServiceUnavailable, now: bus.now}
+	hs := httptest.NewServer(rc)
+	defer hs.Close()
+
+	sub, err := srv.Subscribe(ctx, &pb.SubscribeReq{
+		Kind:       pb.SubscriptionKind_SUBSCRIPTION_KIND_WEBHOOK,
+		Url:        hs.URL,
+		EventTypes: []string{TypeEventAdded},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Secret == "" {
+		t.Fatal("new subscription should come with its secret")
+	}
+	rc.secret = sub.Secret
+
+	subs, err := srv.ListSubscriptions(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs.Subscriptions) != 1 || subs.Subscriptions[0].Secret != "" {
+		t.Fatalf("listed subscriptions shouldn't have secrets: %v", subs)
+	}
+
+	// Not subscribed to switches.
+	if err := bus.Publish(ctx, Event{Type: TypeSwitch, Data: &pb.SwitchEvent{}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bus.Publish(ctx, Event{
+		Type:  TypeEventAdded,
+		Title: "New event: GopherCon",
+		URL:   "https://gophercon.com",
+		Data:  &pb.Event{Id: 1, Name: "GopherCon"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bus.deliverDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	due, err := dao.DueWebhookDeliveries(ctx, now.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || due[0].Attempts != 1 || due[0].LastError == "" {
+		t.Fatalf("wanted one delivery with a failed attempt, got: %+v", due)
+	}
+	if !due[0].NextAttemptAt.After(now) {
+		t.Error("failed delivery should be retried later")
+	}
+
+	// Fail for the last time.
+	now = now.Add(time.Hour)
+	if err := bus.deliverDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	dls, err := srv.ListDeadLetters(ctx, &pb.ListDeadLettersReq{SubscriptionId: sub.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dls.DeadLetters) != 1 || dls.DeadLetters[0].Attempts != 2 || dls.DeadLetters[0].EventType != TypeEventAdded {
+		t.Fatalf("wanted one dead letter after two attempts, got: %v", dls)
+	}
+
+	rc.status = http.StatusOK
+	if _, err := srv.RetryDeadLetter(ctx, &pb.RetryDeadLetterReq{Id: dls.DeadLetters[0].Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bus.deliverDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rc.errs) != 0 {
+		t.Fatalf("receiver had errors: %v", rc.errs)
+	}
+	if len(rc.payloads) != 1 {
+		t.Fatalf("wanted one payload, got: %d", len(rc.payloads))
+	}
+
+	p := rc.payloads[0]
+	if p.Type != TypeEventAdded || p.Title != "New event: GopherCon" || p.URL != "https://gophercon.com" {
+		t.Errorf("wrong payload: %+v", p)
+	}
+
+	var ev pb.Event
+	if err := json.Unmarshal(p.Data, &ev); err != nil || ev.Name != "GopherCon" {
+		t.Errorf("payload data should be the event, got: %s", p.Data)
+	}
+
+	dls, err = srv.ListDeadLetters(ctx, &pb.ListDeadLettersReq{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dls.DeadLetters) != 0 {
+		t.Error("retried dead letter should be gone")
+	}
+
+	if _, err := srv.Unsubscribe(ctx, &pb.UnsubscribeReq{Id: sub.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Unsubscribe(ctx, &pb.UnsubscribeReq{Id: sub.Id}); err == nil {
+		t.Error("unsubscribing twice should fail")
+	}
+}
+
+type fakeAnnounce struct {
+	announcev1.UnimplementedAnnounceServer
+
+	lock  sync.Mutex
+	items []*jsonfeedv1.Item
+}
+
+func (fa *fakeAnnounce) Announce(ctx context.Context, it *jsonfeedv1.Item) (*emptypb.Empty, error) {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+	fa.items = append(fa.items, it)
+	return &emptypb.Empty{}, nil
+}
+
+func TestAnnounceDelivery(t *testing.T) {
+	ctx := t.Context()
+	_, bus, srv := newTestServer(t)
+
+	fa := &fakeAnnounce{}
+	var sigErr error
+	var secret string
+	inner := announcev1.NewAnnounceServer(fa)
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sigErr = VerifyRequest(r, secret, time.Minute)
+		inner.ServeHTTP(w, r)
+	}))
+	defer hs.Close()
+
+	sub, err := srv.Subscribe(ctx, &pb.SubscribeReq{
+		Kind: pb.SubscriptionKind_SUBSCRIPTION_KIND_ANNOUNCE,
+		Url:  hs.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret = sub.Secret
+
+	if err := bus.Publish(ctx, Event{
+		Type:  TypeSwitch,
+		Title: "Cadey is now front",
+		Data:  &pb.SwitchEvent{Current: &pb.FrontChange{Member: &pb.Member{Name: "Cadey"}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bus.deliverDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if sigErr != nil {
+		t.Errorf("announce request wasn't signed: %v", sigErr)
+	}
+	if len(fa.items) != 1 {
+		t.Fatalf("wanted one announcement, got: %d", len(fa.items))
+	}
+	if it := fa.items[0]; it.Title != "Cadey is now front" || len(it.Tags) != 1 || it.Tags[0] != TypeSwitch {
+		t.Errorf("wrong item: %v", it)
+	}
+}
+
+func TestSubscribeValidation(t *testing.T) {
+	_, _, srv := newTestServer(t)
+
+	for _, req := range []*pb.SubscribeReq{
+		{Url: "https://example.com"},
+		{Kind: pb.SubscriptionKind_SUBSCRIPTION_KIND_WEBHOOK, Url: "not a url"},
+		{Kind: pb.SubscriptionKind_SUBSCRIPTION_KIND_WEBHOOK, Url: "https://example.com", EventTypes: []string{"nope"}},
+	} {
+		if _, err := srv.Subscribe(t.Context(), req); err == nil {
+			t.Errorf("invalid request was accepted: %v", req)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/option"
+	"within.website/x/attention"
+	"within.website/x/cmd/mimi-agent/tools"
+)
+
+// ErrTooManyToolRounds is returned when the model keeps calling tools
+// instead of answering.
+var ErrTooManyToolRounds = errors.New("mimi-agent: model kept calling tools without answering")
+
+const (
+	DefaultMaxToolRounds = 8
+	DefaultMaxHistory    = 64
+)
+
+// Chatter is the part of the OpenAI client the agent uses. The client's
+// Chat.Completions service implements it; tests use a fake.
+type Chatter interface {
+	New(ctx context.Context, body openai.ChatCompletionNewParams, opts ...option.RequestOption) (*openai.ChatCompletion, error)
+}
+
+// Message is a chat message the agent may answer.
+type Message struct {
+	ChannelID string
+	MessageID string
+	Author    string
+	Content   string
+}
+
+// Agent keeps a conversation per channel and answers messages in it,
+// calling registered tools as the model asks for them.
+type Agent struct {
+	// MaxToolRounds limits how many times in a row the model can call tools
+	// before it has to answer.
+	MaxToolRounds int
+	// MaxHistory is how many messages of each conversation are kept, not
+	// counting the system prompt.
+	MaxHistory int
+	// OnToolCall, if set, is called before a tool that isn't hidden runs.
+	OnToolCall func(msg Message, tool string)
+
+	chat         Chatter
+	model        string
+	systemPrompt string
+	names        []string
+
+	lock          sync.Mutex
+	conversations map[string]*state
+}
+
+type state struct {
+	lock sync.Mutex
+	conv []openai.ChatCompletionMessageParamUnion
+	aa   *attention.Attenuator
+}
+
+// NewAgent creates an Agent. Mentioning one of names in a channel makes
+// the agent pay attention to it.
+func NewAgent(chat Chatter, model, systemPrompt string, names []string) *Agent {
+	return &Agent{
+		MaxToolRounds: DefaultMaxToolRounds,
+		MaxHistory:    DefaultMaxHistory,
+
+		chat:          chat,
+		model:         model,
+		systemPrompt:  systemPrompt,
+		names:         names,
+		conversations: map[string]*state{},
+	}
+}
+
+func (a *Agent) state(channelID string) *state {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	st, ok := a.conversations[channelID]
+	if !ok {
+		st = &state{aa: attention.NewAttentionAttenuator()}
+		a.conversations[channelID] = st
+	}
+
+	return st
+}
+
+// ClearConversation forgets the conversation in a channel.
+func (a *Agent) ClearConversation(channelID string) {
+	st := a.state(channelID)
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.conv = nil
+}
+
+// Unpoke makes the agent stop paying attention to a channel until it is
+// mentioned again.
+func (a *Agent) Unpoke(channelID string) {
+	st := a.state(channelID)
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.aa.Reset()
+}
+
+func (a *Agent) mentioned(content string) bool {
+	content = strings.ToLower(content)
+	for _, name := range a.names {
+		if strings.Contains(content, strings.ToLower(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Respond answers msg. ok is false if the agent isn't paying attention to
+// the channel. The conversation is only updated if a reply was made.
+func (a *Agent) Respond(ctx context.Context, msg Message) (reply string, ok bool, err error) {
+	st := a.state(msg.ChannelID)
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if a.mentioned(msg.Content) {
+		st.aa.Poke()
+	}
+
+	st.aa.Update()
+
+	if !st.aa.Attention() {
+		slog.Debug("not paying attention", "channel_id", msg.ChannelID, "message_id", msg.MessageID, "probability", st.aa.GetProbability())
+		return "", false, nil
+	}
+
+	st.aa.Poke()
+
+	conv := st.conv
+	if len(conv) == 0 && a.systemPrompt != "" {
+		conv = append(conv, openai.SystemMessage(a.systemPrompt))
+	}
+
+	content, err := json.Marshal(map[string]string{
+		"content": msg.Content,
+		"user":    msg.Author,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	conv = append(conv, openai.UserMessage(string(content)))
+
+	for range a.MaxToolRounds {
+		resp, err := a.chat.New(ctx, openai.ChatCompletionNewParams{
+			Model:    a.model,
+			Messages: conv,
+			Tools:    toolParams(),
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("mimi-agent: can't chat: %w", err)
+		}
+
+		if len(resp.Choices) == 0 {
+			return "", false, errors.New("mimi-agent: model returned no choices")
+		}
+
+		answer := resp.Choices[0].Message
+		conv = append(conv, answer.ToParam())
+
+		if len(answer.ToolCalls) == 0 {
+			st.conv = a.trim(conv)
+			return answer.Content, true, nil
+		}
+
+		for _, tc := range answer.ToolCalls {
+			conv = append(conv, openai.ToolMessage(a.runTool(ctx, msg, tc), tc.ID))
+		}
+	}
+
+	return "", false, ErrTooManyToolRounds
+}
+
+// runTool runs a tool call and returns what the model should see. Failures
+// are reported to the model so it can try again or explain what happened.
+func (a *Agent) runTool(ctx context.Context, msg Message, tc openai.ChatCompletionMessageToolCallUnion) string {
+	name := tc.Function.Name
+	args := []byte(tc.Function.Arguments)
+
+	impl, ok := tools.Get(name)
+	if !ok {
+		slog.Error("model called unknown tool", "channel_id", msg.ChannelID, "message_id", msg.MessageID, "tool", name)
+		return fmt.Sprintf("error: there is no tool named %q", name)
+	}
+
+	hide, err := impl.Valid(args)
+	if err != nil {
+		return fmt.Sprintf("error: invalid arguments for %s: %v", name, err)
+	}
+
+	if !hide && a.OnToolCall != nil {
+		a.OnToolCall(msg, name)
+	}
+
+	slog.Info("running tool", "channel_id", msg.ChannelID, "message_id", msg.MessageID, "tool", name)
+
+	result, err := impl.Run(ctx, args)
+	if err != nil {
+		slog.Error("error running tool", "channel_id", msg.ChannelID, "message_id", msg.MessageID, "tool", name, "err", err)
+		return fmt.Sprintf("error: %s failed: %v", name, err)
+	}
+
+	return result
+}
+
+// trim drops the oldest messages of conv past MaxHistory, keeping the
+// system prompt. The kept history starts at a user message so tool results
+// are never separated from the call that asked for them.
+func (a *Agent) trim(conv []openai.ChatCompletionMessageParamUnion) []openai.ChatCompletionMessageParamUnion {
+	var system []openai.ChatCompletionMessageParamUnion
+	if len(conv) != 0 && conv[0].OfSystem != nil {
+		system, conv = conv[:1], conv[1:]
+	}
+
+	if a.MaxHistory <= 0 || len(conv) <= a.MaxHistory {
+		return slices.Concat(system, conv)
+	}
+
+	start := len(conv) - a.MaxHistory
+	for start < len(conv) && conv[start].OfUser == nil {
+		start++
+	}
+
+	return slices.Concat(system, conv[start:])
+}
+
+func toolParams() []openai.ChatCompletionToolUnionParam {
+	impls := tools.All()
+	if len(impls) == 0 {
+		return nil
+	}
+
+	result := make([]openai.ChatCompletionToolUnionParam, 0, len(impls))
+	for _, impl := range impls {
+		result = append(result, openai.ChatCompletionFunctionTool(impl.Usage()))
+	}
+
+	return result
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/option"
+	"within.website/x/cmd/mimi-agent/tools"
+)
+
+// fakeChatter answers with canned responses in order and records the
+// requests it got.
+type fakeChatter struct {
+	responses []string
+	requests  []openai.ChatCompletionNewParams
+}
+
+func (f *fakeChatter) New(ctx context.Context, body openai.ChatCompletionNewParams, opts ...option.RequestOption) (*openai.ChatCompletion, error) {
+	f.requests = append(f.requests, body)
+
+	if len(f.responses) == 0 {
+		return nil, errors.New("no more responses")
+	}
+
+	var result openai.ChatCompletion
+	if err := json.Unmarshal([]byte(f.responses[0]), &result); err != nil {
+		return nil, err
+	}
+	f.responses = f.responses[1:]
+
+	return &result, nil
+}
+
+func answer(content string) string {
+	data, _ := json.Marshal(map[string]any{
+		"choices": []any{map[string]any{
+			"index":   0,
+			"message": map[string]any{"role": "assistant", "content": content},
+		}},
+	})
+	return string(data)
+}
+
+func toolCall(id, name, args string) string {
+	data, _ := json.Marshal(map[string]any{
+		"choices": []any{map[string]any{
+			"index": 0,
+			"message": map[string]any{
+				"role": "assistant",
+				"tool_calls": []any{map[string]any{
+					"id":       id,
+					"type":     "function",
+					"function": map[string]any{"name": name, "arguments": args},
+				}},
+			},
+		}},
+	})
+	return string(data)
+}
+
+type echoTool struct {
+	name string
+	hide bool
+	runs []string
+}
+
+func (e *echoTool) Name() string { return e.name }
+
+func (e *echoTool) Usage() openai.FunctionDefinitionParam {
+	return openai.FunctionDefinitionParam{
+		Name:        e.name,
+		Description: openai.String("Echoes its input"),
+		Parameters: openai.FunctionParameters{
+			"type":       "object",
+			"properties": map[string]any{"text": map[string]any{"type": "string"}},
+		},
+	}
+}
+
+func (e *echoTool) Valid(data []byte) (bool, error) {
+	var args struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &args); err != nil {
+		return false, err
+	}
+	if args.Text == "" {
+		return false, errors.New("text is required")
+	}
+	return e.hide, nil
+}
+
+func (e *echoTool) Run(ctx context.Context, data []byte) (string, error) {
+	e.runs = append(e.runs, string(data))
+	return "echo: " + string(data), nil
+}
+
+func register(t *testing.T, impl tools.Implementation) {
+	t.Helper()
+	tools.Set(impl.Name(), impl)
+	t.Cleanup(func() { tools.Delete(impl.Name()) })
+}
+
+func TestAgentToolLoop(t *testing.T) {
+	echo := &echoTool{name: "test_echo"}
+	register(t, echo)
+
+	chat := &fakeChatter{responses: []string{
+		toolCall("call_1", "test_echo", `{"text":"hi"}`),
+		toolCall("call_2", "test_echo", `{}`),
+		toolCall("call_3", "nonexistent", `{}`),
+		answer("all done"),
+	}}
+
+	a := NewAgent(chat, "test-model", "you are a test", []string{"mimi"})
+
+	var shown []string
+	a.OnToolCall = func(msg Message, tool string) { shown = append(shown, tool) }
+
+	reply, ok, err := a.Respond(t.Context(), Message{ChannelID: "c", Author: "cadey", Content: "hey Mimi, echo hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("agent didn't pay attention after being mentioned")
+	}
+	if reply != "all done" {
+		t.Errorf("wanted reply %q, got: %q", "all done", reply)
+	}
+
+	if len(echo.runs) != 1 || echo.runs[0] != `{"text":"hi"}` {
+		t.Errorf("tool should have run once with valid arguments, got: %q", echo.runs)
+	}
+	if len(shown) != 1 {
+		t.Errorf("tool call should have been shown once, got: %q", shown)
+	}
+
+	if len(chat.requests) != 4 {
+		t.Fatalf("wanted 4 requests, got: %d", len(chat.requests))
+	}
+
+	first := chat.requests[0]
+	if first.Model != "test-model" {
+		t.Errorf("wanted model test-model, got: %q", first.Model)
+	}
+	if len(first.Tools) != 1 || first.Tools[0].GetFunction().Name != "test_echo" {
+		t.Errorf("registered tool wasn't offered to the model: %+v", first.Tools)
+	}
+
+	// system, user, then a call and result per round
+	last := chat.requests[3].Messages
+	if len(last) != 8 {
+		t.Fatalf("wanted 8 messages in the last request, got: %d", len(last))
+	}
+	if last[0].OfSystem == nil || last[1].OfUser == nil {
+		t.Error("conversation should start with the system prompt and the user's message")
+	}
+	if !strings.Contains(last[1].OfUser.Content.OfString.Value, `"user":"cadey"`) {
+		t.Errorf("user message should name the author, got: %s", last[1].OfUser.Content.OfString.Value)
+	}
+
+	for i, want := range []string{"echo: ", "text is required", "no tool named"} {
+		msg := last[3+2*i].OfTool
+		if msg == nil {
+			t.Fatalf("message %d isn't a tool result", 3+2*i)
+		}
+		if !strings.Contains(msg.Content.OfString.Value, want) {
+			t.Errorf("tool result %d should contain %q, got: %q", i, want, msg.Content.OfString.Value)
+		}
+	}
+
+	// The conversation carries over to the next message.
+	chat.responses = []string{answer("again")}
+	if _, _, err := a.Respond(t.Context(), Message{ChannelID: "c", Author: "cadey", Content: "mimi, again"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(chat.requests[4].Messages); got != 10 {
+		t.Errorf("wanted 10 messages in the follow-up request, got: %d", got)
+	}
+}
+
+func TestAgentAttention(t *testing.T) {
+	chat := &fakeChatter{responses: []string{answer("hi")}}
+	a := NewAgent(chat, "test-model", "", []string{"mimi"})
+
+	if _, ok, err := a.Respond(t.Context(), Message{ChannelID: "c", Content: "hello everyone"}); err != nil || ok {
+		t.Fatalf("agent shouldn't answer a channel it wasn't mentioned in: ok=%v err=%v", ok, err)
+	}
+	if len(chat.requests) != 0 {
+		t.Fatal("model shouldn't have been asked")
+	}
+
+	if _, ok, err := a.Respond(t.Context(), Message{ChannelID: "c", Content: "MIMI?"}); err != nil || !ok {
+		t.Fatalf("agent should answer when mentioned: ok=%v err=%v", ok, err)
+	}
+
+	a.Unpoke("c")
+	if _, ok, _ := a.Respond(t.Context(), Message{ChannelID: "c", Content: "still there?"}); ok {
+		t.Error("agent shouldn't answer after being unpoked")
+	}
+}
+
+func TestAgentTooManyToolRounds(t *testing.T) {
+	register(t, &echoTool{name: "test_loop", hide: true})
+
+	chat := &fakeChatter{}
+	for range 3 {
+		chat.responses = append(chat.responses, toolCall("call", "test_loop", `{"text":"again"}`))
+	}
+
+	a := NewAgent(chat, "test-model", "", []string{"mimi"})
+	a.MaxToolRounds = 3
+	a.OnToolCall = func(msg Message, tool string) { t.Error("hidden tool call was shown") }
+
+	if _, _, err := a.Respond(t.Context(), Message{ChannelID: "c", Content: "mimi"}); !errors.Is(err, ErrTooManyToolRounds) {
+		t.Fatalf("wanted ErrTooManyToolRounds, got: %v", err)
+	}
+
+	a.state("c").lock.Lock()
+	defer a.state("c").lock.Unlock()
+	if len(a.state("c").conv) != 0 {
+		t.Error("failed turn shouldn't be kept in the conversation")
+	}
+}
+
+func TestAgentTrim(t *testing.T) {
+	a := NewAgent(nil, "", "", nil)
+	a.MaxHistory = 3
+
+	conv := []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage("system"),
+		openai.UserMessage("1"),
+		openai.AssistantMessage("2"),
+		openai.UserMessage("3"),
+		openai.AssistantMessage("4"),
+		openai.ToolMessage("5", "call"),
+		openai.AssistantMessage("6"),
+	}
+
+	got := a.trim(conv)
+	if len(got) != 1 || got[0].OfSystem == nil {
+		t.Fatalf("history should be cut at a user message, got %d messages", len(got))
+	}
+
+	a.MaxHistory = 4
+	got = a.trim(conv)
+	if len(got) != 5 || got[1].OfUser == nil {
+		t.Fatalf("wanted the system prompt and the last turn, got %d messages", len(got))
+	}
+}
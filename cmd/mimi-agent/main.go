@@ -1,44 +1,64 @@
-//go:build ignore
-
 package main
 
 import (
 	"context"
+	_ "embed"
 	"flag"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
-	"sync"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/openai/openai-go/v2"
 	"github.com/openai/openai-go/v2/option"
-	"within.website/x/attention"
 	"within.website/x/internal"
 )
 
 var (
+	chatChannels  = flag.String("chat-channels", "", "comma-separated list of channels to allow chat in")
 	discordToken  = flag.String("discord-token", "", "discord token")
 	grpcAddr      = flag.String("grpc-addr", ":9001", "GRPC listen address")
 	httpAddr      = flag.String("http-addr", ":9002", "HTTP listen address")
+	maxHistory    = flag.Int("max-history", DefaultMaxHistory, "how many messages of each conversation to keep")
+	maxToolRounds = flag.Int("max-tool-rounds", DefaultMaxToolRounds, "how many times in a row the model can call tools before it has to answer")
+	mcpAPIKey     = flag.String("mcp-api-key", "", "bearer token sent to MCP servers")
+	mcpServers    = flag.String("mcp-servers", "", "comma-separated list of MCP server URLs to use tools from")
+	mimiNames     = flag.String("mimi-names", "mimi", "comma-separated list of names for mimi")
 	openAIAPIBase = flag.String("openai-api-base", "", "OpenAI API base URL")
 	openAIAPIKey  = flag.String("openai-api-key", "", "OpenAI API key")
 	openAIModel   = flag.String("openai-model", "gpt-oss:120b", "OpenAI model")
+
+	//go:embed system-prompt.txt
+	systemPrompt string
 )
 
 func main() {
 	internal.HandleStartup()
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
+	for _, endpoint := range splitList(*mcpServers) {
+		srv, err := ConnectMCP(ctx, endpoint, *mcpAPIKey)
+		if err != nil {
+			slog.Error("can't use MCP server", "endpoint", endpoint, "err", err)
+			continue
+		}
+		defer srv.Close()
+	}
+
 	bot, err := New(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	_ = bot
+	defer bot.Close()
 
 	mux := http.NewServeMux()
 
@@ -55,18 +75,9 @@ func main() {
 }
 
 type Bot struct {
-	dg *discordgo.Session
-	ai *openai.Client
-
-	aiModel string
-
-	lock          sync.Mutex
-	conversations map[string]state
-}
-
-type state struct {
-	conv []openai.Message
-	aa   *attention.Attenuator
+	dg       *discordgo.Session
+	agent    *Agent
+	channels []string
 }
 
 func New(ctx context.Context) (*Bot, error) {
@@ -75,17 +86,6 @@ func New(ctx context.Context) (*Bot, error) {
 		return nil, fmt.Errorf("discord: error creating discord session: %w", err)
 	}
 
-	go func() {
-		<-ctx.Done()
-		dg.Close()
-	}()
-
-	go func() {
-		if err := dg.Open(); err != nil {
-			log.Fatal(err)
-		}
-	}()
-
 	dg.StateEnabled = true
 
 	ai := openai.NewClient(
@@ -93,9 +93,183 @@ func New(ctx context.Context) (*Bot, error) {
 		option.WithBaseURL(*openAIAPIBase),
 	)
 
-	return &Bot{
-		dg:      dg,
-		ai:      &ai,
-		aiModel: *openAIModel,
-	}, nil
+	agent := NewAgent(&ai.Chat.Completions, *openAIModel, systemPrompt, splitList(*mimiNames))
+	agent.MaxHistory = *maxHistory
+	agent.MaxToolRounds = *maxToolRounds
+
+	b := &Bot{
+		dg:       dg,
+		agent:    agent,
+		channels: splitList(*chatChannels),
+	}
+
+	agent.OnToolCall = func(msg Message, tool string) {
+		if err := dg.MessageReactionAdd(msg.ChannelID, msg.MessageID, "🔧"); err != nil {
+			slog.Error("can't react to message", "err", err, "channel_id", msg.ChannelID, "message_id", msg.MessageID)
+		}
+	}
+
+	dg.AddHandler(b.messageCreate)
+	dg.AddHandler(b.interactionCreate)
+
+	if err := dg.Open(); err != nil {
+		return nil, fmt.Errorf("discord: error opening discord session: %w", err)
+	}
+
+	for _, cmd := range []*discordgo.ApplicationCommand{
+		{
+			Name:        "clearconv",
+			Description: "Clear the conversation history for the current channel",
+		},
+		{
+			Name:        "unpoke",
+			Description: "Have Mimi stop paying attention to the current channel",
+		},
+	} {
+		cmd.Type = discordgo.ChatApplicationCommand
+		cmd.DefaultMemberPermissions = &[]int64{discordgo.PermissionSendMessages}[0]
+
+		if _, err := dg.ApplicationCommandCreate(dg.State.User.ID, "", cmd); err != nil {
+			slog.Error("error creating command", "command", cmd.Name, "err", err)
+		}
+	}
+
+	return b, nil
+}
+
+func (b *Bot) Close() error {
+	return b.dg.Close()
+}
+
+func (b *Bot) interactionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	var content string
+	switch i.ApplicationCommandData().Name {
+	case "clearconv":
+		b.agent.ClearConversation(i.ChannelID)
+		content = "conversation history cleared"
+	case "unpoke":
+		b.agent.Unpoke(i.ChannelID)
+		content = "Mimi will no longer pay attention to this channel"
+	default:
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+		},
+	}); err != nil {
+		slog.Error("can't respond to interaction", "err", err, "channel_id", i.ChannelID)
+	}
+}
+
+func (b *Bot) messageCreate(s *discordgo.Session, mc *discordgo.MessageCreate) {
+	if !slices.Contains(b.channels, mc.ChannelID) {
+		return
+	}
+
+	if mc.Content == "" || strings.HasPrefix(mc.Content, "!") {
+		return
+	}
+
+	if mc.Author.ID == s.State.User.ID {
+		return
+	}
+
+	nick := mc.Author.Username
+	if gu, err := s.State.Member(mc.GuildID, mc.Author.ID); err == nil && gu.Nick != "" {
+		nick = gu.Nick
+	}
+
+	killChan := make(chan struct{})
+	defer close(killChan)
+
+	go func() {
+		t := time.NewTicker(5 * time.Second)
+		defer t.Stop()
+
+		s.ChannelTyping(mc.ChannelID)
+
+		for {
+			select {
+			case <-t.C:
+				s.ChannelTyping(mc.ChannelID)
+			case <-killChan:
+				return
+			}
+		}
+	}()
+
+	reply, ok, err := b.agent.Respond(context.Background(), Message{
+		ChannelID: mc.ChannelID,
+		MessageID: mc.ID,
+		Author:    nick,
+		Content:   mc.Content,
+	})
+	if err != nil {
+		slog.Error("error chatting", "err", err, "message_id", mc.ID, "channel_id", mc.ChannelID)
+		s.ChannelMessageSend(mc.ChannelID, "error chatting")
+		return
+	}
+
+	if !ok || reply == "" {
+		return
+	}
+
+	for _, chunk := range splitMessage(reply, maxMessageLen) {
+		if _, err := s.ChannelMessageSend(mc.ChannelID, chunk); err != nil {
+			slog.Error("can't send reply", "err", err, "message_id", mc.ID, "channel_id", mc.ChannelID)
+			return
+		}
+	}
+}
+
+// maxMessageLen is the most characters Discord accepts in one message.
+const maxMessageLen = 2000
+
+// splitMessage splits s into chunks of at most limit characters, breaking
+// between lines where it can. Lines longer than limit are cut up.
+func splitMessage(s string, limit int) []string {
+	var result []string
+	var chunk strings.Builder
+	n := 0
+
+	flush := func() {
+		if n > 0 {
+			result = append(result, chunk.String())
+			chunk.Reset()
+			n = 0
+		}
+	}
+
+	for line := range strings.SplitAfterSeq(s, "\n") {
+		runes := []rune(line)
+		if n+len(runes) > limit {
+			flush()
+		}
+		for len(runes) > limit {
+			result = append(result, string(runes[:limit]))
+			runes = runes[limit:]
+		}
+		chunk.WriteString(string(runes))
+		n += len(runes)
+	}
+	flush()
+
+	return result
+}
+
+func splitList(s string) []string {
+	var result []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
 }
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitMessage(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{name: "short", in: "hi", want: []string{"hi"}},
+		{name: "lines", in: "aaa\nbbb\nccc", want: []string{"aaa\n", "bbb\n", "ccc"}},
+		{name: "packed", in: "aa\nbb\ncc", want: []string{"aa\nbb\n", "cc"}},
+		{name: "long line", in: "aaaaaaaaaaaaaa\nb", want: []string{"aaaaaa", "aaaaaa", "aa\nb"}},
+		{name: "runes", in: "ééééééé", want: []string{"éééééé", "é"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitMessage(tt.in, 6)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for _, chunk := range splitMessage(strings.Repeat("line of text\n", 500), maxMessageLen) {
+		if n := utf8.RuneCountInString(chunk); n > maxMessageLen {
+			t.Errorf("chunk is %d characters", n)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/openai/openai-go/v2"
+	"within.website/x"
+	"within.website/x/cmd/mimi-agent/tools"
+)
+
+// MCPServer is a connection to a remote MCP server whose tools are
+// registered with the tools package.
+type MCPServer struct {
+	endpoint string
+	sess     *mcp.ClientSession
+	names    []string
+}
+
+// ConnectMCP connects to the MCP server at endpoint and registers each of
+// its tools. If apiKey is set, it is sent as a bearer token. Tools whose
+// names are already registered are skipped.
+func ConnectMCP(ctx context.Context, endpoint, apiKey string) (*MCPServer, error) {
+	hc := &http.Client{Transport: http.DefaultTransport}
+	if apiKey != "" {
+		hc.Transport = bearerTransport{token: apiKey, next: http.DefaultTransport}
+	}
+
+	cli := mcp.NewClient(&mcp.Implementation{Name: "mimi-agent", Version: x.Version}, nil)
+	sess, err := cli.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: endpoint, HTTPClient: hc}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("mimi-agent: can't connect to MCP server %s: %w", endpoint, err)
+	}
+
+	s := &MCPServer{endpoint: endpoint, sess: sess}
+
+	for tool, err := range sess.Tools(ctx, nil) {
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("mimi-agent: can't list tools of MCP server %s: %w", endpoint, err)
+		}
+
+		if _, ok := tools.Get(tool.Name); ok {
+			slog.Warn("tool is already registered, skipping", "tool", tool.Name, "endpoint", endpoint)
+			continue
+		}
+
+		tools.Set(tool.Name, &mcpTool{sess: sess, tool: tool})
+		s.names = append(s.names, tool.Name)
+	}
+
+	slog.Info("connected to MCP server", "endpoint", endpoint, "tools", s.names)
+
+	return s, nil
+}
+
+// Close unregisters the server's tools and closes the connection.
+func (s *MCPServer) Close() error {
+	for _, name := range s.names {
+		tools.Delete(name)
+	}
+
+	return s.sess.Close()
+}
+
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (bt bearerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+bt.token)
+
+	return bt.next.RoundTrip(r)
+}
+
+// mcpTool is a tool on a remote MCP server.
+type mcpTool struct {
+	sess *mcp.ClientSession
+	tool *mcp.Tool
+}
+
+func (t *mcpTool) Name() string { return t.tool.Name }
+
+func (t *mcpTool) Usage() openai.FunctionDefinitionParam {
+	result := openai.FunctionDefinitionParam{
+		Name: t.tool.Name,
+	}
+
+	if t.tool.Description != "" {
+		result.Description = openai.String(t.tool.Description)
+	}
+
+	if schema, ok := t.tool.InputSchema.(map[string]any); ok {
+		result.Parameters = schema
+	}
+
+	return result
+}
+
+// Valid checks that the arguments are a JSON object. The server validates
+// them against its schema.
+func (t *mcpTool) Valid(data []byte) (bool, error) {
+	var args map[string]any
+	if err := json.Unmarshal(data, &args); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+func (t *mcpTool) Run(ctx context.Context, data []byte) (string, error) {
+	result, err := t.sess.CallTool(ctx, &mcp.CallToolParams{
+		Name:      t.tool.Name,
+		Arguments: json.RawMessage(data),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	text, err := toolResultText(result)
+	if err != nil {
+		return "", err
+	}
+
+	if result.IsError {
+		return "", errors.New(text)
+	}
+
+	return text, nil
+}
+
+// toolResultText flattens a tool result into text for the model. Structured
+// content is preferred as it carries the whole result.
+func toolResultText(result *mcp.CallToolResult) (string, error) {
+	if result.StructuredContent != nil && !result.IsError {
+		data, err := json.Marshal(result.StructuredContent)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	var sb strings.Builder
+	for _, c := range result.Content {
+		switch c := c.(type) {
+		case *mcp.TextContent:
+			if sb.Len() != 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(c.Text)
+		default:
+			slog.Debug("dropping non-text tool result content", "type", fmt.Sprintf("%T", c))
+		}
+	}
+
+	return sb.String(), nil
+}
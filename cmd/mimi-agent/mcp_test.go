@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"within.website/x/cmd/mimi-agent/tools"
+)
+
+type addInput struct {
+	A int `json:"a" jsonschema:"The first number"`
+	B int `json:"b" jsonschema:"The second number"`
+}
+
+type addOutput struct {
+	Sum int `json:"sum"`
+}
+
+func newMCPServer(t *testing.T, apiKey string) string {
+	t.Helper()
+
+	srv := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(srv, &mcp.Tool{Name: "test_add", Description: "Adds two numbers"}, func(ctx context.Context, req *mcp.CallToolRequest, in addInput) (*mcp.CallToolResult, *addOutput, error) {
+		return nil, &addOutput{Sum: in.A + in.B}, nil
+	})
+	mcp.AddTool(srv, &mcp.Tool{Name: "test_fail", Description: "Always fails"}, func(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, *addOutput, error) {
+		return nil, nil, errors.New("it broke")
+	})
+
+	inner := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return srv }, nil)
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if apiKey != "" && r.Header.Get("Authorization") != "Bearer "+apiKey {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		inner.ServeHTTP(w, r)
+	}))
+	t.Cleanup(hs.Close)
+
+	return hs.URL
+}
+
+func TestConnectMCP(t *testing.T) {
+	endpoint := newMCPServer(t, "hunter2")
+
+	if _, err := ConnectMCP(t.Context(), endpoint, "wrong"); err == nil {
+		t.Fatal("connecting with the wrong API key should fail")
+	}
+
+	srv, err := ConnectMCP(t.Context(), endpoint, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	add, ok := tools.Get("test_add")
+	if !ok {
+		t.Fatal("test_add wasn't registered")
+	}
+
+	usage := add.Usage()
+	if usage.Description.Value != "Adds two numbers" {
+		t.Errorf("wrong description: %q", usage.Description.Value)
+	}
+	if _, ok := usage.Parameters["properties"].(map[string]any)["a"]; !ok {
+		t.Errorf("input schema wasn't passed on: %v", usage.Parameters)
+	}
+
+	if _, err := add.Valid([]byte("[1, 2]")); err == nil {
+		t.Error("arguments that aren't an object should be invalid")
+	}
+
+	result, err := add.Run(t.Context(), []byte(`{"a": 2, "b": 3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != `{"sum":5}` {
+		t.Errorf("wanted structured result, got: %q", result)
+	}
+
+	fail, ok := tools.Get("test_fail")
+	if !ok {
+		t.Fatal("test_fail wasn't registered")
+	}
+	if _, err := fail.Run(t.Context(), []byte(`{}`)); err == nil || !strings.Contains(err.Error(), "it broke") {
+		t.Errorf("wanted the tool's error, got: %v", err)
+	}
+
+	if err := srv.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tools.Get("test_add"); ok {
+		t.Error("closing the server should unregister its tools")
+	}
+}
+
+func TestAgentWithMCP(t *testing.T) {
+	srv, err := ConnectMCP(t.Context(), newMCPServer(t, ""), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	chat := &fakeChatter{responses: []string{
+		toolCall("call_1", "test_add", `{"a": 40, "b": 2}`),
+		answer("it's 42"),
+	}}
+	a := NewAgent(chat, "test-model", "", []string{"mimi"})
+
+	reply, _, err := a.Respond(t.Context(), Message{ChannelID: "c", Content: "mimi, what is 40+2?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != "it's 42" {
+		t.Errorf("wrong reply: %q", reply)
+	}
+
+	got := chat.requests[1].Messages[2].OfTool.Content.OfString.Value
+	if got != `{"sum":42}` {
+		t.Errorf("wanted the MCP tool's result, got: %q", got)
+	}
+}
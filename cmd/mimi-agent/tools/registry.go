@@ -1,12 +1,17 @@
 package tools
 
-import "sync"
+import (
+	"slices"
+	"strings"
+	"sync"
+)
 
 var (
 	lock  sync.Mutex
-	tools map[string]Implementation
+	tools = map[string]Implementation{}
 )
 
+// Set registers impl under name, replacing any tool with the same name.
 func Set(name string, impl Implementation) {
 	lock.Lock()
 	defer lock.Unlock()
@@ -21,3 +26,29 @@ func Get(name string) (Implementation, bool) {
 	tool, ok := tools[name]
 	return tool, ok
 }
+
+// Delete unregisters the tool with the given name.
+func Delete(name string) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	delete(tools, name)
+}
+
+// All returns every registered tool, sorted by name so that the tool list
+// sent to the model is stable.
+func All() []Implementation {
+	lock.Lock()
+	defer lock.Unlock()
+
+	result := make([]Implementation, 0, len(tools))
+	for _, impl := range tools {
+		result = append(result, impl)
+	}
+
+	slices.SortFunc(result, func(a, b Implementation) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	return result
+}
@@ -1,3 +1,4 @@
+// Package tools holds the tools Mimi can call while answering a message.
 package tools
 
 import (
@@ -6,9 +7,16 @@ import (
 	"github.com/openai/openai-go/v2"
 )
 
+// Implementation is a tool the model can call. data is the JSON arguments
+// the model sent.
 type Implementation interface {
 	Name() string
+	// Usage describes the tool and its arguments to the model.
 	Usage() openai.FunctionDefinitionParam
+	// Valid checks the arguments before the tool is run. hide reports
+	// whether the call should be kept out of the chat instead of being
+	// shown to users.
 	Valid(data []byte) (hide bool, err error)
+	// Run runs the tool and returns the result the model will see.
 	Run(ctx context.Context, data []byte) (string, error)
 }
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"path/filepath"
 
 	"google.golang.org/grpc"
 	"within.website/x/cmd/mimi/internal"
@@ -15,6 +16,7 @@ import (
 	"within.website/x/cmd/mimi/modules/discord/heic2jpeg"
 	"within.website/x/cmd/mimi/modules/discord/jufra"
 	"within.website/x/cmd/mimi/modules/irc"
+	"within.website/x/store"
 )
 
 var (
@@ -39,7 +41,12 @@ func main() {
 
 	b := flyio.New()
 
-	juf := jufra.New(d.Session())
+	st, err := store.NewDirectFile(filepath.Join(internal.DataDir(), "store"))
+	if err != nil {
+		log.Fatalf("error creating store: %v", err)
+	}
+
+	juf := jufra.New(d.Session(), st)
 	_ = juf
 
 	d.Register(b)
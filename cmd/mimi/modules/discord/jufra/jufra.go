@@ -8,14 +8,18 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"within.website/x/cmd/mimi/internal"
+	"within.website/x/store"
 	"within.website/x/web/ollama"
 	"within.website/x/web/ollama/llamaguard"
 	"within.website/x/web/openai/chatgpt"
@@ -54,22 +58,26 @@ type Module struct {
 	cli    chatgpt.Client
 	ollama *ollama.Client
 	lg     *ollama.Client
+	mem    *Memory
 
 	convHistory map[string]state
 	lock        sync.Mutex
 }
 
 type state struct {
-	conv []ollama.Message
+	conv *Conversation
 	aa   *AttentionAttenuator
 }
 
-func New(sess *discordgo.Session) *Module {
+// New creates the module. Conversations are kept in st so they survive
+// restarts.
+func New(sess *discordgo.Session, st store.Interface) *Module {
 	result := &Module{
 		sess:        sess,
 		cli:         chatgpt.NewClient("").WithBaseURL(internal.OllamaHost()),
 		ollama:      internal.OllamaClient(),
 		lg:          ollama.NewClient(*llamaGuardHost),
+		mem:         NewMemory(st),
 		convHistory: make(map[string]state),
 	}
 
@@ -93,8 +101,28 @@ func New(sess *discordgo.Session) *Module {
 		slog.Error("error creating clearconv command", "err", err)
 	}
 
+	if _, err := sess.ApplicationCommandCreate("1119055490882732105", "", &discordgo.ApplicationCommand{
+		Name:                     "exportconv",
+		Type:                     discordgo.ChatApplicationCommand,
+		Description:              "Download what Mimi remembers of the conversation in the current channel",
+		DefaultMemberPermissions: &[]int64{discordgo.PermissionSendMessages}[0],
+	}); err != nil {
+		slog.Error("error creating exportconv command", "err", err)
+	}
+
+	if _, err := sess.ApplicationCommandCreate("1119055490882732105", "", &discordgo.ApplicationCommand{
+		Name:                     "forgetme",
+		Type:                     discordgo.ChatApplicationCommand,
+		Description:              "Have Mimi forget your messages in every channel",
+		DefaultMemberPermissions: &[]int64{discordgo.PermissionSendMessages}[0],
+	}); err != nil {
+		slog.Error("error creating forgetme command", "err", err)
+	}
+
 	sess.AddHandler(result.clearConv)
 	sess.AddHandler(result.unpoke)
+	sess.AddHandler(result.exportConv)
+	sess.AddHandler(result.forgetMe)
 
 	return result
 }
@@ -108,6 +136,9 @@ func (m *Module) unpoke(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	defer m.lock.Unlock()
 
 	st := m.convHistory[i.ChannelID]
+	if st.aa == nil {
+		st.aa = NewAttentionAttenuator()
+	}
 	st.aa.Reset()
 	m.convHistory[i.ChannelID] = st
 
@@ -127,16 +158,133 @@ func (m *Module) clearConv(s *discordgo.Session, i *discordgo.InteractionCreate)
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	delete(m.convHistory, i.ChannelID)
+	st := m.convHistory[i.ChannelID]
+	st.conv = nil
+	m.convHistory[i.ChannelID] = st
+
+	content := "conversation history cleared"
+	if err := m.mem.Delete(context.Background(), i.ChannelID); err != nil {
+		slog.Error("error deleting conversation", "err", err, "channel_id", i.ChannelID)
+		content = "error clearing conversation history"
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+		},
+	})
+}
+
+func (m *Module) exportConv(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.ApplicationCommandData().Name != "exportconv" {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	data := &discordgo.InteractionResponseData{
+		Flags: discordgo.MessageFlagsEphemeral,
+	}
+
+	conv, err := m.conversation(context.Background(), i.ChannelID)
+	if err != nil {
+		slog.Error("error loading conversation", "err", err, "channel_id", i.ChannelID)
+		data.Content = "error loading conversation history"
+	} else {
+		buf, err := json.MarshalIndent(conv, "", "  ")
+		if err != nil {
+			slog.Error("error encoding conversation", "err", err, "channel_id", i.ChannelID)
+			data.Content = "error encoding conversation history"
+		} else {
+			data.Content = fmt.Sprintf("%d turns remembered", len(conv.Turns))
+			data.Files = []*discordgo.File{{
+				Name:        "conversation-" + i.ChannelID + ".json",
+				ContentType: "application/json",
+				Reader:      bytes.NewReader(buf),
+			}}
+		}
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+}
+
+func (m *Module) forgetMe(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.ApplicationCommandData().Name != "forgetme" {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	content := "your messages were forgotten"
+	if err := m.forget(context.Background(), interactionUserID(i)); err != nil {
+		slog.Error("error forgetting user", "err", err, "channel_id", i.ChannelID)
+		content = "error forgetting your messages"
+	}
 
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "conversation history cleared",
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
 }
 
+// conversation returns the conversation in a channel, loading it from
+// memory if needed. m.lock must be held.
+func (m *Module) conversation(ctx context.Context, channelID string) (*Conversation, error) {
+	st := m.convHistory[channelID]
+	if st.conv != nil {
+		return st.conv, nil
+	}
+
+	conv, err := m.mem.Load(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	st.conv = conv
+	m.convHistory[channelID] = st
+	return conv, nil
+}
+
+// forget removes the turns userID started from every stored conversation.
+// m.lock must be held.
+func (m *Module) forget(ctx context.Context, userID string) error {
+	channels, err := m.mem.Channels(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, channelID := range channels {
+		conv, err := m.conversation(ctx, channelID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if conv.Forget(userID) {
+			errs = append(errs, m.mem.Save(ctx, conv))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil {
+		return i.Member.User.ID
+	}
+	return i.User.ID
+}
+
 func (m *Module) messageCreate(s *discordgo.Session, mc *discordgo.MessageCreate) {
 	if !strings.Contains(*chatChannels, mc.ChannelID) {
 		return
@@ -157,16 +305,18 @@ func (m *Module) messageCreate(s *discordgo.Session, mc *discordgo.MessageCreate
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	st := m.convHistory[mc.ChannelID]
-	conv := st.conv
+	ctx := context.Background()
 
-	if len(conv) == 0 {
-		conv = append(conv, ollama.Message{
-			Role:    "system",
-			Content: mimiSystemMessage,
-		})
+	history, err := m.conversation(ctx, mc.ChannelID)
+	if err != nil {
+		slog.Error("error loading conversation", "err", err, "channel_id", mc.ChannelID)
+		return
 	}
 
+	st := m.convHistory[mc.ChannelID]
+	conv := history.Messages(mimiSystemMessage)
+	turnStart := len(conv)
+
 	if st.aa == nil {
 		st.aa = NewAttentionAttenuator()
 	}
@@ -223,7 +373,7 @@ func (m *Module) messageCreate(s *discordgo.Session, mc *discordgo.MessageCreate
 	slog.Info("message count", "len", len(conv))
 
 	if !*disableLlamaguard {
-		lgResp, err := m.llamaGuardCheck(context.Background(), "user", conv)
+		lgResp, err := m.llamaGuardCheck(ctx, "user", conv)
 		if err != nil {
 			slog.Error("error checking message", "err", err, "message_id", mc.ID, "channel_id", mc.ChannelID)
 			s.ChannelMessageSend(mc.ChannelID, "error checking message")
@@ -231,7 +381,7 @@ func (m *Module) messageCreate(s *discordgo.Session, mc *discordgo.MessageCreate
 		}
 
 		if !lgResp.IsSafe {
-			msg, err := m.llamaGuardComplain(context.Background(), "user", lgResp)
+			msg, err := m.llamaGuardComplain(ctx, "user", lgResp)
 			if err != nil {
 				slog.Error("error generating response", "err", err, "message_id", mc.ID, "channel_id", mc.ChannelID)
 				s.ChannelMessageSend(mc.ChannelID, "error generating response")
@@ -252,7 +402,7 @@ func (m *Module) messageCreate(s *discordgo.Session, mc *discordgo.MessageCreate
 		Tools: m.getTools(),
 	}
 
-	resp, err := m.ollama.Chat(context.Background(), cr)
+	resp, err := m.ollama.Chat(ctx, cr)
 	if err != nil {
 		slog.Error("error chatting", "err", err, "message_id", mc.ID, "channel_id", mc.ChannelID)
 		s.ChannelMessageSend(mc.ChannelID, "error chatting")
@@ -285,7 +435,7 @@ func (m *Module) messageCreate(s *discordgo.Session, mc *discordgo.MessageCreate
 
 				m.sess.MessageReactionAdd(mc.ChannelID, mc.ID, "🐍")
 
-				msg, err := m.runPythonCode(context.Background(), tc.Function)
+				msg, err := m.runPythonCode(ctx, tc.Function)
 				if err != nil {
 					slog.Error("error running python code", "err", err, "message_id", mc.ID, "channel_id", mc.ChannelID)
 					s.ChannelMessageSend(mc.ChannelID, "error running python code")
@@ -294,7 +444,7 @@ func (m *Module) messageCreate(s *discordgo.Session, mc *discordgo.MessageCreate
 
 				conv = append(conv, *msg)
 
-				resp, err = m.ollama.Chat(context.Background(), &ollama.CompleteRequest{
+				resp, err = m.ollama.Chat(ctx, &ollama.CompleteRequest{
 					Model:    *mimiModel,
 					Messages: conv,
 					Options: map[string]any{
@@ -317,7 +467,7 @@ func (m *Module) messageCreate(s *discordgo.Session, mc *discordgo.MessageCreate
 	}
 
 	if !*disableLlamaguard {
-		lgResp, err := m.llamaGuardCheck(context.Background(), "assistant", conv)
+		lgResp, err := m.llamaGuardCheck(ctx, "assistant", conv)
 		if err != nil {
 			slog.Error("error checking message", "err", err, "message_id", mc.ID, "channel_id", mc.ChannelID)
 			s.ChannelMessageSend(mc.ChannelID, "error checking message")
@@ -326,7 +476,7 @@ func (m *Module) messageCreate(s *discordgo.Session, mc *discordgo.MessageCreate
 
 		if !lgResp.IsSafe {
 			slog.Error("rule violation detected", "message_id", mc.ID, "channel_id", mc.ChannelID, "categories", lgResp.ViolationCategories, "message", resp.Message.Content)
-			msg, err := m.llamaGuardComplain(context.Background(), "assistant", lgResp)
+			msg, err := m.llamaGuardComplain(ctx, "assistant", lgResp)
 			if err != nil {
 				slog.Error("error generating response", "err", err, "message_id", mc.ID, "channel_id", mc.ChannelID)
 				s.ChannelMessageSend(mc.ChannelID, "error generating response")
@@ -342,10 +492,58 @@ func (m *Module) messageCreate(s *discordgo.Session, mc *discordgo.MessageCreate
 
 	s.ChannelMessageSend(mc.ChannelID, resp.Message.Content)
 
-	st.conv = conv
+	history.Turns = append(history.Turns, Turn{
+		AuthorID: mc.Author.ID,
+		Messages: slices.Clone(conv[turnStart:]),
+	})
+
+	budget := *contextWindow*3/4 - estimateTokens(conv[:1])
+	if err := history.Compact(ctx, budget, m.summarize); err != nil {
+		slog.Error("error compacting conversation", "err", err, "channel_id", mc.ChannelID)
+	}
+
+	if err := m.mem.Save(ctx, history); err != nil {
+		slog.Error("error saving conversation", "err", err, "channel_id", mc.ChannelID)
+	}
+
+	st.conv = history
 	m.convHistory[mc.ChannelID] = st
 }
 
+const summarizePrompt = `You keep the memory of a chat bot named Mimi. Summarize the conversation you are given so Mimi can keep talking without it. Keep who said what, names, facts people shared about themselves, decisions, open questions and the results of code Mimi ran. Leave out greetings and small talk. If there is a summary of what happened before, fold it in. Reply with only the summary.`
+
+// summarize asks the model to summarize messages for conversation
+// compaction.
+func (m *Module) summarize(ctx context.Context, prev string, messages []ollama.Message) (string, error) {
+	var sb strings.Builder
+	if prev != "" {
+		fmt.Fprintf(&sb, "Summary of what happened before:\n\n%s\n\nConversation:\n\n", prev)
+	}
+
+	for _, msg := range messages {
+		if msg.Content == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s: %s\n", msg.Role, msg.Content)
+	}
+
+	resp, err := m.ollama.Chat(ctx, &ollama.CompleteRequest{
+		Model: *mimiModel,
+		Messages: []ollama.Message{
+			{Role: "system", Content: summarizePrompt},
+			{Role: "user", Content: sb.String()},
+		},
+		Options: map[string]any{
+			"num_ctx": *contextWindow,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Message.Content, nil
+}
+
 func (m *Module) llamaGuardCheck(ctx context.Context, role string, messages []ollama.Message) (*llamaguard.Response, error) {
 	return llamaguard.Check(ctx, m.lg, role, *llamaGuardModel, messages)
 }
@@ -0,0 +1,181 @@
+package jufra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	"within.website/x/store"
+	"within.website/x/web/ollama"
+)
+
+// Conversation is the history of a channel as it is stored between
+// restarts.
+type Conversation struct {
+	ChannelID string `json:"channel_id"`
+	// Summary covers the turns that were compacted away.
+	Summary string `json:"summary,omitempty"`
+	// SummaryAuthors are the IDs of the users whose messages are covered by
+	// Summary.
+	SummaryAuthors []string  `json:"summary_authors,omitempty"`
+	Turns          []Turn    `json:"turns"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Turn is a user's message and everything Mimi did to answer it.
+type Turn struct {
+	AuthorID string           `json:"author_id"`
+	Messages []ollama.Message `json:"messages"`
+}
+
+// Messages returns the conversation in the form the model takes, starting
+// with the system prompt and the summary of older turns.
+func (c *Conversation) Messages(systemPrompt string) []ollama.Message {
+	result := []ollama.Message{{Role: "system", Content: systemPrompt}}
+
+	if c.Summary != "" {
+		result = append(result, ollama.Message{
+			Role:    "system",
+			Content: "Summary of the conversation so far:\n\n" + c.Summary,
+		})
+	}
+
+	for _, t := range c.Turns {
+		result = append(result, t.Messages...)
+	}
+
+	return result
+}
+
+// estimateTokens guesses how many tokens messages take up. It's a rough
+// character count, as the model's tokenizer isn't available here.
+func estimateTokens(messages []ollama.Message) int {
+	var n int
+	for _, m := range messages {
+		n += len(m.Content)/4 + 4
+		for _, tc := range m.ToolCalls {
+			n += len(tc.Function.Arguments)/4 + 4
+		}
+	}
+	return n
+}
+
+// Summarizer summarizes messages, folding in the summary of the turns
+// before them if there is one.
+type Summarizer func(ctx context.Context, prev string, messages []ollama.Message) (string, error)
+
+// Compact summarizes the oldest turns of c once it takes up more than
+// budget tokens. The newest turns that fit in half the budget are kept as
+// they are, and at least one turn is always kept.
+func (c *Conversation) Compact(ctx context.Context, budget int, summarize Summarizer) error {
+	if estimateTokens(c.Messages("")) <= budget || len(c.Turns) < 2 {
+		return nil
+	}
+
+	keep := len(c.Turns) - 1
+	used := estimateTokens(c.Turns[keep].Messages)
+	for keep > 1 {
+		n := estimateTokens(c.Turns[keep-1].Messages)
+		if used+n > budget/2 {
+			break
+		}
+		used += n
+		keep--
+	}
+
+	old := c.Turns[:keep]
+
+	var messages []ollama.Message
+	authors := c.SummaryAuthors
+	for _, t := range old {
+		messages = append(messages, t.Messages...)
+		if t.AuthorID != "" && !slices.Contains(authors, t.AuthorID) {
+			authors = append(authors, t.AuthorID)
+		}
+	}
+
+	summary, err := summarize(ctx, c.Summary, messages)
+	if err != nil {
+		return fmt.Errorf("jufra: can't summarize conversation: %w", err)
+	}
+
+	c.Summary = strings.TrimSpace(summary)
+	c.SummaryAuthors = authors
+	c.Turns = slices.Clone(c.Turns[keep:])
+
+	return nil
+}
+
+// Forget removes the turns started by userID. If the summary covers any of
+// their messages, it is dropped too. It reports whether anything was
+// removed.
+func (c *Conversation) Forget(userID string) bool {
+	n := len(c.Turns)
+	c.Turns = slices.DeleteFunc(c.Turns, func(t Turn) bool {
+		return t.AuthorID == userID
+	})
+	changed := len(c.Turns) != n
+
+	if slices.Contains(c.SummaryAuthors, userID) {
+		c.Summary = ""
+		c.SummaryAuthors = nil
+		changed = true
+	}
+
+	return changed
+}
+
+// Memory stores conversations in a store.Interface.
+type Memory struct {
+	convs *store.JSON[Conversation]
+}
+
+func NewMemory(st store.Interface) *Memory {
+	return &Memory{
+		convs: &store.JSON[Conversation]{
+			Underlying: st,
+			Prefix:     "jufra/conversations",
+		},
+	}
+}
+
+// Load returns the conversation in a channel. A channel without one gets
+// an empty conversation.
+func (m *Memory) Load(ctx context.Context, channelID string) (*Conversation, error) {
+	conv, err := m.convs.Get(ctx, channelID)
+	if errors.Is(err, store.ErrNotFound) {
+		return &Conversation{ChannelID: channelID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &conv, nil
+}
+
+func (m *Memory) Save(ctx context.Context, conv *Conversation) error {
+	conv.UpdatedAt = time.Now()
+	return m.convs.Set(ctx, conv.ChannelID, *conv)
+}
+
+// Delete forgets the conversation in a channel.
+func (m *Memory) Delete(ctx context.Context, channelID string) error {
+	if err := m.convs.Delete(ctx, channelID); err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// Channels lists the channels that have a stored conversation.
+func (m *Memory) Channels(ctx context.Context) ([]string, error) {
+	channels, err := m.convs.List(ctx, "")
+	if errors.Is(err, fs.ErrNotExist) {
+		// Nothing was saved yet.
+		return nil, nil
+	}
+	return channels, err
+}
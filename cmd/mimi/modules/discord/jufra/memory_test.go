@@ -0,0 +1,157 @@
+package jufra
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"within.website/x/store"
+	"within.website/x/web/ollama"
+)
+
+func turn(author, question, answer string) Turn {
+	return Turn{
+		AuthorID: author,
+		Messages: []ollama.Message{
+			{Role: "user", Content: question},
+			{Role: "assistant", Content: answer},
+		},
+	}
+}
+
+func TestMemoryRoundTrip(t *testing.T) {
+	st, err := store.NewDirectFile(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := NewMemory(st)
+
+	conv, err := mem.Load(t.Context(), "chan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conv.Turns) != 0 || conv.ChannelID != "chan" {
+		t.Fatalf("new channel should have an empty conversation, got: %+v", conv)
+	}
+
+	channels, err := mem.Channels(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(channels) != 0 {
+		t.Fatalf("wanted no channels, got: %q", channels)
+	}
+
+	conv.Turns = append(conv.Turns, turn("alice", "hi mimi", "hi alice"))
+	if err := mem.Save(t.Context(), conv); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := mem.Load(t.Context(), "chan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Turns) != 1 || got.Turns[0].Messages[1].Content != "hi alice" {
+		t.Errorf("conversation didn't survive a round trip: %+v", got)
+	}
+
+	channels, err = mem.Channels(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(channels) != 1 || channels[0] != "chan" {
+		t.Errorf("wanted [chan], got: %q", channels)
+	}
+
+	if err := mem.Delete(t.Context(), "chan"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mem.Delete(t.Context(), "chan"); err != nil {
+		t.Errorf("deleting a missing conversation should work: %v", err)
+	}
+}
+
+func TestConversationCompact(t *testing.T) {
+	long := strings.Repeat("word ", 100) // about 125 tokens
+
+	conv := &Conversation{ChannelID: "chan"}
+	for _, author := range []string{"alice", "bob", "alice", "carol"} {
+		conv.Turns = append(conv.Turns, turn(author, long, long))
+	}
+
+	var summarized []ollama.Message
+	summarize := func(ctx context.Context, prev string, messages []ollama.Message) (string, error) {
+		summarized = messages
+		return " a summary ", nil
+	}
+
+	if err := conv.Compact(t.Context(), 10000, summarize); err != nil {
+		t.Fatal(err)
+	}
+	if summarized != nil || len(conv.Turns) != 4 {
+		t.Fatal("conversation under budget shouldn't be compacted")
+	}
+
+	if err := conv.Compact(t.Context(), 600, summarize); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(conv.Turns) != 1 || conv.Turns[0].AuthorID != "carol" {
+		t.Errorf("only the newest turn fits in half the budget, got %d turns", len(conv.Turns))
+	}
+	if len(summarized) != 6 {
+		t.Errorf("wanted the 6 messages of the older turns summarized, got: %d", len(summarized))
+	}
+	if conv.Summary != "a summary" {
+		t.Errorf("wrong summary: %q", conv.Summary)
+	}
+	if strings.Join(conv.SummaryAuthors, ",") != "alice,bob" {
+		t.Errorf("wrong summary authors: %q", conv.SummaryAuthors)
+	}
+
+	msgs := conv.Messages("system prompt")
+	if len(msgs) != 4 || msgs[1].Role != "system" || !strings.Contains(msgs[1].Content, "a summary") {
+		t.Errorf("summary should follow the system prompt, got: %+v", msgs)
+	}
+
+	conv.Turns = append(conv.Turns, turn("dave", long, long))
+	if err := conv.Compact(t.Context(), 100, func(ctx context.Context, prev string, messages []ollama.Message) (string, error) {
+		return "", errors.New("model is down")
+	}); err == nil {
+		t.Error("summarizer errors should be returned")
+	}
+	if len(conv.Turns) != 2 || conv.Summary != "a summary" {
+		t.Error("failed compaction shouldn't change the conversation")
+	}
+}
+
+func TestConversationForget(t *testing.T) {
+	conv := &Conversation{
+		Summary:        "bob likes cheese",
+		SummaryAuthors: []string{"bob"},
+		Turns: []Turn{
+			turn("alice", "hi", "hello"),
+			turn("bob", "hi", "hello"),
+			turn("alice", "bye", "goodbye"),
+		},
+	}
+
+	if conv.Forget("carol") {
+		t.Error("forgetting someone who said nothing shouldn't change anything")
+	}
+
+	if !conv.Forget("alice") {
+		t.Fatal("forgetting alice should change the conversation")
+	}
+	if len(conv.Turns) != 1 || conv.Turns[0].AuthorID != "bob" || conv.Summary == "" {
+		t.Errorf("only alice's turns should be gone, got: %+v", conv)
+	}
+
+	if !conv.Forget("bob") {
+		t.Fatal("forgetting bob should change the conversation")
+	}
+	if len(conv.Turns) != 0 || conv.Summary != "" || conv.SummaryAuthors != nil {
+		t.Errorf("bob's turns and the summary covering them should be gone, got: %+v", conv)
+	}
+}
@@ -2,9 +2,15 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"flag"
+	"io/fs"
 	"log"
 	"net/http"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"within.website/x"
@@ -13,28 +19,194 @@ import (
 )
 
 var (
-	bind   = flag.String("bind", "", "TCP host:port to bind HTTP to")
-	apiKey = flag.String("api-key", "", "API key required for Authorization Bearer header")
+	bind               = flag.String("bind", "", "TCP host:port to bind HTTP to")
+	apiKey             = flag.String("api-key", "", "API key required for Authorization Bearer header")
+	inputDir           = flag.String("input-dir", "", "if set, directory mounted read-only at /input for the code to read")
+	timeout            = flag.Duration("timeout", python.DefaultTimeout, "default and maximum wall-clock time for each run")
+	memoryLimitMiB     = flag.Int("memory-limit-mib", 256, "interpreter memory limit in MiB, rounded down to a power of two")
+	maxOutput          = flag.Int("max-output", python.DefaultMaxOutput, "how many bytes of stdout and stderr are returned")
+	maxSessions        = flag.Int("max-sessions", 8, "how many interpreter sessions can be open at once")
+	sessionIdleTimeout = flag.Duration("session-idle-timeout", 15*time.Minute, "close sessions that weren't used for this long")
 )
 
 type Input struct {
-	Code string `json:"code" jsonschema:"The python code to execute"`
+	Code           string `json:"code" jsonschema:"The python code to execute"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty" jsonschema:"Optional wall-clock limit in seconds, capped by the server's limit"`
+}
+
+type SessionStartOutput struct {
+	SessionID string `json:"session_id" jsonschema:"Pass this to session_exec and session_close"`
+}
+
+type SessionExecInput struct {
+	SessionID string `json:"session_id" jsonschema:"The session from session_start"`
+	Code      string `json:"code" jsonschema:"The python code to execute; globals from earlier calls are kept"`
+}
+
+type SessionCloseInput struct {
+	SessionID string `json:"session_id" jsonschema:"The session from session_start"`
+}
+
+type SessionCloseOutput struct {
+	Closed bool `json:"closed"`
+}
+
+// options returns the interpreter options set by flags.
+func options() python.Options {
+	opts := python.Options{
+		Timeout:          *timeout,
+		MemoryLimitPages: uint32(*memoryLimitMiB) * 16, // 64 KiB pages
+		MaxOutput:        *maxOutput,
+	}
+	if *inputDir != "" {
+		opts.FS = os.DirFS(*inputDir)
+	}
+	return opts
 }
 
 func Python(ctx context.Context, req *mcp.CallToolRequest, input Input) (*mcp.CallToolResult, *python.Result, error) {
-	result, err := python.Run(ctx, nil, input.Code)
+	opts := options()
+	if d := time.Duration(input.TimeoutSeconds) * time.Second; d > 0 && d < opts.Timeout {
+		opts.Timeout = d
+	}
+
+	result, err := python.RunWithOptions(ctx, input.Code, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, result, nil
+}
+
+var errTooManySessions = errors.New("too many open sessions, close one with session_close first")
+
+// Sessions tracks open interpreter sessions by ID.
+type Sessions struct {
+	opts        python.Options
+	max         int
+	idleTimeout time.Duration
+
+	lock     sync.Mutex
+	sessions map[string]*sessionEntry
+}
+
+type sessionEntry struct {
+	sess     *python.Session
+	lastUsed time.Time
+}
+
+func NewSessions(opts python.Options, max int, idleTimeout time.Duration) *Sessions {
+	return &Sessions{
+		opts:        opts,
+		max:         max,
+		idleTimeout: idleTimeout,
+		sessions:    map[string]*sessionEntry{},
+	}
+}
+
+func (s *Sessions) Start(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, *SessionStartOutput, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.sessions) >= s.max {
+		return nil, nil, errTooManySessions
+	}
+
+	sess, err := python.NewSession(ctx, s.opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id := rand.Text()
+	s.sessions[id] = &sessionEntry{sess: sess, lastUsed: time.Now()}
+
+	return nil, &SessionStartOutput{SessionID: id}, nil
+}
+
+func (s *Sessions) Exec(ctx context.Context, req *mcp.CallToolRequest, input SessionExecInput) (*mcp.CallToolResult, *python.Result, error) {
+	s.lock.Lock()
+	entry, ok := s.sessions[input.SessionID]
+	if ok {
+		entry.lastUsed = time.Now()
+	}
+	s.lock.Unlock()
+
+	if !ok {
+		return nil, nil, python.ErrSessionClosed
+	}
+
+	result, err := entry.sess.Exec(ctx, input.Code)
 	if err != nil {
+		if errors.Is(err, python.ErrTimeout) || errors.Is(err, python.ErrSessionClosed) {
+			s.remove(input.SessionID)
+		}
 		return nil, nil, err
 	}
 
 	return nil, result, nil
 }
 
+func (s *Sessions) Close(ctx context.Context, req *mcp.CallToolRequest, input SessionCloseInput) (*mcp.CallToolResult, *SessionCloseOutput, error) {
+	return nil, &SessionCloseOutput{Closed: s.remove(input.SessionID)}, nil
+}
+
+// remove closes and forgets a session, reporting whether it was open.
+func (s *Sessions) remove(id string) bool {
+	s.lock.Lock()
+	entry, ok := s.sessions[id]
+	delete(s.sessions, id)
+	s.lock.Unlock()
+
+	if ok {
+		entry.sess.Close()
+	}
+	return ok
+}
+
+// Reap closes idle sessions every minute until ctx is done.
+func (s *Sessions) Reap(ctx context.Context) {
+	t := time.NewTicker(time.Minute)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			s.lock.Lock()
+			var idle []string
+			for id, entry := range s.sessions {
+				if now.Sub(entry.lastUsed) > s.idleTimeout {
+					idle = append(idle, id)
+				}
+			}
+			s.lock.Unlock()
+
+			for _, id := range idle {
+				log.Printf("closing idle session %s", id)
+				s.remove(id)
+			}
+		}
+	}
+}
+
 func main() {
 	internal.HandleStartup()
 
+	if *inputDir != "" {
+		if _, err := fs.Stat(os.DirFS(*inputDir), "."); err != nil {
+			log.Fatalf("can't use input dir: %v", err)
+		}
+	}
+
+	sessions := NewSessions(options(), *maxSessions, *sessionIdleTimeout)
+	go sessions.Reap(context.Background())
+
 	srv := mcp.NewServer(&mcp.Implementation{Name: "python-wasm-mcp", Version: x.Version}, nil)
-	mcp.AddTool(srv, &mcp.Tool{Name: "run", Description: "Run Python code"}, Python)
+	mcp.AddTool(srv, &mcp.Tool{Name: "run", Description: "Run Python code in a fresh interpreter. Files in /input are readable and files written to /scratch are returned."}, Python)
+	mcp.AddTool(srv, &mcp.Tool{Name: "session_start", Description: "Start a Python interpreter that keeps its state between session_exec calls"}, sessions.Start)
+	mcp.AddTool(srv, &mcp.Tool{Name: "session_exec", Description: "Run Python code in a session. Variables, imports and /scratch files from earlier calls are kept."}, sessions.Exec)
+	mcp.AddTool(srv, &mcp.Tool{Name: "session_close", Description: "Stop a session and free its resources"}, sessions.Close)
 
 	switch *bind {
 	case "":
@@ -19,6 +19,8 @@ var (
 	domain      = flag.String("domain", "within.website", "domain this is run on")
 	port        = flag.String("port", "2134", "HTTP port to listen on")
 	tysonConfig = flag.String("tyson-config", "./config.ts", "TySON config file")
+	xCheckout   = flag.String("x-checkout", "", "if set, git checkout of the x repo to serve a module proxy and documentation from")
+	modCache    = flag.String("mod-cache", "", "if set, module proxy directory to serve x from when the checkout doesn't have a version, such as $GOMODCACHE/cache/download")
 )
 
 type Repo struct {
@@ -50,18 +52,57 @@ func (r Repo) LogValue() slog.Value {
 	)
 }
 
-func (r Repo) RegisterHandlers(mux *http.ServeMux, lg *slog.Logger) {
+func (r Repo) RegisterHandlers(mux *http.ServeMux, lg *slog.Logger, opts ...vanity.Option) {
 	switch r.Kind {
 	case "gitea":
-		mux.Handle("/"+r.Repo, vanity.GogsHandler(*domain+"/"+r.Repo, r.Domain, r.User, r.Repo, "https"))
-		mux.Handle("/"+r.Repo+"/", vanity.GogsHandler(*domain+"/"+r.Repo, r.Domain, r.User, r.Repo, "https"))
+		mux.Handle("/"+r.Repo, vanity.GogsHandler(*domain+"/"+r.Repo, r.Domain, r.User, r.Repo, "https", opts...))
+		mux.Handle("/"+r.Repo+"/", vanity.GogsHandler(*domain+"/"+r.Repo, r.Domain, r.User, r.Repo, "https", opts...))
 	case "github":
-		mux.Handle("/"+r.Repo, vanity.GitHubHandler(*domain+"/"+r.Repo, r.User, r.Repo, "https"))
-		mux.Handle("/"+r.Repo+"/", vanity.GitHubHandler(*domain+"/"+r.Repo, r.User, r.Repo, "https"))
+		mux.Handle("/"+r.Repo, vanity.GitHubHandler(*domain+"/"+r.Repo, r.User, r.Repo, "https", opts...))
+		mux.Handle("/"+r.Repo+"/", vanity.GitHubHandler(*domain+"/"+r.Repo, r.User, r.Repo, "https", opts...))
 	}
 	lg.Debug("registered repo handler", "repo", r)
 }
 
+// registerX serves a module proxy and documentation for within.website/x
+// from the local checkout and module cache, so that it can be fetched even
+// when GitHub is down. It returns the vanity options that point the go
+// command and browsers at them.
+func registerX(mux *http.ServeMux, lg *slog.Logger) ([]vanity.Option, error) {
+	const (
+		proxyPrefix = "/.within/goproxy"
+		docPrefix   = "/.within/doc"
+	)
+
+	modulePath := *domain + "/x"
+	var result []vanity.Option
+	var sources []vanity.Source
+
+	if *xCheckout != "" {
+		sources = append(sources, vanity.GitRepo{Dir: *xCheckout, Root: modulePath})
+
+		docs, err := vanity.LoadDocs(modulePath, *xCheckout)
+		if err != nil {
+			return nil, fmt.Errorf("can't load documentation: %w", err)
+		}
+
+		mux.Handle(docPrefix+"/", docs.Handler(docPrefix))
+		result = append(result, vanity.WithRedirector(docs.Redirector("https://"+*domain+docPrefix, nil)))
+	}
+
+	if *modCache != "" {
+		sources = append(sources, vanity.CacheDir{Dir: *modCache})
+	}
+
+	if len(sources) != 0 {
+		mux.Handle(proxyPrefix+"/", http.StripPrefix(proxyPrefix, vanity.Proxy(sources...)))
+		result = append(result, vanity.WithGoModProxy(modulePath, "https://"+*domain+proxyPrefix))
+		lg.Info("serving module proxy", "module", modulePath, "checkout", *xCheckout, "modCache", *modCache)
+	}
+
+	return result, nil
+}
+
 //go:generate go tool templ generate
 
 func main() {
@@ -77,8 +118,18 @@ func main() {
 
 	mux := http.NewServeMux()
 
+	xOpts, err := registerX(mux, lg)
+	if err != nil {
+		lg.Error("can't serve the x repo", "err", err)
+		os.Exit(1)
+	}
+
 	for _, repo := range repos {
-		repo.RegisterHandlers(mux, lg)
+		var opts []vanity.Option
+		if repo.Repo == "x" {
+			opts = xOpts
+		}
+		repo.RegisterHandlers(mux, lg, opts...)
 	}
 
 	xess.Mount(mux)
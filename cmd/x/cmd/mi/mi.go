@@ -21,6 +21,7 @@ type Client struct {
 	conn          *grpc.ClientConn
 	Events        miv1.EventsClient
 	SwitchTracker miv1.SwitchTrackerClient
+	Webhooks      miv1.WebhooksClient
 }
 
 func New() (*Client, error) {
@@ -47,5 +48,6 @@ func New() (*Client, error) {
 		conn:          conn,
 		Events:        miv1.NewEventsClient(conn),
 		SwitchTracker: miv1.NewSwitchTrackerClient(conn),
+		Webhooks:      miv1.NewWebhooksClient(conn),
 	}, nil
 }
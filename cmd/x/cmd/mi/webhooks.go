@@ -0,0 +1,245 @@
+package mi
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"github.com/google/subcommands"
+	"github.com/rodaine/table"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	mi "within.website/x/gen/within/website/x/mi/v1"
+)
+
+var subscriptionKinds = map[string]mi.SubscriptionKind{
+	"webhook":  mi.SubscriptionKind_SUBSCRIPTION_KIND_WEBHOOK,
+	"announce": mi.SubscriptionKind_SUBSCRIPTION_KIND_ANNOUNCE,
+}
+
+// Subscribe implements the "subscribe" subcommand.
+type Subscribe struct {
+	url         string
+	kind        string
+	events      string
+	description string
+}
+
+func (*Subscribe) Name() string     { return "subscribe" }
+func (*Subscribe) Synopsis() string { return "Send mi's events to a URL." }
+func (*Subscribe) Usage() string {
+	return `subscribe --url <url> [--kind] [--events] [--description]:
+Subscribe a webhook or Announce endpoint to mi's events. The signing secret
+is only shown once, so save it somewhere.
+`
+}
+
+func (s *Subscribe) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&s.url, "url", "", "URL to send events to.")
+	f.StringVar(&s.kind, "kind", "webhook", "Kind of subscription (webhook or announce).")
+	f.StringVar(&s.events, "events", "", "Comma-separated event types to send (switch, event.added, event.removed, blogpost.published), empty means all.")
+	f.StringVar(&s.description, "description", "", "What the subscription is for.")
+}
+
+func (s *Subscribe) Execute(ctx context.Context, f *flag.FlagSet, _ ...any) subcommands.ExitStatus {
+	kind, ok := subscriptionKinds[s.kind]
+	if !ok {
+		fmt.Printf("error: unknown subscription kind %q, want webhook or announce\n", s.kind)
+		return subcommands.ExitUsageError
+	}
+
+	req := &mi.SubscribeReq{
+		Kind:        kind,
+		Url:         s.url,
+		Description: s.description,
+	}
+	if s.events != "" {
+		req.EventTypes = strings.Split(s.events, ",")
+	}
+
+	if err := protovalidate.Validate(req); err != nil {
+		fmt.Printf("error: %v\n", err)
+		return subcommands.ExitUsageError
+	}
+
+	client, err := New()
+	if err != nil {
+		fmt.Printf("can't connect to mi %v\n", err)
+		return subcommands.ExitFailure
+	}
+
+	sub, err := client.Webhooks.Subscribe(ctx, req)
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		return subcommands.ExitFailure
+	}
+
+	fmt.Printf("id:     %s\n", sub.GetId())
+	fmt.Printf("secret: %s\n", sub.GetSecret())
+	return subcommands.ExitSuccess
+}
+
+// ListSubscriptions implements the "list-subscriptions" subcommand.
+type ListSubscriptions struct{}
+
+func (*ListSubscriptions) Name() string     { return "list-subscriptions" }
+func (*ListSubscriptions) Synopsis() string { return "List webhook subscriptions." }
+func (*ListSubscriptions) Usage() string {
+	return `list-subscriptions:
+List every webhook subscription.
+`
+}
+
+func (*ListSubscriptions) SetFlags(f *flag.FlagSet) {}
+
+func (*ListSubscriptions) Execute(ctx context.Context, f *flag.FlagSet, _ ...any) subcommands.ExitStatus {
+	client, err := New()
+	if err != nil {
+		fmt.Printf("can't connect to mi %v\n", err)
+		return subcommands.ExitFailure
+	}
+
+	resp, err := client.Webhooks.ListSubscriptions(ctx, &emptypb.Empty{})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		return subcommands.ExitFailure
+	}
+
+	tbl := table.New("ID", "Kind", "URL", "Events", "Description")
+	for _, sub := range resp.GetSubscriptions() {
+		events := strings.Join(sub.GetEventTypes(), ",")
+		if events == "" {
+			events = "all"
+		}
+
+		kind := strings.ToLower(strings.TrimPrefix(sub.GetKind().String(), "SUBSCRIPTION_KIND_"))
+		tbl.AddRow(sub.GetId(), kind, sub.GetUrl(), events, sub.GetDescription())
+	}
+
+	tbl.Print()
+	return subcommands.ExitSuccess
+}
+
+// Unsubscribe implements the "unsubscribe" subcommand.
+type Unsubscribe struct {
+	id string
+}
+
+func (*Unsubscribe) Name() string     { return "unsubscribe" }
+func (*Unsubscribe) Synopsis() string { return "Remove a webhook subscription." }
+func (*Unsubscribe) Usage() string {
+	return `unsubscribe --id <id>:
+Remove a webhook subscription and its pending deliveries.
+`
+}
+
+func (u *Unsubscribe) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&u.id, "id", "", "ID of the subscription.")
+}
+
+func (u *Unsubscribe) Execute(ctx context.Context, f *flag.FlagSet, _ ...any) subcommands.ExitStatus {
+	req := &mi.UnsubscribeReq{Id: u.id}
+	if err := protovalidate.Validate(req); err != nil {
+		fmt.Printf("error: %v\n", err)
+		return subcommands.ExitUsageError
+	}
+
+	client, err := New()
+	if err != nil {
+		fmt.Printf("can't connect to mi %v\n", err)
+		return subcommands.ExitFailure
+	}
+
+	if _, err := client.Webhooks.Unsubscribe(ctx, req); err != nil {
+		fmt.Printf("error: %v\n", err)
+		return subcommands.ExitFailure
+	}
+
+	fmt.Printf("unsubscribed %s\n", u.id)
+	return subcommands.ExitSuccess
+}
+
+// ListDeadLetters implements the "list-dead-letters" subcommand.
+type ListDeadLetters struct {
+	subscription string
+	count        int
+}
+
+func (*ListDeadLetters) Name() string     { return "list-dead-letters" }
+func (*ListDeadLetters) Synopsis() string { return "List webhook deliveries that failed." }
+func (*ListDeadLetters) Usage() string {
+	return `list-dead-letters [--subscription] [--count]:
+List the newest webhook deliveries that failed too many times.
+`
+}
+
+func (ldl *ListDeadLetters) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&ldl.subscription, "subscription", "", "Only list dead letters for this subscription ID.")
+	f.IntVar(&ldl.count, "count", 30, "Number of dead letters to list.")
+}
+
+func (ldl *ListDeadLetters) Execute(ctx context.Context, f *flag.FlagSet, _ ...any) subcommands.ExitStatus {
+	client, err := New()
+	if err != nil {
+		fmt.Printf("can't connect to mi %v\n", err)
+		return subcommands.ExitFailure
+	}
+
+	resp, err := client.Webhooks.ListDeadLetters(ctx, &mi.ListDeadLettersReq{
+		SubscriptionId: ldl.subscription,
+		Count:          int32(ldl.count),
+	})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		return subcommands.ExitFailure
+	}
+
+	tbl := table.New("ID", "Subscription", "Event", "Failed at", "Attempts", "Last error")
+	for _, dl := range resp.GetDeadLetters() {
+		tbl.AddRow(dl.GetId(), dl.GetSubscriptionId(), dl.GetEventType(), dl.GetFailedAt().AsTime(), dl.GetAttempts(), dl.GetLastError())
+	}
+
+	tbl.Print()
+	return subcommands.ExitSuccess
+}
+
+// RetryDeadLetter implements the "retry-dead-letter" subcommand.
+type RetryDeadLetter struct {
+	id string
+}
+
+func (*RetryDeadLetter) Name() string     { return "retry-dead-letter" }
+func (*RetryDeadLetter) Synopsis() string { return "Try a failed webhook delivery again." }
+func (*RetryDeadLetter) Usage() string {
+	return `retry-dead-letter --id <id>:
+Put a dead letter back in the delivery queue.
+`
+}
+
+func (rdl *RetryDeadLetter) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&rdl.id, "id", "", "ID of the dead letter.")
+}
+
+func (rdl *RetryDeadLetter) Execute(ctx context.Context, f *flag.FlagSet, _ ...any) subcommands.ExitStatus {
+	req := &mi.RetryDeadLetterReq{Id: rdl.id}
+	if err := protovalidate.Validate(req); err != nil {
+		fmt.Printf("error: %v\n", err)
+		return subcommands.ExitUsageError
+	}
+
+	client, err := New()
+	if err != nil {
+		fmt.Printf("can't connect to mi %v\n", err)
+		return subcommands.ExitFailure
+	}
+
+	if _, err := client.Webhooks.RetryDeadLetter(ctx, req); err != nil {
+		fmt.Printf("error: %v\n", err)
+		return subcommands.ExitFailure
+	}
+
+	fmt.Printf("queued %s for another try\n", rdl.id)
+	return subcommands.ExitSuccess
+}
@@ -30,6 +30,13 @@ func main() {
 	subcommands.Register(&mi.Switch{}, "switch-tracker")
 	subcommands.Register(&mi.WhoIsFront{}, "switch-tracker")
 
+	// Webhook commands
+	subcommands.Register(&mi.Subscribe{}, "webhooks")
+	subcommands.Register(&mi.ListSubscriptions{}, "webhooks")
+	subcommands.Register(&mi.Unsubscribe{}, "webhooks")
+	subcommands.Register(&mi.ListDeadLetters{}, "webhooks")
+	subcommands.Register(&mi.RetryDeadLetter{}, "webhooks")
+
 	// // Events
 	// subcommands.Register(&miListEvents{}, "events")
 	// subcommands.Register(&miAddEvent{}, "events")
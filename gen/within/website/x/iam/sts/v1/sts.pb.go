@@ -360,6 +360,244 @@ func (x *TokenIdentity) GetDisplayName() string {
 	return ""
 }
 
+type GetLoginConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoginConfigRequest) Reset() {
+	*x = GetLoginConfigRequest{}
+	mi := &file_within_website_x_iam_sts_v1_sts_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginConfigRequest) ProtoMessage() {}
+
+func (x *GetLoginConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_iam_sts_v1_sts_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetLoginConfigRequest) Descriptor() ([]byte, []int) {
+	return file_within_website_x_iam_sts_v1_sts_proto_rawDescGZIP(), []int{5}
+}
+
+type GetLoginConfigResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The OIDC issuer URL clients discover endpoints from.
+	Issuer string `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// The OAuth2 client id registered for the iam CLI. It is a public client:
+	// the authorization code flow is protected with PKCE, not a secret.
+	ClientId string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Scopes to request. Always includes "openid".
+	Scopes        []string `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoginConfigResponse) Reset() {
+	*x = GetLoginConfigResponse{}
+	mi := &file_within_website_x_iam_sts_v1_sts_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginConfigResponse) ProtoMessage() {}
+
+func (x *GetLoginConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_iam_sts_v1_sts_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetLoginConfigResponse) Descriptor() ([]byte, []int) {
+	return file_within_website_x_iam_sts_v1_sts_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetLoginConfigResponse) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *GetLoginConfigResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *GetLoginConfigResponse) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+type AssumeRoleWithWebIdentityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The raw, compact-serialized ID token.
+	IdToken string `protobuf:"bytes,1,opt,name=id_token,json=idToken,proto3" json:"id_token,omitempty"`
+	// Requested credential lifetime in seconds. Zero means the server's
+	// maximum; larger values are clamped to it.
+	DurationSeconds int64 `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AssumeRoleWithWebIdentityRequest) Reset() {
+	*x = AssumeRoleWithWebIdentityRequest{}
+	mi := &file_within_website_x_iam_sts_v1_sts_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssumeRoleWithWebIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssumeRoleWithWebIdentityRequest) ProtoMessage() {}
+
+func (x *AssumeRoleWithWebIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_iam_sts_v1_sts_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssumeRoleWithWebIdentityRequest.ProtoReflect.Descriptor instead.
+func (*AssumeRoleWithWebIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_within_website_x_iam_sts_v1_sts_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AssumeRoleWithWebIdentityRequest) GetIdToken() string {
+	if x != nil {
+		return x.IdToken
+	}
+	return ""
+}
+
+func (x *AssumeRoleWithWebIdentityRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type AssumeRoleWithWebIdentityResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The newly minted access key id.
+	AccessKeyId string `protobuf:"bytes,1,opt,name=access_key_id,json=accessKeyId,proto3" json:"access_key_id,omitempty"`
+	// The secret access key. Returned exactly once; iamd never reveals it
+	// again.
+	SecretAccessKey string `protobuf:"bytes,2,opt,name=secret_access_key,json=secretAccessKey,proto3" json:"secret_access_key,omitempty"`
+	// When the key stops verifying. The key is not renewable; log in again.
+	Expiration *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	// The iamd user the ID token mapped to.
+	Identity *TokenIdentity `protobuf:"bytes,4,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Whether the mapped user is an administrator, as decided by the
+	// issuer's group claim on this login.
+	IsAdmin       bool `protobuf:"varint,5,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssumeRoleWithWebIdentityResponse) Reset() {
+	*x = AssumeRoleWithWebIdentityResponse{}
+	mi := &file_within_website_x_iam_sts_v1_sts_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssumeRoleWithWebIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssumeRoleWithWebIdentityResponse) ProtoMessage() {}
+
+func (x *AssumeRoleWithWebIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_iam_sts_v1_sts_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssumeRoleWithWebIdentityResponse.ProtoReflect.Descriptor instead.
+func (*AssumeRoleWithWebIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_within_website_x_iam_sts_v1_sts_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AssumeRoleWithWebIdentityResponse) GetAccessKeyId() string {
+	if x != nil {
+		return x.AccessKeyId
+	}
+	return ""
+}
+
+func (x *AssumeRoleWithWebIdentityResponse) GetSecretAccessKey() string {
+	if x != nil {
+		return x.SecretAccessKey
+	}
+	return ""
+}
+
+func (x *AssumeRoleWithWebIdentityResponse) GetExpiration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+func (x *AssumeRoleWithWebIdentityResponse) GetIdentity() *TokenIdentity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *AssumeRoleWithWebIdentityResponse) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
 var File_within_website_x_iam_sts_v1_sts_proto protoreflect.FileDescriptor
 
 const file_within_website_x_iam_sts_v1_sts_proto_rawDesc = "" +
@@ -390,10 +628,29 @@ const file_within_website_x_iam_sts_v1_sts_proto_rawDesc = "" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12'\n" +
 	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12!\n" +
 	"\fprincipal_id\x18\x03 \x01(\tR\vprincipalId\x12!\n" +
-	"\fdisplay_name\x18\x04 \x01(\tR\vdisplayName2\x80\x02\n" +
+	"\fdisplay_name\x18\x04 \x01(\tR\vdisplayName\"\x17\n" +
+	"\x15GetLoginConfigRequest\"e\n" +
+	"\x16GetLoginConfigResponse\x12\x16\n" +
+	"\x06issuer\x18\x01 \x01(\tR\x06issuer\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\"y\n" +
+	" AssumeRoleWithWebIdentityRequest\x12!\n" +
+	"\bid_token\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\aidToken\x122\n" +
+	"\x10duration_seconds\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x0fdurationSeconds\"\x92\x02\n" +
+	"!AssumeRoleWithWebIdentityResponse\x12\"\n" +
+	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12*\n" +
+	"\x11secret_access_key\x18\x02 \x01(\tR\x0fsecretAccessKey\x12:\n" +
+	"\n" +
+	"expiration\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expiration\x12F\n" +
+	"\bidentity\x18\x04 \x01(\v2*.within.website.x.iam.sts.v1.TokenIdentityR\bidentity\x12\x19\n" +
+	"\bis_admin\x18\x05 \x01(\bR\aisAdmin2\x80\x02\n" +
 	"\x11SigningKeyService\x12v\n" +
 	"\rGetSigningKey\x121.within.website.x.iam.sts.v1.GetSigningKeyRequest\x1a2.within.website.x.iam.sts.v1.GetSigningKeyResponse\x12s\n" +
-	"\fGetPublicKey\x120.within.website.x.iam.sts.v1.GetPublicKeyRequest\x1a1.within.website.x.iam.sts.v1.GetPublicKeyResponseB\xf6\x01\n" +
+	"\fGetPublicKey\x120.within.website.x.iam.sts.v1.GetPublicKeyRequest\x1a1.within.website.x.iam.sts.v1.GetPublicKeyResponse2\xac\x02\n" +
+	"\x12WebIdentityService\x12y\n" +
+	"\x0eGetLoginConfig\x122.within.website.x.iam.sts.v1.GetLoginConfigRequest\x1a3.within.website.x.iam.sts.v1.GetLoginConfigResponse\x12\x9a\x01\n" +
+	"\x19AssumeRoleWithWebIdentity\x12=.within.website.x.iam.sts.v1.AssumeRoleWithWebIdentityRequest\x1a>.within.website.x.iam.sts.v1.AssumeRoleWithWebIdentityResponseB\xf6\x01\n" +
 	"\x1fcom.within.website.x.iam.sts.v1B\bStsProtoP\x01Z6within.website/x/gen/within/website/x/iam/sts/v1;stsv1\xa2\x02\x05WWXIS\xaa\x02\x1bWithin.Website.X.Iam.Sts.V1\xca\x02\x1bWithin\\Website\\X\\Iam\\Sts\\V1\xe2\x02'Within\\Website\\X\\Iam\\Sts\\V1\\GPBMetadata\xea\x02 Within::Website::X::Iam::Sts::V1b\x06proto3"
 
 var (
@@ -408,30 +665,40 @@ func file_within_website_x_iam_sts_v1_sts_proto_rawDescGZIP() []byte {
 	return file_within_website_x_iam_sts_v1_sts_proto_rawDescData
 }
 
-var file_within_website_x_iam_sts_v1_sts_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_within_website_x_iam_sts_v1_sts_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_within_website_x_iam_sts_v1_sts_proto_goTypes = []any{
-	(*GetSigningKeyRequest)(nil),  // 0: within.website.x.iam.sts.v1.GetSigningKeyRequest
-	(*GetSigningKeyResponse)(nil), // 1: within.website.x.iam.sts.v1.GetSigningKeyResponse
-	(*GetPublicKeyRequest)(nil),   // 2: within.website.x.iam.sts.v1.GetPublicKeyRequest
-	(*GetPublicKeyResponse)(nil),  // 3: within.website.x.iam.sts.v1.GetPublicKeyResponse
-	(*TokenIdentity)(nil),         // 4: within.website.x.iam.sts.v1.TokenIdentity
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*GetSigningKeyRequest)(nil),              // 0: within.website.x.iam.sts.v1.GetSigningKeyRequest
+	(*GetSigningKeyResponse)(nil),             // 1: within.website.x.iam.sts.v1.GetSigningKeyResponse
+	(*GetPublicKeyRequest)(nil),               // 2: within.website.x.iam.sts.v1.GetPublicKeyRequest
+	(*GetPublicKeyResponse)(nil),              // 3: within.website.x.iam.sts.v1.GetPublicKeyResponse
+	(*TokenIdentity)(nil),                     // 4: within.website.x.iam.sts.v1.TokenIdentity
+	(*GetLoginConfigRequest)(nil),             // 5: within.website.x.iam.sts.v1.GetLoginConfigRequest
+	(*GetLoginConfigResponse)(nil),            // 6: within.website.x.iam.sts.v1.GetLoginConfigResponse
+	(*AssumeRoleWithWebIdentityRequest)(nil),  // 7: within.website.x.iam.sts.v1.AssumeRoleWithWebIdentityRequest
+	(*AssumeRoleWithWebIdentityResponse)(nil), // 8: within.website.x.iam.sts.v1.AssumeRoleWithWebIdentityResponse
+	(*timestamppb.Timestamp)(nil),             // 9: google.protobuf.Timestamp
 }
 var file_within_website_x_iam_sts_v1_sts_proto_depIdxs = []int32{
-	4, // 0: within.website.x.iam.sts.v1.GetSigningKeyResponse.identity:type_name -> within.website.x.iam.sts.v1.TokenIdentity
-	5, // 1: within.website.x.iam.sts.v1.GetSigningKeyResponse.not_valid_after:type_name -> google.protobuf.Timestamp
-	5, // 2: within.website.x.iam.sts.v1.GetSigningKeyResponse.cache_until:type_name -> google.protobuf.Timestamp
-	4, // 3: within.website.x.iam.sts.v1.GetPublicKeyResponse.identity:type_name -> within.website.x.iam.sts.v1.TokenIdentity
-	5, // 4: within.website.x.iam.sts.v1.GetPublicKeyResponse.cache_until:type_name -> google.protobuf.Timestamp
-	0, // 5: within.website.x.iam.sts.v1.SigningKeyService.GetSigningKey:input_type -> within.website.x.iam.sts.v1.GetSigningKeyRequest
-	2, // 6: within.website.x.iam.sts.v1.SigningKeyService.GetPublicKey:input_type -> within.website.x.iam.sts.v1.GetPublicKeyRequest
-	1, // 7: within.website.x.iam.sts.v1.SigningKeyService.GetSigningKey:output_type -> within.website.x.iam.sts.v1.GetSigningKeyResponse
-	3, // 8: within.website.x.iam.sts.v1.SigningKeyService.GetPublicKey:output_type -> within.website.x.iam.sts.v1.GetPublicKeyResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	4,  // 0: within.website.x.iam.sts.v1.GetSigningKeyResponse.identity:type_name -> within.website.x.iam.sts.v1.TokenIdentity
+	9,  // 1: within.website.x.iam.sts.v1.GetSigningKeyResponse.not_valid_after:type_name -> google.protobuf.Timestamp
+	9,  // 2: within.website.x.iam.sts.v1.GetSigningKeyResponse.cache_until:type_name -> google.protobuf.Timestamp
+	4,  // 3: within.website.x.iam.sts.v1.GetPublicKeyResponse.identity:type_name -> within.website.x.iam.sts.v1.TokenIdentity
+	9,  // 4: within.website.x.iam.sts.v1.GetPublicKeyResponse.cache_until:type_name -> google.protobuf.Timestamp
+	9,  // 5: within.website.x.iam.sts.v1.AssumeRoleWithWebIdentityResponse.expiration:type_name -> google.protobuf.Timestamp
+	4,  // 6: within.website.x.iam.sts.v1.AssumeRoleWithWebIdentityResponse.identity:type_name -> within.website.x.iam.sts.v1.TokenIdentity
+	0,  // 7: within.website.x.iam.sts.v1.SigningKeyService.GetSigningKey:input_type -> within.website.x.iam.sts.v1.GetSigningKeyRequest
+	2,  // 8: within.website.x.iam.sts.v1.SigningKeyService.GetPublicKey:input_type -> within.website.x.iam.sts.v1.GetPublicKeyRequest
+	5,  // 9: within.website.x.iam.sts.v1.WebIdentityService.GetLoginConfig:input_type -> within.website.x.iam.sts.v1.GetLoginConfigRequest
+	7,  // 10: within.website.x.iam.sts.v1.WebIdentityService.AssumeRoleWithWebIdentity:input_type -> within.website.x.iam.sts.v1.AssumeRoleWithWebIdentityRequest
+	1,  // 11: within.website.x.iam.sts.v1.SigningKeyService.GetSigningKey:output_type -> within.website.x.iam.sts.v1.GetSigningKeyResponse
+	3,  // 12: within.website.x.iam.sts.v1.SigningKeyService.GetPublicKey:output_type -> within.website.x.iam.sts.v1.GetPublicKeyResponse
+	6,  // 13: within.website.x.iam.sts.v1.WebIdentityService.GetLoginConfig:output_type -> within.website.x.iam.sts.v1.GetLoginConfigResponse
+	8,  // 14: within.website.x.iam.sts.v1.WebIdentityService.AssumeRoleWithWebIdentity:output_type -> within.website.x.iam.sts.v1.AssumeRoleWithWebIdentityResponse
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_within_website_x_iam_sts_v1_sts_proto_init() }
@@ -445,9 +712,9 @@ func file_within_website_x_iam_sts_v1_sts_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_within_website_x_iam_sts_v1_sts_proto_rawDesc), len(file_within_website_x_iam_sts_v1_sts_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   9,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_within_website_x_iam_sts_v1_sts_proto_goTypes,
 		DependencyIndexes: file_within_website_x_iam_sts_v1_sts_proto_depIdxs,
@@ -843,6 +843,798 @@ func (s *signingKeyServiceServer) PathPrefix() string {
 	return baseServicePath(s.pathPrefix, "within.website.x.iam.sts.v1", "SigningKeyService")
 }
 
+// ============================
+// WebIdentityService Interface
+// ============================
+
+// WebIdentityService federates an external OpenID Connect identity provider
+// into iamd: a caller that holds an ID token from the configured issuer can
+// trade it for a short-lived iamd credential, the same way AWS STS's
+// AssumeRoleWithWebIdentity trades a web identity token for temporary keys.
+//
+// Unlike every other iamd route these RPCs are not request-signed — the
+// caller has no credential yet. The ID token is the proof of identity.
+type WebIdentityService interface {
+	// GetLoginConfig returns what a client needs to run the OIDC flow against
+	// the issuer iamd trusts, so `iam login` only needs the iamd endpoint.
+	GetLoginConfig(context.Context, *GetLoginConfigRequest) (*GetLoginConfigResponse, error)
+
+	// AssumeRoleWithWebIdentity verifies an ID token issued by the configured
+	// issuer for the configured client, maps its subject to an iamd user
+	// (creating the user on first login), and mints a key that expires at
+	// the returned expiration.
+	//
+	// Errors:
+	//   UNAUTHENTICATED   - the ID token does not verify
+	//   PERMISSION_DENIED - the token lacks the required group, or the mapped
+	//                       user is disabled
+	//   INVALID_ARGUMENT  - missing id_token or out-of-range duration
+	AssumeRoleWithWebIdentity(context.Context, *AssumeRoleWithWebIdentityRequest) (*AssumeRoleWithWebIdentityResponse, error)
+}
+
+// ==================================
+// WebIdentityService Protobuf Client
+// ==================================
+
+type webIdentityServiceProtobufClient struct {
+	client      HTTPClient
+	urls        [2]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewWebIdentityServiceProtobufClient creates a Protobuf client that implements the WebIdentityService interface.
+// It communicates using Protobuf and can be configured with a custom HTTPClient.
+func NewWebIdentityServiceProtobufClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) WebIdentityService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "within.website.x.iam.sts.v1", "WebIdentityService")
+	urls := [2]string{
+		serviceURL + "GetLoginConfig",
+		serviceURL + "AssumeRoleWithWebIdentity",
+	}
+
+	return &webIdentityServiceProtobufClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *webIdentityServiceProtobufClient) GetLoginConfig(ctx context.Context, in *GetLoginConfigRequest) (*GetLoginConfigResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.iam.sts.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "WebIdentityService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetLoginConfig")
+	caller := c.callGetLoginConfig
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetLoginConfigRequest) (*GetLoginConfigResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetLoginConfigRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetLoginConfigRequest) when calling interceptor")
+					}
+					return c.callGetLoginConfig(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetLoginConfigResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetLoginConfigResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webIdentityServiceProtobufClient) callGetLoginConfig(ctx context.Context, in *GetLoginConfigRequest) (*GetLoginConfigResponse, error) {
+	out := new(GetLoginConfigResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *webIdentityServiceProtobufClient) AssumeRoleWithWebIdentity(ctx context.Context, in *AssumeRoleWithWebIdentityRequest) (*AssumeRoleWithWebIdentityResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.iam.sts.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "WebIdentityService")
+	ctx = ctxsetters.WithMethodName(ctx, "AssumeRoleWithWebIdentity")
+	caller := c.callAssumeRoleWithWebIdentity
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *AssumeRoleWithWebIdentityRequest) (*AssumeRoleWithWebIdentityResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AssumeRoleWithWebIdentityRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AssumeRoleWithWebIdentityRequest) when calling interceptor")
+					}
+					return c.callAssumeRoleWithWebIdentity(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AssumeRoleWithWebIdentityResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AssumeRoleWithWebIdentityResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webIdentityServiceProtobufClient) callAssumeRoleWithWebIdentity(ctx context.Context, in *AssumeRoleWithWebIdentityRequest) (*AssumeRoleWithWebIdentityResponse, error) {
+	out := new(AssumeRoleWithWebIdentityResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ==============================
+// WebIdentityService JSON Client
+// ==============================
+
+type webIdentityServiceJSONClient struct {
+	client      HTTPClient
+	urls        [2]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewWebIdentityServiceJSONClient creates a JSON client that implements the WebIdentityService interface.
+// It communicates using JSON and can be configured with a custom HTTPClient.
+func NewWebIdentityServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) WebIdentityService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "within.website.x.iam.sts.v1", "WebIdentityService")
+	urls := [2]string{
+		serviceURL + "GetLoginConfig",
+		serviceURL + "AssumeRoleWithWebIdentity",
+	}
+
+	return &webIdentityServiceJSONClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *webIdentityServiceJSONClient) GetLoginConfig(ctx context.Context, in *GetLoginConfigRequest) (*GetLoginConfigResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.iam.sts.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "WebIdentityService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetLoginConfig")
+	caller := c.callGetLoginConfig
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetLoginConfigRequest) (*GetLoginConfigResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetLoginConfigRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetLoginConfigRequest) when calling interceptor")
+					}
+					return c.callGetLoginConfig(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetLoginConfigResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetLoginConfigResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webIdentityServiceJSONClient) callGetLoginConfig(ctx context.Context, in *GetLoginConfigRequest) (*GetLoginConfigResponse, error) {
+	out := new(GetLoginConfigResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *webIdentityServiceJSONClient) AssumeRoleWithWebIdentity(ctx context.Context, in *AssumeRoleWithWebIdentityRequest) (*AssumeRoleWithWebIdentityResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.iam.sts.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "WebIdentityService")
+	ctx = ctxsetters.WithMethodName(ctx, "AssumeRoleWithWebIdentity")
+	caller := c.callAssumeRoleWithWebIdentity
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *AssumeRoleWithWebIdentityRequest) (*AssumeRoleWithWebIdentityResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AssumeRoleWithWebIdentityRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AssumeRoleWithWebIdentityRequest) when calling interceptor")
+					}
+					return c.callAssumeRoleWithWebIdentity(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AssumeRoleWithWebIdentityResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AssumeRoleWithWebIdentityResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webIdentityServiceJSONClient) callAssumeRoleWithWebIdentity(ctx context.Context, in *AssumeRoleWithWebIdentityRequest) (*AssumeRoleWithWebIdentityResponse, error) {
+	out := new(AssumeRoleWithWebIdentityResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// =================================
+// WebIdentityService Server Handler
+// =================================
+
+type webIdentityServiceServer struct {
+	WebIdentityService
+	interceptor      twirp.Interceptor
+	hooks            *twirp.ServerHooks
+	pathPrefix       string // prefix for routing
+	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
+	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
+}
+
+// NewWebIdentityServiceServer builds a TwirpServer that can be used as an http.Handler to handle
+// HTTP requests that are routed to the right method in the provided svc implementation.
+// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
+func NewWebIdentityServiceServer(svc WebIdentityService, opts ...interface{}) TwirpServer {
+	serverOpts := newServerOpts(opts)
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	jsonSkipDefaults := false
+	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
+	jsonCamelCase := false
+	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
+	var pathPrefix string
+	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	return &webIdentityServiceServer{
+		WebIdentityService: svc,
+		hooks:              serverOpts.Hooks,
+		interceptor:        twirp.ChainInterceptors(serverOpts.Interceptors...),
+		pathPrefix:         pathPrefix,
+		jsonSkipDefaults:   jsonSkipDefaults,
+		jsonCamelCase:      jsonCamelCase,
+	}
+}
+
+// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func (s *webIdentityServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+	writeError(ctx, resp, err, s.hooks)
+}
+
+// handleRequestBodyError is used to handle error when the twirp server cannot read request
+func (s *webIdentityServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
+	if context.Canceled == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
+		return
+	}
+	if context.DeadlineExceeded == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
+		return
+	}
+	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
+}
+
+// WebIdentityServicePathPrefix is a convenience constant that may identify URL paths.
+// Should be used with caution, it only matches routes generated by Twirp Go clients,
+// with the default "/twirp" prefix and default CamelCase service and method names.
+// More info: https://twitchtv.github.io/twirp/docs/routing.html
+const WebIdentityServicePathPrefix = "/twirp/within.website.x.iam.sts.v1.WebIdentityService/"
+
+func (s *webIdentityServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.iam.sts.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "WebIdentityService")
+	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+
+	var err error
+	ctx, err = callRequestReceived(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	if req.Method != "POST" {
+		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
+	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
+	if pkgService != "within.website.x.iam.sts.v1.WebIdentityService" {
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+	if prefix != s.pathPrefix {
+		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	switch method {
+	case "GetLoginConfig":
+		s.serveGetLoginConfig(ctx, resp, req)
+		return
+	case "AssumeRoleWithWebIdentity":
+		s.serveAssumeRoleWithWebIdentity(ctx, resp, req)
+		return
+	default:
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+}
+
+func (s *webIdentityServiceServer) serveGetLoginConfig(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetLoginConfigJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetLoginConfigProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *webIdentityServiceServer) serveGetLoginConfigJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetLoginConfig")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetLoginConfigRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.WebIdentityService.GetLoginConfig
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetLoginConfigRequest) (*GetLoginConfigResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetLoginConfigRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetLoginConfigRequest) when calling interceptor")
+					}
+					return s.WebIdentityService.GetLoginConfig(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetLoginConfigResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetLoginConfigResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetLoginConfigResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetLoginConfigResponse and nil error while calling GetLoginConfig. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webIdentityServiceServer) serveGetLoginConfigProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetLoginConfig")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetLoginConfigRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.WebIdentityService.GetLoginConfig
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetLoginConfigRequest) (*GetLoginConfigResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetLoginConfigRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetLoginConfigRequest) when calling interceptor")
+					}
+					return s.WebIdentityService.GetLoginConfig(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetLoginConfigResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetLoginConfigResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetLoginConfigResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetLoginConfigResponse and nil error while calling GetLoginConfig. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webIdentityServiceServer) serveAssumeRoleWithWebIdentity(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveAssumeRoleWithWebIdentityJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveAssumeRoleWithWebIdentityProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *webIdentityServiceServer) serveAssumeRoleWithWebIdentityJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "AssumeRoleWithWebIdentity")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(AssumeRoleWithWebIdentityRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.WebIdentityService.AssumeRoleWithWebIdentity
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *AssumeRoleWithWebIdentityRequest) (*AssumeRoleWithWebIdentityResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AssumeRoleWithWebIdentityRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AssumeRoleWithWebIdentityRequest) when calling interceptor")
+					}
+					return s.WebIdentityService.AssumeRoleWithWebIdentity(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AssumeRoleWithWebIdentityResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AssumeRoleWithWebIdentityResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *AssumeRoleWithWebIdentityResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AssumeRoleWithWebIdentityResponse and nil error while calling AssumeRoleWithWebIdentity. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webIdentityServiceServer) serveAssumeRoleWithWebIdentityProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "AssumeRoleWithWebIdentity")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(AssumeRoleWithWebIdentityRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.WebIdentityService.AssumeRoleWithWebIdentity
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *AssumeRoleWithWebIdentityRequest) (*AssumeRoleWithWebIdentityResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AssumeRoleWithWebIdentityRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AssumeRoleWithWebIdentityRequest) when calling interceptor")
+					}
+					return s.WebIdentityService.AssumeRoleWithWebIdentity(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AssumeRoleWithWebIdentityResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AssumeRoleWithWebIdentityResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *AssumeRoleWithWebIdentityResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AssumeRoleWithWebIdentityResponse and nil error while calling AssumeRoleWithWebIdentity. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webIdentityServiceServer) ServiceDescriptor() ([]byte, int) {
+	return twirpFileDescriptor0, 1
+}
+
+func (s *webIdentityServiceServer) ProtocGenTwirpVersion() string {
+	return "v8.1.3"
+}
+
+// PathPrefix returns the base service path, in the form: "/<prefix>/<package>.<Service>/"
+// that is everything in a Twirp route except for the <Method>. This can be used for routing,
+// for example to identify the requests that are targeted to this service in a mux.
+func (s *webIdentityServiceServer) PathPrefix() string {
+	return baseServicePath(s.pathPrefix, "within.website.x.iam.sts.v1", "WebIdentityService")
+}
+
 // =====
 // Utils
 // =====
@@ -1409,47 +2201,62 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 659 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0x86, 0x65, 0xa7, 0x94, 0x76, 0x93, 0x50, 0x75, 0x29, 0x52, 0x94, 0x0a, 0x5a, 0x22, 0x50,
-	0xab, 0x4a, 0xac, 0xeb, 0x20, 0x21, 0x70, 0x4f, 0xcd, 0x81, 0xd6, 0xaa, 0x40, 0x91, 0x5d, 0x92,
-	0x08, 0x02, 0xd1, 0xc6, 0xde, 0xba, 0xab, 0xc6, 0x6b, 0xe3, 0xdd, 0xa4, 0x0d, 0x08, 0x09, 0x5e,
-	0xa5, 0x47, 0x0e, 0x3c, 0x40, 0x9f, 0xa0, 0xe2, 0x31, 0x38, 0xf2, 0x00, 0x9c, 0x91, 0xbd, 0x4e,
-	0x48, 0xaa, 0xca, 0x28, 0x88, 0x53, 0xe2, 0x99, 0xef, 0x9f, 0xdd, 0xf9, 0x67, 0x6c, 0xf0, 0xf0,
-	0x94, 0x8a, 0x63, 0xca, 0xb4, 0x53, 0xd2, 0xe5, 0x54, 0x10, 0xed, 0x4c, 0xa3, 0xd8, 0xd7, 0xb8,
-	0xe0, 0xda, 0x40, 0x8f, 0x7f, 0x50, 0x18, 0x05, 0x22, 0x80, 0xab, 0x12, 0x43, 0x29, 0x86, 0xce,
-	0x10, 0xc5, 0x3e, 0x8a, 0xf3, 0x03, 0xbd, 0xbc, 0xda, 0xed, 0x1f, 0x69, 0x03, 0xdc, 0xa3, 0x2e,
-	0x16, 0x64, 0xfc, 0x47, 0x2a, 0xcb, 0x6b, 0x5e, 0x10, 0x78, 0x3d, 0xa2, 0x25, 0x4f, 0x31, 0x28,
-	0xa8, 0x4f, 0xb8, 0xc0, 0x7e, 0x28, 0x81, 0xca, 0x37, 0x05, 0xac, 0xec, 0x11, 0x61, 0x53, 0x8f,
-	0x51, 0xe6, 0x1d, 0x90, 0xa1, 0x45, 0xde, 0xf7, 0x09, 0x17, 0x70, 0x0b, 0x14, 0xb1, 0xe3, 0x10,
-	0xce, 0x3b, 0x27, 0x64, 0xd8, 0xa1, 0x6e, 0x49, 0x59, 0x57, 0x36, 0x17, 0x6b, 0xf3, 0x17, 0xfb,
-	0xb9, 0x4b, 0x45, 0xb1, 0xf2, 0x32, 0x79, 0x40, 0x86, 0xa6, 0x0b, 0x37, 0xc1, 0x5c, 0x7c, 0x66,
-	0x49, 0x4d, 0x90, 0x95, 0x8b, 0xfd, 0xe5, 0x4b, 0x45, 0x89, 0x0a, 0x55, 0xf0, 0xee, 0xcd, 0xf6,
-	0xa3, 0x67, 0x6f, 0x3f, 0x3e, 0xfd, 0xf4, 0xc0, 0x4a, 0x08, 0x78, 0x0f, 0xcc, 0x47, 0xc4, 0xa3,
-	0x01, 0x2b, 0xe5, 0xa6, 0xca, 0xa5, 0x51, 0xb8, 0x0e, 0x6e, 0x72, 0x12, 0x0d, 0xa8, 0x43, 0x4a,
-	0x73, 0x53, 0xc0, 0x28, 0x5c, 0xf9, 0xa2, 0x82, 0x3b, 0x57, 0x2e, 0xcc, 0xc3, 0x80, 0x71, 0x02,
-	0xd7, 0x40, 0x9e, 0xcb, 0x68, 0x7c, 0xe5, 0xe4, 0xbe, 0x05, 0x0b, 0xf0, 0x31, 0x08, 0x9f, 0x83,
-	0x05, 0xea, 0x12, 0x26, 0xa8, 0x18, 0x26, 0x57, 0xcd, 0x57, 0xb7, 0x50, 0x86, 0xb3, 0xe8, 0x30,
-	0x38, 0x21, 0xcc, 0x4c, 0x15, 0xd6, 0x58, 0x0b, 0x6b, 0x60, 0x89, 0x05, 0xa2, 0x93, 0x58, 0xdd,
-	0xc1, 0x47, 0x82, 0x44, 0x49, 0x37, 0xf9, 0x6a, 0x19, 0x49, 0xbb, 0xd1, 0xc8, 0x6e, 0x74, 0x38,
-	0xb2, 0xdb, 0x2a, 0xb2, 0x40, 0x34, 0x62, 0xc5, 0x6e, 0x2c, 0x80, 0x3b, 0x20, 0xef, 0x60, 0xe7,
-	0x98, 0x74, 0xfa, 0x4c, 0xd0, 0x5e, 0xd2, 0x6c, 0xb6, 0x1e, 0x24, 0xf8, 0xab, 0x98, 0xae, 0xec,
-	0x82, 0xdb, 0x7b, 0x44, 0xd4, 0xfb, 0xdd, 0x1e, 0x75, 0xfe, 0x6d, 0x64, 0x95, 0x0b, 0x39, 0xf7,
-	0x89, 0x1a, 0xa9, 0x8b, 0x77, 0x01, 0x08, 0x93, 0xe0, 0x84, 0x89, 0x8b, 0xe1, 0x08, 0xfb, 0x6f,
-	0x1e, 0x5e, 0xe9, 0x3f, 0x37, 0x53, 0xff, 0xe7, 0x0a, 0x28, 0x4e, 0x15, 0x86, 0x95, 0x6b, 0x5b,
-	0x9f, 0xde, 0xd2, 0x0d, 0xb0, 0x14, 0x44, 0x1e, 0x66, 0xf4, 0x03, 0x16, 0x34, 0x60, 0x31, 0x95,
-	0x2c, 0xac, 0x75, 0x6b, 0x32, 0x6c, 0xba, 0xf0, 0x3e, 0x28, 0x84, 0x11, 0x65, 0x0e, 0x0d, 0x71,
-	0x2f, 0xa6, 0x72, 0xb2, 0xd6, 0x38, 0x26, 0x11, 0x97, 0xf2, 0xb0, 0x87, 0x87, 0x1d, 0x86, 0xfd,
-	0x74, 0x59, 0xad, 0x7c, 0x1a, 0x7b, 0x89, 0x7d, 0x52, 0xfd, 0xac, 0x82, 0xe5, 0x3f, 0x5b, 0x6a,
-	0xcb, 0xf5, 0x85, 0x03, 0x50, 0x9c, 0xda, 0x5e, 0xa8, 0x67, 0xda, 0x77, 0xdd, 0xab, 0x59, 0xae,
-	0xce, 0x22, 0x49, 0xc7, 0xca, 0x41, 0x61, 0x72, 0xdc, 0x70, 0xfb, 0x6f, 0x35, 0xae, 0x6e, 0x57,
-	0x59, 0x9f, 0x41, 0x21, 0x0f, 0xad, 0xfd, 0x52, 0xc0, 0x9a, 0x13, 0xf8, 0x59, 0xc2, 0xda, 0x82,
-	0x2d, 0x78, 0x3d, 0x1e, 0x77, 0x5d, 0x79, 0xfd, 0x64, 0x1a, 0xd4, 0xce, 0x34, 0x8f, 0x30, 0x2d,
-	0xe3, 0x1b, 0xb9, 0xc3, 0x05, 0x1f, 0xe8, 0xe7, 0xea, 0x8d, 0x66, 0xb3, 0x65, 0xda, 0x5f, 0xd5,
-	0xd5, 0xa6, 0x2c, 0xd0, 0x4c, 0x4f, 0x6a, 0x21, 0x13, 0xfb, 0xc8, 0x16, 0x1c, 0x35, 0xf4, 0xef,
-	0xa3, 0x6c, 0x3b, 0xcd, 0xb6, 0x5b, 0x6d, 0x13, 0xfb, 0x6d, 0x5b, 0xf0, 0x76, 0x43, 0xff, 0xa1,
-	0x6e, 0x64, 0x64, 0xdb, 0x7b, 0xf5, 0xda, 0x0b, 0x22, 0xb0, 0x8b, 0x05, 0xfe, 0xa9, 0xae, 0x4b,
-	0xd2, 0x30, 0x52, 0xd4, 0x30, 0x5a, 0x86, 0x61, 0x62, 0xdf, 0x30, 0x6c, 0xc1, 0x0d, 0xa3, 0xa1,
-	0x77, 0xe7, 0x93, 0x05, 0x7e, 0xfc, 0x3b, 0x00, 0x00, 0xff, 0xff, 0xd5, 0x72, 0xef, 0xe5, 0xe0,
-	0x05, 0x00, 0x00,
+	// 905 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xd1, 0x6e, 0x1b, 0x45,
+	0x17, 0xfe, 0x77, 0xed, 0x26, 0xce, 0x71, 0xd2, 0xfc, 0x19, 0x4a, 0x71, 0x1d, 0x41, 0x9c, 0x15,
+	0xa8, 0x51, 0x24, 0x76, 0x6b, 0x57, 0x42, 0xb0, 0x15, 0x48, 0x36, 0x12, 0x89, 0x55, 0x40, 0xd1,
+	0xba, 0xc4, 0x11, 0x18, 0xac, 0xf1, 0xee, 0x89, 0x33, 0xaa, 0x77, 0x76, 0xd9, 0x19, 0xbb, 0x31,
+	0x08, 0x09, 0x5e, 0x81, 0x4b, 0x2e, 0x7b, 0x89, 0x10, 0x0f, 0x90, 0x27, 0xa8, 0x78, 0x0c, 0x2e,
+	0x79, 0x00, 0xae, 0xd1, 0xee, 0x8c, 0x1d, 0xdb, 0x14, 0xa7, 0x29, 0x5c, 0xd9, 0x73, 0xce, 0xf7,
+	0x9d, 0x39, 0xf3, 0x9d, 0x6f, 0x46, 0x0b, 0x6f, 0x3d, 0x61, 0xf2, 0x8c, 0x71, 0xe7, 0x09, 0xf6,
+	0x04, 0x93, 0xe8, 0x9c, 0x3b, 0x8c, 0x86, 0x8e, 0x90, 0xc2, 0x19, 0x55, 0xd3, 0x1f, 0x3b, 0x4e,
+	0x22, 0x19, 0x91, 0x6d, 0x05, 0xb3, 0x35, 0xcc, 0x3e, 0xb7, 0x19, 0x0d, 0xed, 0x34, 0x3f, 0xaa,
+	0x96, 0xb7, 0x7b, 0xc3, 0x53, 0x67, 0x44, 0x07, 0x2c, 0xa0, 0x12, 0xa7, 0x7f, 0x14, 0xb3, 0xbc,
+	0xd3, 0x8f, 0xa2, 0xfe, 0x00, 0x9d, 0x6c, 0x95, 0x02, 0x25, 0x0b, 0x51, 0x48, 0x1a, 0xc6, 0x0a,
+	0x60, 0xfd, 0x6a, 0xc0, 0xad, 0x03, 0x94, 0x2d, 0xd6, 0xe7, 0x8c, 0xf7, 0x1f, 0xe2, 0xd8, 0xc3,
+	0xaf, 0x87, 0x28, 0x24, 0xd9, 0x87, 0x0d, 0xea, 0xfb, 0x28, 0x44, 0xf7, 0x31, 0x8e, 0xbb, 0x2c,
+	0x28, 0x19, 0x15, 0x63, 0x6f, 0xad, 0xb1, 0x72, 0x71, 0x98, 0x7b, 0x66, 0x18, 0x5e, 0x51, 0x25,
+	0x1f, 0xe2, 0xb8, 0x19, 0x90, 0x3d, 0xc8, 0xa7, 0x7b, 0x96, 0xcc, 0x0c, 0x72, 0xeb, 0xe2, 0x70,
+	0xeb, 0x99, 0x61, 0x24, 0xeb, 0x35, 0xf8, 0xea, 0x8b, 0x7b, 0x6f, 0xbf, 0xf7, 0xe5, 0xb7, 0xef,
+	0x7e, 0xf7, 0xa6, 0x97, 0x21, 0xc8, 0x1b, 0xb0, 0x92, 0x60, 0x9f, 0x45, 0xbc, 0x94, 0x9b, 0x2b,
+	0xa7, 0xa3, 0xa4, 0x02, 0xab, 0x02, 0x93, 0x11, 0xf3, 0xb1, 0x94, 0x9f, 0x03, 0x4c, 0xc2, 0xd6,
+	0x0f, 0x26, 0xbc, 0xba, 0xd0, 0xb0, 0x88, 0x23, 0x2e, 0x90, 0xec, 0x40, 0x51, 0xa8, 0x68, 0xda,
+	0x72, 0xd6, 0xef, 0xba, 0x07, 0x62, 0x0a, 0x24, 0x1f, 0x41, 0x81, 0x05, 0xc8, 0x25, 0x93, 0xe3,
+	0xac, 0xd5, 0x62, 0x6d, 0xdf, 0x5e, 0xa2, 0xac, 0xfd, 0x28, 0x7a, 0x8c, 0xbc, 0xa9, 0x19, 0xde,
+	0x94, 0x4b, 0x1a, 0xb0, 0xc9, 0x23, 0xd9, 0xcd, 0xa4, 0xee, 0xd2, 0x53, 0x89, 0x49, 0x76, 0x9a,
+	0x62, 0xad, 0x6c, 0x2b, 0xb9, 0xed, 0x89, 0xdc, 0xf6, 0xa3, 0x89, 0xdc, 0xde, 0x06, 0x8f, 0xe4,
+	0x71, 0xca, 0xa8, 0xa7, 0x04, 0xf2, 0x00, 0x8a, 0x3e, 0xf5, 0xcf, 0xb0, 0x3b, 0xe4, 0x92, 0x0d,
+	0x4a, 0xf9, 0x2b, 0xf9, 0x90, 0xc1, 0x3f, 0x4b, 0xd1, 0x56, 0x1d, 0x5e, 0x39, 0x40, 0x79, 0x34,
+	0xec, 0x0d, 0x98, 0xff, 0x72, 0x23, 0xb3, 0x2e, 0xd4, 0xdc, 0x67, 0x6a, 0x68, 0x15, 0x5f, 0x07,
+	0x88, 0xb3, 0xe0, 0x8c, 0x88, 0x6b, 0xf1, 0x04, 0xf6, 0x9f, 0x69, 0xb8, 0x70, 0xfe, 0xdc, 0xb5,
+	0xce, 0xff, 0xd4, 0x80, 0x8d, 0xb9, 0xc2, 0xc4, 0x7a, 0xee, 0xd1, 0xe7, 0x5d, 0x7a, 0x17, 0x36,
+	0xa3, 0xa4, 0x4f, 0x39, 0xfb, 0x86, 0x4a, 0x16, 0xf1, 0x14, 0x95, 0x19, 0xd6, 0xbb, 0x39, 0x1b,
+	0x6e, 0x06, 0x64, 0x17, 0xd6, 0xe3, 0x84, 0x71, 0x9f, 0xc5, 0x74, 0x90, 0xa2, 0x72, 0xaa, 0xd6,
+	0x34, 0xa6, 0x20, 0x01, 0x13, 0xf1, 0x80, 0x8e, 0xbb, 0x9c, 0x86, 0xda, 0xac, 0x5e, 0x51, 0xc7,
+	0x3e, 0xa5, 0x21, 0x5a, 0xaf, 0x65, 0x3e, 0xfd, 0x38, 0xea, 0x33, 0xfe, 0x61, 0xc4, 0x4f, 0x59,
+	0x5f, 0x8f, 0xc9, 0x42, 0xb8, 0xbd, 0x98, 0xd0, 0xda, 0xdf, 0x86, 0x15, 0x26, 0xc4, 0x10, 0x13,
+	0xdd, 0xbe, 0x5e, 0x91, 0x6d, 0x58, 0xf3, 0x07, 0x0c, 0xb9, 0xbc, 0xec, 0xb9, 0xa0, 0x02, 0xcd,
+	0x20, 0x25, 0x09, 0x3f, 0x8a, 0x51, 0x94, 0x72, 0x95, 0x5c, 0x4a, 0x52, 0x2b, 0x6b, 0x0c, 0x95,
+	0xba, 0x10, 0xc3, 0x10, 0xbd, 0x68, 0x80, 0x6d, 0x26, 0xcf, 0xda, 0xd8, 0x9b, 0x0e, 0x42, 0x3b,
+	0x66, 0x37, 0x9d, 0x66, 0x57, 0xa6, 0x52, 0x2e, 0x98, 0x65, 0x95, 0x05, 0x99, 0xc2, 0xa4, 0x06,
+	0xff, 0x0f, 0x86, 0x89, 0x52, 0x4c, 0xa0, 0x1f, 0xf1, 0x40, 0x64, 0x2d, 0xe4, 0x1a, 0xab, 0x17,
+	0x87, 0x79, 0xcb, 0xdc, 0xfb, 0x9f, 0xb7, 0x39, 0x01, 0xb4, 0x54, 0xde, 0xfa, 0xd1, 0x84, 0xdd,
+	0x25, 0x7b, 0xeb, 0xd3, 0xbe, 0xc8, 0xcc, 0xf6, 0x61, 0x4b, 0xa0, 0x9f, 0xa0, 0xec, 0x5e, 0x42,
+	0xb5, 0x02, 0x9b, 0x2a, 0x51, 0x9f, 0xa0, 0x89, 0x0b, 0x80, 0xe7, 0x31, 0x53, 0xad, 0xbc, 0x88,
+	0xa3, 0x2e, 0xd1, 0x73, 0xb6, 0xce, 0xff, 0x0b, 0x5b, 0xdf, 0x81, 0x02, 0x13, 0x5d, 0x1a, 0x84,
+	0x8c, 0x97, 0x6e, 0x54, 0x8c, 0xbd, 0x82, 0xb7, 0xca, 0x44, 0x3d, 0x5d, 0xd6, 0xbe, 0x37, 0x61,
+	0xeb, 0xf2, 0xd5, 0x6a, 0xa9, 0xe7, 0x8c, 0x8c, 0x60, 0x63, 0xee, 0x35, 0x23, 0xd5, 0xa5, 0xfb,
+	0x3e, 0xef, 0xa9, 0x2e, 0xd7, 0xae, 0x43, 0xd1, 0xe2, 0x0b, 0x58, 0x9f, 0xbd, 0xfe, 0xe4, 0xde,
+	0x55, 0x35, 0x16, 0x5f, 0x9b, 0x72, 0xf5, 0x1a, 0x0c, 0xb5, 0x69, 0xed, 0x17, 0x13, 0xc8, 0x8c,
+	0x13, 0x26, 0x1a, 0x8c, 0xe1, 0xe6, 0xfc, 0x85, 0x20, 0x57, 0x9e, 0xe8, 0xef, 0xd7, 0xaa, 0x7c,
+	0xff, 0x5a, 0x1c, 0x2d, 0xc3, 0x4f, 0x06, 0xdc, 0xf9, 0x47, 0xa7, 0x92, 0xf7, 0x97, 0x96, 0xbc,
+	0xea, 0x76, 0x95, 0x3f, 0x78, 0x59, 0xba, 0x6a, 0xae, 0xf1, 0xa7, 0x01, 0x3b, 0x7e, 0x14, 0x2e,
+	0xab, 0xd2, 0x28, 0xb4, 0xa4, 0x38, 0x4a, 0xbd, 0x7d, 0x64, 0x7c, 0xfe, 0xce, 0x3c, 0xd0, 0x39,
+	0x77, 0xfa, 0xc8, 0x9d, 0x25, 0x9f, 0x18, 0x0f, 0x84, 0x14, 0xa3, 0xea, 0x53, 0xf3, 0x46, 0xbb,
+	0x7d, 0xd2, 0x6c, 0xfd, 0x6c, 0x6e, 0xb7, 0x55, 0x81, 0xb6, 0xde, 0xe9, 0xc4, 0x6e, 0xd2, 0xd0,
+	0x6e, 0x49, 0x61, 0x1f, 0x57, 0x7f, 0x9b, 0x64, 0x3b, 0x3a, 0xdb, 0x39, 0xe9, 0x34, 0x69, 0xd8,
+	0x69, 0x49, 0xd1, 0x39, 0xae, 0xfe, 0x6e, 0xde, 0x5d, 0x92, 0xed, 0x1c, 0x1c, 0x35, 0x3e, 0x41,
+	0x49, 0x03, 0x2a, 0xe9, 0x1f, 0x66, 0x45, 0x21, 0x5d, 0x57, 0x43, 0x5d, 0xf7, 0xc4, 0x75, 0x9b,
+	0x34, 0x74, 0xdd, 0x96, 0x14, 0xae, 0x7b, 0x5c, 0xed, 0xad, 0x64, 0xb7, 0xf5, 0xfe, 0x5f, 0x03,
+	0x00, 0xa0, 0x40, 0x23, 0x29, 0x1f, 0x09, 0x00, 0x00,
 }
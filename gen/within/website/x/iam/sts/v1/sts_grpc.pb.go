@@ -45,12 +45,11 @@ type SigningKeyServiceClient interface {
 	// id and credential scope, plus the identity the key belongs to.
 	//
 	// Errors:
-	//
-	//	NOT_FOUND         - no such access key id
-	//	PERMISSION_DENIED - key or owning user is disabled, or the requested
-	//	                    (region, service, date) scope is outside what this
-	//	                    deployment issues keys for
-	//	INVALID_ARGUMENT  - malformed access_key_id/date/region/service
+	//   NOT_FOUND         - no such access key id
+	//   PERMISSION_DENIED - key or owning user is disabled, or the requested
+	//                       (region, service, date) scope is outside what this
+	//                       deployment issues keys for
+	//   INVALID_ARGUMENT  - malformed access_key_id/date/region/service
 	GetSigningKey(ctx context.Context, in *GetSigningKeyRequest, opts ...grpc.CallOption) (*GetSigningKeyResponse, error)
 	// GetPublicKey returns the SigV4A (ECDSA P-256) public verification key
 	// for an access key id, plus the identity it authenticates. Public keys
@@ -58,10 +57,9 @@ type SigningKeyServiceClient interface {
 	// mint them, unlike the symmetric derived keys from GetSigningKey.
 	//
 	// Errors:
-	//
-	//	NOT_FOUND         - no such access key id
-	//	PERMISSION_DENIED - key or owning user is disabled
-	//	INVALID_ARGUMENT  - missing access_key_id
+	//   NOT_FOUND         - no such access key id
+	//   PERMISSION_DENIED - key or owning user is disabled
+	//   INVALID_ARGUMENT  - missing access_key_id
 	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
 }
 
@@ -114,12 +112,11 @@ type SigningKeyServiceServer interface {
 	// id and credential scope, plus the identity the key belongs to.
 	//
 	// Errors:
-	//
-	//	NOT_FOUND         - no such access key id
-	//	PERMISSION_DENIED - key or owning user is disabled, or the requested
-	//	                    (region, service, date) scope is outside what this
-	//	                    deployment issues keys for
-	//	INVALID_ARGUMENT  - malformed access_key_id/date/region/service
+	//   NOT_FOUND         - no such access key id
+	//   PERMISSION_DENIED - key or owning user is disabled, or the requested
+	//                       (region, service, date) scope is outside what this
+	//                       deployment issues keys for
+	//   INVALID_ARGUMENT  - malformed access_key_id/date/region/service
 	GetSigningKey(context.Context, *GetSigningKeyRequest) (*GetSigningKeyResponse, error)
 	// GetPublicKey returns the SigV4A (ECDSA P-256) public verification key
 	// for an access key id, plus the identity it authenticates. Public keys
@@ -127,10 +124,9 @@ type SigningKeyServiceServer interface {
 	// mint them, unlike the symmetric derived keys from GetSigningKey.
 	//
 	// Errors:
-	//
-	//	NOT_FOUND         - no such access key id
-	//	PERMISSION_DENIED - key or owning user is disabled
-	//	INVALID_ARGUMENT  - missing access_key_id
+	//   NOT_FOUND         - no such access key id
+	//   PERMISSION_DENIED - key or owning user is disabled
+	//   INVALID_ARGUMENT  - missing access_key_id
 	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
 	mustEmbedUnimplementedSigningKeyServiceServer()
 }
@@ -224,3 +220,183 @@ var SigningKeyService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "within/website/x/iam/sts/v1/sts.proto",
 }
+
+const (
+	WebIdentityService_GetLoginConfig_FullMethodName            = "/within.website.x.iam.sts.v1.WebIdentityService/GetLoginConfig"
+	WebIdentityService_AssumeRoleWithWebIdentity_FullMethodName = "/within.website.x.iam.sts.v1.WebIdentityService/AssumeRoleWithWebIdentity"
+)
+
+// WebIdentityServiceClient is the client API for WebIdentityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// WebIdentityService federates an external OpenID Connect identity provider
+// into iamd: a caller that holds an ID token from the configured issuer can
+// trade it for a short-lived iamd credential, the same way AWS STS's
+// AssumeRoleWithWebIdentity trades a web identity token for temporary keys.
+//
+// Unlike every other iamd route these RPCs are not request-signed — the
+// caller has no credential yet. The ID token is the proof of identity.
+type WebIdentityServiceClient interface {
+	// GetLoginConfig returns what a client needs to run the OIDC flow against
+	// the issuer iamd trusts, so `iam login` only needs the iamd endpoint.
+	GetLoginConfig(ctx context.Context, in *GetLoginConfigRequest, opts ...grpc.CallOption) (*GetLoginConfigResponse, error)
+	// AssumeRoleWithWebIdentity verifies an ID token issued by the configured
+	// issuer for the configured client, maps its subject to an iamd user
+	// (creating the user on first login), and mints a key that expires at
+	// the returned expiration.
+	//
+	// Errors:
+	//   UNAUTHENTICATED   - the ID token does not verify
+	//   PERMISSION_DENIED - the token lacks the required group, or the mapped
+	//                       user is disabled
+	//   INVALID_ARGUMENT  - missing id_token or out-of-range duration
+	AssumeRoleWithWebIdentity(ctx context.Context, in *AssumeRoleWithWebIdentityRequest, opts ...grpc.CallOption) (*AssumeRoleWithWebIdentityResponse, error)
+}
+
+type webIdentityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebIdentityServiceClient(cc grpc.ClientConnInterface) WebIdentityServiceClient {
+	return &webIdentityServiceClient{cc}
+}
+
+func (c *webIdentityServiceClient) GetLoginConfig(ctx context.Context, in *GetLoginConfigRequest, opts ...grpc.CallOption) (*GetLoginConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLoginConfigResponse)
+	err := c.cc.Invoke(ctx, WebIdentityService_GetLoginConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webIdentityServiceClient) AssumeRoleWithWebIdentity(ctx context.Context, in *AssumeRoleWithWebIdentityRequest, opts ...grpc.CallOption) (*AssumeRoleWithWebIdentityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssumeRoleWithWebIdentityResponse)
+	err := c.cc.Invoke(ctx, WebIdentityService_AssumeRoleWithWebIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebIdentityServiceServer is the server API for WebIdentityService service.
+// All implementations must embed UnimplementedWebIdentityServiceServer
+// for forward compatibility.
+//
+// WebIdentityService federates an external OpenID Connect identity provider
+// into iamd: a caller that holds an ID token from the configured issuer can
+// trade it for a short-lived iamd credential, the same way AWS STS's
+// AssumeRoleWithWebIdentity trades a web identity token for temporary keys.
+//
+// Unlike every other iamd route these RPCs are not request-signed — the
+// caller has no credential yet. The ID token is the proof of identity.
+type WebIdentityServiceServer interface {
+	// GetLoginConfig returns what a client needs to run the OIDC flow against
+	// the issuer iamd trusts, so `iam login` only needs the iamd endpoint.
+	GetLoginConfig(context.Context, *GetLoginConfigRequest) (*GetLoginConfigResponse, error)
+	// AssumeRoleWithWebIdentity verifies an ID token issued by the configured
+	// issuer for the configured client, maps its subject to an iamd user
+	// (creating the user on first login), and mints a key that expires at
+	// the returned expiration.
+	//
+	// Errors:
+	//   UNAUTHENTICATED   - the ID token does not verify
+	//   PERMISSION_DENIED - the token lacks the required group, or the mapped
+	//                       user is disabled
+	//   INVALID_ARGUMENT  - missing id_token or out-of-range duration
+	AssumeRoleWithWebIdentity(context.Context, *AssumeRoleWithWebIdentityRequest) (*AssumeRoleWithWebIdentityResponse, error)
+	mustEmbedUnimplementedWebIdentityServiceServer()
+}
+
+// UnimplementedWebIdentityServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebIdentityServiceServer struct{}
+
+func (UnimplementedWebIdentityServiceServer) GetLoginConfig(context.Context, *GetLoginConfigRequest) (*GetLoginConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLoginConfig not implemented")
+}
+func (UnimplementedWebIdentityServiceServer) AssumeRoleWithWebIdentity(context.Context, *AssumeRoleWithWebIdentityRequest) (*AssumeRoleWithWebIdentityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AssumeRoleWithWebIdentity not implemented")
+}
+func (UnimplementedWebIdentityServiceServer) mustEmbedUnimplementedWebIdentityServiceServer() {}
+func (UnimplementedWebIdentityServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeWebIdentityServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebIdentityServiceServer will
+// result in compilation errors.
+type UnsafeWebIdentityServiceServer interface {
+	mustEmbedUnimplementedWebIdentityServiceServer()
+}
+
+func RegisterWebIdentityServiceServer(s grpc.ServiceRegistrar, srv WebIdentityServiceServer) {
+	// If the following call panics, it indicates UnimplementedWebIdentityServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WebIdentityService_ServiceDesc, srv)
+}
+
+func _WebIdentityService_GetLoginConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLoginConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebIdentityServiceServer).GetLoginConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebIdentityService_GetLoginConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebIdentityServiceServer).GetLoginConfig(ctx, req.(*GetLoginConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebIdentityService_AssumeRoleWithWebIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssumeRoleWithWebIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebIdentityServiceServer).AssumeRoleWithWebIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebIdentityService_AssumeRoleWithWebIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebIdentityServiceServer).AssumeRoleWithWebIdentity(ctx, req.(*AssumeRoleWithWebIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebIdentityService_ServiceDesc is the grpc.ServiceDesc for WebIdentityService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebIdentityService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "within.website.x.iam.sts.v1.WebIdentityService",
+	HandlerType: (*WebIdentityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLoginConfig",
+			Handler:    _WebIdentityService_GetLoginConfig_Handler,
+		},
+		{
+			MethodName: "AssumeRoleWithWebIdentity",
+			Handler:    _WebIdentityService_AssumeRoleWithWebIdentity_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "within/website/x/iam/sts/v1/sts.proto",
+}
@@ -24,6 +24,8 @@ const _ = connect.IsAtLeastVersion1_13_0
 const (
 	// SigningKeyServiceName is the fully-qualified name of the SigningKeyService service.
 	SigningKeyServiceName = "within.website.x.iam.sts.v1.SigningKeyService"
+	// WebIdentityServiceName is the fully-qualified name of the WebIdentityService service.
+	WebIdentityServiceName = "within.website.x.iam.sts.v1.WebIdentityService"
 )
 
 // These constants are the fully-qualified names of the RPCs defined in this package. They're
@@ -40,6 +42,12 @@ const (
 	// SigningKeyServiceGetPublicKeyProcedure is the fully-qualified name of the SigningKeyService's
 	// GetPublicKey RPC.
 	SigningKeyServiceGetPublicKeyProcedure = "/within.website.x.iam.sts.v1.SigningKeyService/GetPublicKey"
+	// WebIdentityServiceGetLoginConfigProcedure is the fully-qualified name of the WebIdentityService's
+	// GetLoginConfig RPC.
+	WebIdentityServiceGetLoginConfigProcedure = "/within.website.x.iam.sts.v1.WebIdentityService/GetLoginConfig"
+	// WebIdentityServiceAssumeRoleWithWebIdentityProcedure is the fully-qualified name of the
+	// WebIdentityService's AssumeRoleWithWebIdentity RPC.
+	WebIdentityServiceAssumeRoleWithWebIdentityProcedure = "/within.website.x.iam.sts.v1.WebIdentityService/AssumeRoleWithWebIdentity"
 )
 
 // SigningKeyServiceClient is a client for the within.website.x.iam.sts.v1.SigningKeyService
@@ -49,12 +57,11 @@ type SigningKeyServiceClient interface {
 	// id and credential scope, plus the identity the key belongs to.
 	//
 	// Errors:
-	//
-	//	NOT_FOUND         - no such access key id
-	//	PERMISSION_DENIED - key or owning user is disabled, or the requested
-	//	                    (region, service, date) scope is outside what this
-	//	                    deployment issues keys for
-	//	INVALID_ARGUMENT  - malformed access_key_id/date/region/service
+	//   NOT_FOUND         - no such access key id
+	//   PERMISSION_DENIED - key or owning user is disabled, or the requested
+	//                       (region, service, date) scope is outside what this
+	//                       deployment issues keys for
+	//   INVALID_ARGUMENT  - malformed access_key_id/date/region/service
 	GetSigningKey(context.Context, *connect.Request[v1.GetSigningKeyRequest]) (*connect.Response[v1.GetSigningKeyResponse], error)
 	// GetPublicKey returns the SigV4A (ECDSA P-256) public verification key
 	// for an access key id, plus the identity it authenticates. Public keys
@@ -62,10 +69,9 @@ type SigningKeyServiceClient interface {
 	// mint them, unlike the symmetric derived keys from GetSigningKey.
 	//
 	// Errors:
-	//
-	//	NOT_FOUND         - no such access key id
-	//	PERMISSION_DENIED - key or owning user is disabled
-	//	INVALID_ARGUMENT  - missing access_key_id
+	//   NOT_FOUND         - no such access key id
+	//   PERMISSION_DENIED - key or owning user is disabled
+	//   INVALID_ARGUMENT  - missing access_key_id
 	GetPublicKey(context.Context, *connect.Request[v1.GetPublicKeyRequest]) (*connect.Response[v1.GetPublicKeyResponse], error)
 }
 
@@ -119,12 +125,11 @@ type SigningKeyServiceHandler interface {
 	// id and credential scope, plus the identity the key belongs to.
 	//
 	// Errors:
-	//
-	//	NOT_FOUND         - no such access key id
-	//	PERMISSION_DENIED - key or owning user is disabled, or the requested
-	//	                    (region, service, date) scope is outside what this
-	//	                    deployment issues keys for
-	//	INVALID_ARGUMENT  - malformed access_key_id/date/region/service
+	//   NOT_FOUND         - no such access key id
+	//   PERMISSION_DENIED - key or owning user is disabled, or the requested
+	//                       (region, service, date) scope is outside what this
+	//                       deployment issues keys for
+	//   INVALID_ARGUMENT  - malformed access_key_id/date/region/service
 	GetSigningKey(context.Context, *connect.Request[v1.GetSigningKeyRequest]) (*connect.Response[v1.GetSigningKeyResponse], error)
 	// GetPublicKey returns the SigV4A (ECDSA P-256) public verification key
 	// for an access key id, plus the identity it authenticates. Public keys
@@ -132,10 +137,9 @@ type SigningKeyServiceHandler interface {
 	// mint them, unlike the symmetric derived keys from GetSigningKey.
 	//
 	// Errors:
-	//
-	//	NOT_FOUND         - no such access key id
-	//	PERMISSION_DENIED - key or owning user is disabled
-	//	INVALID_ARGUMENT  - missing access_key_id
+	//   NOT_FOUND         - no such access key id
+	//   PERMISSION_DENIED - key or owning user is disabled
+	//   INVALID_ARGUMENT  - missing access_key_id
 	GetPublicKey(context.Context, *connect.Request[v1.GetPublicKeyRequest]) (*connect.Response[v1.GetPublicKeyResponse], error)
 }
 
@@ -180,3 +184,127 @@ func (UnimplementedSigningKeyServiceHandler) GetSigningKey(context.Context, *con
 func (UnimplementedSigningKeyServiceHandler) GetPublicKey(context.Context, *connect.Request[v1.GetPublicKeyRequest]) (*connect.Response[v1.GetPublicKeyResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("within.website.x.iam.sts.v1.SigningKeyService.GetPublicKey is not implemented"))
 }
+
+// WebIdentityServiceClient is a client for the within.website.x.iam.sts.v1.WebIdentityService
+// service.
+type WebIdentityServiceClient interface {
+	// GetLoginConfig returns what a client needs to run the OIDC flow against
+	// the issuer iamd trusts, so `iam login` only needs the iamd endpoint.
+	GetLoginConfig(context.Context, *connect.Request[v1.GetLoginConfigRequest]) (*connect.Response[v1.GetLoginConfigResponse], error)
+	// AssumeRoleWithWebIdentity verifies an ID token issued by the configured
+	// issuer for the configured client, maps its subject to an iamd user
+	// (creating the user on first login), and mints a key that expires at
+	// the returned expiration.
+	//
+	// Errors:
+	//   UNAUTHENTICATED   - the ID token does not verify
+	//   PERMISSION_DENIED - the token lacks the required group, or the mapped
+	//                       user is disabled
+	//   INVALID_ARGUMENT  - missing id_token or out-of-range duration
+	AssumeRoleWithWebIdentity(context.Context, *connect.Request[v1.AssumeRoleWithWebIdentityRequest]) (*connect.Response[v1.AssumeRoleWithWebIdentityResponse], error)
+}
+
+// NewWebIdentityServiceClient constructs a client for the
+// within.website.x.iam.sts.v1.WebIdentityService service. By default, it uses the Connect protocol
+// with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed requests. To
+// use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or connect.WithGRPCWeb()
+// options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWebIdentityServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) WebIdentityServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	webIdentityServiceMethods := v1.File_within_website_x_iam_sts_v1_sts_proto.Services().ByName("WebIdentityService").Methods()
+	return &webIdentityServiceClient{
+		getLoginConfig: connect.NewClient[v1.GetLoginConfigRequest, v1.GetLoginConfigResponse](
+			httpClient,
+			baseURL+WebIdentityServiceGetLoginConfigProcedure,
+			connect.WithSchema(webIdentityServiceMethods.ByName("GetLoginConfig")),
+			connect.WithClientOptions(opts...),
+		),
+		assumeRoleWithWebIdentity: connect.NewClient[v1.AssumeRoleWithWebIdentityRequest, v1.AssumeRoleWithWebIdentityResponse](
+			httpClient,
+			baseURL+WebIdentityServiceAssumeRoleWithWebIdentityProcedure,
+			connect.WithSchema(webIdentityServiceMethods.ByName("AssumeRoleWithWebIdentity")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// webIdentityServiceClient implements WebIdentityServiceClient.
+type webIdentityServiceClient struct {
+	getLoginConfig            *connect.Client[v1.GetLoginConfigRequest, v1.GetLoginConfigResponse]
+	assumeRoleWithWebIdentity *connect.Client[v1.AssumeRoleWithWebIdentityRequest, v1.AssumeRoleWithWebIdentityResponse]
+}
+
+// GetLoginConfig calls within.website.x.iam.sts.v1.WebIdentityService.GetLoginConfig.
+func (c *webIdentityServiceClient) GetLoginConfig(ctx context.Context, req *connect.Request[v1.GetLoginConfigRequest]) (*connect.Response[v1.GetLoginConfigResponse], error) {
+	return c.getLoginConfig.CallUnary(ctx, req)
+}
+
+// AssumeRoleWithWebIdentity calls
+// within.website.x.iam.sts.v1.WebIdentityService.AssumeRoleWithWebIdentity.
+func (c *webIdentityServiceClient) AssumeRoleWithWebIdentity(ctx context.Context, req *connect.Request[v1.AssumeRoleWithWebIdentityRequest]) (*connect.Response[v1.AssumeRoleWithWebIdentityResponse], error) {
+	return c.assumeRoleWithWebIdentity.CallUnary(ctx, req)
+}
+
+// WebIdentityServiceHandler is an implementation of the
+// within.website.x.iam.sts.v1.WebIdentityService service.
+type WebIdentityServiceHandler interface {
+	// GetLoginConfig returns what a client needs to run the OIDC flow against
+	// the issuer iamd trusts, so `iam login` only needs the iamd endpoint.
+	GetLoginConfig(context.Context, *connect.Request[v1.GetLoginConfigRequest]) (*connect.Response[v1.GetLoginConfigResponse], error)
+	// AssumeRoleWithWebIdentity verifies an ID token issued by the configured
+	// issuer for the configured client, maps its subject to an iamd user
+	// (creating the user on first login), and mints a key that expires at
+	// the returned expiration.
+	//
+	// Errors:
+	//   UNAUTHENTICATED   - the ID token does not verify
+	//   PERMISSION_DENIED - the token lacks the required group, or the mapped
+	//                       user is disabled
+	//   INVALID_ARGUMENT  - missing id_token or out-of-range duration
+	AssumeRoleWithWebIdentity(context.Context, *connect.Request[v1.AssumeRoleWithWebIdentityRequest]) (*connect.Response[v1.AssumeRoleWithWebIdentityResponse], error)
+}
+
+// NewWebIdentityServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWebIdentityServiceHandler(svc WebIdentityServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	webIdentityServiceMethods := v1.File_within_website_x_iam_sts_v1_sts_proto.Services().ByName("WebIdentityService").Methods()
+	webIdentityServiceGetLoginConfigHandler := connect.NewUnaryHandler(
+		WebIdentityServiceGetLoginConfigProcedure,
+		svc.GetLoginConfig,
+		connect.WithSchema(webIdentityServiceMethods.ByName("GetLoginConfig")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webIdentityServiceAssumeRoleWithWebIdentityHandler := connect.NewUnaryHandler(
+		WebIdentityServiceAssumeRoleWithWebIdentityProcedure,
+		svc.AssumeRoleWithWebIdentity,
+		connect.WithSchema(webIdentityServiceMethods.ByName("AssumeRoleWithWebIdentity")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/within.website.x.iam.sts.v1.WebIdentityService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case WebIdentityServiceGetLoginConfigProcedure:
+			webIdentityServiceGetLoginConfigHandler.ServeHTTP(w, r)
+		case WebIdentityServiceAssumeRoleWithWebIdentityProcedure:
+			webIdentityServiceAssumeRoleWithWebIdentityHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedWebIdentityServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedWebIdentityServiceHandler struct{}
+
+func (UnimplementedWebIdentityServiceHandler) GetLoginConfig(context.Context, *connect.Request[v1.GetLoginConfigRequest]) (*connect.Response[v1.GetLoginConfigResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("within.website.x.iam.sts.v1.WebIdentityService.GetLoginConfig is not implemented"))
+}
+
+func (UnimplementedWebIdentityServiceHandler) AssumeRoleWithWebIdentity(context.Context, *connect.Request[v1.AssumeRoleWithWebIdentityRequest]) (*connect.Response[v1.AssumeRoleWithWebIdentityResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("within.website.x.iam.sts.v1.WebIdentityService.AssumeRoleWithWebIdentity is not implemented"))
+}
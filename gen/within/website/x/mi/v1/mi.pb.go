@@ -16,6 +16,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	v1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
 )
 
 const (
@@ -25,6 +26,120 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// POSSEAction is what announcing a blogpost does to its thread on a social
+// network.
+type POSSEAction int32
+
+const (
+	POSSEAction_POSSE_ACTION_UNSPECIFIED POSSEAction = 0
+	// The thread is already up to date.
+	POSSEAction_POSSE_ACTION_NONE POSSEAction = 1
+	// A new thread is posted.
+	POSSEAction_POSSE_ACTION_CREATE POSSEAction = 2
+	// The posts in the existing thread are edited in place.
+	POSSEAction_POSSE_ACTION_EDIT POSSEAction = 3
+	// The existing thread is deleted and a new one is posted.
+	POSSEAction_POSSE_ACTION_REPLACE POSSEAction = 4
+)
+
+// Enum value maps for POSSEAction.
+var (
+	POSSEAction_name = map[int32]string{
+		0: "POSSE_ACTION_UNSPECIFIED",
+		1: "POSSE_ACTION_NONE",
+		2: "POSSE_ACTION_CREATE",
+		3: "POSSE_ACTION_EDIT",
+		4: "POSSE_ACTION_REPLACE",
+	}
+	POSSEAction_value = map[string]int32{
+		"POSSE_ACTION_UNSPECIFIED": 0,
+		"POSSE_ACTION_NONE":        1,
+		"POSSE_ACTION_CREATE":      2,
+		"POSSE_ACTION_EDIT":        3,
+		"POSSE_ACTION_REPLACE":     4,
+	}
+)
+
+func (x POSSEAction) Enum() *POSSEAction {
+	p := new(POSSEAction)
+	*p = x
+	return p
+}
+
+func (x POSSEAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (POSSEAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_within_website_x_mi_v1_mi_proto_enumTypes[0].Descriptor()
+}
+
+func (POSSEAction) Type() protoreflect.EnumType {
+	return &file_within_website_x_mi_v1_mi_proto_enumTypes[0]
+}
+
+func (x POSSEAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use POSSEAction.Descriptor instead.
+func (POSSEAction) EnumDescriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{0}
+}
+
+// How events are sent to a subscription.
+type SubscriptionKind int32
+
+const (
+	SubscriptionKind_SUBSCRIPTION_KIND_UNSPECIFIED SubscriptionKind = 0
+	// The payload is POSTed to the URL as JSON.
+	SubscriptionKind_SUBSCRIPTION_KIND_WEBHOOK SubscriptionKind = 1
+	// The URL is the base URL of a Twirp Announce service, which is sent a
+	// JSON Feed item describing the event.
+	SubscriptionKind_SUBSCRIPTION_KIND_ANNOUNCE SubscriptionKind = 2
+)
+
+// Enum value maps for SubscriptionKind.
+var (
+	SubscriptionKind_name = map[int32]string{
+		0: "SUBSCRIPTION_KIND_UNSPECIFIED",
+		1: "SUBSCRIPTION_KIND_WEBHOOK",
+		2: "SUBSCRIPTION_KIND_ANNOUNCE",
+	}
+	SubscriptionKind_value = map[string]int32{
+		"SUBSCRIPTION_KIND_UNSPECIFIED": 0,
+		"SUBSCRIPTION_KIND_WEBHOOK":     1,
+		"SUBSCRIPTION_KIND_ANNOUNCE":    2,
+	}
+)
+
+func (x SubscriptionKind) Enum() *SubscriptionKind {
+	p := new(SubscriptionKind)
+	*p = x
+	return p
+}
+
+func (x SubscriptionKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscriptionKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_within_website_x_mi_v1_mi_proto_enumTypes[1].Descriptor()
+}
+
+func (SubscriptionKind) Type() protoreflect.EnumType {
+	return &file_within_website_x_mi_v1_mi_proto_enumTypes[1]
+}
+
+func (x SubscriptionKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscriptionKind.Descriptor instead.
+func (SubscriptionKind) EnumDescriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{1}
+}
+
 type MembersResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Members       []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"` // required
@@ -493,6 +608,137 @@ func (x *ListSwitchesResp) GetSwitches() []*FrontChange {
 	return nil
 }
 
+// POSSEThread is what would be posted to one social network.
+type POSSEThread struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`                                        // required, such as "bluesky" or "mastodon"
+	Action        POSSEAction            `protobuf:"varint,2,opt,name=action,proto3,enum=within.website.x.mi.v1.POSSEAction" json:"action,omitempty"` // required
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                           // required, the length limit of each post
+	Posts         []string               `protobuf:"bytes,4,rep,name=posts,proto3" json:"posts,omitempty"`                                            // required, the text of each post in the thread
+	Existing      []string               `protobuf:"bytes,5,rep,name=existing,proto3" json:"existing,omitempty"`                                      // optional, the text of the posts that were already made
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *POSSEThread) Reset() {
+	*x = POSSEThread{}
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *POSSEThread) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*POSSEThread) ProtoMessage() {}
+
+func (x *POSSEThread) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use POSSEThread.ProtoReflect.Descriptor instead.
+func (*POSSEThread) Descriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *POSSEThread) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *POSSEThread) GetAction() POSSEAction {
+	if x != nil {
+		return x.Action
+	}
+	return POSSEAction_POSSE_ACTION_UNSPECIFIED
+}
+
+func (x *POSSEThread) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *POSSEThread) GetPosts() []string {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *POSSEThread) GetExisting() []string {
+	if x != nil {
+		return x.Existing
+	}
+	return nil
+}
+
+type POSSEPreview struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the blogpost was already announced. Only edits are made to
+	// blogposts that were already announced.
+	Known         bool           `protobuf:"varint,1,opt,name=known,proto3" json:"known,omitempty"`
+	Threads       []*POSSEThread `protobuf:"bytes,2,rep,name=threads,proto3" json:"threads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *POSSEPreview) Reset() {
+	*x = POSSEPreview{}
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *POSSEPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*POSSEPreview) ProtoMessage() {}
+
+func (x *POSSEPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use POSSEPreview.ProtoReflect.Descriptor instead.
+func (*POSSEPreview) Descriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *POSSEPreview) GetKnown() bool {
+	if x != nil {
+		return x.Known
+	}
+	return false
+}
+
+func (x *POSSEPreview) GetThreads() []*POSSEThread {
+	if x != nil {
+		return x.Threads
+	}
+	return nil
+}
+
 // Event represents an event that Xe will be attending.
 type Event struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -509,14 +755,18 @@ type Event struct {
 	// Id of the event
 	Id int32 `protobuf:"varint,6,opt,name=id,proto3" json:"id,omitempty"`
 	// The description of the event
-	Description   string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Description string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	// How the event repeats as an RFC 5545 recurrence rule, such as
+	// "FREQ=YEARLY;COUNT=5" (optional). FREQ, INTERVAL, COUNT and UNTIL are
+	// supported.
+	Recurrence    string `protobuf:"bytes,8,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Event) Reset() {
 	*x = Event{}
-	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[9]
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -528,7 +778,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[9]
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,7 +791,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{9}
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Event) GetName() string {
@@ -593,6 +843,13 @@ func (x *Event) GetDescription() string {
 	return ""
 }
 
+func (x *Event) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
 // A feed of events, result from mi query.
 type EventFeed struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -604,7 +861,7 @@ type EventFeed struct {
 
 func (x *EventFeed) Reset() {
 	*x = EventFeed{}
-	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[10]
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -616,7 +873,7 @@ func (x *EventFeed) String() string {
 func (*EventFeed) ProtoMessage() {}
 
 func (x *EventFeed) ProtoReflect() protoreflect.Message {
-	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[10]
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -629,7 +886,7 @@ func (x *EventFeed) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventFeed.ProtoReflect.Descriptor instead.
 func (*EventFeed) Descriptor() ([]byte, []int) {
-	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{10}
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *EventFeed) GetEvents() []*Event {
@@ -639,11 +896,557 @@ func (x *EventFeed) GetEvents() []*Event {
 	return nil
 }
 
+type Subscription struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                   // required
+	Kind  SubscriptionKind       `protobuf:"varint,2,opt,name=kind,proto3,enum=within.website.x.mi.v1.SubscriptionKind" json:"kind,omitempty"` // required
+	Url   string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`                                                 // required
+	// The event types sent to this subscription: "switch", "event.added",
+	// "event.removed" and "blogpost.published". Empty means all of them.
+	EventTypes []string `protobuf:"bytes,4,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// The HMAC-SHA256 key payloads are signed with, only set when the
+	// subscription is created.
+	Secret        string                 `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // required
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`              // optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Subscription) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Subscription) GetKind() SubscriptionKind {
+	if x != nil {
+		return x.Kind
+	}
+	return SubscriptionKind_SUBSCRIPTION_KIND_UNSPECIFIED
+}
+
+func (x *Subscription) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Subscription) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Subscription) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Subscription) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Subscription) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type SubscribeReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          SubscriptionKind       `protobuf:"varint,1,opt,name=kind,proto3,enum=within.website.x.mi.v1.SubscriptionKind" json:"kind,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeReq) Reset() {
+	*x = SubscribeReq{}
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeReq) ProtoMessage() {}
+
+func (x *SubscribeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeReq.ProtoReflect.Descriptor instead.
+func (*SubscribeReq) Descriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SubscribeReq) GetKind() SubscriptionKind {
+	if x != nil {
+		return x.Kind
+	}
+	return SubscriptionKind_SUBSCRIPTION_KIND_UNSPECIFIED
+}
+
+func (x *SubscribeReq) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SubscribeReq) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *SubscribeReq) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ListSubscriptionsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*Subscription        `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionsResp) Reset() {
+	*x = ListSubscriptionsResp{}
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsResp) ProtoMessage() {}
+
+func (x *ListSubscriptionsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsResp.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsResp) Descriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListSubscriptionsResp) GetSubscriptions() []*Subscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+type UnsubscribeReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeReq) Reset() {
+	*x = UnsubscribeReq{}
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeReq) ProtoMessage() {}
+
+func (x *UnsubscribeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeReq.ProtoReflect.Descriptor instead.
+func (*UnsubscribeReq) Descriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UnsubscribeReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// DeadLetter is a delivery that failed too many times.
+type DeadLetter struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                               // required
+	SubscriptionId string                 `protobuf:"bytes,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"` // required
+	EventId        string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`                      // required
+	EventType      string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`                // required
+	Attempts       int32                  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`                                  // required
+	LastError      string                 `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`                // required
+	FailedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`                   // required
+	Payload        string                 `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`                                     // required, the JSON payload
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeadLetter) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+type ListDeadLettersReq struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"` // optional, limits the results to one subscription
+	Count          int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`                                        // optional, defaults to 30
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersReq) Reset() {
+	*x = ListDeadLettersReq{}
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersReq) ProtoMessage() {}
+
+func (x *ListDeadLettersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersReq.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersReq) Descriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListDeadLettersReq) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *ListDeadLettersReq) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ListDeadLettersResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResp) Reset() {
+	*x = ListDeadLettersResp{}
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResp) ProtoMessage() {}
+
+func (x *ListDeadLettersResp) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResp.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResp) Descriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListDeadLettersResp) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+type RetryDeadLetterReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryDeadLetterReq) Reset() {
+	*x = RetryDeadLetterReq{}
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryDeadLetterReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryDeadLetterReq) ProtoMessage() {}
+
+func (x *RetryDeadLetterReq) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryDeadLetterReq.ProtoReflect.Descriptor instead.
+func (*RetryDeadLetterReq) Descriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RetryDeadLetterReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// SwitchEvent is the data of a "switch" event.
+type SwitchEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Old           *FrontChange           `protobuf:"bytes,1,opt,name=old,proto3" json:"old,omitempty"`         // required
+	Current       *FrontChange           `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"` // required
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwitchEvent) Reset() {
+	*x = SwitchEvent{}
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwitchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwitchEvent) ProtoMessage() {}
+
+func (x *SwitchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_within_website_x_mi_v1_mi_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwitchEvent.ProtoReflect.Descriptor instead.
+func (*SwitchEvent) Descriptor() ([]byte, []int) {
+	return file_within_website_x_mi_v1_mi_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SwitchEvent) GetOld() *FrontChange {
+	if x != nil {
+		return x.Old
+	}
+	return nil
+}
+
+func (x *SwitchEvent) GetCurrent() *FrontChange {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
 var File_within_website_x_mi_v1_mi_proto protoreflect.FileDescriptor
 
 const file_within_website_x_mi_v1_mi_proto_rawDesc = "" +
 	"\n" +
-	"\x1fwithin/website/x/mi/v1/mi.proto\x12\x16within.website.x.mi.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"G\n" +
+	"\x1fwithin/website/x/mi/v1/mi.proto\x12\x16within.website.x.mi.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a4within/website/x/external/jsonfeed/v1/jsonfeed.proto\"G\n" +
 	"\vMembersResp\x128\n" +
 	"\amembers\x18\x01 \x03(\v2\x1e.within.website.x.mi.v1.MemberR\amembers\"\x83\x01\n" +
 	"\x06Member\x12\x0e\n" +
@@ -674,7 +1477,16 @@ const file_within_website_x_mi_v1_mi_proto_rawDesc = "" +
 	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\"S\n" +
 	"\x10ListSwitchesResp\x12?\n" +
-	"\bswitches\x18\x01 \x03(\v2#.within.website.x.mi.v1.FrontChangeR\bswitches\"\xd9\x02\n" +
+	"\bswitches\x18\x01 \x03(\v2#.within.website.x.mi.v1.FrontChangeR\bswitches\"\xac\x01\n" +
+	"\vPOSSEThread\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12;\n" +
+	"\x06action\x18\x02 \x01(\x0e2#.within.website.x.mi.v1.POSSEActionR\x06action\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05posts\x18\x04 \x03(\tR\x05posts\x12\x1a\n" +
+	"\bexisting\x18\x05 \x03(\tR\bexisting\"c\n" +
+	"\fPOSSEPreview\x12\x14\n" +
+	"\x05known\x18\x01 \x01(\bR\x05known\x12=\n" +
+	"\athreads\x18\x02 \x03(\v2#.within.website.x.mi.v1.POSSEThreadR\athreads\"\xf9\x02\n" +
 	"\x05Event\x12\x1a\n" +
 	"\x04name\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04name\x12T\n" +
 	"\x03url\x18\x02 \x01(\tBB\xbaH?\xba\x019\n" +
@@ -685,22 +1497,87 @@ const file_within_website_x_mi_v1_mi_proto_rawDesc = "" +
 	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\aendDate\x12\"\n" +
 	"\blocation\x18\x05 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\blocation\x12\x0e\n" +
 	"\x02id\x18\x06 \x01(\x05R\x02id\x12(\n" +
-	"\vdescription\x18\a \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\vdescription\"B\n" +
+	"\vdescription\x18\a \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\vdescription\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\b \x01(\tR\n" +
+	"recurrence\"B\n" +
 	"\tEventFeed\x125\n" +
-	"\x06events\x18\x01 \x03(\v2\x1d.within.website.x.mi.v1.EventR\x06events2\xae\x03\n" +
+	"\x06events\x18\x01 \x03(\v2\x1d.within.website.x.mi.v1.EventR\x06events\"\x84\x02\n" +
+	"\fSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12<\n" +
+	"\x04kind\x18\x02 \x01(\x0e2(.within.website.x.mi.v1.SubscriptionKindR\x04kind\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x04 \x03(\tR\n" +
+	"eventTypes\x12\x16\n" +
+	"\x06secret\x18\x05 \x01(\tR\x06secret\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"\xbc\x02\n" +
+	"\fSubscribeReq\x12H\n" +
+	"\x04kind\x18\x01 \x01(\x0e2(.within.website.x.mi.v1.SubscriptionKindB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x04kind\x12[\n" +
+	"\x03url\x18\x02 \x01(\tBI\xbaHF\xba\x01@\n" +
+	"\n" +
+	"url.is_url\x12$Subscription URL must be a valid URL\x1a\fthis.isUri()\xc8\x01\x01R\x03url\x12c\n" +
+	"\vevent_types\x18\x03 \x03(\tBB\xbaH?\x92\x01<\":r8R\x06switchR\vevent.addedR\revent.removedR\x12blogpost.publishedR\n" +
+	"eventTypes\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"c\n" +
+	"\x15ListSubscriptionsResp\x12J\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2$.within.website.x.mi.v1.SubscriptionR\rsubscriptions\"(\n" +
+	"\x0eUnsubscribeReq\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"\x8d\x02\n" +
+	"\n" +
+	"DeadLetter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\tR\x0esubscriptionId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x06 \x01(\tR\tlastError\x127\n" +
+	"\tfailed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\x12\x18\n" +
+	"\apayload\x18\b \x01(\tR\apayload\"S\n" +
+	"\x12ListDeadLettersReq\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\\\n" +
+	"\x13ListDeadLettersResp\x12E\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\".within.website.x.mi.v1.DeadLetterR\vdeadLetters\",\n" +
+	"\x12RetryDeadLetterReq\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"\x83\x01\n" +
+	"\vSwitchEvent\x125\n" +
+	"\x03old\x18\x01 \x01(\v2#.within.website.x.mi.v1.FrontChangeR\x03old\x12=\n" +
+	"\acurrent\x18\x02 \x01(\v2#.within.website.x.mi.v1.FrontChangeR\acurrent*\x8c\x01\n" +
+	"\vPOSSEAction\x12\x1c\n" +
+	"\x18POSSE_ACTION_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11POSSE_ACTION_NONE\x10\x01\x12\x17\n" +
+	"\x13POSSE_ACTION_CREATE\x10\x02\x12\x15\n" +
+	"\x11POSSE_ACTION_EDIT\x10\x03\x12\x18\n" +
+	"\x14POSSE_ACTION_REPLACE\x10\x04*t\n" +
+	"\x10SubscriptionKind\x12!\n" +
+	"\x1dSUBSCRIPTION_KIND_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19SUBSCRIPTION_KIND_WEBHOOK\x10\x01\x12\x1e\n" +
+	"\x1aSUBSCRIPTION_KIND_ANNOUNCE\x10\x022\xae\x03\n" +
 	"\rSwitchTracker\x12F\n" +
 	"\aMembers\x12\x16.google.protobuf.Empty\x1a#.within.website.x.mi.v1.MembersResp\x12I\n" +
 	"\n" +
 	"WhoIsFront\x12\x16.google.protobuf.Empty\x1a#.within.website.x.mi.v1.FrontChange\x12O\n" +
 	"\x06Switch\x12!.within.website.x.mi.v1.SwitchReq\x1a\".within.website.x.mi.v1.SwitchResp\x12V\n" +
 	"\tGetSwitch\x12$.within.website.x.mi.v1.GetSwitchReq\x1a#.within.website.x.mi.v1.FrontChange\x12a\n" +
-	"\fListSwitches\x12'.within.website.x.mi.v1.ListSwitchesReq\x1a(.within.website.x.mi.v1.ListSwitchesResp2F\n" +
+	"\fListSwitches\x12'.within.website.x.mi.v1.ListSwitchesReq\x1a(.within.website.x.mi.v1.ListSwitchesResp2\xa4\x01\n" +
 	"\x05POSSE\x12=\n" +
-	"\vRefreshBlog\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty2\xc9\x01\n" +
+	"\vRefreshBlog\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12\\\n" +
+	"\aPreview\x12+.within.website.x.external.jsonfeed.v1.Item\x1a$.within.website.x.mi.v1.POSSEPreview2\xc9\x01\n" +
 	"\x06Events\x12@\n" +
 	"\x03Get\x12\x16.google.protobuf.Empty\x1a!.within.website.x.mi.v1.EventFeed\x12<\n" +
 	"\x03Add\x12\x1d.within.website.x.mi.v1.Event\x1a\x16.google.protobuf.Empty\x12?\n" +
-	"\x06Remove\x12\x1d.within.website.x.mi.v1.Event\x1a\x16.google.protobuf.EmptyB\xd4\x01\n" +
+	"\x06Remove\x12\x1d.within.website.x.mi.v1.Event\x1a\x16.google.protobuf.Empty2\xd1\x03\n" +
+	"\bWebhooks\x12W\n" +
+	"\tSubscribe\x12$.within.website.x.mi.v1.SubscribeReq\x1a$.within.website.x.mi.v1.Subscription\x12Z\n" +
+	"\x11ListSubscriptions\x12\x16.google.protobuf.Empty\x1a-.within.website.x.mi.v1.ListSubscriptionsResp\x12M\n" +
+	"\vUnsubscribe\x12&.within.website.x.mi.v1.UnsubscribeReq\x1a\x16.google.protobuf.Empty\x12j\n" +
+	"\x0fListDeadLetters\x12*.within.website.x.mi.v1.ListDeadLettersReq\x1a+.within.website.x.mi.v1.ListDeadLettersResp\x12U\n" +
+	"\x0fRetryDeadLetter\x12*.within.website.x.mi.v1.RetryDeadLetterReq\x1a\x16.google.protobuf.EmptyB\xd4\x01\n" +
 	"\x1acom.within.website.x.mi.v1B\aMiProtoP\x01Z0within.website/x/gen/within/website/x/mi/v1;miv1\xa2\x02\x04WWXM\xaa\x02\x16Within.Website.X.Mi.V1\xca\x02\x16Within\\Website\\X\\Mi\\V1\xe2\x02\"Within\\Website\\X\\Mi\\V1\\GPBMetadata\xea\x02\x1aWithin::Website::X::Mi::V1b\x06proto3"
 
 var (
@@ -715,56 +1592,93 @@ func file_within_website_x_mi_v1_mi_proto_rawDescGZIP() []byte {
 	return file_within_website_x_mi_v1_mi_proto_rawDescData
 }
 
-var file_within_website_x_mi_v1_mi_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_within_website_x_mi_v1_mi_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_within_website_x_mi_v1_mi_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_within_website_x_mi_v1_mi_proto_goTypes = []any{
-	(*MembersResp)(nil),           // 0: within.website.x.mi.v1.MembersResp
-	(*Member)(nil),                // 1: within.website.x.mi.v1.Member
-	(*Switch)(nil),                // 2: within.website.x.mi.v1.Switch
-	(*SwitchReq)(nil),             // 3: within.website.x.mi.v1.SwitchReq
-	(*SwitchResp)(nil),            // 4: within.website.x.mi.v1.SwitchResp
-	(*GetSwitchReq)(nil),          // 5: within.website.x.mi.v1.GetSwitchReq
-	(*FrontChange)(nil),           // 6: within.website.x.mi.v1.FrontChange
-	(*ListSwitchesReq)(nil),       // 7: within.website.x.mi.v1.ListSwitchesReq
-	(*ListSwitchesResp)(nil),      // 8: within.website.x.mi.v1.ListSwitchesResp
-	(*Event)(nil),                 // 9: within.website.x.mi.v1.Event
-	(*EventFeed)(nil),             // 10: within.website.x.mi.v1.EventFeed
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
+	(POSSEAction)(0),              // 0: within.website.x.mi.v1.POSSEAction
+	(SubscriptionKind)(0),         // 1: within.website.x.mi.v1.SubscriptionKind
+	(*MembersResp)(nil),           // 2: within.website.x.mi.v1.MembersResp
+	(*Member)(nil),                // 3: within.website.x.mi.v1.Member
+	(*Switch)(nil),                // 4: within.website.x.mi.v1.Switch
+	(*SwitchReq)(nil),             // 5: within.website.x.mi.v1.SwitchReq
+	(*SwitchResp)(nil),            // 6: within.website.x.mi.v1.SwitchResp
+	(*GetSwitchReq)(nil),          // 7: within.website.x.mi.v1.GetSwitchReq
+	(*FrontChange)(nil),           // 8: within.website.x.mi.v1.FrontChange
+	(*ListSwitchesReq)(nil),       // 9: within.website.x.mi.v1.ListSwitchesReq
+	(*ListSwitchesResp)(nil),      // 10: within.website.x.mi.v1.ListSwitchesResp
+	(*POSSEThread)(nil),           // 11: within.website.x.mi.v1.POSSEThread
+	(*POSSEPreview)(nil),          // 12: within.website.x.mi.v1.POSSEPreview
+	(*Event)(nil),                 // 13: within.website.x.mi.v1.Event
+	(*EventFeed)(nil),             // 14: within.website.x.mi.v1.EventFeed
+	(*Subscription)(nil),          // 15: within.website.x.mi.v1.Subscription
+	(*SubscribeReq)(nil),          // 16: within.website.x.mi.v1.SubscribeReq
+	(*ListSubscriptionsResp)(nil), // 17: within.website.x.mi.v1.ListSubscriptionsResp
+	(*UnsubscribeReq)(nil),        // 18: within.website.x.mi.v1.UnsubscribeReq
+	(*DeadLetter)(nil),            // 19: within.website.x.mi.v1.DeadLetter
+	(*ListDeadLettersReq)(nil),    // 20: within.website.x.mi.v1.ListDeadLettersReq
+	(*ListDeadLettersResp)(nil),   // 21: within.website.x.mi.v1.ListDeadLettersResp
+	(*RetryDeadLetterReq)(nil),    // 22: within.website.x.mi.v1.RetryDeadLetterReq
+	(*SwitchEvent)(nil),           // 23: within.website.x.mi.v1.SwitchEvent
+	(*timestamppb.Timestamp)(nil), // 24: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 25: google.protobuf.Empty
+	(*v1.Item)(nil),               // 26: within.website.x.external.jsonfeed.v1.Item
 }
 var file_within_website_x_mi_v1_mi_proto_depIdxs = []int32{
-	1,  // 0: within.website.x.mi.v1.MembersResp.members:type_name -> within.website.x.mi.v1.Member
-	11, // 1: within.website.x.mi.v1.Member.birthday:type_name -> google.protobuf.Timestamp
-	2,  // 2: within.website.x.mi.v1.SwitchResp.old:type_name -> within.website.x.mi.v1.Switch
-	2,  // 3: within.website.x.mi.v1.SwitchResp.current:type_name -> within.website.x.mi.v1.Switch
-	2,  // 4: within.website.x.mi.v1.FrontChange.switch:type_name -> within.website.x.mi.v1.Switch
-	1,  // 5: within.website.x.mi.v1.FrontChange.member:type_name -> within.website.x.mi.v1.Member
-	6,  // 6: within.website.x.mi.v1.ListSwitchesResp.switches:type_name -> within.website.x.mi.v1.FrontChange
-	11, // 7: within.website.x.mi.v1.Event.start_date:type_name -> google.protobuf.Timestamp
-	11, // 8: within.website.x.mi.v1.Event.end_date:type_name -> google.protobuf.Timestamp
-	9,  // 9: within.website.x.mi.v1.EventFeed.events:type_name -> within.website.x.mi.v1.Event
-	12, // 10: within.website.x.mi.v1.SwitchTracker.Members:input_type -> google.protobuf.Empty
-	12, // 11: within.website.x.mi.v1.SwitchTracker.WhoIsFront:input_type -> google.protobuf.Empty
-	3,  // 12: within.website.x.mi.v1.SwitchTracker.Switch:input_type -> within.website.x.mi.v1.SwitchReq
-	5,  // 13: within.website.x.mi.v1.SwitchTracker.GetSwitch:input_type -> within.website.x.mi.v1.GetSwitchReq
-	7,  // 14: within.website.x.mi.v1.SwitchTracker.ListSwitches:input_type -> within.website.x.mi.v1.ListSwitchesReq
-	12, // 15: within.website.x.mi.v1.POSSE.RefreshBlog:input_type -> google.protobuf.Empty
-	12, // 16: within.website.x.mi.v1.Events.Get:input_type -> google.protobuf.Empty
-	9,  // 17: within.website.x.mi.v1.Events.Add:input_type -> within.website.x.mi.v1.Event
-	9,  // 18: within.website.x.mi.v1.Events.Remove:input_type -> within.website.x.mi.v1.Event
-	0,  // 19: within.website.x.mi.v1.SwitchTracker.Members:output_type -> within.website.x.mi.v1.MembersResp
-	6,  // 20: within.website.x.mi.v1.SwitchTracker.WhoIsFront:output_type -> within.website.x.mi.v1.FrontChange
-	4,  // 21: within.website.x.mi.v1.SwitchTracker.Switch:output_type -> within.website.x.mi.v1.SwitchResp
-	6,  // 22: within.website.x.mi.v1.SwitchTracker.GetSwitch:output_type -> within.website.x.mi.v1.FrontChange
-	8,  // 23: within.website.x.mi.v1.SwitchTracker.ListSwitches:output_type -> within.website.x.mi.v1.ListSwitchesResp
-	12, // 24: within.website.x.mi.v1.POSSE.RefreshBlog:output_type -> google.protobuf.Empty
-	10, // 25: within.website.x.mi.v1.Events.Get:output_type -> within.website.x.mi.v1.EventFeed
-	12, // 26: within.website.x.mi.v1.Events.Add:output_type -> google.protobuf.Empty
-	12, // 27: within.website.x.mi.v1.Events.Remove:output_type -> google.protobuf.Empty
-	19, // [19:28] is the sub-list for method output_type
-	10, // [10:19] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	3,  // 0: within.website.x.mi.v1.MembersResp.members:type_name -> within.website.x.mi.v1.Member
+	24, // 1: within.website.x.mi.v1.Member.birthday:type_name -> google.protobuf.Timestamp
+	4,  // 2: within.website.x.mi.v1.SwitchResp.old:type_name -> within.website.x.mi.v1.Switch
+	4,  // 3: within.website.x.mi.v1.SwitchResp.current:type_name -> within.website.x.mi.v1.Switch
+	4,  // 4: within.website.x.mi.v1.FrontChange.switch:type_name -> within.website.x.mi.v1.Switch
+	3,  // 5: within.website.x.mi.v1.FrontChange.member:type_name -> within.website.x.mi.v1.Member
+	8,  // 6: within.website.x.mi.v1.ListSwitchesResp.switches:type_name -> within.website.x.mi.v1.FrontChange
+	0,  // 7: within.website.x.mi.v1.POSSEThread.action:type_name -> within.website.x.mi.v1.POSSEAction
+	11, // 8: within.website.x.mi.v1.POSSEPreview.threads:type_name -> within.website.x.mi.v1.POSSEThread
+	24, // 9: within.website.x.mi.v1.Event.start_date:type_name -> google.protobuf.Timestamp
+	24, // 10: within.website.x.mi.v1.Event.end_date:type_name -> google.protobuf.Timestamp
+	13, // 11: within.website.x.mi.v1.EventFeed.events:type_name -> within.website.x.mi.v1.Event
+	1,  // 12: within.website.x.mi.v1.Subscription.kind:type_name -> within.website.x.mi.v1.SubscriptionKind
+	24, // 13: within.website.x.mi.v1.Subscription.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 14: within.website.x.mi.v1.SubscribeReq.kind:type_name -> within.website.x.mi.v1.SubscriptionKind
+	15, // 15: within.website.x.mi.v1.ListSubscriptionsResp.subscriptions:type_name -> within.website.x.mi.v1.Subscription
+	24, // 16: within.website.x.mi.v1.DeadLetter.failed_at:type_name -> google.protobuf.Timestamp
+	19, // 17: within.website.x.mi.v1.ListDeadLettersResp.dead_letters:type_name -> within.website.x.mi.v1.DeadLetter
+	8,  // 18: within.website.x.mi.v1.SwitchEvent.old:type_name -> within.website.x.mi.v1.FrontChange
+	8,  // 19: within.website.x.mi.v1.SwitchEvent.current:type_name -> within.website.x.mi.v1.FrontChange
+	25, // 20: within.website.x.mi.v1.SwitchTracker.Members:input_type -> google.protobuf.Empty
+	25, // 21: within.website.x.mi.v1.SwitchTracker.WhoIsFront:input_type -> google.protobuf.Empty
+	5,  // 22: within.website.x.mi.v1.SwitchTracker.Switch:input_type -> within.website.x.mi.v1.SwitchReq
+	7,  // 23: within.website.x.mi.v1.SwitchTracker.GetSwitch:input_type -> within.website.x.mi.v1.GetSwitchReq
+	9,  // 24: within.website.x.mi.v1.SwitchTracker.ListSwitches:input_type -> within.website.x.mi.v1.ListSwitchesReq
+	25, // 25: within.website.x.mi.v1.POSSE.RefreshBlog:input_type -> google.protobuf.Empty
+	26, // 26: within.website.x.mi.v1.POSSE.Preview:input_type -> within.website.x.external.jsonfeed.v1.Item
+	25, // 27: within.website.x.mi.v1.Events.Get:input_type -> google.protobuf.Empty
+	13, // 28: within.website.x.mi.v1.Events.Add:input_type -> within.website.x.mi.v1.Event
+	13, // 29: within.website.x.mi.v1.Events.Remove:input_type -> within.website.x.mi.v1.Event
+	16, // 30: within.website.x.mi.v1.Webhooks.Subscribe:input_type -> within.website.x.mi.v1.SubscribeReq
+	25, // 31: within.website.x.mi.v1.Webhooks.ListSubscriptions:input_type -> google.protobuf.Empty
+	18, // 32: within.website.x.mi.v1.Webhooks.Unsubscribe:input_type -> within.website.x.mi.v1.UnsubscribeReq
+	20, // 33: within.website.x.mi.v1.Webhooks.ListDeadLetters:input_type -> within.website.x.mi.v1.ListDeadLettersReq
+	22, // 34: within.website.x.mi.v1.Webhooks.RetryDeadLetter:input_type -> within.website.x.mi.v1.RetryDeadLetterReq
+	2,  // 35: within.website.x.mi.v1.SwitchTracker.Members:output_type -> within.website.x.mi.v1.MembersResp
+	8,  // 36: within.website.x.mi.v1.SwitchTracker.WhoIsFront:output_type -> within.website.x.mi.v1.FrontChange
+	6,  // 37: within.website.x.mi.v1.SwitchTracker.Switch:output_type -> within.website.x.mi.v1.SwitchResp
+	8,  // 38: within.website.x.mi.v1.SwitchTracker.GetSwitch:output_type -> within.website.x.mi.v1.FrontChange
+	10, // 39: within.website.x.mi.v1.SwitchTracker.ListSwitches:output_type -> within.website.x.mi.v1.ListSwitchesResp
+	25, // 40: within.website.x.mi.v1.POSSE.RefreshBlog:output_type -> google.protobuf.Empty
+	12, // 41: within.website.x.mi.v1.POSSE.Preview:output_type -> within.website.x.mi.v1.POSSEPreview
+	14, // 42: within.website.x.mi.v1.Events.Get:output_type -> within.website.x.mi.v1.EventFeed
+	25, // 43: within.website.x.mi.v1.Events.Add:output_type -> google.protobuf.Empty
+	25, // 44: within.website.x.mi.v1.Events.Remove:output_type -> google.protobuf.Empty
+	15, // 45: within.website.x.mi.v1.Webhooks.Subscribe:output_type -> within.website.x.mi.v1.Subscription
+	17, // 46: within.website.x.mi.v1.Webhooks.ListSubscriptions:output_type -> within.website.x.mi.v1.ListSubscriptionsResp
+	25, // 47: within.website.x.mi.v1.Webhooks.Unsubscribe:output_type -> google.protobuf.Empty
+	21, // 48: within.website.x.mi.v1.Webhooks.ListDeadLetters:output_type -> within.website.x.mi.v1.ListDeadLettersResp
+	25, // 49: within.website.x.mi.v1.Webhooks.RetryDeadLetter:output_type -> google.protobuf.Empty
+	35, // [35:50] is the sub-list for method output_type
+	20, // [20:35] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_within_website_x_mi_v1_mi_proto_init() }
@@ -777,13 +1691,14 @@ func file_within_website_x_mi_v1_mi_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_within_website_x_mi_v1_mi_proto_rawDesc), len(file_within_website_x_mi_v1_mi_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      2,
+			NumMessages:   22,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   4,
 		},
 		GoTypes:           file_within_website_x_mi_v1_mi_proto_goTypes,
 		DependencyIndexes: file_within_website_x_mi_v1_mi_proto_depIdxs,
+		EnumInfos:         file_within_website_x_mi_v1_mi_proto_enumTypes,
 		MessageInfos:      file_within_website_x_mi_v1_mi_proto_msgTypes,
 	}.Build()
 	File_within_website_x_mi_v1_mi_proto = out.File
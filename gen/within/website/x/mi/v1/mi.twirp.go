@@ -25,7 +25,9 @@ import (
 
 	ctxsetters "github.com/twitchtv/twirp/ctxsetters"
 
-	google_protobuf3 "google.golang.org/protobuf/types/known/emptypb"
+	google_protobuf4 "google.golang.org/protobuf/types/known/emptypb"
+
+	within_website_x_external_jsonfeed_v1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
 
 	bytes "bytes"
 
@@ -47,9 +49,9 @@ const _ = twirp.TwirpPackageMinVersion_8_1_0
 // =======================
 
 type SwitchTracker interface {
-	Members(context.Context, *google_protobuf3.Empty) (*MembersResp, error)
+	Members(context.Context, *google_protobuf4.Empty) (*MembersResp, error)
 
-	WhoIsFront(context.Context, *google_protobuf3.Empty) (*FrontChange, error)
+	WhoIsFront(context.Context, *google_protobuf4.Empty) (*FrontChange, error)
 
 	Switch(context.Context, *SwitchReq) (*SwitchResp, error)
 
@@ -108,18 +110,18 @@ func NewSwitchTrackerProtobufClient(baseURL string, client HTTPClient, opts ...t
 	}
 }
 
-func (c *switchTrackerProtobufClient) Members(ctx context.Context, in *google_protobuf3.Empty) (*MembersResp, error) {
+func (c *switchTrackerProtobufClient) Members(ctx context.Context, in *google_protobuf4.Empty) (*MembersResp, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "SwitchTracker")
 	ctx = ctxsetters.WithMethodName(ctx, "Members")
 	caller := c.callMembers
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *google_protobuf3.Empty) (*MembersResp, error) {
+		caller = func(ctx context.Context, req *google_protobuf4.Empty) (*MembersResp, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return c.callMembers(ctx, typedReq)
 				},
@@ -137,7 +139,7 @@ func (c *switchTrackerProtobufClient) Members(ctx context.Context, in *google_pr
 	return caller(ctx, in)
 }
 
-func (c *switchTrackerProtobufClient) callMembers(ctx context.Context, in *google_protobuf3.Empty) (*MembersResp, error) {
+func (c *switchTrackerProtobufClient) callMembers(ctx context.Context, in *google_protobuf4.Empty) (*MembersResp, error) {
 	out := new(MembersResp)
 	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
 	if err != nil {
@@ -154,18 +156,18 @@ func (c *switchTrackerProtobufClient) callMembers(ctx context.Context, in *googl
 	return out, nil
 }
 
-func (c *switchTrackerProtobufClient) WhoIsFront(ctx context.Context, in *google_protobuf3.Empty) (*FrontChange, error) {
+func (c *switchTrackerProtobufClient) WhoIsFront(ctx context.Context, in *google_protobuf4.Empty) (*FrontChange, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "SwitchTracker")
 	ctx = ctxsetters.WithMethodName(ctx, "WhoIsFront")
 	caller := c.callWhoIsFront
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *google_protobuf3.Empty) (*FrontChange, error) {
+		caller = func(ctx context.Context, req *google_protobuf4.Empty) (*FrontChange, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return c.callWhoIsFront(ctx, typedReq)
 				},
@@ -183,7 +185,7 @@ func (c *switchTrackerProtobufClient) WhoIsFront(ctx context.Context, in *google
 	return caller(ctx, in)
 }
 
-func (c *switchTrackerProtobufClient) callWhoIsFront(ctx context.Context, in *google_protobuf3.Empty) (*FrontChange, error) {
+func (c *switchTrackerProtobufClient) callWhoIsFront(ctx context.Context, in *google_protobuf4.Empty) (*FrontChange, error) {
 	out := new(FrontChange)
 	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
 	if err != nil {
@@ -388,18 +390,18 @@ func NewSwitchTrackerJSONClient(baseURL string, client HTTPClient, opts ...twirp
 	}
 }
 
-func (c *switchTrackerJSONClient) Members(ctx context.Context, in *google_protobuf3.Empty) (*MembersResp, error) {
+func (c *switchTrackerJSONClient) Members(ctx context.Context, in *google_protobuf4.Empty) (*MembersResp, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "SwitchTracker")
 	ctx = ctxsetters.WithMethodName(ctx, "Members")
 	caller := c.callMembers
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *google_protobuf3.Empty) (*MembersResp, error) {
+		caller = func(ctx context.Context, req *google_protobuf4.Empty) (*MembersResp, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return c.callMembers(ctx, typedReq)
 				},
@@ -417,7 +419,7 @@ func (c *switchTrackerJSONClient) Members(ctx context.Context, in *google_protob
 	return caller(ctx, in)
 }
 
-func (c *switchTrackerJSONClient) callMembers(ctx context.Context, in *google_protobuf3.Empty) (*MembersResp, error) {
+func (c *switchTrackerJSONClient) callMembers(ctx context.Context, in *google_protobuf4.Empty) (*MembersResp, error) {
 	out := new(MembersResp)
 	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
 	if err != nil {
@@ -434,18 +436,18 @@ func (c *switchTrackerJSONClient) callMembers(ctx context.Context, in *google_pr
 	return out, nil
 }
 
-func (c *switchTrackerJSONClient) WhoIsFront(ctx context.Context, in *google_protobuf3.Empty) (*FrontChange, error) {
+func (c *switchTrackerJSONClient) WhoIsFront(ctx context.Context, in *google_protobuf4.Empty) (*FrontChange, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "SwitchTracker")
 	ctx = ctxsetters.WithMethodName(ctx, "WhoIsFront")
 	caller := c.callWhoIsFront
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *google_protobuf3.Empty) (*FrontChange, error) {
+		caller = func(ctx context.Context, req *google_protobuf4.Empty) (*FrontChange, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return c.callWhoIsFront(ctx, typedReq)
 				},
@@ -463,7 +465,7 @@ func (c *switchTrackerJSONClient) WhoIsFront(ctx context.Context, in *google_pro
 	return caller(ctx, in)
 }
 
-func (c *switchTrackerJSONClient) callWhoIsFront(ctx context.Context, in *google_protobuf3.Empty) (*FrontChange, error) {
+func (c *switchTrackerJSONClient) callWhoIsFront(ctx context.Context, in *google_protobuf4.Empty) (*FrontChange, error) {
 	out := new(FrontChange)
 	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
 	if err != nil {
@@ -770,7 +772,7 @@ func (s *switchTrackerServer) serveMembersJSON(ctx context.Context, resp http.Re
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(google_protobuf3.Empty)
+	reqContent := new(google_protobuf4.Empty)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
@@ -779,12 +781,12 @@ func (s *switchTrackerServer) serveMembersJSON(ctx context.Context, resp http.Re
 
 	handler := s.SwitchTracker.Members
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *google_protobuf3.Empty) (*MembersResp, error) {
+		handler = func(ctx context.Context, req *google_protobuf4.Empty) (*MembersResp, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return s.SwitchTracker.Members(ctx, typedReq)
 				},
@@ -852,7 +854,7 @@ func (s *switchTrackerServer) serveMembersProtobuf(ctx context.Context, resp htt
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(google_protobuf3.Empty)
+	reqContent := new(google_protobuf4.Empty)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
@@ -860,12 +862,12 @@ func (s *switchTrackerServer) serveMembersProtobuf(ctx context.Context, resp htt
 
 	handler := s.SwitchTracker.Members
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *google_protobuf3.Empty) (*MembersResp, error) {
+		handler = func(ctx context.Context, req *google_protobuf4.Empty) (*MembersResp, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return s.SwitchTracker.Members(ctx, typedReq)
 				},
@@ -950,7 +952,7 @@ func (s *switchTrackerServer) serveWhoIsFrontJSON(ctx context.Context, resp http
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(google_protobuf3.Empty)
+	reqContent := new(google_protobuf4.Empty)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
@@ -959,12 +961,12 @@ func (s *switchTrackerServer) serveWhoIsFrontJSON(ctx context.Context, resp http
 
 	handler := s.SwitchTracker.WhoIsFront
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *google_protobuf3.Empty) (*FrontChange, error) {
+		handler = func(ctx context.Context, req *google_protobuf4.Empty) (*FrontChange, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return s.SwitchTracker.WhoIsFront(ctx, typedReq)
 				},
@@ -1032,7 +1034,7 @@ func (s *switchTrackerServer) serveWhoIsFrontProtobuf(ctx context.Context, resp
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(google_protobuf3.Empty)
+	reqContent := new(google_protobuf4.Empty)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
@@ -1040,12 +1042,12 @@ func (s *switchTrackerServer) serveWhoIsFrontProtobuf(ctx context.Context, resp
 
 	handler := s.SwitchTracker.WhoIsFront
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *google_protobuf3.Empty) (*FrontChange, error) {
+		handler = func(ctx context.Context, req *google_protobuf4.Empty) (*FrontChange, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return s.SwitchTracker.WhoIsFront(ctx, typedReq)
 				},
@@ -1657,7 +1659,13 @@ func (s *switchTrackerServer) PathPrefix() string {
 // ===============
 
 type POSSE interface {
-	RefreshBlog(context.Context, *google_protobuf3.Empty) (*google_protobuf3.Empty, error)
+	// RefreshBlog fetches the blog's JSON Feed and updates or deletes the posts
+	// made for blogposts that were edited or removed.
+	RefreshBlog(context.Context, *google_protobuf4.Empty) (*google_protobuf4.Empty, error)
+
+	// Preview shows what announcing a blogpost would post, without posting
+	// anything.
+	Preview(context.Context, *within_website_x_external_jsonfeed_v1.Item) (*POSSEPreview, error)
 }
 
 // =====================
@@ -1666,7 +1674,7 @@ type POSSE interface {
 
 type pOSSEProtobufClient struct {
 	client      HTTPClient
-	urls        [1]string
+	urls        [2]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -1694,8 +1702,9 @@ func NewPOSSEProtobufClient(baseURL string, client HTTPClient, opts ...twirp.Cli
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "within.website.x.mi.v1", "POSSE")
-	urls := [1]string{
+	urls := [2]string{
 		serviceURL + "RefreshBlog",
+		serviceURL + "Preview",
 	}
 
 	return &pOSSEProtobufClient{
@@ -1706,26 +1715,26 @@ func NewPOSSEProtobufClient(baseURL string, client HTTPClient, opts ...twirp.Cli
 	}
 }
 
-func (c *pOSSEProtobufClient) RefreshBlog(ctx context.Context, in *google_protobuf3.Empty) (*google_protobuf3.Empty, error) {
+func (c *pOSSEProtobufClient) RefreshBlog(ctx context.Context, in *google_protobuf4.Empty) (*google_protobuf4.Empty, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "POSSE")
 	ctx = ctxsetters.WithMethodName(ctx, "RefreshBlog")
 	caller := c.callRefreshBlog
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *google_protobuf3.Empty) (*google_protobuf3.Empty, error) {
+		caller = func(ctx context.Context, req *google_protobuf4.Empty) (*google_protobuf4.Empty, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return c.callRefreshBlog(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1735,8 +1744,8 @@ func (c *pOSSEProtobufClient) RefreshBlog(ctx context.Context, in *google_protob
 	return caller(ctx, in)
 }
 
-func (c *pOSSEProtobufClient) callRefreshBlog(ctx context.Context, in *google_protobuf3.Empty) (*google_protobuf3.Empty, error) {
-	out := new(google_protobuf3.Empty)
+func (c *pOSSEProtobufClient) callRefreshBlog(ctx context.Context, in *google_protobuf4.Empty) (*google_protobuf4.Empty, error) {
+	out := new(google_protobuf4.Empty)
 	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -1752,13 +1761,59 @@ func (c *pOSSEProtobufClient) callRefreshBlog(ctx context.Context, in *google_pr
 	return out, nil
 }
 
+func (c *pOSSEProtobufClient) Preview(ctx context.Context, in *within_website_x_external_jsonfeed_v1.Item) (*POSSEPreview, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "POSSE")
+	ctx = ctxsetters.WithMethodName(ctx, "Preview")
+	caller := c.callPreview
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *within_website_x_external_jsonfeed_v1.Item) (*POSSEPreview, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*within_website_x_external_jsonfeed_v1.Item)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*within_website_x_external_jsonfeed_v1.Item) when calling interceptor")
+					}
+					return c.callPreview(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*POSSEPreview)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*POSSEPreview) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *pOSSEProtobufClient) callPreview(ctx context.Context, in *within_website_x_external_jsonfeed_v1.Item) (*POSSEPreview, error) {
+	out := new(POSSEPreview)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // =================
 // POSSE JSON Client
 // =================
 
 type pOSSEJSONClient struct {
 	client      HTTPClient
-	urls        [1]string
+	urls        [2]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -1786,8 +1841,9 @@ func NewPOSSEJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientO
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "within.website.x.mi.v1", "POSSE")
-	urls := [1]string{
+	urls := [2]string{
 		serviceURL + "RefreshBlog",
+		serviceURL + "Preview",
 	}
 
 	return &pOSSEJSONClient{
@@ -1798,26 +1854,26 @@ func NewPOSSEJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientO
 	}
 }
 
-func (c *pOSSEJSONClient) RefreshBlog(ctx context.Context, in *google_protobuf3.Empty) (*google_protobuf3.Empty, error) {
+func (c *pOSSEJSONClient) RefreshBlog(ctx context.Context, in *google_protobuf4.Empty) (*google_protobuf4.Empty, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "POSSE")
 	ctx = ctxsetters.WithMethodName(ctx, "RefreshBlog")
 	caller := c.callRefreshBlog
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *google_protobuf3.Empty) (*google_protobuf3.Empty, error) {
+		caller = func(ctx context.Context, req *google_protobuf4.Empty) (*google_protobuf4.Empty, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return c.callRefreshBlog(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1827,8 +1883,8 @@ func (c *pOSSEJSONClient) RefreshBlog(ctx context.Context, in *google_protobuf3.
 	return caller(ctx, in)
 }
 
-func (c *pOSSEJSONClient) callRefreshBlog(ctx context.Context, in *google_protobuf3.Empty) (*google_protobuf3.Empty, error) {
-	out := new(google_protobuf3.Empty)
+func (c *pOSSEJSONClient) callRefreshBlog(ctx context.Context, in *google_protobuf4.Empty) (*google_protobuf4.Empty, error) {
+	out := new(google_protobuf4.Empty)
 	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -1844,6 +1900,52 @@ func (c *pOSSEJSONClient) callRefreshBlog(ctx context.Context, in *google_protob
 	return out, nil
 }
 
+func (c *pOSSEJSONClient) Preview(ctx context.Context, in *within_website_x_external_jsonfeed_v1.Item) (*POSSEPreview, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "POSSE")
+	ctx = ctxsetters.WithMethodName(ctx, "Preview")
+	caller := c.callPreview
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *within_website_x_external_jsonfeed_v1.Item) (*POSSEPreview, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*within_website_x_external_jsonfeed_v1.Item)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*within_website_x_external_jsonfeed_v1.Item) when calling interceptor")
+					}
+					return c.callPreview(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*POSSEPreview)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*POSSEPreview) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *pOSSEJSONClient) callPreview(ctx context.Context, in *within_website_x_external_jsonfeed_v1.Item) (*POSSEPreview, error) {
+	out := new(POSSEPreview)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ====================
 // POSSE Server Handler
 // ====================
@@ -1944,6 +2046,9 @@ func (s *pOSSEServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	case "RefreshBlog":
 		s.serveRefreshBlog(ctx, resp, req)
 		return
+	case "Preview":
+		s.servePreview(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
@@ -1984,7 +2089,7 @@ func (s *pOSSEServer) serveRefreshBlogJSON(ctx context.Context, resp http.Respon
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(google_protobuf3.Empty)
+	reqContent := new(google_protobuf4.Empty)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
@@ -1993,20 +2098,20 @@ func (s *pOSSEServer) serveRefreshBlogJSON(ctx context.Context, resp http.Respon
 
 	handler := s.POSSE.RefreshBlog
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *google_protobuf3.Empty) (*google_protobuf3.Empty, error) {
+		handler = func(ctx context.Context, req *google_protobuf4.Empty) (*google_protobuf4.Empty, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return s.POSSE.RefreshBlog(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -2015,7 +2120,7 @@ func (s *pOSSEServer) serveRefreshBlogJSON(ctx context.Context, resp http.Respon
 	}
 
 	// Call service method
-	var respContent *google_protobuf3.Empty
+	var respContent *google_protobuf4.Empty
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -2026,7 +2131,7 @@ func (s *pOSSEServer) serveRefreshBlogJSON(ctx context.Context, resp http.Respon
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf3.Empty and nil error while calling RefreshBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf4.Empty and nil error while calling RefreshBlog. nil responses are not supported"))
 		return
 	}
 
@@ -2066,7 +2171,7 @@ func (s *pOSSEServer) serveRefreshBlogProtobuf(ctx context.Context, resp http.Re
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(google_protobuf3.Empty)
+	reqContent := new(google_protobuf4.Empty)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
@@ -2074,20 +2179,200 @@ func (s *pOSSEServer) serveRefreshBlogProtobuf(ctx context.Context, resp http.Re
 
 	handler := s.POSSE.RefreshBlog
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *google_protobuf3.Empty) (*google_protobuf3.Empty, error) {
+		handler = func(ctx context.Context, req *google_protobuf4.Empty) (*google_protobuf4.Empty, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return s.POSSE.RefreshBlog(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *google_protobuf4.Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf4.Empty and nil error while calling RefreshBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *pOSSEServer) servePreview(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.servePreviewJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.servePreviewProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *pOSSEServer) servePreviewJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Preview")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(within_website_x_external_jsonfeed_v1.Item)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.POSSE.Preview
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *within_website_x_external_jsonfeed_v1.Item) (*POSSEPreview, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*within_website_x_external_jsonfeed_v1.Item)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*within_website_x_external_jsonfeed_v1.Item) when calling interceptor")
+					}
+					return s.POSSE.Preview(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*POSSEPreview)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*POSSEPreview) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *POSSEPreview
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *POSSEPreview and nil error while calling Preview. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *pOSSEServer) servePreviewProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Preview")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(within_website_x_external_jsonfeed_v1.Item)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.POSSE.Preview
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *within_website_x_external_jsonfeed_v1.Item) (*POSSEPreview, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*within_website_x_external_jsonfeed_v1.Item)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*within_website_x_external_jsonfeed_v1.Item) when calling interceptor")
+					}
+					return s.POSSE.Preview(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*POSSEPreview)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*POSSEPreview) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -2096,7 +2381,7 @@ func (s *pOSSEServer) serveRefreshBlogProtobuf(ctx context.Context, resp http.Re
 	}
 
 	// Call service method
-	var respContent *google_protobuf3.Empty
+	var respContent *POSSEPreview
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -2107,7 +2392,7 @@ func (s *pOSSEServer) serveRefreshBlogProtobuf(ctx context.Context, resp http.Re
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf3.Empty and nil error while calling RefreshBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *POSSEPreview and nil error while calling Preview. nil responses are not supported"))
 		return
 	}
 
@@ -2153,13 +2438,13 @@ func (s *pOSSEServer) PathPrefix() string {
 // Events lets users fetch the current feed of events that Xe will be attending.
 type Events interface {
 	// Get fetches the current feed of upcoming events.
-	Get(context.Context, *google_protobuf3.Empty) (*EventFeed, error)
+	Get(context.Context, *google_protobuf4.Empty) (*EventFeed, error)
 
 	// Add adds an event to the feed.
-	Add(context.Context, *Event) (*google_protobuf3.Empty, error)
+	Add(context.Context, *Event) (*google_protobuf4.Empty, error)
 
 	// Remove removes an event from the feed.
-	Remove(context.Context, *Event) (*google_protobuf3.Empty, error)
+	Remove(context.Context, *Event) (*google_protobuf4.Empty, error)
 }
 
 // ======================
@@ -2210,18 +2495,18 @@ func NewEventsProtobufClient(baseURL string, client HTTPClient, opts ...twirp.Cl
 	}
 }
 
-func (c *eventsProtobufClient) Get(ctx context.Context, in *google_protobuf3.Empty) (*EventFeed, error) {
+func (c *eventsProtobufClient) Get(ctx context.Context, in *google_protobuf4.Empty) (*EventFeed, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "Events")
 	ctx = ctxsetters.WithMethodName(ctx, "Get")
 	caller := c.callGet
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *google_protobuf3.Empty) (*EventFeed, error) {
+		caller = func(ctx context.Context, req *google_protobuf4.Empty) (*EventFeed, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return c.callGet(ctx, typedReq)
 				},
@@ -2239,7 +2524,7 @@ func (c *eventsProtobufClient) Get(ctx context.Context, in *google_protobuf3.Emp
 	return caller(ctx, in)
 }
 
-func (c *eventsProtobufClient) callGet(ctx context.Context, in *google_protobuf3.Empty) (*EventFeed, error) {
+func (c *eventsProtobufClient) callGet(ctx context.Context, in *google_protobuf4.Empty) (*EventFeed, error) {
 	out := new(EventFeed)
 	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
 	if err != nil {
@@ -2256,13 +2541,13 @@ func (c *eventsProtobufClient) callGet(ctx context.Context, in *google_protobuf3
 	return out, nil
 }
 
-func (c *eventsProtobufClient) Add(ctx context.Context, in *Event) (*google_protobuf3.Empty, error) {
+func (c *eventsProtobufClient) Add(ctx context.Context, in *Event) (*google_protobuf4.Empty, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "Events")
 	ctx = ctxsetters.WithMethodName(ctx, "Add")
 	caller := c.callAdd
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *Event) (*google_protobuf3.Empty, error) {
+		caller = func(ctx context.Context, req *Event) (*google_protobuf4.Empty, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
 					typedReq, ok := req.(*Event)
@@ -2273,9 +2558,9 @@ func (c *eventsProtobufClient) Add(ctx context.Context, in *Event) (*google_prot
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -2285,8 +2570,8 @@ func (c *eventsProtobufClient) Add(ctx context.Context, in *Event) (*google_prot
 	return caller(ctx, in)
 }
 
-func (c *eventsProtobufClient) callAdd(ctx context.Context, in *Event) (*google_protobuf3.Empty, error) {
-	out := new(google_protobuf3.Empty)
+func (c *eventsProtobufClient) callAdd(ctx context.Context, in *Event) (*google_protobuf4.Empty, error) {
+	out := new(google_protobuf4.Empty)
 	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -2302,13 +2587,13 @@ func (c *eventsProtobufClient) callAdd(ctx context.Context, in *Event) (*google_
 	return out, nil
 }
 
-func (c *eventsProtobufClient) Remove(ctx context.Context, in *Event) (*google_protobuf3.Empty, error) {
+func (c *eventsProtobufClient) Remove(ctx context.Context, in *Event) (*google_protobuf4.Empty, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "Events")
 	ctx = ctxsetters.WithMethodName(ctx, "Remove")
 	caller := c.callRemove
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *Event) (*google_protobuf3.Empty, error) {
+		caller = func(ctx context.Context, req *Event) (*google_protobuf4.Empty, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
 					typedReq, ok := req.(*Event)
@@ -2319,9 +2604,9 @@ func (c *eventsProtobufClient) Remove(ctx context.Context, in *Event) (*google_p
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -2331,8 +2616,8 @@ func (c *eventsProtobufClient) Remove(ctx context.Context, in *Event) (*google_p
 	return caller(ctx, in)
 }
 
-func (c *eventsProtobufClient) callRemove(ctx context.Context, in *Event) (*google_protobuf3.Empty, error) {
-	out := new(google_protobuf3.Empty)
+func (c *eventsProtobufClient) callRemove(ctx context.Context, in *Event) (*google_protobuf4.Empty, error) {
+	out := new(google_protobuf4.Empty)
 	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -2396,18 +2681,18 @@ func NewEventsJSONClient(baseURL string, client HTTPClient, opts ...twirp.Client
 	}
 }
 
-func (c *eventsJSONClient) Get(ctx context.Context, in *google_protobuf3.Empty) (*EventFeed, error) {
+func (c *eventsJSONClient) Get(ctx context.Context, in *google_protobuf4.Empty) (*EventFeed, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "Events")
 	ctx = ctxsetters.WithMethodName(ctx, "Get")
 	caller := c.callGet
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *google_protobuf3.Empty) (*EventFeed, error) {
+		caller = func(ctx context.Context, req *google_protobuf4.Empty) (*EventFeed, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return c.callGet(ctx, typedReq)
 				},
@@ -2425,7 +2710,7 @@ func (c *eventsJSONClient) Get(ctx context.Context, in *google_protobuf3.Empty)
 	return caller(ctx, in)
 }
 
-func (c *eventsJSONClient) callGet(ctx context.Context, in *google_protobuf3.Empty) (*EventFeed, error) {
+func (c *eventsJSONClient) callGet(ctx context.Context, in *google_protobuf4.Empty) (*EventFeed, error) {
 	out := new(EventFeed)
 	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
 	if err != nil {
@@ -2442,13 +2727,13 @@ func (c *eventsJSONClient) callGet(ctx context.Context, in *google_protobuf3.Emp
 	return out, nil
 }
 
-func (c *eventsJSONClient) Add(ctx context.Context, in *Event) (*google_protobuf3.Empty, error) {
+func (c *eventsJSONClient) Add(ctx context.Context, in *Event) (*google_protobuf4.Empty, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "Events")
 	ctx = ctxsetters.WithMethodName(ctx, "Add")
 	caller := c.callAdd
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *Event) (*google_protobuf3.Empty, error) {
+		caller = func(ctx context.Context, req *Event) (*google_protobuf4.Empty, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
 					typedReq, ok := req.(*Event)
@@ -2459,9 +2744,9 @@ func (c *eventsJSONClient) Add(ctx context.Context, in *Event) (*google_protobuf
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -2471,8 +2756,8 @@ func (c *eventsJSONClient) Add(ctx context.Context, in *Event) (*google_protobuf
 	return caller(ctx, in)
 }
 
-func (c *eventsJSONClient) callAdd(ctx context.Context, in *Event) (*google_protobuf3.Empty, error) {
-	out := new(google_protobuf3.Empty)
+func (c *eventsJSONClient) callAdd(ctx context.Context, in *Event) (*google_protobuf4.Empty, error) {
+	out := new(google_protobuf4.Empty)
 	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -2488,13 +2773,13 @@ func (c *eventsJSONClient) callAdd(ctx context.Context, in *Event) (*google_prot
 	return out, nil
 }
 
-func (c *eventsJSONClient) Remove(ctx context.Context, in *Event) (*google_protobuf3.Empty, error) {
+func (c *eventsJSONClient) Remove(ctx context.Context, in *Event) (*google_protobuf4.Empty, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
 	ctx = ctxsetters.WithServiceName(ctx, "Events")
 	ctx = ctxsetters.WithMethodName(ctx, "Remove")
 	caller := c.callRemove
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *Event) (*google_protobuf3.Empty, error) {
+		caller = func(ctx context.Context, req *Event) (*google_protobuf4.Empty, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
 					typedReq, ok := req.(*Event)
@@ -2505,9 +2790,9 @@ func (c *eventsJSONClient) Remove(ctx context.Context, in *Event) (*google_proto
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -2517,8 +2802,8 @@ func (c *eventsJSONClient) Remove(ctx context.Context, in *Event) (*google_proto
 	return caller(ctx, in)
 }
 
-func (c *eventsJSONClient) callRemove(ctx context.Context, in *Event) (*google_protobuf3.Empty, error) {
-	out := new(google_protobuf3.Empty)
+func (c *eventsJSONClient) callRemove(ctx context.Context, in *Event) (*google_protobuf4.Empty, error) {
+	out := new(google_protobuf4.Empty)
 	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -2680,7 +2965,7 @@ func (s *eventsServer) serveGetJSON(ctx context.Context, resp http.ResponseWrite
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(google_protobuf3.Empty)
+	reqContent := new(google_protobuf4.Empty)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
@@ -2689,12 +2974,12 @@ func (s *eventsServer) serveGetJSON(ctx context.Context, resp http.ResponseWrite
 
 	handler := s.Events.Get
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *google_protobuf3.Empty) (*EventFeed, error) {
+		handler = func(ctx context.Context, req *google_protobuf4.Empty) (*EventFeed, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return s.Events.Get(ctx, typedReq)
 				},
@@ -2762,7 +3047,7 @@ func (s *eventsServer) serveGetProtobuf(ctx context.Context, resp http.ResponseW
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(google_protobuf3.Empty)
+	reqContent := new(google_protobuf4.Empty)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
@@ -2770,12 +3055,12 @@ func (s *eventsServer) serveGetProtobuf(ctx context.Context, resp http.ResponseW
 
 	handler := s.Events.Get
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *google_protobuf3.Empty) (*EventFeed, error) {
+		handler = func(ctx context.Context, req *google_protobuf4.Empty) (*EventFeed, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*google_protobuf3.Empty)
+					typedReq, ok := req.(*google_protobuf4.Empty)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf3.Empty) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
 					}
 					return s.Events.Get(ctx, typedReq)
 				},
@@ -2869,7 +3154,7 @@ func (s *eventsServer) serveAddJSON(ctx context.Context, resp http.ResponseWrite
 
 	handler := s.Events.Add
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *Event) (*google_protobuf3.Empty, error) {
+		handler = func(ctx context.Context, req *Event) (*google_protobuf4.Empty, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
 					typedReq, ok := req.(*Event)
@@ -2880,9 +3165,9 @@ func (s *eventsServer) serveAddJSON(ctx context.Context, resp http.ResponseWrite
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -2891,7 +3176,7 @@ func (s *eventsServer) serveAddJSON(ctx context.Context, resp http.ResponseWrite
 	}
 
 	// Call service method
-	var respContent *google_protobuf3.Empty
+	var respContent *google_protobuf4.Empty
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -2902,7 +3187,7 @@ func (s *eventsServer) serveAddJSON(ctx context.Context, resp http.ResponseWrite
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf3.Empty and nil error while calling Add. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf4.Empty and nil error while calling Add. nil responses are not supported"))
 		return
 	}
 
@@ -2950,7 +3235,7 @@ func (s *eventsServer) serveAddProtobuf(ctx context.Context, resp http.ResponseW
 
 	handler := s.Events.Add
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *Event) (*google_protobuf3.Empty, error) {
+		handler = func(ctx context.Context, req *Event) (*google_protobuf4.Empty, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
 					typedReq, ok := req.(*Event)
@@ -2961,9 +3246,9 @@ func (s *eventsServer) serveAddProtobuf(ctx context.Context, resp http.ResponseW
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -2972,7 +3257,7 @@ func (s *eventsServer) serveAddProtobuf(ctx context.Context, resp http.ResponseW
 	}
 
 	// Call service method
-	var respContent *google_protobuf3.Empty
+	var respContent *google_protobuf4.Empty
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -2983,7 +3268,7 @@ func (s *eventsServer) serveAddProtobuf(ctx context.Context, resp http.ResponseW
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf3.Empty and nil error while calling Add. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf4.Empty and nil error while calling Add. nil responses are not supported"))
 		return
 	}
 
@@ -3049,7 +3334,7 @@ func (s *eventsServer) serveRemoveJSON(ctx context.Context, resp http.ResponseWr
 
 	handler := s.Events.Remove
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *Event) (*google_protobuf3.Empty, error) {
+		handler = func(ctx context.Context, req *Event) (*google_protobuf4.Empty, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
 					typedReq, ok := req.(*Event)
@@ -3060,9 +3345,9 @@ func (s *eventsServer) serveRemoveJSON(ctx context.Context, resp http.ResponseWr
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -3071,7 +3356,7 @@ func (s *eventsServer) serveRemoveJSON(ctx context.Context, resp http.ResponseWr
 	}
 
 	// Call service method
-	var respContent *google_protobuf3.Empty
+	var respContent *google_protobuf4.Empty
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -3082,7 +3367,7 @@ func (s *eventsServer) serveRemoveJSON(ctx context.Context, resp http.ResponseWr
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf3.Empty and nil error while calling Remove. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf4.Empty and nil error while calling Remove. nil responses are not supported"))
 		return
 	}
 
@@ -3130,7 +3415,7 @@ func (s *eventsServer) serveRemoveProtobuf(ctx context.Context, resp http.Respon
 
 	handler := s.Events.Remove
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *Event) (*google_protobuf3.Empty, error) {
+		handler = func(ctx context.Context, req *Event) (*google_protobuf4.Empty, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
 					typedReq, ok := req.(*Event)
@@ -3141,9 +3426,9 @@ func (s *eventsServer) serveRemoveProtobuf(ctx context.Context, resp http.Respon
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*google_protobuf3.Empty)
+				typedResp, ok := resp.(*google_protobuf4.Empty)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf3.Empty) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -3152,7 +3437,7 @@ func (s *eventsServer) serveRemoveProtobuf(ctx context.Context, resp http.Respon
 	}
 
 	// Call service method
-	var respContent *google_protobuf3.Empty
+	var respContent *google_protobuf4.Empty
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -3163,7 +3448,7 @@ func (s *eventsServer) serveRemoveProtobuf(ctx context.Context, resp http.Respon
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf3.Empty and nil error while calling Remove. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf4.Empty and nil error while calling Remove. nil responses are not supported"))
 		return
 	}
 
@@ -3202,6 +3487,1627 @@ func (s *eventsServer) PathPrefix() string {
 	return baseServicePath(s.pathPrefix, "within.website.x.mi.v1", "Events")
 }
 
+// ==================
+// Webhooks Interface
+// ==================
+
+// Webhooks manages the subscriptions that mi sends its events to.
+//
+// Events are sent as JSON payloads signed with the subscription's secret.
+// Deliveries that keep failing are moved to a dead-letter table, where they
+// can be inspected and retried.
+type Webhooks interface {
+	// Subscribe adds a subscription. The secret used to sign its payloads is
+	// only returned here.
+	Subscribe(context.Context, *SubscribeReq) (*Subscription, error)
+
+	// ListSubscriptions lists every subscription, without their secrets.
+	ListSubscriptions(context.Context, *google_protobuf4.Empty) (*ListSubscriptionsResp, error)
+
+	// Unsubscribe removes a subscription and its pending deliveries.
+	Unsubscribe(context.Context, *UnsubscribeReq) (*google_protobuf4.Empty, error)
+
+	// ListDeadLetters lists deliveries that failed too many times.
+	ListDeadLetters(context.Context, *ListDeadLettersReq) (*ListDeadLettersResp, error)
+
+	// RetryDeadLetter queues a dead-lettered delivery to be sent again.
+	RetryDeadLetter(context.Context, *RetryDeadLetterReq) (*google_protobuf4.Empty, error)
+}
+
+// ========================
+// Webhooks Protobuf Client
+// ========================
+
+type webhooksProtobufClient struct {
+	client      HTTPClient
+	urls        [5]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewWebhooksProtobufClient creates a Protobuf client that implements the Webhooks interface.
+// It communicates using Protobuf and can be configured with a custom HTTPClient.
+func NewWebhooksProtobufClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) Webhooks {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "within.website.x.mi.v1", "Webhooks")
+	urls := [5]string{
+		serviceURL + "Subscribe",
+		serviceURL + "ListSubscriptions",
+		serviceURL + "Unsubscribe",
+		serviceURL + "ListDeadLetters",
+		serviceURL + "RetryDeadLetter",
+	}
+
+	return &webhooksProtobufClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *webhooksProtobufClient) Subscribe(ctx context.Context, in *SubscribeReq) (*Subscription, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Webhooks")
+	ctx = ctxsetters.WithMethodName(ctx, "Subscribe")
+	caller := c.callSubscribe
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SubscribeReq) (*Subscription, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SubscribeReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SubscribeReq) when calling interceptor")
+					}
+					return c.callSubscribe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Subscription)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Subscription) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webhooksProtobufClient) callSubscribe(ctx context.Context, in *SubscribeReq) (*Subscription, error) {
+	out := new(Subscription)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *webhooksProtobufClient) ListSubscriptions(ctx context.Context, in *google_protobuf4.Empty) (*ListSubscriptionsResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Webhooks")
+	ctx = ctxsetters.WithMethodName(ctx, "ListSubscriptions")
+	caller := c.callListSubscriptions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *google_protobuf4.Empty) (*ListSubscriptionsResp, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*google_protobuf4.Empty)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
+					}
+					return c.callListSubscriptions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListSubscriptionsResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListSubscriptionsResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webhooksProtobufClient) callListSubscriptions(ctx context.Context, in *google_protobuf4.Empty) (*ListSubscriptionsResp, error) {
+	out := new(ListSubscriptionsResp)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *webhooksProtobufClient) Unsubscribe(ctx context.Context, in *UnsubscribeReq) (*google_protobuf4.Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Webhooks")
+	ctx = ctxsetters.WithMethodName(ctx, "Unsubscribe")
+	caller := c.callUnsubscribe
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UnsubscribeReq) (*google_protobuf4.Empty, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnsubscribeReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnsubscribeReq) when calling interceptor")
+					}
+					return c.callUnsubscribe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf4.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webhooksProtobufClient) callUnsubscribe(ctx context.Context, in *UnsubscribeReq) (*google_protobuf4.Empty, error) {
+	out := new(google_protobuf4.Empty)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *webhooksProtobufClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersReq) (*ListDeadLettersResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Webhooks")
+	ctx = ctxsetters.WithMethodName(ctx, "ListDeadLetters")
+	caller := c.callListDeadLetters
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListDeadLettersReq) (*ListDeadLettersResp, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListDeadLettersReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListDeadLettersReq) when calling interceptor")
+					}
+					return c.callListDeadLetters(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListDeadLettersResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListDeadLettersResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webhooksProtobufClient) callListDeadLetters(ctx context.Context, in *ListDeadLettersReq) (*ListDeadLettersResp, error) {
+	out := new(ListDeadLettersResp)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *webhooksProtobufClient) RetryDeadLetter(ctx context.Context, in *RetryDeadLetterReq) (*google_protobuf4.Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Webhooks")
+	ctx = ctxsetters.WithMethodName(ctx, "RetryDeadLetter")
+	caller := c.callRetryDeadLetter
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RetryDeadLetterReq) (*google_protobuf4.Empty, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RetryDeadLetterReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RetryDeadLetterReq) when calling interceptor")
+					}
+					return c.callRetryDeadLetter(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf4.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webhooksProtobufClient) callRetryDeadLetter(ctx context.Context, in *RetryDeadLetterReq) (*google_protobuf4.Empty, error) {
+	out := new(google_protobuf4.Empty)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ====================
+// Webhooks JSON Client
+// ====================
+
+type webhooksJSONClient struct {
+	client      HTTPClient
+	urls        [5]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewWebhooksJSONClient creates a JSON client that implements the Webhooks interface.
+// It communicates using JSON and can be configured with a custom HTTPClient.
+func NewWebhooksJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) Webhooks {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "within.website.x.mi.v1", "Webhooks")
+	urls := [5]string{
+		serviceURL + "Subscribe",
+		serviceURL + "ListSubscriptions",
+		serviceURL + "Unsubscribe",
+		serviceURL + "ListDeadLetters",
+		serviceURL + "RetryDeadLetter",
+	}
+
+	return &webhooksJSONClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *webhooksJSONClient) Subscribe(ctx context.Context, in *SubscribeReq) (*Subscription, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Webhooks")
+	ctx = ctxsetters.WithMethodName(ctx, "Subscribe")
+	caller := c.callSubscribe
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SubscribeReq) (*Subscription, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SubscribeReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SubscribeReq) when calling interceptor")
+					}
+					return c.callSubscribe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Subscription)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Subscription) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webhooksJSONClient) callSubscribe(ctx context.Context, in *SubscribeReq) (*Subscription, error) {
+	out := new(Subscription)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *webhooksJSONClient) ListSubscriptions(ctx context.Context, in *google_protobuf4.Empty) (*ListSubscriptionsResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Webhooks")
+	ctx = ctxsetters.WithMethodName(ctx, "ListSubscriptions")
+	caller := c.callListSubscriptions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *google_protobuf4.Empty) (*ListSubscriptionsResp, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*google_protobuf4.Empty)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
+					}
+					return c.callListSubscriptions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListSubscriptionsResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListSubscriptionsResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webhooksJSONClient) callListSubscriptions(ctx context.Context, in *google_protobuf4.Empty) (*ListSubscriptionsResp, error) {
+	out := new(ListSubscriptionsResp)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *webhooksJSONClient) Unsubscribe(ctx context.Context, in *UnsubscribeReq) (*google_protobuf4.Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Webhooks")
+	ctx = ctxsetters.WithMethodName(ctx, "Unsubscribe")
+	caller := c.callUnsubscribe
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UnsubscribeReq) (*google_protobuf4.Empty, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnsubscribeReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnsubscribeReq) when calling interceptor")
+					}
+					return c.callUnsubscribe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf4.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webhooksJSONClient) callUnsubscribe(ctx context.Context, in *UnsubscribeReq) (*google_protobuf4.Empty, error) {
+	out := new(google_protobuf4.Empty)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *webhooksJSONClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersReq) (*ListDeadLettersResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Webhooks")
+	ctx = ctxsetters.WithMethodName(ctx, "ListDeadLetters")
+	caller := c.callListDeadLetters
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListDeadLettersReq) (*ListDeadLettersResp, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListDeadLettersReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListDeadLettersReq) when calling interceptor")
+					}
+					return c.callListDeadLetters(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListDeadLettersResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListDeadLettersResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webhooksJSONClient) callListDeadLetters(ctx context.Context, in *ListDeadLettersReq) (*ListDeadLettersResp, error) {
+	out := new(ListDeadLettersResp)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *webhooksJSONClient) RetryDeadLetter(ctx context.Context, in *RetryDeadLetterReq) (*google_protobuf4.Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Webhooks")
+	ctx = ctxsetters.WithMethodName(ctx, "RetryDeadLetter")
+	caller := c.callRetryDeadLetter
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RetryDeadLetterReq) (*google_protobuf4.Empty, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RetryDeadLetterReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RetryDeadLetterReq) when calling interceptor")
+					}
+					return c.callRetryDeadLetter(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf4.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *webhooksJSONClient) callRetryDeadLetter(ctx context.Context, in *RetryDeadLetterReq) (*google_protobuf4.Empty, error) {
+	out := new(google_protobuf4.Empty)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// =======================
+// Webhooks Server Handler
+// =======================
+
+type webhooksServer struct {
+	Webhooks
+	interceptor      twirp.Interceptor
+	hooks            *twirp.ServerHooks
+	pathPrefix       string // prefix for routing
+	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
+	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
+}
+
+// NewWebhooksServer builds a TwirpServer that can be used as an http.Handler to handle
+// HTTP requests that are routed to the right method in the provided svc implementation.
+// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
+func NewWebhooksServer(svc Webhooks, opts ...interface{}) TwirpServer {
+	serverOpts := newServerOpts(opts)
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	jsonSkipDefaults := false
+	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
+	jsonCamelCase := false
+	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
+	var pathPrefix string
+	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	return &webhooksServer{
+		Webhooks:         svc,
+		hooks:            serverOpts.Hooks,
+		interceptor:      twirp.ChainInterceptors(serverOpts.Interceptors...),
+		pathPrefix:       pathPrefix,
+		jsonSkipDefaults: jsonSkipDefaults,
+		jsonCamelCase:    jsonCamelCase,
+	}
+}
+
+// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func (s *webhooksServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+	writeError(ctx, resp, err, s.hooks)
+}
+
+// handleRequestBodyError is used to handle error when the twirp server cannot read request
+func (s *webhooksServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
+	if context.Canceled == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
+		return
+	}
+	if context.DeadlineExceeded == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
+		return
+	}
+	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
+}
+
+// WebhooksPathPrefix is a convenience constant that may identify URL paths.
+// Should be used with caution, it only matches routes generated by Twirp Go clients,
+// with the default "/twirp" prefix and default CamelCase service and method names.
+// More info: https://twitchtv.github.io/twirp/docs/routing.html
+const WebhooksPathPrefix = "/twirp/within.website.x.mi.v1.Webhooks/"
+
+func (s *webhooksServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	ctx = ctxsetters.WithPackageName(ctx, "within.website.x.mi.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Webhooks")
+	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+
+	var err error
+	ctx, err = callRequestReceived(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	if req.Method != "POST" {
+		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
+	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
+	if pkgService != "within.website.x.mi.v1.Webhooks" {
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+	if prefix != s.pathPrefix {
+		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	switch method {
+	case "Subscribe":
+		s.serveSubscribe(ctx, resp, req)
+		return
+	case "ListSubscriptions":
+		s.serveListSubscriptions(ctx, resp, req)
+		return
+	case "Unsubscribe":
+		s.serveUnsubscribe(ctx, resp, req)
+		return
+	case "ListDeadLetters":
+		s.serveListDeadLetters(ctx, resp, req)
+		return
+	case "RetryDeadLetter":
+		s.serveRetryDeadLetter(ctx, resp, req)
+		return
+	default:
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+}
+
+func (s *webhooksServer) serveSubscribe(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSubscribeJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSubscribeProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *webhooksServer) serveSubscribeJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Subscribe")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SubscribeReq)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.Webhooks.Subscribe
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SubscribeReq) (*Subscription, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SubscribeReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SubscribeReq) when calling interceptor")
+					}
+					return s.Webhooks.Subscribe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Subscription)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Subscription) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *Subscription
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Subscription and nil error while calling Subscribe. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webhooksServer) serveSubscribeProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Subscribe")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SubscribeReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.Webhooks.Subscribe
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SubscribeReq) (*Subscription, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SubscribeReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SubscribeReq) when calling interceptor")
+					}
+					return s.Webhooks.Subscribe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Subscription)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Subscription) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *Subscription
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Subscription and nil error while calling Subscribe. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webhooksServer) serveListSubscriptions(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListSubscriptionsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListSubscriptionsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *webhooksServer) serveListSubscriptionsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListSubscriptions")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(google_protobuf4.Empty)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.Webhooks.ListSubscriptions
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *google_protobuf4.Empty) (*ListSubscriptionsResp, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*google_protobuf4.Empty)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
+					}
+					return s.Webhooks.ListSubscriptions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListSubscriptionsResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListSubscriptionsResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListSubscriptionsResp
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListSubscriptionsResp and nil error while calling ListSubscriptions. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webhooksServer) serveListSubscriptionsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListSubscriptions")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(google_protobuf4.Empty)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.Webhooks.ListSubscriptions
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *google_protobuf4.Empty) (*ListSubscriptionsResp, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*google_protobuf4.Empty)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*google_protobuf4.Empty) when calling interceptor")
+					}
+					return s.Webhooks.ListSubscriptions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListSubscriptionsResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListSubscriptionsResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListSubscriptionsResp
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListSubscriptionsResp and nil error while calling ListSubscriptions. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webhooksServer) serveUnsubscribe(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUnsubscribeJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUnsubscribeProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *webhooksServer) serveUnsubscribeJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Unsubscribe")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(UnsubscribeReq)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.Webhooks.Unsubscribe
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UnsubscribeReq) (*google_protobuf4.Empty, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnsubscribeReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnsubscribeReq) when calling interceptor")
+					}
+					return s.Webhooks.Unsubscribe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf4.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *google_protobuf4.Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf4.Empty and nil error while calling Unsubscribe. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webhooksServer) serveUnsubscribeProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Unsubscribe")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(UnsubscribeReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.Webhooks.Unsubscribe
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UnsubscribeReq) (*google_protobuf4.Empty, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnsubscribeReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnsubscribeReq) when calling interceptor")
+					}
+					return s.Webhooks.Unsubscribe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf4.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *google_protobuf4.Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf4.Empty and nil error while calling Unsubscribe. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webhooksServer) serveListDeadLetters(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListDeadLettersJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListDeadLettersProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *webhooksServer) serveListDeadLettersJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListDeadLetters")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListDeadLettersReq)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.Webhooks.ListDeadLetters
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListDeadLettersReq) (*ListDeadLettersResp, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListDeadLettersReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListDeadLettersReq) when calling interceptor")
+					}
+					return s.Webhooks.ListDeadLetters(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListDeadLettersResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListDeadLettersResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListDeadLettersResp
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListDeadLettersResp and nil error while calling ListDeadLetters. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webhooksServer) serveListDeadLettersProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListDeadLetters")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListDeadLettersReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.Webhooks.ListDeadLetters
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListDeadLettersReq) (*ListDeadLettersResp, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListDeadLettersReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListDeadLettersReq) when calling interceptor")
+					}
+					return s.Webhooks.ListDeadLetters(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListDeadLettersResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListDeadLettersResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListDeadLettersResp
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListDeadLettersResp and nil error while calling ListDeadLetters. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webhooksServer) serveRetryDeadLetter(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRetryDeadLetterJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRetryDeadLetterProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *webhooksServer) serveRetryDeadLetterJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RetryDeadLetter")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(RetryDeadLetterReq)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.Webhooks.RetryDeadLetter
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RetryDeadLetterReq) (*google_protobuf4.Empty, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RetryDeadLetterReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RetryDeadLetterReq) when calling interceptor")
+					}
+					return s.Webhooks.RetryDeadLetter(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf4.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *google_protobuf4.Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf4.Empty and nil error while calling RetryDeadLetter. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webhooksServer) serveRetryDeadLetterProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RetryDeadLetter")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(RetryDeadLetterReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.Webhooks.RetryDeadLetter
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RetryDeadLetterReq) (*google_protobuf4.Empty, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RetryDeadLetterReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RetryDeadLetterReq) when calling interceptor")
+					}
+					return s.Webhooks.RetryDeadLetter(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf4.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf4.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *google_protobuf4.Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf4.Empty and nil error while calling RetryDeadLetter. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *webhooksServer) ServiceDescriptor() ([]byte, int) {
+	return twirpFileDescriptor0, 3
+}
+
+func (s *webhooksServer) ProtocGenTwirpVersion() string {
+	return "v8.1.3"
+}
+
+// PathPrefix returns the base service path, in the form: "/<prefix>/<package>.<Service>/"
+// that is everything in a Twirp route except for the <Method>. This can be used for routing,
+// for example to identify the requests that are targeted to this service in a mux.
+func (s *webhooksServer) PathPrefix() string {
+	return baseServicePath(s.pathPrefix, "within.website.x.mi.v1", "Webhooks")
+}
+
 // =====
 // Utils
 // =====
@@ -3768,63 +5674,116 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 924 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xdd, 0x6e, 0xe3, 0x44,
-	0x14, 0x96, 0x9d, 0xc4, 0x49, 0x4e, 0xca, 0x8f, 0x46, 0xa8, 0x0a, 0xae, 0xca, 0x7a, 0xbd, 0x88,
-	0x0d, 0x37, 0xf6, 0x26, 0xc0, 0x0a, 0xb2, 0xac, 0x4a, 0x03, 0x6d, 0xb7, 0xd2, 0x86, 0xad, 0xdc,
-	0x9f, 0x54, 0x28, 0x52, 0x35, 0x89, 0x67, 0x93, 0x11, 0xf1, 0xcf, 0x7a, 0x26, 0x69, 0xf7, 0x82,
-	0x2b, 0xde, 0x84, 0x4b, 0x2e, 0x78, 0x80, 0x3e, 0x01, 0x70, 0xcd, 0x0d, 0xb7, 0x5c, 0xf2, 0x14,
-	0xc8, 0x33, 0x13, 0x6f, 0x52, 0xea, 0x34, 0x70, 0xe7, 0x39, 0xe7, 0xfb, 0x8e, 0xbf, 0x33, 0xe7,
-	0x3b, 0x96, 0xe1, 0xde, 0x25, 0xe5, 0x63, 0x1a, 0xba, 0x97, 0x64, 0xc0, 0x28, 0x27, 0xee, 0x95,
-	0x1b, 0x50, 0x77, 0xd6, 0x74, 0x03, 0xea, 0xc4, 0x49, 0xc4, 0x23, 0xb4, 0x29, 0x01, 0x8e, 0x02,
-	0x38, 0x57, 0x4e, 0x40, 0x9d, 0x59, 0xd3, 0xdc, 0x1a, 0x4c, 0x5f, 0xba, 0x33, 0x3c, 0xa1, 0x3e,
-	0xe6, 0x24, 0x7b, 0x90, 0x24, 0x73, 0x6b, 0x14, 0x45, 0xa3, 0x09, 0x71, 0xc5, 0x29, 0x05, 0x92,
-	0x20, 0xe6, 0xaf, 0x55, 0xf2, 0xde, 0xcd, 0x24, 0xa7, 0x01, 0x61, 0x1c, 0x07, 0xb1, 0x04, 0xd8,
-	0x07, 0x50, 0xeb, 0x92, 0x60, 0x40, 0x12, 0xe6, 0x11, 0x16, 0xa3, 0xcf, 0xa1, 0x1c, 0xc8, 0x63,
-	0x5d, 0xb3, 0x0a, 0x8d, 0x5a, 0xeb, 0x03, 0xe7, 0x76, 0x4d, 0x8e, 0x64, 0x79, 0x73, 0xb8, 0xfd,
-	0xa3, 0x06, 0x86, 0x8c, 0xa1, 0xb7, 0x41, 0xa7, 0x7e, 0x5d, 0xb3, 0xb4, 0x46, 0xc9, 0xd3, 0xa9,
-	0x8f, 0x10, 0x14, 0x43, 0x1c, 0x90, 0xba, 0x6e, 0x69, 0x8d, 0xaa, 0x27, 0x9e, 0xd1, 0x36, 0x00,
-	0x9e, 0x61, 0x8e, 0x93, 0x8b, 0x69, 0x32, 0xa9, 0x17, 0x44, 0xa6, 0x2a, 0x23, 0xa7, 0xc9, 0x04,
-	0x3d, 0x86, 0xca, 0x80, 0x26, 0x7c, 0xec, 0xe3, 0xd7, 0xf5, 0xa2, 0xa5, 0x35, 0x6a, 0x2d, 0xd3,
-	0x91, 0xad, 0x38, 0xf3, 0x56, 0x9c, 0x93, 0x79, 0x2b, 0x5e, 0x86, 0xb5, 0x23, 0x30, 0x8e, 0x2f,
-	0x29, 0x1f, 0x8e, 0x17, 0x44, 0x54, 0x85, 0x88, 0x2d, 0xa8, 0x4a, 0xa9, 0x17, 0xd4, 0x17, 0x4a,
-	0x4a, 0x5e, 0x45, 0x06, 0x0e, 0xfd, 0x54, 0x0d, 0xe3, 0x38, 0xe1, 0xc4, 0xbf, 0xc0, 0x7c, 0xae,
-	0x46, 0x45, 0x76, 0x39, 0x7a, 0x1f, 0x2a, 0x24, 0xf4, 0x65, 0xb2, 0x28, 0x92, 0x65, 0x71, 0xde,
-	0xe5, 0xf6, 0xa7, 0x50, 0x95, 0x2f, 0xf4, 0xc8, 0x2b, 0xf4, 0x10, 0x6a, 0xea, 0x1d, 0xa2, 0x5f,
-	0xf1, 0xf2, 0x8e, 0x71, 0xfd, 0xac, 0xf0, 0xab, 0xa6, 0x79, 0x20, 0x53, 0xdf, 0xe2, 0x80, 0xd8,
-	0x57, 0x00, 0x73, 0x16, 0x8b, 0xd1, 0x23, 0x28, 0x44, 0x13, 0xa9, 0x75, 0xc5, 0x85, 0x2b, 0x42,
-	0x0a, 0x4d, 0xc7, 0x34, 0x9c, 0x26, 0x09, 0x09, 0xb9, 0x68, 0xe5, 0x6e, 0xd6, 0x1c, 0x6e, 0x7f,
-	0x04, 0x1b, 0x07, 0x84, 0xbf, 0x91, 0xbc, 0xf9, 0xe6, 0x9a, 0x32, 0xa5, 0x3a, 0xf5, 0xed, 0x1f,
-	0xa0, 0xb6, 0x9f, 0x44, 0x21, 0xff, 0x7a, 0x8c, 0xc3, 0x11, 0x41, 0x8f, 0xc1, 0x60, 0x82, 0xb3,
-	0xa6, 0x4a, 0x85, 0x4e, 0x79, 0xb2, 0xed, 0xbb, 0x74, 0x2a, 0x3b, 0x29, 0xb4, 0xfd, 0x04, 0xde,
-	0x79, 0x4e, 0x99, 0xd2, 0x49, 0x58, 0xaa, 0xf4, 0x3d, 0x28, 0x0d, 0xa3, 0x69, 0xc8, 0x95, 0xb1,
-	0xe4, 0x21, 0xf5, 0x56, 0x8c, 0x47, 0x44, 0x4d, 0x54, 0x3c, 0xdb, 0xc7, 0xf0, 0xee, 0x32, 0x99,
-	0xc5, 0x68, 0x07, 0x2a, 0x4c, 0x9d, 0x95, 0xb3, 0x1f, 0xe4, 0x49, 0x59, 0xe8, 0xdb, 0xcb, 0x48,
-	0xf6, 0x9f, 0x3a, 0x94, 0xf6, 0x66, 0x24, 0xe4, 0xc8, 0x54, 0x76, 0x5e, 0xbe, 0x34, 0x69, 0xeb,
-	0x13, 0x28, 0xa4, 0x7e, 0x16, 0x4e, 0xef, 0x74, 0xae, 0x9f, 0xed, 0x5c, 0x6b, 0x5f, 0x00, 0x4c,
-	0x93, 0x89, 0x43, 0x59, 0xea, 0x74, 0xb4, 0x2d, 0x6a, 0x58, 0xa7, 0xde, 0x73, 0x2b, 0x98, 0x32,
-	0x6e, 0x0d, 0x88, 0x85, 0x2d, 0xb1, 0xd1, 0x69, 0xcc, 0xdc, 0xe0, 0x63, 0xca, 0x1c, 0xca, 0x4e,
-	0x13, 0xda, 0xf8, 0x38, 0x2d, 0x9b, 0x96, 0x43, 0xbb, 0xca, 0x9e, 0x17, 0xe9, 0xda, 0x0b, 0x7b,
-	0xae, 0xdc, 0x87, 0x4c, 0x93, 0xb4, 0xf0, 0x37, 0x98, 0x13, 0xf4, 0x54, 0x58, 0x58, 0x16, 0x28,
-	0xae, 0x5d, 0x20, 0xb5, 0xb9, 0xa0, 0xdb, 0x50, 0x99, 0x44, 0x43, 0xcc, 0x69, 0x14, 0xd6, 0x4b,
-	0x4b, 0x7d, 0x67, 0x71, 0xb5, 0x71, 0x46, 0xb6, 0xf6, 0x0d, 0xa8, 0xf9, 0x84, 0x0d, 0x13, 0x1a,
-	0x0b, 0x5a, 0x79, 0x89, 0xb6, 0x98, 0xb2, 0x3b, 0x50, 0x15, 0xd7, 0xb2, 0x4f, 0x88, 0x8f, 0x3e,
-	0x03, 0x83, 0xa4, 0x87, 0xf9, 0x9c, 0xb6, 0xf3, 0xe6, 0x24, 0x28, 0x9e, 0x02, 0xb7, 0x7e, 0x29,
-	0xc0, 0x5b, 0x72, 0xe2, 0x27, 0x09, 0x1e, 0x7e, 0x4f, 0x12, 0xb4, 0x0f, 0x65, 0xf5, 0x69, 0x43,
-	0x9b, 0xff, 0xea, 0x75, 0x2f, 0xfd, 0x48, 0x9a, 0x0f, 0x56, 0xdb, 0x51, 0x5a, 0xe7, 0x10, 0xa0,
-	0x37, 0x8e, 0x0e, 0x99, 0xf0, 0xc5, 0x7f, 0x2f, 0xb5, 0xb8, 0x46, 0x2f, 0xb2, 0xcf, 0xd3, 0xfd,
-	0x3b, 0x16, 0x88, 0xbc, 0x32, 0xed, 0xbb, 0x20, 0x2c, 0x46, 0x67, 0x50, 0xcd, 0xd6, 0x19, 0x7d,
-	0x98, 0x47, 0x58, 0xdc, 0xf8, 0xf5, 0x84, 0x62, 0xd8, 0x58, 0x5c, 0x21, 0xf4, 0x30, 0x8f, 0x74,
-	0x63, 0x4b, 0xcd, 0xc6, 0x7a, 0x40, 0x16, 0xb7, 0xf6, 0xa1, 0x74, 0xf4, 0xe2, 0xf8, 0x78, 0x0f,
-	0x3d, 0x85, 0x9a, 0x47, 0x5e, 0x26, 0x84, 0x8d, 0x3b, 0x93, 0x68, 0x94, 0x7b, 0xc1, 0x39, 0xf1,
-	0xd6, 0x6f, 0x1a, 0x18, 0xc2, 0x0a, 0x0c, 0x7d, 0x05, 0x85, 0x03, 0x92, 0x3f, 0xa2, 0xfb, 0x2b,
-	0x9d, 0x24, 0xcc, 0xf7, 0x25, 0x14, 0x76, 0x7d, 0x1f, 0xad, 0xf6, 0x5c, 0x9e, 0x14, 0xb4, 0x03,
-	0x86, 0x47, 0x82, 0x68, 0x46, 0xfe, 0x67, 0x81, 0xce, 0x1f, 0x1a, 0x98, 0xc3, 0x28, 0xc8, 0x21,
-	0x77, 0xca, 0x5d, 0x7a, 0x94, 0x12, 0x8e, 0xb4, 0xef, 0x1e, 0x2d, 0x43, 0xdc, 0x2b, 0x77, 0x44,
-	0x42, 0xf7, 0xf6, 0x1f, 0x8c, 0x27, 0x01, 0x9d, 0x35, 0x7f, 0xd2, 0x8b, 0xbd, 0xde, 0x79, 0xf7,
-	0x67, 0x7d, 0xb3, 0x27, 0x99, 0x3d, 0x55, 0xfc, 0xdc, 0xe9, 0x52, 0xe7, 0xac, 0xf9, 0xfb, 0x3c,
-	0xd1, 0x57, 0x89, 0xfe, 0x79, 0xbf, 0x4b, 0xfb, 0x67, 0xcd, 0xbf, 0x74, 0xfb, 0xf6, 0x44, 0xff,
-	0xe0, 0xa8, 0xd3, 0x25, 0x1c, 0xfb, 0x98, 0xe3, 0xbf, 0x75, 0x53, 0x82, 0xda, 0x6d, 0x85, 0x6a,
-	0xb7, 0xcf, 0xdb, 0xed, 0x2e, 0x6d, 0xb7, 0xcf, 0x9a, 0x03, 0x43, 0xb4, 0xf9, 0xc9, 0x3f, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0x10, 0x71, 0x06, 0x1d, 0x01, 0x09, 0x00, 0x00,
+	// 1761 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xcd, 0x72, 0x1b, 0xc7,
+	0x11, 0xf6, 0xe2, 0x1f, 0x0d, 0x5a, 0x82, 0xc7, 0x36, 0x03, 0x43, 0xa1, 0x04, 0xad, 0x55, 0x16,
+	0x22, 0x27, 0x0b, 0x93, 0xb1, 0x1d, 0x1b, 0x92, 0x4a, 0x06, 0x48, 0x50, 0x44, 0x24, 0x82, 0xac,
+	0x05, 0x29, 0xaa, 0x1c, 0x56, 0xa1, 0x06, 0xd8, 0x11, 0x31, 0x26, 0xb0, 0x0b, 0xef, 0x0c, 0x41,
+	0xf2, 0x90, 0x4b, 0x9c, 0x63, 0x72, 0xc9, 0x23, 0xa4, 0x72, 0x4a, 0xa5, 0xf2, 0x00, 0xac, 0x3c,
+	0x80, 0x93, 0x5b, 0xaa, 0xf2, 0x04, 0x39, 0xe6, 0x0d, 0x72, 0x4b, 0xcd, 0xcf, 0x82, 0x0b, 0x82,
+	0x0b, 0xc2, 0xb9, 0xa1, 0x7f, 0xa7, 0xfb, 0x9b, 0x9e, 0xee, 0x5e, 0xc0, 0xbd, 0x53, 0xca, 0xfb,
+	0xd4, 0xad, 0x9c, 0x92, 0x2e, 0xa3, 0x9c, 0x54, 0xce, 0x2a, 0x43, 0x5a, 0x19, 0xaf, 0x56, 0x86,
+	0xd4, 0x1a, 0xf9, 0x1e, 0xf7, 0xd0, 0xb2, 0x52, 0xb0, 0xb4, 0x82, 0x75, 0x66, 0x0d, 0xa9, 0x35,
+	0x5e, 0x2d, 0xde, 0xe9, 0x9e, 0xbc, 0xa9, 0x8c, 0xf1, 0x80, 0x3a, 0x98, 0x93, 0xc9, 0x0f, 0x65,
+	0x54, 0xbc, 0x73, 0xe4, 0x79, 0x47, 0x03, 0x52, 0x91, 0x94, 0x50, 0x24, 0xc3, 0x11, 0x3f, 0xd7,
+	0xc2, 0x7b, 0x57, 0x85, 0x9c, 0x0e, 0x09, 0xe3, 0x78, 0x38, 0xd2, 0x0a, 0x9f, 0xce, 0xc4, 0x44,
+	0xce, 0x38, 0xf1, 0x5d, 0x3c, 0xa8, 0x7c, 0xc3, 0x3c, 0xf7, 0x0d, 0x21, 0x8e, 0x08, 0x31, 0xf8,
+	0xad, 0xac, 0xcc, 0xe7, 0x90, 0xdb, 0x26, 0xc3, 0x2e, 0xf1, 0x99, 0x4d, 0xd8, 0x08, 0x7d, 0x01,
+	0xe9, 0xa1, 0x22, 0x0b, 0x46, 0x29, 0x5e, 0xce, 0xad, 0xdd, 0xb5, 0xae, 0xcf, 0xc4, 0x52, 0x56,
+	0x76, 0xa0, 0x6e, 0x7e, 0x67, 0x40, 0x4a, 0xf1, 0xd0, 0x2d, 0x88, 0x51, 0xa7, 0x60, 0x94, 0x8c,
+	0x72, 0xd2, 0x8e, 0x51, 0x07, 0x21, 0x48, 0xb8, 0x78, 0x48, 0x0a, 0xb1, 0x92, 0x51, 0xce, 0xda,
+	0xf2, 0x37, 0x5a, 0x01, 0xc0, 0x63, 0xcc, 0xb1, 0xdf, 0x39, 0xf1, 0x07, 0x85, 0xb8, 0x94, 0x64,
+	0x15, 0x67, 0xdf, 0x1f, 0xa0, 0xcf, 0x21, 0xd3, 0xa5, 0x3e, 0xef, 0x3b, 0xf8, 0xbc, 0x90, 0x28,
+	0x19, 0xe5, 0xdc, 0x5a, 0xd1, 0x52, 0x00, 0x58, 0x01, 0x00, 0xd6, 0x5e, 0x00, 0x80, 0x3d, 0xd1,
+	0x35, 0x3d, 0x48, 0xb5, 0x4f, 0x29, 0xef, 0xf5, 0x43, 0x41, 0x64, 0x65, 0x10, 0x77, 0x20, 0xab,
+	0x42, 0xed, 0x50, 0x47, 0x46, 0x92, 0xb4, 0x33, 0x8a, 0xd1, 0x74, 0x44, 0x34, 0x8c, 0x63, 0x9f,
+	0x13, 0xa7, 0x83, 0x79, 0x10, 0x8d, 0xe6, 0xd4, 0x38, 0xfa, 0x00, 0x32, 0xc4, 0x75, 0x94, 0x30,
+	0x21, 0x85, 0x69, 0x49, 0xd7, 0xb8, 0xf9, 0x29, 0x64, 0xd5, 0x81, 0x36, 0xf9, 0x16, 0x3d, 0x84,
+	0x9c, 0x3e, 0x43, 0xe6, 0x2b, 0x0f, 0xaf, 0xa7, 0x2e, 0xb6, 0xe2, 0xdf, 0x1b, 0x86, 0x0d, 0x4a,
+	0xd4, 0xc2, 0x43, 0x62, 0x9e, 0x01, 0x04, 0x56, 0x6c, 0x84, 0x3e, 0x81, 0xb8, 0x37, 0x50, 0xb1,
+	0xce, 0x01, 0x5c, 0x1b, 0x08, 0x55, 0x71, 0x4d, 0xbd, 0x13, 0xdf, 0x27, 0x2e, 0x2f, 0xc4, 0x16,
+	0xb2, 0x0a, 0xd4, 0xcd, 0x8f, 0x60, 0xe9, 0x39, 0xe1, 0x97, 0x21, 0x2f, 0x5f, 0xc2, 0x34, 0x89,
+	0x34, 0x46, 0x1d, 0xf3, 0xd7, 0x90, 0xdb, 0xf4, 0x3d, 0x97, 0xaf, 0xf7, 0xb1, 0x7b, 0x44, 0xd0,
+	0xe7, 0x90, 0x62, 0xd2, 0x66, 0xc1, 0x28, 0xb5, 0xb6, 0xb0, 0x53, 0x69, 0xdf, 0x14, 0xa7, 0x2e,
+	0x27, 0xad, 0x6d, 0x3e, 0x86, 0xdb, 0x2f, 0x29, 0xd3, 0x71, 0x12, 0x26, 0x22, 0x7d, 0x0f, 0x92,
+	0x3d, 0xef, 0xc4, 0xe5, 0xba, 0xb0, 0x14, 0x21, 0x6a, 0x6b, 0x84, 0x8f, 0x88, 0xbe, 0x51, 0xf9,
+	0xdb, 0x6c, 0x43, 0x7e, 0xda, 0x98, 0x8d, 0xd0, 0x33, 0xc8, 0x30, 0x4d, 0xeb, 0xca, 0xfe, 0x30,
+	0x2a, 0x94, 0x50, 0xde, 0xf6, 0xc4, 0xc8, 0xfc, 0x8b, 0x01, 0xb9, 0xdd, 0x9d, 0x76, 0xbb, 0xb1,
+	0xd7, 0xf7, 0x09, 0x76, 0x50, 0x01, 0xd2, 0x2e, 0xe1, 0xa7, 0x9e, 0x7f, 0xac, 0x8b, 0x2c, 0x20,
+	0xd1, 0x63, 0x48, 0xe1, 0x1e, 0xa7, 0x9e, 0x2b, 0x83, 0xba, 0x15, 0x7d, 0x90, 0x74, 0x57, 0x93,
+	0xaa, 0xb6, 0x36, 0x11, 0x59, 0x0e, 0xe8, 0x90, 0xaa, 0x22, 0x4c, 0xda, 0x8a, 0x10, 0xdc, 0x91,
+	0xc7, 0x38, 0x2b, 0x24, 0x4a, 0xf1, 0x72, 0xd6, 0x56, 0x04, 0x2a, 0x42, 0x86, 0x9c, 0x51, 0xc6,
+	0xa9, 0x7b, 0x54, 0x48, 0x4a, 0xc1, 0x84, 0x36, 0x7b, 0xb0, 0x24, 0xdd, 0xef, 0xfa, 0x64, 0x4c,
+	0xc9, 0xa9, 0xf0, 0x70, 0xec, 0x7a, 0xa7, 0xae, 0x0c, 0x36, 0x63, 0x2b, 0x02, 0x3d, 0x85, 0x34,
+	0x97, 0xe9, 0xb0, 0x42, 0x6c, 0x3e, 0x28, 0xa1, 0xd4, 0xed, 0xc0, 0xc6, 0xfc, 0x6f, 0x0c, 0x92,
+	0x8d, 0x31, 0x71, 0x39, 0x2a, 0xea, 0x27, 0x3e, 0x5d, 0x48, 0x92, 0x87, 0xf6, 0x20, 0x2e, 0xde,
+	0xb8, 0x7c, 0xfd, 0xf5, 0xfa, 0xc5, 0xd6, 0xb3, 0xef, 0x0d, 0xe3, 0xc2, 0xf8, 0x12, 0xe0, 0xc4,
+	0x1f, 0x58, 0x94, 0x89, 0x06, 0x80, 0x56, 0xa4, 0x9b, 0xd2, 0xbe, 0xfd, 0xb2, 0x34, 0x3c, 0x61,
+	0xbc, 0xd4, 0x25, 0x25, 0x5c, 0x92, 0xed, 0x51, 0xf0, 0x8a, 0x4b, 0xbc, 0x4f, 0x99, 0x45, 0xd9,
+	0xbe, 0x4f, 0xcb, 0x3f, 0xb1, 0x85, 0x3b, 0x54, 0xd3, 0x4f, 0xb6, 0x23, 0x1a, 0x68, 0x21, 0x7e,
+	0x53, 0x8f, 0x98, 0xc4, 0xa4, 0x9e, 0xf5, 0x06, 0xe6, 0x04, 0x3d, 0x95, 0xcf, 0x5a, 0x39, 0x48,
+	0x2c, 0xec, 0x40, 0x3c, 0x7d, 0x69, 0x6e, 0x42, 0x66, 0xe0, 0xf5, 0xb0, 0xbc, 0xe9, 0xe4, 0x54,
+	0xde, 0x13, 0xbe, 0xee, 0x42, 0xa9, 0x49, 0x2b, 0x2c, 0x43, 0xce, 0x21, 0xac, 0xe7, 0xd3, 0x91,
+	0x34, 0x4b, 0x4f, 0x99, 0x85, 0x45, 0xe8, 0x2e, 0x80, 0x4f, 0xd4, 0xab, 0xed, 0x91, 0x42, 0x46,
+	0x96, 0x58, 0x88, 0x63, 0xd6, 0x21, 0x2b, 0x31, 0xdb, 0x24, 0xc4, 0x41, 0x9f, 0x41, 0x8a, 0x08,
+	0x22, 0xa8, 0xed, 0x95, 0xa8, 0x6b, 0x94, 0x26, 0xb6, 0x56, 0x36, 0x7f, 0x1b, 0x83, 0xa5, 0xf6,
+	0x49, 0xf7, 0xf2, 0xd0, 0xab, 0x4d, 0xf3, 0x09, 0x24, 0x8e, 0xa9, 0xeb, 0xe8, 0x42, 0x2e, 0x47,
+	0x3e, 0xfa, 0x90, 0x8f, 0x17, 0xd4, 0x75, 0x6c, 0x69, 0x85, 0xf2, 0xea, 0xe2, 0x55, 0x3b, 0x15,
+	0x3f, 0xd1, 0x3d, 0xc8, 0xc9, 0xa3, 0x3b, 0xfc, 0x7c, 0x44, 0x82, 0x6a, 0x06, 0xc9, 0xda, 0x13,
+	0x1c, 0xb4, 0x0c, 0x29, 0x46, 0x7a, 0x3e, 0xe1, 0x0a, 0x51, 0x5b, 0x53, 0xe8, 0x4b, 0x80, 0x9e,
+	0x4f, 0xb0, 0x6e, 0xd0, 0xa9, 0x1b, 0x27, 0x42, 0x56, 0x6b, 0xd7, 0x38, 0x2a, 0x5d, 0x03, 0xf9,
+	0x14, 0xd4, 0xe6, 0xdf, 0x2e, 0x61, 0xe8, 0x12, 0xd1, 0x6a, 0xb6, 0x74, 0xda, 0xc6, 0x0f, 0x4b,
+	0xbb, 0x0e, 0x17, 0x5b, 0xe9, 0xdf, 0x18, 0x89, 0xbc, 0x51, 0x7a, 0x4b, 0x43, 0xf0, 0xab, 0x70,
+	0xed, 0x37, 0x2f, 0xb6, 0x36, 0x2f, 0x8c, 0xaf, 0xa6, 0x0a, 0xff, 0x41, 0xd8, 0xc9, 0x62, 0xf5,
+	0x2f, 0x4a, 0x45, 0xa2, 0xd9, 0x9b, 0x46, 0x33, 0x5e, 0x8a, 0xeb, 0x07, 0xf6, 0x07, 0xe3, 0x89,
+	0x59, 0xf5, 0xbf, 0x08, 0xba, 0xb0, 0xad, 0xd4, 0x2c, 0xec, 0x38, 0xc4, 0xb1, 0xdf, 0x56, 0x84,
+	0x4f, 0x86, 0xde, 0x98, 0x38, 0x36, 0xea, 0x0e, 0xbc, 0x23, 0xd1, 0x4f, 0xac, 0xd1, 0x49, 0x77,
+	0x40, 0x59, 0x9f, 0x38, 0x53, 0x37, 0x72, 0x05, 0xbe, 0xc4, 0x2c, 0x7c, 0x3d, 0x78, 0x5f, 0xb6,
+	0xdb, 0x50, 0x22, 0xaa, 0xe7, 0xfe, 0x12, 0xde, 0x66, 0x61, 0xa6, 0x2e, 0xce, 0x07, 0x8b, 0xe0,
+	0x69, 0x4f, 0x9b, 0x9a, 0x65, 0xb8, 0xb5, 0xef, 0xb2, 0xf0, 0x25, 0x45, 0x4d, 0xae, 0xdf, 0xc7,
+	0x00, 0x36, 0x08, 0x76, 0x5e, 0x12, 0xce, 0x89, 0x3f, 0x53, 0xd2, 0x0f, 0xe1, 0x76, 0xd8, 0x73,
+	0xb0, 0x0d, 0x64, 0xed, 0x5b, 0x61, 0x76, 0xd3, 0x91, 0x43, 0x5f, 0xa2, 0x4b, 0x1d, 0x5d, 0xc2,
+	0x69, 0x49, 0xab, 0x75, 0xe1, 0x12, 0x78, 0x0d, 0x49, 0x76, 0x82, 0x99, 0xe8, 0xcb, 0x98, 0x73,
+	0xb1, 0xbc, 0x31, 0x59, 0xc6, 0x49, 0x7b, 0x42, 0x0b, 0xd3, 0x01, 0x66, 0xbc, 0x43, 0x7c, 0xdf,
+	0xf3, 0x65, 0x21, 0x67, 0xed, 0xac, 0xe0, 0x34, 0x04, 0x03, 0xfd, 0x02, 0xb2, 0x6f, 0x30, 0x1d,
+	0xa8, 0x32, 0x4f, 0xdf, 0xbc, 0xf8, 0x28, 0xe5, 0x1a, 0x17, 0xe3, 0x68, 0x84, 0xcf, 0x07, 0x1e,
+	0x76, 0x74, 0xaf, 0x08, 0x48, 0xb3, 0x0d, 0x48, 0x5c, 0xcf, 0x25, 0x24, 0x4c, 0xad, 0x2a, 0x33,
+	0x30, 0x18, 0xd7, 0xc2, 0x30, 0x19, 0xbb, 0xb1, 0xd0, 0xd8, 0x35, 0x0f, 0xe1, 0xdd, 0x19, 0xa7,
+	0x6c, 0x84, 0x1a, 0xb0, 0xe4, 0x10, 0xec, 0x74, 0x06, 0x8a, 0xa7, 0x2f, 0xdc, 0x8c, 0xba, 0xf0,
+	0x4b, 0x73, 0x51, 0x51, 0x13, 0x57, 0xe6, 0x4f, 0x01, 0xd9, 0x84, 0xfb, 0xe7, 0x21, 0xf9, 0x9c,
+	0x0b, 0xff, 0xce, 0x80, 0x9c, 0x9a, 0xf5, 0x6a, 0x16, 0x7d, 0x16, 0x5e, 0xa7, 0x16, 0x9a, 0xf2,
+	0x42, 0x5f, 0xcc, 0xc2, 0xe9, 0x9d, 0x6a, 0x21, 0xd3, 0xc0, 0xe6, 0xd1, 0xef, 0x82, 0xfd, 0x40,
+	0x0d, 0x74, 0xf4, 0x63, 0x28, 0x48, 0xb2, 0x53, 0x5b, 0xdf, 0x6b, 0xee, 0xb4, 0x3a, 0xfb, 0xad,
+	0xf6, 0x6e, 0x63, 0xbd, 0xb9, 0xd9, 0x6c, 0x6c, 0xe4, 0xdf, 0x42, 0xef, 0xc3, 0x3b, 0x53, 0xd2,
+	0xd6, 0x4e, 0xab, 0x91, 0x37, 0xd0, 0x8f, 0xe0, 0xdd, 0x29, 0xf6, 0xba, 0xdd, 0xa8, 0xed, 0x35,
+	0xf2, 0xb1, 0x19, 0xfd, 0xc6, 0x46, 0x73, 0x2f, 0x1f, 0x47, 0x05, 0x78, 0x6f, 0x8a, 0x6d, 0x37,
+	0x76, 0x5f, 0xd6, 0xd6, 0x1b, 0xf9, 0xc4, 0x23, 0x0e, 0xf9, 0xab, 0xed, 0x09, 0xdd, 0x87, 0x95,
+	0xf6, 0x7e, 0xbd, 0xbd, 0x6e, 0x37, 0x77, 0xa5, 0xf6, 0x8b, 0x66, 0x6b, 0xe3, 0x4a, 0x5c, 0x2b,
+	0xf0, 0xc1, 0xac, 0xca, 0x41, 0xa3, 0xbe, 0xb5, 0xb3, 0xf3, 0x22, 0x6f, 0xa0, 0xbb, 0x50, 0x9c,
+	0x15, 0xd7, 0x5a, 0xad, 0x9d, 0xfd, 0xd6, 0x7a, 0x23, 0x1f, 0x5b, 0xfb, 0x6b, 0x1c, 0xde, 0x56,
+	0x57, 0xb1, 0xe7, 0xe3, 0xde, 0x31, 0xf1, 0xd1, 0x26, 0xa4, 0xf5, 0xf7, 0x05, 0x5a, 0x9e, 0x29,
+	0xe4, 0x86, 0xf8, 0xbe, 0x29, 0x7e, 0x38, 0x7f, 0x27, 0x54, 0x95, 0xd5, 0x04, 0x38, 0xe8, 0x7b,
+	0x4d, 0x26, 0xb1, 0xff, 0xe1, 0xae, 0xc2, 0xbb, 0xec, 0xce, 0xe4, 0x1b, 0xe1, 0xfe, 0x0d, 0x5b,
+	0x2c, 0xf9, 0xb6, 0x68, 0xde, 0xa4, 0xc2, 0x46, 0xe8, 0x15, 0x64, 0x27, 0x3b, 0x35, 0x8a, 0xec,
+	0x6e, 0xe1, 0xb5, 0x7b, 0xb1, 0x40, 0x31, 0x2c, 0x85, 0xf7, 0x58, 0xf4, 0x30, 0xca, 0xe8, 0xca,
+	0xaa, 0x5c, 0x2c, 0x2f, 0xa6, 0xc8, 0x46, 0x6b, 0x7f, 0x32, 0x20, 0x29, 0x2b, 0x08, 0x3d, 0x85,
+	0x9c, 0x4d, 0xde, 0xf8, 0x84, 0xf5, 0xeb, 0x03, 0xef, 0x28, 0x12, 0xe1, 0x08, 0x3e, 0x3a, 0x84,
+	0x74, 0xb0, 0x6a, 0x7e, 0x3c, 0x7b, 0x7a, 0xf0, 0x25, 0x6a, 0x4d, 0xbe, 0x3e, 0xc7, 0xab, 0x56,
+	0x93, 0x93, 0x61, 0xf1, 0xc1, 0xdc, 0x85, 0x53, 0xbb, 0x5c, 0xfb, 0xbb, 0x01, 0x29, 0xf9, 0xb8,
+	0x19, 0xfa, 0x0a, 0xe2, 0xcf, 0x49, 0x74, 0x05, 0xdc, 0x9f, 0xbb, 0xf9, 0xc8, 0x65, 0xe9, 0x09,
+	0xc4, 0x6b, 0x8e, 0x83, 0xe6, 0xef, 0x48, 0x91, 0x89, 0x3e, 0x83, 0x94, 0x2d, 0x47, 0xe7, 0xff,
+	0xe9, 0x60, 0xed, 0x9f, 0x71, 0xc8, 0x1c, 0x90, 0x6e, 0xdf, 0xf3, 0x8e, 0x19, 0x3a, 0x80, 0xec,
+	0x64, 0xf3, 0x40, 0x37, 0x0d, 0x46, 0x39, 0xf7, 0x8a, 0x0b, 0x8d, 0x4f, 0xf4, 0x35, 0xbc, 0x33,
+	0x33, 0x94, 0x23, 0x41, 0xfb, 0xd9, 0xdc, 0x7a, 0x99, 0x99, 0xeb, 0xdb, 0x90, 0x0b, 0xcd, 0x62,
+	0xf4, 0x51, 0x94, 0xf5, 0xf4, 0xc0, 0x8e, 0x44, 0xf4, 0x1b, 0xf5, 0xad, 0x17, 0x9a, 0x25, 0xe8,
+	0xd1, 0xbc, 0x80, 0xa6, 0x27, 0x59, 0xf1, 0xe3, 0x85, 0x75, 0xd9, 0x08, 0xed, 0xc3, 0xed, 0x2b,
+	0x93, 0x25, 0xfa, 0xac, 0xd9, 0x11, 0x14, 0x95, 0x42, 0xfd, 0x5f, 0x06, 0x14, 0x7b, 0xde, 0x30,
+	0xc2, 0x53, 0x3d, 0xbd, 0x4d, 0x77, 0x85, 0xc1, 0xae, 0xf1, 0xf5, 0x27, 0xd3, 0x2a, 0x95, 0xb3,
+	0xca, 0x11, 0x71, 0x2b, 0xd7, 0xff, 0x9d, 0xf4, 0x78, 0x48, 0xc7, 0xab, 0x7f, 0x8c, 0x25, 0x0e,
+	0x0e, 0x5e, 0x6f, 0xff, 0x39, 0xb6, 0x7c, 0xa0, 0x2c, 0x0f, 0xb4, 0xf3, 0xd7, 0xd6, 0x36, 0xb5,
+	0x5e, 0xad, 0xfe, 0x23, 0x10, 0x1c, 0x6a, 0xc1, 0xe1, 0xeb, 0xc3, 0x6d, 0x7a, 0xf8, 0x6a, 0xf5,
+	0xdf, 0x31, 0xf3, 0x7a, 0xc1, 0xe1, 0xf3, 0xdd, 0xfa, 0x36, 0xe1, 0xd8, 0xc1, 0x1c, 0xff, 0x27,
+	0x56, 0x54, 0x4a, 0xd5, 0xaa, 0xd6, 0xaa, 0x56, 0x5f, 0x57, 0xab, 0xdb, 0xb4, 0x5a, 0x7d, 0xb5,
+	0xda, 0x4d, 0xc9, 0x34, 0x7f, 0xfe, 0xbf, 0x01, 0x00, 0xad, 0xed, 0xfe, 0xb7, 0xef, 0x12, 0x00,
+	0x00,
 }
@@ -13,6 +13,7 @@ import (
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	v1 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -276,13 +277,19 @@ var SwitchTracker_ServiceDesc = grpc.ServiceDesc{
 
 const (
 	POSSE_RefreshBlog_FullMethodName = "/within.website.x.mi.v1.POSSE/RefreshBlog"
+	POSSE_Preview_FullMethodName     = "/within.website.x.mi.v1.POSSE/Preview"
 )
 
 // POSSEClient is the client API for POSSE service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type POSSEClient interface {
+	// RefreshBlog fetches the blog's JSON Feed and updates or deletes the posts
+	// made for blogposts that were edited or removed.
 	RefreshBlog(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Preview shows what announcing a blogpost would post, without posting
+	// anything.
+	Preview(ctx context.Context, in *v1.Item, opts ...grpc.CallOption) (*POSSEPreview, error)
 }
 
 type pOSSEClient struct {
@@ -303,11 +310,26 @@ func (c *pOSSEClient) RefreshBlog(ctx context.Context, in *emptypb.Empty, opts .
 	return out, nil
 }
 
+func (c *pOSSEClient) Preview(ctx context.Context, in *v1.Item, opts ...grpc.CallOption) (*POSSEPreview, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(POSSEPreview)
+	err := c.cc.Invoke(ctx, POSSE_Preview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // POSSEServer is the server API for POSSE service.
 // All implementations must embed UnimplementedPOSSEServer
 // for forward compatibility.
 type POSSEServer interface {
+	// RefreshBlog fetches the blog's JSON Feed and updates or deletes the posts
+	// made for blogposts that were edited or removed.
 	RefreshBlog(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Preview shows what announcing a blogpost would post, without posting
+	// anything.
+	Preview(context.Context, *v1.Item) (*POSSEPreview, error)
 	mustEmbedUnimplementedPOSSEServer()
 }
 
@@ -321,6 +343,9 @@ type UnimplementedPOSSEServer struct{}
 func (UnimplementedPOSSEServer) RefreshBlog(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method RefreshBlog not implemented")
 }
+func (UnimplementedPOSSEServer) Preview(context.Context, *v1.Item) (*POSSEPreview, error) {
+	return nil, status.Error(codes.Unimplemented, "method Preview not implemented")
+}
 func (UnimplementedPOSSEServer) mustEmbedUnimplementedPOSSEServer() {}
 func (UnimplementedPOSSEServer) testEmbeddedByValue()               {}
 
@@ -360,6 +385,24 @@ func _POSSE_RefreshBlog_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _POSSE_Preview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.Item)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(POSSEServer).Preview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: POSSE_Preview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(POSSEServer).Preview(ctx, req.(*v1.Item))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // POSSE_ServiceDesc is the grpc.ServiceDesc for POSSE service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -371,6 +414,10 @@ var POSSE_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefreshBlog",
 			Handler:    _POSSE_RefreshBlog_Handler,
 		},
+		{
+			MethodName: "Preview",
+			Handler:    _POSSE_Preview_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "within/website/x/mi/v1/mi.proto",
@@ -563,3 +610,281 @@ var Events_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "within/website/x/mi/v1/mi.proto",
 }
+
+const (
+	Webhooks_Subscribe_FullMethodName         = "/within.website.x.mi.v1.Webhooks/Subscribe"
+	Webhooks_ListSubscriptions_FullMethodName = "/within.website.x.mi.v1.Webhooks/ListSubscriptions"
+	Webhooks_Unsubscribe_FullMethodName       = "/within.website.x.mi.v1.Webhooks/Unsubscribe"
+	Webhooks_ListDeadLetters_FullMethodName   = "/within.website.x.mi.v1.Webhooks/ListDeadLetters"
+	Webhooks_RetryDeadLetter_FullMethodName   = "/within.website.x.mi.v1.Webhooks/RetryDeadLetter"
+)
+
+// WebhooksClient is the client API for Webhooks service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Webhooks manages the subscriptions that mi sends its events to.
+//
+// Events are sent as JSON payloads signed with the subscription's secret.
+// Deliveries that keep failing are moved to a dead-letter table, where they
+// can be inspected and retried.
+type WebhooksClient interface {
+	// Subscribe adds a subscription. The secret used to sign its payloads is
+	// only returned here.
+	Subscribe(ctx context.Context, in *SubscribeReq, opts ...grpc.CallOption) (*Subscription, error)
+	// ListSubscriptions lists every subscription, without their secrets.
+	ListSubscriptions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSubscriptionsResp, error)
+	// Unsubscribe removes a subscription and its pending deliveries.
+	Unsubscribe(ctx context.Context, in *UnsubscribeReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListDeadLetters lists deliveries that failed too many times.
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersReq, opts ...grpc.CallOption) (*ListDeadLettersResp, error)
+	// RetryDeadLetter queues a dead-lettered delivery to be sent again.
+	RetryDeadLetter(ctx context.Context, in *RetryDeadLetterReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type webhooksClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhooksClient(cc grpc.ClientConnInterface) WebhooksClient {
+	return &webhooksClient{cc}
+}
+
+func (c *webhooksClient) Subscribe(ctx context.Context, in *SubscribeReq, opts ...grpc.CallOption) (*Subscription, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Subscription)
+	err := c.cc.Invoke(ctx, Webhooks_Subscribe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhooksClient) ListSubscriptions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSubscriptionsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubscriptionsResp)
+	err := c.cc.Invoke(ctx, Webhooks_ListSubscriptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhooksClient) Unsubscribe(ctx context.Context, in *UnsubscribeReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Webhooks_Unsubscribe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhooksClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersReq, opts ...grpc.CallOption) (*ListDeadLettersResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResp)
+	err := c.cc.Invoke(ctx, Webhooks_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhooksClient) RetryDeadLetter(ctx context.Context, in *RetryDeadLetterReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Webhooks_RetryDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhooksServer is the server API for Webhooks service.
+// All implementations must embed UnimplementedWebhooksServer
+// for forward compatibility.
+//
+// Webhooks manages the subscriptions that mi sends its events to.
+//
+// Events are sent as JSON payloads signed with the subscription's secret.
+// Deliveries that keep failing are moved to a dead-letter table, where they
+// can be inspected and retried.
+type WebhooksServer interface {
+	// Subscribe adds a subscription. The secret used to sign its payloads is
+	// only returned here.
+	Subscribe(context.Context, *SubscribeReq) (*Subscription, error)
+	// ListSubscriptions lists every subscription, without their secrets.
+	ListSubscriptions(context.Context, *emptypb.Empty) (*ListSubscriptionsResp, error)
+	// Unsubscribe removes a subscription and its pending deliveries.
+	Unsubscribe(context.Context, *UnsubscribeReq) (*emptypb.Empty, error)
+	// ListDeadLetters lists deliveries that failed too many times.
+	ListDeadLetters(context.Context, *ListDeadLettersReq) (*ListDeadLettersResp, error)
+	// RetryDeadLetter queues a dead-lettered delivery to be sent again.
+	RetryDeadLetter(context.Context, *RetryDeadLetterReq) (*emptypb.Empty, error)
+	mustEmbedUnimplementedWebhooksServer()
+}
+
+// UnimplementedWebhooksServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebhooksServer struct{}
+
+func (UnimplementedWebhooksServer) Subscribe(context.Context, *SubscribeReq) (*Subscription, error) {
+	return nil, status.Error(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedWebhooksServer) ListSubscriptions(context.Context, *emptypb.Empty) (*ListSubscriptionsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSubscriptions not implemented")
+}
+func (UnimplementedWebhooksServer) Unsubscribe(context.Context, *UnsubscribeReq) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Unsubscribe not implemented")
+}
+func (UnimplementedWebhooksServer) ListDeadLetters(context.Context, *ListDeadLettersReq) (*ListDeadLettersResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedWebhooksServer) RetryDeadLetter(context.Context, *RetryDeadLetterReq) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RetryDeadLetter not implemented")
+}
+func (UnimplementedWebhooksServer) mustEmbedUnimplementedWebhooksServer() {}
+func (UnimplementedWebhooksServer) testEmbeddedByValue()                  {}
+
+// UnsafeWebhooksServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhooksServer will
+// result in compilation errors.
+type UnsafeWebhooksServer interface {
+	mustEmbedUnimplementedWebhooksServer()
+}
+
+func RegisterWebhooksServer(s grpc.ServiceRegistrar, srv WebhooksServer) {
+	// If the following call panics, it indicates UnimplementedWebhooksServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Webhooks_ServiceDesc, srv)
+}
+
+func _Webhooks_Subscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhooksServer).Subscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Webhooks_Subscribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhooksServer).Subscribe(ctx, req.(*SubscribeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Webhooks_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhooksServer).ListSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Webhooks_ListSubscriptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhooksServer).ListSubscriptions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Webhooks_Unsubscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhooksServer).Unsubscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Webhooks_Unsubscribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhooksServer).Unsubscribe(ctx, req.(*UnsubscribeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Webhooks_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhooksServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Webhooks_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhooksServer).ListDeadLetters(ctx, req.(*ListDeadLettersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Webhooks_RetryDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryDeadLetterReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhooksServer).RetryDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Webhooks_RetryDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhooksServer).RetryDeadLetter(ctx, req.(*RetryDeadLetterReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Webhooks_ServiceDesc is the grpc.ServiceDesc for Webhooks service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Webhooks_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "within.website.x.mi.v1.Webhooks",
+	HandlerType: (*WebhooksServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Subscribe",
+			Handler:    _Webhooks_Subscribe_Handler,
+		},
+		{
+			MethodName: "ListSubscriptions",
+			Handler:    _Webhooks_ListSubscriptions_Handler,
+		},
+		{
+			MethodName: "Unsubscribe",
+			Handler:    _Webhooks_Unsubscribe_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _Webhooks_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "RetryDeadLetter",
+			Handler:    _Webhooks_RetryDeadLetter_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "within/website/x/mi/v1/mi.proto",
+}
@@ -12,6 +12,7 @@ import (
 
 	connect "connectrpc.com/connect"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	v11 "within.website/x/gen/within/website/x/external/jsonfeed/v1"
 	v1 "within.website/x/gen/within/website/x/mi/v1"
 )
 
@@ -29,6 +30,8 @@ const (
 	POSSEName = "within.website.x.mi.v1.POSSE"
 	// EventsName is the fully-qualified name of the Events service.
 	EventsName = "within.website.x.mi.v1.Events"
+	// WebhooksName is the fully-qualified name of the Webhooks service.
+	WebhooksName = "within.website.x.mi.v1.Webhooks"
 )
 
 // These constants are the fully-qualified names of the RPCs defined in this package. They're
@@ -53,12 +56,27 @@ const (
 	SwitchTrackerListSwitchesProcedure = "/within.website.x.mi.v1.SwitchTracker/ListSwitches"
 	// POSSERefreshBlogProcedure is the fully-qualified name of the POSSE's RefreshBlog RPC.
 	POSSERefreshBlogProcedure = "/within.website.x.mi.v1.POSSE/RefreshBlog"
+	// POSSEPreviewProcedure is the fully-qualified name of the POSSE's Preview RPC.
+	POSSEPreviewProcedure = "/within.website.x.mi.v1.POSSE/Preview"
 	// EventsGetProcedure is the fully-qualified name of the Events's Get RPC.
 	EventsGetProcedure = "/within.website.x.mi.v1.Events/Get"
 	// EventsAddProcedure is the fully-qualified name of the Events's Add RPC.
 	EventsAddProcedure = "/within.website.x.mi.v1.Events/Add"
 	// EventsRemoveProcedure is the fully-qualified name of the Events's Remove RPC.
 	EventsRemoveProcedure = "/within.website.x.mi.v1.Events/Remove"
+	// WebhooksSubscribeProcedure is the fully-qualified name of the Webhooks's Subscribe RPC.
+	WebhooksSubscribeProcedure = "/within.website.x.mi.v1.Webhooks/Subscribe"
+	// WebhooksListSubscriptionsProcedure is the fully-qualified name of the Webhooks's
+	// ListSubscriptions RPC.
+	WebhooksListSubscriptionsProcedure = "/within.website.x.mi.v1.Webhooks/ListSubscriptions"
+	// WebhooksUnsubscribeProcedure is the fully-qualified name of the Webhooks's Unsubscribe RPC.
+	WebhooksUnsubscribeProcedure = "/within.website.x.mi.v1.Webhooks/Unsubscribe"
+	// WebhooksListDeadLettersProcedure is the fully-qualified name of the Webhooks's ListDeadLetters
+	// RPC.
+	WebhooksListDeadLettersProcedure = "/within.website.x.mi.v1.Webhooks/ListDeadLetters"
+	// WebhooksRetryDeadLetterProcedure is the fully-qualified name of the Webhooks's RetryDeadLetter
+	// RPC.
+	WebhooksRetryDeadLetterProcedure = "/within.website.x.mi.v1.Webhooks/RetryDeadLetter"
 )
 
 // SwitchTrackerClient is a client for the within.website.x.mi.v1.SwitchTracker service.
@@ -237,7 +255,12 @@ func (UnimplementedSwitchTrackerHandler) ListSwitches(context.Context, *connect.
 
 // POSSEClient is a client for the within.website.x.mi.v1.POSSE service.
 type POSSEClient interface {
+	// RefreshBlog fetches the blog's JSON Feed and updates or deletes the posts
+	// made for blogposts that were edited or removed.
 	RefreshBlog(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error)
+	// Preview shows what announcing a blogpost would post, without posting
+	// anything.
+	Preview(context.Context, *connect.Request[v11.Item]) (*connect.Response[v1.POSSEPreview], error)
 }
 
 // NewPOSSEClient constructs a client for the within.website.x.mi.v1.POSSE service. By default, it
@@ -257,12 +280,19 @@ func NewPOSSEClient(httpClient connect.HTTPClient, baseURL string, opts ...conne
 			connect.WithSchema(pOSSEMethods.ByName("RefreshBlog")),
 			connect.WithClientOptions(opts...),
 		),
+		preview: connect.NewClient[v11.Item, v1.POSSEPreview](
+			httpClient,
+			baseURL+POSSEPreviewProcedure,
+			connect.WithSchema(pOSSEMethods.ByName("Preview")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // pOSSEClient implements POSSEClient.
 type pOSSEClient struct {
 	refreshBlog *connect.Client[emptypb.Empty, emptypb.Empty]
+	preview     *connect.Client[v11.Item, v1.POSSEPreview]
 }
 
 // RefreshBlog calls within.website.x.mi.v1.POSSE.RefreshBlog.
@@ -270,9 +300,19 @@ func (c *pOSSEClient) RefreshBlog(ctx context.Context, req *connect.Request[empt
 	return c.refreshBlog.CallUnary(ctx, req)
 }
 
+// Preview calls within.website.x.mi.v1.POSSE.Preview.
+func (c *pOSSEClient) Preview(ctx context.Context, req *connect.Request[v11.Item]) (*connect.Response[v1.POSSEPreview], error) {
+	return c.preview.CallUnary(ctx, req)
+}
+
 // POSSEHandler is an implementation of the within.website.x.mi.v1.POSSE service.
 type POSSEHandler interface {
+	// RefreshBlog fetches the blog's JSON Feed and updates or deletes the posts
+	// made for blogposts that were edited or removed.
 	RefreshBlog(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error)
+	// Preview shows what announcing a blogpost would post, without posting
+	// anything.
+	Preview(context.Context, *connect.Request[v11.Item]) (*connect.Response[v1.POSSEPreview], error)
 }
 
 // NewPOSSEHandler builds an HTTP handler from the service implementation. It returns the path on
@@ -288,10 +328,18 @@ func NewPOSSEHandler(svc POSSEHandler, opts ...connect.HandlerOption) (string, h
 		connect.WithSchema(pOSSEMethods.ByName("RefreshBlog")),
 		connect.WithHandlerOptions(opts...),
 	)
+	pOSSEPreviewHandler := connect.NewUnaryHandler(
+		POSSEPreviewProcedure,
+		svc.Preview,
+		connect.WithSchema(pOSSEMethods.ByName("Preview")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/within.website.x.mi.v1.POSSE/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case POSSERefreshBlogProcedure:
 			pOSSERefreshBlogHandler.ServeHTTP(w, r)
+		case POSSEPreviewProcedure:
+			pOSSEPreviewHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -305,6 +353,10 @@ func (UnimplementedPOSSEHandler) RefreshBlog(context.Context, *connect.Request[e
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("within.website.x.mi.v1.POSSE.RefreshBlog is not implemented"))
 }
 
+func (UnimplementedPOSSEHandler) Preview(context.Context, *connect.Request[v11.Item]) (*connect.Response[v1.POSSEPreview], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("within.website.x.mi.v1.POSSE.Preview is not implemented"))
+}
+
 // EventsClient is a client for the within.website.x.mi.v1.Events service.
 type EventsClient interface {
 	// Get fetches the current feed of upcoming events.
@@ -432,3 +484,189 @@ func (UnimplementedEventsHandler) Add(context.Context, *connect.Request[v1.Event
 func (UnimplementedEventsHandler) Remove(context.Context, *connect.Request[v1.Event]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("within.website.x.mi.v1.Events.Remove is not implemented"))
 }
+
+// WebhooksClient is a client for the within.website.x.mi.v1.Webhooks service.
+type WebhooksClient interface {
+	// Subscribe adds a subscription. The secret used to sign its payloads is
+	// only returned here.
+	Subscribe(context.Context, *connect.Request[v1.SubscribeReq]) (*connect.Response[v1.Subscription], error)
+	// ListSubscriptions lists every subscription, without their secrets.
+	ListSubscriptions(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListSubscriptionsResp], error)
+	// Unsubscribe removes a subscription and its pending deliveries.
+	Unsubscribe(context.Context, *connect.Request[v1.UnsubscribeReq]) (*connect.Response[emptypb.Empty], error)
+	// ListDeadLetters lists deliveries that failed too many times.
+	ListDeadLetters(context.Context, *connect.Request[v1.ListDeadLettersReq]) (*connect.Response[v1.ListDeadLettersResp], error)
+	// RetryDeadLetter queues a dead-lettered delivery to be sent again.
+	RetryDeadLetter(context.Context, *connect.Request[v1.RetryDeadLetterReq]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewWebhooksClient constructs a client for the within.website.x.mi.v1.Webhooks service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWebhooksClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) WebhooksClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	webhooksMethods := v1.File_within_website_x_mi_v1_mi_proto.Services().ByName("Webhooks").Methods()
+	return &webhooksClient{
+		subscribe: connect.NewClient[v1.SubscribeReq, v1.Subscription](
+			httpClient,
+			baseURL+WebhooksSubscribeProcedure,
+			connect.WithSchema(webhooksMethods.ByName("Subscribe")),
+			connect.WithClientOptions(opts...),
+		),
+		listSubscriptions: connect.NewClient[emptypb.Empty, v1.ListSubscriptionsResp](
+			httpClient,
+			baseURL+WebhooksListSubscriptionsProcedure,
+			connect.WithSchema(webhooksMethods.ByName("ListSubscriptions")),
+			connect.WithClientOptions(opts...),
+		),
+		unsubscribe: connect.NewClient[v1.UnsubscribeReq, emptypb.Empty](
+			httpClient,
+			baseURL+WebhooksUnsubscribeProcedure,
+			connect.WithSchema(webhooksMethods.ByName("Unsubscribe")),
+			connect.WithClientOptions(opts...),
+		),
+		listDeadLetters: connect.NewClient[v1.ListDeadLettersReq, v1.ListDeadLettersResp](
+			httpClient,
+			baseURL+WebhooksListDeadLettersProcedure,
+			connect.WithSchema(webhooksMethods.ByName("ListDeadLetters")),
+			connect.WithClientOptions(opts...),
+		),
+		retryDeadLetter: connect.NewClient[v1.RetryDeadLetterReq, emptypb.Empty](
+			httpClient,
+			baseURL+WebhooksRetryDeadLetterProcedure,
+			connect.WithSchema(webhooksMethods.ByName("RetryDeadLetter")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// webhooksClient implements WebhooksClient.
+type webhooksClient struct {
+	subscribe         *connect.Client[v1.SubscribeReq, v1.Subscription]
+	listSubscriptions *connect.Client[emptypb.Empty, v1.ListSubscriptionsResp]
+	unsubscribe       *connect.Client[v1.UnsubscribeReq, emptypb.Empty]
+	listDeadLetters   *connect.Client[v1.ListDeadLettersReq, v1.ListDeadLettersResp]
+	retryDeadLetter   *connect.Client[v1.RetryDeadLetterReq, emptypb.Empty]
+}
+
+// Subscribe calls within.website.x.mi.v1.Webhooks.Subscribe.
+func (c *webhooksClient) Subscribe(ctx context.Context, req *connect.Request[v1.SubscribeReq]) (*connect.Response[v1.Subscription], error) {
+	return c.subscribe.CallUnary(ctx, req)
+}
+
+// ListSubscriptions calls within.website.x.mi.v1.Webhooks.ListSubscriptions.
+func (c *webhooksClient) ListSubscriptions(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListSubscriptionsResp], error) {
+	return c.listSubscriptions.CallUnary(ctx, req)
+}
+
+// Unsubscribe calls within.website.x.mi.v1.Webhooks.Unsubscribe.
+func (c *webhooksClient) Unsubscribe(ctx context.Context, req *connect.Request[v1.UnsubscribeReq]) (*connect.Response[emptypb.Empty], error) {
+	return c.unsubscribe.CallUnary(ctx, req)
+}
+
+// ListDeadLetters calls within.website.x.mi.v1.Webhooks.ListDeadLetters.
+func (c *webhooksClient) ListDeadLetters(ctx context.Context, req *connect.Request[v1.ListDeadLettersReq]) (*connect.Response[v1.ListDeadLettersResp], error) {
+	return c.listDeadLetters.CallUnary(ctx, req)
+}
+
+// RetryDeadLetter calls within.website.x.mi.v1.Webhooks.RetryDeadLetter.
+func (c *webhooksClient) RetryDeadLetter(ctx context.Context, req *connect.Request[v1.RetryDeadLetterReq]) (*connect.Response[emptypb.Empty], error) {
+	return c.retryDeadLetter.CallUnary(ctx, req)
+}
+
+// WebhooksHandler is an implementation of the within.website.x.mi.v1.Webhooks service.
+type WebhooksHandler interface {
+	// Subscribe adds a subscription. The secret used to sign its payloads is
+	// only returned here.
+	Subscribe(context.Context, *connect.Request[v1.SubscribeReq]) (*connect.Response[v1.Subscription], error)
+	// ListSubscriptions lists every subscription, without their secrets.
+	ListSubscriptions(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListSubscriptionsResp], error)
+	// Unsubscribe removes a subscription and its pending deliveries.
+	Unsubscribe(context.Context, *connect.Request[v1.UnsubscribeReq]) (*connect.Response[emptypb.Empty], error)
+	// ListDeadLetters lists deliveries that failed too many times.
+	ListDeadLetters(context.Context, *connect.Request[v1.ListDeadLettersReq]) (*connect.Response[v1.ListDeadLettersResp], error)
+	// RetryDeadLetter queues a dead-lettered delivery to be sent again.
+	RetryDeadLetter(context.Context, *connect.Request[v1.RetryDeadLetterReq]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewWebhooksHandler builds an HTTP handler from the service implementation. It returns the path on
+// which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWebhooksHandler(svc WebhooksHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	webhooksMethods := v1.File_within_website_x_mi_v1_mi_proto.Services().ByName("Webhooks").Methods()
+	webhooksSubscribeHandler := connect.NewUnaryHandler(
+		WebhooksSubscribeProcedure,
+		svc.Subscribe,
+		connect.WithSchema(webhooksMethods.ByName("Subscribe")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhooksListSubscriptionsHandler := connect.NewUnaryHandler(
+		WebhooksListSubscriptionsProcedure,
+		svc.ListSubscriptions,
+		connect.WithSchema(webhooksMethods.ByName("ListSubscriptions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhooksUnsubscribeHandler := connect.NewUnaryHandler(
+		WebhooksUnsubscribeProcedure,
+		svc.Unsubscribe,
+		connect.WithSchema(webhooksMethods.ByName("Unsubscribe")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhooksListDeadLettersHandler := connect.NewUnaryHandler(
+		WebhooksListDeadLettersProcedure,
+		svc.ListDeadLetters,
+		connect.WithSchema(webhooksMethods.ByName("ListDeadLetters")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhooksRetryDeadLetterHandler := connect.NewUnaryHandler(
+		WebhooksRetryDeadLetterProcedure,
+		svc.RetryDeadLetter,
+		connect.WithSchema(webhooksMethods.ByName("RetryDeadLetter")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/within.website.x.mi.v1.Webhooks/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case WebhooksSubscribeProcedure:
+			webhooksSubscribeHandler.ServeHTTP(w, r)
+		case WebhooksListSubscriptionsProcedure:
+			webhooksListSubscriptionsHandler.ServeHTTP(w, r)
+		case WebhooksUnsubscribeProcedure:
+			webhooksUnsubscribeHandler.ServeHTTP(w, r)
+		case WebhooksListDeadLettersProcedure:
+			webhooksListDeadLettersHandler.ServeHTTP(w, r)
+		case WebhooksRetryDeadLetterProcedure:
+			webhooksRetryDeadLetterHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedWebhooksHandler returns CodeUnimplemented from all methods.
+type UnimplementedWebhooksHandler struct{}
+
+func (UnimplementedWebhooksHandler) Subscribe(context.Context, *connect.Request[v1.SubscribeReq]) (*connect.Response[v1.Subscription], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("within.website.x.mi.v1.Webhooks.Subscribe is not implemented"))
+}
+
+func (UnimplementedWebhooksHandler) ListSubscriptions(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListSubscriptionsResp], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("within.website.x.mi.v1.Webhooks.ListSubscriptions is not implemented"))
+}
+
+func (UnimplementedWebhooksHandler) Unsubscribe(context.Context, *connect.Request[v1.UnsubscribeReq]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("within.website.x.mi.v1.Webhooks.Unsubscribe is not implemented"))
+}
+
+func (UnimplementedWebhooksHandler) ListDeadLetters(context.Context, *connect.Request[v1.ListDeadLettersReq]) (*connect.Response[v1.ListDeadLettersResp], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("within.website.x.mi.v1.Webhooks.ListDeadLetters is not implemented"))
+}
+
+func (UnimplementedWebhooksHandler) RetryDeadLetter(context.Context, *connect.Request[v1.RetryDeadLetterReq]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("within.website.x.mi.v1.Webhooks.RetryDeadLetter is not implemented"))
+}
@@ -26,6 +26,26 @@ func (s Session) ChatML() string {
 type Message struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
+
+	// ToolCalls are the tools an assistant message asks to have run.
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// ToolCallID is the call a "tool" message has the result of.
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+// ToolCall is a request from the model to run a tool, in the format used by
+// OpenAI-compatible APIs.
+type ToolCall struct {
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type"` // "function"
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall names the function to run. Arguments is a JSON object
+// encoded as a string.
+type FunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
 }
 
 func (m Message) ChatML() string {
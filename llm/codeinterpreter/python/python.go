@@ -1,14 +1,27 @@
+// Package python runs untrusted Python code in a WebAssembly build of
+// CPython under wazero.
+//
+// Code can read the fs.FS it is given at InputDir and write files to
+// ScratchDir; files left in ScratchDir are returned in the Result. Each run
+// is bounded by a wall-clock timeout, a linear memory cap, a quota on scratch
+// files and a limit on how much output is kept.
 package python
 
 import (
 	"bytes"
 	"context"
 	_ "embed"
+	"errors"
+	"fmt"
 	"io/fs"
+	"math/bits"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/experimental/sysfs"
 	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
 )
 
@@ -16,117 +29,378 @@ var (
 	//go:embed python.wasm
 	Binary []byte
 
-	r    wazero.Runtime
-	code wazero.CompiledModule
+	// cache shares compiled code between the runtimes of different memory
+	// limits, so only the first runtime pays for compiling CPython.
+	cache = wazero.NewCompilationCache()
+
+	// engines has one engine for each memory limit that has been used.
+	// There are only a few limits, see memoryLimit.
+	enginesLock sync.Mutex
+	engines     = map[uint32]*engine{}
+)
+
+const (
+	// InputDir is where Options.FS is mounted, read-only.
+	InputDir = "/input"
+	// ScratchDir is a writable directory that starts out empty. Files left
+	// in it are returned in Result.Files.
+	ScratchDir = "/scratch"
+
+	// DefaultTimeout is the wall-clock limit of a run.
+	DefaultTimeout = 30 * time.Second
+	// DefaultMemoryLimitPages is the linear memory cap, in 64 KiB WebAssembly
+	// pages (256 MiB).
+	DefaultMemoryLimitPages = 4096
+	// MinMemoryLimitPages is the smallest memory cap (16 MiB), which is
+	// about what the interpreter needs to start.
+	MinMemoryLimitPages = 256
+	// DefaultMaxOutput is how much of stdout and of stderr is kept.
+	DefaultMaxOutput = 64 << 10
+	// DefaultMaxFileBytes is how many bytes of scratch files are returned.
+	DefaultMaxFileBytes = 1 << 20
+	// DefaultMaxScratchBytes is how many bytes of files ScratchDir can hold.
+	DefaultMaxScratchBytes = 64 << 20
 )
 
 func init() {
-	ctx := context.Background()
-	r = wazero.NewRuntime(ctx)
+	if _, err := engineFor(context.Background(), DefaultMemoryLimitPages); err != nil {
+		panic(err)
+	}
+}
+
+// engine is a runtime and CPython compiled for it. Memory limits are set per
+// runtime, so there is one engine per limit in use. Engines are never
+// closed, which is fine as there are at most nine of them.
+type engine struct {
+	r    wazero.Runtime
+	code wazero.CompiledModule
+}
+
+// memoryLimit rounds a memory cap down to a power of two, so that there is
+// only an engine for each of a few limits.
+func memoryLimit(pages uint32) (uint32, error) {
+	if pages < MinMemoryLimitPages {
+		return 0, fmt.Errorf("python: memory limit of %d pages is under the minimum of %d", pages, MinMemoryLimitPages)
+	}
+
+	// wasm32 can't address more than 4 GiB anyway.
+	pages = min(pages, 65536)
+	return 1 << (bits.Len32(pages) - 1), nil
+}
+
+func engineFor(ctx context.Context, memoryLimitPages uint32) (*engine, error) {
+	memoryLimitPages, err := memoryLimit(memoryLimitPages)
+	if err != nil {
+		return nil, err
+	}
+
+	enginesLock.Lock()
+	defer enginesLock.Unlock()
+
+	if e, ok := engines[memoryLimitPages]; ok {
+		return e, nil
+	}
+
+	// Closing modules when their context is done is what enforces timeouts:
+	// wazero checks the context as the guest runs, so a busy loop can't
+	// outlive its deadline.
+	r := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(memoryLimitPages).
+		WithCompilationCache(cache))
 
 	wasi_snapshot_preview1.MustInstantiate(ctx, r)
 
-	var err error
-	code, err = r.CompileModule(ctx, Binary)
+	code, err := r.CompileModule(ctx, Binary)
 	if err != nil {
-		panic(err)
+		r.Close(ctx)
+		return nil, err
+	}
+
+	e := &engine{r: r, code: code}
+	engines[memoryLimitPages] = e
+	return e, nil
+}
+
+// Options configure a run or a Session. The zero value uses the defaults.
+type Options struct {
+	// FS, if set, is mounted read-only at InputDir. If nil, InputDir does
+	// not exist.
+	FS fs.FS
+	// Timeout is the wall-clock limit of a run, or of each Session.Exec.
+	// Zero means DefaultTimeout.
+	Timeout time.Duration
+	// MemoryLimitPages caps the interpreter's linear memory in 64 KiB
+	// pages. It is rounded down to a power of two and can't be less than
+	// MinMemoryLimitPages. Zero means DefaultMemoryLimitPages.
+	MemoryLimitPages uint32
+	// MaxOutput is how many bytes of stdout and of stderr are kept. Zero
+	// means DefaultMaxOutput.
+	MaxOutput int
+	// MaxFileBytes is how many bytes of scratch files are returned in
+	// total. Zero means DefaultMaxFileBytes.
+	MaxFileBytes int64
+	// MaxScratchBytes is how many bytes of files ScratchDir can hold, for
+	// the whole life of a Session. Writes past it fail in the guest and the
+	// run returns ErrScratchFull. Zero means DefaultMaxScratchBytes.
+	MaxScratchBytes int64
+}
+
+func (o Options) withDefaults() Options {
+	if o.Timeout == 0 {
+		o.Timeout = DefaultTimeout
+	}
+	if o.MemoryLimitPages == 0 {
+		o.MemoryLimitPages = DefaultMemoryLimitPages
+	}
+	if o.MaxOutput == 0 {
+		o.MaxOutput = DefaultMaxOutput
+	}
+	if o.MaxFileBytes == 0 {
+		o.MaxFileBytes = DefaultMaxFileBytes
+	}
+	if o.MaxScratchBytes == 0 {
+		o.MaxScratchBytes = DefaultMaxScratchBytes
 	}
+	return o
 }
 
 type Result struct {
 	Stdout        string
 	Stderr        string
 	PlatformError string
+
+	// StdoutTruncated and StderrTruncated are set when output was cut at
+	// Options.MaxOutput.
+	StdoutTruncated bool
+	StderrTruncated bool
+
+	// Files are the files in ScratchDir after the run.
+	Files []File
+	// FilesTruncated is set when some files were left out of Files because
+	// they didn't fit in Options.MaxFileBytes.
+	FilesTruncated bool
+}
+
+// File is a file the code left in ScratchDir.
+type File struct {
+	Path string // absolute guest path, such as /scratch/plot.png
+	Size int64
+	Data []byte
 }
 
+var (
+	// ErrTimeout is returned when a run is stopped at its timeout.
+	ErrTimeout = errors.New("python: execution timed out")
+
+	// ErrScratchFull is returned when the code tried to write more than
+	// Options.MaxScratchBytes to ScratchDir.
+	ErrScratchFull = errors.New("python: scratch directory is full")
+)
+
 // mainPyPath is the path where main.py is placed in the filesystem.
 const mainPyPath = "/.within.website.main.py"
 
+// Run runs userCode in a fresh interpreter with fsys mounted at InputDir
+// and the default limits.
 func Run(ctx context.Context, fsys fs.FS, userCode string) (*Result, error) {
-	fout := &bytes.Buffer{}
-	ferr := &bytes.Buffer{}
-	fin := &bytes.Buffer{}
+	return RunWithOptions(ctx, userCode, Options{FS: fsys})
+}
 
-	// If fsys is nil, use an empty filesystem.
-	if fsys == nil {
-		fsys = emptyFS{}
-	}
+// RunWithOptions runs userCode in a fresh interpreter.
+func RunWithOptions(ctx context.Context, userCode string, opts Options) (*Result, error) {
+	opts = opts.withDefaults()
 
-	// Create a temporary directory and write main.py there.
-	tmpDir, err := os.MkdirTemp("", "python-wasm-*")
+	e, err := engineFor(ctx, opts.MemoryLimitPages)
 	if err != nil {
 		return nil, err
 	}
-	defer os.RemoveAll(tmpDir)
 
-	// Write main.py at the special path.
-	// The path is /.within.website.main.py (no directory separator).
-	if err := os.WriteFile(filepath.Join(tmpDir, ".within.website.main.py"), []byte(userCode), 0644); err != nil {
+	sb, err := newSandbox(userCode, opts.MaxScratchBytes)
+	if err != nil {
 		return nil, err
 	}
+	defer sb.cleanup()
+
+	fout := &limitedBuffer{max: opts.MaxOutput}
+	ferr := &limitedBuffer{max: opts.MaxOutput}
 
-	// Mount the temporary directory at root.
-	fsConfig := wazero.NewFSConfig().
-		WithDirMount(tmpDir, "/")
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
 
 	config := wazero.NewModuleConfig().
 		// stdio
 		WithStdout(fout).
 		WithStderr(ferr).
-		WithStdin(fin).
+		WithStdin(&bytes.Buffer{}).
 		// argv
 		WithArgs("python", mainPyPath).
-		WithName("python").
+		// Anonymous, so that concurrent runs don't collide on the name.
+		WithName("").
 		// fs / system
-		WithFSConfig(fsConfig).
+		WithFSConfig(sb.fsConfig(opts.FS)).
 		WithSysNanosleep().
 		WithSysNanotime().
 		WithSysWalltime()
 
-	mod, err := r.InstantiateModule(ctx, code, config)
-	if err != nil {
-		result := &Result{
-			Stdout:        fout.String(),
-			Stderr:        ferr.String(),
-			PlatformError: err.Error(),
+	mod, err := e.r.InstantiateModule(ctx, e.code, config)
+	if mod != nil {
+		defer mod.Close(context.WithoutCancel(ctx))
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = ErrTimeout
+	}
+	if sb.scratch.resetFull() {
+		if err == nil {
+			err = ErrScratchFull
+		} else {
+			err = fmt.Errorf("%w: %w", ErrScratchFull, err)
 		}
+	}
+
+	result := &Result{
+		Stdout:          fout.String(),
+		Stderr:          ferr.String(),
+		StdoutTruncated: fout.truncated,
+		StderrTruncated: ferr.truncated,
+	}
+
+	files, filesTruncated, filesErr := collectFiles(sb.scratchDir, opts.MaxFileBytes)
+	result.Files = files
+	result.FilesTruncated = filesTruncated
+
+	if err != nil {
+		result.PlatformError = err.Error()
 		return result, err
 	}
 
-	defer mod.Close(ctx)
+	if filesErr != nil {
+		return result, filesErr
+	}
+
+	return result, nil
+}
 
-	return &Result{
-		Stdout: fout.String(),
-		Stderr: ferr.String(),
-	}, nil
+// sandbox is the host side of an interpreter's filesystem.
+type sandbox struct {
+	rootDir    string     // holds main.py, mounted read-only at /
+	scratchDir string     // mounted read-write at ScratchDir
+	scratch    *scratchFS // scratchDir with a quota
 }
 
-// emptyFS is an empty filesystem that returns ENOENT for all paths.
-type emptyFS struct{}
+func newSandbox(mainPy string, maxScratchBytes int64) (*sandbox, error) {
+	rootDir, err := os.MkdirTemp("", "python-wasm-*")
+	if err != nil {
+		return nil, err
+	}
+
+	scratchDir, err := os.MkdirTemp("", "python-wasm-scratch-*")
+	if err != nil {
+		os.RemoveAll(rootDir)
+		return nil, err
+	}
+
+	sb := &sandbox{
+		rootDir:    rootDir,
+		scratchDir: scratchDir,
+		scratch:    newScratchFS(scratchDir, maxScratchBytes),
+	}
+
+	// Write main.py at the special path.
+	// The path is /.within.website.main.py (no directory separator).
+	if err := os.WriteFile(filepath.Join(rootDir, ".within.website.main.py"), []byte(mainPy), 0644); err != nil {
+		sb.cleanup()
+		return nil, err
+	}
+
+	return sb, nil
+}
 
-func (emptyFS) Open(name string) (fs.File, error) {
-	// Return a more specific error for better debugging.
-	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+func (sb *sandbox) fsConfig(fsys fs.FS) wazero.FSConfig {
+	cfg := wazero.NewFSConfig().
+		WithReadOnlyDirMount(sb.rootDir, "/")
+	cfg = cfg.(sysfs.FSConfig).WithSysFSMount(sb.scratch, ScratchDir)
+	if fsys != nil {
+		// fs.FS mounts are always read-only.
+		cfg = cfg.WithFSMount(fsys, InputDir)
+	}
+	return cfg
 }
 
-// globFS implements fs.GlobFS for better compatibility.
-func (emptyFS) Glob(pattern string) ([]string, error) {
-	return nil, nil
+func (sb *sandbox) cleanup() {
+	os.RemoveAll(sb.rootDir)
+	os.RemoveAll(sb.scratchDir)
 }
 
-// readDirFS implements fs.ReadDirFS for directory listing.
-func (emptyFS) ReadDir(name string) ([]fs.DirEntry, error) {
-	return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+// collectFiles reads the regular files under dir, in lexical order, until
+// maxBytes have been read. Files that don't fit are skipped.
+func collectFiles(dir string, maxBytes int64) ([]File, bool, error) {
+	var (
+		files     []File
+		budget    = maxBytes
+		truncated bool
+	)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if info.Size() > budget {
+			truncated = true
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		budget -= int64(len(data))
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, File{
+			Path: ScratchDir + "/" + filepath.ToSlash(rel),
+			Size: int64(len(data)),
+			Data: data,
+		})
+		return nil
+	})
+	if err != nil {
+		return files, truncated, fmt.Errorf("python: reading scratch files: %w", err)
+	}
+
+	return files, truncated, nil
 }
 
-// subFS is a wrapper to make emptyFS implement fs.SubFS.
-type subFS struct {
-	fs.FS
+// limitedBuffer keeps the first max bytes written to it and drops the rest.
+// Writes always succeed so the guest doesn't see an I/O error.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
 }
 
-func (s subFS) Sub(dir string) (fs.FS, error) {
-	if dir == "." {
-		return s, nil
+func (lb *limitedBuffer) Write(p []byte) (int, error) {
+	if room := lb.max - lb.buf.Len(); room < len(p) {
+		lb.truncated = true
+		lb.buf.Write(p[:max(room, 0)])
+		return len(p), nil
 	}
-	return nil, &fs.PathError{Op: "sub", Path: dir, Err: fs.ErrNotExist}
+	return lb.buf.Write(p)
+}
+
+func (lb *limitedBuffer) String() string {
+	return lb.buf.String()
 }
@@ -2,7 +2,11 @@ package python
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 )
 
 func TestRun(t *testing.T) {
@@ -21,3 +25,130 @@ print(f"Python {sys.version} running in {sys.platform}/wazero.")`
 	t.Logf("stdout: %s", res.Stdout)
 	t.Logf("stderr: %s", res.Stderr)
 }
+
+func TestRunFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"data.txt": {Data: []byte("hello")},
+	}
+
+	code := `with open("/input/data.txt") as f:
+    data = f.read()
+
+with open("/scratch/out.txt", "w") as f:
+    f.write(data.upper())
+
+try:
+    open("/input/new.txt", "w")
+except OSError:
+    print("read-only")`
+
+	res, err := Run(context.Background(), fsys, code)
+	if err != nil {
+		t.Logf("stderr: %s", res.Stderr)
+		t.Fatal(err)
+	}
+
+	if got := strings.TrimSpace(res.Stdout); got != "read-only" {
+		t.Errorf("stdout = %q, want the input dir to be read-only", got)
+	}
+
+	if len(res.Files) != 1 || res.Files[0].Path != "/scratch/out.txt" || string(res.Files[0].Data) != "HELLO" {
+		t.Errorf("files = %+v, want /scratch/out.txt with HELLO", res.Files)
+	}
+}
+
+func TestRunLimits(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		_, err := RunWithOptions(context.Background(), "while True: pass", Options{Timeout: time.Second})
+		if !errors.Is(err, ErrTimeout) {
+			t.Errorf("err = %v, want ErrTimeout", err)
+		}
+	})
+
+	t.Run("output", func(t *testing.T) {
+		res, err := RunWithOptions(context.Background(), `print("x" * 1000)`, Options{MaxOutput: 100})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Stdout) != 100 || !res.StdoutTruncated {
+			t.Errorf("got %d bytes of stdout, truncated: %v", len(res.Stdout), res.StdoutTruncated)
+		}
+	})
+
+	t.Run("scratch", func(t *testing.T) {
+		code := `import os
+
+try:
+    with open("/scratch/big", "wb") as f:
+        while True:
+            f.write(b"x" * 4096)
+            f.flush()
+except OSError:
+    print(os.path.getsize("/scratch/big"))`
+
+		res, err := RunWithOptions(context.Background(), code, Options{MaxScratchBytes: 64 << 10})
+		if !errors.Is(err, ErrScratchFull) {
+			t.Errorf("err = %v, want ErrScratchFull", err)
+		}
+		if got := strings.TrimSpace(res.Stdout); got != "65536" {
+			t.Errorf("wrote %s bytes, want the quota of 65536", got)
+		}
+	})
+}
+
+func TestSession(t *testing.T) {
+	sess, err := NewSession(context.Background(), Options{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sess.Close()
+
+	ctx := context.Background()
+
+	if _, err := sess.Exec(ctx, "x = 41\nimport math"); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := sess.Exec(ctx, "print(x + 1, math.floor(2.5))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(res.Stdout); got != "42 2" {
+		t.Errorf("stdout = %q, want state kept between calls", got)
+	}
+
+	res, err = sess.Exec(ctx, "1 / 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.PlatformError == "" || !strings.Contains(res.Stderr, "ZeroDivisionError") {
+		t.Errorf("result = %+v, want a traceback", res)
+	}
+
+	if _, err := sess.Exec(ctx, "while True: pass"); !errors.Is(err, ErrTimeout) {
+		t.Errorf("err = %v, want ErrTimeout", err)
+	}
+
+	if _, err := sess.Exec(ctx, "print(x)"); !errors.Is(err, ErrSessionClosed) {
+		t.Errorf("err = %v, want ErrSessionClosed after a timeout", err)
+	}
+}
+
+func TestMemoryLimit(t *testing.T) {
+	for _, tt := range []struct {
+		pages, want uint32
+		ok          bool
+	}{
+		{DefaultMemoryLimitPages, DefaultMemoryLimitPages, true},
+		{MinMemoryLimitPages, MinMemoryLimitPages, true},
+		{5000, 4096, true},
+		{8191, 4096, true},
+		{1 << 20, 65536, true},
+		{100, 0, false},
+	} {
+		got, err := memoryLimit(tt.pages)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("memoryLimit(%d) = %d, %v; want %d", tt.pages, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package python
+
+import (
+	"io"
+	"io/fs"
+	"sync"
+
+	experimentalsys "github.com/tetratelabs/wazero/experimental/sys"
+	"github.com/tetratelabs/wazero/experimental/sysfs"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// scratchFS is ScratchDir as the guest sees it: a host directory that can
+// only hold so many bytes of files. Writes that would go over fail with EIO,
+// as WASI has no ENOSPC.
+//
+// Only growth is counted, so overwriting a file in place is free and
+// deleting or truncating one gives its bytes back. Hard links are refused,
+// as they would let a file be counted as deleted while its data stays.
+type scratchFS struct {
+	experimentalsys.FS
+
+	lock  sync.Mutex
+	used  int64
+	limit int64
+	full  bool // a write was refused since the last call to resetFull
+}
+
+func newScratchFS(dir string, limit int64) *scratchFS {
+	return &scratchFS{FS: sysfs.DirFS(dir), limit: limit}
+}
+
+// grow reserves n more bytes, or reports that they don't fit.
+func (s *scratchFS) grow(n int64) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if n <= 0 {
+		return true
+	}
+	if s.used+n > s.limit {
+		s.full = true
+		return false
+	}
+
+	s.used += n
+	return true
+}
+
+// shrink gives back the bytes of a regular file that is going away.
+func (s *scratchFS) shrink(st sys.Stat_t) {
+	if !st.Mode.IsRegular() {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.used = max(s.used-st.Size, 0)
+}
+
+// resetFull reports whether a write was refused and starts over.
+func (s *scratchFS) resetFull() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	full := s.full
+	s.full = false
+	return full
+}
+
+func (s *scratchFS) OpenFile(path string, flag experimentalsys.Oflag, perm fs.FileMode) (experimentalsys.File, experimentalsys.Errno) {
+	var before sys.Stat_t
+	var existed bool
+	if flag&experimentalsys.O_TRUNC != 0 {
+		st, errno := s.FS.Lstat(path)
+		before, existed = st, errno == 0
+	}
+
+	f, errno := s.FS.OpenFile(path, flag, perm)
+	if errno != 0 {
+		return nil, errno
+	}
+	if existed {
+		s.shrink(before)
+	}
+
+	return &scratchFile{File: f, fs: s}, 0
+}
+
+func (s *scratchFS) Unlink(path string) experimentalsys.Errno {
+	st, statErr := s.FS.Lstat(path)
+
+	errno := s.FS.Unlink(path)
+	if errno == 0 && statErr == 0 {
+		s.shrink(st)
+	}
+	return errno
+}
+
+func (s *scratchFS) Rename(from, to string) experimentalsys.Errno {
+	st, statErr := s.FS.Lstat(to)
+
+	errno := s.FS.Rename(from, to)
+	if errno == 0 && statErr == 0 {
+		// The file that was at to is gone.
+		s.shrink(st)
+	}
+	return errno
+}
+
+func (s *scratchFS) Link(_, _ string) experimentalsys.Errno {
+	return experimentalsys.EPERM
+}
+
+// scratchFile is a file in a scratchFS that counts how much it grows.
+type scratchFile struct {
+	experimentalsys.File
+	fs *scratchFS
+}
+
+// growth is how many bytes the file grows by if n bytes are written at off.
+func (f *scratchFile) growth(off, n int64) (int64, experimentalsys.Errno) {
+	st, errno := f.File.Stat()
+	if errno != 0 {
+		return 0, errno
+	}
+	return off + n - st.Size, 0
+}
+
+func (f *scratchFile) Write(p []byte) (int, experimentalsys.Errno) {
+	var off int64
+	if f.File.IsAppend() {
+		st, errno := f.File.Stat()
+		if errno != 0 {
+			return 0, errno
+		}
+		off = st.Size
+	} else {
+		var errno experimentalsys.Errno
+		if off, errno = f.File.Seek(0, io.SeekCurrent); errno != 0 {
+			return 0, errno
+		}
+	}
+
+	n, errno := f.growth(off, int64(len(p)))
+	if errno != 0 {
+		return 0, errno
+	}
+	if !f.fs.grow(n) {
+		return 0, experimentalsys.EIO
+	}
+
+	return f.File.Write(p)
+}
+
+func (f *scratchFile) Pwrite(p []byte, off int64) (int, experimentalsys.Errno) {
+	n, errno := f.growth(off, int64(len(p)))
+	if errno != 0 {
+		return 0, errno
+	}
+	if !f.fs.grow(n) {
+		return 0, experimentalsys.EIO
+	}
+
+	return f.File.Pwrite(p, off)
+}
+
+func (f *scratchFile) Truncate(size int64) experimentalsys.Errno {
+	st, errno := f.File.Stat()
+	if errno != 0 {
+		return errno
+	}
+
+	if size > st.Size {
+		if !f.fs.grow(size - st.Size) {
+			return experimentalsys.EIO
+		}
+	} else {
+		f.fs.shrink(sys.Stat_t{Mode: st.Mode, Size: st.Size - size})
+	}
+
+	return f.File.Truncate(size)
+}
@@ -0,0 +1,286 @@
+package python
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/tetratelabs/wazero"
+)
+
+// ErrSessionClosed is returned by Session.Exec once the session was closed,
+// timed out or its interpreter exited.
+var ErrSessionClosed = errors.New("python: session closed")
+
+// sessionDriver is the main.py of a session. It reads length-prefixed code
+// from stdin, runs it in one globals dict so that state carries over between
+// calls and writes a length-prefixed JSON reply to the real stdout.
+const sessionDriver = `import contextlib, io, json, sys, traceback
+
+_max = int(sys.argv[1])
+_stdin = sys.stdin.buffer
+_stdout = sys.__stdout__.buffer
+_globals = {"__name__": "__main__", "__builtins__": __builtins__}
+
+def _read(n):
+    data = _stdin.read(n)
+    if data is None or len(data) < n:
+        raise EOFError
+    return data
+
+while True:
+    try:
+        code = _read(int.from_bytes(_read(8), "big")).decode("utf-8")
+    except EOFError:
+        break
+
+    out, err, ok = io.StringIO(), io.StringIO(), True
+    with contextlib.redirect_stdout(out), contextlib.redirect_stderr(err):
+        try:
+            exec(compile(code, "<session>", "exec"), _globals)
+        except SystemExit as e:
+            ok = e.code in (None, 0)
+        except BaseException:
+            ok = False
+            traceback.print_exc()
+
+    out, err = out.getvalue(), err.getvalue()
+    reply = json.dumps({
+        "stdout": out[:_max],
+        "stderr": err[:_max],
+        "stdout_truncated": len(out) > _max,
+        "stderr_truncated": len(err) > _max,
+        "ok": ok,
+    }).encode("utf-8")
+    _stdout.write(len(reply).to_bytes(8, "big"))
+    _stdout.write(reply)
+    _stdout.flush()
+`
+
+// sessionReply is what sessionDriver writes after running a snippet.
+type sessionReply struct {
+	Stdout          string `json:"stdout"`
+	Stderr          string `json:"stderr"`
+	StdoutTruncated bool   `json:"stdout_truncated"`
+	StderrTruncated bool   `json:"stderr_truncated"`
+	OK              bool   `json:"ok"`
+}
+
+// Session is an interpreter that keeps its globals, imports and scratch
+// files between calls to Exec. Calls are serialized. A call that runs past
+// Options.Timeout kills the session.
+type Session struct {
+	opts Options
+	sb   *sandbox
+
+	stdin   *io.PipeWriter
+	stdoutR *io.PipeReader
+	stdout  *bufio.Reader
+	stderr  *limitedBuffer // interpreter output outside of a snippet
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error // why the interpreter exited, set before done is closed
+
+	lock   sync.Mutex
+	closed bool
+}
+
+// NewSession starts an interpreter. The session outlives ctx; call Close
+// when done with it.
+func NewSession(ctx context.Context, opts Options) (*Session, error) {
+	opts = opts.withDefaults()
+
+	e, err := engineFor(ctx, opts.MemoryLimitPages)
+	if err != nil {
+		return nil, err
+	}
+
+	sb, err := newSandbox(sessionDriver, opts.MaxScratchBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+
+	s := &Session{
+		opts:    opts,
+		sb:      sb,
+		stdin:   stdinW,
+		stdoutR: stdoutR,
+		stdout:  bufio.NewReader(stdoutR),
+		stderr:  &limitedBuffer{max: opts.MaxOutput},
+		done:    make(chan struct{}),
+	}
+
+	config := wazero.NewModuleConfig().
+		WithStdout(stdoutW).
+		WithStderr(s.stderr).
+		WithStdin(stdinR).
+		WithArgs("python", mainPyPath, strconv.Itoa(opts.MaxOutput)).
+		WithName("").
+		WithFSConfig(sb.fsConfig(opts.FS)).
+		WithSysNanosleep().
+		WithSysNanotime().
+		WithSysWalltime()
+
+	// Cancelling runCtx is how a runaway snippet is stopped.
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s.cancel = cancel
+
+	go func() {
+		defer close(s.done)
+		mod, err := e.r.InstantiateModule(runCtx, e.code, config)
+		if mod != nil {
+			mod.Close(context.WithoutCancel(runCtx))
+		}
+		if err == nil {
+			err = errors.New("interpreter exited")
+		}
+		s.err = err
+		stdoutW.CloseWithError(ErrSessionClosed)
+	}()
+
+	return s, nil
+}
+
+// Exec runs code in the session. Unlike Run, an uncaught Python exception
+// isn't an error: the traceback is in Result.Stderr, PlatformError is set
+// and the session can still be used. The same goes for ErrScratchFull,
+// which is returned along with the result.
+func (s *Session) Exec(ctx context.Context, code string) (*Result, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return nil, ErrSessionClosed
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	type roundTrip struct {
+		reply *sessionReply
+		err   error
+	}
+	ch := make(chan roundTrip, 1)
+	go func() {
+		reply, err := s.roundTrip(code)
+		ch <- roundTrip{reply, err}
+	}()
+
+	var rt roundTrip
+	select {
+	case rt = <-ch:
+	case <-ctx.Done():
+		s.kill()
+		<-ch
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, ErrTimeout
+		}
+		return nil, ctx.Err()
+	}
+
+	if rt.err != nil {
+		s.kill()
+		err := rt.err
+		if errors.Is(err, ErrSessionClosed) {
+			// The pipe was closed because the interpreter exited.
+			err = s.err
+		}
+		if stderr := s.stderr.String(); stderr != "" {
+			return nil, fmt.Errorf("%w: %w\n%s", ErrSessionClosed, err, stderr)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrSessionClosed, err)
+	}
+
+	fout := &limitedBuffer{max: s.opts.MaxOutput}
+	ferr := &limitedBuffer{max: s.opts.MaxOutput}
+	fout.Write([]byte(rt.reply.Stdout))
+	ferr.Write([]byte(rt.reply.Stderr))
+
+	result := &Result{
+		Stdout:          fout.String(),
+		Stderr:          ferr.String(),
+		StdoutTruncated: fout.truncated || rt.reply.StdoutTruncated,
+		StderrTruncated: ferr.truncated || rt.reply.StderrTruncated,
+	}
+	if !rt.reply.OK {
+		result.PlatformError = "python: uncaught exception"
+	}
+
+	files, filesTruncated, err := collectFiles(s.sb.scratchDir, s.opts.MaxFileBytes)
+	result.Files = files
+	result.FilesTruncated = filesTruncated
+	if err != nil {
+		return result, err
+	}
+
+	if s.sb.scratch.resetFull() {
+		result.PlatformError = ErrScratchFull.Error()
+		return result, ErrScratchFull
+	}
+
+	return result, nil
+}
+
+// roundTrip sends code to the driver and reads its reply.
+func (s *Session) roundTrip(code string) (*sessionReply, error) {
+	msg := binary.BigEndian.AppendUint64(nil, uint64(len(code)))
+	msg = append(msg, code...)
+	if _, err := s.stdin.Write(msg); err != nil {
+		return nil, err
+	}
+
+	var size [8]byte
+	if _, err := io.ReadFull(s.stdout, size[:]); err != nil {
+		return nil, err
+	}
+
+	// JSON escaping can make each kept character up to 12 bytes long.
+	n := binary.BigEndian.Uint64(size[:])
+	if limit := uint64(24*s.opts.MaxOutput + 4096); n > limit {
+		return nil, fmt.Errorf("reply of %d bytes is over the %d byte limit", n, limit)
+	}
+
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(s.stdout, buf); err != nil {
+		return nil, err
+	}
+
+	var reply sessionReply
+	if err := json.Unmarshal(buf, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// kill stops the interpreter and removes its files. s.lock must be held.
+func (s *Session) kill() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+
+	s.cancel()
+	s.stdin.CloseWithError(ErrSessionClosed)
+	s.stdoutR.CloseWithError(ErrSessionClosed)
+	<-s.done
+	s.sb.cleanup()
+}
+
+// Close stops the interpreter. It is safe to call more than once.
+func (s *Session) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.kill()
+	return nil
+}
@@ -0,0 +1,297 @@
+package llm
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// ErrNotGGUF is returned when a file doesn't start with the GGUF magic.
+var ErrNotGGUF = errors.New("llm: not a GGUF file")
+
+// GGUFMetadata is the key-value metadata at the start of a GGUF model file.
+// Values are Go numbers, bools and strings as stored in the file; arrays of
+// strings are []string and other arrays are []any.
+type GGUFMetadata map[string]any
+
+// Limits on GGUF metadata, so a corrupt file can't make the reader allocate
+// without bound.
+const (
+	maxGGUFString = 64 << 20
+	maxGGUFArray  = 16 << 20
+
+	// maxGGUFItems bounds the items of all arrays put together. The
+	// biggest real arrays are tokenizer vocabularies, a few hundred thousand
+	// items each.
+	maxGGUFItems = 16 << 20
+
+	// ggufArrayPrealloc is the most items an array has room for before
+	// any are read.
+	ggufArrayPrealloc = 1024
+
+	// Real files don't nest arrays at all, but the format allows it.
+	maxGGUFDepth = 8
+)
+
+// GGUF metadata value types.
+const (
+	ggufUint8 uint32 = iota
+	ggufInt8
+	ggufUint16
+	ggufInt16
+	ggufUint32
+	ggufInt32
+	ggufFloat32
+	ggufBool
+	ggufString
+	ggufArray
+	ggufUint64
+	ggufInt64
+	ggufFloat64
+)
+
+// ReadGGUFMetadata reads the metadata of a GGUF file (version 2 or later),
+// stopping before the tensor info.
+func ReadGGUFMetadata(r io.Reader) (GGUFMetadata, error) {
+	gr := &ggufReader{r: bufio.NewReader(r)}
+
+	var magic [4]byte
+	if _, err := io.ReadFull(gr.r, magic[:]); err != nil || string(magic[:]) != "GGUF" {
+		return nil, ErrNotGGUF
+	}
+
+	var header struct {
+		Version     uint32
+		TensorCount uint64
+		KVCount     uint64
+	}
+	if err := binary.Read(gr.r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("llm: can't read GGUF header: %w", err)
+	}
+	if header.Version < 2 {
+		return nil, fmt.Errorf("llm: GGUF version %d is not supported", header.Version)
+	}
+
+	result := GGUFMetadata{}
+	for range header.KVCount {
+		key, err := gr.string()
+		if err != nil {
+			return nil, fmt.Errorf("llm: can't read GGUF metadata key: %w", err)
+		}
+
+		typ, err := gr.uint32()
+		if err != nil {
+			return nil, fmt.Errorf("llm: can't read GGUF type of %s: %w", key, err)
+		}
+
+		val, err := gr.value(typ)
+		if err != nil {
+			return nil, fmt.Errorf("llm: can't read GGUF value of %s: %w", key, err)
+		}
+
+		result[key] = val
+	}
+
+	return result, nil
+}
+
+// ReadGGUFMetadataFile reads the metadata of the GGUF file at path.
+func ReadGGUFMetadataFile(path string) (GGUFMetadata, error) {
+	fin, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fin.Close()
+
+	return ReadGGUFMetadata(fin)
+}
+
+// String returns the string value of key.
+func (m GGUFMetadata) String(key string) (string, bool) {
+	s, ok := m[key].(string)
+	return s, ok
+}
+
+// Int returns the integer value of key.
+func (m GGUFMetadata) Int(key string) (int64, bool) {
+	switch v := m[key].(type) {
+	case uint8:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
+
+// Token returns the text of the token whose ID is the value of idKey, such
+// as "tokenizer.ggml.bos_token_id".
+func (m GGUFMetadata) Token(idKey string) (string, bool) {
+	id, ok := m.Int(idKey)
+	if !ok {
+		return "", false
+	}
+
+	tokens, ok := m["tokenizer.ggml.tokens"].([]string)
+	if !ok || id < 0 || id >= int64(len(tokens)) {
+		return "", false
+	}
+
+	return tokens[id], true
+}
+
+type ggufReader struct {
+	r     *bufio.Reader
+	depth int // arrays we're in
+	items int // array items read so far
+}
+
+func (gr *ggufReader) uint32() (uint32, error) {
+	var v uint32
+	err := binary.Read(gr.r, binary.LittleEndian, &v)
+	return v, err
+}
+
+func (gr *ggufReader) uint64() (uint64, error) {
+	var v uint64
+	err := binary.Read(gr.r, binary.LittleEndian, &v)
+	return v, err
+}
+
+func (gr *ggufReader) string() (string, error) {
+	n, err := gr.uint64()
+	if err != nil {
+		return "", err
+	}
+	if n > maxGGUFString {
+		return "", fmt.Errorf("string of %d bytes is too long", n)
+	}
+
+	// The buffer grows as the string is read, so a length that the file
+	// doesn't back up with data can't allocate much.
+	var sb strings.Builder
+	if _, err := io.CopyN(&sb, gr.r, int64(n)); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func (gr *ggufReader) value(typ uint32) (any, error) {
+	switch typ {
+	case ggufUint8:
+		return readGGUFNumber[uint8](gr)
+	case ggufInt8:
+		return readGGUFNumber[int8](gr)
+	case ggufUint16:
+		return readGGUFNumber[uint16](gr)
+	case ggufInt16:
+		return readGGUFNumber[int16](gr)
+	case ggufUint32:
+		return readGGUFNumber[uint32](gr)
+	case ggufInt32:
+		return readGGUFNumber[int32](gr)
+	case ggufUint64:
+		return readGGUFNumber[uint64](gr)
+	case ggufInt64:
+		return readGGUFNumber[int64](gr)
+	case ggufFloat32:
+		bits, err := gr.uint32()
+		return math.Float32frombits(bits), err
+	case ggufFloat64:
+		bits, err := gr.uint64()
+		return math.Float64frombits(bits), err
+	case ggufBool:
+		b, err := readGGUFNumber[uint8](gr)
+		return b != 0, err
+	case ggufString:
+		return gr.string()
+	case ggufArray:
+		return gr.array()
+	}
+
+	return nil, fmt.Errorf("unknown value type %d", typ)
+}
+
+func (gr *ggufReader) array() (any, error) {
+	gr.depth++
+	defer func() { gr.depth-- }()
+	if gr.depth > maxGGUFDepth {
+		return nil, fmt.Errorf("arrays are nested more than %d deep", maxGGUFDepth)
+	}
+
+	typ, err := gr.uint32()
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := gr.uint64()
+	if err != nil {
+		return nil, err
+	}
+	if n > maxGGUFArray {
+		return nil, fmt.Errorf("array of %d items is too long", n)
+	}
+
+	// Like strings, arrays grow as items are read rather than trusting n.
+	if typ == ggufString {
+		result := make([]string, 0, min(n, ggufArrayPrealloc))
+		for range n {
+			if err := gr.item(); err != nil {
+				return nil, err
+			}
+			s, err := gr.string()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, s)
+		}
+		return result, nil
+	}
+
+	result := make([]any, 0, min(n, ggufArrayPrealloc))
+	for range n {
+		if err := gr.item(); err != nil {
+			return nil, err
+		}
+		v, err := gr.value(typ)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// item counts an array item against maxGGUFItems.
+func (gr *ggufReader) item() error {
+	gr.items++
+	if gr.items > maxGGUFItems {
+		return fmt.Errorf("metadata has more than %d array items", maxGGUFItems)
+	}
+	return nil
+}
+
+func readGGUFNumber[T uint8 | int8 | uint16 | int16 | uint32 | int32 | uint64 | int64](gr *ggufReader) (T, error) {
+	var v T
+	err := binary.Read(gr.r, binary.LittleEndian, &v)
+	return v, err
+}
@@ -0,0 +1,184 @@
+package llm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nikolalohinski/gonja/v2"
+	"github.com/nikolalohinski/gonja/v2/exec"
+	"github.com/nikolalohinski/gonja/v2/loaders"
+)
+
+var (
+	// ErrNoChatTemplate is returned for GGUF files without a chat template.
+	ErrNoChatTemplate = errors.New("llm: GGUF file has no chat template")
+
+	// ErrTemplateInclude is returned when a chat template tries to include,
+	// import or extend another template.
+	ErrTemplateInclude = errors.New("llm: chat templates can't load other templates")
+)
+
+// JinjaTemplate renders a Jinja chat template, the format Hugging Face
+// tokenizers and GGUF files ship with.
+//
+// Templates are given the variables transformers passes them: messages,
+// add_generation_prompt, bos_token and eos_token, along with the
+// raise_exception and strftime_now functions.
+type JinjaTemplate struct {
+	tmpl     *exec.Template
+	bosToken string
+	eosToken string
+}
+
+// NewJinjaTemplate parses a chat template. bosToken and eosToken are the
+// model's beginning and end of sequence tokens, which templates usually
+// emit themselves.
+func NewJinjaTemplate(source, bosToken, eosToken string) (*JinjaTemplate, error) {
+	// Chat templates are written for these settings, which transformers
+	// uses.
+	cfg := gonja.DefaultConfig.Inherit()
+	cfg.TrimBlocks = true
+	cfg.LeftStripBlocks = true
+
+	// Chat templates come from model files, which anyone can make, so they
+	// don't get to read files on this machine.
+	loader, err := loaders.NewShiftedLoader("chat_template", bytes.NewReader([]byte(source)), noIncludes{})
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := exec.NewTemplate("chat_template", cfg, loader, gonja.DefaultEnvironment)
+	if err != nil {
+		return nil, fmt.Errorf("llm: can't parse chat template: %w", err)
+	}
+
+	return &JinjaTemplate{
+		tmpl:     tmpl,
+		bosToken: bosToken,
+		eosToken: eosToken,
+	}, nil
+}
+
+// noIncludes is a loader that refuses to load anything.
+type noIncludes struct{}
+
+func (noIncludes) Read(path string) (io.Reader, error) {
+	return nil, fmt.Errorf("%w: %q", ErrTemplateInclude, path)
+}
+
+func (noIncludes) Resolve(path string) (string, error) {
+	return "", fmt.Errorf("%w: %q", ErrTemplateInclude, path)
+}
+
+func (n noIncludes) Inherit(from string) (loaders.Loader, error) {
+	return n, nil
+}
+
+// JinjaTemplateFromGGUF returns the chat template in a GGUF file's
+// metadata.
+func JinjaTemplateFromGGUF(md GGUFMetadata) (*JinjaTemplate, error) {
+	source, ok := md.String("tokenizer.chat_template")
+	if !ok {
+		return nil, ErrNoChatTemplate
+	}
+
+	bos, _ := md.Token("tokenizer.ggml.bos_token_id")
+	eos, _ := md.Token("tokenizer.ggml.eos_token_id")
+
+	return NewJinjaTemplate(source, bos, eos)
+}
+
+func (j *JinjaTemplate) Render(messages []Message) (string, error) {
+	msgs := make([]map[string]any, len(messages))
+	for i, m := range messages {
+		msg := map[string]any{
+			"role":    m.Role,
+			"content": m.Content,
+		}
+		if len(m.ToolCalls) != 0 {
+			var calls []map[string]any
+			for _, tc := range m.ToolCalls {
+				calls = append(calls, map[string]any{
+					"id":   tc.ID,
+					"type": "function",
+					"function": map[string]any{
+						"name":      tc.Function.Name,
+						"arguments": tc.Function.Arguments,
+					},
+				})
+			}
+			msg["tool_calls"] = calls
+		}
+		if m.ToolCallID != "" {
+			msg["tool_call_id"] = m.ToolCallID
+		}
+		msgs[i] = msg
+	}
+
+	result, err := j.tmpl.ExecuteToString(exec.NewContext(map[string]any{
+		"messages":              msgs,
+		"add_generation_prompt": !prefill(messages),
+		"bos_token":             j.bosToken,
+		"eos_token":             j.eosToken,
+		"raise_exception": func(msg string) (string, error) {
+			return "", errors.New(msg)
+		},
+		"strftime_now": func(format string) string {
+			return strftime(time.Now(), format)
+		},
+	}))
+	if err != nil {
+		return "", fmt.Errorf("llm: can't render chat template: %w", err)
+	}
+
+	return result, nil
+}
+
+// strftime formats t with the strftime directives chat templates use for
+// the current date.
+func strftime(t time.Time, format string) string {
+	var sb bytes.Buffer
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			sb.WriteByte(format[i])
+			continue
+		}
+
+		i++
+		switch format[i] {
+		case 'd':
+			sb.WriteString(t.Format("02"))
+		case 'm':
+			sb.WriteString(t.Format("01"))
+		case 'Y':
+			sb.WriteString(t.Format("2006"))
+		case 'y':
+			sb.WriteString(t.Format("06"))
+		case 'b':
+			sb.WriteString(t.Format("Jan"))
+		case 'B':
+			sb.WriteString(t.Format("January"))
+		case 'a':
+			sb.WriteString(t.Format("Mon"))
+		case 'A':
+			sb.WriteString(t.Format("Monday"))
+		case 'H':
+			sb.WriteString(t.Format("15"))
+		case 'M':
+			sb.WriteString(t.Format("04"))
+		case 'S':
+			sb.WriteString(t.Format("05"))
+		case '%':
+			sb.WriteByte('%')
+		default:
+			sb.WriteByte('%')
+			sb.WriteByte(format[i])
+		}
+	}
+
+	return sb.String()
+}
@@ -13,6 +13,12 @@ import (
 type Client struct {
 	cli       *http.Client
 	serverURL string
+
+	// Template renders sessions into prompts for ExecSession. It has to
+	// match the model the server runs, such as one made from the model's
+	// GGUF metadata with JinjaTemplateFromGGUF. If it is nil, sessions are
+	// rendered as ChatML.
+	Template Template
 }
 
 func NewClient(cli *http.Client, serverURL string) *Client {
@@ -23,11 +29,26 @@ func NewClient(cli *http.Client, serverURL string) *Client {
 }
 
 func (c *Client) ExecSession(session Session) (*LLAMAResponse, error) {
+	prompt, err := c.prompt(session)
+	if err != nil {
+		return nil, err
+	}
+
 	opts := DefaultLLAMAOpts()
-	opts.Prompt = session.ChatML()
+	opts.Prompt = prompt
 	return c.Predict(opts)
 }
 
+// prompt renders session with c.Template.
+func (c *Client) prompt(session Session) (string, error) {
+	if c.Template == nil {
+		// Session.ChatML also handles messages other than Message.
+		return session.ChatML(), nil
+	}
+
+	return session.Render(c.Template)
+}
+
 func (c *Client) Predict(opts *LLAMAOpts) (*LLAMAResponse, error) {
 	jsonData, err := json.Marshal(opts)
 	if err != nil {
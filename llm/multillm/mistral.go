@@ -11,7 +11,7 @@ type Mistral struct {
 	*mistral.Client
 }
 
-func (m *Mistral) Chat(ctx context.Context, req *Request) (*Response, error) {
+func (m *Mistral) request(req *Request) *mistral.CompleteRequest {
 	cr := &mistral.CompleteRequest{
 		Model:       req.Model,
 		Messages:    make([]llm.Message, len(req.Messages)),
@@ -19,24 +19,91 @@ func (m *Mistral) Chat(ctx context.Context, req *Request) (*Response, error) {
 		RandomSeed:  req.RandomSeed,
 	}
 
-	for i, m := range req.Messages {
-		cr.Messages[i] = llm.Message{
-			Role:    m.Role,
-			Content: m.Content,
-		}
+	// Mistral uses the same message format as llm.Message.
+	copy(cr.Messages, req.Messages)
+
+	for _, t := range req.Tools {
+		cr.Tools = append(cr.Tools, mistral.Tool{
+			Type: "function",
+			Function: mistral.Function{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  schema(t.Parameters),
+			},
+		})
 	}
 
-	resp, err := m.Client.Chat(ctx, cr)
+	return cr
+}
+
+func (m *Mistral) Chat(ctx context.Context, req *Request) (*Response, error) {
+	resp, err := m.Client.Chat(ctx, m.request(req))
 	if err != nil {
 		return nil, err
 	}
 
+	if len(resp.Choices) == 0 {
+		return nil, ErrNoChoices
+	}
+
 	return &Response{
 		Response: llm.Message{
-			Role:    resp.Choices[0].Message[0].Role,
-			Content: resp.Choices[0].Message[0].Content,
+			Role:      resp.Choices[0].Message.Role,
+			Content:   resp.Choices[0].Message.Content,
+			ToolCalls: resp.Choices[0].Message.ToolCalls,
 		},
 		PromptTokens:     resp.Usage.PromptTokens,
 		CompletionTokens: resp.Usage.CompletionTokens,
+		Model:            resp.Model,
 	}, nil
 }
+
+func (m *Mistral) ChatStream(ctx context.Context, req *Request, fn func(Delta) error) (*Response, error) {
+	result := &Response{
+		Response: llm.Message{Role: "assistant"},
+	}
+	var tools toolCallBuilder
+
+	if err := m.Client.ChatStream(ctx, m.request(req), func(chunk mistral.StreamChunk) error {
+		result.Model = chunk.Model
+		if chunk.Usage != nil {
+			result.PromptTokens = chunk.Usage.PromptTokens
+			result.CompletionTokens = chunk.Usage.CompletionTokens
+		}
+
+		for _, choice := range chunk.Choices {
+			if choice.Index != 0 {
+				continue
+			}
+
+			// Mistral sends each tool call whole.
+			for _, tc := range choice.Delta.ToolCalls {
+				if err := tools.add(len(tools.calls), tc.ID, tc.Function.Name, tc.Function.Arguments); err != nil {
+					return err
+				}
+			}
+
+			if choice.Delta.Content == "" {
+				continue
+			}
+
+			result.Response.Content += choice.Delta.Content
+			if err := fn(Delta{Content: choice.Delta.Content}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(tools.calls) != 0 {
+		result.Response.ToolCalls = tools.calls
+		if err := fn(Delta{ToolCalls: tools.calls}); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -4,33 +4,125 @@ package multillm
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 
 	"within.website/x/llm"
 )
 
+var (
+	// ErrNoChoices is returned when a provider answers without a message.
+	ErrNoChoices = errors.New("multillm: response has no choices")
+
+	// ErrBadToolCall is returned when a provider streams a tool call with an
+	// index that is negative or over maxToolCalls.
+	ErrBadToolCall = errors.New("multillm: bad tool call index")
+)
+
+// maxToolCalls is how many tool calls a streamed response can have. Models
+// make a handful at most.
+const maxToolCalls = 128
+
 type Request struct {
 	Model       string        `json:"model"`
 	Messages    []llm.Message `json:"messages"`
+	Tools       []Tool        `json:"tools,omitempty"`
 	Temperature *float64      `json:"temperature,omitempty"`
 	RandomSeed  *int          `json:"random_seed,omitempty"`
 }
 
+// Tool is a function the model may ask to have called. Calls show up in
+// Response.Response.ToolCalls, and their results go back to the model as
+// messages with the "tool" role and the call's ID in ToolCallID.
+type Tool struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Parameters is the JSON Schema of the arguments object. If empty, the
+	// tool takes no arguments.
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+}
+
 type Response struct {
 	Response         llm.Message `json:"response"`
 	PromptTokens     int         `json:"prompt_tokens"`
 	CompletionTokens int         `json:"completion_tokens"`
+	// Model is the model that answered, as reported by the provider.
+	Model string `json:"model,omitempty"`
+	// Provider is set by Router to the name of the provider that answered.
+	Provider string `json:"provider,omitempty"`
 }
 
 type Chatter interface {
 	Chat(ctx context.Context, req *Request) (*Response, error)
 }
 
+// Delta is a piece of a streamed response.
+type Delta struct {
+	// Content is the next bit of text.
+	Content string `json:"content,omitempty"`
+	// ToolCalls are complete tool calls. Providers stream tool calls in
+	// fragments, so they are only sent once put back together.
+	ToolCalls []llm.ToolCall `json:"tool_calls,omitempty"`
+}
+
+// Streamer is a Chatter that can send its response as it is generated.
+type Streamer interface {
+	Chatter
+	// ChatStream calls fn with each piece of the response as it arrives and
+	// returns the whole response once it is done. If fn returns an error,
+	// the stream is stopped and the error is returned.
+	ChatStream(ctx context.Context, req *Request, fn func(Delta) error) (*Response, error)
+}
+
 type MultiChatModel struct {
 	Provider string   `json:"provider"`
 	Models   []string `json:"models"`
 }
 
 type MultiChatRequest struct {
-	Models   []MultiChatModel `json:"models"`
-	Messages []llm.Message    `json:"messages"`
+	Models      []MultiChatModel `json:"models"`
+	Messages    []llm.Message    `json:"messages"`
+	Tools       []Tool           `json:"tools,omitempty"`
+	Temperature *float64         `json:"temperature,omitempty"`
+	RandomSeed  *int             `json:"random_seed,omitempty"`
+}
+
+// schema returns params, or the schema of an empty object if it is unset.
+func schema(params json.RawMessage) json.RawMessage {
+	if len(params) == 0 {
+		return json.RawMessage(`{"type":"object","properties":{}}`)
+	}
+	return params
+}
+
+// decodeParam converts a JSON Schema into a client's parameter type.
+func decodeParam[T any](params json.RawMessage) (T, error) {
+	var result T
+	err := json.Unmarshal(schema(params), &result)
+	return result, err
+}
+
+// toolCallBuilder puts tool calls streamed in fragments back together.
+type toolCallBuilder struct {
+	calls []llm.ToolCall
+}
+
+// add appends a fragment to the call at index.
+func (b *toolCallBuilder) add(index int, id, name, arguments string) error {
+	if index < 0 || index >= maxToolCalls {
+		return fmt.Errorf("%w: %d", ErrBadToolCall, index)
+	}
+
+	for len(b.calls) <= index {
+		b.calls = append(b.calls, llm.ToolCall{Type: "function"})
+	}
+
+	tc := &b.calls[index]
+	if id != "" {
+		tc.ID = id
+	}
+	tc.Function.Name += name
+	tc.Function.Arguments += arguments
+	return nil
 }
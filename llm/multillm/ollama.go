@@ -2,6 +2,8 @@ package multillm
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"within.website/x/llm"
 	"within.website/x/web/ollama"
@@ -11,7 +13,7 @@ type Ollama struct {
 	*ollama.Client
 }
 
-func (o *Ollama) Chat(ctx context.Context, req *Request) (*Response, error) {
+func (o *Ollama) request(req *Request) (*ollama.CompleteRequest, error) {
 	cr := &ollama.CompleteRequest{
 		Model:    req.Model,
 		Messages: make([]ollama.Message, len(req.Messages)),
@@ -26,6 +28,64 @@ func (o *Ollama) Chat(ctx context.Context, req *Request) (*Response, error) {
 			Role:    m.Role,
 			Content: m.Content,
 		}
+
+		for _, tc := range m.ToolCalls {
+			args := json.RawMessage(tc.Function.Arguments)
+			if len(args) == 0 {
+				args = json.RawMessage("{}")
+			}
+
+			cr.Messages[i].ToolCalls = append(cr.Messages[i].ToolCalls, ollama.ToolInvocation{
+				Function: ollama.ToolCall{
+					Name:      tc.Function.Name,
+					Arguments: args,
+				},
+			})
+		}
+	}
+
+	for _, t := range req.Tools {
+		params, err := decodeParam[ollama.Param](t.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("multillm: tool %s has invalid parameters: %w", t.Name, err)
+		}
+
+		cr.Tools = append(cr.Tools, ollama.Tool{
+			Type: "function",
+			Function: ollama.Function{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  params,
+			},
+		})
+	}
+
+	return cr, nil
+}
+
+// convertOllamaToolCalls gives Ollama's tool calls IDs, which Ollama doesn't
+// use but other providers need.
+func convertOllamaToolCalls(offset int, tcs []ollama.ToolInvocation) []llm.ToolCall {
+	var result []llm.ToolCall
+
+	for i, tc := range tcs {
+		result = append(result, llm.ToolCall{
+			ID:   fmt.Sprintf("call_%d", offset+i),
+			Type: "function",
+			Function: llm.FunctionCall{
+				Name:      tc.Function.Name,
+				Arguments: string(tc.Function.Arguments),
+			},
+		})
+	}
+
+	return result
+}
+
+func (o *Ollama) Chat(ctx context.Context, req *Request) (*Response, error) {
+	cr, err := o.request(req)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := o.Client.Chat(ctx, cr)
@@ -35,10 +95,51 @@ func (o *Ollama) Chat(ctx context.Context, req *Request) (*Response, error) {
 
 	return &Response{
 		Response: llm.Message{
-			Role:    resp.Message.Role,
-			Content: resp.Message.Content,
+			Role:      resp.Message.Role,
+			Content:   resp.Message.Content,
+			ToolCalls: convertOllamaToolCalls(0, resp.Message.ToolCalls),
 		},
 		PromptTokens:     int(resp.PromptEvalCount),
 		CompletionTokens: int(resp.EvalCount),
+		Model:            resp.Model,
 	}, nil
 }
+
+func (o *Ollama) ChatStream(ctx context.Context, req *Request, fn func(Delta) error) (*Response, error) {
+	cr, err := o.request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{
+		Response: llm.Message{Role: "assistant"},
+	}
+
+	if err := o.Client.ChatStream(ctx, cr, func(resp ollama.CompleteResponse) error {
+		result.Model = resp.Model
+		if resp.Done {
+			result.PromptTokens = int(resp.PromptEvalCount)
+			result.CompletionTokens = int(resp.EvalCount)
+		}
+
+		// Ollama sends each tool call whole.
+		result.Response.ToolCalls = append(result.Response.ToolCalls, convertOllamaToolCalls(len(result.Response.ToolCalls), resp.Message.ToolCalls)...)
+
+		if resp.Message.Content == "" {
+			return nil
+		}
+
+		result.Response.Content += resp.Message.Content
+		return fn(Delta{Content: resp.Message.Content})
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(result.Response.ToolCalls) != 0 {
+		if err := fn(Delta{ToolCalls: result.Response.ToolCalls}); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
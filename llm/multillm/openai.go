@@ -13,13 +13,47 @@ type OpenAI struct {
 }
 
 func convertToChatGPTMessage(m llm.Message) chatgpt.Message {
-	return chatgpt.Message{
+	result := chatgpt.Message{
+		Role:       m.Role,
+		Content:    m.Content,
+		ToolCallID: m.ToolCallID,
+	}
+
+	for _, tc := range m.ToolCalls {
+		result.ToolCalls = append(result.ToolCalls, chatgpt.ToolCall{
+			ID:   tc.ID,
+			Type: "function",
+			Function: chatgpt.Funcall{
+				Name:      tc.Function.Name,
+				Arguments: tc.Function.Arguments,
+			},
+		})
+	}
+
+	return result
+}
+
+func convertFromChatGPTMessage(m chatgpt.Message) llm.Message {
+	result := llm.Message{
 		Role:    m.Role,
 		Content: m.Content,
 	}
+
+	for _, tc := range m.ToolCalls {
+		result.ToolCalls = append(result.ToolCalls, llm.ToolCall{
+			ID:   tc.ID,
+			Type: "function",
+			Function: llm.FunctionCall{
+				Name:      tc.Function.Name,
+				Arguments: tc.Function.Arguments,
+			},
+		})
+	}
+
+	return result
 }
 
-func (oaic *OpenAI) Chat(ctx context.Context, req *Request) (*Response, error) {
+func (oaic *OpenAI) request(req *Request) (chatgpt.Request, error) {
 	chatReq := chatgpt.Request{
 		Model:       req.Model,
 		Temperature: req.Temperature,
@@ -31,15 +65,95 @@ func (oaic *OpenAI) Chat(ctx context.Context, req *Request) (*Response, error) {
 		chatReq.Messages[i] = convertToChatGPTMessage(m)
 	}
 
+	for _, t := range req.Tools {
+		params, err := decodeParam[chatgpt.Param](t.Parameters)
+		if err != nil {
+			return chatReq, fmt.Errorf("multillm: tool %s has invalid parameters: %w", t.Name, err)
+		}
+
+		chatReq.Tools = append(chatReq.Tools, chatgpt.Tool{
+			Type: "function",
+			Function: chatgpt.Function{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  params,
+			},
+		})
+	}
+
+	return chatReq, nil
+}
+
+func (oaic *OpenAI) Chat(ctx context.Context, req *Request) (*Response, error) {
+	chatReq, err := oaic.request(req)
+	if err != nil {
+		return nil, err
+	}
+
 	chatResp, err := oaic.Client.Complete(ctx, chatReq)
 	if err != nil {
 		return nil, fmt.Errorf("multillm: error chatting: %w", err)
 	}
 
+	if len(chatResp.Choices) == 0 {
+		return nil, ErrNoChoices
+	}
+
 	return &Response{
-		Response: llm.Message{
-			Role:    chatResp.Choices[0].Message.Role,
-			Content: chatResp.Choices[0].Message.Content,
-		},
+		Response:         convertFromChatGPTMessage(chatResp.Choices[0].Message),
+		PromptTokens:     chatResp.Usage.PromptTokens,
+		CompletionTokens: chatResp.Usage.CompletionTokens,
+		Model:            chatResp.Model,
 	}, nil
 }
+
+func (oaic *OpenAI) ChatStream(ctx context.Context, req *Request, fn func(Delta) error) (*Response, error) {
+	chatReq, err := oaic.request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var acc chatgpt.Accumulator
+
+	if err := oaic.Client.CompleteStream(ctx, chatReq, func(chunk chatgpt.StreamChunk) error {
+		acc.Add(chunk)
+
+		for _, choice := range chunk.Choices {
+			if choice.Index != 0 || choice.Delta.Content == "" {
+				continue
+			}
+
+			if err := fn(Delta{Content: choice.Delta.Content}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("multillm: error chatting: %w", err)
+	}
+	if err := acc.Err(); err != nil {
+		return nil, fmt.Errorf("multillm: error chatting: %w", err)
+	}
+
+	chatResp := acc.Response()
+	if len(chatResp.Choices) == 0 {
+		return nil, ErrNoChoices
+	}
+
+	result := &Response{
+		Response:         convertFromChatGPTMessage(chatResp.Choices[0].Message),
+		PromptTokens:     chatResp.Usage.PromptTokens,
+		CompletionTokens: chatResp.Usage.CompletionTokens,
+		Model:            chatResp.Model,
+	}
+	result.Response.Role = "assistant"
+
+	if len(result.Response.ToolCalls) != 0 {
+		if err := fn(Delta{ToolCalls: result.Response.ToolCalls}); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
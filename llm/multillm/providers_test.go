@@ -0,0 +1,275 @@
+package multillm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"within.website/x/llm"
+	"within.website/x/web/mistral"
+	"within.website/x/web/ollama"
+	"within.website/x/web/openai/chatgpt"
+)
+
+var weatherTool = Tool{
+	Name:        "weather",
+	Description: "Get the weather in a city",
+	Parameters:  json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}},"required":["city"]}`),
+}
+
+func writeSSE(w http.ResponseWriter, chunks ...any) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	for _, chunk := range chunks {
+		data, _ := json.Marshal(chunk)
+		fmt.Fprintf(w, "data: %s\n\n", data)
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+}
+
+// fakeOpenAI answers like the OpenAI chat completions API. Streamed tool
+// calls are split over chunks like the real thing does.
+func fakeOpenAI(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req chatgpt.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("can't decode request: %v", err)
+		}
+		if len(req.Tools) != 1 || req.Tools[0].Function.Parameters.Properties["city"].Type != "string" {
+			t.Errorf("tools = %+v", req.Tools)
+		}
+
+		if !req.Stream {
+			json.NewEncoder(w).Encode(chatgpt.Response{
+				Model: req.Model,
+				Usage: chatgpt.Usage{PromptTokens: 10, CompletionTokens: 5},
+				Choices: []chatgpt.Choice{{
+					Message: chatgpt.Message{
+						Role:    "assistant",
+						Content: "Hello",
+						ToolCalls: []chatgpt.ToolCall{{
+							ID:       "call_abc",
+							Type:     "function",
+							Function: chatgpt.Funcall{Name: "weather", Arguments: `{"city":"Ottawa"}`},
+						}},
+					},
+				}},
+			})
+			return
+		}
+
+		delta := func(d chatgpt.StreamDelta) chatgpt.StreamChunk {
+			return chatgpt.StreamChunk{Model: req.Model, Choices: []chatgpt.StreamChoice{{Delta: d}}}
+		}
+		writeSSE(w,
+			delta(chatgpt.StreamDelta{Role: "assistant", Content: "Hel"}),
+			delta(chatgpt.StreamDelta{Content: "lo"}),
+			delta(chatgpt.StreamDelta{ToolCalls: []chatgpt.ToolCallDelta{{Index: 0, ID: "call_abc", Type: "function", Function: chatgpt.Funcall{Name: "weather"}}}}),
+			delta(chatgpt.StreamDelta{ToolCalls: []chatgpt.ToolCallDelta{{Index: 0, Function: chatgpt.Funcall{Arguments: `{"city":`}}}}),
+			delta(chatgpt.StreamDelta{ToolCalls: []chatgpt.ToolCallDelta{{Index: 0, Function: chatgpt.Funcall{Arguments: `"Ottawa"}`}}}}),
+			chatgpt.StreamChunk{Model: req.Model, Usage: &chatgpt.Usage{PromptTokens: 10, CompletionTokens: 5}},
+		)
+	}
+}
+
+// fakeOllama answers like Ollama's /api/chat.
+func fakeOllama(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ollama.CompleteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("can't decode request: %v", err)
+		}
+		if len(req.Tools) != 1 || req.Tools[0].Function.Name != "weather" {
+			t.Errorf("tools = %+v", req.Tools)
+		}
+
+		call := []ollama.ToolInvocation{{Function: ollama.ToolCall{Name: "weather", Arguments: json.RawMessage(`{"city":"Ottawa"}`)}}}
+		done := ollama.CompleteResponse{Model: req.Model, Done: true, PromptEvalCount: 10, EvalCount: 5}
+
+		enc := json.NewEncoder(w)
+		if !req.Stream {
+			done.Message = ollama.Message{Role: "assistant", Content: "Hello", ToolCalls: call}
+			enc.Encode(done)
+			return
+		}
+
+		enc.Encode(ollama.CompleteResponse{Model: req.Model, Message: ollama.Message{Role: "assistant", Content: "Hel"}})
+		enc.Encode(ollama.CompleteResponse{Model: req.Model, Message: ollama.Message{Role: "assistant", Content: "lo"}})
+		enc.Encode(ollama.CompleteResponse{Model: req.Model, Message: ollama.Message{Role: "assistant", ToolCalls: call}})
+		enc.Encode(done)
+	}
+}
+
+// fakeMistral answers like the Mistral chat completions API.
+func fakeMistral(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer hunter2" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+
+		var req mistral.CompleteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("can't decode request: %v", err)
+		}
+		if len(req.Tools) != 1 || !strings.Contains(string(req.Tools[0].Function.Parameters), "city") {
+			t.Errorf("tools = %+v", req.Tools)
+		}
+
+		call := []llm.ToolCall{{ID: "call_abc", Type: "function", Function: llm.FunctionCall{Name: "weather", Arguments: `{"city":"Ottawa"}`}}}
+		usage := mistral.UsageInfo{PromptTokens: 10, CompletionTokens: 5}
+
+		if req.Stream == nil || !*req.Stream {
+			json.NewEncoder(w).Encode(mistral.CompleteResponse{
+				Model:   req.Model,
+				Usage:   usage,
+				Choices: []mistral.CompletionChoice{{Message: mistral.Message{Role: "assistant", Content: "Hello", ToolCalls: call}}},
+			})
+			return
+		}
+
+		delta := func(m mistral.Message) mistral.StreamChunk {
+			return mistral.StreamChunk{Model: req.Model, Choices: []mistral.StreamChoice{{Delta: m}}}
+		}
+		last := delta(mistral.Message{ToolCalls: call})
+		last.Usage = &usage
+		writeSSE(w,
+			delta(mistral.Message{Role: "assistant", Content: "Hel"}),
+			delta(mistral.Message{Content: "lo"}),
+			last,
+		)
+	}
+}
+
+func TestProviders(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		handler func(*testing.T) http.HandlerFunc
+		path    string
+		mk      func(url string) Streamer
+	}{
+		{
+			name:    "openai",
+			handler: fakeOpenAI,
+			path:    "/v1/chat/completions",
+			mk: func(url string) Streamer {
+				cli := chatgpt.NewClient("hunter2").WithBaseURL(url)
+				return &OpenAI{Client: &cli}
+			},
+		},
+		{
+			name:    "ollama",
+			handler: fakeOllama,
+			path:    "/api/chat",
+			mk: func(url string) Streamer {
+				return &Ollama{Client: ollama.NewClient(url)}
+			},
+		},
+		{
+			name:    "mistral",
+			handler: fakeMistral,
+			path:    "/v1/chat/completions",
+			mk: func(url string) Streamer {
+				return &Mistral{Client: mistral.NewClient("hunter2").WithBaseURL(url)}
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.Handle("POST "+tt.path, tt.handler(t))
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			c := tt.mk(srv.URL)
+			req := &Request{
+				Model:    "fake",
+				Messages: []llm.Message{{Role: "user", Content: "What's the weather in Ottawa?"}},
+				Tools:    []Tool{weatherTool},
+			}
+
+			check := func(t *testing.T, resp *Response) {
+				t.Helper()
+				if resp.Response.Content != "Hello" || resp.PromptTokens != 10 || resp.CompletionTokens != 5 || resp.Model != "fake" {
+					t.Errorf("response = %+v", resp)
+				}
+				if len(resp.Response.ToolCalls) != 1 {
+					t.Fatalf("tool calls = %+v, want one", resp.Response.ToolCalls)
+				}
+				tc := resp.Response.ToolCalls[0]
+				if tc.ID == "" || tc.Function.Name != "weather" || tc.Function.Arguments != `{"city":"Ottawa"}` {
+					t.Errorf("tool call = %+v", tc)
+				}
+			}
+
+			t.Run("chat", func(t *testing.T) {
+				resp, err := c.Chat(t.Context(), req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				check(t, resp)
+			})
+
+			t.Run("stream", func(t *testing.T) {
+				var content []string
+				var calls []llm.ToolCall
+				resp, err := c.ChatStream(t.Context(), req, func(d Delta) error {
+					if d.Content != "" {
+						content = append(content, d.Content)
+					}
+					calls = append(calls, d.ToolCalls...)
+					return nil
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				check(t, resp)
+
+				if strings.Join(content, "|") != "Hel|lo" {
+					t.Errorf("deltas = %q, want Hel then lo", content)
+				}
+				if len(calls) != 1 {
+					t.Errorf("streamed tool calls = %+v, want one", calls)
+				}
+			})
+
+			t.Run("stop stream", func(t *testing.T) {
+				stop := errors.New("stop")
+				if _, err := c.ChatStream(t.Context(), req, func(Delta) error { return stop }); !errors.Is(err, stop) {
+					t.Errorf("err = %v, want the callback's error", err)
+				}
+			})
+		})
+	}
+}
+
+func TestToolCallBuilder(t *testing.T) {
+	var b toolCallBuilder
+
+	for _, frag := range []struct {
+		index               int
+		id, name, arguments string
+	}{
+		{0, "call_1", "get_", `{"city":`},
+		{0, "", "weather", `"Ottawa"}`},
+		{1, "call_2", "get_time", `{}`},
+	} {
+		if err := b.add(frag.index, frag.id, frag.name, frag.arguments); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(b.calls) != 2 || b.calls[0].ID != "call_1" || b.calls[0].Function.Name != "get_weather" || b.calls[0].Function.Arguments != `{"city":"Ottawa"}` {
+		t.Errorf("got calls %+v", b.calls)
+	}
+
+	for _, index := range []int{-1, maxToolCalls, 1 << 40} {
+		if err := b.add(index, "", "x", ""); !errors.Is(err, ErrBadToolCall) {
+			t.Errorf("add(%d): err = %v, want ErrBadToolCall", index, err)
+		}
+	}
+	if len(b.calls) != 2 {
+		t.Errorf("bad indexes grew the calls to %d", len(b.calls))
+	}
+}
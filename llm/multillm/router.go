@@ -0,0 +1,275 @@
+package multillm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCircuitOpen is returned for a provider that failed too often
+	// recently and is being skipped until its cooldown is over.
+	ErrCircuitOpen = errors.New("multillm: provider circuit breaker is open")
+	// ErrNoProviders is returned by a Router with no providers.
+	ErrNoProviders = errors.New("multillm: no providers configured")
+	// ErrUnknownProvider is returned for a MultiChatRequest naming a
+	// provider the Router doesn't have.
+	ErrUnknownProvider = errors.New("multillm: unknown provider")
+)
+
+var (
+	_ Streamer = &Router{}
+	_ Streamer = &OpenAI{}
+	_ Streamer = &Ollama{}
+	_ Streamer = &Mistral{}
+)
+
+const (
+	DefaultFailureThreshold = 3
+	DefaultCooldown         = 30 * time.Second
+)
+
+// Provider is a backend a Router can send requests to.
+type Provider struct {
+	// Name identifies the provider in MultiChatRequest and Response.Provider.
+	Name    string
+	Chatter Chatter
+	// Model, if set, replaces Request.Model for this provider, as model names
+	// differ between providers.
+	Model string
+	// Timeout, if set, limits each request to the provider.
+	Timeout time.Duration
+}
+
+// Router is a Chatter that tries its providers in order until one answers.
+//
+// Each provider has a circuit breaker: after FailureThreshold failures in a
+// row the provider is skipped for Cooldown, then given another chance.
+// Cancelling the caller's context doesn't count as a failure.
+type Router struct {
+	// FailureThreshold and Cooldown configure the circuit breakers. Set them
+	// before the Router is used.
+	FailureThreshold int
+	Cooldown         time.Duration
+
+	providers []Provider
+	now       func() time.Time
+
+	lock     sync.Mutex
+	breakers map[string]*breaker
+}
+
+type breaker struct {
+	failures  int
+	openUntil time.Time
+}
+
+// NewRouter creates a Router that tries providers in the order given.
+func NewRouter(providers ...Provider) *Router {
+	return &Router{
+		FailureThreshold: DefaultFailureThreshold,
+		Cooldown:         DefaultCooldown,
+		providers:        providers,
+		now:              time.Now,
+		breakers:         map[string]*breaker{},
+	}
+}
+
+// allow reports whether a request may be sent to the named provider.
+func (r *Router) allow(name string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	b, ok := r.breakers[name]
+	if !ok {
+		return true
+	}
+
+	return b.failures < r.FailureThreshold || !r.now().Before(b.openUntil)
+}
+
+// record updates the named provider's circuit breaker with a result.
+func (r *Router) record(name string, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	b, ok := r.breakers[name]
+	if !ok {
+		b = &breaker{}
+		r.breakers[name] = b
+	}
+
+	if err == nil {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= r.FailureThreshold {
+		b.openUntil = r.now().Add(r.Cooldown)
+	}
+}
+
+type chatFunc func(ctx context.Context, c Chatter, req *Request) (*Response, error)
+
+// call sends req to p, enforcing its timeout and model override.
+func (r *Router) call(ctx context.Context, p Provider, req *Request, fn chatFunc) (*Response, error) {
+	if !r.allow(p.Name) {
+		return nil, ErrCircuitOpen
+	}
+
+	preq := *req
+	if p.Model != "" {
+		preq.Model = p.Model
+	}
+
+	pctx := ctx
+	if p.Timeout != 0 {
+		var cancel context.CancelFunc
+		pctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	resp, err := fn(pctx, p.Chatter, &preq)
+	if ctx.Err() == nil {
+		r.record(p.Name, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Provider = p.Name
+	return resp, nil
+}
+
+// try calls providers in order until one succeeds, the caller's context is
+// done or stop reports that falling back isn't possible anymore.
+func (r *Router) try(ctx context.Context, req *Request, fn chatFunc, stop func() bool) (*Response, error) {
+	if len(r.providers) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	var errs []error
+	for _, p := range r.providers {
+		resp, err := r.call(ctx, p, req, fn)
+		if err == nil {
+			return resp, nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+		if ctx.Err() != nil || stop() {
+			break
+		}
+
+		if !errors.Is(err, ErrCircuitOpen) {
+			slog.WarnContext(ctx, "provider failed, trying the next one", "provider", p.Name, "err", err)
+		}
+	}
+
+	return nil, fmt.Errorf("multillm: no provider answered: %w", errors.Join(errs...))
+}
+
+func (r *Router) Chat(ctx context.Context, req *Request) (*Response, error) {
+	return r.try(ctx, req, func(ctx context.Context, c Chatter, req *Request) (*Response, error) {
+		return c.Chat(ctx, req)
+	}, func() bool { return false })
+}
+
+// ChatStream streams from the first provider that answers. Providers that
+// can't stream send their whole response as one Delta. Once a provider has
+// sent part of its response, its failure is returned instead of falling
+// back, as the caller has already seen the partial response.
+func (r *Router) ChatStream(ctx context.Context, req *Request, fn func(Delta) error) (*Response, error) {
+	var started bool
+	send := func(d Delta) error {
+		started = true
+		return fn(d)
+	}
+
+	return r.try(ctx, req, func(ctx context.Context, c Chatter, req *Request) (*Response, error) {
+		if s, ok := c.(Streamer); ok {
+			return s.ChatStream(ctx, req, send)
+		}
+
+		resp, err := c.Chat(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := send(Delta{Content: resp.Response.Content, ToolCalls: resp.Response.ToolCalls}); err != nil {
+			return nil, err
+		}
+
+		return resp, nil
+	}, func() bool { return started })
+}
+
+// MultiChatResult is the answer of one model to a MultiChatRequest.
+type MultiChatResult struct {
+	Provider string        `json:"provider"`
+	Model    string        `json:"model"`
+	Response *Response     `json:"response,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// MultiChat sends the same conversation to every model in mcr at once, for
+// comparing their answers side by side. There is no fallback: each result
+// has either the model's response or its error. Results are in the order of
+// mcr.Models.
+func (r *Router) MultiChat(ctx context.Context, mcr *MultiChatRequest) []MultiChatResult {
+	var results []MultiChatResult
+	for _, m := range mcr.Models {
+		for _, model := range m.Models {
+			results = append(results, MultiChatResult{Provider: m.Provider, Model: model})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Go(func() {
+			res := &results[i]
+
+			p, ok := r.provider(res.Provider)
+			if !ok {
+				res.Error = ErrUnknownProvider.Error()
+				return
+			}
+			p.Model = ""
+
+			req := &Request{
+				Model:       res.Model,
+				Messages:    mcr.Messages,
+				Tools:       mcr.Tools,
+				Temperature: mcr.Temperature,
+				RandomSeed:  mcr.RandomSeed,
+			}
+
+			start := time.Now()
+			resp, err := r.call(ctx, p, req, func(ctx context.Context, c Chatter, req *Request) (*Response, error) {
+				return c.Chat(ctx, req)
+			})
+			res.Duration = time.Since(start)
+			if err != nil {
+				res.Error = err.Error()
+				return
+			}
+			res.Response = resp
+		})
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (r *Router) provider(name string) (Provider, bool) {
+	for _, p := range r.providers {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Provider{}, false
+}
@@ -0,0 +1,186 @@
+package multillm
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"within.website/x/llm"
+)
+
+// fakeChatter answers with its name, or fails with err.
+type fakeChatter struct {
+	name  string
+	err   error
+	delay time.Duration
+	calls int
+	model string
+}
+
+func (f *fakeChatter) Chat(ctx context.Context, req *Request) (*Response, error) {
+	f.calls++
+	f.model = req.Model
+
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &Response{Response: llm.Message{Role: "assistant", Content: f.name}, Model: req.Model}, nil
+}
+
+var errDown = errors.New("provider is down")
+
+func TestRouterFallback(t *testing.T) {
+	primary := &fakeChatter{name: "primary", err: errDown}
+	slow := &fakeChatter{name: "slow", delay: time.Second}
+	backup := &fakeChatter{name: "backup"}
+
+	r := NewRouter(
+		Provider{Name: "primary", Chatter: primary},
+		Provider{Name: "slow", Chatter: slow, Timeout: 10 * time.Millisecond},
+		Provider{Name: "backup", Chatter: backup, Model: "backup-model"},
+	)
+
+	resp, err := r.Chat(t.Context(), &Request{Model: "default-model"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Response.Content != "backup" || resp.Provider != "backup" {
+		t.Errorf("response = %+v, want the backup's", resp)
+	}
+	if primary.model != "default-model" || backup.model != "backup-model" {
+		t.Errorf("models = %q, %q", primary.model, backup.model)
+	}
+}
+
+func TestRouterAllFail(t *testing.T) {
+	r := NewRouter(
+		Provider{Name: "a", Chatter: &fakeChatter{err: errDown}},
+		Provider{Name: "b", Chatter: &fakeChatter{err: errDown}},
+	)
+
+	if _, err := r.Chat(t.Context(), &Request{}); !errors.Is(err, errDown) {
+		t.Errorf("err = %v, want errDown", err)
+	}
+
+	if _, err := NewRouter().Chat(t.Context(), &Request{}); !errors.Is(err, ErrNoProviders) {
+		t.Errorf("err = %v, want ErrNoProviders", err)
+	}
+}
+
+func TestRouterCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	flaky := &fakeChatter{name: "flaky", err: errDown}
+	backup := &fakeChatter{name: "backup"}
+
+	r := NewRouter(
+		Provider{Name: "flaky", Chatter: flaky},
+		Provider{Name: "backup", Chatter: backup},
+	)
+	r.FailureThreshold = 2
+	r.Cooldown = time.Minute
+	r.now = func() time.Time { return now }
+
+	for range 4 {
+		if _, err := r.Chat(t.Context(), &Request{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if flaky.calls != 2 || backup.calls != 4 {
+		t.Errorf("calls = %d, %d, want flaky skipped after 2 failures", flaky.calls, backup.calls)
+	}
+
+	// After the cooldown the provider gets another chance.
+	now = now.Add(2 * time.Minute)
+	flaky.err = nil
+
+	resp, err := r.Chat(t.Context(), &Request{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Provider != "flaky" {
+		t.Errorf("provider = %q, want flaky after the cooldown", resp.Provider)
+	}
+}
+
+func TestRouterCancelIsNotAFailure(t *testing.T) {
+	slow := &fakeChatter{name: "slow", delay: time.Second}
+	r := NewRouter(Provider{Name: "slow", Chatter: slow})
+	r.FailureThreshold = 1
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if _, err := r.Chat(ctx, &Request{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if !r.allow("slow") {
+		t.Error("circuit opened because the caller gave up")
+	}
+}
+
+func TestRouterChatStream(t *testing.T) {
+	r := NewRouter(
+		Provider{Name: "down", Chatter: &fakeChatter{err: errDown}},
+		Provider{Name: "up", Chatter: &fakeChatter{name: "not streaming"}},
+	)
+
+	var deltas []Delta
+	resp, err := r.ChatStream(t.Context(), &Request{}, func(d Delta) error {
+		deltas = append(deltas, d)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(deltas) != 1 || deltas[0].Content != "not streaming" || resp.Provider != "up" {
+		t.Errorf("deltas = %+v, response = %+v", deltas, resp)
+	}
+}
+
+func TestRouterMultiChat(t *testing.T) {
+	a := &fakeChatter{name: "a", delay: 50 * time.Millisecond}
+	b := &fakeChatter{name: "b", delay: 50 * time.Millisecond}
+	r := NewRouter(
+		Provider{Name: "a", Chatter: a, Model: "ignored"},
+		Provider{Name: "b", Chatter: b},
+	)
+
+	start := time.Now()
+	results := r.MultiChat(t.Context(), &MultiChatRequest{
+		Models: []MultiChatModel{
+			{Provider: "a", Models: []string{"a-1"}},
+			{Provider: "b", Models: []string{"b-1"}},
+			{Provider: "c", Models: []string{"c-1"}},
+		},
+		Messages: []llm.Message{{Role: "user", Content: "hi"}},
+	})
+	if took := time.Since(start); took > 500*time.Millisecond {
+		t.Errorf("took %s, want the requests to run in parallel", took)
+	}
+
+	var got []string
+	for _, res := range results {
+		if res.Response != nil {
+			got = append(got, res.Provider+"/"+res.Response.Model)
+		} else {
+			got = append(got, res.Provider+": "+res.Error)
+		}
+	}
+
+	want := []string{"a/a-1", "b/b-1", "c: " + ErrUnknownProvider.Error()}
+	if !slices.Equal(got, want) {
+		t.Errorf("results = %q, want %q", got, want)
+	}
+}
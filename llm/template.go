@@ -0,0 +1,242 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Template renders a conversation into a prompt for a model that takes raw
+// text, such as one served by llama.cpp.
+//
+// The prompt ends where the model should continue writing: after the
+// header of a new assistant turn, or, if the last message is from the
+// assistant, inside that message so the model continues it.
+type Template interface {
+	Render(messages []Message) (string, error)
+}
+
+// UnsupportedRoleError is returned when a template has no way to render a
+// message's role.
+type UnsupportedRoleError struct {
+	Template string
+	Role     string
+}
+
+func (e *UnsupportedRoleError) Error() string {
+	return fmt.Sprintf("llm: the %s template can't render %q messages", e.Template, e.Role)
+}
+
+// Render formats s with t. Only Message values can be rendered by a
+// Template.
+func (s Session) Render(t Template) (string, error) {
+	messages := make([]Message, 0, len(s.Messages))
+
+	for _, m := range s.Messages {
+		msg, ok := m.(Message)
+		if !ok {
+			return "", fmt.Errorf("llm: can't render %T with a template", m)
+		}
+		messages = append(messages, msg)
+	}
+
+	return t.Render(messages)
+}
+
+// prefill reports whether the last message is an assistant message the
+// model should continue.
+func prefill(messages []Message) bool {
+	return len(messages) != 0 && messages[len(messages)-1].Role == "assistant"
+}
+
+// foldSystemPrompt merges leading system messages into the first user
+// message, for formats without a system role.
+func foldSystemPrompt(messages []Message) []Message {
+	var system []string
+	for len(messages) != 0 && messages[0].Role == "system" {
+		system = append(system, messages[0].Content)
+		messages = messages[1:]
+	}
+
+	if len(system) == 0 {
+		return messages
+	}
+
+	result := make([]Message, len(messages))
+	copy(result, messages)
+
+	if len(result) == 0 || result[0].Role != "user" {
+		return append([]Message{{Role: "user", Content: strings.Join(system, "\n\n")}}, result...)
+	}
+
+	result[0].Content = strings.Join(append(system, result[0].Content), "\n\n")
+	return result
+}
+
+// ChatMLTemplate is the ChatML format used by Qwen, Hermes and many
+// fine-tunes.
+type ChatMLTemplate struct{}
+
+func (ChatMLTemplate) Render(messages []Message) (string, error) {
+	var sb strings.Builder
+
+	for i, m := range messages {
+		fmt.Fprintf(&sb, "<|im_start|>%s\n%s", m.Role, m.Content)
+		if i == len(messages)-1 && prefill(messages) {
+			return sb.String(), nil
+		}
+		sb.WriteString("<|im_end|>\n")
+	}
+
+	sb.WriteString("<|im_start|>assistant\n")
+	return sb.String(), nil
+}
+
+// MistralTemplate is the [INST] format of Mistral and Mixtral instruct
+// models. Mistral has no system role, so system messages are put at the
+// start of the first user message.
+type MistralTemplate struct{}
+
+func (MistralTemplate) Render(messages []Message) (string, error) {
+	messages = foldSystemPrompt(messages)
+
+	var sb strings.Builder
+	sb.WriteString("<s>")
+
+	for i, m := range messages {
+		switch m.Role {
+		case "user":
+			fmt.Fprintf(&sb, "[INST] %s [/INST]", m.Content)
+		case "assistant":
+			sb.WriteString(m.Content)
+			if i != len(messages)-1 {
+				sb.WriteString("</s>")
+			}
+		default:
+			return "", &UnsupportedRoleError{Template: "Mistral", Role: m.Role}
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// GemmaTemplate is the turn format of Google's Gemma models. Gemma calls the
+// assistant "model" and has no system role, so system messages are put at
+// the start of the first user message.
+type GemmaTemplate struct{}
+
+func (GemmaTemplate) Render(messages []Message) (string, error) {
+	messages = foldSystemPrompt(messages)
+
+	var sb strings.Builder
+	sb.WriteString("<bos>")
+
+	for i, m := range messages {
+		role := m.Role
+		switch role {
+		case "user":
+		case "assistant":
+			role = "model"
+		default:
+			return "", &UnsupportedRoleError{Template: "Gemma", Role: m.Role}
+		}
+
+		fmt.Fprintf(&sb, "<start_of_turn>%s\n%s", role, strings.TrimSpace(m.Content))
+		if i == len(messages)-1 && prefill(messages) {
+			return sb.String(), nil
+		}
+		sb.WriteString("<end_of_turn>\n")
+	}
+
+	sb.WriteString("<start_of_turn>model\n")
+	return sb.String(), nil
+}
+
+// Llama3Template is the header format of Llama 3 and later. Roles are passed
+// through, so tool results can use the "ipython" role.
+type Llama3Template struct{}
+
+func (Llama3Template) Render(messages []Message) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("<|begin_of_text|>")
+
+	for i, m := range messages {
+		fmt.Fprintf(&sb, "<|start_header_id|>%s<|end_header_id|>\n\n%s", m.Role, strings.TrimSpace(m.Content))
+		if i == len(messages)-1 && prefill(messages) {
+			return sb.String(), nil
+		}
+		sb.WriteString("<|eot_id|>")
+	}
+
+	sb.WriteString("<|start_header_id|>assistant<|end_header_id|>\n\n")
+	return sb.String(), nil
+}
+
+// HarmonyTemplate is the Harmony format of OpenAI's gpt-oss models.
+//
+// Harmony's system message describes the model rather than the task, so
+// system messages in the conversation become the developer message.
+// Assistant messages are rendered on the final channel; reasoning from
+// earlier turns is not kept.
+type HarmonyTemplate struct {
+	// Identity is the first line of the system message. If empty, the
+	// identity gpt-oss was trained with is used.
+	Identity string
+	// KnowledgeCutoff is shown in the system message. If empty, "2024-06".
+	KnowledgeCutoff string
+	// CurrentDate, such as "2025-08-05", is shown in the system message if
+	// set.
+	CurrentDate string
+	// Reasoning is the reasoning effort: "low", "medium" or "high". If empty,
+	// "medium".
+	Reasoning string
+}
+
+func (h HarmonyTemplate) Render(messages []Message) (string, error) {
+	identity := h.Identity
+	if identity == "" {
+		identity = "You are ChatGPT, a large language model trained by OpenAI."
+	}
+	cutoff := h.KnowledgeCutoff
+	if cutoff == "" {
+		cutoff = "2024-06"
+	}
+	reasoning := h.Reasoning
+	if reasoning == "" {
+		reasoning = "medium"
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "<|start|>system<|message|>%s\nKnowledge cutoff: %s\n", identity, cutoff)
+	if h.CurrentDate != "" {
+		fmt.Fprintf(&sb, "Current date: %s\n", h.CurrentDate)
+	}
+	fmt.Fprintf(&sb, "\nReasoning: %s\n\n# Valid channels: analysis, commentary, final. Channel must be included for every message.<|end|>", reasoning)
+
+	var instructions []string
+	for len(messages) != 0 && messages[0].Role == "system" {
+		instructions = append(instructions, messages[0].Content)
+		messages = messages[1:]
+	}
+	if len(instructions) != 0 {
+		fmt.Fprintf(&sb, "<|start|>developer<|message|># Instructions\n\n%s<|end|>", strings.Join(instructions, "\n\n"))
+	}
+
+	for i, m := range messages {
+		switch m.Role {
+		case "user":
+			fmt.Fprintf(&sb, "<|start|>user<|message|>%s<|end|>", m.Content)
+		case "assistant":
+			fmt.Fprintf(&sb, "<|start|>assistant<|channel|>final<|message|>%s", m.Content)
+			if i == len(messages)-1 {
+				return sb.String(), nil
+			}
+			sb.WriteString("<|end|>")
+		default:
+			return "", &UnsupportedRoleError{Template: "Harmony", Role: m.Role}
+		}
+	}
+
+	sb.WriteString("<|start|>assistant")
+	return sb.String(), nil
+}
@@ -0,0 +1,345 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+var updateGoldenFiles = flag.Bool("update", false, "update golden files in testdata/")
+
+var templateConversations = []struct {
+	name     string
+	messages []Message
+}{
+	{
+		name: "single",
+		messages: []Message{
+			{Role: "user", Content: "What is the capital of Canada?"},
+		},
+	},
+	{
+		name: "system",
+		messages: []Message{
+			{Role: "system", Content: "You are a helpful assistant."},
+			{Role: "user", Content: "Hi!"},
+			{Role: "assistant", Content: "Hello! How can I help?"},
+			{Role: "user", Content: "Tell me a joke."},
+		},
+	},
+	{
+		name: "prefill",
+		messages: []Message{
+			{Role: "user", Content: "Reply in JSON."},
+			{Role: "assistant", Content: "{\"answer\":"},
+		},
+	},
+}
+
+// llama3ChatTemplate is the chat template Meta ships with Llama 3 Instruct.
+const llama3ChatTemplate = `{% set loop_messages = messages %}{% for message in loop_messages %}{% set content = '<|start_header_id|>' + message['role'] + '<|end_header_id|>
+
+'+ message['content'] | trim + '<|eot_id|>' %}{% if loop.index0 == 0 %}{% set content = bos_token + content %}{% endif %}{{ content }}{% endfor %}{% if add_generation_prompt %}{{ '<|start_header_id|>assistant<|end_header_id|>
+
+' }}{% endif %}`
+
+// mistralChatTemplate is the chat template of Mistral 7B Instruct v0.1.
+const mistralChatTemplate = `{{ bos_token }}{% for message in messages %}{% if (message['role'] == 'user') != (loop.index0 % 2 == 0) %}{{ raise_exception('Conversation roles must alternate user/assistant/user/assistant/...') }}{% endif %}{% if message['role'] == 'user' %}{{ '[INST] ' + message['content'] + ' [/INST]' }}{% elif message['role'] == 'assistant' %}{{ message['content'] + eos_token + ' ' }}{% else %}{{ raise_exception('Only user and assistant roles are supported!') }}{% endif %}{% endfor %}`
+
+func mustJinja(t *testing.T, source, bos, eos string) Template {
+	t.Helper()
+	tmpl, err := NewJinjaTemplate(source, bos, eos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tmpl
+}
+
+func TestTemplates(t *testing.T) {
+	templates := []struct {
+		name string
+		tmpl Template
+	}{
+		{"chatml", ChatMLTemplate{}},
+		{"mistral", MistralTemplate{}},
+		{"gemma", GemmaTemplate{}},
+		{"llama3", Llama3Template{}},
+		{"harmony", HarmonyTemplate{CurrentDate: "2025-08-05"}},
+		{"jinja_llama3", mustJinja(t, llama3ChatTemplate, "<|begin_of_text|>", "<|eot_id|>")},
+	}
+
+	for _, tmpl := range templates {
+		for _, conv := range templateConversations {
+			name := tmpl.name + "_" + conv.name
+			t.Run(name, func(t *testing.T) {
+				got, err := tmpl.tmpl.Render(conv.messages)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				goldenFilename := filepath.Join("testdata", "templates", name+".golden")
+
+				if *updateGoldenFiles {
+					if err := os.MkdirAll(filepath.Dir(goldenFilename), 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(goldenFilename, []byte(got), 0644); err != nil {
+						t.Fatalf("unable to update golden file: %v", err)
+					}
+				}
+
+				want, err := os.ReadFile(goldenFilename)
+				if err != nil {
+					t.Fatalf("error loading golden file: %v", err)
+				}
+
+				if got != string(want) {
+					t.Errorf("wanted:\n%q\n\ngot:\n%q", want, got)
+				}
+			})
+		}
+	}
+}
+
+func TestJinjaMatchesBuiltin(t *testing.T) {
+	// The built-in Llama 3 template should render exactly what Meta's does,
+	// apart from prefills, which chat templates can't express.
+	jinja := mustJinja(t, llama3ChatTemplate, "<|begin_of_text|>", "<|eot_id|>")
+
+	for _, conv := range templateConversations[:2] {
+		want, err := jinja.Render(conv.messages)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Llama3Template{}.Render(conv.messages)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("%s: builtin:\n%q\n\njinja:\n%q", conv.name, got, want)
+		}
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	tool := []Message{{Role: "tool", Content: "{}"}}
+
+	for _, tmpl := range []Template{MistralTemplate{}, GemmaTemplate{}, HarmonyTemplate{}} {
+		var ure *UnsupportedRoleError
+		if _, err := tmpl.Render(tool); !errors.As(err, &ure) {
+			t.Errorf("%T: err = %v, want UnsupportedRoleError", tmpl, err)
+		}
+	}
+
+	// raise_exception in a chat template becomes an error.
+	jinja := mustJinja(t, mistralChatTemplate, "<s>", "</s>")
+	if _, err := jinja.Render(tool); err == nil {
+		t.Error("rendering a tool message with the Mistral chat template succeeded")
+	}
+
+	if _, err := (Session{Messages: []ChatMLer{LlamaInstruct{Content: "hi"}}}).Render(ChatMLTemplate{}); err == nil {
+		t.Error("rendering a LlamaInstruct message with a template succeeded")
+	}
+}
+
+func TestJinjaNoIncludes(t *testing.T) {
+	hostname := filepath.Join(t.TempDir(), "hostname")
+	if err := os.WriteFile(hostname, []byte("secret-host"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, source := range []string{
+		`{% include "` + hostname + `" %}`,
+		`{% include "../../../../../../../../../..` + hostname + `" %}`,
+		`{% import "` + hostname + `" as h %}{{ h }}`,
+		`{% extends "` + hostname + `" %}`,
+		`{% from "` + hostname + `" import x %}{{ x }}`,
+	} {
+		tmpl, err := NewJinjaTemplate(source, "<s>", "</s>")
+		if err == nil {
+			var got string
+			got, err = tmpl.Render([]Message{{Role: "user", Content: "hi"}})
+			if strings.Contains(got, "secret-host") {
+				t.Errorf("%s: read a file from disk: %q", source, got)
+			}
+		}
+		// gonja doesn't wrap loader errors, so look for the message.
+		if err == nil || !strings.Contains(err.Error(), ErrTemplateInclude.Error()) {
+			t.Errorf("%s: err = %v, want ErrTemplateInclude", source, err)
+		}
+	}
+}
+
+// writeGGUF writes a GGUF header with the given string and uint32 metadata
+// and a token list.
+func writeGGUF(strs map[string]string, ints map[string]uint32, tokens []string) []byte {
+	var buf bytes.Buffer
+	le := binary.LittleEndian
+
+	writeString := func(s string) {
+		binary.Write(&buf, le, uint64(len(s)))
+		buf.WriteString(s)
+	}
+
+	buf.WriteString("GGUF")
+	binary.Write(&buf, le, uint32(3))
+	binary.Write(&buf, le, uint64(0))
+	binary.Write(&buf, le, uint64(len(strs)+len(ints)+1))
+
+	for k, v := range strs {
+		writeString(k)
+		binary.Write(&buf, le, ggufString)
+		writeString(v)
+	}
+	for k, v := range ints {
+		writeString(k)
+		binary.Write(&buf, le, ggufUint32)
+		binary.Write(&buf, le, v)
+	}
+
+	writeString("tokenizer.ggml.tokens")
+	binary.Write(&buf, le, ggufArray)
+	binary.Write(&buf, le, ggufString)
+	binary.Write(&buf, le, uint64(len(tokens)))
+	for _, tok := range tokens {
+		writeString(tok)
+	}
+
+	return buf.Bytes()
+}
+
+func TestJinjaTemplateFromGGUF(t *testing.T) {
+	data := writeGGUF(
+		map[string]string{
+			"general.architecture":    "llama",
+			"tokenizer.chat_template": mistralChatTemplate,
+		},
+		map[string]uint32{
+			"tokenizer.ggml.bos_token_id": 1,
+			"tokenizer.ggml.eos_token_id": 2,
+		},
+		[]string{"<unk>", "<s>", "</s>"},
+	)
+
+	md, err := ReadGGUFMetadata(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if arch, _ := md.String("general.architecture"); arch != "llama" {
+		t.Errorf("architecture = %q", arch)
+	}
+
+	tmpl, err := JinjaTemplateFromGGUF(md)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Mistral's own template puts a space after each </s>, unlike
+	// MistralTemplate.
+	conv := templateConversations[1].messages[1:]
+	got, err := tmpl.Render(conv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "<s>[INST] Hi! [/INST]Hello! How can I help?</s> [INST] Tell me a joke. [/INST]"
+	if got != want {
+		t.Errorf("got:\n%q\n\nwant:\n%q", got, want)
+	}
+
+	if _, err := ReadGGUFMetadata(bytes.NewReader([]byte("GGML\x03\x00\x00\x00"))); !errors.Is(err, ErrNotGGUF) {
+		t.Errorf("err = %v, want ErrNotGGUF", err)
+	}
+
+	// Arrays of arrays of arrays... fail instead of running out of stack.
+	var nested bytes.Buffer
+	nested.Write(writeGGUF(nil, nil, nil))
+	binary.LittleEndian.PutUint64(nested.Bytes()[16:], 2)
+	binary.Write(&nested, binary.LittleEndian, uint64(len("deep")))
+	nested.WriteString("deep")
+	binary.Write(&nested, binary.LittleEndian, ggufArray)
+	for range 1000 {
+		binary.Write(&nested, binary.LittleEndian, ggufArray)
+		binary.Write(&nested, binary.LittleEndian, uint64(1))
+	}
+	if _, err := ReadGGUFMetadata(bytes.NewReader(nested.Bytes())); err == nil || !strings.Contains(err.Error(), "nested more than") {
+		t.Errorf("reading deeply nested arrays: err = %v", err)
+	}
+
+	// Lengths the file doesn't have the data for don't get allocated up
+	// front.
+	for name, tail := range map[string][]any{
+		"string":          {ggufString, uint64(maxGGUFString)},
+		"array":           {ggufArray, ggufUint64, uint64(maxGGUFArray)},
+		"array of arrays": {ggufArray, ggufArray, uint64(maxGGUFArray), ggufArray, uint64(maxGGUFArray), ggufString, uint64(maxGGUFArray)},
+	} {
+		var short bytes.Buffer
+		short.Write(writeGGUF(nil, nil, nil))
+		binary.LittleEndian.PutUint64(short.Bytes()[16:], 2)
+		binary.Write(&short, binary.LittleEndian, uint64(len("short")))
+		short.WriteString("short")
+		for _, v := range tail {
+			binary.Write(&short, binary.LittleEndian, v)
+		}
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, err := ReadGGUFMetadata(bytes.NewReader(short.Bytes()))
+		runtime.ReadMemStats(&after)
+
+		if !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			t.Errorf("%s: err = %v, want EOF", name, err)
+		}
+		if n := after.TotalAlloc - before.TotalAlloc; n > 1<<20 {
+			t.Errorf("%s: allocated %d bytes for a %d byte file", name, n, short.Len())
+		}
+	}
+
+	md, err = ReadGGUFMetadata(bytes.NewReader(writeGGUF(nil, nil, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := JinjaTemplateFromGGUF(md); !errors.Is(err, ErrNoChatTemplate) {
+		t.Errorf("err = %v, want ErrNoChatTemplate", err)
+	}
+}
+
+func TestClientTemplate(t *testing.T) {
+	var prompt string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var opts LLAMAOpts
+		json.NewDecoder(r.Body).Decode(&opts)
+		prompt = opts.Prompt
+		w.Write([]byte(`{"content":"Hi!"}`))
+	}))
+	defer srv.Close()
+
+	session := Session{Messages: []ChatMLer{Message{Role: "user", Content: "Hello"}}}
+	cli := NewClient(srv.Client(), srv.URL)
+
+	if _, err := cli.ExecSession(session); err != nil {
+		t.Fatal(err)
+	}
+	if prompt != session.ChatML() {
+		t.Errorf("without a template, prompt = %q, want ChatML", prompt)
+	}
+
+	cli.Template = MistralTemplate{}
+	if _, err := cli.ExecSession(session); err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := session.Render(MistralTemplate{}); prompt != want {
+		t.Errorf("with a template, prompt = %q, want %q", prompt, want)
+	}
+}
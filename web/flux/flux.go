@@ -2,6 +2,7 @@ package flux
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -82,28 +83,58 @@ func NewClient(baseURL string) *Client {
 	}
 }
 
+// WithClient makes the client send requests through cli, such as one made
+// by useragent.NewClient.
+func (c *Client) WithClient(cli *http.Client) *Client {
+	c.HTTPClient = cli
+	return c
+}
+
 // Methods to interact with the API endpoints
 
 func (c *Client) Predict(predictionReq PredictionRequest) (*PredictionResponse, error) {
+	return c.PredictContext(context.Background(), predictionReq)
+}
+
+// PredictContext is Predict with a context, which cancels waiting for the
+// prediction.
+func (c *Client) PredictContext(ctx context.Context, predictionReq PredictionRequest) (*PredictionResponse, error) {
+	return c.predict(ctx, predictionReq, false)
+}
+
+// PredictAsync starts a prediction and returns without waiting for it to
+// finish. Cog reports its progress to predictionReq.Webhook, which should
+// be set.
+func (c *Client) PredictAsync(ctx context.Context, predictionReq PredictionRequest) (*PredictionResponse, error) {
+	return c.predict(ctx, predictionReq, true)
+}
+
+func (c *Client) predict(ctx context.Context, predictionReq PredictionRequest, async bool) (*PredictionResponse, error) {
 	body, err := json.Marshal(predictionReq)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/predictions", c.BaseURL), bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/predictions", c.BaseURL), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
+	wantStatus := http.StatusOK
+	if async {
+		req.Header.Set("Prefer", "respond-async")
+		wantStatus = http.StatusAccepted
+	}
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, web.NewError(http.StatusOK, resp)
+	if resp.StatusCode != wantStatus {
+		return nil, web.NewError(wantStatus, resp)
 	}
 
 	var predictionResp PredictionResponse
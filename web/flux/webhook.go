@@ -0,0 +1,56 @@
+package flux
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Prediction statuses reported by Cog.
+const (
+	StatusStarting   = "starting"
+	StatusProcessing = "processing"
+	StatusSucceeded  = "succeeded"
+	StatusFailed     = "failed"
+	StatusCanceled   = "canceled"
+)
+
+// Webhook events that PredictionRequest.WebhookEventsFilter can select.
+const (
+	EventStart     = "start"
+	EventOutput    = "output"
+	EventLogs      = "logs"
+	EventCompleted = "completed"
+)
+
+// maxWebhookSize bounds webhook bodies, which have the outputs inline as
+// data URIs unless Cog is set up to upload them.
+const maxWebhookSize = 64 * 1024 * 1024
+
+// Done reports whether the prediction has finished, one way or another.
+func (p *PredictionResponse) Done() bool {
+	switch p.Status {
+	case StatusSucceeded, StatusFailed, StatusCanceled:
+		return true
+	}
+	return false
+}
+
+// ParseWebhook reads the prediction that Cog sent to the URL in
+// PredictionRequest.Webhook.
+//
+// Cog doesn't sign webhooks, so put something unguessable in the URL or
+// keep the handler where only Cog can reach it.
+func ParseWebhook(r *http.Request) (*PredictionResponse, error) {
+	if r.Method != http.MethodPost {
+		return nil, fmt.Errorf("flux: webhook sent with %s, not POST", r.Method)
+	}
+
+	var result PredictionResponse
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxWebhookSize)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("flux: can't parse webhook: %w", err)
+	}
+
+	return &result, nil
+}
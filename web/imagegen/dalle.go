@@ -0,0 +1,79 @@
+package imagegen
+
+import (
+	"context"
+	"fmt"
+
+	"within.website/x/web/openai/dalle"
+)
+
+var (
+	dalle2Sizes = []size{{256, 256}, {512, 512}, {1024, 1024}}
+	dalle3Sizes = []size{{1024, 1024}, {1792, 1024}, {1024, 1792}}
+)
+
+// DALLE makes images with OpenAI's DALL·E. It doesn't support negative
+// prompts or seeds.
+type DALLE struct {
+	Client dalle.Client
+
+	// Model is dalle.DALLE3 by default.
+	Model dalle.Model
+
+	// Style is only used with dalle.DALLE3.
+	Style *dalle.Style
+}
+
+// Generate implements Generator.
+func (d DALLE) Generate(ctx context.Context, req Request) ([]Image, error) {
+	model := d.Model
+	if model == "" {
+		model = dalle.DALLE3
+	}
+
+	sizes := dalle3Sizes
+	if model == dalle.DALLE2 {
+		sizes = dalle2Sizes
+	}
+
+	sz := dalle.Size1024
+	if req.Width != 0 && req.Height != 0 {
+		s := closest(sizes, size{req.Width, req.Height})
+		sz = dalle.Size(fmt.Sprintf("%dx%d", s.w, s.h))
+	}
+
+	format := dalle.FormatB64JSON
+	opts := dalle.Options{
+		Model:          model,
+		Prompt:         req.Prompt,
+		ResponseFormat: &format,
+		Size:           &sz,
+	}
+	if model == dalle.DALLE3 {
+		opts.Style = d.Style
+	}
+
+	// DALL·E 3 only makes one image at a time.
+	perRequest := req.count()
+	if model == dalle.DALLE3 {
+		perRequest = 1
+	}
+	opts.N = &perRequest
+
+	var result []Image
+	for len(result) < req.count() {
+		resp, err := d.Client.GenerateImage(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Data) == 0 {
+			return nil, fmt.Errorf("imagegen: DALL·E returned no images")
+		}
+
+		for _, img := range resp.Data {
+			result = append(result, newImage(img.B64JSON))
+		}
+	}
+
+	return result[:req.count()], nil
+}
@@ -0,0 +1,256 @@
+package imagegen
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"within.website/x/web"
+	"within.website/x/web/flux"
+)
+
+// maxOutputSize bounds images downloaded from Cog.
+const maxOutputSize = 64 * 1024 * 1024
+
+var fluxAspectRatios = []size{{1, 1}, {16, 9}, {21, 9}, {3, 2}, {2, 3}, {4, 5}, {5, 4}, {3, 4}, {4, 3}, {9, 16}, {9, 21}}
+
+// Flux makes images with a Flux model served by Cog. It doesn't support
+// negative prompts, and Width and Height only pick the aspect ratio.
+type Flux struct {
+	Client *flux.Client
+
+	// Defaults has the settings Request doesn't, such as the number of
+	// inference steps. Its prompt is put in front of the request's.
+	Defaults flux.Input
+
+	// WebhookURL is where Cog reports finished predictions, so a Queue
+	// doesn't have to wait for them. Serve Webhook there. If it's empty,
+	// the queue waits.
+	WebhookURL string
+
+	// WebhookSecret signs the webhook URL of each attempt, so that only
+	// whoever was given that URL can finish the attempt. It's required
+	// with WebhookURL and has to stay the same across restarts while jobs
+	// are running.
+	WebhookSecret string
+}
+
+// webhookSignature is the signature Webhook expects for attempt of job id.
+func (f Flux) webhookSignature(id string, attempt int) string {
+	h := hmac.New(sha256.New, []byte(f.WebhookSecret))
+	fmt.Fprintf(h, "%s\x00%d", id, attempt)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (f Flux) input(req Request) flux.Input {
+	inp := f.Defaults
+	inp.Prompt = joinPrompts(inp.Prompt, req.Prompt)
+	inp.NumOutputs = req.count()
+
+	if req.Width != 0 && req.Height != 0 {
+		r := closest(fluxAspectRatios, size{req.Width, req.Height})
+		inp.AspectRatio = fmt.Sprintf("%d:%d", r.w, r.h)
+	}
+	if req.Seed != nil {
+		inp.Seed = req.Seed
+	}
+
+	// Cog rejects zero for these, so fill in the model's defaults.
+	if inp.GuidanceScale == 0 {
+		inp.GuidanceScale = 3.5
+	}
+	if inp.MaxSequenceLength == 0 {
+		inp.MaxSequenceLength = 256
+	}
+	if inp.NumInferenceSteps == 0 {
+		inp.NumInferenceSteps = 28
+	}
+	if inp.PromptStrength == 0 {
+		inp.PromptStrength = 0.8
+	}
+	if inp.OutputFormat == "" {
+		inp.OutputFormat = "png"
+	}
+	if inp.OutputQuality == 0 {
+		inp.OutputQuality = 90
+	}
+
+	return inp
+}
+
+// Generate implements Generator.
+func (f Flux) Generate(ctx context.Context, req Request) ([]Image, error) {
+	resp, err := f.Client.PredictContext(ctx, flux.PredictionRequest{Input: f.input(req)})
+	if err != nil {
+		return nil, err
+	}
+
+	return f.images(ctx, resp)
+}
+
+// Start implements Starter by starting a prediction that reports back to
+// WebhookURL.
+func (f Flux) Start(ctx context.Context, id string, attempt int, req Request) error {
+	if f.WebhookURL == "" {
+		return ErrSynchronous
+	}
+	if f.WebhookSecret == "" {
+		return errors.New("imagegen: Flux.WebhookURL needs a WebhookSecret")
+	}
+
+	u, err := url.Parse(f.WebhookURL)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	q.Set("job", id)
+	q.Set("attempt", strconv.Itoa(attempt))
+	q.Set("sig", f.webhookSignature(id, attempt))
+	u.RawQuery = q.Encode()
+
+	_, err = f.Client.PredictAsync(ctx, flux.PredictionRequest{
+		Input:               f.input(req),
+		ID:                  fmt.Sprintf("%s-%d", id, attempt),
+		Webhook:             u.String(),
+		WebhookEventsFilter: []string{flux.EventCompleted},
+	})
+	return err
+}
+
+// Webhook handles the reports Cog sends to WebhookURL and finishes the
+// jobs in q.
+func (f Flux) Webhook(q *Queue) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("job")
+		attempt, err := strconv.Atoi(r.URL.Query().Get("attempt"))
+		if id == "" || err != nil {
+			http.Error(w, "missing job or attempt", http.StatusBadRequest)
+			return
+		}
+
+		sig := r.URL.Query().Get("sig")
+		if f.WebhookSecret == "" || !hmac.Equal([]byte(sig), []byte(f.webhookSignature(id, attempt))) {
+			http.Error(w, "bad signature", http.StatusForbidden)
+			return
+		}
+
+		pr, err := flux.ParseWebhook(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !pr.Done() {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		imgs, genErr := f.images(r.Context(), pr)
+		switch err := q.Complete(r.Context(), id, attempt, imgs, genErr); {
+		case errors.Is(err, ErrUnknownJob), errors.Is(err, ErrStaleAttempt):
+			// Telling Cog to try again won't help.
+			slog.InfoContext(r.Context(), "ignoring webhook for old prediction", "job", id, "attempt", attempt, "err", err)
+		case err != nil:
+			slog.ErrorContext(r.Context(), "can't complete job", "job", id, "attempt", attempt, "err", err)
+			http.Error(w, "can't complete job", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// images gets the images from a finished prediction.
+func (f Flux) images(ctx context.Context, pr *flux.PredictionResponse) ([]Image, error) {
+	if pr.Status != flux.StatusSucceeded {
+		return nil, fmt.Errorf("imagegen: prediction %s %s: %s", pr.ID, pr.Status, pr.Error)
+	}
+
+	result := make([]Image, 0, len(pr.Output))
+	for _, out := range pr.Output {
+		img, err := f.output(ctx, out)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, img)
+	}
+
+	return result, nil
+}
+
+// output decodes an image that Cog put inline as a data URI, or downloads
+// it if Cog uploaded it somewhere.
+func (f Flux) output(ctx context.Context, out string) (Image, error) {
+	if data, ok := strings.CutPrefix(out, "data:"); ok {
+		header, payload, ok := strings.Cut(data, ",")
+		if !ok {
+			return Image{}, errors.New("imagegen: bad data URI from Cog")
+		}
+
+		var body []byte
+		var err error
+		if mediaType, ok := strings.CutSuffix(header, ";base64"); ok {
+			header = mediaType
+			body, err = base64.StdEncoding.DecodeString(payload)
+		} else {
+			var s string
+			s, err = url.PathUnescape(payload)
+			body = []byte(s)
+		}
+		if err != nil {
+			return Image{}, fmt.Errorf("imagegen: bad data URI from Cog: %w", err)
+		}
+
+		img := newImage(body)
+		if header != "" {
+			img.ContentType = header
+		}
+		return img, nil
+	}
+
+	// Outputs come from webhooks too, so only download ones Cog serves
+	// itself rather than whatever a request names.
+	u, err := url.Parse(out)
+	if err != nil {
+		return Image{}, fmt.Errorf("imagegen: bad output URL from Cog: %w", err)
+	}
+	base, err := url.Parse(f.Client.BaseURL)
+	if err != nil {
+		return Image{}, err
+	}
+	if u.Scheme != base.Scheme || u.Host != base.Host {
+		return Image{}, fmt.Errorf("imagegen: output %s isn't on the Cog server", out)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Image{}, err
+	}
+
+	resp, err := f.Client.HTTPClient.Do(req)
+	if err != nil {
+		return Image{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Image{}, web.NewError(http.StatusOK, resp)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOutputSize))
+	if err != nil {
+		return Image{}, err
+	}
+
+	return newImage(body), nil
+}
@@ -0,0 +1,173 @@
+package imagegen
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"within.website/x/web/flux"
+	"within.website/x/web/openai/dalle"
+	"within.website/x/web/stablediffusion"
+)
+
+var (
+	testPNG  = []byte("\x89PNG\r\n\x1a\nnot really a png")
+	testJPEG = []byte("\xff\xd8\xffnot really a jpeg")
+)
+
+func TestStableDiffusion(t *testing.T) {
+	var got stablediffusion.SimpleImageRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sdapi/v1/txt2img" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+
+		// A grid comes first.
+		json.NewEncoder(w).Encode(stablediffusion.ImageResponse{Images: [][]byte{testJPEG, testPNG, testPNG}})
+	}))
+	defer srv.Close()
+
+	sd := StableDiffusion{
+		Client:   &stablediffusion.Client{HTTP: srv.Client(), APIServer: srv.URL},
+		Defaults: stablediffusion.SimpleImageRequest{Prompt: "masterpiece,", Steps: 20, Width: 512, Height: 512},
+	}
+
+	imgs, err := sd.Generate(t.Context(), Request{Prompt: "a pony", NegativePrompt: "blurry", Height: 768, Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(imgs) != 2 || imgs[0].ContentType != "image/png" || imgs[0].extension() != ".png" {
+		t.Errorf("got images %+v", imgs)
+	}
+	if got.Prompt != "masterpiece, a pony" || got.NegativePrompt != "blurry" || got.Seed != -1 || got.Steps != 20 ||
+		got.Width != 512 || got.Height != 768 || got.BatchSize != 2 || got.NIter != 1 {
+		t.Errorf("sent the wrong request: %+v", got)
+	}
+}
+
+func TestFlux(t *testing.T) {
+	var got flux.PredictionRequest
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("POST /predictions", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+
+		json.NewEncoder(w).Encode(flux.PredictionResponse{
+			ID:     "p1",
+			Status: flux.StatusSucceeded,
+			Output: flux.Output{
+				"data:image/webp;base64," + base64.StdEncoding.EncodeToString([]byte("RIFF\x00\x00\x00\x00WEBPVP8 ")),
+				srv.URL + "/outputs/1.png",
+			},
+		})
+	})
+	mux.HandleFunc("GET /outputs/1.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(testPNG)
+	})
+
+	seed := 42
+	f := Flux{Client: flux.NewClient(srv.URL).WithClient(srv.Client())}
+	imgs, err := f.Generate(t.Context(), Request{Prompt: "a pony", Width: 1920, Height: 1080, Count: 2, Seed: &seed})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(imgs) != 2 || imgs[0].ContentType != "image/webp" || imgs[1].ContentType != "image/png" {
+		t.Errorf("got images %+v", imgs)
+	}
+	if in := got.Input; in.Prompt != "a pony" || in.AspectRatio != "16:9" || in.NumOutputs != 2 || in.Seed == nil || *in.Seed != 42 ||
+		in.NumInferenceSteps != 28 || in.OutputFormat != "png" {
+		t.Errorf("sent the wrong input: %+v", in)
+	}
+
+	// Only Cog's own URLs are followed.
+	if _, err := f.output(t.Context(), "http://169.254.169.254/latest/meta-data/"); err == nil {
+		t.Error("downloaded an output from another host")
+	}
+
+	if err := f.Start(t.Context(), "job", 1, Request{Prompt: "a pony"}); err != ErrSynchronous {
+		t.Errorf("Start without a webhook URL: got %v, want ErrSynchronous", err)
+	}
+}
+
+// rewriteTransport sends every request to a test server.
+type rewriteTransport struct {
+	to *url.URL
+	rt http.RoundTripper
+}
+
+func (rt rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme, r.URL.Host = rt.to.Scheme, rt.to.Host
+	return rt.rt.RoundTrip(r)
+}
+
+func TestDALLE(t *testing.T) {
+	var requests atomic.Int32
+	var got dalle.Options
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer hunter2" {
+			t.Errorf("wrong authorization: %q", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+
+		json.NewEncoder(w).Encode(dalle.Response{Data: []dalle.Image{{B64JSON: testPNG}}})
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	cli := &http.Client{Transport: rewriteTransport{to: u, rt: srv.Client().Transport}}
+	d := DALLE{Client: dalle.New("hunter2").WithClient(cli)}
+
+	imgs, err := d.Generate(t.Context(), Request{Prompt: "a pony", Width: 1080, Height: 1920, Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(imgs) != 2 || imgs[1].ContentType != "image/png" {
+		t.Errorf("got images %+v", imgs)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("made %d requests, want one for each image", requests.Load())
+	}
+	if got.Model != dalle.DALLE3 || *got.Size != dalle.SizeHDTall || *got.N != 1 || *got.ResponseFormat != dalle.FormatB64JSON {
+		t.Errorf("sent the wrong options: %+v", got)
+	}
+}
+
+func TestClosest(t *testing.T) {
+	for _, tt := range []struct {
+		sizes []size
+		want  size
+		got   size
+	}{
+		{dalle2Sizes, size{300, 300}, size{256, 256}},
+		{dalle2Sizes, size{800, 600}, size{512, 512}},
+		{dalle2Sizes, size{2048, 2048}, size{1024, 1024}},
+		{dalle3Sizes, size{1920, 1080}, size{1792, 1024}},
+		{dalle3Sizes, size{100, 90}, size{1024, 1024}},
+		{fluxAspectRatios, size{1280, 720}, size{16, 9}},
+		{fluxAspectRatios, size{1000, 1300}, size{3, 4}},
+		{fluxAspectRatios, size{3440, 1440}, size{21, 9}},
+	} {
+		if got := closest(tt.sizes, tt.want); got != tt.got {
+			t.Errorf("closest to %v: got %v, want %v", tt.want, got, tt.got)
+		}
+	}
+}
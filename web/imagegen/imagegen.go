@@ -0,0 +1,119 @@
+// Package imagegen makes images with whatever image generation service is
+// handy behind one interface, and queues up jobs so callers don't have to
+// wait on a GPU.
+//
+// Generators are available for the Automatic1111 Stable Diffusion web UI,
+// Flux models served with Cog and OpenAI's DALL·E. A Queue runs jobs with
+// them in the background, retries ones that fail and puts the images it
+// makes in a store.Interface.
+package imagegen
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+)
+
+// ErrSynchronous is returned by Starter.Start when the generator can only
+// make images with Generate.
+var ErrSynchronous = errors.New("imagegen: generator can't run in the background")
+
+// Request describes the images to make. Fields left zero use the
+// generator's defaults, and generators ignore fields they don't support.
+type Request struct {
+	Prompt string `json:"prompt"`
+
+	// NegativePrompt describes what shouldn't be in the image. Only Stable
+	// Diffusion supports it.
+	NegativePrompt string `json:"negative_prompt,omitempty"`
+
+	// Width and Height are the size of the image in pixels. Generators that
+	// only support some sizes or aspect ratios use the closest one they
+	// have.
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+
+	// Count is how many images to make, one by default.
+	Count int `json:"count,omitempty"`
+
+	// Seed makes the output repeatable for generators that support it.
+	Seed *int `json:"seed,omitempty"`
+}
+
+func (r Request) count() int {
+	return max(r.Count, 1)
+}
+
+// Image is an image that a generator made.
+type Image struct {
+	Data        []byte
+	ContentType string // such as "image/png"
+}
+
+// newImage sniffs the content type of data.
+func newImage(data []byte) Image {
+	return Image{Data: data, ContentType: http.DetectContentType(data)}
+}
+
+// extension returns the file extension for the image's format.
+func (i Image) extension() string {
+	switch i.ContentType {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/webp":
+		return ".webp"
+	case "image/gif":
+		return ".gif"
+	default:
+		return ".bin"
+	}
+}
+
+// Generator makes images.
+type Generator interface {
+	// Generate makes the images described by req and waits for them.
+	Generate(ctx context.Context, req Request) ([]Image, error)
+}
+
+// Starter is implemented by generators that can make images in the
+// background and report back when they are done, such as Cog with webhooks.
+// A Queue uses Start instead of Generate for them, and whatever receives the
+// report calls Queue.Complete with the job's ID and attempt.
+type Starter interface {
+	// Start starts making the images for attempt of job id. It returns
+	// ErrSynchronous if the generator can't do that, and the queue calls
+	// Generate instead.
+	Start(ctx context.Context, id string, attempt int, req Request) error
+}
+
+// size is a width and height, or an aspect ratio.
+type size struct{ w, h int }
+
+// closest returns the size from sizes with the aspect ratio closest to
+// want's, preferring the one closest in area when several have the same
+// ratio.
+func closest(sizes []size, want size) size {
+	ratio := func(s size) float64 {
+		return math.Abs(math.Log(float64(s.w)/float64(s.h)) - math.Log(float64(want.w)/float64(want.h)))
+	}
+	area := func(s size) int { return abs(s.w*s.h - want.w*want.h) }
+
+	result := sizes[0]
+	for _, s := range sizes[1:] {
+		if d, best := ratio(s), ratio(result); d < best-1e-9 || (d < best+1e-9 && area(s) < area(result)) {
+			result = s
+		}
+	}
+
+	return result
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,592 @@
+package imagegen
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"within.website/x/store"
+	"within.website/x/web"
+)
+
+var (
+	jobs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "within_website_x",
+		Subsystem: "imagegen",
+		Name:      "job_attempts_total",
+		Help:      "Attempts at image generation jobs, by generator and result.",
+	}, []string{"generator", "result"})
+
+	jobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "within_website_x",
+		Subsystem: "imagegen",
+		Name:      "job_duration_seconds",
+		Help:      "How long image generation jobs took from being enqueued to finishing.",
+		Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800},
+	}, []string{"generator", "status"})
+)
+
+var (
+	// ErrUnknownGenerator is returned when enqueueing a job for a generator
+	// that wasn't registered.
+	ErrUnknownGenerator = errors.New("imagegen: unknown generator")
+
+	// ErrUnknownJob is returned when the queue has no job with an ID.
+	ErrUnknownJob = errors.New("imagegen: unknown job")
+
+	// ErrStaleAttempt is returned by Queue.Complete when the job isn't
+	// waiting for that attempt any more, such as because it timed out and
+	// was tried again.
+	ErrStaleAttempt = errors.New("imagegen: stale attempt")
+
+	// ErrJobFailed is returned by Queue.Wait when a job ran out of
+	// attempts.
+	ErrJobFailed = errors.New("imagegen: job failed")
+
+	errTimeout  = errors.New("imagegen: attempt timed out")
+	errNoImages = errors.New("imagegen: generator made no images")
+)
+
+// Status is where a job is in the queue.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"    // waiting to run, maybe to be retried
+	StatusRunning   Status = "running"   // a generator is making the images
+	StatusSucceeded Status = "succeeded" // the images are in the store
+	StatusFailed    Status = "failed"    // out of attempts, see Job.Error
+)
+
+// Job is a request to make images with a generator.
+type Job struct {
+	ID        string
+	Generator string
+	Request   Request
+	Status    Status
+	Attempts  int    // attempts so far, including a running one
+	Error     string // why the last attempt failed
+
+	// Images are the store keys of the images once the job succeeded.
+	Images []string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Done reports whether the job has finished, one way or another.
+func (j *Job) Done() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusFailed
+}
+
+// QueueOptions controls how a Queue runs jobs.
+type QueueOptions struct {
+	// Prefix is put in front of the store keys of images, "imagegen/" by
+	// default. Images are stored at Prefix + job ID + "/" + index +
+	// extension, such as "imagegen/<id>/0.png".
+	Prefix string
+
+	// MaxAttempts is how many times a job is tried before it fails, 3 by
+	// default. Errors that won't go away by trying again, such as the
+	// generator rejecting the request, fail the job right away.
+	MaxAttempts int
+
+	// RetryBackoff is how long to wait before trying a job again, 30
+	// seconds by default. It doubles with every attempt.
+	RetryBackoff time.Duration
+
+	// Timeout bounds how long an attempt takes, including waiting for a
+	// webhook from generators that run in the background. It is 10 minutes
+	// by default.
+	Timeout time.Duration
+
+	// PollInterval is how often the queue looks for jobs that are due, 5
+	// seconds by default. Enqueued jobs start right away if there's room.
+	PollInterval time.Duration
+}
+
+// Queue runs image generation jobs in the background. Jobs are kept in a
+// SQLite database, so they survive restarts, and each generator only runs
+// so many at once.
+type Queue struct {
+	db    *sql.DB
+	store store.Interface
+	opts  QueueOptions
+
+	lock       sync.Mutex
+	generators map[string]registeredGenerator
+
+	wake chan struct{}
+
+	// now is time.Now, replaced in tests.
+	now func() time.Time
+}
+
+type registeredGenerator struct {
+	gen         Generator
+	concurrency int
+}
+
+// NewQueue creates the queue's tables in db if they don't exist and makes a
+// queue that puts images in st. The database can be opened with any SQLite
+// driver, but set a busy timeout as jobs finish at the same time, such as
+// with "?_pragma=busy_timeout(5000)" for modernc.org/sqlite.
+func NewQueue(ctx context.Context, db *sql.DB, st store.Interface, opts QueueOptions) (*Queue, error) {
+	for _, stmt := range []string{
+		`CREATE TABLE IF NOT EXISTS imagegen_jobs
+			( id         TEXT PRIMARY KEY
+			, generator  TEXT NOT NULL
+			, request    TEXT NOT NULL
+			, status     TEXT NOT NULL
+			, attempts   INTEGER NOT NULL DEFAULT 0
+			, last_error TEXT NOT NULL DEFAULT ''
+			, images     TEXT NOT NULL DEFAULT '[]'
+			, created_at INTEGER NOT NULL
+			, updated_at INTEGER NOT NULL
+			, run_at     INTEGER NOT NULL -- when to start a queued job, or give up on a running one
+			)`,
+		`CREATE INDEX IF NOT EXISTS imagegen_jobs_status_run_at ON imagegen_jobs (status, run_at)`,
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Prefix == "" {
+		opts.Prefix = "imagegen/"
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.RetryBackoff == 0 {
+		opts.RetryBackoff = 30 * time.Second
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Minute
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+
+	return &Queue{
+		db:         db,
+		store:      st,
+		opts:       opts,
+		generators: map[string]registeredGenerator{},
+		wake:       make(chan struct{}, 1),
+		now:        time.Now,
+	}, nil
+}
+
+// Register makes gen available to jobs as name. At most concurrency jobs
+// run with it at once, one if concurrency isn't positive.
+func (q *Queue) Register(name string, gen Generator, concurrency int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.generators[name] = registeredGenerator{gen: gen, concurrency: max(concurrency, 1)}
+	q.poke()
+}
+
+// poke tells Run to look for jobs.
+func (q *Queue) poke() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Enqueue adds a job that makes images with the generator registered as
+// generator and returns its ID.
+func (q *Queue) Enqueue(ctx context.Context, generator string, req Request) (string, error) {
+	q.lock.Lock()
+	_, ok := q.generators[generator]
+	q.lock.Unlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownGenerator, generator)
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	id := uuid.NewString()
+	now := q.now().Unix()
+	if _, err := q.db.ExecContext(ctx, `INSERT INTO imagegen_jobs (id, generator, request, status, created_at, updated_at, run_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`, id, generator, string(data), StatusQueued, now, now, now); err != nil {
+		return "", err
+	}
+
+	q.poke()
+	return id, nil
+}
+
+// Get returns a job.
+func (q *Queue) Get(ctx context.Context, id string) (*Job, error) {
+	var (
+		result                         Job
+		request, images                string
+		createdAt, updatedAt, attempts int64
+	)
+
+	err := q.db.QueryRowContext(ctx, `SELECT id, generator, request, status, attempts, last_error, images, created_at, updated_at
+		FROM imagegen_jobs WHERE id = ?`, id).Scan(
+		&result.ID, &result.Generator, &request, &result.Status, &attempts, &result.Error, &images, &createdAt, &updatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJob, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(request), &result.Request); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(images), &result.Images); err != nil {
+		return nil, err
+	}
+
+	result.Attempts = int(attempts)
+	result.CreatedAt = time.Unix(createdAt, 0)
+	result.UpdatedAt = time.Unix(updatedAt, 0)
+
+	return &result, nil
+}
+
+// Wait waits for a job to finish. If it failed, the job is returned along
+// with an error wrapping ErrJobFailed.
+func (q *Queue) Wait(ctx context.Context, id string) (*Job, error) {
+	t := time.NewTicker(q.opts.PollInterval)
+	defer t.Stop()
+
+	for {
+		job, err := q.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch job.Status {
+		case StatusSucceeded:
+			return job, nil
+		case StatusFailed:
+			return job, fmt.Errorf("%w: %s", ErrJobFailed, job.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Run runs jobs until ctx is cancelled. Only run one at a time for each
+// database. Jobs that were running when Run stopped are tried again when it
+// starts.
+func (q *Queue) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	t := time.NewTicker(q.opts.PollInterval)
+	defer t.Stop()
+
+	for {
+		if err := q.expire(ctx); err != nil {
+			return err
+		}
+		if err := q.dispatch(ctx, &wg); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		case <-q.wake:
+		}
+	}
+}
+
+// expire fails the attempts that took longer than Timeout, such as ones
+// whose webhook never came or that were running when the program stopped.
+func (q *Queue) expire(ctx context.Context) error {
+	rows, err := q.db.QueryContext(ctx, `SELECT id, attempts FROM imagegen_jobs WHERE status = ? AND run_at <= ?`, StatusRunning, q.now().Unix())
+	if err != nil {
+		return err
+	}
+
+	type attempt struct {
+		id string
+		n  int
+	}
+	var expired []attempt
+	for rows.Next() {
+		var a attempt
+		if err := rows.Scan(&a.id, &a.n); err != nil {
+			rows.Close()
+			return err
+		}
+		expired = append(expired, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, a := range expired {
+		if err := q.Complete(ctx, a.id, a.n, nil, errTimeout); err != nil && !errors.Is(err, ErrStaleAttempt) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// claimed is an attempt at a job that Run started.
+type claimed struct {
+	id      string
+	attempt int
+	req     Request
+}
+
+// dispatch starts as many due jobs as each generator has room for.
+func (q *Queue) dispatch(ctx context.Context, wg *sync.WaitGroup) error {
+	q.lock.Lock()
+	generators := make(map[string]registeredGenerator, len(q.generators))
+	for name, rg := range q.generators {
+		generators[name] = rg
+	}
+	q.lock.Unlock()
+
+	for name, rg := range generators {
+		var running int
+		if err := q.db.QueryRowContext(ctx, `SELECT count(*) FROM imagegen_jobs WHERE generator = ? AND status = ?`, name, StatusRunning).Scan(&running); err != nil {
+			return err
+		}
+		if running >= rg.concurrency {
+			continue
+		}
+
+		started, err := q.claim(ctx, name, rg.concurrency-running)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range started {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				q.run(ctx, name, rg.gen, c)
+			}()
+		}
+	}
+
+	return nil
+}
+
+// claim marks up to limit due jobs for generator as running.
+func (q *Queue) claim(ctx context.Context, generator string, limit int) ([]claimed, error) {
+	now := q.now()
+
+	rows, err := q.db.QueryContext(ctx, `SELECT id, attempts, request FROM imagegen_jobs
+		WHERE generator = ? AND status = ? AND run_at <= ? ORDER BY run_at, created_at LIMIT ?`,
+		generator, StatusQueued, now.Unix(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var due []claimed
+	for rows.Next() {
+		var c claimed
+		var request string
+		if err := rows.Scan(&c.id, &c.attempt, &request); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(request), &c.req); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		c.attempt++
+		due = append(due, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var result []claimed
+	for _, c := range due {
+		res, err := q.db.ExecContext(ctx, `UPDATE imagegen_jobs SET status = ?, attempts = ?, updated_at = ?, run_at = ?
+			WHERE id = ? AND status = ?`,
+			StatusRunning, c.attempt, now.Unix(), now.Add(q.opts.Timeout).Unix(), c.id, StatusQueued)
+		if err != nil {
+			return nil, err
+		}
+
+		// Something else got to it first.
+		if n, err := res.RowsAffected(); err != nil {
+			return nil, err
+		} else if n == 0 {
+			continue
+		}
+
+		result = append(result, c)
+	}
+
+	return result, nil
+}
+
+// run makes an attempt at a job.
+func (q *Queue) run(ctx context.Context, generator string, gen Generator, c claimed) {
+	genCtx, cancel := context.WithTimeout(ctx, q.opts.Timeout)
+	defer cancel()
+
+	var (
+		imgs []Image
+		err  = ErrSynchronous
+	)
+	if s, ok := gen.(Starter); ok {
+		err = s.Start(genCtx, c.id, c.attempt, c.req)
+		if err == nil {
+			// The webhook takes it from here.
+			return
+		}
+	}
+	if errors.Is(err, ErrSynchronous) {
+		imgs, err = gen.Generate(genCtx, c.req)
+	}
+
+	if ctx.Err() != nil {
+		// Shutting down isn't the job's fault, so give the attempt back.
+		q.release(context.WithoutCancel(ctx), c)
+		return
+	}
+
+	if err := q.Complete(ctx, c.id, c.attempt, imgs, err); err != nil {
+		slog.ErrorContext(ctx, "can't complete job", "job", c.id, "generator", generator, "attempt", c.attempt, "err", err)
+	}
+}
+
+// release puts a job back in the queue without counting the attempt.
+func (q *Queue) release(ctx context.Context, c claimed) {
+	now := q.now().Unix()
+	if _, err := q.db.ExecContext(ctx, `UPDATE imagegen_jobs SET status = ?, attempts = attempts - 1, updated_at = ?, run_at = ?
+		WHERE id = ? AND status = ? AND attempts = ?`,
+		StatusQueued, now, now, c.id, StatusRunning, c.attempt); err != nil {
+		slog.ErrorContext(ctx, "can't put job back in the queue", "job", c.id, "err", err)
+	}
+}
+
+// Complete finishes an attempt at a job. If genErr is nil, imgs are put in
+// the store and the job succeeds. Otherwise it is tried again later, unless
+// it's out of attempts or genErr won't go away by trying again.
+//
+// Run calls this itself. Things that report back for a Starter, such as
+// Flux.Webhook, call it with the attempt passed to Start. It returns
+// ErrStaleAttempt if the job isn't waiting for that attempt.
+func (q *Queue) Complete(ctx context.Context, id string, attempt int, imgs []Image, genErr error) error {
+	job, err := q.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if job.Status != StatusRunning || job.Attempts != attempt {
+		return fmt.Errorf("%w: attempt %d of job %s", ErrStaleAttempt, attempt, id)
+	}
+
+	if genErr == nil && len(imgs) == 0 {
+		genErr = errNoImages
+	}
+
+	var keys []string
+	if genErr == nil {
+		for i, img := range imgs {
+			key := fmt.Sprintf("%s%s/%d%s", q.opts.Prefix, id, i, img.extension())
+			if err := q.store.Set(ctx, key, img.Data); err != nil {
+				genErr = fmt.Errorf("imagegen: can't store image: %w", err)
+				break
+			}
+			keys = append(keys, key)
+		}
+	}
+
+	now := q.now()
+	var (
+		status Status
+		runAt  = now
+		errMsg string
+	)
+	switch {
+	case genErr == nil:
+		status = StatusSucceeded
+	case attempt < q.opts.MaxAttempts && retryable(genErr):
+		status = StatusQueued
+		runAt = now.Add(q.opts.RetryBackoff << (attempt - 1))
+		errMsg = genErr.Error()
+	default:
+		status = StatusFailed
+		errMsg = genErr.Error()
+	}
+
+	if keys == nil {
+		keys = []string{}
+	}
+	images, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+
+	res, err := q.db.ExecContext(ctx, `UPDATE imagegen_jobs SET status = ?, last_error = ?, images = ?, updated_at = ?, run_at = ?
+		WHERE id = ? AND status = ? AND attempts = ?`,
+		status, errMsg, string(images), now.Unix(), runAt.Unix(), id, StatusRunning, attempt)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("%w: attempt %d of job %s", ErrStaleAttempt, attempt, id)
+	}
+
+	result := "ok"
+	if genErr != nil {
+		result = "error"
+		slog.InfoContext(ctx, "image generation failed", "job", id, "generator", job.Generator, "attempt", attempt, "status", status, "err", genErr)
+	}
+	jobs.WithLabelValues(job.Generator, result).Inc()
+	if status != StatusQueued {
+		jobDuration.WithLabelValues(job.Generator, string(status)).Observe(now.Sub(job.CreatedAt).Seconds())
+	}
+
+	q.poke()
+	return nil
+}
+
+// retryable reports whether an attempt that failed with err might work if
+// it's tried again. Generators rejecting the request won't change their
+// minds.
+func retryable(err error) bool {
+	var we *web.Error
+	if !errors.As(err, &we) {
+		return true
+	}
+
+	switch {
+	case we.GotStatus == http.StatusRequestTimeout, we.GotStatus == http.StatusTooManyRequests:
+		return true
+	case we.GotStatus >= 400 && we.GotStatus < 500:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,369 @@
+package imagegen
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+	"within.website/x/store"
+	"within.website/x/web"
+	"within.website/x/web/flux"
+)
+
+type generatorFunc func(ctx context.Context, req Request) ([]Image, error)
+
+func (f generatorFunc) Generate(ctx context.Context, req Request) ([]Image, error) {
+	return f(ctx, req)
+}
+
+func newTestQueue(t *testing.T, opts QueueOptions) (*Queue, store.Interface) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "imagegen.db")+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	st, err := store.NewDirectFile(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if opts.PollInterval == 0 {
+		opts.PollInterval = 10 * time.Millisecond
+	}
+	if opts.RetryBackoff == 0 {
+		opts.RetryBackoff = time.Nanosecond
+	}
+
+	q, err := NewQueue(t.Context(), db, st, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return q, st
+}
+
+// runQueue runs q until the test is over.
+func runQueue(t *testing.T, q *Queue) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- q.Run(ctx) }()
+
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("Run: %v", err)
+		}
+	})
+}
+
+func waitFor(t *testing.T, q *Queue, id string) (*Job, error) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	return q.Wait(ctx, id)
+}
+
+func TestQueue(t *testing.T) {
+	q, st := newTestQueue(t, QueueOptions{})
+
+	var calls atomic.Int32
+	q.Register("flaky", generatorFunc(func(ctx context.Context, req Request) ([]Image, error) {
+		if calls.Add(1) == 1 {
+			return nil, errors.New("the GPU caught fire")
+		}
+
+		result := make([]Image, req.count())
+		for i := range result {
+			result[i] = newImage(testPNG)
+		}
+		return result, nil
+	}), 1)
+	runQueue(t, q)
+
+	if _, err := q.Enqueue(t.Context(), "missing", Request{}); !errors.Is(err, ErrUnknownGenerator) {
+		t.Errorf("enqueueing for a missing generator: %v", err)
+	}
+
+	id, err := q.Enqueue(t.Context(), "flaky", Request{Prompt: "a pony", Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := waitFor(t, q, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if job.Attempts != 2 || job.Error != "" || job.Request.Prompt != "a pony" {
+		t.Errorf("got job %+v", job)
+	}
+
+	want := []string{"imagegen/" + id + "/0.png", "imagegen/" + id + "/1.png"}
+	if len(job.Images) != len(want) || job.Images[0] != want[0] || job.Images[1] != want[1] {
+		t.Fatalf("got images %v, want %v", job.Images, want)
+	}
+
+	data, err := st.Get(t.Context(), job.Images[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(testPNG) {
+		t.Errorf("stored %q", data)
+	}
+
+	if _, err := q.Get(t.Context(), "nope"); !errors.Is(err, ErrUnknownJob) {
+		t.Errorf("getting a missing job: %v", err)
+	}
+}
+
+func TestQueueFailures(t *testing.T) {
+	q, _ := newTestQueue(t, QueueOptions{MaxAttempts: 2})
+
+	var calls atomic.Int32
+	q.Register("broken", generatorFunc(func(ctx context.Context, req Request) ([]Image, error) {
+		calls.Add(1)
+		if req.Prompt == "rejected" {
+			return nil, &web.Error{WantStatus: http.StatusOK, GotStatus: http.StatusUnprocessableEntity, URL: &url.URL{}}
+		}
+		return nil, errors.New("the GPU caught fire")
+	}), 1)
+	runQueue(t, q)
+
+	for _, tt := range []struct {
+		prompt   string
+		attempts int
+	}{
+		{"rejected", 1},
+		{"on fire", 2},
+	} {
+		calls.Store(0)
+
+		id, err := q.Enqueue(t.Context(), "broken", Request{Prompt: tt.prompt})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		job, err := waitFor(t, q, id)
+		if !errors.Is(err, ErrJobFailed) {
+			t.Fatalf("%s: got error %v, want ErrJobFailed", tt.prompt, err)
+		}
+		if job.Status != StatusFailed || job.Attempts != tt.attempts || int(calls.Load()) != tt.attempts || job.Error == "" {
+			t.Errorf("%s: got job %+v after %d calls, want %d attempts", tt.prompt, job, calls.Load(), tt.attempts)
+		}
+	}
+}
+
+func TestQueueConcurrency(t *testing.T) {
+	q, _ := newTestQueue(t, QueueOptions{})
+
+	var running, most atomic.Int32
+	q.Register("gpu", generatorFunc(func(ctx context.Context, req Request) ([]Image, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := most.Load()
+			if n <= m || most.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		return []Image{newImage(testPNG)}, nil
+	}), 2)
+	runQueue(t, q)
+
+	var ids []string
+	for range 6 {
+		id, err := q.Enqueue(t.Context(), "gpu", Request{Prompt: "a pony"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
+		if _, err := waitFor(t, q, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if most.Load() != 2 {
+		t.Errorf("ran %d jobs at once, want 2", most.Load())
+	}
+}
+
+func TestQueueTimeout(t *testing.T) {
+	q, _ := newTestQueue(t, QueueOptions{MaxAttempts: 2})
+
+	now := time.Unix(1700000000, 0)
+	var lock sync.Mutex
+	q.now = func() time.Time {
+		lock.Lock()
+		defer lock.Unlock()
+		return now
+	}
+
+	var calls atomic.Int32
+	q.Register("gpu", generatorFunc(func(ctx context.Context, req Request) ([]Image, error) {
+		calls.Add(1)
+		return []Image{newImage(testPNG)}, nil
+	}), 1)
+
+	id, err := q.Enqueue(t.Context(), "gpu", Request{Prompt: "a pony"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Pretend a previous run crashed in the middle of the job.
+	claimed, err := q.claim(t.Context(), "gpu", 1)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("claiming the job: %v, %v", claimed, err)
+	}
+
+	runQueue(t, q)
+	time.Sleep(50 * time.Millisecond)
+	if calls.Load() != 0 {
+		t.Fatal("the job ran before its attempt timed out")
+	}
+
+	lock.Lock()
+	now = now.Add(time.Hour)
+	lock.Unlock()
+
+	job, err := waitFor(t, q, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Attempts != 2 || calls.Load() != 1 {
+		t.Errorf("got job %+v after %d calls", job, calls.Load())
+	}
+
+	if err := q.Complete(t.Context(), id, 1, []Image{newImage(testPNG)}, nil); !errors.Is(err, ErrStaleAttempt) {
+		t.Errorf("completing the timed out attempt: %v", err)
+	}
+}
+
+func TestQueueFluxWebhook(t *testing.T) {
+	q, st := newTestQueue(t, QueueOptions{})
+
+	hooks := http.NewServeMux()
+	hookSrv := httptest.NewServer(hooks)
+	defer hookSrv.Close()
+
+	var started sync.WaitGroup
+	cog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pr flux.PredictionRequest
+		if err := json.NewDecoder(r.Body).Decode(&pr); err != nil {
+			t.Error(err)
+		}
+		if r.Header.Get("Prefer") != "respond-async" {
+			t.Errorf("prediction wasn't asynchronous")
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(flux.PredictionResponse{ID: pr.ID, Status: flux.StatusStarting})
+
+		// Report back like Cog does once the prediction is done.
+		started.Add(1)
+		go func() {
+			defer started.Done()
+
+			for _, status := range []string{flux.StatusProcessing, flux.StatusSucceeded} {
+				body := flux.PredictionResponse{ID: pr.ID, Status: status}
+				if status == flux.StatusSucceeded {
+					body.Output = flux.Output{"data:image/png;base64,iVBORw0KGgo="}
+				}
+
+				data, _ := json.Marshal(body)
+				resp, err := http.Post(pr.Webhook, "application/json", bytes.NewReader(data))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusNoContent {
+					t.Errorf("webhook returned status %d", resp.StatusCode)
+				}
+			}
+		}()
+	}))
+	defer cog.Close()
+	defer started.Wait()
+
+	f := Flux{
+		Client:        flux.NewClient(cog.URL),
+		WebhookURL:    hookSrv.URL + "/cog?route=flux",
+		WebhookSecret: "hunter2",
+	}
+	hooks.Handle("/cog", f.Webhook(q))
+	q.Register("flux", f, 1)
+	runQueue(t, q)
+
+	id, err := q.Enqueue(t.Context(), "flux", Request{Prompt: "a pony"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := waitFor(t, q, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := st.Get(t.Context(), job.Images[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "\x89PNG\r\n\x1a\n" {
+		t.Errorf("stored %q", data)
+	}
+}
+
+func TestFluxWebhookSignature(t *testing.T) {
+	q, _ := newTestQueue(t, QueueOptions{})
+	f := Flux{Client: flux.NewClient("http://cog.invalid"), WebhookURL: "http://hooks.invalid/cog", WebhookSecret: "hunter2"}
+	h := f.Webhook(q)
+
+	body := `{"id":"p1","status":"succeeded","output":["data:image/png;base64,iVBORw0KGgo="]}`
+	for _, tt := range []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{"no signature", "job=j&attempt=1", http.StatusForbidden},
+		{"wrong signature", "job=j&attempt=1&sig=" + f.webhookSignature("j", 2), http.StatusForbidden},
+		{"other job's signature", "job=j&attempt=1&sig=" + f.webhookSignature("k", 1), http.StatusForbidden},
+		// Signed, but the queue has no such job.
+		{"signed", "job=j&attempt=1&sig=" + f.webhookSignature("j", 1), http.StatusNoContent},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/cog?"+tt.query, strings.NewReader(body)))
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+
+	if err := (Flux{Client: f.Client, WebhookURL: f.WebhookURL}).Start(t.Context(), "j", 1, Request{}); err == nil {
+		t.Error("Start with a webhook URL but no secret worked")
+	}
+}
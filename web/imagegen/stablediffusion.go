@@ -0,0 +1,72 @@
+package imagegen
+
+import (
+	"context"
+	"strings"
+
+	"within.website/x/web/stablediffusion"
+)
+
+// StableDiffusion makes images with the Automatic1111 Stable Diffusion web
+// UI.
+type StableDiffusion struct {
+	Client *stablediffusion.Client
+
+	// Defaults has the settings Request doesn't, such as the sampler and
+	// step count. Its prompts are put in front of the request's.
+	Defaults stablediffusion.SimpleImageRequest
+}
+
+// Generate implements Generator.
+func (sd StableDiffusion) Generate(ctx context.Context, req Request) ([]Image, error) {
+	inp := sd.Defaults
+	inp.Prompt = joinPrompts(inp.Prompt, req.Prompt)
+	inp.NegativePrompt = joinPrompts(inp.NegativePrompt, req.NegativePrompt)
+	inp.BatchSize = req.count()
+	inp.NIter = 1
+
+	if req.Width != 0 {
+		inp.Width = req.Width
+	}
+	if req.Height != 0 {
+		inp.Height = req.Height
+	}
+
+	switch {
+	case req.Seed != nil:
+		inp.Seed = *req.Seed
+	case inp.Seed == 0:
+		inp.Seed = -1 // random
+	}
+
+	resp, err := sd.Client.Generate(ctx, inp)
+	if err != nil {
+		return nil, err
+	}
+
+	// When it makes more than one image, the web UI can put a grid of all
+	// of them first.
+	imgs := resp.Images
+	if len(imgs) > inp.BatchSize {
+		imgs = imgs[len(imgs)-inp.BatchSize:]
+	}
+
+	result := make([]Image, 0, len(imgs))
+	for _, img := range imgs {
+		result = append(result, newImage(img))
+	}
+
+	return result, nil
+}
+
+// joinPrompts joins prompts with commas, like people write them.
+func joinPrompts(prompts ...string) string {
+	var result []string
+	for _, p := range prompts {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, strings.TrimSuffix(p, ","))
+		}
+	}
+
+	return strings.Join(result, ", ")
+}
@@ -0,0 +1,125 @@
+package iptoasn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"sync/atomic"
+	"time"
+
+	"within.website/x/web"
+	"within.website/x/web/useragent"
+)
+
+// DefaultDumpURL is where the iptoasn project publishes its database.
+const DefaultDumpURL = "https://iptoasn.com/data/ip2asn-combined.tsv.gz"
+
+var ErrNotLoaded = errors.New("iptoasn: database isn't loaded")
+
+// LoadFunc loads a fresh copy of the iptoasn database.
+type LoadFunc func(ctx context.Context) (*Table, error)
+
+// FromFile loads the database from a TSV dump on disk.
+func FromFile(fname string) LoadFunc {
+	return func(context.Context) (*Table, error) {
+		return LoadFile(fname)
+	}
+}
+
+// FromURL downloads the database from a TSV dump on the web, such as
+// DefaultDumpURL. If cli is nil, http.DefaultClient is used.
+func FromURL(cli *http.Client, u string) LoadFunc {
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+	userAgent := useragent.GenUserAgent("within.website/x/web/iptoasn", "https://xeiaso.net/contact")
+
+	return func(ctx context.Context) (*Table, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("can't create request: %w", err)
+		}
+
+		req.Header.Set("User-Agent", userAgent)
+
+		resp, err := cli.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch %s: %w", u, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, web.NewError(http.StatusOK, resp)
+		}
+
+		t, err := ParseTSV(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("iptoasn: can't load %s: %w", u, err)
+		}
+
+		return t, nil
+	}
+}
+
+// Database is a Table that can be refreshed while it is in use. Lookups
+// always see either the old table or the new one, never a mix.
+type Database struct {
+	load  LoadFunc
+	table atomic.Pointer[Table]
+}
+
+// NewDatabase loads the database for the first time.
+func NewDatabase(ctx context.Context, load LoadFunc) (*Database, error) {
+	db := &Database{load: load}
+
+	if err := db.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Refresh loads a new copy of the database and swaps it in. If loading
+// fails, the old copy is kept.
+func (db *Database) Refresh(ctx context.Context) error {
+	t, err := db.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	db.table.Store(t)
+	return nil
+}
+
+// Run refreshes the database every interval until ctx is cancelled.
+// Failures are logged and the old copy is kept until the next try.
+func (db *Database) Run(ctx context.Context, every time.Duration) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := db.Refresh(ctx); err != nil {
+				slog.ErrorContext(ctx, "can't refresh iptoasn database", "err", err)
+				continue
+			}
+			slog.DebugContext(ctx, "refreshed iptoasn database", "ranges", db.table.Load().Len())
+		}
+	}
+}
+
+// Lookup finds addr in the current copy of the database.
+func (db *Database) Lookup(ctx context.Context, addr netip.Addr) (*ASNInfo, error) {
+	t := db.table.Load()
+	if t == nil {
+		return nil, ErrNotLoaded
+	}
+
+	return t.Lookup(ctx, addr)
+}
@@ -109,6 +109,7 @@ func (c Client) Lookup(ctx context.Context, addr netip.Addr) (*ASNInfo, error) {
 	if err != nil {
 		return nil, fmt.Errorf("can't do request to %s: %w", c.baseURL, err)
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, web.NewError(http.StatusOK, resp)
@@ -0,0 +1,189 @@
+package iptoasn
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Lookuper finds out which autonomous system announces an IP address.
+// Client asks a remote iptoasn server and Table uses a local copy of the
+// database.
+type Lookuper interface {
+	Lookup(ctx context.Context, addr netip.Addr) (*ASNInfo, error)
+}
+
+var (
+	_ Lookuper = Client{}
+	_ Lookuper = &Table{}
+	_ Lookuper = &Database{}
+)
+
+// asnRange is a row of the iptoasn database: a range of addresses and who
+// announces it.
+type asnRange struct {
+	first, last netip.Addr
+	asNumber    int
+	country     string
+	description string
+}
+
+// Table is an in-memory copy of the iptoasn database. Lookups are a binary
+// search over its ranges. Tables are immutable once loaded and safe for
+// concurrent use.
+type Table struct {
+	ranges []asnRange
+}
+
+// Len returns the number of ranges in the table.
+func (t *Table) Len() int {
+	return len(t.ranges)
+}
+
+// LoadFile loads a table from an iptoasn TSV dump such as
+// ip2asn-combined.tsv.gz. The file may be gzipped.
+func LoadFile(fname string) (*Table, error) {
+	fin, err := os.Open(fname)
+	if err != nil {
+		return nil, err
+	}
+	defer fin.Close()
+
+	t, err := ParseTSV(fin)
+	if err != nil {
+		return nil, fmt.Errorf("iptoasn: can't load %s: %w", fname, err)
+	}
+
+	return t, nil
+}
+
+// ParseTSV reads an iptoasn TSV dump, gzipped or not. Each line has the
+// first and last address of a range, the AS number, its country code and
+// its description separated by tabs. Ranges with AS number 0 aren't
+// announced by anyone.
+func ParseTSV(r io.Reader) (*Table, error) {
+	br := bufio.NewReader(r)
+
+	if magic, err := br.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		br = bufio.NewReader(gz)
+	}
+
+	// Most descriptions are repeated across many ranges, so they are only
+	// stored once.
+	strs := map[string]string{}
+	intern := func(s string) string {
+		if result, ok := strs[s]; ok {
+			return result
+		}
+		strs[s] = s
+		return s
+	}
+
+	var result Table
+	sc := bufio.NewScanner(br)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		line := sc.Text()
+		if line == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, "\t", 5)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("line %d: want 5 fields, got %d", lineNo, len(fields))
+		}
+
+		first, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w: %w", lineNo, ErrFirstIPNotValid, err)
+		}
+
+		last, err := netip.ParseAddr(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w: %w", lineNo, ErrLastIPNotValid, err)
+		}
+
+		if first.Is4() != last.Is4() || last.Less(first) {
+			return nil, fmt.Errorf("line %d: %s-%s isn't a range", lineNo, first, last)
+		}
+
+		asNumber, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: bad AS number: %w", lineNo, err)
+		}
+
+		result.ranges = append(result.ranges, asnRange{
+			first:       first,
+			last:        last,
+			asNumber:    asNumber,
+			country:     intern(fields[3]),
+			description: intern(fields[4]),
+		})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(result.ranges, func(a, b asnRange) int {
+		return a.first.Compare(b.first)
+	})
+
+	for i := 1; i < len(result.ranges); i++ {
+		if !result.ranges[i-1].last.Less(result.ranges[i].first) {
+			return nil, fmt.Errorf("ranges starting at %s and %s overlap", result.ranges[i-1].first, result.ranges[i].first)
+		}
+	}
+
+	return &result, nil
+}
+
+// Lookup finds the range that addr is in. Addresses that aren't in any
+// announced range are returned with Announced set to false.
+func (t *Table) Lookup(_ context.Context, addr netip.Addr) (*ASNInfo, error) {
+	if !addr.IsValid() {
+		return nil, ErrIPNotValid
+	}
+	addr = addr.Unmap().WithZone("")
+
+	result := &ASNInfo{IP: addr}
+
+	// Find the last range that starts at or before addr.
+	i, found := slices.BinarySearchFunc(t.ranges, addr, func(r asnRange, addr netip.Addr) int {
+		return r.first.Compare(addr)
+	})
+	if !found {
+		i--
+	}
+	if i < 0 {
+		return result, nil
+	}
+
+	r := t.ranges[i]
+	if r.last.Less(addr) || r.asNumber == 0 {
+		return result, nil
+	}
+
+	first, last := r.first, r.last
+	result.Announced = true
+	result.CountryCode = &r.country
+	result.Description = &r.description
+	result.ASNumber = &r.asNumber
+	result.FirstIP = &first
+	result.LastIP = &last
+
+	return result, nil
+}
@@ -0,0 +1,147 @@
+package iptoasn
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTableLookup(t *testing.T) {
+	tbl, err := LoadFile(filepath.Join("testdata", "ip2asn-combined.tsv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		addr      string
+		announced bool
+		asNumber  int
+		desc      string
+	}{
+		{addr: "1.0.0.0", announced: true, asNumber: 13335, desc: "CLOUDFLARENET"},
+		{addr: "1.0.0.1", announced: true, asNumber: 13335, desc: "CLOUDFLARENET"},
+		{addr: "1.0.0.255", announced: true, asNumber: 13335, desc: "CLOUDFLARENET"},
+		{addr: "1.0.2.3", announced: false},
+		{addr: "1.0.7.255", announced: true, asNumber: 38803, desc: "WPL-AS-AP Wirefreebroadband Pty Ltd"},
+		{addr: "0.0.0.1", announced: false},
+		{addr: "4.4.4.4", announced: false},
+		{addr: "::ffff:8.8.8.8", announced: true, asNumber: 15169, desc: "GOOGLE"},
+		{addr: "255.255.255.255", announced: false},
+		{addr: "2001:4860:4860::8888", announced: true, asNumber: 15169, desc: "GOOGLE"},
+		{addr: "2606:4700:4700::1111", announced: true, asNumber: 13335, desc: "CLOUDFLARENET"},
+		{addr: "2a00::1", announced: false},
+	} {
+		t.Run(tt.addr, func(t *testing.T) {
+			ai, err := tbl.Lookup(t.Context(), netip.MustParseAddr(tt.addr))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := ai.Valid(); err != nil {
+				t.Errorf("result isn't valid: %v", err)
+			}
+
+			if ai.Announced != tt.announced {
+				t.Fatalf("announced: got %v, want %v", ai.Announced, tt.announced)
+			}
+
+			if !tt.announced {
+				return
+			}
+
+			if *ai.ASNumber != tt.asNumber || *ai.Description != tt.desc {
+				t.Errorf("got AS%d %q, want AS%d %q", *ai.ASNumber, *ai.Description, tt.asNumber, tt.desc)
+			}
+
+			if addr := ai.IP; addr.Less(*ai.FirstIP) || ai.LastIP.Less(addr) {
+				t.Errorf("%s isn't in %s-%s", addr, ai.FirstIP, ai.LastIP)
+			}
+		})
+	}
+}
+
+func TestParseTSVGzip(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "ip2asn-combined.tsv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	gw.Write(data)
+	gw.Close()
+
+	tbl, err := ParseTSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tbl.Len() != 6 {
+		t.Errorf("got %d ranges, want 6", tbl.Len())
+	}
+}
+
+func TestParseTSVErrors(t *testing.T) {
+	for _, data := range []string{
+		"1.0.0.0\t1.0.0.255\t13335\tUS\n",
+		"1.0.0.0\tnope\t13335\tUS\tCLOUDFLARENET\n",
+		"1.0.0.255\t1.0.0.0\t13335\tUS\tCLOUDFLARENET\n",
+		"1.0.0.0\t1.0.0.255\tAS13335\tUS\tCLOUDFLARENET\n",
+		"1.0.0.0\t::1\t13335\tUS\tCLOUDFLARENET\n",
+		"1.0.0.0\t1.0.0.255\t13335\tUS\tCLOUDFLARENET\n1.0.0.128\t1.0.1.0\t15169\tUS\tGOOGLE\n",
+	} {
+		if _, err := ParseTSV(strings.NewReader(data)); err == nil {
+			t.Errorf("want an error parsing %q", data)
+		}
+	}
+}
+
+func TestDatabaseRefresh(t *testing.T) {
+	dumps := []string{
+		"8.8.8.0\t8.8.8.255\t15169\tUS\tGOOGLE\n",
+		"8.8.8.0\t8.8.8.255\t0\tNone\tNot routed\n",
+	}
+
+	var calls atomic.Int32
+	fail := false
+	db, err := NewDatabase(t.Context(), func(context.Context) (*Table, error) {
+		if fail {
+			return nil, errors.New("no network")
+		}
+		return ParseTSV(strings.NewReader(dumps[calls.Add(1)-1]))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var l Lookuper = db
+	addr := netip.MustParseAddr("8.8.8.8")
+
+	if ai, _ := l.Lookup(t.Context(), addr); !ai.Announced {
+		t.Error("want 8.8.8.8 to be announced before refreshing")
+	}
+
+	if err := db.Refresh(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	if ai, _ := l.Lookup(t.Context(), addr); ai.Announced {
+		t.Error("want 8.8.8.8 to not be announced after refreshing")
+	}
+
+	fail = true
+	if err := db.Refresh(t.Context()); err == nil {
+		t.Error("want refreshing to fail")
+	}
+
+	if _, err := l.Lookup(t.Context(), addr); err != nil {
+		t.Errorf("want the old table to be kept, got: %v", err)
+	}
+}
@@ -55,6 +55,13 @@ func New(apiKey string, httpCli *http.Client) *Client {
 	}
 }
 
+// WithClient makes the client send requests through cli, such as one made
+// by useragent.NewClient.
+func (c *Client) WithClient(cli *http.Client) *Client {
+	c.httpCli = cli
+	return c
+}
+
 func (c *Client) Search(ctx context.Context, req *Request) (*Response, error) {
 	u, err := url.Parse("https://api.marginalia.nu/")
 	if err != nil {
@@ -0,0 +1,81 @@
+package mastodon
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Account fetches an account by ID.
+func (c *Client) Account(ctx context.Context, id string) (*Account, error) {
+	var result Account
+	if _, err := c.getJSON(ctx, "/api/v1/accounts/"+url.PathEscape(id), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// LookupAccount finds an account by its webfinger address, such as
+// user@example.com or just user for local accounts.
+func (c *Client) LookupAccount(ctx context.Context, acct string) (*Account, error) {
+	var result Account
+	if _, err := c.getJSON(ctx, "/api/v1/accounts/lookup", url.Values{"acct": {acct}}, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// FollowParams changes how a followed account shows up.
+type FollowParams struct {
+	HideReblogs bool     // don't show the account's boosts in the home timeline
+	Notify      bool     // get a notification when the account posts
+	Languages   []string // only show statuses in these languages
+}
+
+func (fp FollowParams) Values() url.Values {
+	result := url.Values{}
+
+	if fp.HideReblogs {
+		result.Set("reblogs", "false")
+	}
+	if fp.Notify {
+		result.Set("notify", "true")
+	}
+	for _, lang := range fp.Languages {
+		result.Add("languages[]", lang)
+	}
+
+	return result
+}
+
+// Follow follows an account, or sends a follow request if the account is
+// locked.
+func (c *Client) Follow(ctx context.Context, accountID string, fp FollowParams) (*Relationship, error) {
+	return c.relationship(ctx, accountID, "follow", fp.Values())
+}
+
+// Unfollow unfollows an account.
+func (c *Client) Unfollow(ctx context.Context, accountID string) (*Relationship, error) {
+	return c.relationship(ctx, accountID, "unfollow", nil)
+}
+
+func (c *Client) relationship(ctx context.Context, accountID, action string, vals url.Values) (*Relationship, error) {
+	var result Relationship
+	if err := c.doForm(ctx, http.MethodPost, "/api/v1/accounts/"+url.PathEscape(accountID)+"/"+action, vals, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Relationships fetches how the user is related to some accounts.
+func (c *Client) Relationships(ctx context.Context, accountIDs ...string) ([]Relationship, error) {
+	var result []Relationship
+	if _, err := c.getJSON(ctx, "/api/v1/accounts/relationships", url.Values{"id[]": accountIDs}, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
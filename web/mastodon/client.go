@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"within.website/x/web"
 	"within.website/x/web/useragent"
@@ -18,6 +19,7 @@ type Client struct {
 	cli    *http.Client
 	server *url.URL
 	token  string
+	limits *rateLimiter
 }
 
 // Unauthenticated makes a new unauthenticated Mastodon client.
@@ -27,9 +29,12 @@ func Unauthenticated(botName, botURL, instanceURL string) (*Client, error) {
 		return nil, err
 	}
 
+	rl := &rateLimiter{next: http.DefaultTransport}
+
 	return &Client{
-		cli:    &http.Client{Transport: useragent.Transport(botName, botURL, http.DefaultTransport)},
+		cli:    &http.Client{Transport: useragent.Transport(botName, botURL, rl)},
 		server: u,
+		limits: rl,
 	}, nil
 }
 
@@ -40,15 +45,41 @@ func Authenticated(botName, botURL, instanceURL, token string) (*Client, error)
 		return nil, err
 	}
 
+	rl := &rateLimiter{next: authTransport{token, http.DefaultTransport}}
+
 	return &Client{
 		cli: &http.Client{
-			Transport: useragent.Transport(botName, botURL, authTransport{token, http.DefaultTransport}),
+			Transport: useragent.Transport(botName, botURL, rl),
 		},
 		server: u,
 		token:  token,
+		limits: rl,
 	}, nil
 }
 
+// WithClient makes the client send requests through cli, such as one made
+// by useragent.NewClient. The client still keeps track of the server's rate
+// limit and sends its token on top of cli's transport, so cli shouldn't
+// turn error responses into errors.
+func (c *Client) WithClient(cli *http.Client) *Client {
+	next := cli.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if c.token != "" {
+		next = authTransport{c.token, next}
+	}
+
+	c.limits = &rateLimiter{next: next}
+
+	result := *cli
+	result.Transport = c.limits
+	c.cli = &result
+
+	return c
+}
+
 type authTransport struct {
 	bearerToken string
 	next        http.RoundTripper
@@ -74,7 +105,51 @@ func (c *Client) doJSONPost(ctx context.Context, path string, wantCode int, data
 		return nil, err
 	}
 
-	return c.doRequest(ctx, http.MethodPost, "/api/v1/apps", h, http.StatusOK, &buf)
+	return c.doRequest(ctx, http.MethodPost, path, h, wantCode, &buf)
+}
+
+// doForm posts or puts form values and decodes the JSON response into
+// result, if it isn't nil.
+func (c *Client) doForm(ctx context.Context, method, path string, vals url.Values, result any) error {
+	h := http.Header{}
+	h.Set("Content-Type", "application/x-www-form-urlencoded")
+	h.Set("Accept", "application/json")
+
+	resp, err := c.doRequest(ctx, method, path, h, http.StatusOK, strings.NewReader(vals.Encode()))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// getJSON fetches path with the query q and decodes the JSON response into
+// result. The response is returned with its body closed so that callers can
+// look at its headers.
+func (c *Client) getJSON(ctx context.Context, path string, q url.Values, result any) (*http.Response, error) {
+	if len(q) != 0 {
+		path += "?" + q.Encode()
+	}
+
+	h := http.Header{}
+	h.Set("Accept", "application/json")
+
+	resp, err := c.doRequest(ctx, http.MethodGet, path, h, http.StatusOK, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("mastodon: can't decode response from %s: %w", path, err)
+	}
+
+	return resp, nil
 }
 
 func (c *Client) doRequest(ctx context.Context, method, path string, headers http.Header, wantCode int, body io.Reader) (*http.Response, error) {
@@ -83,7 +158,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, headers htt
 		return nil, err
 	}
 
-	req, err := http.NewRequest(method, u.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, fmt.Errorf("mastodon: can't make request: %w", err)
 	}
@@ -0,0 +1,366 @@
+package mastodon
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fixture is a recorded response from a Mastodon server.
+type fixture struct {
+	file string            // in testdata/fixtures
+	link map[string]string // Link header relations to paths on the test server
+	code int
+}
+
+// fixtureServer serves recorded responses keyed by method, path and query.
+// It records the requests it gets.
+type fixtureServer struct {
+	*httptest.Server
+
+	lock     sync.Mutex
+	requests []*http.Request
+	forms    []string
+}
+
+func newFixtureServer(t *testing.T, routes map[string]fixture) *fixtureServer {
+	t.Helper()
+
+	fs := &fixtureServer{}
+	fs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		fs.lock.Lock()
+		fs.requests = append(fs.requests, r)
+		fs.forms = append(fs.forms, r.PostForm.Encode())
+		fs.lock.Unlock()
+
+		key := r.Method + " " + r.URL.Path
+		if r.URL.RawQuery != "" {
+			key += "?" + r.URL.RawQuery
+		}
+
+		f, ok := routes[key]
+		if !ok {
+			t.Errorf("no fixture for %s", key)
+			http.NotFound(w, r)
+			return
+		}
+
+		var links []string
+		for rel, path := range f.link {
+			links = append(links, `<`+fs.URL+path+`>; rel="`+rel+`"`)
+		}
+		slices.Sort(links)
+		if len(links) != 0 {
+			w.Header().Set("Link", strings.Join(links, ", "))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if f.code != 0 {
+			w.WriteHeader(f.code)
+		}
+
+		if f.file == "" {
+			w.Write([]byte("{}"))
+			return
+		}
+
+		data, err := os.ReadFile(filepath.Join("testdata", "fixtures", f.file))
+		if err != nil {
+			t.Error(err)
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(fs.Close)
+
+	return fs
+}
+
+func newTestClient(t *testing.T, fs *fixtureServer) *Client {
+	t.Helper()
+
+	cli, err := Authenticated("Xe/x test", "https://within.website/.x.botinfo", fs.URL, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cli
+}
+
+func TestHomeTimeline(t *testing.T) {
+	fs := newFixtureServer(t, map[string]fixture{
+		"GET /api/v1/timelines/home?limit=2": {
+			file: "home_1.json",
+			link: map[string]string{"next": "/api/v1/timelines/home?limit=2&max_id=102", "prev": "/api/v1/timelines/home?limit=2&min_id=103"},
+		},
+		"GET /api/v1/timelines/home?limit=2&max_id=102": {
+			file: "home_2.json",
+			link: map[string]string{"next": "/api/v1/timelines/home?limit=2&max_id=101", "prev": "/api/v1/timelines/home?limit=2&min_id=101"},
+		},
+		"GET /api/v1/timelines/home?limit=2&max_id=101": {file: "empty.json"},
+	})
+	cli := newTestClient(t, fs)
+
+	var ids []string
+	for st, err := range cli.HomeTimeline(t.Context(), PageParams{Limit: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, st.ID)
+	}
+
+	if want := []string{"103", "102", "101"}; !slices.Equal(ids, want) {
+		t.Errorf("got statuses %v, want %v", ids, want)
+	}
+
+	if auth := fs.requests[0].Header.Get("Authorization"); auth != "Bearer hunter2" {
+		t.Errorf("got Authorization %q", auth)
+	}
+}
+
+func TestTimelineForward(t *testing.T) {
+	fs := newFixtureServer(t, map[string]fixture{
+		"GET /api/v1/timelines/public?local=true&min_id=100": {
+			file: "home_2.json",
+			link: map[string]string{"next": "/api/v1/timelines/public?local=true&max_id=101", "prev": "/api/v1/timelines/public?local=true&min_id=101"},
+		},
+		"GET /api/v1/timelines/public?local=true&min_id=101": {
+			file: "home_1.json",
+			link: map[string]string{"prev": "/api/v1/timelines/public?local=true&min_id=103"},
+		},
+		"GET /api/v1/timelines/public?local=true&min_id=103": {file: "empty.json"},
+	})
+	cli := newTestClient(t, fs)
+
+	var ids []string
+	for st, err := range cli.PublicTimeline(t.Context(), true, PageParams{MinID: "100"}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, st.ID)
+	}
+
+	if want := []string{"101", "103", "102"}; !slices.Equal(ids, want) {
+		t.Errorf("got statuses %v, want %v", ids, want)
+	}
+}
+
+func TestIteratorStopsEarly(t *testing.T) {
+	fs := newFixtureServer(t, map[string]fixture{
+		"GET /api/v1/timelines/tag/h": {
+			file: "home_1.json",
+			link: map[string]string{"next": "/api/v1/timelines/tag/h?max_id=102"},
+		},
+	})
+	cli := newTestClient(t, fs)
+
+	for range cli.HashtagTimeline(t.Context(), "h", PageParams{}) {
+		break
+	}
+
+	if len(fs.requests) != 1 {
+		t.Errorf("want only the first page fetched, got %d requests", len(fs.requests))
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	fs := newFixtureServer(t, map[string]fixture{
+		"GET /api/v1/favourites": {code: http.StatusUnauthorized},
+	})
+	cli := newTestClient(t, fs)
+
+	n := 0
+	for _, err := range cli.Favourites(t.Context(), PageParams{}) {
+		n++
+		if err == nil {
+			t.Error("want an error")
+		}
+	}
+
+	if n != 1 {
+		t.Errorf("want the error yielded once, got %d items", n)
+	}
+}
+
+func TestIteratorForeignLink(t *testing.T) {
+	var stolen []string
+	evil := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stolen = append(stolen, r.Header.Get("Authorization"))
+		w.Write([]byte("[]"))
+	}))
+	defer evil.Close()
+
+	fs := newFixtureServer(t, map[string]fixture{
+		"GET /api/v1/timelines/home": {file: "home_1.json"},
+	})
+	// The fixture server only links to itself, so add a link elsewhere.
+	inner := fs.Config.Handler
+	fs.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<`+evil.URL+`/api/v1/timelines/home?max_id=102>; rel="next"`)
+		inner.ServeHTTP(w, r)
+	})
+	cli := newTestClient(t, fs)
+
+	var ids []string
+	var gotErr error
+	for st, err := range cli.HomeTimeline(t.Context(), PageParams{}) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		ids = append(ids, st.ID)
+	}
+
+	if len(ids) != 2 {
+		t.Errorf("got statuses %v, want the first page", ids)
+	}
+	if !errors.Is(gotErr, ErrForeignLink) {
+		t.Errorf("got error %v, want %v", gotErr, ErrForeignLink)
+	}
+	if len(stolen) != 0 {
+		t.Errorf("other server got requests with Authorization %q", stolen)
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	fs := newFixtureServer(t, map[string]fixture{
+		"GET /api/v1/notifications?exclude_types%5B%5D=favourite&exclude_types%5B%5D=reblog": {file: "notifications.json"},
+		"POST /api/v1/notifications/34975861/dismiss":                                        {},
+	})
+	cli := newTestClient(t, fs)
+
+	var notes []Notification
+	for n, err := range cli.Notifications(t.Context(), NotificationParams{ExcludeTypes: []string{NotificationFavourite, NotificationReblog}}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		notes = append(notes, n)
+	}
+
+	if len(notes) != 2 || notes[0].Type != NotificationMention || notes[0].Status.ID != "104" || notes[1].Type != NotificationFollow {
+		t.Fatalf("got bad notifications: %+v", notes)
+	}
+
+	if err := cli.DismissNotification(t.Context(), notes[0].ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestActions(t *testing.T) {
+	fs := newFixtureServer(t, map[string]fixture{
+		"POST /api/v1/accounts/109/follow":      {file: "relationship.json"},
+		"POST /api/v1/statuses/101/favourite":   {file: "favourite.json"},
+		"POST /api/v1/statuses/101/reblog":      {file: "favourite.json"},
+		"GET /api/v2/search?q=h&resolve=true":   {file: "search.json"},
+		"GET /api/v1/accounts/lookup?acct=xe":   {code: http.StatusNotFound},
+		"POST /api/v1/notifications/clear":      {},
+		"POST /api/v1/statuses/101/unfavourite": {file: "favourite.json"},
+	})
+	cli := newTestClient(t, fs)
+	ctx := t.Context()
+
+	rel, err := cli.Follow(ctx, "109", FollowParams{HideReblogs: true, Languages: []string{"en"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rel.Following || rel.ShowingReblogs {
+		t.Errorf("got bad relationship: %+v", rel)
+	}
+	if got, want := fs.forms[0], "languages%5B%5D=en&reblogs=false"; got != want {
+		t.Errorf("follow sent %q, want %q", got, want)
+	}
+
+	st, err := cli.Favourite(ctx, "101")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.Favourited || st.FavouritesCount != 1 {
+		t.Errorf("got bad status: %+v", st)
+	}
+
+	if _, err := cli.Reblog(ctx, "101", "unlisted"); err != nil {
+		t.Fatal(err)
+	}
+	if got := fs.forms[2]; got != "visibility=unlisted" {
+		t.Errorf("reblog sent %q", got)
+	}
+
+	res, err := cli.Search(ctx, SearchParams{Query: "h", Resolve: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Accounts) != 1 || len(res.Hashtags) != 1 || res.Hashtags[0].History[0].Uses != 3 {
+		t.Errorf("got bad search results: %+v", res)
+	}
+
+	if _, err := cli.LookupAccount(ctx, "xe"); err == nil {
+		t.Error("want looking up a missing account to fail")
+	}
+}
+
+func TestParseLinks(t *testing.T) {
+	h := http.Header{}
+	h.Add("Link", `<https://example.com/api/v1/timelines/home?max_id=1>; rel="next", <https://example.com/api/v1/timelines/home?min_id=2>; rel="prev"`)
+
+	links := parseLinks(h)
+	if links["next"] != "https://example.com/api/v1/timelines/home?max_id=1" || links["prev"] != "https://example.com/api/v1/timelines/home?min_id=2" {
+		t.Errorf("got links %v", links)
+	}
+
+	if links := parseLinks(http.Header{"Link": {"garbage"}}); len(links) != 0 {
+		t.Errorf("got links from garbage: %v", links)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	reset := now.Add(50 * time.Millisecond)
+
+	var lock sync.Mutex
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		calls++
+
+		w.Header().Set("X-RateLimit-Limit", "300")
+		w.Header().Set("X-RateLimit-Reset", reset.Format(time.RFC3339Nano))
+
+		if calls == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"Too many requests"}`))
+			return
+		}
+
+		w.Header().Set("X-RateLimit-Remaining", "299")
+		w.Write([]byte(`{"uri":"example.com"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := Unauthenticated("Xe/x test", "https://within.website/.x.botinfo", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli.limits.now = func() time.Time { return now }
+
+	inst, err := cli.Instance(t.Context())
+	if err != nil {
+		t.Fatalf("want the request to be retried after the reset: %v", err)
+	}
+	if inst.URI != "example.com" || calls != 2 {
+		t.Errorf("got %+v after %d calls", inst, calls)
+	}
+
+	if rl := cli.RateLimit(); rl.Limit != 300 || rl.Remaining != 299 || !rl.Reset.Equal(reset) {
+		t.Errorf("got rate limit %+v", rl)
+	}
+}
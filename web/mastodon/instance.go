@@ -0,0 +1,52 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// Instance fetches information about the server the client talks to, such as
+// how long statuses can be.
+func (c *Client) Instance(ctx context.Context) (*Instance, error) {
+	h := http.Header{}
+	h.Set("Accept", "application/json")
+
+	resp, err := c.doRequest(ctx, http.MethodGet, "/api/v1/instance", h, http.StatusOK, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result Instance
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// maxPeersSize bounds how much of a peer list is read. Big servers know
+// about hundreds of thousands of others.
+const maxPeersSize = 32 << 20
+
+// InstancePeers lists the domains of the servers the server knows about.
+// Servers can turn this off, in which case it fails with a *web.Error.
+func (c *Client) InstancePeers(ctx context.Context) ([]InstancePeer, error) {
+	h := http.Header{}
+	h.Set("Accept", "application/json")
+
+	resp, err := c.doRequest(ctx, http.MethodGet, "/api/v1/instance/peers", h, http.StatusOK, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result []InstancePeer
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxPeersSize)).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package mastodon
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// Notification types.
+const (
+	NotificationMention       = "mention"
+	NotificationStatus        = "status"
+	NotificationReblog        = "reblog"
+	NotificationFollow        = "follow"
+	NotificationFollowRequest = "follow_request"
+	NotificationFavourite     = "favourite"
+	NotificationPoll          = "poll"
+	NotificationUpdate        = "update"
+)
+
+// NotificationParams filters the notifications listed by Notifications.
+type NotificationParams struct {
+	PageParams
+
+	Types        []string // only include these types
+	ExcludeTypes []string // leave out these types
+	AccountID    string   // only include notifications from this account
+}
+
+func (np NotificationParams) values() url.Values {
+	result := np.PageParams.values()
+
+	for _, t := range np.Types {
+		result.Add("types[]", t)
+	}
+	for _, t := range np.ExcludeTypes {
+		result.Add("exclude_types[]", t)
+	}
+	if np.AccountID != "" {
+		result.Set("account_id", np.AccountID)
+	}
+
+	return result
+}
+
+// Notifications iterates over the user's notifications.
+func (c *Client) Notifications(ctx context.Context, np NotificationParams) iter.Seq2[Notification, error] {
+	return paginate[Notification](ctx, c, "/api/v1/notifications", np.values(), np.forward())
+}
+
+// Notification fetches a single notification.
+func (c *Client) Notification(ctx context.Context, id string) (*Notification, error) {
+	var result Notification
+	if _, err := c.getJSON(ctx, "/api/v1/notifications/"+url.PathEscape(id), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DismissNotification removes a single notification.
+func (c *Client) DismissNotification(ctx context.Context, id string) error {
+	return c.doForm(ctx, http.MethodPost, "/api/v1/notifications/"+url.PathEscape(id)+"/dismiss", nil, nil)
+}
+
+// ClearNotifications removes all of the user's notifications.
+func (c *Client) ClearNotifications(ctx context.Context) error {
+	return c.doForm(ctx, http.MethodPost, "/api/v1/notifications/clear", nil, nil)
+}
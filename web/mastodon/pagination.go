@@ -0,0 +1,132 @@
+package mastodon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrForeignLink is returned by iterators when the server links to a next
+// page on another server.
+var ErrForeignLink = errors.New("mastodon: page link points to another server")
+
+// PageParams picks which page of a list to start at. Lists are newest
+// first. By default iterators start at the newest item and walk back in
+// time. If MinID or SinceID is set, they walk forward in time from there
+// instead, a page at a time. Items within a page are always newest first.
+type PageParams struct {
+	MaxID   string // only return items older than this ID
+	MinID   string // return the items just newer than this ID
+	SinceID string // return the newest items newer than this ID
+	Limit   int    // how many items to fetch per page, 0 means the server's default
+}
+
+func (pp PageParams) values() url.Values {
+	result := url.Values{}
+
+	if pp.MaxID != "" {
+		result.Set("max_id", pp.MaxID)
+	}
+	if pp.MinID != "" {
+		result.Set("min_id", pp.MinID)
+	}
+	if pp.SinceID != "" {
+		result.Set("since_id", pp.SinceID)
+	}
+	if pp.Limit != 0 {
+		result.Set("limit", strconv.Itoa(pp.Limit))
+	}
+
+	return result
+}
+
+// forward reports whether pages should be walked forward in time.
+func (pp PageParams) forward() bool {
+	return pp.MinID != "" || pp.SinceID != ""
+}
+
+// parseLinks parses an RFC 8288 Link header into a map of relation types to
+// URLs.
+func parseLinks(h http.Header) map[string]string {
+	result := map[string]string{}
+
+	for _, val := range h.Values("Link") {
+		for link := range strings.SplitSeq(val, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			target = target[1 : len(target)-1]
+
+			for _, param := range parts[1:] {
+				k, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(k, "rel") {
+					continue
+				}
+				for rel := range strings.FieldsSeq(strings.Trim(v, `"`)) {
+					result[strings.ToLower(rel)] = target
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// paginate fetches pages of a list, following the Link header from each
+// page to the next, and yields every item. It stops when a page is empty,
+// there is no next page or the caller stops iterating. Errors are yielded
+// once and end the iteration.
+func paginate[T any](ctx context.Context, c *Client, path string, q url.Values, forward bool) iter.Seq2[T, error] {
+	rel := "next"
+	if forward {
+		rel = "prev"
+	}
+
+	return func(yield func(T, error) bool) {
+		next := path
+		if len(q) != 0 {
+			next += "?" + q.Encode()
+		}
+
+		for next != "" {
+			var page []T
+			resp, err := c.getJSON(ctx, next, nil, &page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			if len(page) == 0 {
+				return
+			}
+
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			next = parseLinks(resp.Header)[rel]
+			if next == "" {
+				return
+			}
+
+			// The token goes along with the next request, so it can only go
+			// back to the same server.
+			u, err := c.server.Parse(next)
+			if err != nil || u.Scheme != c.server.Scheme || u.Host != c.server.Host {
+				var zero T
+				yield(zero, fmt.Errorf("%w: %q", ErrForeignLink, next))
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package mastodon
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit is what the server said about the client's rate limit in the
+// X-RateLimit headers of its last response.
+type RateLimit struct {
+	Limit     int       // requests allowed per period
+	Remaining int       // requests left in this period
+	Reset     time.Time // when the period ends
+}
+
+// RateLimit returns the rate limit the server last reported. It is the zero
+// value until the server reports one.
+func (c *Client) RateLimit() RateLimit {
+	if c.limits == nil {
+		return RateLimit{}
+	}
+
+	return c.limits.get()
+}
+
+// rateLimiter keeps track of the server's rate limit headers. When the
+// server says there are no requests left, requests wait for the limit to
+// reset instead of being refused. A request that is refused anyway with
+// 429 Too Many Requests is tried once more after the reset.
+type rateLimiter struct {
+	next http.RoundTripper
+
+	lock sync.Mutex
+	last RateLimit
+
+	// now is time.Now, replaced in tests.
+	now func() time.Time
+}
+
+var (
+	_ http.RoundTripper = &rateLimiter{}
+)
+
+func (rl *rateLimiter) get() RateLimit {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+	return rl.last
+}
+
+func (rl *rateLimiter) timeNow() time.Time {
+	if rl.now != nil {
+		return rl.now()
+	}
+	return time.Now()
+}
+
+// wait blocks until the rate limit resets if there are no requests left.
+func (rl *rateLimiter) wait(r *http.Request) error {
+	rl.lock.Lock()
+	last := rl.last
+	rl.lock.Unlock()
+
+	if last.Limit == 0 || last.Remaining > 0 {
+		return nil
+	}
+
+	d := last.Reset.Sub(rl.timeNow())
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-r.Context().Done():
+		return r.Context().Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func (rl *rateLimiter) observe(resp *http.Response) {
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	reset, err := time.Parse(time.RFC3339Nano, resp.Header.Get("X-RateLimit-Reset"))
+	if err != nil {
+		return
+	}
+
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+	rl.last = RateLimit{Limit: limit, Remaining: remaining, Reset: reset}
+}
+
+func (rl *rateLimiter) RoundTrip(r *http.Request) (*http.Response, error) {
+	if err := rl.wait(r); err != nil {
+		return nil, err
+	}
+
+	resp, err := rl.next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	rl.observe(resp)
+
+	if resp.StatusCode != http.StatusTooManyRequests || (r.Body != nil && r.GetBody == nil) {
+		return resp, nil
+	}
+
+	// The request can be sent again, so try once more after the reset.
+	rl.lock.Lock()
+	rl.last.Remaining = 0
+	rl.lock.Unlock()
+
+	if r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		r = r.Clone(r.Context())
+		r.Body = body
+	}
+	resp.Body.Close()
+
+	if err := rl.wait(r); err != nil {
+		return nil, err
+	}
+
+	resp, err = rl.next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	rl.observe(resp)
+
+	return resp, nil
+}
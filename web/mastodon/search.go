@@ -0,0 +1,70 @@
+package mastodon
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Search result types.
+const (
+	SearchAccounts = "accounts"
+	SearchHashtags = "hashtags"
+	SearchStatuses = "statuses"
+)
+
+// SearchParams is a search query.
+type SearchParams struct {
+	Query string
+
+	// Type limits the results to accounts, hashtags or statuses.
+	Type string
+
+	// Resolve looks up remote accounts and statuses by URL or address if
+	// the server doesn't know about them yet.
+	Resolve bool
+
+	// Following only includes accounts the user follows.
+	Following bool
+
+	AccountID string // only include statuses from this account
+	Limit     int
+	Offset    int
+}
+
+func (sp SearchParams) Values() url.Values {
+	result := url.Values{}
+
+	result.Set("q", sp.Query)
+
+	if sp.Type != "" {
+		result.Set("type", sp.Type)
+	}
+	if sp.Resolve {
+		result.Set("resolve", "true")
+	}
+	if sp.Following {
+		result.Set("following", "true")
+	}
+	if sp.AccountID != "" {
+		result.Set("account_id", sp.AccountID)
+	}
+	if sp.Limit != 0 {
+		result.Set("limit", strconv.Itoa(sp.Limit))
+	}
+	if sp.Offset != 0 {
+		result.Set("offset", strconv.Itoa(sp.Offset))
+	}
+
+	return result
+}
+
+// Search searches for accounts, hashtags and statuses.
+func (c *Client) Search(ctx context.Context, sp SearchParams) (*Results, error) {
+	var result Results
+	if _, err := c.getJSON(ctx, "/api/v2/search", sp.Values(), &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
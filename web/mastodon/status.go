@@ -6,7 +6,10 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
+
+	"within.website/x/web"
 )
 
 type CreateStatusParams struct {
@@ -50,13 +53,23 @@ func (c *Client) CreateStatus(ctx context.Context, csp CreateStatusParams) (*Sta
 		return nil, err
 	}
 
-	resp, err := c.cli.PostForm(u.String(), vals)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(vals.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.cli.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, web.NewError(http.StatusOK, resp)
+	}
+
 	var result Status
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
@@ -66,6 +79,59 @@ func (c *Client) CreateStatus(ctx context.Context, csp CreateStatusParams) (*Sta
 	return &result, nil
 }
 
+// EditStatusParams are the new contents of an edited status.
+type EditStatusParams struct {
+	Status      string   `json:"status"`
+	MediaIDs    []string `json:"media_ids"`
+	SpoilerText string   `json:"spoiler_text"`
+}
+
+func (esp EditStatusParams) Values() url.Values {
+	result := url.Values{}
+
+	result.Set("status", esp.Status)
+
+	if esp.SpoilerText != "" {
+		result.Set("spoiler_text", esp.SpoilerText)
+	}
+
+	for _, id := range esp.MediaIDs {
+		result.Add("media_ids[]", id)
+	}
+
+	return result
+}
+
+// EditStatus replaces the contents of one of the account's statuses.
+func (c *Client) EditStatus(ctx context.Context, id string, esp EditStatusParams) (*Status, error) {
+	h := http.Header{}
+	h.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.doRequest(ctx, http.MethodPut, "/api/v1/statuses/"+url.PathEscape(id), h, http.StatusOK, strings.NewReader(esp.Values().Encode()))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result Status
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteStatus deletes one of the account's statuses.
+func (c *Client) DeleteStatus(ctx context.Context, id string) error {
+	resp, err := c.doRequest(ctx, http.MethodDelete, "/api/v1/statuses/"+url.PathEscape(id), nil, http.StatusOK, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
 // FetchStatus fetches a Mastodon status over the internet using the federation protocol.
 //
 // This will not work if the target server has "secure" mode enabled.
@@ -90,3 +156,48 @@ func FetchStatus(ctx context.Context, statusURL string) (*Status, error) {
 
 	return &result, nil
 }
+
+// Status fetches a status by ID from the server the client talks to.
+func (c *Client) Status(ctx context.Context, id string) (*Status, error) {
+	var result Status
+	if _, err := c.getJSON(ctx, "/api/v1/statuses/"+url.PathEscape(id), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Favourite favourites a status.
+func (c *Client) Favourite(ctx context.Context, id string) (*Status, error) {
+	return c.statusAction(ctx, id, "favourite", nil)
+}
+
+// Unfavourite removes a favourite from a status.
+func (c *Client) Unfavourite(ctx context.Context, id string) (*Status, error) {
+	return c.statusAction(ctx, id, "unfavourite", nil)
+}
+
+// Reblog boosts a status with the given visibility. If visibility is
+// empty, the boost is public.
+func (c *Client) Reblog(ctx context.Context, id, visibility string) (*Status, error) {
+	vals := url.Values{}
+	if visibility != "" {
+		vals.Set("visibility", visibility)
+	}
+
+	return c.statusAction(ctx, id, "reblog", vals)
+}
+
+// Unreblog undoes a boost.
+func (c *Client) Unreblog(ctx context.Context, id string) (*Status, error) {
+	return c.statusAction(ctx, id, "unreblog", nil)
+}
+
+func (c *Client) statusAction(ctx context.Context, id, action string, vals url.Values) (*Status, error) {
+	var result Status
+	if err := c.doForm(ctx, http.MethodPost, "/api/v1/statuses/"+url.PathEscape(id)+"/"+action, vals, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,251 @@
+package mastodon
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// Streaming event types.
+const (
+	EventUpdate       = "update"
+	EventNotification = "notification"
+	EventDelete       = "delete"
+	EventStatusUpdate = "status.update"
+	EventConversation = "conversation"
+)
+
+// maxBackfill is the most statuses or notifications fetched over REST after
+// a reconnect.
+const maxBackfill = 200
+
+// Event is a decoded streaming event. Which field is set depends on Type:
+//
+//   - update and status.update set Status
+//   - notification sets Notification
+//   - delete sets DeletedID
+//   - conversation sets Conversation
+//
+// Other event types only have Message.
+type Event struct {
+	Type         string
+	Stream       []string
+	Status       *Status
+	Notification *Notification
+	Conversation *Conversation
+	DeletedID    string
+
+	// Backfill is set for events that were missed while the stream was
+	// disconnected and were fetched over REST instead.
+	Backfill bool
+
+	// Message is the raw message the event came from. It is empty for
+	// backfilled events.
+	Message WSMessage
+}
+
+// DecodeEvent decodes the payload of a streaming message.
+func DecodeEvent(msg WSMessage) (*Event, error) {
+	ev := &Event{
+		Type:    msg.Event,
+		Stream:  msg.Stream,
+		Message: msg,
+	}
+
+	var err error
+	switch msg.Event {
+	case EventUpdate, EventStatusUpdate:
+		ev.Status = &Status{}
+		err = json.Unmarshal([]byte(msg.Payload), ev.Status)
+	case EventNotification:
+		ev.Notification = &Notification{}
+		err = json.Unmarshal([]byte(msg.Payload), ev.Notification)
+	case EventConversation:
+		ev.Conversation = &Conversation{}
+		err = json.Unmarshal([]byte(msg.Payload), ev.Conversation)
+	case EventDelete:
+		// The payload is the bare ID of the deleted status.
+		ev.DeletedID = msg.Payload
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("mastodon: can't decode %s event: %w", msg.Event, err)
+	}
+
+	return ev, nil
+}
+
+// compareIDs orders Mastodon IDs, which are numbers too big for JSON
+// numbers sent as strings.
+func compareIDs(a, b string) int {
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a, b)
+}
+
+// StreamEvents streams decoded events. When the connection drops it
+// reconnects with exponential backoff, then fetches the statuses and
+// notifications it missed from the timelines matching subreq and sends
+// them, oldest first, with Backfill set. Messages that can't be decoded are
+// logged and skipped. The channel is closed when ctx is cancelled.
+func (c *Client) StreamEvents(ctx context.Context, so StreamOptions, subreq ...WSSubscribeRequest) (<-chan Event, error) {
+	u, err := c.streamingURL()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(chan Event, 10)
+	s := &eventStream{c: c, subreq: subreq, out: result}
+
+	go func() {
+		defer close(result)
+
+		c.stream(ctx, u, so, subreq, s.backfill, func(msg WSMessage) bool {
+			ev, err := DecodeEvent(msg)
+			if err != nil {
+				slog.ErrorContext(ctx, "can't decode streaming event", "err", err, "event", msg.Event)
+				return true
+			}
+
+			return s.send(ctx, *ev)
+		})
+	}()
+
+	return result, nil
+}
+
+// eventStream remembers the newest status and notification it has sent so
+// that it knows where to backfill from, and what it backfilled so that it
+// doesn't send it again if it comes in over the stream too.
+type eventStream struct {
+	c      *Client
+	subreq []WSSubscribeRequest
+	out    chan<- Event
+
+	lock             sync.Mutex
+	lastStatusID     string
+	lastNotification string
+	backfilled       map[string]bool
+}
+
+func (s *eventStream) send(ctx context.Context, ev Event) bool {
+	var key string
+	switch {
+	case ev.Type == EventUpdate && ev.Status != nil:
+		key = "status:" + ev.Status.ID
+	case ev.Type == EventNotification && ev.Notification != nil:
+		key = "notification:" + ev.Notification.ID
+	}
+
+	if key != "" {
+		s.lock.Lock()
+		if s.backfilled[key] {
+			s.lock.Unlock()
+			return true
+		}
+
+		if ev.Backfill {
+			s.backfilled[key] = true
+		}
+
+		if ev.Status != nil && compareIDs(ev.Status.ID, s.lastStatusID) > 0 {
+			s.lastStatusID = ev.Status.ID
+		}
+		if ev.Notification != nil && compareIDs(ev.Notification.ID, s.lastNotification) > 0 {
+			s.lastNotification = ev.Notification.ID
+		}
+		s.lock.Unlock()
+	}
+
+	select {
+	case s.out <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// backfill fetches what was missed while the stream was disconnected. Only
+// timelines that have been seen before are backfilled, as there's nothing
+// to resume from otherwise.
+func (s *eventStream) backfill(ctx context.Context) bool {
+	s.lock.Lock()
+	lastStatus, lastNotification := s.lastStatusID, s.lastNotification
+	s.backfilled = map[string]bool{}
+	s.lock.Unlock()
+
+	for _, sub := range s.subreq {
+		var statuses iter.Seq2[Status, error]
+		pp := PageParams{MinID: lastStatus}
+
+		switch sub.Stream {
+		case StreamUser:
+			statuses = s.c.HomeTimeline(ctx, pp)
+		case StreamPublic:
+			statuses = s.c.PublicTimeline(ctx, false, pp)
+		case StreamPublicLocal:
+			statuses = s.c.PublicTimeline(ctx, true, pp)
+		case StreamHashtag:
+			statuses = s.c.HashtagTimeline(ctx, sub.Hashtag, pp)
+		case StreamList:
+			statuses = s.c.ListTimeline(ctx, sub.List, pp)
+		}
+
+		if statuses != nil && lastStatus != "" {
+			missed, err := collect(statuses, func(st Status) string { return st.ID })
+			if err != nil {
+				slog.ErrorContext(ctx, "can't backfill statuses", "err", err, "stream", sub.Stream)
+			}
+
+			for _, st := range missed {
+				if !s.send(ctx, Event{Type: EventUpdate, Stream: []string{sub.Stream}, Status: &st, Backfill: true}) {
+					return false
+				}
+			}
+		}
+
+		if (sub.Stream == StreamUser || sub.Stream == StreamNotifications) && lastNotification != "" {
+			missed, err := collect(s.c.Notifications(ctx, NotificationParams{PageParams: PageParams{MinID: lastNotification}}), func(n Notification) string { return n.ID })
+			if err != nil {
+				slog.ErrorContext(ctx, "can't backfill notifications", "err", err, "stream", sub.Stream)
+			}
+
+			for _, n := range missed {
+				if !s.send(ctx, Event{Type: EventNotification, Stream: []string{sub.Stream}, Notification: &n, Backfill: true}) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// collect reads up to maxBackfill items and sorts them oldest first.
+func collect[T any](seq iter.Seq2[T, error], id func(T) string) ([]T, error) {
+	var result []T
+	var err error
+
+	for item, ierr := range seq {
+		if ierr != nil {
+			err = ierr
+			break
+		}
+		result = append(result, item)
+		if len(result) >= maxBackfill {
+			break
+		}
+	}
+
+	slices.SortFunc(result, func(a, b T) int {
+		return compareIDs(id(a), id(b))
+	})
+
+	return result, err
+}
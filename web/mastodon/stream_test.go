@@ -0,0 +1,176 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+func fixtureJSON(t *testing.T, fname string, index int) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "fixtures", fname))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		t.Fatal(err)
+	}
+
+	return string(items[index])
+}
+
+func TestDecodeEvent(t *testing.T) {
+	ev, err := DecodeEvent(WSMessage{Stream: []string{"user"}, Event: EventUpdate, Payload: fixtureJSON(t, "home_1.json", 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Status == nil || ev.Status.ID != "103" {
+		t.Errorf("got bad update: %+v", ev)
+	}
+
+	ev, err = DecodeEvent(WSMessage{Event: EventDelete, Payload: "103"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.DeletedID != "103" {
+		t.Errorf("got bad delete: %+v", ev)
+	}
+
+	if _, err := DecodeEvent(WSMessage{Event: EventNotification, Payload: "{"}); err == nil {
+		t.Error("want a bad payload to fail")
+	}
+
+	ev, err = DecodeEvent(WSMessage{Event: "filters_changed"})
+	if err != nil || ev.Type != "filters_changed" {
+		t.Errorf("want unknown events passed through, got %+v, %v", ev, err)
+	}
+}
+
+func TestCompareIDs(t *testing.T) {
+	if compareIDs("99", "100") >= 0 || compareIDs("110", "109") <= 0 || compareIDs("5", "5") != 0 || compareIDs("1", "") <= 0 {
+		t.Error("IDs compare wrong")
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	fs := newFixtureServer(t, map[string]fixture{
+		"GET /api/v1/timelines/home?min_id=101":     {file: "home_1.json"},
+		"GET /api/v1/notifications?min_id=34975861": {file: "empty.json"},
+	})
+
+	var lock sync.Mutex
+	conns := 0
+	stream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") != "hunter2" {
+			http.Error(w, "no", http.StatusUnauthorized)
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.CloseNow()
+
+		lock.Lock()
+		conns++
+		n := conns
+		lock.Unlock()
+
+		_, data, err := conn.Read(r.Context())
+		if err != nil {
+			return
+		}
+		var sub WSSubscribeRequest
+		if err := json.Unmarshal(data, &sub); err != nil || sub.Stream != StreamUser {
+			t.Errorf("bad subscription %s: %v", data, err)
+		}
+
+		send := func(msg WSMessage) {
+			data, _ := json.Marshal(msg)
+			conn.Write(r.Context(), websocket.MessageText, data)
+		}
+
+		switch n {
+		case 1:
+			send(WSMessage{Stream: []string{"user"}, Event: EventUpdate, Payload: fixtureJSON(t, "home_2.json", 0)})
+			send(WSMessage{Stream: []string{"user"}, Event: EventNotification, Payload: fixtureJSON(t, "notifications.json", 0)})
+			conn.Close(websocket.StatusGoingAway, "restarting")
+		default:
+			// 103 was backfilled already.
+			send(WSMessage{Stream: []string{"user"}, Event: EventUpdate, Payload: fixtureJSON(t, "home_1.json", 0)})
+			send(WSMessage{Stream: []string{"user"}, Event: EventDelete, Payload: "102"})
+			<-r.Context().Done()
+		}
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/v1/streaming", stream)
+	mux.Handle("/", fs.Config.Handler)
+	fs.Config.Handler = mux
+
+	cli := newTestClient(t, fs)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	events, err := cli.StreamEvents(ctx, StreamOptions{InitialBackoff: 10 * time.Millisecond}, WSSubscribeRequest{Type: "subscribe", Stream: StreamUser})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type seen struct {
+		typ, id  string
+		backfill bool
+	}
+	want := []seen{
+		{EventUpdate, "101", false},
+		{EventNotification, "34975861", false},
+		{EventUpdate, "102", true},
+		{EventUpdate, "103", true},
+		{EventDelete, "102", false},
+	}
+
+	for i, w := range want {
+		select {
+		case ev := <-events:
+			got := seen{typ: ev.Type, backfill: ev.Backfill}
+			switch {
+			case ev.Status != nil:
+				got.id = ev.Status.ID
+			case ev.Notification != nil:
+				got.id = ev.Notification.ID
+			default:
+				got.id = ev.DeletedID
+			}
+
+			if got != w {
+				t.Errorf("event %d: got %+v, want %+v", i, got, w)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("got an event after cancelling")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel wasn't closed after cancelling")
+	}
+}
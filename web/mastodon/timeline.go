@@ -0,0 +1,46 @@
+package mastodon
+
+import (
+	"context"
+	"iter"
+	"net/url"
+)
+
+// HomeTimeline iterates over statuses from the accounts the user follows.
+func (c *Client) HomeTimeline(ctx context.Context, pp PageParams) iter.Seq2[Status, error] {
+	return paginate[Status](ctx, c, "/api/v1/timelines/home", pp.values(), pp.forward())
+}
+
+// PublicTimeline iterates over public statuses the server knows about. If
+// local is set, only statuses from accounts on the server are included.
+func (c *Client) PublicTimeline(ctx context.Context, local bool, pp PageParams) iter.Seq2[Status, error] {
+	q := pp.values()
+	if local {
+		q.Set("local", "true")
+	}
+
+	return paginate[Status](ctx, c, "/api/v1/timelines/public", q, pp.forward())
+}
+
+// HashtagTimeline iterates over public statuses with a hashtag. The tag
+// doesn't include the leading #.
+func (c *Client) HashtagTimeline(ctx context.Context, tag string, pp PageParams) iter.Seq2[Status, error] {
+	return paginate[Status](ctx, c, "/api/v1/timelines/tag/"+url.PathEscape(tag), pp.values(), pp.forward())
+}
+
+// ListTimeline iterates over statuses from the accounts in a list.
+func (c *Client) ListTimeline(ctx context.Context, listID string, pp PageParams) iter.Seq2[Status, error] {
+	return paginate[Status](ctx, c, "/api/v1/timelines/list/"+url.PathEscape(listID), pp.values(), pp.forward())
+}
+
+// AccountStatuses iterates over the statuses an account posted.
+func (c *Client) AccountStatuses(ctx context.Context, accountID string, pp PageParams) iter.Seq2[Status, error] {
+	return paginate[Status](ctx, c, "/api/v1/accounts/"+url.PathEscape(accountID)+"/statuses", pp.values(), pp.forward())
+}
+
+// Favourites iterates over the statuses the user favourited. Favourites are
+// paginated by when they were favourited, so only the Link headers can be
+// used to page through them.
+func (c *Client) Favourites(ctx context.Context, pp PageParams) iter.Seq2[Status, error] {
+	return paginate[Status](ctx, c, "/api/v1/favourites", pp.values(), pp.forward())
+}
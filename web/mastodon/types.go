@@ -181,9 +181,19 @@ type Instance struct {
 		StatusCount int64 `json:"status_count"`
 		DomainCount int64 `json:"domain_count"`
 	} `json:"stats"`
-	Thumbnail      *string  `json:"thumbnail"`
-	Languages      []string `json:"languages"`
-	ContactAccount *Account `json:"contact_account"`
+	Thumbnail      *string               `json:"thumbnail"`
+	Languages      []string              `json:"languages"`
+	ContactAccount *Account              `json:"contact_account"`
+	Configuration  InstanceConfiguration `json:"configuration"`
+}
+
+// InstanceConfiguration represents what a Mastodon instance allows
+type InstanceConfiguration struct {
+	Statuses struct {
+		MaxCharacters            int `json:"max_characters"`
+		MaxMediaAttachments      int `json:"max_media_attachments"`
+		CharactersReservedPerURL int `json:"characters_reserved_per_url"`
+	} `json:"statuses"`
 }
 
 // List represents a Mastodon list entity
@@ -3,18 +3,32 @@ package mastodon
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/url"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"nhooyr.io/websocket"
 )
 
+// Streams that can be subscribed to.
+const (
+	StreamUser          = "user"
+	StreamNotifications = "user:notification"
+	StreamPublic        = "public"
+	StreamPublicLocal   = "public:local"
+	StreamHashtag       = "hashtag"
+	StreamList          = "list"
+	StreamDirect        = "direct"
+)
+
 // WSSubscribeRequest is a websocket instruction to subscribe to a streaming feed.
 type WSSubscribeRequest struct {
 	Type    string `json:"type"` // should be "subscribe" or "unsubscribe"
 	Stream  string `json:"stream"`
 	Hashtag string `json:"hashtag,omitempty"`
+	List    string `json:"list,omitempty"`
 }
 
 // WSMessage is a websocket message. Whenever you get something from the streaming service, it will fit into this box.
@@ -24,10 +38,59 @@ type WSMessage struct {
 	Payload string   `json:"payload"` // json string
 }
 
-// StreamMessages is a low-level message streaming facility.
+// StreamOptions changes how streams reconnect.
+type StreamOptions struct {
+	// InitialBackoff and MaxBackoff bound how long to wait between
+	// reconnects. The wait doubles after each failed connection, with
+	// jitter, and goes back to InitialBackoff once a connection works.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func (so StreamOptions) backOff() backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = time.Second
+	bo.MaxInterval = 5 * time.Minute
+	bo.MaxElapsedTime = 0 // never give up
+
+	if so.InitialBackoff != 0 {
+		bo.InitialInterval = so.InitialBackoff
+	}
+	if so.MaxBackoff != 0 {
+		bo.MaxInterval = so.MaxBackoff
+	}
+
+	bo.Reset()
+	return bo
+}
+
+// StreamMessages is a low-level message streaming facility. It reconnects
+// until ctx is cancelled, then closes the channel.
 func (c *Client) StreamMessages(ctx context.Context, subreq ...WSSubscribeRequest) (chan WSMessage, error) {
+	u, err := c.streamingURL()
+	if err != nil {
+		return nil, err
+	}
+
 	result := make(chan WSMessage, 10)
 
+	go func() {
+		defer close(result)
+
+		c.stream(ctx, u, StreamOptions{}, subreq, nil, func(msg WSMessage) bool {
+			select {
+			case result <- msg:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	return result, nil
+}
+
+func (c *Client) streamingURL() (*url.URL, error) {
 	u, err := c.server.Parse("/api/v1/streaming")
 	if err != nil {
 		return nil, err
@@ -44,25 +107,61 @@ func (c *Client) StreamMessages(ctx context.Context, subreq ...WSSubscribeReques
 	q.Set("access_token", c.token)
 	u.RawQuery = q.Encode()
 
-	go func(ctx context.Context) {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
+	return u, nil
+}
+
+// stream connects to the streaming API and passes messages to emit until ctx
+// is cancelled or emit returns false. onReconnect, if set, is called after
+// every connection but the first one, before any messages are read.
+func (c *Client) stream(ctx context.Context, u *url.URL, so StreamOptions, subreq []WSSubscribeRequest, onReconnect func(context.Context) bool, emit func(WSMessage) bool) {
+	bo := so.backOff()
+	everConnected := false
+
+	for {
+		var connectedAt time.Time
+		gotMessage := false
+
+		err := doWebsocket(ctx, u, subreq, func() bool {
+			first := !everConnected
+			everConnected = true
+			connectedAt = time.Now()
 
-			if err := doWebsocket(ctx, u, result, subreq); err != nil {
-				slog.ErrorContext(ctx, "websocket error, retrying", "err", err)
+			if first || onReconnect == nil {
+				return true
 			}
-			time.Sleep(time.Minute)
+			return onReconnect(ctx)
+		}, func(msg WSMessage) bool {
+			gotMessage = true
+			return emit(msg)
+		})
+
+		if ctx.Err() != nil || errors.Is(err, errStopped) {
+			return
 		}
-	}(ctx)
 
-	return result, nil
+		// Only a connection that worked resets the backoff, so that a server
+		// that hangs up right away isn't hammered.
+		if gotMessage || (!connectedAt.IsZero() && time.Since(connectedAt) > time.Minute) {
+			bo.Reset()
+		}
+
+		wait := bo.NextBackOff()
+		slog.ErrorContext(ctx, "websocket error, retrying", "err", err, "wait", wait)
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
 }
 
-func doWebsocket(ctx context.Context, u *url.URL, result chan WSMessage, subreq []WSSubscribeRequest) error {
+// errStopped means the consumer of a stream went away.
+var errStopped = errors.New("mastodon: stream stopped")
+
+func doWebsocket(ctx context.Context, u *url.URL, subreq []WSSubscribeRequest, onConnect func() bool, emit func(WSMessage) bool) error {
 	conn, _, err := websocket.Dial(ctx, u.String(), &websocket.DialOptions{})
 	if err != nil {
 		return err
@@ -80,14 +179,11 @@ func doWebsocket(ctx context.Context, u *url.URL, result chan WSMessage, subreq
 		}
 	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-
-		default:
-		}
+	if !onConnect() {
+		return errStopped
+	}
 
+	for {
 		msgType, data, err := conn.Read(ctx)
 		if err != nil {
 			return err
@@ -104,6 +200,8 @@ func doWebsocket(ctx context.Context, u *url.URL, result chan WSMessage, subreq
 			return err
 		}
 
-		result <- msg
+		if !emit(msg) {
+			return errStopped
+		}
 	}
 }
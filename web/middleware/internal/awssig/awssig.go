@@ -37,3 +37,13 @@ func HMACSHA256(key, data []byte) []byte {
 	h.Write(data)
 	return h.Sum(nil)
 }
+
+// DeriveSigningKey computes the SigV4 derived signing key for a credential
+// scope: HMAC(HMAC(HMAC(HMAC("AWS4"+secret, date), region), service),
+// "aws4_request").
+func DeriveSigningKey(secret, date, region, service string) []byte {
+	kDate := HMACSHA256([]byte("AWS4"+secret), []byte(date))
+	kRegion := HMACSHA256(kDate, []byte(region))
+	kService := HMACSHA256(kRegion, []byte(service))
+	return HMACSHA256(kService, []byte(Terminator))
+}
@@ -0,0 +1,460 @@
+package awssig
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EmptySHA256 is hex(sha256("")). Every aws-chunked string to sign carries it
+// in the slot the event-stream framing uses for a header-block hash, since
+// aws-chunked chunks have no headers of their own.
+const EmptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// trailerSignatureHeader closes the trailing-header block of a *-TRAILER
+// streaming body and carries the signature over the trailers.
+const trailerSignatureHeader = "x-amz-trailer-signature"
+
+// maxChunkLine bounds a chunk-size line or trailer line. The longest
+// legitimate line is a SigV4A chunk header (16 hex digits of size plus a
+// padded 144-character DER signature), well under this.
+const maxChunkLine = 4096
+
+// maxTrailerLines and maxTrailerBytes bound the trailing headers of a
+// *-TRAILER body, which are buffered until the trailer signature arrives.
+// Clients send a checksum or two, so these are generous.
+const (
+	maxTrailerLines = 64
+	maxTrailerBytes = 16 << 10
+)
+
+// Error sentinels for aws-chunked bodies. They surface from Read on the
+// verified request body, after the seed signature has already been accepted.
+var (
+	ErrMalformedChunk = errors.New("sigv4: malformed aws-chunked body")
+	ErrChunkSignature = errors.New("sigv4: chunk signature mismatch")
+)
+
+// ChunkStringToSign builds the string to sign for one aws-chunked chunk:
+// the payload algorithm, the request timestamp and scope, the previous
+// signature in the chain (the seed signature for the first chunk), the
+// empty-header hash, and the hash of the chunk data.
+func ChunkStringToSign(algorithm, amzDate, scope, prevSignature string, chunk []byte) string {
+	sum := sha256.Sum256(chunk)
+	return strings.Join([]string{
+		algorithm,
+		amzDate,
+		scope,
+		prevSignature,
+		EmptySHA256,
+		hex.EncodeToString(sum[:]),
+	}, "\n")
+}
+
+// TrailerStringToSign builds the string to sign for the trailing headers of
+// a *-TRAILER streaming body. canonicalTrailers is every trailer rendered as
+// "name:value\n" in the order sent.
+func TrailerStringToSign(algorithm, amzDate, scope, prevSignature string, canonicalTrailers []byte) string {
+	sum := sha256.Sum256(canonicalTrailers)
+	return strings.Join([]string{
+		algorithm,
+		amzDate,
+		scope,
+		prevSignature,
+		hex.EncodeToString(sum[:]),
+	}, "\n")
+}
+
+// ChunkConfig describes one streaming body: where its signature chain
+// starts and how each link is checked. The verifiers fill it in after the
+// seed signature has verified.
+type ChunkConfig struct {
+	// PayloadAlgorithm and TrailerAlgorithm head the chunk and trailer
+	// strings to sign, e.g. "AWS4-HMAC-SHA256-PAYLOAD".
+	PayloadAlgorithm string
+	TrailerAlgorithm string
+
+	// AmzDate and Scope are copied verbatim from the seed request.
+	AmzDate string
+	Scope   string
+
+	// Seed is the hex signature from the Authorization header. It is the
+	// previous signature of the first chunk.
+	Seed string
+
+	// Trailer selects the *-TRAILER framing, where signed trailing headers
+	// follow the final chunk.
+	Trailer bool
+
+	// MaxChunkSize caps the bytes of a single chunk held in memory while
+	// its signature is checked. Zero means unlimited.
+	MaxChunkSize int64
+
+	// Verify reports whether signature is valid over stringToSign.
+	Verify func(stringToSign, signature string) bool
+}
+
+// StreamChunked replaces r.Body with a reader that decodes and verifies the
+// aws-chunked framing incrementally. Only chunk data whose signature has
+// verified is ever returned from Read; a bad signature or framing error is
+// returned from Read instead, so handlers must treat a Read error as a
+// rejected request. The decoded length is enforced when the client declared
+// x-amz-decoded-content-length, and trailing headers land in r.Trailer once
+// the body has been read to EOF.
+func StreamChunked(r *http.Request, cfg ChunkConfig) error {
+	decoded := int64(-1)
+	if dl := r.Header.Get("X-Amz-Decoded-Content-Length"); dl != "" {
+		n, err := strconv.ParseInt(dl, 10, 64)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%w: bad x-amz-decoded-content-length", ErrMalformedChunk)
+		}
+		decoded = n
+	}
+
+	var allowed []string
+	if cfg.Trailer {
+		for _, name := range strings.Split(r.Header.Get("X-Amz-Trailer"), ",") {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				allowed = append(allowed, name)
+			}
+		}
+		r.Trailer = make(http.Header, len(allowed))
+	}
+
+	body := r.Body
+	if body == nil {
+		body = http.NoBody
+	}
+	r.Body = &chunkReader{
+		cfg:      cfg,
+		src:      bufio.NewReaderSize(body, maxChunkLine),
+		closer:   body,
+		prev:     cfg.Seed,
+		decoded:  decoded,
+		allowed:  allowed,
+		trailers: r.Trailer,
+	}
+	r.ContentLength = decoded
+	r.Header.Del("Content-Length")
+	if decoded >= 0 {
+		r.Header.Set("Content-Length", strconv.FormatInt(decoded, 10))
+	}
+	stripAWSChunked(r.Header)
+	return nil
+}
+
+// stripAWSChunked drops the aws-chunked token from Content-Encoding now that
+// the framing is decoded, keeping any real encoding (e.g. gzip) the client
+// layered underneath it.
+func stripAWSChunked(h http.Header) {
+	var keep []string
+	for _, v := range h.Values("Content-Encoding") {
+		for _, enc := range strings.Split(v, ",") {
+			if enc = strings.TrimSpace(enc); enc != "" && !strings.EqualFold(enc, "aws-chunked") {
+				keep = append(keep, enc)
+			}
+		}
+	}
+	h.Del("Content-Encoding")
+	if len(keep) > 0 {
+		h.Set("Content-Encoding", strings.Join(keep, ","))
+	}
+}
+
+type chunkReader struct {
+	cfg      ChunkConfig
+	src      *bufio.Reader
+	closer   io.Closer
+	prev     string
+	decoded  int64
+	total    int64
+	allowed  []string
+	trailers http.Header
+
+	chunk []byte
+	buf   []byte
+	err   error
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 {
+		if c.err != nil {
+			return 0, c.err
+		}
+		c.err = c.next()
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+func (c *chunkReader) Close() error {
+	return c.closer.Close()
+}
+
+// next reads, verifies, and stages one chunk. It returns io.EOF after the
+// final chunk (and its trailers) verified.
+func (c *chunkReader) next() error {
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	sizeStr, ext, ok := strings.Cut(line, ";")
+	if !ok {
+		return fmt.Errorf("%w: chunk without signature", ErrMalformedChunk)
+	}
+	size, err := strconv.ParseInt(sizeStr, 16, 64)
+	if err != nil || size < 0 {
+		return fmt.Errorf("%w: bad chunk size", ErrMalformedChunk)
+	}
+	sig, ok := strings.CutPrefix(ext, "chunk-signature=")
+	if !ok || sig == "" {
+		return fmt.Errorf("%w: bad chunk extension", ErrMalformedChunk)
+	}
+	// SigV4A pads its variable-length DER signatures with '*' so chunk
+	// headers have a fixed width; the chain always uses the bare hex.
+	sig = strings.TrimRight(sig, "*")
+	if c.cfg.MaxChunkSize > 0 && size > c.cfg.MaxChunkSize {
+		return ErrBodyTooLarge
+	}
+	if c.decoded >= 0 && c.total+size > c.decoded {
+		return fmt.Errorf("%w: body longer than x-amz-decoded-content-length", ErrMalformedChunk)
+	}
+
+	if int64(cap(c.chunk)) < size {
+		c.chunk = make([]byte, size)
+	}
+	c.chunk = c.chunk[:size]
+	if _, err := io.ReadFull(c.src, c.chunk); err != nil {
+		return fmt.Errorf("%w: truncated chunk: %w", ErrMalformedChunk, err)
+	}
+
+	if !c.cfg.Verify(ChunkStringToSign(c.cfg.PayloadAlgorithm, c.cfg.AmzDate, c.cfg.Scope, c.prev, c.chunk), sig) {
+		return ErrChunkSignature
+	}
+	c.prev = sig
+	c.total += size
+
+	if size > 0 {
+		if err := c.expectCRLF(); err != nil {
+			return err
+		}
+		c.buf = c.chunk
+		return nil
+	}
+
+	if c.cfg.Trailer {
+		if err := c.readTrailers(); err != nil {
+			return err
+		}
+	} else if err := c.expectCRLF(); err != nil {
+		return err
+	}
+	if c.decoded >= 0 && c.total != c.decoded {
+		return fmt.Errorf("%w: body shorter than x-amz-decoded-content-length", ErrMalformedChunk)
+	}
+	return io.EOF
+}
+
+// readTrailers consumes the trailing headers after the final chunk, checks
+// the trailer signature over them, and publishes them into c.trailers.
+func (c *chunkReader) readTrailers() error {
+	var canon bytes.Buffer
+	got := make(http.Header)
+	for lines := 0; ; lines++ {
+		if lines > maxTrailerLines || canon.Len() > maxTrailerBytes {
+			return fmt.Errorf("%w: too many trailers", ErrMalformedChunk)
+		}
+
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return fmt.Errorf("%w: bad trailer line", ErrMalformedChunk)
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+
+		if name == trailerSignatureHeader {
+			if !c.cfg.Verify(TrailerStringToSign(c.cfg.TrailerAlgorithm, c.cfg.AmzDate, c.cfg.Scope, c.prev, canon.Bytes()), strings.TrimRight(value, "*")) {
+				return ErrChunkSignature
+			}
+			if err := c.expectCRLF(); err != nil {
+				return err
+			}
+			for k, vs := range got {
+				c.trailers[k] = vs
+			}
+			return nil
+		}
+
+		if !slices.Contains(c.allowed, name) {
+			return fmt.Errorf("%w: trailer %q not declared in x-amz-trailer", ErrMalformedChunk, name)
+		}
+		canon.WriteString(name)
+		canon.WriteByte(':')
+		canon.WriteString(value)
+		canon.WriteByte('\n')
+		got.Add(name, value)
+	}
+}
+
+func (c *chunkReader) readLine() (string, error) {
+	line, err := c.src.ReadSlice('\n')
+	switch {
+	case errors.Is(err, bufio.ErrBufferFull):
+		return "", fmt.Errorf("%w: line too long", ErrMalformedChunk)
+	case errors.Is(err, io.EOF):
+		return "", fmt.Errorf("%w: unexpected end of body", ErrMalformedChunk)
+	case err != nil:
+		return "", err
+	}
+	s, ok := strings.CutSuffix(string(line), "\r\n")
+	if !ok {
+		return "", fmt.Errorf("%w: line not CRLF-terminated", ErrMalformedChunk)
+	}
+	return s, nil
+}
+
+func (c *chunkReader) expectCRLF() error {
+	var crlf [2]byte
+	if _, err := io.ReadFull(c.src, crlf[:]); err != nil || crlf != [2]byte{'\r', '\n'} {
+		return fmt.Errorf("%w: missing CRLF after chunk", ErrMalformedChunk)
+	}
+	return nil
+}
+
+// ChunkEncoder frames a body as aws-chunked, signing each chunk as it is
+// read. It is the client-side counterpart to StreamChunked.
+type ChunkEncoder struct {
+	cfg       ChunkConfig
+	src       io.Reader
+	closer    io.Closer
+	chunkSize int
+	trailer   http.Header
+	sign      func(stringToSign string) (string, error)
+
+	prev string
+	data []byte
+	out  bytes.Buffer
+	done bool
+}
+
+// NewChunkEncoder returns a reader producing the aws-chunked encoding of
+// body in chunkSize pieces. sign computes the hex signature of a string to
+// sign; cfg supplies the algorithms, timestamp, scope and seed signature
+// (cfg.Verify and cfg.MaxChunkSize are unused). When cfg.Trailer is set,
+// the values of trailer are read after body hits EOF, matching
+// http.Request.Trailer semantics, and sent as signed trailing headers.
+func NewChunkEncoder(body io.ReadCloser, chunkSize int, trailer http.Header, cfg ChunkConfig, sign func(stringToSign string) (string, error)) *ChunkEncoder {
+	return &ChunkEncoder{
+		cfg:       cfg,
+		src:       body,
+		closer:    body,
+		chunkSize: chunkSize,
+		trailer:   trailer,
+		sign:      sign,
+		prev:      cfg.Seed,
+		data:      make([]byte, chunkSize),
+	}
+}
+
+func (e *ChunkEncoder) Read(p []byte) (int, error) {
+	for e.out.Len() == 0 {
+		if e.done {
+			return 0, io.EOF
+		}
+		if err := e.fill(); err != nil {
+			return 0, err
+		}
+	}
+	return e.out.Read(p)
+}
+
+// Close closes the underlying body.
+func (e *ChunkEncoder) Close() error {
+	return e.closer.Close()
+}
+
+func (e *ChunkEncoder) fill() error {
+	n, err := io.ReadFull(e.src, e.data)
+	switch {
+	case err == nil:
+		return e.writeChunk(e.data[:n])
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		if n > 0 {
+			if err := e.writeChunk(e.data[:n]); err != nil {
+				return err
+			}
+		}
+		e.done = true
+		return e.writeFinal()
+	default:
+		return err
+	}
+}
+
+func (e *ChunkEncoder) writeChunk(chunk []byte) error {
+	sig, err := e.sign(ChunkStringToSign(e.cfg.PayloadAlgorithm, e.cfg.AmzDate, e.cfg.Scope, e.prev, chunk))
+	if err != nil {
+		return err
+	}
+	e.prev = sig
+	fmt.Fprintf(&e.out, "%x;chunk-signature=%s\r\n", len(chunk), sig)
+	e.out.Write(chunk)
+	if len(chunk) > 0 || !e.cfg.Trailer {
+		e.out.WriteString("\r\n")
+	}
+	return nil
+}
+
+func (e *ChunkEncoder) writeFinal() error {
+	if err := e.writeChunk(nil); err != nil {
+		return err
+	}
+	if !e.cfg.Trailer {
+		return nil
+	}
+
+	names := make([]string, 0, len(e.trailer))
+	for k := range e.trailer {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canon bytes.Buffer
+	for _, k := range names {
+		name := strings.ToLower(k)
+		for _, v := range e.trailer[k] {
+			fmt.Fprintf(&canon, "%s:%s\n", name, strings.TrimSpace(v))
+			fmt.Fprintf(&e.out, "%s:%s\r\n", name, strings.TrimSpace(v))
+		}
+	}
+	sig, err := e.sign(TrailerStringToSign(e.cfg.TrailerAlgorithm, e.cfg.AmzDate, e.cfg.Scope, e.prev, canon.Bytes()))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(&e.out, "%s:%s\r\n\r\n", trailerSignatureHeader, sig)
+	return nil
+}
+
+// TrailerNames renders the names of trailer as an X-Amz-Trailer value.
+func TrailerNames(trailer http.Header) string {
+	names := make([]string, 0, len(trailer))
+	for k := range trailer {
+		names = append(names, strings.ToLower(k))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
@@ -16,9 +16,10 @@ import (
 // sent a concrete hash in x-amz-content-sha256 the buffered body is re-hashed
 // and confirmed to match, otherwise the signed hash proves nothing about the
 // bytes actually received. Any declared hash with a "STREAMING-" prefix is
-// rejected — chunked-signing integrity lives in per-chunk signatures in the
-// body framing, which neither middleware verifies, and rejecting the whole
-// reserved sentinel family is the safe direction for both algorithms.
+// rejected: the verifiers route the streaming sentinels they understand to
+// StreamChunked before calling this, and every other member of the reserved
+// family (e.g. STREAMING-UNSIGNED-PAYLOAD-TRAILER, whose chunks carry no
+// signatures at all) is refused as the safe direction for both algorithms.
 func ResolvePayloadHash(r *http.Request, maxBodySize int64) (string, error) {
 	declared := r.Header.Get("X-Amz-Content-Sha256")
 
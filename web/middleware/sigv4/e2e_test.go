@@ -101,3 +101,83 @@ func TestEndToEnd_SigV4Client(t *testing.T) {
 		}
 	})
 }
+
+// TestEndToEnd_SigV4ClientStreaming uploads a body larger than the
+// verifier's MaxBodySize through sigv4client's aws-chunked mode, with and
+// without signed trailers. Nothing on either side buffers more than a chunk.
+func TestEndToEnd_SigV4ClientStreaming(t *testing.T) {
+	v := &Verifier{
+		Region:      testRegion,
+		Service:     testSvc,
+		MaxBodySize: 1024,
+		Lookup: LookuperFunc(func(id string) (string, error) {
+			if id == testKey {
+				return testSecret, nil
+			}
+			return "", ErrUnknownKey
+		}),
+	}
+
+	var gotBody, gotChecksum string
+	var readErr error
+	srv := httptest.NewServer(v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var b []byte
+		b, readErr = io.ReadAll(r.Body)
+		gotBody = string(b)
+		gotChecksum = r.Trailer.Get("X-Amz-Checksum-Crc32")
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	defer srv.Close()
+
+	rt, err := sigv4client.NewSigV4RoundTripper(&sigv4client.Config{
+		Region:      testRegion,
+		AccessKey:   testKey,
+		SecretKey:   testSecret,
+		ServiceName: testSvc,
+		ChunkSize:   1000,
+	}, nil)
+	if err != nil {
+		t.Fatalf("new round tripper: %v", err)
+	}
+	client := &http.Client{Transport: rt}
+	body := strings.Repeat("0123456789", 1000)
+
+	t.Run("chunked", func(t *testing.T) {
+		resp, err := client.Post(srv.URL+"/v1/upload", "application/octet-stream", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("status = %d, want 204", resp.StatusCode)
+		}
+		if readErr != nil {
+			t.Fatalf("handler read: %v", readErr)
+		}
+		if gotBody != body {
+			t.Fatalf("body mismatch: got %d bytes, want %d", len(gotBody), len(body))
+		}
+	})
+
+	t.Run("chunked with trailer", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPut, srv.URL+"/v1/upload", strings.NewReader(body))
+		req.Trailer = http.Header{"X-Amz-Checksum-Crc32": {"sOO8/Q=="}}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("PUT: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("status = %d, want 204", resp.StatusCode)
+		}
+		if readErr != nil {
+			t.Fatalf("handler read: %v", readErr)
+		}
+		if gotBody != body {
+			t.Fatalf("body mismatch: got %d bytes, want %d", len(gotBody), len(body))
+		}
+		if gotChecksum != "sOO8/Q==" {
+			t.Fatalf("trailer = %q, want sOO8/Q==", gotChecksum)
+		}
+	})
+}
@@ -36,8 +36,16 @@ const (
 // Payload-hash sentinels defined by AWS SigV4, re-exported from the shared
 // internals so both middlewares agree on one definition.
 const (
-	UnsignedPayload  = awssig.UnsignedPayload
-	StreamingPayload = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	UnsignedPayload         = awssig.UnsignedPayload
+	StreamingPayload        = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	StreamingPayloadTrailer = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
+)
+
+// Algorithms heading the per-chunk and trailer strings to sign of a
+// streaming body.
+const (
+	chunkAlgorithm   = "AWS4-HMAC-SHA256-PAYLOAD"
+	trailerAlgorithm = "AWS4-HMAC-SHA256-TRAILER"
 )
 
 // Errors returned by Verify. Callers typically map ErrUnauthorized and
@@ -54,6 +62,11 @@ var (
 	ErrUnauthorized         = errors.New("sigv4: signature mismatch")
 	ErrBodyTooLarge         = awssig.ErrBodyTooLarge
 	ErrNotConfigured        = errors.New("sigv4: neither Verifier.Lookup nor Verifier.KeyLookup is set")
+
+	// ErrMalformedChunk and ErrChunkSignature are returned from Read on the
+	// body of a verified streaming request, never from Verify itself.
+	ErrMalformedChunk = awssig.ErrMalformedChunk
+	ErrChunkSignature = awssig.ErrChunkSignature
 )
 
 // DefaultMaxBodySize is the byte cap applied to request bodies when
@@ -90,7 +103,8 @@ type Verifier struct {
 
 	// MaxBodySize caps how many bytes of the body will be buffered to verify
 	// the payload hash. Zero means DefaultMaxBodySize (10 MiB). Requests that
-	// exceed it are rejected with ErrBodyTooLarge.
+	// exceed it are rejected with ErrBodyTooLarge. For streaming bodies it
+	// caps each chunk instead, so the total size is unbounded.
 	MaxBodySize int64
 
 	// Now is overridable for tests. Defaults to time.Now.
@@ -126,13 +140,14 @@ func TwirpError(ctx context.Context, err error) error {
 		return twirp.WrapError(twirp.InvalidArgument.Error("malformed authentication header"), err)
 	case errors.Is(err, ErrScopeMismatch),
 		errors.Is(err, ErrClockSkew), errors.Is(err, ErrBodyTooLarge),
-		errors.Is(err, ErrStreamingUnsupported), errors.Is(err, ErrMissingSignedHost):
+		errors.Is(err, ErrStreamingUnsupported), errors.Is(err, ErrMissingSignedHost),
+		errors.Is(err, ErrMalformedChunk):
 		// These sentinels describe the caller's own request and carry no
 		// internal detail, so surface them: a client cannot correct clock
 		// skew it can't distinguish from a scope mismatch.
 		return twirp.WrapError(twirp.InvalidArgument.Error(err.Error()), err)
 	case errors.Is(err, ErrUnknownKey), errors.Is(err, ErrUnauthorized),
-		errors.Is(err, ErrBodyHash):
+		errors.Is(err, ErrBodyHash), errors.Is(err, ErrChunkSignature):
 		return twirp.WrapError(twirp.PermissionDenied.Error("invalid authentication header"), err)
 	default:
 		// Unexpected errors (e.g. the key store being down) are server
@@ -145,6 +160,11 @@ func TwirpError(ctx context.Context, err error) error {
 // Verify checks a single request. On success it returns the access key id of
 // the caller. The request body is buffered and reset so downstream handlers
 // can read it normally.
+//
+// Streaming requests are the exception: only the seed signature is checked
+// here, and r.Body is replaced with a reader that verifies each chunk as it
+// is read. Handlers must treat any error from reading such a body as an
+// authentication failure.
 func (v *Verifier) Verify(r *http.Request) (string, error) {
 	if v.Lookup == nil && v.KeyLookup == nil {
 		return "", ErrNotConfigured
@@ -159,12 +179,48 @@ func (v *Verifier) Verify(r *http.Request) (string, error) {
 	if maxBodySize == 0 {
 		maxBodySize = DefaultMaxBodySize
 	}
+
+	if declared := r.Header.Get("X-Amz-Content-Sha256"); declared == StreamingPayload || declared == StreamingPayloadTrailer {
+		sc, err := v.verify(r, sr, declared)
+		if err != nil {
+			return "", err
+		}
+		if err := awssig.StreamChunked(r, awssig.ChunkConfig{
+			PayloadAlgorithm: chunkAlgorithm,
+			TrailerAlgorithm: trailerAlgorithm,
+			AmzDate:          sc.amzDate,
+			Scope:            sc.scope,
+			Seed:             sr.signature,
+			Trailer:          declared == StreamingPayloadTrailer,
+			MaxChunkSize:     maxBodySize,
+			Verify: func(stringToSign, signature string) bool {
+				want := hex.EncodeToString(awssig.HMACSHA256(sc.key, []byte(stringToSign)))
+				return subtle.ConstantTimeCompare([]byte(want), []byte(signature)) == 1
+			},
+		}); err != nil {
+			return "", err
+		}
+		return sr.accessKeyID, nil
+	}
+
 	payloadHash, err := awssig.ResolvePayloadHash(r, maxBodySize)
 	if err != nil {
 		return "", err
 	}
 
-	return v.verify(r, sr, payloadHash)
+	if _, err := v.verify(r, sr, payloadHash); err != nil {
+		return "", err
+	}
+	return sr.accessKeyID, nil
+}
+
+// signingContext is what a verified seed signature leaves behind. Streaming
+// bodies chain their chunk signatures under the same key, timestamp, and
+// scope.
+type signingContext struct {
+	key     []byte
+	amzDate string
+	scope   string
 }
 
 // verify is the shared core run after the Authorization header is parsed and the
@@ -172,7 +228,7 @@ func (v *Verifier) Verify(r *http.Request) (string, error) {
 // signed host header, enforces the clock-skew window, resolves the signing
 // secret, and compares the recomputed signature in constant time. It never
 // touches r.Body.
-func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string) (string, error) {
+func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string) (*signingContext, error) {
 	now := time.Now
 	if v.Now != nil {
 		now = v.Now
@@ -185,14 +241,14 @@ func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string
 	// Pin the scope. Without this, a signature valid for some other
 	// region/service would be accepted here.
 	if sr.scope.region != v.Region || sr.scope.service != v.Service {
-		return "", ErrScopeMismatch
+		return nil, ErrScopeMismatch
 	}
 
 	// AWS always signs the host header; requiring it binds the signature to
 	// the actual host so a captured request cannot be replayed against a
 	// different vhost or port.
 	if !slices.Contains(sr.signedHeaders, "host") {
-		return "", ErrMissingSignedHost
+		return nil, ErrMissingSignedHost
 	}
 
 	amzDate := r.Header.Get("X-Amz-Date")
@@ -202,20 +258,20 @@ func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string
 		if d := r.Header.Get("Date"); d != "" {
 			dt, derr := http.ParseTime(d)
 			if derr != nil {
-				return "", fmt.Errorf("%w: bad Date", ErrMissingAuth)
+				return nil, fmt.Errorf("%w: bad Date", ErrMissingAuth)
 			}
 			amzDate = dt.UTC().Format(amzTimeFormat)
 		}
 	}
 	t, err := time.Parse(amzTimeFormat, amzDate)
 	if err != nil {
-		return "", fmt.Errorf("%w: bad X-Amz-Date", ErrMissingAuth)
+		return nil, fmt.Errorf("%w: bad X-Amz-Date", ErrMissingAuth)
 	}
 	if t.Format(shortDateFormat) != sr.scope.date {
-		return "", ErrScopeMismatch
+		return nil, ErrScopeMismatch
 	}
 	if d := now().Sub(t); d > skew || d < -skew {
-		return "", ErrClockSkew
+		return nil, ErrClockSkew
 	}
 
 	var key []byte
@@ -229,7 +285,7 @@ func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string
 		}
 	}
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	canonReq := v.canonicalRequest(r, sr, payloadHash)
@@ -245,9 +301,9 @@ func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string
 	want := hex.EncodeToString(awssig.HMACSHA256(key, []byte(stringToSign)))
 
 	if subtle.ConstantTimeCompare([]byte(want), []byte(sr.signature)) != 1 {
-		return "", ErrUnauthorized
+		return nil, ErrUnauthorized
 	}
-	return sr.accessKeyID, nil
+	return &signingContext{key: key, amzDate: amzDate, scope: scopeStr}, nil
 }
 
 func (v *Verifier) canonicalRequest(r *http.Request, sr *signedRequest, payloadHash string) string {
@@ -348,8 +404,5 @@ func ParseCredential(authorization string) (*Credential, error) {
 // matches (date, region, service) exactly, so exposure is bounded to one UTC
 // day and one service; it never reveals the secret.
 func DeriveSigningKey(secret, date, region, service string) []byte {
-	kDate := awssig.HMACSHA256([]byte("AWS4"+secret), []byte(date))
-	kRegion := awssig.HMACSHA256(kDate, []byte(region))
-	kService := awssig.HMACSHA256(kRegion, []byte(service))
-	return awssig.HMACSHA256(kService, []byte(terminator))
+	return awssig.DeriveSigningKey(secret, date, region, service)
 }
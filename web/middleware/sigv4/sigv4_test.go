@@ -226,12 +226,13 @@ func TestMiddleware(t *testing.T) {
 	})
 }
 
-// Streaming payloads are not supported (chunk signatures would need to be
-// verified), so the marker must be rejected rather than silently accepted.
+// Streaming sentinels other than the signed HMAC ones (here the unsigned
+// trailer variant, whose chunks carry no signatures) must be rejected rather
+// than silently accepted.
 func TestStreamingRejected(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "https://api.example.com/", nil)
 	signWithSDK(t, req, nil)
-	req.Header.Set("X-Amz-Content-Sha256", "STREAMING-AWS4-HMAC-SHA256-PAYLOAD")
+	req.Header.Set("X-Amz-Content-Sha256", "STREAMING-UNSIGNED-PAYLOAD-TRAILER")
 
 	if _, err := newVerifier().Verify(req); err != ErrStreamingUnsupported {
 		t.Fatalf("err = %v, want ErrStreamingUnsupported", err)
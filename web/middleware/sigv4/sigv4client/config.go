@@ -29,6 +29,10 @@ type Config struct { //nolint:revive
 	ExternalID         string `yaml:"external_id,omitempty"`
 	UseFIPSSTSEndpoint bool   `yaml:"use_fips_sts_endpoint,omitempty"`
 	ServiceName        string `yaml:"service_name,omitempty"`
+	// ChunkSize, when positive, sends request bodies as aws-chunked
+	// streaming uploads (STREAMING-AWS4-HMAC-SHA256-PAYLOAD) signed
+	// ChunkSize bytes at a time instead of buffering them whole.
+	ChunkSize int `yaml:"chunk_size,omitempty"`
 }
 
 func (c *Config) Valid() error {
@@ -38,6 +42,9 @@ func (c *Config) Valid() error {
 	if c.ExternalID != "" && c.RoleARN == "" {
 		return fmt.Errorf("external_id can only be used with role_arn")
 	}
+	if c.ChunkSize < 0 {
+		return fmt.Errorf("chunk_size must not be negative")
+	}
 	return nil
 }
 
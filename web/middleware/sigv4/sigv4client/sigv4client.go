@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,6 +32,16 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"within.website/x/web/middleware/internal/awssig"
+)
+
+// Payload-hash sentinels for aws-chunked uploads, as in
+// web/middleware/sigv4 (which this package cannot import without a test
+// import cycle).
+const (
+	streamingPayload        = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	streamingPayloadTrailer = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
 )
 
 var sigv4HeaderDenylist = []string{
@@ -43,6 +55,7 @@ type sigV4RoundTripper struct {
 	creds       *aws.CredentialsCache
 	serviceName string
 	signer      *signer.Signer
+	chunkSize   int
 }
 
 // NewSigV4RoundTripper returns a new http.RoundTripper that will sign requests
@@ -120,6 +133,7 @@ func NewSigV4RoundTripper(cfg *Config, next http.RoundTripper) (http.RoundTrippe
 		creds:       aws.NewCredentialsCache(awscfg.Credentials, credentialCacheOptions),
 		signer:      signer.NewSigner(),
 		serviceName: serviceName,
+		chunkSize:   cfg.ChunkSize,
 	}
 	rt.pool.New = rt.newBuf
 	return rt, nil
@@ -137,6 +151,17 @@ func (rt *sigV4RoundTripper) RoundTrip(req *http.Request) (*http.Response, error
 		signReq.Header.Del(header)
 	}
 
+	if rt.chunkSize > 0 && req.Body != nil && req.Body != http.NoBody {
+		// Share the caller's trailer map: its values may be filled in
+		// while the body is being read.
+		signReq.Trailer = req.Trailer
+		if err := rt.signStreaming(signReq); err != nil {
+			return nil, err
+		}
+		restoreDenylisted(req, signReq)
+		return rt.next.RoundTrip(signReq)
+	}
+
 	buf := rt.pool.Get().(*bytes.Buffer)
 
 	strHash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" // sha256 of an empty file
@@ -189,15 +214,73 @@ func (rt *sigV4RoundTripper) RoundTrip(req *http.Request) (*http.Response, error
 		return nil, fmt.Errorf("failed to sign request: %w", err)
 	}
 
-	// Set unsigned headers into the new req
+	restoreDenylisted(req, signReq)
+	return rt.next.RoundTrip(signReq)
+}
+
+// signStreaming signs signReq as an aws-chunked upload and replaces its body
+// with an encoder that signs each chunk, chained from the seed signature, as
+// it is sent. Only one chunk of the body is ever held in memory.
+func (rt *sigV4RoundTripper) signStreaming(signReq *http.Request) error {
+	declared := streamingPayload
+	if len(signReq.Trailer) > 0 {
+		declared = streamingPayloadTrailer
+		signReq.Header.Set("X-Amz-Trailer", awssig.TrailerNames(signReq.Trailer))
+	}
+	signReq.Header.Set("X-Amz-Content-Sha256", declared)
+	signReq.Header.Add("Content-Encoding", "aws-chunked")
+	if signReq.ContentLength >= 0 {
+		signReq.Header.Set("X-Amz-Decoded-Content-Length", strconv.FormatInt(signReq.ContentLength, 10))
+	}
+	// The encoded length is unknown up front; leaving it unset also keeps
+	// the SDK from signing a content-length the server will never see.
+	body := signReq.Body
+	signReq.ContentLength = -1
+	signReq.GetBody = nil
+	signReq.Header.Del("Content-Length")
+
+	ctx := signReq.Context()
+	creds, err := rt.creds.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("error retrieving credentials: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if err := rt.signer.SignHTTP(ctx, creds, signReq, declared, rt.serviceName, rt.region, now); err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+	_, seed, ok := strings.Cut(signReq.Header.Get("Authorization"), "Signature=")
+	if !ok {
+		return fmt.Errorf("failed to sign request: no seed signature in Authorization")
+	}
+
+	date := now.Format(awssig.ShortDateFormat)
+	key := awssig.DeriveSigningKey(creds.SecretAccessKey, date, rt.region, rt.serviceName)
+	signReq.Body = awssig.NewChunkEncoder(body, rt.chunkSize, signReq.Trailer, awssig.ChunkConfig{
+		PayloadAlgorithm: "AWS4-HMAC-SHA256-PAYLOAD",
+		TrailerAlgorithm: "AWS4-HMAC-SHA256-TRAILER",
+		AmzDate:          now.Format(awssig.AmzTimeFormat),
+		Scope:            strings.Join([]string{date, rt.region, rt.serviceName, awssig.Terminator}, "/"),
+		Seed:             seed,
+		Trailer:          declared == streamingPayloadTrailer,
+	}, func(stringToSign string) (string, error) {
+		return hex.EncodeToString(awssig.HMACSHA256(key, []byte(stringToSign))), nil
+	})
+	// The trailers travel inside the aws-chunked framing, not as HTTP
+	// trailers.
+	signReq.Trailer = nil
+	return nil
+}
+
+// restoreDenylisted copies the unsigned, denylisted headers from the
+// caller's request onto the signed one.
+func restoreDenylisted(req, signReq *http.Request) {
 	for _, header := range sigv4HeaderDenylist {
 		headerValue := req.Header.Get(header)
 		if headerValue != "" {
 			signReq.Header.Set(header, headerValue)
 		}
 	}
-
-	return rt.next.RoundTrip(signReq)
 }
 
 func credentialCacheOptions(options *aws.CredentialsCacheOptions) {
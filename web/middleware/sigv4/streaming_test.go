@@ -0,0 +1,168 @@
+package sigv4
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// newStreamingRequest builds an aws-chunked upload the way an AWS SDK would:
+// the seed is signed by the SDK's v4 signer over the streaming sentinel, and
+// every chunk is signed by the SDK's StreamSigner chained from that seed.
+// Neither half shares code with this package, so a verified body proves the
+// chunk string-to-sign agrees with the reference implementation.
+func newStreamingRequest(t *testing.T, chunks ...[]byte) *http.Request {
+	t.Helper()
+	var decoded int
+	for _, c := range chunks {
+		decoded += len(c)
+	}
+	return newStreamingRequestDecoded(t, decoded, chunks...)
+}
+
+// newStreamingRequestDecoded is newStreamingRequest with an explicit (and
+// possibly wrong) signed x-amz-decoded-content-length.
+func newStreamingRequestDecoded(t *testing.T, decoded int, chunks ...[]byte) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPut, "https://api.example.com/v1/upload", nil)
+	req.Header.Set("Content-Encoding", "aws-chunked")
+	req.Header.Set("X-Amz-Decoded-Content-Length", strconv.Itoa(decoded))
+	signWithSDKPayloadHash(t, req, StreamingPayload)
+
+	_, seedHex, _ := strings.Cut(req.Header.Get("Authorization"), "Signature=")
+	seed, err := hex.DecodeString(seedHex)
+	if err != nil {
+		t.Fatalf("decode seed: %v", err)
+	}
+
+	creds := aws.Credentials{AccessKeyID: testKey, SecretAccessKey: testSecret}
+	ss := v4.NewStreamSigner(creds, testSvc, testRegion, seed)
+	signingTime := time.Date(2026, 6, 29, 12, 0, 0, 0, time.UTC)
+
+	var body bytes.Buffer
+	for _, c := range append(chunks, nil) {
+		sig, err := ss.GetSignature(context.Background(), nil, c, signingTime)
+		if err != nil {
+			t.Fatalf("chunk signature: %v", err)
+		}
+		fmt.Fprintf(&body, "%x;chunk-signature=%x\r\n", len(c), sig)
+		body.Write(c)
+		body.WriteString("\r\n")
+	}
+	req.Body = io.NopCloser(&body)
+	req.ContentLength = int64(body.Len())
+	return req
+}
+
+func TestStreaming_SDKReference(t *testing.T) {
+	req := newStreamingRequest(t, []byte("hello, "), []byte("streaming "), []byte("world"))
+
+	got, err := newVerifier().Verify(req)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if got != testKey {
+		t.Fatalf("key = %q, want %q", got, testKey)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if string(body) != "hello, streaming world" {
+		t.Fatalf("body = %q", body)
+	}
+	if req.ContentLength != int64(len(body)) {
+		t.Errorf("ContentLength = %d, want decoded length %d", req.ContentLength, len(body))
+	}
+	if ce := req.Header.Get("Content-Encoding"); ce != "" {
+		t.Errorf("Content-Encoding = %q, want aws-chunked stripped", ce)
+	}
+}
+
+// A flipped byte in the second chunk must fail that chunk's signature. The
+// first chunk, already verified, may be released; nothing after it may.
+func TestStreaming_TamperedChunk(t *testing.T) {
+	req := newStreamingRequest(t, []byte("first"), []byte("second"))
+	raw, _ := io.ReadAll(req.Body)
+	raw = bytes.Replace(raw, []byte("second"), []byte("SECOND"), 1)
+	req.Body = io.NopCloser(bytes.NewReader(raw))
+
+	if _, err := newVerifier().Verify(req); err != nil {
+		t.Fatalf("verify seed: %v", err)
+	}
+	body, err := io.ReadAll(req.Body)
+	if !errors.Is(err, ErrChunkSignature) {
+		t.Fatalf("read err = %v, want ErrChunkSignature", err)
+	}
+	if string(body) != "first" {
+		t.Fatalf("released %q, want only the verified first chunk", body)
+	}
+}
+
+// Dropping the final chunk must not read as a clean EOF, or a truncated
+// upload would be indistinguishable from a complete one.
+func TestStreaming_Truncated(t *testing.T) {
+	req := newStreamingRequest(t, []byte("first"), []byte("second"))
+	raw, _ := io.ReadAll(req.Body)
+	end := bytes.LastIndex(raw, []byte("0;chunk-signature="))
+	req.Body = io.NopCloser(bytes.NewReader(raw[:end]))
+
+	if _, err := newVerifier().Verify(req); err != nil {
+		t.Fatalf("verify seed: %v", err)
+	}
+	if _, err := io.ReadAll(req.Body); !errors.Is(err, ErrMalformedChunk) {
+		t.Fatalf("read err = %v, want ErrMalformedChunk", err)
+	}
+}
+
+// The signed decoded length must match the bytes actually framed, in both
+// directions.
+func TestStreaming_DecodedLengthMismatch(t *testing.T) {
+	for _, decoded := range []int{2, 4} {
+		t.Run(strconv.Itoa(decoded), func(t *testing.T) {
+			req := newStreamingRequestDecoded(t, decoded, []byte("abc"))
+			if _, err := newVerifier().Verify(req); err != nil {
+				t.Fatalf("verify seed: %v", err)
+			}
+			if _, err := io.ReadAll(req.Body); !errors.Is(err, ErrMalformedChunk) {
+				t.Fatalf("read err = %v, want ErrMalformedChunk", err)
+			}
+		})
+	}
+}
+
+// MaxBodySize bounds each chunk, not the whole stream: many small chunks
+// may add up to more than the cap, but a single oversized chunk is refused.
+func TestStreaming_MaxBodySizeIsPerChunk(t *testing.T) {
+	v := newVerifier()
+	v.MaxBodySize = 4
+
+	req := newStreamingRequest(t, []byte("abcd"), []byte("efgh"), []byte("ijkl"))
+	if _, err := v.Verify(req); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if body, err := io.ReadAll(req.Body); err != nil || string(body) != "abcdefghijkl" {
+		t.Fatalf("body = %q, err = %v", body, err)
+	}
+
+	req = newStreamingRequest(t, []byte("abcde"))
+	if _, err := v.Verify(req); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if _, err := io.ReadAll(req.Body); !errors.Is(err, ErrBodyTooLarge) {
+		t.Fatalf("read err = %v, want ErrBodyTooLarge", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -50,14 +51,86 @@ func (s *Signer) Sign(r *http.Request, body []byte) error {
 	sum := sha256.Sum256(body)
 	payloadHash := hex.EncodeToString(sum[:])
 	r.Header.Set("X-Amz-Content-Sha256", payloadHash)
-	return s.sign(r, []string{"host", "x-amz-content-sha256", "x-amz-date", "x-amz-region-set"}, payloadHash)
+	_, err := s.sign(r, []string{"host", "x-amz-content-sha256", "x-amz-date", "x-amz-region-set"}, payloadHash)
+	return err
+}
+
+// SignStreaming signs r in place for an aws-chunked upload and replaces
+// r.Body with an encoder that signs each chunkSize piece of the original
+// body as it is sent, chaining from the seed signature in the Authorization
+// header. The body is never buffered beyond one chunk, so it may be of any
+// size. A non-negative r.ContentLength is declared as the decoded length;
+// the encoded length is not known up front, so r.ContentLength becomes -1.
+// When r.Trailer is non-empty its values are sent as signed trailing
+// headers once the body has been read to EOF.
+func (s *Signer) SignStreaming(r *http.Request, chunkSize int) error {
+	if chunkSize <= 0 {
+		return fmt.Errorf("sigv4a: chunk size must be positive, got %d", chunkSize)
+	}
+	body := r.Body
+	if body == nil {
+		body = http.NoBody
+	}
+
+	declared := StreamingPayload
+	if len(r.Trailer) > 0 {
+		declared = StreamingPayloadTrailer
+		r.Header.Set("X-Amz-Trailer", awssig.TrailerNames(r.Trailer))
+	}
+	r.Header.Set("X-Amz-Content-Sha256", declared)
+	r.Header.Add("Content-Encoding", "aws-chunked")
+	signedHeaders := []string{"content-encoding", "host", "x-amz-content-sha256", "x-amz-date", "x-amz-region-set"}
+	if r.ContentLength >= 0 {
+		r.Header.Set("X-Amz-Decoded-Content-Length", strconv.FormatInt(r.ContentLength, 10))
+		signedHeaders = append(signedHeaders, "x-amz-decoded-content-length")
+	}
+	if declared == StreamingPayloadTrailer {
+		signedHeaders = append(signedHeaders, "x-amz-trailer")
+	}
+
+	seed, err := s.sign(r, signedHeaders, declared)
+	if err != nil {
+		return err
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	r.Body = awssig.NewChunkEncoder(body, chunkSize, r.Trailer, awssig.ChunkConfig{
+		PayloadAlgorithm: chunkAlgorithm,
+		TrailerAlgorithm: trailerAlgorithm,
+		AmzDate:          amzDate,
+		Scope:            s.scope(amzDate),
+		Seed:             seed,
+		Trailer:          declared == StreamingPayloadTrailer,
+	}, func(stringToSign string) (string, error) {
+		digest := sha256.Sum256([]byte(stringToSign))
+		sig, err := ecdsa.SignASN1(rand.Reader, s.priv, digest[:])
+		if err != nil {
+			return "", fmt.Errorf("sigv4a: signing chunk: %w", err)
+		}
+		return hex.EncodeToString(sig), nil
+	})
+	r.ContentLength = -1
+	r.GetBody = nil
+	r.Header.Del("Content-Length")
+	// The trailers travel inside the aws-chunked framing, not as HTTP
+	// trailers.
+	r.Trailer = nil
+	return nil
+}
+
+// scope renders the SigV4A credential scope, date/service/aws4_request — no
+// region. The region set is bound to the signature as a signed header
+// instead.
+func (s *Signer) scope(amzDate string) string {
+	return strings.Join([]string{amzDate[:len(shortDateFormat)], s.service, terminator}, "/")
 }
 
 // sign stamps X-Amz-Date and X-Amz-Region-Set, builds the canonical request
-// over exactly signedHeaders, and writes the Authorization header. It is
+// over exactly signedHeaders, and writes the Authorization header. It
+// returns the hex signature, the seed of a streaming body's chain. It is
 // split from Sign so tests can reproduce the AWS test-suite vectors, which
 // sign without x-amz-content-sha256.
-func (s *Signer) sign(r *http.Request, signedHeaders []string, payloadHash string) error {
+func (s *Signer) sign(r *http.Request, signedHeaders []string, payloadHash string) (string, error) {
 	if r.Host == "" {
 		r.Host = r.URL.Host
 	}
@@ -73,9 +146,7 @@ func (s *Signer) sign(r *http.Request, signedHeaders []string, payloadHash strin
 	sort.Strings(headers)
 
 	canonReq := awssig.BuildCanonicalRequest(r, headers, payloadHash, false)
-	// SigV4A credential scope: date/service/aws4_request — no region. The
-	// region set is bound to the signature as a signed header instead.
-	scope := strings.Join([]string{amzDate[:len(shortDateFormat)], s.service, terminator}, "/")
+	scope := s.scope(amzDate)
 	hashed := sha256.Sum256([]byte(canonReq))
 	stringToSign := strings.Join([]string{
 		algorithm,
@@ -87,10 +158,10 @@ func (s *Signer) sign(r *http.Request, signedHeaders []string, payloadHash strin
 	digest := sha256.Sum256([]byte(stringToSign))
 	sig, err := ecdsa.SignASN1(rand.Reader, s.priv, digest[:])
 	if err != nil {
-		return fmt.Errorf("sigv4a: signing: %w", err)
+		return "", fmt.Errorf("sigv4a: signing: %w", err)
 	}
 
 	r.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
 		algorithm, s.accessKeyID, scope, strings.Join(headers, ";"), hex.EncodeToString(sig)))
-	return nil
+	return hex.EncodeToString(sig), nil
 }
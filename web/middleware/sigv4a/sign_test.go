@@ -52,7 +52,7 @@ func TestSigner_Vectors(t *testing.T) {
 
 			wantSigned := authField(t, readVectorFile(t, dir, "header-signed-request.txt"), "SignedHeaders")
 			signedHeaders := strings.Split(wantSigned, ";")
-			if err := s.sign(r, signedHeaders, payloadHash); err != nil {
+			if _, err := s.sign(r, signedHeaders, payloadHash); err != nil {
 				t.Fatalf("sign: %v", err)
 			}
 
@@ -25,11 +25,20 @@ const (
 )
 
 // Payload-hash sentinels. UnsignedPayload matches exactly (AWS defines it
-// case-sensitively); any STREAMING-* sentinel is rejected inside
+// case-sensitively). The two ECDSA streaming sentinels select chunk-by-chunk
+// verification; any other STREAMING-* sentinel is rejected inside
 // awssig.ResolvePayloadHash.
 const (
-	UnsignedPayload  = awssig.UnsignedPayload
-	StreamingPayload = "STREAMING-AWS4-ECDSA-P256-SHA256-PAYLOAD"
+	UnsignedPayload         = awssig.UnsignedPayload
+	StreamingPayload        = "STREAMING-AWS4-ECDSA-P256-SHA256-PAYLOAD"
+	StreamingPayloadTrailer = "STREAMING-AWS4-ECDSA-P256-SHA256-PAYLOAD-TRAILER"
+)
+
+// Algorithms heading the per-chunk and trailer strings to sign of a
+// streaming body.
+const (
+	chunkAlgorithm   = "AWS4-ECDSA-P256-SHA256-PAYLOAD"
+	trailerAlgorithm = "AWS4-ECDSA-P256-SHA256-TRAILER"
 )
 
 // Errors returned by Verify. Callers typically map ErrUnauthorized and
@@ -47,6 +56,11 @@ var (
 	ErrUnauthorized         = errors.New("sigv4a: signature mismatch")
 	ErrBodyTooLarge         = awssig.ErrBodyTooLarge
 	ErrNotConfigured        = errors.New("sigv4a: neither Verifier.Lookup nor Verifier.KeyLookup is set")
+
+	// ErrMalformedChunk and ErrChunkSignature are returned from Read on the
+	// body of a verified streaming request, never from Verify itself.
+	ErrMalformedChunk = awssig.ErrMalformedChunk
+	ErrChunkSignature = awssig.ErrChunkSignature
 )
 
 // DefaultMaxBodySize is the byte cap applied to request bodies when
@@ -95,7 +109,8 @@ type Verifier struct {
 
 	// MaxBodySize caps how many bytes of the body will be buffered to verify
 	// the payload hash. Zero means DefaultMaxBodySize (10 MiB). Requests that
-	// exceed it are rejected with ErrBodyTooLarge.
+	// exceed it are rejected with ErrBodyTooLarge. For streaming bodies it
+	// caps each chunk instead, so the total size is unbounded.
 	MaxBodySize int64
 
 	// Now is overridable for tests. Defaults to time.Now.
@@ -132,13 +147,13 @@ func TwirpError(ctx context.Context, err error) error {
 	case errors.Is(err, ErrScopeMismatch),
 		errors.Is(err, ErrClockSkew), errors.Is(err, ErrBodyTooLarge),
 		errors.Is(err, ErrStreamingUnsupported), errors.Is(err, ErrMissingSignedHost),
-		errors.Is(err, ErrMissingRegionSet):
+		errors.Is(err, ErrMissingRegionSet), errors.Is(err, ErrMalformedChunk):
 		// These sentinels describe the caller's own request and carry no
 		// internal detail, so surface them: a client cannot correct clock
 		// skew it can't distinguish from a scope mismatch.
 		return twirp.WrapError(twirp.InvalidArgument.Error(err.Error()), err)
 	case errors.Is(err, ErrUnknownKey), errors.Is(err, ErrUnauthorized),
-		errors.Is(err, ErrBodyHash):
+		errors.Is(err, ErrBodyHash), errors.Is(err, ErrChunkSignature):
 		return twirp.WrapError(twirp.PermissionDenied.Error("invalid authentication header"), err)
 	default:
 		// Unexpected errors (e.g. the key store being down) are server
@@ -151,6 +166,11 @@ func TwirpError(ctx context.Context, err error) error {
 // Verify checks a single request. On success it returns the access key id of
 // the caller. The request body is buffered and reset so downstream handlers
 // can read it normally.
+//
+// Streaming requests are the exception: only the seed signature is checked
+// here, and r.Body is replaced with a reader that verifies each chunk's
+// ECDSA signature as it is read. Handlers must treat any error from reading
+// such a body as an authentication failure.
 func (v *Verifier) Verify(r *http.Request) (string, error) {
 	if v.Lookup == nil && v.KeyLookup == nil {
 		return "", ErrNotConfigured
@@ -165,19 +185,59 @@ func (v *Verifier) Verify(r *http.Request) (string, error) {
 	if maxBodySize == 0 {
 		maxBodySize = DefaultMaxBodySize
 	}
+
+	if declared := r.Header.Get("X-Amz-Content-Sha256"); declared == StreamingPayload || declared == StreamingPayloadTrailer {
+		sc, err := v.verify(r, sr, declared)
+		if err != nil {
+			return "", err
+		}
+		if err := awssig.StreamChunked(r, awssig.ChunkConfig{
+			PayloadAlgorithm: chunkAlgorithm,
+			TrailerAlgorithm: trailerAlgorithm,
+			AmzDate:          sc.amzDate,
+			Scope:            sc.scope,
+			Seed:             sr.signature,
+			Trailer:          declared == StreamingPayloadTrailer,
+			MaxChunkSize:     maxBodySize,
+			Verify: func(stringToSign, signature string) bool {
+				sig, err := hex.DecodeString(signature)
+				if err != nil {
+					return false
+				}
+				digest := sha256.Sum256([]byte(stringToSign))
+				return ecdsa.VerifyASN1(sc.pub, digest[:], sig)
+			},
+		}); err != nil {
+			return "", err
+		}
+		return sr.accessKeyID, nil
+	}
+
 	payloadHash, err := awssig.ResolvePayloadHash(r, maxBodySize)
 	if err != nil {
 		return "", err
 	}
 
-	return v.verify(r, sr, payloadHash)
+	if _, err := v.verify(r, sr, payloadHash); err != nil {
+		return "", err
+	}
+	return sr.accessKeyID, nil
+}
+
+// signingContext is what a verified seed signature leaves behind. Streaming
+// bodies chain their chunk signatures under the same key, timestamp, and
+// scope.
+type signingContext struct {
+	pub     *ecdsa.PublicKey
+	amzDate string
+	scope   string
 }
 
 // verify is the shared core run after the Authorization header is parsed and the
 // canonical payload hash is known. It pins the credential scope, requires a
 // signed host header, enforces the clock-skew window, resolves the signing
 // public key, and verifies the ECDSA signature. It never touches r.Body.
-func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string) (string, error) {
+func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string) (*signingContext, error) {
 	now := time.Now
 	if v.Now != nil {
 		now = v.Now
@@ -191,23 +251,23 @@ func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string
 	// would be accepted here. Region is not part of the SigV4A credential
 	// scope; it is checked below through the signed X-Amz-Region-Set.
 	if sr.scope.service != v.Service {
-		return "", ErrScopeMismatch
+		return nil, ErrScopeMismatch
 	}
 
 	// AWS always signs the host header; requiring it binds the signature to
 	// the actual host so a captured request cannot be replayed against a
 	// different vhost or port.
 	if !slices.Contains(sr.signedHeaders, "host") {
-		return "", ErrMissingSignedHost
+		return nil, ErrMissingSignedHost
 	}
 
 	// The region set feeds the scope decision, so it must be signed —
 	// otherwise a relay could rewrite the audience of a captured signature.
 	if !slices.Contains(sr.signedHeaders, "x-amz-region-set") {
-		return "", ErrMissingRegionSet
+		return nil, ErrMissingRegionSet
 	}
 	if !regionSetMatches(r.Header.Get("X-Amz-Region-Set"), v.Region) {
-		return "", ErrScopeMismatch
+		return nil, ErrScopeMismatch
 	}
 
 	amzDate := r.Header.Get("X-Amz-Date")
@@ -217,20 +277,20 @@ func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string
 		if d := r.Header.Get("Date"); d != "" {
 			dt, derr := http.ParseTime(d)
 			if derr != nil {
-				return "", fmt.Errorf("%w: bad Date", ErrMissingAuth)
+				return nil, fmt.Errorf("%w: bad Date", ErrMissingAuth)
 			}
 			amzDate = dt.UTC().Format(amzTimeFormat)
 		}
 	}
 	t, err := time.Parse(amzTimeFormat, amzDate)
 	if err != nil {
-		return "", fmt.Errorf("%w: bad X-Amz-Date", ErrMissingAuth)
+		return nil, fmt.Errorf("%w: bad X-Amz-Date", ErrMissingAuth)
 	}
 	if t.Format(shortDateFormat) != sr.scope.date {
-		return "", ErrScopeMismatch
+		return nil, ErrScopeMismatch
 	}
 	if d := now().Sub(t); d > skew || d < -skew {
-		return "", ErrClockSkew
+		return nil, ErrClockSkew
 	}
 
 	var pub *ecdsa.PublicKey
@@ -248,14 +308,14 @@ func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string
 		}
 	}
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if pub == nil {
 		// A lookuper that returns no key and no error must read as
 		// unknown-key, never reach VerifyASN1: it dereferences pub.Curve
 		// unconditionally and would panic on a nil key, turning a lookuper
 		// bug into a DoS of this auth middleware.
-		return "", ErrUnknownKey
+		return nil, ErrUnknownKey
 	}
 
 	canonReq := v.canonicalRequest(r, sr, payloadHash)
@@ -270,13 +330,13 @@ func (v *Verifier) verify(r *http.Request, sr *signedRequest, payloadHash string
 
 	sig, err := hex.DecodeString(sr.signature)
 	if err != nil {
-		return "", ErrUnauthorized
+		return nil, ErrUnauthorized
 	}
 	digest := sha256.Sum256([]byte(stringToSign))
 	if !ecdsa.VerifyASN1(pub, digest[:], sig) {
-		return "", ErrUnauthorized
+		return nil, ErrUnauthorized
 	}
-	return sr.accessKeyID, nil
+	return &signingContext{pub: pub, amzDate: amzDate, scope: scopeStr}, nil
 }
 
 func (v *Verifier) canonicalRequest(r *http.Request, sr *signedRequest, payloadHash string) string {
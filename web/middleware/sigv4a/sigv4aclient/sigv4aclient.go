@@ -26,6 +26,11 @@ type Config struct {
 	SecretKey string
 	// ServiceName is the credential-scope service, e.g. "iam".
 	ServiceName string
+	// ChunkSize, when positive, sends request bodies as aws-chunked
+	// streaming uploads signed ChunkSize bytes at a time instead of
+	// buffering and hashing them whole. Use it for bodies too large for the
+	// verifier's MaxBodySize.
+	ChunkSize int
 }
 
 // Valid reports whether every required field is set.
@@ -41,14 +46,17 @@ func (c *Config) Valid() error {
 		return fmt.Errorf("sigv4aclient: SecretKey is required")
 	case c.ServiceName == "":
 		return fmt.Errorf("sigv4aclient: ServiceName is required")
+	case c.ChunkSize < 0:
+		return fmt.Errorf("sigv4aclient: ChunkSize must not be negative")
 	}
 	return nil
 }
 
 // NewSigV4ARoundTripper returns an http.RoundTripper that signs every
 // request per cfg and forwards it to next (http.DefaultTransport when nil).
-// The caller's request is cloned and its body buffered for hashing; the
-// original is never mutated.
+// The caller's request is cloned and its body buffered for hashing (or
+// streamed in signed chunks when cfg.ChunkSize is set); the original is
+// never mutated.
 func NewSigV4ARoundTripper(cfg *Config, next http.RoundTripper) (http.RoundTripper, error) {
 	if err := cfg.Valid(); err != nil {
 		return nil, err
@@ -60,16 +68,26 @@ func NewSigV4ARoundTripper(cfg *Config, next http.RoundTripper) (http.RoundTripp
 	if next == nil {
 		next = http.DefaultTransport
 	}
-	return &roundTripper{signer: signer, next: next}, nil
+	return &roundTripper{signer: signer, next: next, chunkSize: cfg.ChunkSize}, nil
 }
 
 type roundTripper struct {
-	signer *sigv4a.Signer
-	next   http.RoundTripper
+	signer    *sigv4a.Signer
+	next      http.RoundTripper
+	chunkSize int
 }
 
 func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	r := req.Clone(req.Context())
+	if rt.chunkSize > 0 && req.Body != nil && req.Body != http.NoBody {
+		// Share the caller's trailer map: its values may be filled in
+		// while the body is being read.
+		r.Trailer = req.Trailer
+		if err := rt.signer.SignStreaming(r, rt.chunkSize); err != nil {
+			return nil, err
+		}
+		return rt.next.RoundTrip(r)
+	}
 	var body []byte
 	if req.Body != nil {
 		var err error
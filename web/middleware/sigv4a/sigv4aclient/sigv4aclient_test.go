@@ -8,6 +8,8 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"within.website/x/web/middleware/sigv4a"
 )
 
 func validConfig() *Config {
@@ -30,6 +32,7 @@ func TestConfigValid(t *testing.T) {
 		{name: "missing access key", mutate: func(c *Config) { c.AccessKey = "" }, wantErr: true},
 		{name: "missing secret key", mutate: func(c *Config) { c.SecretKey = "" }, wantErr: true},
 		{name: "missing service", mutate: func(c *Config) { c.ServiceName = "" }, wantErr: true},
+		{name: "negative chunk size", mutate: func(c *Config) { c.ChunkSize = -1 }, wantErr: true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -163,6 +166,55 @@ func TestRoundTrip_SetsGetBody(t *testing.T) {
 	}
 }
 
+// TestRoundTrip_Streaming sends a body larger than the verifier's
+// MaxBodySize as an aws-chunked upload and checks the sigv4a middleware
+// verifies every chunk and hands the handler the decoded bytes.
+func TestRoundTrip_Streaming(t *testing.T) {
+	cfg := validConfig()
+	cfg.ServiceName = "execute-api"
+	cfg.ChunkSize = 512
+	v := &sigv4a.Verifier{
+		Region:      cfg.Region,
+		Service:     cfg.ServiceName,
+		MaxBodySize: 512,
+		Lookup: sigv4a.LookuperFunc(func(id string) (string, error) {
+			if id == cfg.AccessKey {
+				return cfg.SecretKey, nil
+			}
+			return "", sigv4a.ErrUnknownKey
+		}),
+	}
+
+	var gotBody []byte
+	var readErr error
+	srv := httptest.NewServer(v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, readErr = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	defer srv.Close()
+
+	rt, err := NewSigV4ARoundTripper(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewSigV4ARoundTripper: %v", err)
+	}
+	body := strings.Repeat("x", 4096)
+	req, _ := http.NewRequest(http.MethodPut, srv.URL+"/blob", strings.NewReader(body))
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", resp.StatusCode)
+	}
+	if readErr != nil {
+		t.Fatalf("handler read: %v", readErr)
+	}
+	if string(gotBody) != body {
+		t.Fatalf("body mismatch: got %d bytes, want %d", len(gotBody), len(body))
+	}
+}
+
 func TestInvalidConfigRejected(t *testing.T) {
 	cfg := validConfig()
 	cfg.SecretKey = ""
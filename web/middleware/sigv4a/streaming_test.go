@@ -0,0 +1,131 @@
+package sigv4a
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// signStreaming builds a PUT whose body is body, signed by SignStreaming in
+// chunkSize pieces, and returns it with the encoded body fully rendered so
+// tests can tamper with the framing.
+func signStreaming(t *testing.T, body string, chunkSize int, trailer http.Header) (*http.Request, []byte) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPut, "https://api.example.com/v1/upload", strings.NewReader(body))
+	req.Trailer = trailer
+	if err := testSigner(t).SignStreaming(req, chunkSize); err != nil {
+		t.Fatalf("SignStreaming: %v", err)
+	}
+	if got := req.Header.Get("X-Amz-Content-Sha256"); trailer == nil && got != StreamingPayload || trailer != nil && got != StreamingPayloadTrailer {
+		t.Fatalf("x-amz-content-sha256 = %q", got)
+	}
+	raw, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(raw))
+	return req, raw
+}
+
+func TestStreaming_SignVerify(t *testing.T) {
+	body := strings.Repeat("sigv4a streaming ", 100)
+
+	t.Run("chunks", func(t *testing.T) {
+		req, _ := signStreaming(t, body, 64, nil)
+		if _, err := testVerifier().Verify(req); err != nil {
+			t.Fatalf("verify: %v", err)
+		}
+		got, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		if string(got) != body {
+			t.Fatalf("body mismatch: got %d bytes, want %d", len(got), len(body))
+		}
+	})
+
+	t.Run("trailer", func(t *testing.T) {
+		req, _ := signStreaming(t, body, 64, http.Header{"X-Amz-Checksum-Sha256": {"abc="}})
+		if _, err := testVerifier().Verify(req); err != nil {
+			t.Fatalf("verify: %v", err)
+		}
+		if _, err := io.ReadAll(req.Body); err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		if got := req.Trailer.Get("X-Amz-Checksum-Sha256"); got != "abc=" {
+			t.Fatalf("trailer = %q, want abc=", got)
+		}
+	})
+
+	t.Run("tampered trailer", func(t *testing.T) {
+		req, raw := signStreaming(t, body, 64, http.Header{"X-Amz-Checksum-Sha256": {"abc="}})
+		raw = bytes.Replace(raw, []byte("x-amz-checksum-sha256:abc="), []byte("x-amz-checksum-sha256:xyz="), 1)
+		req.Body = io.NopCloser(bytes.NewReader(raw))
+		if _, err := testVerifier().Verify(req); err != nil {
+			t.Fatalf("verify seed: %v", err)
+		}
+		if _, err := io.ReadAll(req.Body); !errors.Is(err, ErrChunkSignature) {
+			t.Fatalf("read err = %v, want ErrChunkSignature", err)
+		}
+	})
+
+	t.Run("too many trailers", func(t *testing.T) {
+		req, raw := signStreaming(t, body, 64, http.Header{"X-Amz-Checksum-Sha256": {"abc="}})
+		line := []byte("x-amz-checksum-sha256:abc=\r\n")
+		raw = bytes.Replace(raw, line, bytes.Repeat(line, 1000), 1)
+		req.Body = io.NopCloser(bytes.NewReader(raw))
+		if _, err := testVerifier().Verify(req); err != nil {
+			t.Fatalf("verify seed: %v", err)
+		}
+		if _, err := io.ReadAll(req.Body); !errors.Is(err, ErrMalformedChunk) || !strings.Contains(err.Error(), "too many trailers") {
+			t.Fatalf("read err = %v, want ErrMalformedChunk for too many trailers", err)
+		}
+	})
+
+	t.Run("tampered chunk", func(t *testing.T) {
+		req, raw := signStreaming(t, "aaaabbbb", 4, nil)
+		raw = bytes.Replace(raw, []byte("bbbb"), []byte("BBBB"), 1)
+		req.Body = io.NopCloser(bytes.NewReader(raw))
+		if _, err := testVerifier().Verify(req); err != nil {
+			t.Fatalf("verify seed: %v", err)
+		}
+		got, err := io.ReadAll(req.Body)
+		if !errors.Is(err, ErrChunkSignature) {
+			t.Fatalf("read err = %v, want ErrChunkSignature", err)
+		}
+		if string(got) != "aaaa" {
+			t.Fatalf("released %q, want only the verified first chunk", got)
+		}
+	})
+
+	// aws-c-auth pads chunk signatures with '*' to a fixed width; padding
+	// must not break the chain.
+	t.Run("padded signatures", func(t *testing.T) {
+		req, raw := signStreaming(t, "aaaabbbb", 4, nil)
+		raw = bytes.ReplaceAll(raw, []byte("\r\naaaa"), []byte("****\r\naaaa"))
+		req.Body = io.NopCloser(bytes.NewReader(raw))
+		if _, err := testVerifier().Verify(req); err != nil {
+			t.Fatalf("verify seed: %v", err)
+		}
+		if got, err := io.ReadAll(req.Body); err != nil || string(got) != "aaaabbbb" {
+			t.Fatalf("body = %q, err = %v", got, err)
+		}
+	})
+}
+
+// A classic SigV4 streaming sentinel is not a SigV4A one; it must not be
+// routed into chunk verification.
+func TestStreaming_ForeignSentinelRejected(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "https://api.example.com/", nil)
+	if err := testSigner(t).Sign(req, nil); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	req.Header.Set("X-Amz-Content-Sha256", "STREAMING-AWS4-HMAC-SHA256-PAYLOAD")
+	if _, err := testVerifier().Verify(req); !errors.Is(err, ErrStreamingUnsupported) {
+		t.Fatalf("err = %v, want ErrStreamingUnsupported", err)
+	}
+}
@@ -1,11 +1,13 @@
 package mistral
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"within.website/x/llm"
 	"within.website/x/web"
@@ -13,16 +15,26 @@ import (
 
 type Client struct {
 	*http.Client
-	apiKey string
+	apiKey  string
+	baseURL string
 }
 
 func NewClient(apiKey string) *Client {
 	return &Client{
-		Client: &http.Client{},
-		apiKey: apiKey,
+		Client:  &http.Client{},
+		apiKey:  apiKey,
+		baseURL: "https://api.mistral.ai",
 	}
 }
 
+// WithBaseURL returns a copy of the client that talks to baseURL instead of
+// the Mistral API.
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	result := *c
+	result.baseURL = baseURL
+	return &result
+}
+
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	return c.Client.Do(req)
@@ -37,6 +49,18 @@ type CompleteRequest struct {
 	Stream      *bool         `json:"stream,omitempty"`
 	SafeMode    *bool         `json:"safe_mode,omitempty"`
 	RandomSeed  *int          `json:"random_seed,omitempty"`
+	Tools       []Tool        `json:"tools,omitempty"`
+}
+
+type Tool struct {
+	Type     string   `json:"type"` // "function"
+	Function Function `json:"function"`
+}
+
+type Function struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters"` // JSON Schema
 }
 
 type CompleteResponse struct {
@@ -49,14 +73,31 @@ type CompleteResponse struct {
 }
 
 type CompletionChoice struct {
-	Index        int       `json:"index"`
-	Message      []Message `json:"message"`
-	FinishReason string    `json:"finish_reason"`
+	Index        int     `json:"index"`
+	Message      Message `json:"message"`
+	FinishReason string  `json:"finish_reason"`
 }
 
 type Message struct {
-	Content string `json:"content"`
-	Role    string `json:"role"`
+	Content   string         `json:"content"`
+	Role      string         `json:"role"`
+	ToolCalls []llm.ToolCall `json:"tool_calls,omitempty"`
+}
+
+// StreamChunk is one server-sent event of a streamed completion.
+type StreamChunk struct {
+	ID      string         `json:"id"`
+	Object  string         `json:"object"`
+	Created int64          `json:"created"`
+	Model   string         `json:"model"`
+	Choices []StreamChoice `json:"choices"`
+	Usage   *UsageInfo     `json:"usage,omitempty"` // only on the last chunk
+}
+
+type StreamChoice struct {
+	Index        int     `json:"index"`
+	Delta        Message `json:"delta"`
+	FinishReason string  `json:"finish_reason"`
 }
 
 type UsageInfo struct {
@@ -71,7 +112,7 @@ func (c *Client) Chat(ctx context.Context, req *CompleteRequest) (*CompleteRespo
 		return nil, fmt.Errorf("mistral: error encoding request: %w", err)
 	}
 
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.mistral.chat/v1/chat/completions", &data)
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url(), &data)
 	if err != nil {
 		return nil, fmt.Errorf("mistral: error creating request: %w", err)
 	}
@@ -96,3 +137,69 @@ func (c *Client) Chat(ctx context.Context, req *CompleteRequest) (*CompleteRespo
 
 	return &res, nil
 }
+
+// ChatStream streams a completion, calling fn with each chunk as it arrives.
+// If fn returns an error, the stream is stopped and the error is returned.
+func (c *Client) ChatStream(ctx context.Context, req *CompleteRequest, fn func(StreamChunk) error) error {
+	stream := true
+	req.Stream = &stream
+
+	var data bytes.Buffer
+	if err := json.NewEncoder(&data).Encode(req); err != nil {
+		return fmt.Errorf("mistral: error encoding request: %w", err)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url(), &data)
+	if err != nil {
+		return fmt.Errorf("mistral: error creating request: %w", err)
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.Do(r)
+	if err != nil {
+		return fmt.Errorf("mistral: error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return web.NewError(http.StatusOK, resp)
+	}
+
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		data, ok := strings.CutPrefix(sc.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			return nil
+		}
+
+		var chunk StreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("mistral: error decoding stream chunk: %w", err)
+		}
+
+		if err := fn(chunk); err != nil {
+			return err
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("mistral: error reading stream: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) url() string {
+	baseURL := c.baseURL
+	if baseURL == "" {
+		baseURL = "https://api.mistral.ai"
+	}
+	return baseURL + "/v1/chat/completions"
+}
@@ -0,0 +1,326 @@
+package nodeinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/errgroup"
+	"within.website/x/web/mastodon"
+	"within.website/x/web/useragent"
+)
+
+var (
+	crawls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "within_website_x",
+		Subsystem: "nodeinfo",
+		Name:      "crawls_total",
+		Help:      "Servers the crawler tried to fetch node information from, by result.",
+	}, []string{"result"})
+
+	discovered = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "within_website_x",
+		Subsystem: "nodeinfo",
+		Name:      "discovered_total",
+		Help:      "New servers the crawler found in peer lists.",
+	})
+)
+
+var (
+	// ErrSkipped is recorded for servers that CrawlOptions.Skip says to
+	// leave alone.
+	ErrSkipped = errors.New("nodeinfo: skipped")
+
+	// ErrPrivateAddress is returned by the default crawler client when a
+	// name resolves to an address that isn't on the public internet.
+	ErrPrivateAddress = errors.New("nodeinfo: refusing to connect to a private address")
+)
+
+const (
+	crawlerName    = "within.website/x/web/nodeinfo"
+	crawlerInfoURL = "https://within.website/.x.botinfo"
+)
+
+// CrawlOptions controls how a Crawler behaves. The zero value is polite.
+type CrawlOptions struct {
+	// Client makes the crawler's requests. It should limit how fast
+	// requests are sent to each host, and not connect to internal
+	// services, as servers are found in peer lists anyone can write. The
+	// default sends at most one request a second to each host, only
+	// connects to public addresses and identifies the crawler in its
+	// User-Agent.
+	Client *http.Client
+
+	// Workers is how many servers are crawled at once, 8 by default.
+	Workers int
+
+	// Timeout bounds how long crawling one server takes, 30 seconds by
+	// default.
+	Timeout time.Duration
+
+	// Recrawl is how long to wait before crawling a server again, a day
+	// by default.
+	Recrawl time.Duration
+
+	// RetryBackoff is how long to wait before trying a server that failed
+	// again, an hour by default. It doubles with every failure in a row.
+	RetryBackoff time.Duration
+
+	// MaxInstances is how many servers Run crawls before it stops. Zero
+	// means no limit.
+	MaxInstances int
+
+	// NoPeers turns off fetching peer lists, so only servers that were
+	// already discovered are crawled.
+	NoPeers bool
+
+	// Skip, if set, says whether to leave a server alone, such as
+	// because it asked not to be crawled.
+	Skip func(domain string) bool
+}
+
+// Crawler walks the fediverse. It fetches node information from the servers
+// in a Store and adds the servers in their peer lists, as reported by the
+// Mastodon API, to be crawled next.
+//
+// Servers are only crawled again after Recrawl, and ones that fail are left
+// alone for longer and longer, so running the crawler often is cheap.
+type Crawler struct {
+	store *Store
+	opts  CrawlOptions
+
+	// now is time.Now, replaced in tests.
+	now func() time.Time
+}
+
+// NewCrawler makes a crawler that keeps track of servers in st.
+func NewCrawler(st *Store, opts CrawlOptions) *Crawler {
+	if opts.Client == nil {
+		opts.Client = &http.Client{Transport: useragent.NewTransport(useragent.Options{
+			Prefix:     crawlerName,
+			InfoURL:    crawlerInfoURL,
+			RateLimit:  1,
+			Burst:      2,
+			Retry:      &useragent.RetryPolicy{MaxRetries: 1, InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, MaxRetryAfter: 10 * time.Second},
+			Instrument: true,
+		}, publicTransport())}
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = 8
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	if opts.Recrawl == 0 {
+		opts.Recrawl = 24 * time.Hour
+	}
+	if opts.RetryBackoff == 0 {
+		opts.RetryBackoff = time.Hour
+	}
+
+	return &Crawler{store: st, opts: opts, now: time.Now}
+}
+
+// Run adds seeds to the store and crawls servers until none are due or
+// MaxInstances have been crawled. Servers discovered along the way are
+// crawled too. It only fails if the store does or ctx is cancelled;
+// servers that can't be crawled are noted in the store.
+func (c *Crawler) Run(ctx context.Context, seeds ...string) error {
+	if err := c.discover(ctx, seeds); err != nil {
+		return err
+	}
+
+	// Servers are rescheduled after this, so each one is crawled at most
+	// once per run.
+	start := c.now()
+
+	crawled := 0
+	for {
+		limit := c.opts.Workers * 4
+		if c.opts.MaxInstances != 0 {
+			limit = min(limit, c.opts.MaxInstances-crawled)
+		}
+		if limit <= 0 {
+			return nil
+		}
+
+		due, err := c.store.due(ctx, start, limit)
+		if err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			return nil
+		}
+
+		var g errgroup.Group
+		g.SetLimit(c.opts.Workers)
+		for _, domain := range due {
+			g.Go(func() error {
+				return c.crawl(ctx, domain)
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		crawled += len(due)
+	}
+}
+
+// discover adds the domains that can be crawled to the store.
+func (c *Crawler) discover(ctx context.Context, domains []string) error {
+	var valid []string
+	for _, d := range domains {
+		if d, ok := normalizeDomain(d); ok && (c.opts.Skip == nil || !c.opts.Skip(d)) {
+			valid = append(valid, d)
+		}
+	}
+
+	n, err := c.store.Discover(ctx, c.now(), valid...)
+	discovered.Add(float64(n))
+	return err
+}
+
+// crawl fetches a server's node information and peers. Only errors from
+// the store are returned.
+func (c *Crawler) crawl(ctx context.Context, domain string) error {
+	if c.opts.Skip != nil && c.opts.Skip(domain) {
+		crawls.WithLabelValues("skipped").Inc()
+		return c.store.recordFailure(ctx, domain, ErrSkipped, c.now(), c.opts.Recrawl)
+	}
+
+	node, peers, err := c.fetch(ctx, domain)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		slog.DebugContext(ctx, "can't crawl server", "domain", domain, "err", err)
+		crawls.WithLabelValues("error").Inc()
+		return c.store.recordFailure(ctx, domain, err, c.now(), c.opts.RetryBackoff)
+	}
+
+	crawls.WithLabelValues("ok").Inc()
+	if err := c.store.recordSuccess(ctx, domain, node, c.now(), c.opts.Recrawl); err != nil {
+		return err
+	}
+
+	return c.discover(ctx, peers)
+}
+
+func (c *Crawler) fetch(ctx context.Context, domain string) (*Node, []string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	node, err := FetchWithClient(ctx, c.opts.Client, "https://"+domain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.opts.NoPeers {
+		return node, nil, nil
+	}
+
+	mc, err := mastodon.Unauthenticated(crawlerName, crawlerInfoURL, "https://"+domain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Plenty of servers don't have the Mastodon API or hide their peers,
+	// which doesn't make their node information any less useful.
+	ps, err := mc.WithClient(c.opts.Client).InstancePeers(ctx)
+	if err != nil {
+		slog.DebugContext(ctx, "can't get peers", "domain", domain, "err", err)
+		return node, nil, nil
+	}
+
+	peers := make([]string, 0, len(ps))
+	for _, p := range ps {
+		peers = append(peers, string(p))
+	}
+
+	return node, peers, nil
+}
+
+// publicTransport is http.DefaultTransport, but it only connects to public
+// addresses. Checking the address being dialed, rather than the name,
+// also covers redirects and names that resolve to private addresses.
+func publicTransport() *http.Transport {
+	d := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   refusePrivate,
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.DialContext = d.DialContext
+	tr.Proxy = nil
+	return tr
+}
+
+// refusePrivate is a net.Dialer Control function that fails for addresses
+// on loopback, private or link-local networks.
+func refusePrivate(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, address)
+	}
+	addr = addr.Unmap()
+
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, address)
+	}
+
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, which isn't private
+// by netip's reckoning but isn't public either.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// normalizeDomain lowercases a domain from a peer list and checks that it
+// is a public DNS name, as servers list all kinds of junk.
+func normalizeDomain(domain string) (string, bool) {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+
+	if len(domain) == 0 || len(domain) > 253 || net.ParseIP(domain) != nil || !strings.Contains(domain, ".") {
+		return "", false
+	}
+
+	for _, suffix := range []string{".onion", ".i2p", ".local", ".localhost", ".internal", ".arpa", ".invalid", ".test"} {
+		if strings.HasSuffix(domain, suffix) {
+			return "", false
+		}
+	}
+
+	for label := range strings.SplitSeq(domain, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return "", false
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return "", false
+			}
+		}
+	}
+
+	return domain, true
+}
@@ -0,0 +1,288 @@
+package nodeinfo
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// fediverse pretends to be a few servers at once on a TLS test server,
+// which the returned client connects to whatever the host.
+func fediverse(t *testing.T, peers map[string][]string, software map[string]string) *http.Client {
+	t.Helper()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, _ := strings.Cut(r.Host, ":")
+		name, ok := software[host]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.URL.Path == "/api/v1/instance/peers" {
+			if ps, ok := peers[host]; ok {
+				json.NewEncoder(w).Encode(ps)
+				return
+			}
+			http.NotFound(w, r)
+			return
+		}
+
+		Handler("https://"+host, testProvider(name)).ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	tr := srv.Client().Transport.(*http.Transport).Clone()
+	tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	tr.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, network, srv.Listener.Addr().String())
+	}
+
+	return &http.Client{Transport: tr}
+}
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "nodeinfo.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	st, err := NewStore(t.Context(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return st
+}
+
+func TestCrawler(t *testing.T) {
+	cli := fediverse(t, map[string][]string{
+		"a.example": {"b.example", "C.Example.", "127.0.0.1", "secret.onion", "localhost", "d.example"},
+		"b.example": {"a.example", "c.example"},
+	}, map[string]string{
+		"a.example": "mastodon",
+		"b.example": "Mastodon",
+		"c.example": "gotosocial",
+	})
+
+	st := newTestStore(t)
+	cr := NewCrawler(st, CrawlOptions{Client: cli, Workers: 2})
+
+	now := time.Unix(1700000000, 0)
+	cr.now = func() time.Time { return now }
+
+	if err := cr.Run(t.Context(), "a.example"); err != nil {
+		t.Fatal(err)
+	}
+
+	insts, err := st.BySoftware(t.Context(), "MASTODON")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(insts) != 2 || insts[0].Domain != "a.example" || insts[1].Domain != "b.example" {
+		t.Errorf("got mastodon servers %v", insts)
+	}
+	if insts[0].Users != 42 || insts[0].ActiveMonth != 7 || !insts[0].OpenRegistrations || !insts[0].CrawledAt.Equal(now) {
+		t.Errorf("a.example has the wrong information: %+v", insts[0])
+	}
+
+	if insts, err := st.BySoftware(t.Context(), "gotosocial"); err != nil || len(insts) != 1 || insts[0].Domain != "c.example" {
+		t.Errorf("got gotosocial servers %v, %v", insts, err)
+	}
+
+	d, err := st.Get(t.Context(), "d.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Failures != 1 || d.LastError == "" || !d.CrawledAt.IsZero() {
+		t.Errorf("d.example should have failed once: %+v", d)
+	}
+
+	for _, domain := range []string{"127.0.0.1", "secret.onion", "localhost"} {
+		if _, err := st.Get(t.Context(), domain); !errors.Is(err, ErrUnknownInstance) {
+			t.Errorf("%s shouldn't have been discovered: %v", domain, err)
+		}
+	}
+
+	node, err := st.Node(t.Context(), "c.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.Version != "2.1" || node.Software.Name != "gotosocial" {
+		t.Errorf("got node information %+v", node)
+	}
+
+	// Nothing is due yet, so crawling again does nothing. d.example is tried
+	// again after an hour and then after two more.
+	for _, tt := range []struct {
+		after    time.Duration
+		failures int
+	}{
+		{30 * time.Minute, 1},
+		{time.Hour, 2},
+		{2 * time.Hour, 2},
+		{3 * time.Hour, 3},
+	} {
+		now = time.Unix(1700000000, 0).Add(tt.after)
+		if err := cr.Run(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+
+		d, err := st.Get(t.Context(), "d.example")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Failures != tt.failures {
+			t.Errorf("after %s: d.example failed %d times, want %d", tt.after, d.Failures, tt.failures)
+		}
+
+		a, err := st.Get(t.Context(), "a.example")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.CrawledAt.Equal(time.Unix(1700000000, 0)) {
+			t.Errorf("after %s: a.example was crawled again at %s", tt.after, a.CrawledAt)
+		}
+	}
+}
+
+func TestCrawlerLimits(t *testing.T) {
+	cli := fediverse(t, map[string][]string{
+		"a.example": {"b.example", "c.example"},
+	}, map[string]string{
+		"a.example": "mastodon",
+		"b.example": "mastodon",
+		"c.example": "mastodon",
+	})
+
+	t.Run("MaxInstances", func(t *testing.T) {
+		st := newTestStore(t)
+		cr := NewCrawler(st, CrawlOptions{Client: cli, Workers: 1, MaxInstances: 2})
+		if err := cr.Run(t.Context(), "a.example"); err != nil {
+			t.Fatal(err)
+		}
+
+		insts, err := st.BySoftware(t.Context(), "mastodon")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(insts) != 2 {
+			t.Errorf("crawled %v, want 2 servers", insts)
+		}
+	})
+
+	t.Run("NoPeers", func(t *testing.T) {
+		st := newTestStore(t)
+		cr := NewCrawler(st, CrawlOptions{Client: cli, NoPeers: true})
+		if err := cr.Run(t.Context(), "a.example"); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := st.Get(t.Context(), "b.example"); !errors.Is(err, ErrUnknownInstance) {
+			t.Errorf("b.example shouldn't have been discovered: %v", err)
+		}
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		st := newTestStore(t)
+		if _, err := st.Discover(t.Context(), time.Now(), "c.example"); err != nil {
+			t.Fatal(err)
+		}
+
+		cr := NewCrawler(st, CrawlOptions{Client: cli, Skip: func(domain string) bool { return domain != "a.example" }})
+		if err := cr.Run(t.Context(), "a.example"); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := st.Get(t.Context(), "b.example"); !errors.Is(err, ErrUnknownInstance) {
+			t.Errorf("b.example shouldn't have been discovered: %v", err)
+		}
+
+		c, err := st.Get(t.Context(), "c.example")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Software != "" || c.LastError != ErrSkipped.Error() {
+			t.Errorf("c.example should have been skipped: %+v", c)
+		}
+	})
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+		ok       bool
+	}{
+		{"pony.social", "pony.social", true},
+		{" Pony.Social. ", "pony.social", true},
+		{"xn--n3h.example", "xn--n3h.example", true},
+		{"", "", false},
+		{"localhost", "", false},
+		{"10.0.0.1", "", false},
+		{"::1", "", false},
+		{"mastodon.local", "", false},
+		{"hidden.onion", "", false},
+		{"-bad.example", "", false},
+		{"bad-.example", "", false},
+		{"a..example", "", false},
+		{"under_score.example", "", false},
+		{"pony.social:443", "", false},
+		{strings.Repeat("a", 64) + ".example", "", false},
+	} {
+		got, ok := normalizeDomain(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("normalizeDomain(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCrawlerRefusesPrivateAddresses(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	// A name that resolves to loopback is no better than the address.
+	u := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+
+	cr := NewCrawler(newTestStore(t), CrawlOptions{})
+	resp, err := cr.opts.Client.Get(u)
+	if err == nil {
+		resp.Body.Close()
+	}
+	if !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("got error %v, want %v", err, ErrPrivateAddress)
+	}
+
+	for addr, private := range map[string]bool{
+		"127.0.0.1:443":        true,
+		"10.1.2.3:443":         true,
+		"192.168.1.1:443":      true,
+		"169.254.169.254:80":   true,
+		"100.64.0.1:443":       true,
+		"[::1]:443":            true,
+		"[fe80::1]:443":        true,
+		"[fd00::1]:443":        true,
+		"[::ffff:10.0.0.1]:80": true,
+		"0.0.0.0:443":          true,
+		"93.184.215.14:443":    false,
+		"[2001:db8::1]:443":    false,
+	} {
+		if err := refusePrivate("tcp", addr, nil); (err != nil) != private {
+			t.Errorf("refusePrivate(%s) = %v, want private = %v", addr, err, private)
+		}
+	}
+}
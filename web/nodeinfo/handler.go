@@ -0,0 +1,81 @@
+package nodeinfo
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Provider returns the node information for this server. It is called for
+// every request, so cache anything expensive such as counting users.
+type Provider func(ctx context.Context) (*Node, error)
+
+// Handler publishes node information from provider at /.well-known/nodeinfo,
+// which links to the 2.0 and 2.1 documents at /nodeinfo/2.0 and
+// /nodeinfo/2.1. baseURL is the public URL of the server, such as
+// "https://pony.social", and is used for those links.
+//
+// The documents' Version is filled in, and fields that version 2.0 doesn't
+// have are left out of it.
+func Handler(baseURL string, provider Provider) http.Handler {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /.well-known/nodeinfo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, "application/json", wellKnownLinks{Links: []wellKnownLink{
+			{Rel: schema2point0, Href: baseURL + "/nodeinfo/2.0"},
+			{Rel: schema2point1, Href: baseURL + "/nodeinfo/2.1"},
+		}})
+	})
+
+	for version, schema := range map[string]string{"2.0": schema2point0, "2.1": schema2point1} {
+		mux.HandleFunc("GET /nodeinfo/"+version, func(w http.ResponseWriter, r *http.Request) {
+			node, err := provider(r.Context())
+			if err != nil {
+				slog.ErrorContext(r.Context(), "can't get node information", "err", err)
+				http.Error(w, "can't get node information", http.StatusInternalServerError)
+				return
+			}
+
+			writeJSON(w, `application/json; profile="`+schema+`#"`, document(*node, version))
+		})
+	}
+
+	return mux
+}
+
+// document makes the NodeInfo document of a version. The schemas require
+// lists and metadata to be there, even if they're empty.
+func document(node Node, version string) Node {
+	node.Version = version
+
+	if version == "2.0" {
+		node.Software.Repository, node.Software.Homepage = "", ""
+	}
+
+	if node.Protocols == nil {
+		node.Protocols = []string{}
+	}
+	if node.Services.Inbound == nil {
+		node.Services.Inbound = []string{}
+	}
+	if node.Services.Outbound == nil {
+		node.Services.Outbound = []string{}
+	}
+	if node.Metadata == nil {
+		node.Metadata = map[string]any{}
+	}
+
+	return node
+}
+
+func writeJSON(w http.ResponseWriter, contentType string, v any) {
+	w.Header().Set("Content-Type", contentType)
+	// Web clients on other servers read node information too.
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Cache-Control", "public, max-age=1800")
+	json.NewEncoder(w).Encode(v)
+}
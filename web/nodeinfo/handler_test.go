@@ -0,0 +1,84 @@
+package nodeinfo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testProvider(name string) Provider {
+	return func(ctx context.Context) (*Node, error) {
+		return &Node{
+			Software: Software{
+				Name:       name,
+				Version:    "1.0.0",
+				Repository: "https://github.com/Xe/x",
+			},
+			Protocols:        []string{"activitypub"},
+			OpenRegistration: true,
+			Usage:            Usage{Users: Users{Total: 42, ActiveMonth: 7}},
+		}, nil
+	}
+}
+
+func TestHandler(t *testing.T) {
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+	srv.Config.Handler = Handler(srv.URL+"/", testProvider("xesite"))
+
+	for _, tt := range []struct {
+		version    string
+		repository any
+	}{
+		{"2.0", nil},
+		{"2.1", "https://github.com/Xe/x"},
+	} {
+		resp, err := srv.Client().Get(srv.URL + "/nodeinfo/" + tt.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		if want := `application/json; profile="http://nodeinfo.diaspora.software/ns/schema/` + tt.version + `#"`; resp.Header.Get("Content-Type") != want {
+			t.Errorf("%s: got content type %q, want %q", tt.version, resp.Header.Get("Content-Type"), want)
+		}
+
+		var doc map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+			t.Fatal(err)
+		}
+
+		if doc["version"] != tt.version {
+			t.Errorf("%s: got version %v", tt.version, doc["version"])
+		}
+		if got := doc["software"].(map[string]any)["repository"]; got != tt.repository {
+			t.Errorf("%s: got repository %v, want %v", tt.version, got, tt.repository)
+		}
+		if inbound, ok := doc["services"].(map[string]any)["inbound"].([]any); !ok || len(inbound) != 0 {
+			t.Errorf("%s: services.inbound should be an empty list, got %v", tt.version, doc["services"])
+		}
+		if _, ok := doc["metadata"].(map[string]any); !ok {
+			t.Errorf("%s: metadata should be an object, got %v", tt.version, doc["metadata"])
+		}
+	}
+
+	node, err := FetchWithClient(t.Context(), srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if node.Version != "2.1" || node.Software.Name != "xesite" || node.Usage.Users.Total != 42 {
+		t.Errorf("fetched the wrong node information: %+v", node)
+	}
+
+	resp, err := srv.Client().Post(srv.URL+"/nodeinfo/2.0", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST got status %d", resp.StatusCode)
+	}
+}
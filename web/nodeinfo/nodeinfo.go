@@ -1,14 +1,18 @@
 // Package nodeinfo contains types and a simple client for reading the standard NodeInfo protocol described by Diaspora[1].
 //
-// This package supports version 2.0[2] because that is the one most commonly used.
+// This package supports versions 2.0[2] and 2.1[3] because those are the ones most commonly used.
+// Fetch reads node information from other servers, Handler publishes it for this one and
+// Crawler walks the network of servers to find out what software they run.
 //
 // [1]: http://nodeinfo.diaspora.software/
 // [2]: http://nodeinfo.diaspora.software/docson/index.html#/ns/schema/2.0#$$expand
+// [3]: http://nodeinfo.diaspora.software/docson/index.html#/ns/schema/2.1#$$expand
 package nodeinfo
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,24 +22,35 @@ import (
 	"within.website/x/web/useragent"
 )
 
-const schema2point0 = "http://nodeinfo.diaspora.software/ns/schema/2.0"
+const (
+	schema2point0 = "http://nodeinfo.diaspora.software/ns/schema/2.0"
+	schema2point1 = "http://nodeinfo.diaspora.software/ns/schema/2.1"
+)
+
 const twoMebibytes = 1024 * 1024 * 2
 
+// ErrInsecureLink is returned when a server's nodeinfo link isn't https.
+var ErrInsecureLink = errors.New("nodeinfo: nodeinfo link isn't https")
+
 // Node is the node information you are looking for.
 type Node struct {
 	Version          string   `json:"version"`
 	Software         Software `json:"software"`
 	Protocols        []string `json:"protocols"`
 	Services         Services `json:"services"`
-	OpenRegistration bool     `json:"openRegistration"`
+	OpenRegistration bool     `json:"openRegistrations"`
 	Usage            Usage    `json:"usage"`
 	Metadata         any      `json:"metadata"`
 }
 
 // Software contains metadata about the server software in use.
 type Software struct {
-	Name    string `json:"name"`
+	Name    string `json:"name"` // lowercase, such as "mastodon"
 	Version string `json:"version"`
+
+	// Repository and Homepage are only in version 2.1.
+	Repository string `json:"repository,omitempty"`
+	Homepage   string `json:"homepage,omitempty"`
 }
 
 // Services lists the third party services that this server can connect to with their application API.
@@ -53,7 +68,7 @@ type Usage struct {
 // Users contains statistics about the users of this server.
 type Users struct {
 	Total          int64 `json:"total"`
-	ActiveHalfYear int64 `json:"activeHalfYear"`
+	ActiveHalfYear int64 `json:"activeHalfyear"`
 	ActiveMonth    int64 `json:"activeMonth"`
 }
 
@@ -74,7 +89,7 @@ func FetchWithClient(ctx context.Context, cli *http.Client, nodeURL string) (*No
 	}
 
 	u.Path = "/.well-known/nodeinfo"
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("nodeinfo: can't create HTTP request: %w", err)
 	}
@@ -103,17 +118,29 @@ func FetchWithClient(ctx context.Context, cli *http.Client, nodeURL string) (*No
 
 	var targetURL string
 
+	// 2.1 is a superset of 2.0, so prefer it.
 	for _, link := range niw.Links {
-		if link.Rel == schema2point0 {
+		switch link.Rel {
+		case schema2point1:
 			targetURL = link.Href
+		case schema2point0:
+			if targetURL == "" {
+				targetURL = link.Href
+			}
 		}
 	}
 
 	if targetURL == "" {
-		return nil, fmt.Errorf("nodeinfo: can't find schema 2.0 nodeinfo for %s", nodeURL)
+		return nil, fmt.Errorf("nodeinfo: can't find schema 2.0 or 2.1 nodeinfo for %s", nodeURL)
+	}
+
+	// Servers can point anywhere, but at least make them point somewhere
+	// that says who it is.
+	if tu, err := url.Parse(targetURL); err != nil || tu.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %q", ErrInsecureLink, targetURL)
 	}
 
-	req, err = http.NewRequest(http.MethodGet, targetURL, nil)
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("nodeinfo: can't create HTTP request: %w", err)
 	}
@@ -123,6 +150,12 @@ func FetchWithClient(ctx context.Context, cli *http.Client, nodeURL string) (*No
 		return nil, fmt.Errorf("nodeinfo: can't read HTTP response: %w", err)
 	}
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, web.NewError(http.StatusOK, resp)
+	}
+
+	defer resp.Body.Close()
+
 	data, err = io.ReadAll(io.LimitReader(resp.Body, twoMebibytes))
 	if err != nil {
 		return nil, fmt.Errorf("nodeinfo: can't read from HTTP response body: %w", err)
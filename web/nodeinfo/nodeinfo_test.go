@@ -3,6 +3,7 @@ package nodeinfo
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -14,7 +15,7 @@ const nodeInfo2point0 = `{"version":"2.0","software":{"name":"mastodon","version
 
 func TestNodeInfo(t *testing.T) {
 	mux := http.NewServeMux()
-	s := httptest.NewServer(mux)
+	s := httptest.NewTLSServer(mux)
 	mux.HandleFunc("/.well-known/nodeinfo", func(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(wellKnownLinks{Links: []wellKnownLink{
 			{
@@ -35,3 +36,21 @@ func TestNodeInfo(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestNodeInfoInsecureLink(t *testing.T) {
+	mux := http.NewServeMux()
+	s := httptest.NewTLSServer(mux)
+	defer s.Close()
+	mux.HandleFunc("/.well-known/nodeinfo", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(wellKnownLinks{Links: []wellKnownLink{
+			{
+				Rel:  schema2point0,
+				Href: "http://169.254.169.254/latest/meta-data/",
+			},
+		}})
+	})
+
+	if _, err := FetchWithClient(t.Context(), s.Client(), s.URL); !errors.Is(err, ErrInsecureLink) {
+		t.Errorf("got error %v, want %v", err, ErrInsecureLink)
+	}
+}
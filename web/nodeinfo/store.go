@@ -0,0 +1,243 @@
+package nodeinfo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrUnknownInstance is returned when the store hasn't heard of a server.
+var ErrUnknownInstance = errors.New("nodeinfo: unknown instance")
+
+// maxBackoffDoublings bounds how many times the wait before trying a
+// failing server again doubles.
+const maxBackoffDoublings = 6
+
+// Instance is what a Store knows about a server.
+type Instance struct {
+	Domain string
+
+	// These are from the server's node information, and are empty until
+	// it is crawled.
+	Software          string
+	Version           string
+	OpenRegistrations bool
+	Users             int64
+	ActiveMonth       int64
+	ActiveHalfYear    int64
+	LocalPosts        int64
+
+	DiscoveredAt time.Time
+	CrawledAt    time.Time // zero if it has never been crawled
+	Failures     int       // failed crawls since the last one that worked
+	LastError    string
+}
+
+// Store keeps track of the servers a Crawler found in a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore creates the store's tables in db if they don't exist. The
+// database can be opened with any SQLite driver.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	for _, stmt := range []string{
+		`CREATE TABLE IF NOT EXISTS nodeinfo_instances
+			( domain             TEXT PRIMARY KEY
+			, software           TEXT NOT NULL DEFAULT ''
+			, version            TEXT NOT NULL DEFAULT ''
+			, open_registrations INTEGER NOT NULL DEFAULT 0
+			, users              INTEGER NOT NULL DEFAULT 0
+			, active_month       INTEGER NOT NULL DEFAULT 0
+			, active_half_year   INTEGER NOT NULL DEFAULT 0
+			, local_posts        INTEGER NOT NULL DEFAULT 0
+			, nodeinfo           TEXT
+			, discovered_at      INTEGER NOT NULL
+			, crawled_at         INTEGER
+			, next_crawl_at      INTEGER NOT NULL
+			, failures           INTEGER NOT NULL DEFAULT 0
+			, last_error         TEXT NOT NULL DEFAULT ''
+			)`,
+		`CREATE INDEX IF NOT EXISTS nodeinfo_instances_software ON nodeinfo_instances (software)`,
+		`CREATE INDEX IF NOT EXISTS nodeinfo_instances_next_crawl_at ON nodeinfo_instances (next_crawl_at)`,
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Discover adds servers that haven't been seen before, to be crawled
+// before any others. It returns how many of them were new.
+func (s *Store) Discover(ctx context.Context, now time.Time, domains ...string) (int, error) {
+	if len(domains) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO nodeinfo_instances (domain, discovered_at, next_crawl_at)
+		VALUES (?, ?, ?) ON CONFLICT (domain) DO NOTHING`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	result := 0
+	for _, domain := range domains {
+		res, err := stmt.ExecContext(ctx, domain, now.Unix(), 0)
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		result += int(n)
+	}
+
+	return result, tx.Commit()
+}
+
+// due returns up to limit servers that are due to be crawled at now, the
+// ones that have waited longest first.
+func (s *Store) due(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT domain FROM nodeinfo_instances
+		WHERE next_crawl_at <= ? ORDER BY next_crawl_at, domain LIMIT ?`, now.Unix(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var domain string
+		if err := rows.Scan(&domain); err != nil {
+			return nil, err
+		}
+		result = append(result, domain)
+	}
+
+	return result, rows.Err()
+}
+
+// recordSuccess stores a server's node information and schedules it to be
+// crawled again after recrawl, or a second if that's shorter.
+func (s *Store) recordSuccess(ctx context.Context, domain string, node *Node, now time.Time, recrawl time.Duration) error {
+	data, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `UPDATE nodeinfo_instances SET
+			software = ?, version = ?, open_registrations = ?,
+			users = ?, active_month = ?, active_half_year = ?, local_posts = ?,
+			nodeinfo = ?, crawled_at = ?, next_crawl_at = ?, failures = 0, last_error = ''
+		WHERE domain = ?`,
+		strings.ToLower(node.Software.Name), node.Software.Version, node.OpenRegistration,
+		node.Usage.Users.Total, node.Usage.Users.ActiveMonth, node.Usage.Users.ActiveHalfYear, node.Usage.LocalPosts,
+		string(data), now.Unix(), now.Add(max(recrawl, time.Second)).Unix(),
+		domain,
+	)
+	return err
+}
+
+// recordFailure notes that crawling a server failed. It is tried again
+// after backoff, which doubles with every failure in a row. Like with
+// recordSuccess, it waits for at least a second.
+func (s *Store) recordFailure(ctx context.Context, domain string, crawlErr error, now time.Time, backoff time.Duration) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE nodeinfo_instances SET
+			failures = failures + 1,
+			last_error = ?,
+			next_crawl_at = ? + ? * (1 << min(failures, ?))
+		WHERE domain = ?`,
+		crawlErr.Error(), now.Unix(), max(int64(backoff.Seconds()), 1), maxBackoffDoublings, domain,
+	)
+	return err
+}
+
+const instanceColumns = `domain, software, version, open_registrations, users, active_month,
+	active_half_year, local_posts, discovered_at, crawled_at, failures, last_error`
+
+func scanInstance(row interface{ Scan(...any) error }) (*Instance, error) {
+	var result Instance
+	var discoveredAt int64
+	var crawledAt sql.NullInt64
+
+	err := row.Scan(&result.Domain, &result.Software, &result.Version, &result.OpenRegistrations,
+		&result.Users, &result.ActiveMonth, &result.ActiveHalfYear, &result.LocalPosts,
+		&discoveredAt, &crawledAt, &result.Failures, &result.LastError)
+	if err != nil {
+		return nil, err
+	}
+
+	result.DiscoveredAt = time.Unix(discoveredAt, 0)
+	if crawledAt.Valid {
+		result.CrawledAt = time.Unix(crawledAt.Int64, 0)
+	}
+
+	return &result, nil
+}
+
+// Get returns what the store knows about a server.
+func (s *Store) Get(ctx context.Context, domain string) (*Instance, error) {
+	result, err := scanInstance(s.db.QueryRowContext(ctx, "SELECT "+instanceColumns+" FROM nodeinfo_instances WHERE domain = ?", domain))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownInstance, domain)
+	}
+
+	return result, err
+}
+
+// BySoftware returns the servers that run software, such as "mastodon" or
+// "gotosocial", the ones with the most users first.
+func (s *Store) BySoftware(ctx context.Context, software string) ([]Instance, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+instanceColumns+` FROM nodeinfo_instances
+		WHERE software = ? ORDER BY users DESC, domain`, strings.ToLower(software))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Instance
+	for rows.Next() {
+		inst, err := scanInstance(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *inst)
+	}
+
+	return result, rows.Err()
+}
+
+// Node returns the node information a server last published, or nil if it
+// has never been crawled.
+func (s *Store) Node(ctx context.Context, domain string) (*Node, error) {
+	var data sql.NullString
+	err := s.db.QueryRowContext(ctx, "SELECT nodeinfo FROM nodeinfo_instances WHERE domain = ?", domain).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownInstance, domain)
+	}
+	if err != nil || !data.Valid {
+		return nil, err
+	}
+
+	var result Node
+	if err := json.Unmarshal([]byte(data.String), &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
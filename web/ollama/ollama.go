@@ -40,6 +40,7 @@ type Param struct {
 	Type        string     `json:"type"`
 	Description string     `json:"description,omitempty"`
 	Enum        []string   `json:"enum,omitempty"`
+	Items       *Param     `json:"items,omitempty"`
 	Properties  Properties `json:"properties"`
 	Required    []string   `json:"required,omitempty"`
 }
@@ -136,6 +137,58 @@ func (c *Client) Chat(ctx context.Context, inp *CompleteRequest) (*CompleteRespo
 	return &result, nil
 }
 
+// ChatStream streams a chat completion, calling fn with each response as it
+// arrives. The last one has Done set. If fn returns an error, the stream is
+// stopped and the error is returned.
+func (c *Client) ChatStream(ctx context.Context, inp *CompleteRequest, fn func(CompleteResponse) error) error {
+	if inp.KeepAlive == "" {
+		inp.KeepAlive = (9999 * time.Minute).String()
+	}
+	inp.Stream = true
+
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(inp); err != nil {
+		return fmt.Errorf("ollama: error encoding request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/chat", buf)
+	if err != nil {
+		return fmt.Errorf("ollama: error creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/x-ndjson")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ollama: error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return web.NewError(http.StatusOK, resp)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var cr CompleteResponse
+		if err := dec.Decode(&cr); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("ollama: error decoding response: %w", err)
+		}
+
+		if err := fn(cr); err != nil {
+			return err
+		}
+
+		if cr.Done {
+			return nil
+		}
+	}
+}
+
 // HallucinateOpts contains the options for the Hallucinate function.
 type HallucinateOpts struct {
 	Model    string    `json:"model"`
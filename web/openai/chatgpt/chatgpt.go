@@ -2,22 +2,35 @@
 package chatgpt
 
 import (
-	"bytes"
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
-
-	"within.website/x/web"
 )
 
 type Request struct {
-	Model       string     `json:"model"`
-	Messages    []Message  `json:"messages"`
-	Functions   []Function `json:"functions,omitempty"`
-	Seed        *int       `json:"seed,omitempty"`
-	Temperature *float64   `json:"temperature,omitempty"`
+	Model         string         `json:"model"`
+	Messages      []Message      `json:"messages"`
+	Functions     []Function     `json:"functions,omitempty"`
+	Tools         []Tool         `json:"tools,omitempty"`
+	Seed          *int           `json:"seed,omitempty"`
+	Temperature   *float64       `json:"temperature,omitempty"`
+	Stream        bool           `json:"stream,omitempty"`
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+
+	// ResponseFormat asks for JSON output. See ResponseFormatFor.
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+}
+
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type Tool struct {
+	Type     string   `json:"type"` // "function"
+	Function Function `json:"function"`
 }
 
 type Function struct {
@@ -30,6 +43,7 @@ type Param struct {
 	Type        string     `json:"type"`
 	Description string     `json:"description,omitempty"`
 	Enum        []string   `json:"enum,omitempty"`
+	Items       *Param     `json:"items,omitempty"`
 	Properties  Properties `json:"properties"`
 	Required    []string   `json:"required,omitempty"`
 }
@@ -45,9 +59,15 @@ func (p Properties) MarshalJSON() ([]byte, error) {
 }
 
 type Message struct {
-	Role         string   `json:"role"`
-	Content      string   `json:"content"`
-	FunctionCall *Funcall `json:"function_call,omitempty"`
+	Role         string     `json:"role"`
+	Content      string     `json:"content"`
+	FunctionCall *Funcall   `json:"function_call,omitempty"`
+	ToolCalls    []ToolCall `json:"tool_calls,omitempty"`
+	ToolCallID   string     `json:"tool_call_id,omitempty"`
+
+	// Refusal is set instead of Content when the model won't produce
+	// structured output.
+	Refusal string `json:"refusal,omitempty"`
 }
 
 type Funcall struct {
@@ -55,6 +75,12 @@ type Funcall struct {
 	Arguments string `json:"arguments"`
 }
 
+type ToolCall struct {
+	ID       string  `json:"id"`
+	Type     string  `json:"type"` // "function"
+	Function Funcall `json:"function"`
+}
+
 func (m Message) ProxyFormat() string {
 	return fmt.Sprintf("%s\\ %s", strings.Title(m.Role), m.Content)
 }
@@ -80,10 +106,43 @@ type Response struct {
 	Choices []Choice `json:"choices"`
 }
 
+// StreamChunk is one server-sent event of a streamed completion.
+type StreamChunk struct {
+	ID      string         `json:"id"`
+	Object  string         `json:"object"`
+	Created int            `json:"created"`
+	Model   string         `json:"model"`
+	Choices []StreamChoice `json:"choices"`
+	Usage   *Usage         `json:"usage,omitempty"` // only on the last chunk
+}
+
+type StreamChoice struct {
+	Index        int         `json:"index"`
+	Delta        StreamDelta `json:"delta"`
+	FinishReason string      `json:"finish_reason"`
+}
+
+// StreamDelta is what a chunk adds to the message. Tool calls are split
+// over chunks and have to be put back together by Index.
+type StreamDelta struct {
+	Role      string          `json:"role,omitempty"`
+	Content   string          `json:"content,omitempty"`
+	Refusal   string          `json:"refusal,omitempty"`
+	ToolCalls []ToolCallDelta `json:"tool_calls,omitempty"`
+}
+
+type ToolCallDelta struct {
+	Index    int     `json:"index"`
+	ID       string  `json:"id,omitempty"`
+	Type     string  `json:"type,omitempty"`
+	Function Funcall `json:"function"`
+}
+
 type Client struct {
 	httpCli *http.Client
 	apiKey  string
 	baseURL string
+	retry   RetryPolicy
 }
 
 func (c Client) WithBaseURL(baseURL string) Client {
@@ -91,11 +150,18 @@ func (c Client) WithBaseURL(baseURL string) Client {
 	return c
 }
 
+// WithRetryPolicy changes how failed requests are retried.
+func (c Client) WithRetryPolicy(rp RetryPolicy) Client {
+	c.retry = rp
+	return c
+}
+
 func NewClient(apiKey string) Client {
 	return Client{
 		httpCli: &http.Client{},
 		apiKey:  apiKey,
 		baseURL: "https://api.openai.com",
+		retry:   DefaultRetryPolicy,
 	}
 }
 
@@ -104,33 +170,69 @@ func (c Client) Complete(ctx context.Context, r Request) (*Response, error) {
 		r.Model = "gpt-3.5-turbo"
 	}
 
-	data, err := json.Marshal(r)
+	resp, err := c.post(ctx, r, "application/json")
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/chat/completions", bytes.NewBuffer(data))
-	if err != nil {
-		return nil, fmt.Errorf("chatgpt: [unexpected] can't make request???: %w", err)
+	var result Response
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("chatgpt: can't decode result: %w", err)
 	}
 
-	req.Header.Add("Authorization", "Bearer "+c.apiKey)
-	req.Header.Add("Content-Type", "application/json")
+	recordUsage(r.Model, result.Usage)
 
-	resp, err := c.httpCli.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("chatgpt: can't reach API: %w", err)
+	return &result, nil
+}
+
+// CompleteStream streams a completion, calling fn with each chunk as it
+// arrives. If fn returns an error, the stream is stopped and the error is
+// returned. Use an Accumulator to put the chunks back together.
+//
+// Requests are only retried until the stream starts.
+func (c Client) CompleteStream(ctx context.Context, r Request, fn func(StreamChunk) error) error {
+	if r.Model == "" {
+		r.Model = "gpt-3.5-turbo"
 	}
+	r.Stream = true
+	r.StreamOptions = &StreamOptions{IncludeUsage: true}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, web.NewError(http.StatusOK, resp)
+	resp, err := c.post(ctx, r, "text/event-stream")
+	if err != nil {
+		return err
 	}
 	defer resp.Body.Close()
 
-	var result Response
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("chatgpt: can't decode result: %w", err)
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		data, ok := strings.CutPrefix(sc.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			return nil
+		}
+
+		var chunk StreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("chatgpt: can't decode stream chunk: %w", err)
+		}
+
+		if chunk.Usage != nil {
+			recordUsage(r.Model, *chunk.Usage)
+		}
+
+		if err := fn(chunk); err != nil {
+			return err
+		}
 	}
 
-	return &result, nil
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("chatgpt: can't read stream: %w", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,282 @@
+package chatgpt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"within.website/x/web"
+)
+
+var testRetryPolicy = RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+func newTestClient(t *testing.T, h http.HandlerFunc) Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	return NewClient("hunter2").WithBaseURL(srv.URL).WithRetryPolicy(testRetryPolicy)
+}
+
+func writeSSE(w http.ResponseWriter, chunks ...StreamChunk) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	for _, chunk := range chunks {
+		data, _ := json.Marshal(chunk)
+		fmt.Fprintf(w, "data: %s\n\n", data)
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+}
+
+func deltaChunk(d StreamDelta, finish string) StreamChunk {
+	return StreamChunk{ID: "chatcmpl-1", Model: "gpt-test", Choices: []StreamChoice{{Delta: d, FinishReason: finish}}}
+}
+
+func toolDelta(index int, id, name, args string) StreamDelta {
+	return StreamDelta{ToolCalls: []ToolCallDelta{{Index: index, ID: id, Function: Funcall{Name: name, Arguments: args}}}}
+}
+
+func TestStreamAccumulate(t *testing.T) {
+	cli := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.Stream || req.StreamOptions == nil || !req.StreamOptions.IncludeUsage {
+			t.Errorf("streaming wasn't asked for: %+v", req)
+		}
+
+		writeSSE(w,
+			deltaChunk(StreamDelta{Role: "assistant", Content: "Let me "}, ""),
+			deltaChunk(StreamDelta{Content: "check."}, ""),
+			deltaChunk(toolDelta(0, "call_1", "weather", ""), ""),
+			deltaChunk(toolDelta(0, "", "", `{"city":`), ""),
+			deltaChunk(toolDelta(0, "", "", `"Ottawa"}`), ""),
+			deltaChunk(toolDelta(1, "call_2", "time", `{"tz":"America/Toronto"}`), ""),
+			deltaChunk(StreamDelta{}, "tool_calls"),
+			StreamChunk{Model: "gpt-test", Usage: &Usage{PromptTokens: 12, CompletionTokens: 34, TotalTokens: 46}},
+		)
+	})
+
+	before := testutil.ToFloat64(tokens.WithLabelValues("stream-model", "completion"))
+
+	var acc Accumulator
+	var finished [][]string
+	if err := cli.CompleteStream(t.Context(), Request{Model: "stream-model"}, func(chunk StreamChunk) error {
+		var names []string
+		for _, tc := range acc.Add(chunk) {
+			if !json.Valid([]byte(tc.Function.Arguments)) {
+				t.Errorf("%s finished with incomplete arguments %q", tc.Function.Name, tc.Function.Arguments)
+			}
+			names = append(names, tc.Function.Name)
+		}
+		if names != nil {
+			finished = append(finished, names)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first call is finished when the second starts, and the second
+	// when the choice is.
+	if want := [][]string{{"weather"}, {"time"}}; fmt.Sprint(finished) != fmt.Sprint(want) {
+		t.Errorf("tool calls finished as %v, want %v", finished, want)
+	}
+
+	resp := acc.Response()
+	if len(resp.Choices) != 1 {
+		t.Fatalf("got %d choices", len(resp.Choices))
+	}
+
+	msg := resp.Choices[0].Message
+	if msg.Role != "assistant" || msg.Content != "Let me check." || resp.Choices[0].FinishReason != "tool_calls" {
+		t.Errorf("wrong message: %+v", resp.Choices[0])
+	}
+	if len(msg.ToolCalls) != 2 || msg.ToolCalls[0].ID != "call_1" || msg.ToolCalls[0].Function.Arguments != `{"city":"Ottawa"}` || msg.ToolCalls[1].Type != "function" {
+		t.Errorf("wrong tool calls: %+v", msg.ToolCalls)
+	}
+	if resp.Usage.TotalTokens != 46 || resp.Model != "gpt-test" || resp.ID != "chatcmpl-1" {
+		t.Errorf("wrong response details: %+v", resp)
+	}
+
+	if got := testutil.ToFloat64(tokens.WithLabelValues("stream-model", "completion")) - before; got != 34 {
+		t.Errorf("recorded %v completion tokens, want 34", got)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		statuses []int
+		header   http.Header
+		attempts int32
+		wantCode int
+	}{
+		{name: "rate limited", statuses: []int{429, 429, 200}, header: http.Header{"Retry-After": {"0"}}, attempts: 3},
+		{name: "retry-after-ms", statuses: []int{503, 200}, header: http.Header{"Retry-After-Ms": {"5"}}, attempts: 2},
+		{name: "retry-after too long", statuses: []int{429, 200}, header: http.Header{"Retry-After": {"3600"}}, attempts: 1, wantCode: 429},
+		{name: "server error", statuses: []int{500, 502, 200}, attempts: 3},
+		{name: "give up", statuses: []int{503, 503, 503, 503}, attempts: 3, wantCode: 503},
+		{name: "bad request", statuses: []int{400, 200}, attempts: 1, wantCode: 400},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			cli := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				if code := tt.statuses[n-1]; code != http.StatusOK {
+					for k, v := range tt.header {
+						w.Header()[k] = v
+					}
+					http.Error(w, `{"error":{"message":"try again"}}`, code)
+					return
+				}
+
+				json.NewEncoder(w).Encode(Response{Model: "gpt-test", Choices: []Choice{{Message: Message{Role: "assistant", Content: "hi"}}}})
+			})
+
+			resp, err := cli.Complete(t.Context(), Request{Model: "retry-model"})
+
+			if got := attempts.Load(); got != tt.attempts {
+				t.Errorf("made %d attempts, want %d", got, tt.attempts)
+			}
+
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if resp.Choices[0].Message.Content != "hi" {
+					t.Errorf("wrong response: %+v", resp)
+				}
+				return
+			}
+
+			var werr *web.Error
+			if !errors.As(err, &werr) || werr.GotStatus != tt.wantCode {
+				t.Errorf("want a web.Error with status %d, got: %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		header http.Header
+		want   time.Duration
+		ok     bool
+	}{
+		{header: http.Header{}},
+		{header: http.Header{"Retry-After": {"7"}}, want: 7 * time.Second, ok: true},
+		{header: http.Header{"Retry-After": {now.Add(time.Minute).Format(http.TimeFormat)}}, want: time.Minute, ok: true},
+		{header: http.Header{"Retry-After": {now.Add(-time.Minute).Format(http.TimeFormat)}}, want: 0, ok: true},
+		{header: http.Header{"Retry-After": {"7"}, "Retry-After-Ms": {"1500"}}, want: 1500 * time.Millisecond, ok: true},
+		{header: http.Header{"Retry-After": {"soon"}}},
+	} {
+		got, ok := retryAfter(tt.header, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%v) = %v, %v; want %v, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+type Weather struct {
+	City        string   `json:"city" jsonschema:"The city the forecast is for"`
+	Temperature float64  `json:"temperature"`
+	Conditions  []string `json:"conditions"`
+}
+
+type Loose struct {
+	City  string `json:"city"`
+	Notes string `json:"notes,omitempty"`
+}
+
+func TestResponseFormatFor(t *testing.T) {
+	rf, err := ResponseFormatFor[Weather]("weather report")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rf.Type != "json_schema" || rf.JSONSchema.Name != "weather_report" || !rf.JSONSchema.Strict {
+		t.Errorf("wrong response format: %+v", rf.JSONSchema)
+	}
+
+	schema := rf.JSONSchema.Schema
+	if want := []string{"city", "temperature", "conditions"}; !slices.Equal(schema.Required, want) {
+		t.Errorf("required = %v, want %v", schema.Required, want)
+	}
+	if schema.Properties["city"].Description != "The city the forecast is for" {
+		t.Errorf("description wasn't taken from the jsonschema tag: %+v", schema.Properties["city"])
+	}
+
+	loose, err := ResponseFormatFor[Loose]("loose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loose.JSONSchema.Strict {
+		t.Error("a schema with optional fields can't be strict")
+	}
+
+	if _, err := ResponseFormatFor[map[int]string]("bad"); err == nil {
+		t.Error("want an error for a type that can't be a schema")
+	}
+}
+
+func TestCompleteJSON(t *testing.T) {
+	refuse := false
+	cli := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.ResponseFormat == nil || req.ResponseFormat.JSONSchema.Name != "Weather" {
+			t.Errorf("wrong response format: %+v", req.ResponseFormat)
+		}
+
+		msg := Message{Role: "assistant", Content: `{"city":"Ottawa","temperature":-12.5,"conditions":["snow"]}`}
+		if refuse {
+			msg = Message{Role: "assistant", Refusal: "I can't talk about the weather."}
+		}
+		json.NewEncoder(w).Encode(Response{Model: "gpt-test", Choices: []Choice{{Message: msg}}})
+	})
+
+	weather, _, err := CompleteJSON[Weather](t.Context(), cli, Request{Model: "gpt-test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weather.City != "Ottawa" || weather.Temperature != -12.5 || !slices.Equal(weather.Conditions, []string{"snow"}) {
+		t.Errorf("wrong weather: %+v", weather)
+	}
+
+	refuse = true
+	if _, _, err := CompleteJSON[Weather](t.Context(), cli, Request{Model: "gpt-test"}); !errors.Is(err, ErrRefusal) {
+		t.Errorf("want ErrRefusal, got: %v", err)
+	}
+}
+
+func TestStreamBadIndex(t *testing.T) {
+	var acc Accumulator
+	acc.Add(deltaChunk(StreamDelta{Content: "ok"}, ""))
+
+	for _, chunk := range []StreamChunk{
+		{Choices: []StreamChoice{{Index: -1, Delta: StreamDelta{Content: "bad"}}}},
+		{Choices: []StreamChoice{{Index: 1 << 30, Delta: StreamDelta{Content: "bad"}}}},
+		deltaChunk(toolDelta(-1, "call_1", "weather", "{}"), ""),
+		deltaChunk(toolDelta(1<<30, "call_1", "weather", "{}"), ""),
+	} {
+		acc.Add(chunk)
+	}
+
+	if err := acc.Err(); !errors.Is(err, ErrBadStreamIndex) {
+		t.Errorf("got error %v, want %v", err, ErrBadStreamIndex)
+	}
+
+	resp := acc.Response()
+	if len(resp.Choices) != 1 || resp.Choices[0].Message.Content != "ok" || len(resp.Choices[0].Message.ToolCalls) != 0 {
+		t.Errorf("bad chunks weren't dropped: %+v", resp.Choices)
+	}
+}
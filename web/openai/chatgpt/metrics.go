@@ -0,0 +1,27 @@
+package chatgpt
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	tokens = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "within_website_x",
+		Subsystem: "chatgpt",
+		Name:      "tokens_total",
+		Help:      "Tokens used by chat completions, by the model asked for and whether they were in the prompt or the completion.",
+	}, []string{"model", "kind"})
+
+	retries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "within_website_x",
+		Subsystem: "chatgpt",
+		Name:      "retries_total",
+		Help:      "Chat completion requests that were retried, by model and the status code or \"unreachable\".",
+	}, []string{"model", "reason"})
+)
+
+func recordUsage(model string, u Usage) {
+	tokens.WithLabelValues(model, "prompt").Add(float64(u.PromptTokens))
+	tokens.WithLabelValues(model, "completion").Add(float64(u.CompletionTokens))
+}
@@ -0,0 +1,148 @@
+package chatgpt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"within.website/x/web"
+)
+
+// RetryPolicy controls how requests are retried when the API is rate
+// limited (429), has a server error (5xx) or can't be reached.
+//
+// The wait between attempts grows exponentially from InitialBackoff up to
+// MaxBackoff, unless the API says how long to wait with a Retry-After
+// header, in which case that is used instead. If the API asks for a wait
+// longer than MaxRetryAfter, the request fails right away.
+type RetryPolicy struct {
+	MaxRetries     int // 0 means requests are only tried once
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	MaxRetryAfter  time.Duration // 0 means DefaultRetryPolicy's
+}
+
+// DefaultRetryPolicy is what NewClient uses.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	MaxRetryAfter:  time.Minute,
+}
+
+func (rp RetryPolicy) maxRetryAfter() time.Duration {
+	if rp.MaxRetryAfter != 0 {
+		return rp.MaxRetryAfter
+	}
+	return DefaultRetryPolicy.MaxRetryAfter
+}
+
+func (rp RetryPolicy) backOff() backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = 0 // MaxRetries limits retries
+
+	if rp.InitialBackoff != 0 {
+		bo.InitialInterval = rp.InitialBackoff
+	}
+	if rp.MaxBackoff != 0 {
+		bo.MaxInterval = rp.MaxBackoff
+	}
+
+	bo.Reset()
+	return bo
+}
+
+func retryable(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// retryAfter returns how long the API asked to wait before retrying. OpenAI
+// sends retry-after-ms as well as the standard Retry-After, which can be a
+// number of seconds or a date.
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	if ms, err := strconv.ParseFloat(h.Get("Retry-After-Ms"), 64); err == nil && ms >= 0 {
+		return time.Duration(ms * float64(time.Millisecond)), true
+	}
+
+	ra := h.Get("Retry-After")
+	if ra == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(ra); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(ra); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// post sends r to the chat completions endpoint, retrying as the client's
+// retry policy says. The caller must close the response body.
+func (c Client) post(ctx context.Context, r Request, accept string) (*http.Response, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	bo := c.retry.backOff()
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/chat/completions", bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("chatgpt: [unexpected] can't make request???: %w", err)
+		}
+
+		req.Header.Add("Authorization", "Bearer "+c.apiKey)
+		req.Header.Add("Content-Type", "application/json")
+		req.Header.Add("Accept", accept)
+
+		resp, err := c.httpCli.Do(req)
+
+		var reason string
+		wait := bo.NextBackOff()
+
+		switch {
+		case err != nil:
+			if ctx.Err() != nil || attempt >= c.retry.MaxRetries {
+				return nil, fmt.Errorf("chatgpt: can't reach API: %w", err)
+			}
+			reason = "unreachable"
+		case resp.StatusCode == http.StatusOK:
+			return resp, nil
+		case !retryable(resp.StatusCode) || attempt >= c.retry.MaxRetries:
+			return nil, web.NewError(http.StatusOK, resp)
+		default:
+			reason = strconv.Itoa(resp.StatusCode)
+			if ra, ok := retryAfter(resp.Header, time.Now()); ok {
+				if ra > c.retry.maxRetryAfter() {
+					return nil, web.NewError(http.StatusOK, resp)
+				}
+				wait = ra
+			}
+
+			// Reading the body lets the connection be reused.
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+		}
+
+		retries.WithLabelValues(r.Model, reason).Inc()
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package chatgpt
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBadStreamIndex is reported by Accumulator.Err when a chunk names a
+// choice or tool call that can't exist.
+var ErrBadStreamIndex = errors.New("chatgpt: stream chunk index out of range")
+
+// Streams can't have more choices or tool calls per choice than these, so
+// a broken or hostile server can't make an Accumulator allocate without
+// bound.
+const (
+	maxStreamChoices   = 128
+	maxStreamToolCalls = 128
+)
+
+// Accumulator puts the chunks of a streamed completion back together into
+// a Response. The zero value is ready to use.
+//
+// Tool calls arrive in fragments: the first has the call's ID and function
+// name and the rest add to its arguments. A call is finished once the model
+// moves on to the next call or the choice has a finish reason, and only
+// then are its arguments valid JSON.
+//
+// Chunks with a choice or tool call index out of range are dropped, and Err
+// reports the first of them.
+type Accumulator struct {
+	resp Response
+
+	// finished is how many tool calls of each choice have been returned
+	// from Add.
+	finished []int
+
+	err error
+}
+
+// Add adds a chunk to the response and returns the tool calls that it
+// finished, if any.
+func (a *Accumulator) Add(chunk StreamChunk) []ToolCall {
+	if chunk.ID != "" {
+		a.resp.ID = chunk.ID
+	}
+	if chunk.Model != "" {
+		a.resp.Model = chunk.Model
+	}
+	if chunk.Created != 0 {
+		a.resp.Created = chunk.Created
+	}
+	if chunk.Usage != nil {
+		a.resp.Usage = *chunk.Usage
+	}
+	a.resp.Object = "chat.completion"
+
+	var result []ToolCall
+
+	for _, sc := range chunk.Choices {
+		if sc.Index < 0 || sc.Index >= maxStreamChoices {
+			a.fail(fmt.Errorf("%w: choice %d", ErrBadStreamIndex, sc.Index))
+			continue
+		}
+
+		for len(a.resp.Choices) <= sc.Index {
+			a.resp.Choices = append(a.resp.Choices, Choice{Index: len(a.resp.Choices)})
+			a.finished = append(a.finished, 0)
+		}
+		ch := &a.resp.Choices[sc.Index]
+
+		if sc.Delta.Role != "" {
+			ch.Message.Role = sc.Delta.Role
+		}
+		ch.Message.Content += sc.Delta.Content
+		ch.Message.Refusal += sc.Delta.Refusal
+
+		for _, tcd := range sc.Delta.ToolCalls {
+			if tcd.Index < 0 || tcd.Index >= maxStreamToolCalls {
+				a.fail(fmt.Errorf("%w: tool call %d of choice %d", ErrBadStreamIndex, tcd.Index, sc.Index))
+				continue
+			}
+
+			// Starting a call means the ones before it are done.
+			result = append(result, a.finish(sc.Index, tcd.Index)...)
+
+			for len(ch.Message.ToolCalls) <= tcd.Index {
+				ch.Message.ToolCalls = append(ch.Message.ToolCalls, ToolCall{Type: "function"})
+			}
+			tc := &ch.Message.ToolCalls[tcd.Index]
+
+			if tcd.ID != "" {
+				tc.ID = tcd.ID
+			}
+			if tcd.Type != "" {
+				tc.Type = tcd.Type
+			}
+			tc.Function.Name += tcd.Function.Name
+			tc.Function.Arguments += tcd.Function.Arguments
+		}
+
+		if sc.FinishReason != "" {
+			ch.FinishReason = sc.FinishReason
+			result = append(result, a.finish(sc.Index, len(ch.Message.ToolCalls))...)
+		}
+	}
+
+	return result
+}
+
+// fail records err unless an earlier chunk already failed.
+func (a *Accumulator) fail(err error) {
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+// Err returns the error of the first chunk that was dropped, if any.
+func (a *Accumulator) Err() error {
+	return a.err
+}
+
+// finish marks the tool calls of a choice before index as finished and
+// returns the ones that weren't already.
+func (a *Accumulator) finish(choice, index int) []ToolCall {
+	calls := a.resp.Choices[choice].Message.ToolCalls
+	index = min(index, len(calls))
+
+	if a.finished[choice] >= index {
+		return nil
+	}
+
+	result := calls[a.finished[choice]:index:index]
+	a.finished[choice] = index
+	return result
+}
+
+// Response returns the response put together so far.
+func (a *Accumulator) Response() *Response {
+	result := a.resp
+	return &result
+}
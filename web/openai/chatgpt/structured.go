@@ -0,0 +1,121 @@
+package chatgpt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+var (
+	ErrNoChoices = errors.New("chatgpt: response has no choices")
+	ErrRefusal   = errors.New("chatgpt: model refused to answer")
+)
+
+// ResponseFormat asks the model to answer in a particular format.
+type ResponseFormat struct {
+	Type       string      `json:"type"` // "text", "json_object" or "json_schema"
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+// JSONSchema describes the JSON the model has to answer with.
+type JSONSchema struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Schema      *jsonschema.Schema `json:"schema"`
+	Strict      bool               `json:"strict,omitempty"`
+}
+
+var schemaName = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// ResponseFormatFor asks for JSON that decodes into a T. The schema is made
+// the same way as MCP tool inputs: property names come from json tags and
+// descriptions from jsonschema tags.
+//
+// The model is held to the schema strictly if it can be. OpenAI only allows
+// that if every field is required, so fields tagged omitempty or omitzero
+// and map types make the schema a suggestion instead.
+func ResponseFormatFor[T any](name string) (*ResponseFormat, error) {
+	schema, err := jsonschema.For[T](nil)
+	if err != nil {
+		return nil, fmt.Errorf("chatgpt: can't make schema: %w", err)
+	}
+
+	return &ResponseFormat{
+		Type: "json_schema",
+		JSONSchema: &JSONSchema{
+			Name:   schemaName.ReplaceAllString(name, "_"),
+			Schema: schema,
+			Strict: strictable(schema),
+		},
+	}, nil
+}
+
+// strictable reports whether a schema follows the rules for strict
+// structured outputs: every object has all of its properties required and
+// no additional properties.
+func strictable(s *jsonschema.Schema) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Type == "object" {
+		// Structs disallow additional properties with a schema that
+		// matches nothing. Anything else is a map.
+		if s.AdditionalProperties == nil || s.AdditionalProperties.Not == nil {
+			return false
+		}
+		if len(s.Required) != len(s.Properties) {
+			return false
+		}
+	}
+
+	for _, prop := range s.Properties {
+		if !strictable(prop) {
+			return false
+		}
+	}
+
+	return strictable(s.Items)
+}
+
+// CompleteJSON asks for a completion that decodes into a T and decodes the
+// first choice. The schema is named after T. It returns ErrRefusal if the
+// model refuses to answer.
+func CompleteJSON[T any](ctx context.Context, c Client, r Request) (*T, *Response, error) {
+	name := reflect.TypeFor[T]().Name()
+	if name == "" {
+		name = "response"
+	}
+
+	rf, err := ResponseFormatFor[T](name)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.ResponseFormat = rf
+
+	resp, err := c.Complete(ctx, r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, resp, ErrNoChoices
+	}
+
+	msg := resp.Choices[0].Message
+	if msg.Refusal != "" {
+		return nil, resp, fmt.Errorf("%w: %s", ErrRefusal, msg.Refusal)
+	}
+
+	var result T
+	if err := json.Unmarshal([]byte(msg.Content), &result); err != nil {
+		return nil, resp, fmt.Errorf("chatgpt: can't decode structured output: %w", err)
+	}
+
+	return &result, resp, nil
+}
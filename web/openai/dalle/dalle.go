@@ -12,10 +12,18 @@ import (
 
 type Client struct {
 	apiKey string
+	cli    *http.Client
 }
 
 func New(apiKey string) Client {
-	return Client{apiKey: apiKey}
+	return Client{apiKey: apiKey, cli: http.DefaultClient}
+}
+
+// WithClient makes the client send requests through cli, such as one made
+// by useragent.NewClient.
+func (c Client) WithClient(cli *http.Client) Client {
+	c.cli = cli
+	return c
 }
 
 type Model string
@@ -44,18 +52,31 @@ const (
 	StyleNatural = Style("natural")
 )
 
+type ResponseFormat string
+
+const (
+	// FormatURL links to images that expire an hour after they are made.
+	FormatURL = ResponseFormat("url")
+
+	// FormatB64JSON puts the images in the response, base64 encoded.
+	FormatB64JSON = ResponseFormat("b64_json")
+)
+
 type Options struct {
-	Model   Model   `json:"model"`
-	Prompt  string  `json:"prompt"`
-	N       *int    `json:"n,omitempty"`
-	Quality *string `json:"quality,omitempty"`
-	Size    *Size   `json:"size"`
-	Style   *Style  `json:"style,omitempty"`
-	User    *string `json:"user,omitempty"`
+	Model          Model           `json:"model"`
+	Prompt         string          `json:"prompt"`
+	N              *int            `json:"n,omitempty"`
+	Quality        *string         `json:"quality,omitempty"`
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	Size           *Size           `json:"size"`
+	Style          *Style          `json:"style,omitempty"`
+	User           *string         `json:"user,omitempty"`
 }
 
 type Image struct {
-	URL string `json:"url"`
+	URL           string `json:"url,omitempty"`
+	B64JSON       []byte `json:"b64_json,omitempty"`
+	RevisedPrompt string `json:"revised_prompt,omitempty"`
 }
 
 func (i Image) LogValue() slog.Value {
@@ -78,7 +99,12 @@ func (r Response) LogValue() slog.Value {
 
 func (c Client) Do(req *http.Request) (*http.Response, error) {
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	return http.DefaultClient.Do(req)
+
+	cli := c.cli
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+	return cli.Do(req)
 }
 
 func (c Client) GenerateImage(ctx context.Context, opts Options) (*Response, error) {
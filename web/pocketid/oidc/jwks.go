@@ -0,0 +1,107 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"within.website/x/web/pocketid"
+)
+
+// minRefetch is how often the key set may be fetched again because a token
+// used a key that isn't in it, so that forged key IDs can't be used to make
+// the relying party hammer the provider.
+const minRefetch = time.Minute
+
+// keyCache caches the provider's signing keys. Keys are fetched again when
+// they are older than ttl or a token uses a key ID that isn't known yet,
+// such as after the provider rotates its keys.
+type keyCache struct {
+	cli *http.Client
+	url string
+	ttl time.Duration
+	now func() time.Time
+
+	lock      sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func (kc *keyCache) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	kc.lock.Lock()
+	defer kc.lock.Unlock()
+
+	now := kc.now()
+	key, ok := kc.keys[kid]
+	stale := now.Sub(kc.fetchedAt) > kc.ttl
+
+	if ok && !stale {
+		return key, nil
+	}
+
+	if !stale && now.Sub(kc.fetchedAt) < minRefetch {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+	}
+
+	if err := kc.fetch(ctx); err != nil {
+		if ok {
+			// Better to use a key that was good recently than to lock
+			// everyone out while the provider is down.
+			return key, nil
+		}
+		return nil, err
+	}
+
+	key, ok = kc.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+	}
+
+	return key, nil
+}
+
+func (kc *keyCache) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, kc.url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := kc.cli.Do(req)
+	if err != nil {
+		return fmt.Errorf("oidc: can't fetch keys: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: can't fetch keys: unexpected status code: %d", resp.StatusCode)
+	}
+
+	var jwks pocketid.JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return fmt.Errorf("oidc: can't decode keys: %w", err)
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		pub, err := k.PublicKey()
+		if err != nil {
+			// Skip keys we can't use rather than failing outright.
+			continue
+		}
+		keys[k.KeyID] = pub
+	}
+
+	kc.keys = keys
+	kc.fetchedAt = kc.now()
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// SessionCookie holds the user's ID token.
+	SessionCookie = "pocketid-session"
+
+	// loginCookie holds the state of a login in progress.
+	loginCookie = "pocketid-login"
+
+	// loginTimeout is how long users have to log in.
+	loginTimeout = 10 * time.Minute
+)
+
+type ctxKey struct{}
+
+// ClaimsFrom returns the claims of the user who made a request that went
+// through Middleware.
+func ClaimsFrom(ctx context.Context) (*Claims, bool) {
+	c, ok := ctx.Value(ctxKey{}).(*Claims)
+	return c, ok
+}
+
+// loginState is what the relying party remembers about a login in
+// progress. It is kept in a cookie that only lives until the user comes
+// back.
+type loginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	ReturnTo string `json:"return_to"`
+}
+
+// Middleware makes users log in before they can get to next. Requests with
+// a valid session cookie or an "Authorization: Bearer" ID token go through
+// with their claims in the request context. Other GET requests are sent to
+// Pocket ID to log in and everything else is refused with 401
+// Unauthorized. Users who aren't in one of the allowed groups get 403
+// Forbidden.
+//
+// Middleware handles requests to the path of the redirect URL itself.
+func (rp *RelyingParty) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == rp.redirect.Path {
+			rp.callback(w, r)
+			return
+		}
+
+		raw := bearerToken(r)
+		if raw == "" {
+			if c, err := r.Cookie(SessionCookie); err == nil {
+				raw = c.Value
+			}
+		}
+
+		if raw != "" {
+			claims, err := rp.Verify(r.Context(), raw, "")
+			switch {
+			case err == nil && !rp.allowed(claims):
+				http.Error(w, "you aren't allowed to use this", http.StatusForbidden)
+				return
+			case err == nil:
+				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKey{}, claims)))
+				return
+			case !errors.Is(err, ErrInvalidToken):
+				slog.ErrorContext(r.Context(), "can't verify ID token", "err", err)
+				http.Error(w, "can't verify your session, try again later", http.StatusBadGateway)
+				return
+			}
+		}
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="pocketid"`)
+			http.Error(w, "you need to log in", http.StatusUnauthorized)
+			return
+		}
+
+		rp.login(w, r)
+	})
+}
+
+func bearerToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if len(auth) > 7 && strings.EqualFold(auth[:7], "bearer ") {
+		return strings.TrimSpace(auth[7:])
+	}
+	return ""
+}
+
+// login starts the authorization code flow.
+func (rp *RelyingParty) login(w http.ResponseWriter, r *http.Request) {
+	pkce := NewPKCE()
+	ls := loginState{
+		State:    rand.Text(),
+		Nonce:    rand.Text(),
+		Verifier: pkce.Verifier,
+		ReturnTo: r.URL.RequestURI(),
+	}
+
+	data, err := json.Marshal(ls)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, rp.cookie(loginCookie, base64.RawURLEncoding.EncodeToString(data), time.Now().Add(loginTimeout)))
+	http.Redirect(w, r, rp.AuthCodeURL(ls.State, ls.Nonce, pkce), http.StatusFound)
+}
+
+// callback finishes the authorization code flow.
+func (rp *RelyingParty) callback(w http.ResponseWriter, r *http.Request) {
+	c, err := r.Cookie(loginCookie)
+	if err != nil {
+		http.Error(w, "no login in progress, try again", http.StatusBadRequest)
+		return
+	}
+
+	var ls loginState
+	data, err := base64.RawURLEncoding.DecodeString(c.Value)
+	if err == nil {
+		err = json.Unmarshal(data, &ls)
+	}
+	if err != nil {
+		http.Error(w, "bad login state, try again", http.StatusBadRequest)
+		return
+	}
+
+	// The login cookie is only good once.
+	http.SetCookie(w, rp.cookie(loginCookie, "", time.Unix(0, 0)))
+
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		http.Error(w, "can't log in: "+e, http.StatusForbidden)
+		return
+	}
+
+	if q.Get("state") == "" || q.Get("state") != ls.State {
+		http.Error(w, "login state doesn't match, try again", http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := rp.Exchange(r.Context(), q.Get("code"), PKCE{Verifier: ls.Verifier})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "can't exchange authorization code", "err", err)
+		http.Error(w, "can't log in, try again", http.StatusBadGateway)
+		return
+	}
+
+	claims, err := rp.Verify(r.Context(), tokens.IDToken, ls.Nonce)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "got a bad ID token", "err", err)
+		http.Error(w, "can't log in, try again", http.StatusBadGateway)
+		return
+	}
+
+	if !rp.allowed(claims) {
+		http.Error(w, "you aren't allowed to use this", http.StatusForbidden)
+		return
+	}
+
+	http.SetCookie(w, rp.cookie(SessionCookie, tokens.IDToken, claims.Expiry))
+	http.Redirect(w, r, safeReturnTo(ls.ReturnTo), http.StatusFound)
+}
+
+// safeReturnTo makes sure users are only sent back to this site after
+// logging in.
+func safeReturnTo(to string) string {
+	if !strings.HasPrefix(to, "/") || strings.HasPrefix(to, "//") || strings.HasPrefix(to, "/\\") {
+		return "/"
+	}
+	return to
+}
+
+func (rp *RelyingParty) cookie(name, value string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   rp.redirect.Scheme == "https",
+		SameSite: http.SameSiteLaxMode,
+	}
+}
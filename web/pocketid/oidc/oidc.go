@@ -0,0 +1,300 @@
+// Package oidc is an OpenID Connect relying party for Pocket ID. It logs
+// users in with the authorization code flow and PKCE, verifies their ID
+// tokens against the provider's cached signing keys and can protect
+// net/http handlers.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"within.website/x/web/pocketid"
+)
+
+var (
+	ErrInvalidToken = errors.New("oidc: invalid token")
+	ErrBadConfig    = errors.New("oidc: bad config")
+)
+
+// DefaultScopes are the scopes requested when Config.Scopes is empty.
+var DefaultScopes = []string{"openid", "profile", "email", "groups"}
+
+// Config configures a RelyingParty.
+type Config struct {
+	// Client talks to the Pocket ID instance. Its HTTPClient is used for
+	// every request.
+	Client *pocketid.Client
+
+	// ClientID and ClientSecret identify the OIDC client registered in
+	// Pocket ID. Public clients don't have a secret.
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is where Pocket ID sends users back to. Its path is
+	// handled by Middleware.
+	RedirectURL string
+
+	Scopes []string
+
+	// AllowedGroups limits who may log in to members of these groups. If
+	// it is empty, every user may log in.
+	AllowedGroups []string
+
+	// KeyCacheTTL is how long signing keys are cached for. It defaults to
+	// an hour.
+	KeyCacheTTL time.Duration
+}
+
+// RelyingParty logs users in with Pocket ID.
+type RelyingParty struct {
+	cfg      Config
+	provider *pocketid.OpenIDConfiguration
+	redirect *url.URL
+	keys     *keyCache
+	now      func() time.Time
+}
+
+// New discovers the provider's endpoints and makes a relying party.
+func New(ctx context.Context, cfg Config) (*RelyingParty, error) {
+	if cfg.Client == nil || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, fmt.Errorf("%w: Client, ClientID and RedirectURL are required", ErrBadConfig)
+	}
+
+	redirect, err := url.Parse(cfg.RedirectURL)
+	if err != nil || !redirect.IsAbs() {
+		return nil, fmt.Errorf("%w: RedirectURL must be an absolute URL", ErrBadConfig)
+	}
+
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = DefaultScopes
+	}
+	if cfg.KeyCacheTTL == 0 {
+		cfg.KeyCacheTTL = time.Hour
+	}
+
+	provider, err := cfg.Client.GetOpenIDConfigurationContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: can't discover provider: %w", err)
+	}
+
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc: discovery document is missing endpoints")
+	}
+
+	rp := &RelyingParty{
+		cfg:      cfg,
+		provider: provider,
+		redirect: redirect,
+		now:      time.Now,
+	}
+
+	rp.keys = &keyCache{
+		cli: cfg.Client.HTTPClient,
+		url: provider.JWKSURI,
+		ttl: cfg.KeyCacheTTL,
+		now: func() time.Time { return rp.now() },
+	}
+
+	return rp, nil
+}
+
+// Provider returns the provider's discovery document.
+func (rp *RelyingParty) Provider() *pocketid.OpenIDConfiguration {
+	return rp.provider
+}
+
+// PKCE is a proof key for code exchange. The verifier stays secret until
+// the code is exchanged and the challenge is sent with the authorization
+// request.
+type PKCE struct {
+	Verifier  string
+	Challenge string
+}
+
+// NewPKCE makes a fresh S256 proof key.
+func NewPKCE() PKCE {
+	verifier := rand.Text() + rand.Text()
+	sum := sha256.Sum256([]byte(verifier))
+
+	return PKCE{
+		Verifier:  verifier,
+		Challenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	}
+}
+
+// AuthCodeURL returns the URL to send users to to log in.
+func (rp *RelyingParty) AuthCodeURL(state, nonce string, pkce PKCE) string {
+	u, _ := url.Parse(rp.provider.AuthorizationEndpoint)
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", rp.cfg.ClientID)
+	q.Set("redirect_uri", rp.cfg.RedirectURL)
+	q.Set("scope", strings.Join(rp.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", pkce.Challenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+// Exchange trades an authorization code for tokens.
+func (rp *RelyingParty) Exchange(ctx context.Context, code string, pkce PKCE) (*pocketid.TokenResponse, error) {
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("redirect_uri", rp.cfg.RedirectURL)
+	data.Set("client_id", rp.cfg.ClientID)
+	data.Set("code_verifier", pkce.Verifier)
+	if rp.cfg.ClientSecret != "" {
+		data.Set("client_secret", rp.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rp.provider.TokenEndpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := rp.cfg.Client.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: can't exchange code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: can't exchange code: unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result pocketid.TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	if result.IDToken == "" {
+		return nil, fmt.Errorf("%w: no ID token in token response", ErrInvalidToken)
+	}
+
+	return &result, nil
+}
+
+// Claims is who an ID token says the user is.
+type Claims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+	Picture           string
+	Groups            []string
+	Expiry            time.Time
+
+	// Raw has every claim in the token, including custom claims.
+	Raw map[string]any
+}
+
+// InGroup reports whether the user is in any of the groups.
+func (c Claims) InGroup(groups ...string) bool {
+	for _, g := range groups {
+		if slices.Contains(c.Groups, g) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Verify checks an ID token's signature, issuer, audience and expiry and
+// returns its claims. If nonce isn't empty, the token must have been
+// issued for it.
+func (rp *RelyingParty) Verify(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	tok, err := jwt.Parse(rawIDToken, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return rp.keys.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(rp.provider.Issuer),
+		jwt.WithAudience(rp.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(rp.now),
+	)
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	raw, ok := tok.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected claims type", ErrInvalidToken)
+	}
+
+	if nonce != "" {
+		if got, _ := raw["nonce"].(string); got != nonce {
+			return nil, fmt.Errorf("%w: nonce doesn't match", ErrInvalidToken)
+		}
+	}
+
+	return mapClaims(raw)
+}
+
+// mapClaims maps the standard claims and Pocket ID's groups claim out of a
+// token's claims.
+func mapClaims(raw jwt.MapClaims) (*Claims, error) {
+	str := func(name string) string {
+		s, _ := raw[name].(string)
+		return s
+	}
+
+	result := &Claims{
+		Subject:           str("sub"),
+		Email:             str("email"),
+		Name:              str("name"),
+		PreferredUsername: str("preferred_username"),
+		Picture:           str("picture"),
+		Raw:               raw,
+	}
+
+	if result.Subject == "" {
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+
+	result.EmailVerified, _ = raw["email_verified"].(bool)
+
+	if exp, err := raw.GetExpirationTime(); err == nil && exp != nil {
+		result.Expiry = exp.Time
+	}
+
+	switch groups := raw["groups"].(type) {
+	case []any:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				result.Groups = append(result.Groups, s)
+			}
+		}
+	case string:
+		result.Groups = []string{groups}
+	}
+
+	return result, nil
+}
+
+// allowed reports whether the user may log in.
+func (rp *RelyingParty) allowed(c *Claims) bool {
+	return len(rp.cfg.AllowedGroups) == 0 || c.InGroup(rp.cfg.AllowedGroups...)
+}
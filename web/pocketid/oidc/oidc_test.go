@@ -0,0 +1,342 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"within.website/x/web/pocketid"
+)
+
+// fakePocketID is just enough of Pocket ID to log in with: discovery, keys,
+// an authorization endpoint that approves everything and a token endpoint
+// that checks PKCE.
+type fakePocketID struct {
+	*httptest.Server
+
+	clientID string
+	key      *rsa.PrivateKey
+	kid      string
+
+	lock       sync.Mutex
+	user       jwt.MapClaims
+	codes      map[string]url.Values
+	keyFetches int
+}
+
+func newFakePocketID(t *testing.T, clientID string) *fakePocketID {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &fakePocketID{
+		clientID: clientID,
+		key:      key,
+		kid:      "key-1",
+		user:     jwt.MapClaims{"sub": "cadey", "email": "me@xeiaso.net", "preferred_username": "xe", "groups": []string{"admins", "friends"}},
+		codes:    map[string]url.Values{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(pocketid.OpenIDConfiguration{
+			Issuer:                        f.URL,
+			AuthorizationEndpoint:         f.URL + "/authorize",
+			TokenEndpoint:                 f.URL + "/api/oidc/token",
+			UserinfoEndpoint:              f.URL + "/api/oidc/userinfo",
+			JWKSURI:                       f.URL + "/.well-known/jwks.json",
+			CodeChallengeMethodsSupported: []string{"S256"},
+		})
+	})
+	mux.HandleFunc("GET /.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		f.keyFetches++
+
+		json.NewEncoder(w).Encode(pocketid.JWKS{Keys: []pocketid.JWK{{
+			KeyType:   "RSA",
+			Use:       "sig",
+			KeyID:     f.kid,
+			Algorithm: "RS256",
+			N:         base64.RawURLEncoding.EncodeToString(f.key.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(f.key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("GET /authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("client_id") != f.clientID || q.Get("code_challenge_method") != "S256" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		code := rand.Text()
+		f.lock.Lock()
+		f.codes[code] = q
+		f.lock.Unlock()
+
+		u, _ := url.Parse(q.Get("redirect_uri"))
+		u.RawQuery = url.Values{"code": {code}, "state": {q.Get("state")}}.Encode()
+		http.Redirect(w, r, u.String(), http.StatusFound)
+	})
+	mux.HandleFunc("POST /api/oidc/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		f.lock.Lock()
+		auth, ok := f.codes[r.PostForm.Get("code")]
+		delete(f.codes, r.PostForm.Get("code"))
+		f.lock.Unlock()
+
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != auth.Get("code_challenge") {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+
+		idToken := f.idToken(t, auth.Get("nonce"), time.Now())
+		json.NewEncoder(w).Encode(pocketid.TokenResponse{AccessToken: "access", TokenType: "Bearer", IDToken: idToken, ExpiresIn: 3600})
+	})
+
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func (f *fakePocketID) idToken(t *testing.T, nonce string, issuedAt time.Time) string {
+	t.Helper()
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	claims := jwt.MapClaims{
+		"iss": f.URL,
+		"aud": f.clientID,
+		"iat": issuedAt.Unix(),
+		"exp": issuedAt.Add(time.Hour).Unix(),
+	}
+	for k, v := range f.user {
+		claims[k] = v
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = f.kid
+	result, err := tok.SignedString(f.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+// newApp serves a page that says who is logged in behind the middleware.
+func newApp(t *testing.T, f *fakePocketID, allowedGroups ...string) (*httptest.Server, *RelyingParty) {
+	t.Helper()
+
+	var handler http.Handler
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(app.Close)
+
+	rp, err := New(t.Context(), Config{
+		Client:        pocketid.NewClient(f.URL, "", nil),
+		ClientID:      "test-client",
+		RedirectURL:   app.URL + "/callback",
+		AllowedGroups: allowedGroups,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler = rp.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := ClaimsFrom(r.Context())
+		if !ok {
+			t.Error("no claims in context")
+		}
+		io.WriteString(w, c.PreferredUsername+" "+r.URL.RequestURI())
+	}))
+
+	return app, rp
+}
+
+func browser(t *testing.T) *http.Client {
+	t.Helper()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &http.Client{Jar: jar}
+}
+
+func get(t *testing.T, cli *http.Client, u string) (int, string) {
+	t.Helper()
+
+	resp, err := cli.Get(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data)
+}
+
+func TestLogin(t *testing.T) {
+	f := newFakePocketID(t, "test-client")
+	app, _ := newApp(t, f, "admins")
+	cli := browser(t)
+
+	code, body := get(t, cli, app.URL+"/secret?page=2")
+	if code != http.StatusOK || body != "xe /secret?page=2" {
+		t.Fatalf("got %d %q after logging in", code, body)
+	}
+
+	// The session cookie is enough the second time.
+	f.lock.Lock()
+	f.codes = nil
+	f.lock.Unlock()
+
+	if code, body := get(t, cli, app.URL+"/again"); code != http.StatusOK || body != "xe /again" {
+		t.Errorf("got %d %q with a session", code, body)
+	}
+
+	if f.keyFetches != 1 {
+		t.Errorf("keys fetched %d times, want 1", f.keyFetches)
+	}
+}
+
+func TestLoginForbiddenGroup(t *testing.T) {
+	f := newFakePocketID(t, "test-client")
+	app, _ := newApp(t, f, "wheel")
+
+	if code, _ := get(t, browser(t), app.URL+"/"); code != http.StatusForbidden {
+		t.Errorf("got status %d, want %d", code, http.StatusForbidden)
+	}
+}
+
+func TestCallbackStateMismatch(t *testing.T) {
+	f := newFakePocketID(t, "test-client")
+	app, _ := newApp(t, f)
+
+	cli := browser(t)
+	cli.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	resp, err := cli.Get(app.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("want a redirect to log in, got %d", resp.StatusCode)
+	}
+
+	if code, _ := get(t, cli, app.URL+"/callback?code=x&state=wrong"); code != http.StatusBadRequest {
+		t.Errorf("got status %d for a mismatched state", code)
+	}
+}
+
+func TestBearerAndAPI(t *testing.T) {
+	f := newFakePocketID(t, "test-client")
+	app, _ := newApp(t, f)
+
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, app.URL+"/api", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("got status %d without a token, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	req, _ = http.NewRequestWithContext(t.Context(), http.MethodPost, app.URL+"/api", nil)
+	req.Header.Set("Authorization", "Bearer "+f.idToken(t, "", time.Now()))
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got status %d with a token", resp.StatusCode)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	f := newFakePocketID(t, "test-client")
+	_, rp := newApp(t, f)
+	ctx := t.Context()
+
+	claims, err := rp.Verify(ctx, f.idToken(t, "n0nce", time.Now()), "n0nce")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "cadey" || claims.Email != "me@xeiaso.net" || !claims.InGroup("friends") || claims.InGroup("wheel") {
+		t.Errorf("got bad claims: %+v", claims)
+	}
+
+	for name, tok := range map[string]string{
+		"expired":     f.idToken(t, "", time.Now().Add(-2*time.Hour)),
+		"wrong nonce": f.idToken(t, "other", time.Now()),
+		"garbage":     "not.a.token",
+	} {
+		if _, err := rp.Verify(ctx, tok, "n0nce"); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: want ErrInvalidToken, got %v", name, err)
+		}
+	}
+
+	// After the provider rotates its key, the new key is fetched once.
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.lock.Lock()
+	f.key, f.kid = newKey, "key-2"
+	f.lock.Unlock()
+
+	rp.now = func() time.Time { return time.Now().Add(2 * minRefetch) }
+	if _, err := rp.Verify(ctx, f.idToken(t, "", time.Now()), ""); err != nil {
+		t.Fatalf("want the rotated key to be fetched: %v", err)
+	}
+
+	if _, err := rp.Verify(ctx, f.idToken(t, "", time.Now()), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if f.keyFetches != 2 {
+		t.Errorf("keys fetched %d times, want 2", f.keyFetches)
+	}
+}
+
+func TestSafeReturnTo(t *testing.T) {
+	for in, want := range map[string]string{
+		"/page?x=1":         "/page?x=1",
+		"//evil.example":    "/",
+		"/\\evil.example":   "/",
+		"https://evil.test": "/",
+	} {
+		if got := safeReturnTo(in); got != want {
+			t.Errorf("safeReturnTo(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
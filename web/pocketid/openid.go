@@ -0,0 +1,272 @@
+package pocketid
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var ErrUnsupportedKey = errors.New("pocketid: unsupported key type")
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Key finds the key with the given key ID.
+func (s JWKS) Key(kid string) (*JWK, bool) {
+	for i := range s.Keys {
+		if s.Keys[i].KeyID == kid {
+			return &s.Keys[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// JWK is a public JSON Web Key. Only RSA and elliptic curve keys are
+// supported.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use,omitempty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg,omitempty"`
+
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// Elliptic curve keys
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// PublicKey decodes the key into an *rsa.PublicKey or *ecdsa.PublicKey.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	decode := func(name, val string) (*big.Int, error) {
+		data, err := base64.RawURLEncoding.DecodeString(val)
+		if err != nil {
+			return nil, fmt.Errorf("pocketid: can't decode %s of key %s: %w", name, k.KeyID, err)
+		}
+		return new(big.Int).SetBytes(data), nil
+	}
+
+	switch k.KeyType {
+	case "RSA":
+		n, err := decode("n", k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode("e", k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("pocketid: exponent of key %s is too big", k.KeyID)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("%w: curve %q", ErrUnsupportedKey, k.Curve)
+		}
+		x, err := decode("x", k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode("y", k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedKey, k.KeyType)
+	}
+}
+
+// OpenIDConfiguration is the OpenID Connect discovery document.
+type OpenIDConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	EndSessionEndpoint                string   `json:"end_session_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+// TokenResponse is the result of exchanging an authorization code.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	IDToken      string `json:"id_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// UserInfo is what the userinfo endpoint says about the user an access token
+// belongs to. Custom claims set on the user or their groups are only in
+// Claims.
+type UserInfo struct {
+	Subject           string   `json:"sub"`
+	Email             string   `json:"email"`
+	EmailVerified     bool     `json:"email_verified"`
+	Name              string   `json:"name"`
+	GivenName         string   `json:"given_name"`
+	FamilyName        string   `json:"family_name"`
+	PreferredUsername string   `json:"preferred_username"`
+	Picture           string   `json:"picture"`
+	Groups            []string `json:"groups"`
+
+	// Claims has every claim in the response, including the ones above.
+	Claims map[string]any `json:"-"`
+}
+
+func (u *UserInfo) UnmarshalJSON(data []byte) error {
+	type userInfo UserInfo
+	if err := json.Unmarshal(data, (*userInfo)(u)); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, &u.Claims)
+}
+
+// getJSON fetches path and decodes the JSON response into result.
+func (c *Client) getJSON(ctx context.Context, path string, result any) error {
+	resp, err := c.request(ctx, "GET", path, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// GetJWKSContext returns the JSON Web Key Set used for token verification.
+//
+// See https://pocket-id.example.com/.well-known/jwks.json
+func (c *Client) GetJWKSContext(ctx context.Context) (*JWKS, error) {
+	var result JWKS
+	if err := c.getJSON(ctx, "/.well-known/jwks.json", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetOpenIDConfigurationContext returns the OpenID Connect discovery document.
+//
+// See https://pocket-id.example.com/.well-known/openid-configuration
+func (c *Client) GetOpenIDConfigurationContext(ctx context.Context) (*OpenIDConfiguration, error) {
+	var result OpenIDConfiguration
+	if err := c.getJSON(ctx, "/.well-known/openid-configuration", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateOIDCTokensContext exchanges an authorization code for ID and access tokens.
+//
+// See https://pocket-id.example.com/oidc/token
+func (c *Client) CreateOIDCTokensContext(ctx context.Context, clientID, clientSecret, code, grantType, codeVerifier string) (*TokenResponse, error) {
+	data := url.Values{}
+	data.Set("grant_type", grantType)
+	data.Set("code", code)
+	if clientID != "" {
+		data.Set("client_id", clientID)
+	}
+	if clientSecret != "" {
+		data.Set("client_secret", clientSecret)
+	}
+	if codeVerifier != "" {
+		data.Set("code_verifier", codeVerifier)
+	}
+
+	resp, err := c.request(ctx, "POST", "/oidc/token", strings.NewReader(data.Encode()), "application/x-www-form-urlencoded")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetUserInfoContext gets user information based on the access token.
+//
+// See https://pocket-id.example.com/oidc/userinfo
+func (c *Client) GetUserInfoContext(ctx context.Context, accessToken string) (*UserInfo, error) {
+	return c.userInfo(ctx, "GET", accessToken)
+}
+
+// GetUserInfoPostContext gets user information based on the access token using POST.
+//
+// See https://pocket-id.example.com/oidc/userinfo
+func (c *Client) GetUserInfoPostContext(ctx context.Context, accessToken string) (*UserInfo, error) {
+	return c.userInfo(ctx, "POST", accessToken)
+}
+
+func (c *Client) userInfo(ctx context.Context, method, accessToken string) (*UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/oidc/userinfo", c.BaseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result UserInfo
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
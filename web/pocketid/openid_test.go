@@ -0,0 +1,88 @@
+package pocketid
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetJWKSContext(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, _ := key.PublicKey.ECDH()
+	raw := pub.Bytes() // 0x04 || X || Y
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/jwks.json" {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, `{"keys":[{"kty":"EC","use":"sig","kid":"ec-1","alg":"ES256","crv":"P-256","x":"`+
+			base64.RawURLEncoding.EncodeToString(raw[1:33])+`","y":"`+
+			base64.RawURLEncoding.EncodeToString(raw[33:])+`"},{"kty":"oct","kid":"hmac"}]}`)
+	}))
+	defer srv.Close()
+
+	jwks, err := NewClient(srv.URL, "", nil).GetJWKSContext(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k, ok := jwks.Key("ec-1")
+	if !ok {
+		t.Fatal("can't find key ec-1")
+	}
+
+	pk, err := k.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.PublicKey.Equal(pk) {
+		t.Error("decoded key doesn't match")
+	}
+
+	k, _ = jwks.Key("hmac")
+	if _, err := k.PublicKey(); !errors.Is(err, ErrUnsupportedKey) {
+		t.Errorf("want ErrUnsupportedKey, got: %v", err)
+	}
+}
+
+func TestGetUserInfoContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer hunter2" {
+			http.Error(w, "no", http.StatusUnauthorized)
+			return
+		}
+		io.WriteString(w, `{"sub":"cadey","email":"me@xeiaso.net","email_verified":true,"groups":["admins"],"pronouns":"they/them"}`)
+	}))
+	defer srv.Close()
+
+	cli := NewClient(srv.URL, "", nil)
+
+	ui, err := cli.GetUserInfoContext(t.Context(), "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ui.Subject != "cadey" || !ui.EmailVerified || len(ui.Groups) != 1 || ui.Claims["pronouns"] != "they/them" {
+		t.Errorf("got bad user info: %+v", ui)
+	}
+
+	if _, err := cli.GetUserInfoPostContext(t.Context(), "wrong"); err == nil {
+		t.Error("want an error for a bad token")
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := cli.GetUserInfoContext(ctx, "hunter2"); !errors.Is(err, context.Canceled) {
+		t.Errorf("want context.Canceled, got: %v", err)
+	}
+}
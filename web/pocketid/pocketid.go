@@ -11,6 +11,7 @@ package pocketid
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -75,8 +76,8 @@ func decodePaginated[T any](resp *http.Response, paginated *Paginated[T]) error
 }
 
 // request performs an HTTP request to the PocketID API.
-func (c *Client) request(method, path string, body io.Reader, contentType string) (*http.Response, error) {
-	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", c.BaseURL, path), body)
+func (c *Client) request(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.BaseURL, path), body)
 	if err != nil {
 		return nil, err
 	}
@@ -98,8 +99,10 @@ func (c *Client) request(method, path string, body io.Reader, contentType string
 // GetJWKS returns the JSON Web Key Set used for token verification.
 //
 // See https://pocket-id.example.com/.well-known/jwks.json
+//
+// Deprecated: use GetJWKSContext, which returns a typed response.
 func (c *Client) GetJWKS() (map[string]any, error) {
-	resp, err := c.request("GET", "/.well-known/jwks.json", nil, "")
+	resp, err := c.request(context.Background(), "GET", "/.well-known/jwks.json", nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -121,8 +124,10 @@ func (c *Client) GetJWKS() (map[string]any, error) {
 // GetOpenIDConfiguration returns the OpenID Connect discovery document.
 //
 // See https://pocket-id.example.com/.well-known/openid-configuration
+//
+// Deprecated: use GetOpenIDConfigurationContext, which returns a typed response.
 func (c *Client) GetOpenIDConfiguration() (map[string]any, error) {
-	resp, err := c.request("GET", "/.well-known/openid-configuration", nil, "")
+	resp, err := c.request(context.Background(), "GET", "/.well-known/openid-configuration", nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -199,17 +204,17 @@ type Pagination struct {
 	TotalPages   int `json:"totalPages"`
 }
 
-// ListAPIKeys gets a paginated list of API keys belonging to the current user.
+// ListAPIKeysContext gets a paginated list of API keys belonging to the current user.
 //
 // See https://pocket-id.example.com/api-keys
-func (c *Client) ListAPIKeys(page, limit int, sortColumn, sortDirection string) (*Paginated[ApiKeyDto], error) {
+func (c *Client) ListAPIKeysContext(ctx context.Context, page, limit int, sortColumn, sortDirection string) (*Paginated[ApiKeyDto], error) {
 	params := url.Values{}
 	params.Set("page", strconv.Itoa(page))
 	params.Set("limit", strconv.Itoa(limit))
 	params.Set("sort_column", sortColumn)
 	params.Set("sort_direction", sortDirection)
 
-	resp, err := c.request("GET", fmt.Sprintf("/api-keys?%s", params.Encode()), nil, "")
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/api-keys?%s", params.Encode()), nil, "")
 
 	if err != nil {
 		return nil, err
@@ -228,10 +233,15 @@ func (c *Client) ListAPIKeys(page, limit int, sortColumn, sortDirection string)
 	return &apiKeys, nil
 }
 
-// CreateAPIKey creates a new API key for the current user.
+// ListAPIKeys is like ListAPIKeysContext but uses context.Background().
+func (c *Client) ListAPIKeys(page, limit int, sortColumn, sortDirection string) (*Paginated[ApiKeyDto], error) {
+	return c.ListAPIKeysContext(context.Background(), page, limit, sortColumn, sortDirection)
+}
+
+// CreateAPIKeyContext creates a new API key for the current user.
 //
 // See https://pocket-id.example.com/api-keys
-func (c *Client) CreateAPIKey(apiKeyCreateDto ApiKeyCreateDto) (*ApiKeyResponseDto, error) {
+func (c *Client) CreateAPIKeyContext(ctx context.Context, apiKeyCreateDto ApiKeyCreateDto) (*ApiKeyResponseDto, error) {
 	if err := apiKeyCreateDto.Valid(); err != nil {
 		return nil, err
 	}
@@ -241,7 +251,7 @@ func (c *Client) CreateAPIKey(apiKeyCreateDto ApiKeyCreateDto) (*ApiKeyResponseD
 		return nil, err
 	}
 
-	resp, err := c.request("POST", "/api-keys", bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "POST", "/api-keys", bytes.NewBuffer(body), "application/json")
 
 	if err != nil {
 		return nil, err
@@ -261,11 +271,16 @@ func (c *Client) CreateAPIKey(apiKeyCreateDto ApiKeyCreateDto) (*ApiKeyResponseD
 	return &apiKeyResponseDto, nil
 }
 
-// RevokeAPIKey revokes (deletes) an existing API key by ID.
+// CreateAPIKey is like CreateAPIKeyContext but uses context.Background().
+func (c *Client) CreateAPIKey(apiKeyCreateDto ApiKeyCreateDto) (*ApiKeyResponseDto, error) {
+	return c.CreateAPIKeyContext(context.Background(), apiKeyCreateDto)
+}
+
+// RevokeAPIKeyContext revokes (deletes) an existing API key by ID.
 //
 // See https://pocket-id.example.com/api-keys/{id}
-func (c *Client) RevokeAPIKey(id string) error {
-	resp, err := c.request("DELETE", fmt.Sprintf("/api-keys/%s", id), nil, "")
+func (c *Client) RevokeAPIKeyContext(ctx context.Context, id string) error {
+	resp, err := c.request(ctx, "DELETE", fmt.Sprintf("/api-keys/%s", id), nil, "")
 	if err != nil {
 		return err
 	}
@@ -278,6 +293,11 @@ func (c *Client) RevokeAPIKey(id string) error {
 	return nil
 }
 
+// RevokeAPIKey is like RevokeAPIKeyContext but uses context.Background().
+func (c *Client) RevokeAPIKey(id string) error {
+	return c.RevokeAPIKeyContext(context.Background(), id)
+}
+
 // --- Application Configuration ---
 
 // AppConfigUpdateDto represents the request body for updating application configuration.
@@ -330,11 +350,11 @@ type PublicAppConfigVariableDto struct {
 	Value string `json:"value"`
 }
 
-// ListPublicAppConfig gets all public application configurations.
+// ListPublicAppConfigContext gets all public application configurations.
 //
 // See https://pocket-id.example.com/application-configuration
-func (c *Client) ListPublicAppConfig() ([]PublicAppConfigVariableDto, error) {
-	resp, err := c.request("GET", "/application-configuration", nil, "")
+func (c *Client) ListPublicAppConfigContext(ctx context.Context) ([]PublicAppConfigVariableDto, error) {
+	resp, err := c.request(ctx, "GET", "/application-configuration", nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -353,16 +373,21 @@ func (c *Client) ListPublicAppConfig() ([]PublicAppConfigVariableDto, error) {
 	return config, nil
 }
 
-// UpdateAppConfig updates application configuration settings.
+// ListPublicAppConfig is like ListPublicAppConfigContext but uses context.Background().
+func (c *Client) ListPublicAppConfig() ([]PublicAppConfigVariableDto, error) {
+	return c.ListPublicAppConfigContext(context.Background())
+}
+
+// UpdateAppConfigContext updates application configuration settings.
 //
 // See https://pocket-id.example.com/application-configuration
-func (c *Client) UpdateAppConfig(config AppConfigUpdateDto) ([]AppConfigVariableDto, error) {
+func (c *Client) UpdateAppConfigContext(ctx context.Context, config AppConfigUpdateDto) ([]AppConfigVariableDto, error) {
 	body, err := json.Marshal(config)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("PUT", "/application-configuration", bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "PUT", "/application-configuration", bytes.NewBuffer(body), "application/json")
 
 	if err != nil {
 		return nil, err
@@ -382,11 +407,16 @@ func (c *Client) UpdateAppConfig(config AppConfigUpdateDto) ([]AppConfigVariable
 	return updatedConfig, nil
 }
 
-// ListAllAppConfig gets all application configurations, including private ones.
+// UpdateAppConfig is like UpdateAppConfigContext but uses context.Background().
+func (c *Client) UpdateAppConfig(config AppConfigUpdateDto) ([]AppConfigVariableDto, error) {
+	return c.UpdateAppConfigContext(context.Background(), config)
+}
+
+// ListAllAppConfigContext gets all application configurations, including private ones.
 //
 // See https://pocket-id.example.com/application-configuration/all
-func (c *Client) ListAllAppConfig() ([]AppConfigVariableDto, error) {
-	resp, err := c.request("GET", "/application-configuration/all", nil, "")
+func (c *Client) ListAllAppConfigContext(ctx context.Context) ([]AppConfigVariableDto, error) {
+	resp, err := c.request(ctx, "GET", "/application-configuration/all", nil, "")
 
 	if err != nil {
 		return nil, err
@@ -406,11 +436,16 @@ func (c *Client) ListAllAppConfig() ([]AppConfigVariableDto, error) {
 	return config, nil
 }
 
-// GetBackgroundImage gets the background image for the application.
+// ListAllAppConfig is like ListAllAppConfigContext but uses context.Background().
+func (c *Client) ListAllAppConfig() ([]AppConfigVariableDto, error) {
+	return c.ListAllAppConfigContext(context.Background())
+}
+
+// GetBackgroundImageContext gets the background image for the application.
 //
 // See https://pocket-id.example.com/application-configuration/background-image
-func (c *Client) GetBackgroundImage() ([]byte, error) {
-	resp, err := c.request("GET", "/application-configuration/background-image", nil, "")
+func (c *Client) GetBackgroundImageContext(ctx context.Context) ([]byte, error) {
+	resp, err := c.request(ctx, "GET", "/application-configuration/background-image", nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -423,10 +458,15 @@ func (c *Client) GetBackgroundImage() ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
-// UpdateBackgroundImage updates the application background image.
+// GetBackgroundImage is like GetBackgroundImageContext but uses context.Background().
+func (c *Client) GetBackgroundImage() ([]byte, error) {
+	return c.GetBackgroundImageContext(context.Background())
+}
+
+// UpdateBackgroundImageContext updates the application background image.
 //
 // See https://pocket-id.example.com/application-configuration/background-image
-func (c *Client) UpdateBackgroundImage(file io.Reader) error {
+func (c *Client) UpdateBackgroundImageContext(ctx context.Context, file io.Reader) error {
 	var b bytes.Buffer
 	w := multipart.NewWriter(&b)
 	fw, err := w.CreateFormFile("file", "background.png") // filename doesn't matter for this endpoint
@@ -438,7 +478,7 @@ func (c *Client) UpdateBackgroundImage(file io.Reader) error {
 	}
 	w.Close()
 
-	resp, err := c.request("PUT", "/application-configuration/background-image", &b, w.FormDataContentType())
+	resp, err := c.request(ctx, "PUT", "/application-configuration/background-image", &b, w.FormDataContentType())
 	if err != nil {
 		return err
 	}
@@ -450,11 +490,16 @@ func (c *Client) UpdateBackgroundImage(file io.Reader) error {
 	return nil
 }
 
-// GetFavicon gets the favicon for the application.
+// UpdateBackgroundImage is like UpdateBackgroundImageContext but uses context.Background().
+func (c *Client) UpdateBackgroundImage(file io.Reader) error {
+	return c.UpdateBackgroundImageContext(context.Background(), file)
+}
+
+// GetFaviconContext gets the favicon for the application.
 //
 // See https://pocket-id.example.com/application-configuration/favicon
-func (c *Client) GetFavicon() ([]byte, error) {
-	resp, err := c.request("GET", "/application-configuration/favicon", nil, "")
+func (c *Client) GetFaviconContext(ctx context.Context) ([]byte, error) {
+	resp, err := c.request(ctx, "GET", "/application-configuration/favicon", nil, "")
 
 	if err != nil {
 		return nil, err
@@ -468,10 +513,15 @@ func (c *Client) GetFavicon() ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
-// UpdateFavicon updates the application favicon.
+// GetFavicon is like GetFaviconContext but uses context.Background().
+func (c *Client) GetFavicon() ([]byte, error) {
+	return c.GetFaviconContext(context.Background())
+}
+
+// UpdateFaviconContext updates the application favicon.
 //
 // See https://pocket-id.example.com/application-configuration/favicon
-func (c *Client) UpdateFavicon(file io.Reader) error {
+func (c *Client) UpdateFaviconContext(ctx context.Context, file io.Reader) error {
 	var b bytes.Buffer
 	w := multipart.NewWriter(&b)
 	fw, err := w.CreateFormFile("file", "favicon.ico")
@@ -483,7 +533,7 @@ func (c *Client) UpdateFavicon(file io.Reader) error {
 	}
 	w.Close()
 
-	resp, err := c.request("PUT", "/application-configuration/favicon", &b, w.FormDataContentType())
+	resp, err := c.request(ctx, "PUT", "/application-configuration/favicon", &b, w.FormDataContentType())
 
 	if err != nil {
 		return err
@@ -496,13 +546,18 @@ func (c *Client) UpdateFavicon(file io.Reader) error {
 	return nil
 }
 
-// GetLogo gets the logo image for the application.  If isLight is true, the light mode logo is returned.
+// UpdateFavicon is like UpdateFaviconContext but uses context.Background().
+func (c *Client) UpdateFavicon(file io.Reader) error {
+	return c.UpdateFaviconContext(context.Background(), file)
+}
+
+// GetLogoContext gets the logo image for the application.  If isLight is true, the light mode logo is returned.
 //
 // See https://pocket-id.example.com/application-configuration/logo
-func (c *Client) GetLogo(isLight bool) ([]byte, error) {
+func (c *Client) GetLogoContext(ctx context.Context, isLight bool) ([]byte, error) {
 	params := url.Values{}
 	params.Set("light", strconv.FormatBool(isLight))
-	resp, err := c.request("GET", fmt.Sprintf("/application-configuration/logo?%s", params.Encode()), nil, "")
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/application-configuration/logo?%s", params.Encode()), nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -514,10 +569,15 @@ func (c *Client) GetLogo(isLight bool) ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
-// UpdateLogo updates the application logo. If isLight is true, the light mode logo is updated.
+// GetLogo is like GetLogoContext but uses context.Background().
+func (c *Client) GetLogo(isLight bool) ([]byte, error) {
+	return c.GetLogoContext(context.Background(), isLight)
+}
+
+// UpdateLogoContext updates the application logo. If isLight is true, the light mode logo is updated.
 //
 // See https://pocket-id.example.com/application-configuration/logo
-func (c *Client) UpdateLogo(file io.Reader, isLight bool) error {
+func (c *Client) UpdateLogoContext(ctx context.Context, file io.Reader, isLight bool) error {
 	var b bytes.Buffer
 	w := multipart.NewWriter(&b)
 
@@ -535,7 +595,7 @@ func (c *Client) UpdateLogo(file io.Reader, isLight bool) error {
 	}
 	w.Close()
 
-	resp, err := c.request("PUT", "/application-configuration/logo", &b, w.FormDataContentType())
+	resp, err := c.request(ctx, "PUT", "/application-configuration/logo", &b, w.FormDataContentType())
 
 	if err != nil {
 		return err
@@ -548,11 +608,16 @@ func (c *Client) UpdateLogo(file io.Reader, isLight bool) error {
 	return nil
 }
 
-// SyncLDAP manually triggers LDAP synchronization.
+// UpdateLogo is like UpdateLogoContext but uses context.Background().
+func (c *Client) UpdateLogo(file io.Reader, isLight bool) error {
+	return c.UpdateLogoContext(context.Background(), file, isLight)
+}
+
+// SyncLDAPContext manually triggers LDAP synchronization.
 //
 // See https://pocket-id.example.com/application-configuration/sync-ldap
-func (c *Client) SyncLDAP() error {
-	resp, err := c.request("POST", "/application-configuration/sync-ldap", nil, "")
+func (c *Client) SyncLDAPContext(ctx context.Context) error {
+	resp, err := c.request(ctx, "POST", "/application-configuration/sync-ldap", nil, "")
 
 	if err != nil {
 		return err
@@ -565,11 +630,16 @@ func (c *Client) SyncLDAP() error {
 	return nil
 }
 
-// SendTestEmail sends a test email to verify email configuration.
+// SyncLDAP is like SyncLDAPContext but uses context.Background().
+func (c *Client) SyncLDAP() error {
+	return c.SyncLDAPContext(context.Background())
+}
+
+// SendTestEmailContext sends a test email to verify email configuration.
 //
 // See https://pocket-id.example.com/application-configuration/test-email
-func (c *Client) SendTestEmail() error {
-	resp, err := c.request("POST", "/application-configuration/test-email", nil, "")
+func (c *Client) SendTestEmailContext(ctx context.Context) error {
+	resp, err := c.request(ctx, "POST", "/application-configuration/test-email", nil, "")
 
 	if err != nil {
 		return err
@@ -582,6 +652,11 @@ func (c *Client) SendTestEmail() error {
 	return nil
 }
 
+// SendTestEmail is like SendTestEmailContext but uses context.Background().
+func (c *Client) SendTestEmail() error {
+	return c.SendTestEmailContext(context.Background())
+}
+
 // --- Audit Logs ---
 
 // AuditLogDto represents an audit log entry.
@@ -611,17 +686,17 @@ const (
 // AuditLogData is a custom type representing additional data in the AuditLogDto.
 type AuditLogData map[string]string
 
-// ListAuditLogs gets a paginated list of audit logs for the current user.
+// ListAuditLogsContext gets a paginated list of audit logs for the current user.
 //
 // See https://pocket-id.example.com/audit-logs
-func (c *Client) ListAuditLogs(page, limit int, sortColumn, sortDirection string) (*Paginated[AuditLogDto], error) {
+func (c *Client) ListAuditLogsContext(ctx context.Context, page, limit int, sortColumn, sortDirection string) (*Paginated[AuditLogDto], error) {
 	params := url.Values{}
 	params.Set("page", strconv.Itoa(page))
 	params.Set("limit", strconv.Itoa(limit))
 	params.Set("sort_column", sortColumn)
 	params.Set("sort_direction", sortDirection)
 
-	resp, err := c.request("GET", fmt.Sprintf("/audit-logs?%s", params.Encode()), nil, "")
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/audit-logs?%s", params.Encode()), nil, "")
 
 	if err != nil {
 		return nil, err
@@ -641,6 +716,11 @@ func (c *Client) ListAuditLogs(page, limit int, sortColumn, sortDirection string
 	return &auditLogs, nil
 }
 
+// ListAuditLogs is like ListAuditLogsContext but uses context.Background().
+func (c *Client) ListAuditLogs(page, limit int, sortColumn, sortDirection string) (*Paginated[AuditLogDto], error) {
+	return c.ListAuditLogsContext(context.Background(), page, limit, sortColumn, sortDirection)
+}
+
 // --- Custom Claims ---
 
 // CustomClaimCreateDto represents the request body for creating or updating custom claims.
@@ -668,11 +748,11 @@ func (t CustomClaimDto) Valid() error {
 	return nil
 }
 
-// GetCustomClaimSuggestions gets a list of suggested custom claim names.
+// GetCustomClaimSuggestionsContext gets a list of suggested custom claim names.
 //
 // See https://pocket-id.example.com/custom-claims/suggestions
-func (c *Client) GetCustomClaimSuggestions() ([]string, error) {
-	resp, err := c.request("GET", "/custom-claims/suggestions", nil, "")
+func (c *Client) GetCustomClaimSuggestionsContext(ctx context.Context) ([]string, error) {
+	resp, err := c.request(ctx, "GET", "/custom-claims/suggestions", nil, "")
 
 	if err != nil {
 		return nil, err
@@ -692,16 +772,21 @@ func (c *Client) GetCustomClaimSuggestions() ([]string, error) {
 	return suggestions, nil
 }
 
-// UpdateCustomClaimsForUserGroup updates or creates custom claims for a specific user group.
+// GetCustomClaimSuggestions is like GetCustomClaimSuggestionsContext but uses context.Background().
+func (c *Client) GetCustomClaimSuggestions() ([]string, error) {
+	return c.GetCustomClaimSuggestionsContext(context.Background())
+}
+
+// UpdateCustomClaimsForUserGroupContext updates or creates custom claims for a specific user group.
 //
 // See https://pocket-id.example.com/custom-claims/user-group/{userGroupId}
-func (c *Client) UpdateCustomClaimsForUserGroup(userGroupID string, claims []CustomClaimDto) ([]CustomClaimDto, error) {
+func (c *Client) UpdateCustomClaimsForUserGroupContext(ctx context.Context, userGroupID string, claims []CustomClaimDto) ([]CustomClaimDto, error) {
 	body, err := json.Marshal(claims)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("PUT", fmt.Sprintf("/custom-claims/user-group/%s", userGroupID), bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "PUT", fmt.Sprintf("/custom-claims/user-group/%s", userGroupID), bytes.NewBuffer(body), "application/json")
 
 	if err != nil {
 		return nil, err
@@ -721,16 +806,21 @@ func (c *Client) UpdateCustomClaimsForUserGroup(userGroupID string, claims []Cus
 	return updatedClaims, nil
 }
 
-// UpdateCustomClaimsForUser updates or creates custom claims for a specific user.
+// UpdateCustomClaimsForUserGroup is like UpdateCustomClaimsForUserGroupContext but uses context.Background().
+func (c *Client) UpdateCustomClaimsForUserGroup(userGroupID string, claims []CustomClaimDto) ([]CustomClaimDto, error) {
+	return c.UpdateCustomClaimsForUserGroupContext(context.Background(), userGroupID, claims)
+}
+
+// UpdateCustomClaimsForUserContext updates or creates custom claims for a specific user.
 //
 // See https://pocket-id.example.com/custom-claims/user/{userId}
-func (c *Client) UpdateCustomClaimsForUser(userID string, claims []CustomClaimDto) ([]CustomClaimDto, error) {
+func (c *Client) UpdateCustomClaimsForUserContext(ctx context.Context, userID string, claims []CustomClaimDto) ([]CustomClaimDto, error) {
 	body, err := json.Marshal(claims)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("PUT", fmt.Sprintf("/custom-claims/user/%s", userID), bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "PUT", fmt.Sprintf("/custom-claims/user/%s", userID), bytes.NewBuffer(body), "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -749,6 +839,11 @@ func (c *Client) UpdateCustomClaimsForUser(userID string, claims []CustomClaimDt
 	return updatedClaims, nil
 }
 
+// UpdateCustomClaimsForUser is like UpdateCustomClaimsForUserContext but uses context.Background().
+func (c *Client) UpdateCustomClaimsForUser(userID string, claims []CustomClaimDto) ([]CustomClaimDto, error) {
+	return c.UpdateCustomClaimsForUserContext(context.Background(), userID, claims)
+}
+
 // --- OIDC ---
 
 // AuthorizationRequiredDto is used to check if authorization is required.
@@ -828,16 +923,16 @@ type OidcUpdateAllowedUserGroupsDto struct {
 	UserGroupIds []string `json:"userGroupIds" validate:"required"`
 }
 
-// CheckAuthorizationRequired checks if the user needs to confirm authorization for the client.
+// CheckAuthorizationRequiredContext checks if the user needs to confirm authorization for the client.
 //
 // See https://pocket-id.example.com/oidc/authorization-required
-func (c *Client) CheckAuthorizationRequired(request AuthorizationRequiredDto) (bool, error) {
+func (c *Client) CheckAuthorizationRequiredContext(ctx context.Context, request AuthorizationRequiredDto) (bool, error) {
 	body, err := json.Marshal(request)
 	if err != nil {
 		return false, err
 	}
 
-	resp, err := c.request("POST", "/oidc/authorization-required", bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "POST", "/oidc/authorization-required", bytes.NewBuffer(body), "application/json")
 	if err != nil {
 		return false, err
 	}
@@ -858,16 +953,21 @@ func (c *Client) CheckAuthorizationRequired(request AuthorizationRequiredDto) (b
 	return response.AuthorizationRequired, nil
 }
 
-// AuthorizeOIDCClient starts the OIDC authorization process for a client.
+// CheckAuthorizationRequired is like CheckAuthorizationRequiredContext but uses context.Background().
+func (c *Client) CheckAuthorizationRequired(request AuthorizationRequiredDto) (bool, error) {
+	return c.CheckAuthorizationRequiredContext(context.Background(), request)
+}
+
+// AuthorizeOIDCClientContext starts the OIDC authorization process for a client.
 //
 // See https://pocket-id.example.com/oidc/authorize
-func (c *Client) AuthorizeOIDCClient(request AuthorizeOidcClientRequestDto) (*AuthorizeOidcClientResponseDto, error) {
+func (c *Client) AuthorizeOIDCClientContext(ctx context.Context, request AuthorizeOidcClientRequestDto) (*AuthorizeOidcClientResponseDto, error) {
 	body, err := json.Marshal(request)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("POST", "/oidc/authorize", bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "POST", "/oidc/authorize", bytes.NewBuffer(body), "application/json")
 
 	if err != nil {
 		return nil, err
@@ -887,10 +987,15 @@ func (c *Client) AuthorizeOIDCClient(request AuthorizeOidcClientRequestDto) (*Au
 	return &responseDto, nil
 }
 
-// ListOIDCClients gets a paginated list of OIDC clients.
+// AuthorizeOIDCClient is like AuthorizeOIDCClientContext but uses context.Background().
+func (c *Client) AuthorizeOIDCClient(request AuthorizeOidcClientRequestDto) (*AuthorizeOidcClientResponseDto, error) {
+	return c.AuthorizeOIDCClientContext(context.Background(), request)
+}
+
+// ListOIDCClientsContext gets a paginated list of OIDC clients.
 //
 // See https://pocket-id.example.com/oidc/clients
-func (c *Client) ListOIDCClients(search string, page, limit int, sortColumn, sortDirection string) (*Paginated[OidcClientDto], error) {
+func (c *Client) ListOIDCClientsContext(ctx context.Context, search string, page, limit int, sortColumn, sortDirection string) (*Paginated[OidcClientDto], error) {
 	params := url.Values{}
 	params.Set("search", search)
 	params.Set("page", strconv.Itoa(page))
@@ -898,7 +1003,7 @@ func (c *Client) ListOIDCClients(search string, page, limit int, sortColumn, sor
 	params.Set("sort_column", sortColumn)
 	params.Set("sort_direction", sortDirection)
 
-	resp, err := c.request("GET", fmt.Sprintf("/oidc/clients?%s", params.Encode()), nil, "")
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/oidc/clients?%s", params.Encode()), nil, "")
 
 	if err != nil {
 		return nil, err
@@ -918,16 +1023,21 @@ func (c *Client) ListOIDCClients(search string, page, limit int, sortColumn, sor
 	return &clients, nil
 }
 
-// CreateOIDCClient creates a new OIDC client.
+// ListOIDCClients is like ListOIDCClientsContext but uses context.Background().
+func (c *Client) ListOIDCClients(search string, page, limit int, sortColumn, sortDirection string) (*Paginated[OidcClientDto], error) {
+	return c.ListOIDCClientsContext(context.Background(), search, page, limit, sortColumn, sortDirection)
+}
+
+// CreateOIDCClientContext creates a new OIDC client.
 //
 // See https://pocket-id.example.com/oidc/clients
-func (c *Client) CreateOIDCClient(clientCreateDto OidcClientCreateDto) (*OidcClientWithAllowedUserGroupsDto, error) {
+func (c *Client) CreateOIDCClientContext(ctx context.Context, clientCreateDto OidcClientCreateDto) (*OidcClientWithAllowedUserGroupsDto, error) {
 	body, err := json.Marshal(clientCreateDto)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("POST", "/oidc/clients", bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "POST", "/oidc/clients", bytes.NewBuffer(body), "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -946,11 +1056,16 @@ func (c *Client) CreateOIDCClient(clientCreateDto OidcClientCreateDto) (*OidcCli
 	return &clientDto, nil
 }
 
-// DeleteOIDCClient deletes an OIDC client by ID.
+// CreateOIDCClient is like CreateOIDCClientContext but uses context.Background().
+func (c *Client) CreateOIDCClient(clientCreateDto OidcClientCreateDto) (*OidcClientWithAllowedUserGroupsDto, error) {
+	return c.CreateOIDCClientContext(context.Background(), clientCreateDto)
+}
+
+// DeleteOIDCClientContext deletes an OIDC client by ID.
 //
 // See https://pocket-id.example.com/oidc/clients/{id}
-func (c *Client) DeleteOIDCClient(id string) error {
-	resp, err := c.request("DELETE", fmt.Sprintf("/oidc/clients/%s", id), nil, "")
+func (c *Client) DeleteOIDCClientContext(ctx context.Context, id string) error {
+	resp, err := c.request(ctx, "DELETE", fmt.Sprintf("/oidc/clients/%s", id), nil, "")
 	if err != nil {
 		return err
 	}
@@ -963,11 +1078,16 @@ func (c *Client) DeleteOIDCClient(id string) error {
 	return nil
 }
 
-// GetOIDCClient gets detailed information about an OIDC client.
+// DeleteOIDCClient is like DeleteOIDCClientContext but uses context.Background().
+func (c *Client) DeleteOIDCClient(id string) error {
+	return c.DeleteOIDCClientContext(context.Background(), id)
+}
+
+// GetOIDCClientContext gets detailed information about an OIDC client.
 //
 // See https://pocket-id.example.com/oidc/clients/{id}
-func (c *Client) GetOIDCClient(id string) (*OidcClientWithAllowedUserGroupsDto, error) {
-	resp, err := c.request("GET", fmt.Sprintf("/oidc/clients/%s", id), nil, "")
+func (c *Client) GetOIDCClientContext(ctx context.Context, id string) (*OidcClientWithAllowedUserGroupsDto, error) {
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/oidc/clients/%s", id), nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -986,16 +1106,21 @@ func (c *Client) GetOIDCClient(id string) (*OidcClientWithAllowedUserGroupsDto,
 	return &clientDto, nil
 }
 
-// UpdateOIDCClient updates an existing OIDC client.
+// GetOIDCClient is like GetOIDCClientContext but uses context.Background().
+func (c *Client) GetOIDCClient(id string) (*OidcClientWithAllowedUserGroupsDto, error) {
+	return c.GetOIDCClientContext(context.Background(), id)
+}
+
+// UpdateOIDCClientContext updates an existing OIDC client.
 //
 // See https://pocket-id.example.com/oidc/clients/{id}
-func (c *Client) UpdateOIDCClient(id string, clientCreateDto OidcClientCreateDto) (*OidcClientWithAllowedUserGroupsDto, error) {
+func (c *Client) UpdateOIDCClientContext(ctx context.Context, id string, clientCreateDto OidcClientCreateDto) (*OidcClientWithAllowedUserGroupsDto, error) {
 	body, err := json.Marshal(clientCreateDto)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("PUT", fmt.Sprintf("/oidc/clients/%s", id), bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "PUT", fmt.Sprintf("/oidc/clients/%s", id), bytes.NewBuffer(body), "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -1014,16 +1139,21 @@ func (c *Client) UpdateOIDCClient(id string, clientCreateDto OidcClientCreateDto
 	return &clientDto, nil
 }
 
-// UpdateOIDCClientAllowedUserGroups updates the user groups allowed to access an OIDC client.
+// UpdateOIDCClient is like UpdateOIDCClientContext but uses context.Background().
+func (c *Client) UpdateOIDCClient(id string, clientCreateDto OidcClientCreateDto) (*OidcClientWithAllowedUserGroupsDto, error) {
+	return c.UpdateOIDCClientContext(context.Background(), id, clientCreateDto)
+}
+
+// UpdateOIDCClientAllowedUserGroupsContext updates the user groups allowed to access an OIDC client.
 //
 // See https://pocket-id.example.com/oidc/clients/{id}/allowed-user-groups
-func (c *Client) UpdateOIDCClientAllowedUserGroups(id string, groups OidcUpdateAllowedUserGroupsDto) (*OidcClientDto, error) {
+func (c *Client) UpdateOIDCClientAllowedUserGroupsContext(ctx context.Context, id string, groups OidcUpdateAllowedUserGroupsDto) (*OidcClientDto, error) {
 	body, err := json.Marshal(groups)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("PUT", fmt.Sprintf("/oidc/clients/%s/allowed-user-groups", id), bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "PUT", fmt.Sprintf("/oidc/clients/%s/allowed-user-groups", id), bytes.NewBuffer(body), "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -1042,11 +1172,16 @@ func (c *Client) UpdateOIDCClientAllowedUserGroups(id string, groups OidcUpdateA
 	return &clientDto, nil
 }
 
-// DeleteOIDCClientLogo deletes the logo for an OIDC client.
+// UpdateOIDCClientAllowedUserGroups is like UpdateOIDCClientAllowedUserGroupsContext but uses context.Background().
+func (c *Client) UpdateOIDCClientAllowedUserGroups(id string, groups OidcUpdateAllowedUserGroupsDto) (*OidcClientDto, error) {
+	return c.UpdateOIDCClientAllowedUserGroupsContext(context.Background(), id, groups)
+}
+
+// DeleteOIDCClientLogoContext deletes the logo for an OIDC client.
 //
 // See https://pocket-id.example.com/oidc/clients/{id}/logo
-func (c *Client) DeleteOIDCClientLogo(id string) error {
-	resp, err := c.request("DELETE", fmt.Sprintf("/oidc/clients/%s/logo", id), nil, "")
+func (c *Client) DeleteOIDCClientLogoContext(ctx context.Context, id string) error {
+	resp, err := c.request(ctx, "DELETE", fmt.Sprintf("/oidc/clients/%s/logo", id), nil, "")
 	if err != nil {
 		return err
 	}
@@ -1059,11 +1194,16 @@ func (c *Client) DeleteOIDCClientLogo(id string) error {
 	return nil
 }
 
-// GetOIDCClientLogo gets the logo image for an OIDC client.
+// DeleteOIDCClientLogo is like DeleteOIDCClientLogoContext but uses context.Background().
+func (c *Client) DeleteOIDCClientLogo(id string) error {
+	return c.DeleteOIDCClientLogoContext(context.Background(), id)
+}
+
+// GetOIDCClientLogoContext gets the logo image for an OIDC client.
 //
 // See https://pocket-id.example.com/oidc/clients/{id}/logo
-func (c *Client) GetOIDCClientLogo(id string) ([]byte, error) {
-	resp, err := c.request("GET", fmt.Sprintf("/oidc/clients/%s/logo", id), nil, "")
+func (c *Client) GetOIDCClientLogoContext(ctx context.Context, id string) ([]byte, error) {
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/oidc/clients/%s/logo", id), nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1076,10 +1216,15 @@ func (c *Client) GetOIDCClientLogo(id string) ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
-// UpdateOIDCClientLogo uploads or updates the logo for an OIDC client.
+// GetOIDCClientLogo is like GetOIDCClientLogoContext but uses context.Background().
+func (c *Client) GetOIDCClientLogo(id string) ([]byte, error) {
+	return c.GetOIDCClientLogoContext(context.Background(), id)
+}
+
+// UpdateOIDCClientLogoContext uploads or updates the logo for an OIDC client.
 //
 // See https://pocket-id.example.com/oidc/clients/{id}/logo
-func (c *Client) UpdateOIDCClientLogo(id string, file io.Reader) error {
+func (c *Client) UpdateOIDCClientLogoContext(ctx context.Context, id string, file io.Reader) error {
 	var b bytes.Buffer
 	w := multipart.NewWriter(&b)
 	fw, err := w.CreateFormFile("file", "logo.png") // Filename doesn't affect server-side processing
@@ -1091,7 +1236,7 @@ func (c *Client) UpdateOIDCClientLogo(id string, file io.Reader) error {
 	}
 	w.Close()
 
-	resp, err := c.request("POST", fmt.Sprintf("/oidc/clients/%s/logo", id), &b, w.FormDataContentType())
+	resp, err := c.request(ctx, "POST", fmt.Sprintf("/oidc/clients/%s/logo", id), &b, w.FormDataContentType())
 	if err != nil {
 		return err
 	}
@@ -1104,11 +1249,16 @@ func (c *Client) UpdateOIDCClientLogo(id string, file io.Reader) error {
 	return nil
 }
 
-// GetOIDCClientMeta gets OIDC client metadata for discovery and configuration.
+// UpdateOIDCClientLogo is like UpdateOIDCClientLogoContext but uses context.Background().
+func (c *Client) UpdateOIDCClientLogo(id string, file io.Reader) error {
+	return c.UpdateOIDCClientLogoContext(context.Background(), id, file)
+}
+
+// GetOIDCClientMetaContext gets OIDC client metadata for discovery and configuration.
 //
 // See https://pocket-id.example.com/oidc/clients/{id}/meta
-func (c *Client) GetOIDCClientMeta(id string) (*OidcClientMetaDataDto, error) {
-	resp, err := c.request("GET", fmt.Sprintf("/oidc/clients/%s/meta", id), nil, "")
+func (c *Client) GetOIDCClientMetaContext(ctx context.Context, id string) (*OidcClientMetaDataDto, error) {
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/oidc/clients/%s/meta", id), nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1127,11 +1277,16 @@ func (c *Client) GetOIDCClientMeta(id string) (*OidcClientMetaDataDto, error) {
 	return &metaDto, nil
 }
 
-// CreateOIDCClientSecret generates a new secret for an OIDC client.
+// GetOIDCClientMeta is like GetOIDCClientMetaContext but uses context.Background().
+func (c *Client) GetOIDCClientMeta(id string) (*OidcClientMetaDataDto, error) {
+	return c.GetOIDCClientMetaContext(context.Background(), id)
+}
+
+// CreateOIDCClientSecretContext generates a new secret for an OIDC client.
 //
 // See https://pocket-id.example.com/oidc/clients/{id}/secret
-func (c *Client) CreateOIDCClientSecret(id string) (string, error) {
-	resp, err := c.request("POST", fmt.Sprintf("/oidc/clients/%s/secret", id), nil, "")
+func (c *Client) CreateOIDCClientSecretContext(ctx context.Context, id string) (string, error) {
+	resp, err := c.request(ctx, "POST", fmt.Sprintf("/oidc/clients/%s/secret", id), nil, "")
 
 	if err != nil {
 		return "", err
@@ -1153,10 +1308,15 @@ func (c *Client) CreateOIDCClientSecret(id string) (string, error) {
 	return response.Secret, nil
 }
 
-// EndOIDCSession ends the user session and handles OIDC logout.
+// CreateOIDCClientSecret is like CreateOIDCClientSecretContext but uses context.Background().
+func (c *Client) CreateOIDCClientSecret(id string) (string, error) {
+	return c.CreateOIDCClientSecretContext(context.Background(), id)
+}
+
+// EndOIDCSessionContext ends the user session and handles OIDC logout.
 //
 // See https://pocket-id.example.com/oidc/end-session
-func (c *Client) EndOIDCSession(idTokenHint, postLogoutRedirectURI, state string) error {
+func (c *Client) EndOIDCSessionContext(ctx context.Context, idTokenHint, postLogoutRedirectURI, state string) error {
 	params := url.Values{}
 	if idTokenHint != "" {
 		params.Set("id_token_hint", idTokenHint)
@@ -1168,7 +1328,7 @@ func (c *Client) EndOIDCSession(idTokenHint, postLogoutRedirectURI, state string
 		params.Set("state", state)
 	}
 
-	resp, err := c.request("GET", fmt.Sprintf("/oidc/end-session?%s", params.Encode()), nil, "application/x-www-form-urlencoded")
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/oidc/end-session?%s", params.Encode()), nil, "application/x-www-form-urlencoded")
 	if err != nil {
 		return err
 	}
@@ -1182,10 +1342,15 @@ func (c *Client) EndOIDCSession(idTokenHint, postLogoutRedirectURI, state string
 
 }
 
-// EndOIDCSessionPost ends the user session and handles OIDC logout using POST.
+// EndOIDCSession is like EndOIDCSessionContext but uses context.Background().
+func (c *Client) EndOIDCSession(idTokenHint, postLogoutRedirectURI, state string) error {
+	return c.EndOIDCSessionContext(context.Background(), idTokenHint, postLogoutRedirectURI, state)
+}
+
+// EndOIDCSessionPostContext ends the user session and handles OIDC logout using POST.
 //
 // See https://pocket-id.example.com/oidc/end-session
-func (c *Client) EndOIDCSessionPost(idTokenHint, postLogoutRedirectURI, state string) error {
+func (c *Client) EndOIDCSessionPostContext(ctx context.Context, idTokenHint, postLogoutRedirectURI, state string) error {
 	data := url.Values{}
 	if idTokenHint != "" {
 		data.Set("id_token_hint", idTokenHint)
@@ -1198,7 +1363,7 @@ func (c *Client) EndOIDCSessionPost(idTokenHint, postLogoutRedirectURI, state st
 		data.Set("state", state)
 	}
 
-	resp, err := c.request("POST", "/oidc/end-session", strings.NewReader(data.Encode()), "application/x-www-form-urlencoded")
+	resp, err := c.request(ctx, "POST", "/oidc/end-session", strings.NewReader(data.Encode()), "application/x-www-form-urlencoded")
 
 	if err != nil {
 		return err
@@ -1212,9 +1377,16 @@ func (c *Client) EndOIDCSessionPost(idTokenHint, postLogoutRedirectURI, state st
 	return nil
 }
 
+// EndOIDCSessionPost is like EndOIDCSessionPostContext but uses context.Background().
+func (c *Client) EndOIDCSessionPost(idTokenHint, postLogoutRedirectURI, state string) error {
+	return c.EndOIDCSessionPostContext(context.Background(), idTokenHint, postLogoutRedirectURI, state)
+}
+
 // CreateOIDCTokens exchanges an authorization code for ID and access tokens.
 //
 // See https://pocket-id.example.com/oidc/token
+//
+// Deprecated: use CreateOIDCTokensContext, which returns a typed response.
 func (c *Client) CreateOIDCTokens(clientID, clientSecret, code, grantType, codeVerifier string) (map[string]any, error) {
 	data := url.Values{}
 	data.Set("grant_type", grantType)
@@ -1230,7 +1402,7 @@ func (c *Client) CreateOIDCTokens(clientID, clientSecret, code, grantType, codeV
 		data.Set("code_verifier", codeVerifier)
 	}
 
-	resp, err := c.request("POST", "/oidc/token", strings.NewReader(data.Encode()), "application/x-www-form-urlencoded")
+	resp, err := c.request(context.Background(), "POST", "/oidc/token", strings.NewReader(data.Encode()), "application/x-www-form-urlencoded")
 	if err != nil {
 		return nil, err
 	}
@@ -1252,9 +1424,11 @@ func (c *Client) CreateOIDCTokens(clientID, clientSecret, code, grantType, codeV
 // GetUserInfo gets user information based on the access token.
 //
 // See https://pocket-id.example.com/oidc/userinfo
+//
+// Deprecated: use GetUserInfoContext, which returns a typed response.
 func (c *Client) GetUserInfo(accessToken string) (map[string]any, error) {
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/oidc/userinfo", c.BaseURL), nil)
+	req, err := http.NewRequestWithContext(context.Background(), "GET", fmt.Sprintf("%s/oidc/userinfo", c.BaseURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1282,8 +1456,10 @@ func (c *Client) GetUserInfo(accessToken string) (map[string]any, error) {
 
 // GetUserInfoPost gets user information based on the access token using POST.
 // See https://pocket-id.example.com/oidc/userinfo
+//
+// Deprecated: use GetUserInfoPostContext, which returns a typed response.
 func (c *Client) GetUserInfoPost(accessToken string) (map[string]any, error) {
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/oidc/userinfo", c.BaseURL), nil)
+	req, err := http.NewRequestWithContext(context.Background(), "POST", fmt.Sprintf("%s/oidc/userinfo", c.BaseURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1349,11 +1525,11 @@ type OneTimeAccessTokenCreateDto struct {
 	UserId    string `json:"userId"` // UserId is optional here, as per the API spec. It's only required in some contexts
 }
 
-// ExchangeOneTimeAccessToken exchanges a one-time access token for a session token.
+// ExchangeOneTimeAccessTokenContext exchanges a one-time access token for a session token.
 //
 // See https://pocket-id.example.com/one-time-access-token/{token}
-func (c *Client) ExchangeOneTimeAccessToken(token string) (*UserDto, error) {
-	resp, err := c.request("POST", fmt.Sprintf("/one-time-access-token/%s", token), nil, "")
+func (c *Client) ExchangeOneTimeAccessTokenContext(ctx context.Context, token string) (*UserDto, error) {
+	resp, err := c.request(ctx, "POST", fmt.Sprintf("/one-time-access-token/%s", token), nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1372,12 +1548,17 @@ func (c *Client) ExchangeOneTimeAccessToken(token string) (*UserDto, error) {
 	return &userDto, nil
 }
 
-// SetupInitialAdmin generates a setup access token for initial admin user configuration.
+// ExchangeOneTimeAccessToken is like ExchangeOneTimeAccessTokenContext but uses context.Background().
+func (c *Client) ExchangeOneTimeAccessToken(token string) (*UserDto, error) {
+	return c.ExchangeOneTimeAccessTokenContext(context.Background(), token)
+}
+
+// SetupInitialAdminContext generates a setup access token for initial admin user configuration.
 //
 // See https://pocket-id.example.com/one-time-access-token/setup
-func (c *Client) SetupInitialAdmin() (*UserDto, error) {
+func (c *Client) SetupInitialAdminContext(ctx context.Context) (*UserDto, error) {
 
-	resp, err := c.request("POST", "/one-time-access-token/setup", nil, "")
+	resp, err := c.request(ctx, "POST", "/one-time-access-token/setup", nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1395,10 +1576,15 @@ func (c *Client) SetupInitialAdmin() (*UserDto, error) {
 	return &userDto, nil
 }
 
-// ListUsers gets a paginated list of users.
+// SetupInitialAdmin is like SetupInitialAdminContext but uses context.Background().
+func (c *Client) SetupInitialAdmin() (*UserDto, error) {
+	return c.SetupInitialAdminContext(context.Background())
+}
+
+// ListUsersContext gets a paginated list of users.
 //
 // See https://pocket-id.example.com/users
-func (c *Client) ListUsers(search string, page, limit int, sortColumn, sortDirection string) (*Paginated[UserDto], error) {
+func (c *Client) ListUsersContext(ctx context.Context, search string, page, limit int, sortColumn, sortDirection string) (*Paginated[UserDto], error) {
 	params := url.Values{}
 	params.Set("search", search)
 	params.Set("page", strconv.Itoa(page))
@@ -1406,7 +1592,7 @@ func (c *Client) ListUsers(search string, page, limit int, sortColumn, sortDirec
 	params.Set("sort_column", sortColumn)
 	params.Set("sort_direction", sortDirection)
 
-	resp, err := c.request("GET", fmt.Sprintf("/users?%s", params.Encode()), nil, "")
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/users?%s", params.Encode()), nil, "")
 
 	if err != nil {
 		return nil, err
@@ -1426,16 +1612,21 @@ func (c *Client) ListUsers(search string, page, limit int, sortColumn, sortDirec
 	return &users, nil
 }
 
-// CreateUser creates a new user.
+// ListUsers is like ListUsersContext but uses context.Background().
+func (c *Client) ListUsers(search string, page, limit int, sortColumn, sortDirection string) (*Paginated[UserDto], error) {
+	return c.ListUsersContext(context.Background(), search, page, limit, sortColumn, sortDirection)
+}
+
+// CreateUserContext creates a new user.
 //
 // See https://pocket-id.example.com/users
-func (c *Client) CreateUser(userCreateDto UserCreateDto) (*UserDto, error) {
+func (c *Client) CreateUserContext(ctx context.Context, userCreateDto UserCreateDto) (*UserDto, error) {
 	body, err := json.Marshal(userCreateDto)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("POST", "/users", bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "POST", "/users", bytes.NewBuffer(body), "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -1454,11 +1645,16 @@ func (c *Client) CreateUser(userCreateDto UserCreateDto) (*UserDto, error) {
 	return &userDto, nil
 }
 
-// DeleteUser deletes a specific user by ID.
+// CreateUser is like CreateUserContext but uses context.Background().
+func (c *Client) CreateUser(userCreateDto UserCreateDto) (*UserDto, error) {
+	return c.CreateUserContext(context.Background(), userCreateDto)
+}
+
+// DeleteUserContext deletes a specific user by ID.
 //
 // See https://pocket-id.example.com/users/{id}
-func (c *Client) DeleteUser(id string) error {
-	resp, err := c.request("DELETE", fmt.Sprintf("/users/%s", id), nil, "")
+func (c *Client) DeleteUserContext(ctx context.Context, id string) error {
+	resp, err := c.request(ctx, "DELETE", fmt.Sprintf("/users/%s", id), nil, "")
 	if err != nil {
 		return err
 	}
@@ -1471,11 +1667,16 @@ func (c *Client) DeleteUser(id string) error {
 	return nil
 }
 
-// GetUserByID retrieves detailed information about a specific user.
+// DeleteUser is like DeleteUserContext but uses context.Background().
+func (c *Client) DeleteUser(id string) error {
+	return c.DeleteUserContext(context.Background(), id)
+}
+
+// GetUserByIDContext retrieves detailed information about a specific user.
 //
 // See https://pocket-id.example.com/users/{id}
-func (c *Client) GetUserByID(id string) (*UserDto, error) {
-	resp, err := c.request("GET", fmt.Sprintf("/users/%s", id), nil, "")
+func (c *Client) GetUserByIDContext(ctx context.Context, id string) (*UserDto, error) {
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/users/%s", id), nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1494,16 +1695,21 @@ func (c *Client) GetUserByID(id string) (*UserDto, error) {
 	return &userDto, nil
 }
 
-// UpdateUser updates an existing user by ID.
+// GetUserByID is like GetUserByIDContext but uses context.Background().
+func (c *Client) GetUserByID(id string) (*UserDto, error) {
+	return c.GetUserByIDContext(context.Background(), id)
+}
+
+// UpdateUserContext updates an existing user by ID.
 //
 // See https://pocket-id.example.com/users/{id}
-func (c *Client) UpdateUser(id string, userCreateDto UserCreateDto) (*UserDto, error) {
+func (c *Client) UpdateUserContext(ctx context.Context, id string, userCreateDto UserCreateDto) (*UserDto, error) {
 	body, err := json.Marshal(userCreateDto)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("PUT", fmt.Sprintf("/users/%s", id), bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "PUT", fmt.Sprintf("/users/%s", id), bytes.NewBuffer(body), "application/json")
 
 	if err != nil {
 		return nil, err
@@ -1523,11 +1729,16 @@ func (c *Client) UpdateUser(id string, userCreateDto UserCreateDto) (*UserDto, e
 	return &userDto, nil
 }
 
-// GetUserGroups retrieves all groups a specific user belongs to.
+// UpdateUser is like UpdateUserContext but uses context.Background().
+func (c *Client) UpdateUser(id string, userCreateDto UserCreateDto) (*UserDto, error) {
+	return c.UpdateUserContext(context.Background(), id, userCreateDto)
+}
+
+// GetUserGroupsContext retrieves all groups a specific user belongs to.
 //
 // See https://pocket-id.example.com/users/{id}/groups
-func (c *Client) GetUserGroups(id string) ([]UserGroupDto, error) {
-	resp, err := c.request("GET", fmt.Sprintf("/users/%s/groups", id), nil, "")
+func (c *Client) GetUserGroupsContext(ctx context.Context, id string) ([]UserGroupDto, error) {
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/users/%s/groups", id), nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1545,15 +1756,20 @@ func (c *Client) GetUserGroups(id string) ([]UserGroupDto, error) {
 	return groups, nil
 }
 
-// CreateOneTimeAccessTokenForCurrentUser generates a one-time access token for the currently authenticated user.
+// GetUserGroups is like GetUserGroupsContext but uses context.Background().
+func (c *Client) GetUserGroups(id string) ([]UserGroupDto, error) {
+	return c.GetUserGroupsContext(context.Background(), id)
+}
+
+// CreateOneTimeAccessTokenForCurrentUserContext generates a one-time access token for the currently authenticated user.
 //
 // See https://pocket-id.example.com/users/{id}/one-time-access-token
-func (c *Client) CreateOneTimeAccessTokenForCurrentUser(id string, tokenOptions OneTimeAccessTokenCreateDto) (string, error) {
+func (c *Client) CreateOneTimeAccessTokenForCurrentUserContext(ctx context.Context, id string, tokenOptions OneTimeAccessTokenCreateDto) (string, error) {
 	body, err := json.Marshal(tokenOptions)
 	if err != nil {
 		return "", err
 	}
-	resp, err := c.request("POST", fmt.Sprintf("/users/%s/one-time-access-token", id), bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "POST", fmt.Sprintf("/users/%s/one-time-access-token", id), bytes.NewBuffer(body), "application/json")
 	if err != nil {
 		return "", err
 	}
@@ -1574,10 +1790,15 @@ func (c *Client) CreateOneTimeAccessTokenForCurrentUser(id string, tokenOptions
 	return response.Token, nil
 }
 
-// UpdateUserProfilePicture updates a specific user's profile picture.
+// CreateOneTimeAccessTokenForCurrentUser is like CreateOneTimeAccessTokenForCurrentUserContext but uses context.Background().
+func (c *Client) CreateOneTimeAccessTokenForCurrentUser(id string, tokenOptions OneTimeAccessTokenCreateDto) (string, error) {
+	return c.CreateOneTimeAccessTokenForCurrentUserContext(context.Background(), id, tokenOptions)
+}
+
+// UpdateUserProfilePictureContext updates a specific user's profile picture.
 //
 // See https://pocket-id.example.com/users/{id}/profile-picture
-func (c *Client) UpdateUserProfilePicture(id string, file io.Reader) error {
+func (c *Client) UpdateUserProfilePictureContext(ctx context.Context, id string, file io.Reader) error {
 	var b bytes.Buffer
 	w := multipart.NewWriter(&b)
 	fw, err := w.CreateFormFile("file", "profile.png") // Filename doesn't affect server processing
@@ -1589,7 +1810,7 @@ func (c *Client) UpdateUserProfilePicture(id string, file io.Reader) error {
 	}
 	w.Close()
 
-	resp, err := c.request("PUT", fmt.Sprintf("/users/%s/profile-picture", id), &b, w.FormDataContentType())
+	resp, err := c.request(ctx, "PUT", fmt.Sprintf("/users/%s/profile-picture", id), &b, w.FormDataContentType())
 	if err != nil {
 		return err
 	}
@@ -1602,11 +1823,16 @@ func (c *Client) UpdateUserProfilePicture(id string, file io.Reader) error {
 	return nil
 }
 
-// GetUserProfilePicture retrieves a specific user's profile picture.
+// UpdateUserProfilePicture is like UpdateUserProfilePictureContext but uses context.Background().
+func (c *Client) UpdateUserProfilePicture(id string, file io.Reader) error {
+	return c.UpdateUserProfilePictureContext(context.Background(), id, file)
+}
+
+// GetUserProfilePictureContext retrieves a specific user's profile picture.
 //
 // See https://pocket-id.example.com/users/{id}/profile-picture.png
-func (c *Client) GetUserProfilePicture(id string) ([]byte, error) {
-	resp, err := c.request("GET", fmt.Sprintf("/users/%s/profile-picture.png", id), nil, "")
+func (c *Client) GetUserProfilePictureContext(ctx context.Context, id string) ([]byte, error) {
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/users/%s/profile-picture.png", id), nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1619,21 +1845,26 @@ func (c *Client) GetUserProfilePicture(id string) ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
+// GetUserProfilePicture is like GetUserProfilePictureContext but uses context.Background().
+func (c *Client) GetUserProfilePicture(id string) ([]byte, error) {
+	return c.GetUserProfilePictureContext(context.Background(), id)
+}
+
 // UserUpdateUserGroupDto represents the request body for updating user groups for a user.
 type UserUpdateUserGroupDto struct {
 	UserGroupIds []string `json:"userGroupIds" validate:"required"`
 }
 
-// UpdateUserGroups updates the groups a specific user belongs to.
+// UpdateUserGroupsContext updates the groups a specific user belongs to.
 //
 // See https://pocket-id.example.com/users/{id}/user-groups
-func (c *Client) UpdateUserGroups(id string, groupDto UserUpdateUserGroupDto) (*UserDto, error) {
+func (c *Client) UpdateUserGroupsContext(ctx context.Context, id string, groupDto UserUpdateUserGroupDto) (*UserDto, error) {
 	body, err := json.Marshal(groupDto)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("PUT", fmt.Sprintf("/users/%s/user-groups", id), bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "PUT", fmt.Sprintf("/users/%s/user-groups", id), bytes.NewBuffer(body), "application/json")
 
 	if err != nil {
 		return nil, err
@@ -1653,11 +1884,16 @@ func (c *Client) UpdateUserGroups(id string, groupDto UserUpdateUserGroupDto) (*
 	return &userDto, nil
 }
 
-// GetCurrentUser retrieves information about the currently authenticated user.
+// UpdateUserGroups is like UpdateUserGroupsContext but uses context.Background().
+func (c *Client) UpdateUserGroups(id string, groupDto UserUpdateUserGroupDto) (*UserDto, error) {
+	return c.UpdateUserGroupsContext(context.Background(), id, groupDto)
+}
+
+// GetCurrentUserContext retrieves information about the currently authenticated user.
 //
 // See https://pocket-id.example.com/users/me
-func (c *Client) GetCurrentUser() (*UserDto, error) {
-	resp, err := c.request("GET", "/users/me", nil, "")
+func (c *Client) GetCurrentUserContext(ctx context.Context) (*UserDto, error) {
+	resp, err := c.request(ctx, "GET", "/users/me", nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1676,15 +1912,20 @@ func (c *Client) GetCurrentUser() (*UserDto, error) {
 	return &userDto, nil
 }
 
-// UpdateCurrentUser updates the currently authenticated user's information.
+// GetCurrentUser is like GetCurrentUserContext but uses context.Background().
+func (c *Client) GetCurrentUser() (*UserDto, error) {
+	return c.GetCurrentUserContext(context.Background())
+}
+
+// UpdateCurrentUserContext updates the currently authenticated user's information.
 //
 // See https://pocket-id.example.com/users/me
-func (c *Client) UpdateCurrentUser(userCreateDto UserCreateDto) (*UserDto, error) {
+func (c *Client) UpdateCurrentUserContext(ctx context.Context, userCreateDto UserCreateDto) (*UserDto, error) {
 	body, err := json.Marshal(userCreateDto)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.request("PUT", "/users/me", bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "PUT", "/users/me", bytes.NewBuffer(body), "application/json")
 
 	if err != nil {
 		return nil, err
@@ -1704,10 +1945,15 @@ func (c *Client) UpdateCurrentUser(userCreateDto UserCreateDto) (*UserDto, error
 	return &userDto, nil
 }
 
-// UpdateCurrentUserProfilePicture updates the currently authenticated user's profile picture.
+// UpdateCurrentUser is like UpdateCurrentUserContext but uses context.Background().
+func (c *Client) UpdateCurrentUser(userCreateDto UserCreateDto) (*UserDto, error) {
+	return c.UpdateCurrentUserContext(context.Background(), userCreateDto)
+}
+
+// UpdateCurrentUserProfilePictureContext updates the currently authenticated user's profile picture.
 //
 // See https://pocket-id.example.com/users/me/profile-picture
-func (c *Client) UpdateCurrentUserProfilePicture(file io.Reader) error {
+func (c *Client) UpdateCurrentUserProfilePictureContext(ctx context.Context, file io.Reader) error {
 	var b bytes.Buffer
 	w := multipart.NewWriter(&b)
 	fw, err := w.CreateFormFile("file", "profile.png") // Filename doesn't affect server processing
@@ -1719,7 +1965,7 @@ func (c *Client) UpdateCurrentUserProfilePicture(file io.Reader) error {
 	}
 	w.Close()
 
-	resp, err := c.request("PUT", "/users/me/profile-picture", &b, w.FormDataContentType())
+	resp, err := c.request(ctx, "PUT", "/users/me/profile-picture", &b, w.FormDataContentType())
 	if err != nil {
 		return err
 	}
@@ -1732,11 +1978,16 @@ func (c *Client) UpdateCurrentUserProfilePicture(file io.Reader) error {
 	return nil
 }
 
-// GetCurrentUserProfilePicture retrieves the currently authenticated user's profile picture.
+// UpdateCurrentUserProfilePicture is like UpdateCurrentUserProfilePictureContext but uses context.Background().
+func (c *Client) UpdateCurrentUserProfilePicture(file io.Reader) error {
+	return c.UpdateCurrentUserProfilePictureContext(context.Background(), file)
+}
+
+// GetCurrentUserProfilePictureContext retrieves the currently authenticated user's profile picture.
 //
 // See https://pocket-id.example.com/users/me/profile-picture.png
-func (c *Client) GetCurrentUserProfilePicture() ([]byte, error) {
-	resp, err := c.request("GET", "/users/me/profile-picture.png", nil, "")
+func (c *Client) GetCurrentUserProfilePictureContext(ctx context.Context) ([]byte, error) {
+	resp, err := c.request(ctx, "GET", "/users/me/profile-picture.png", nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1749,6 +2000,11 @@ func (c *Client) GetCurrentUserProfilePicture() ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
+// GetCurrentUserProfilePicture is like GetCurrentUserProfilePictureContext but uses context.Background().
+func (c *Client) GetCurrentUserProfilePicture() ([]byte, error) {
+	return c.GetCurrentUserProfilePictureContext(context.Background())
+}
+
 // --- User Groups ---
 
 // UserGroupCreateDto represents the request body for creating a user group.
@@ -1773,10 +2029,10 @@ type UserGroupUpdateUsersDto struct {
 	UserIds []string `json:"userIds" validate:"required"`
 }
 
-// ListUserGroups gets a paginated list of user groups.
+// ListUserGroupsContext gets a paginated list of user groups.
 //
 // See https://pocket-id.example.com/user-groups
-func (c *Client) ListUserGroups(search string, page, limit int, sortColumn, sortDirection string) (*Paginated[UserGroupDtoWithUserCount], error) {
+func (c *Client) ListUserGroupsContext(ctx context.Context, search string, page, limit int, sortColumn, sortDirection string) (*Paginated[UserGroupDtoWithUserCount], error) {
 	params := url.Values{}
 	params.Set("search", search)
 	params.Set("page", strconv.Itoa(page))
@@ -1784,7 +2040,7 @@ func (c *Client) ListUserGroups(search string, page, limit int, sortColumn, sort
 	params.Set("sort_column", sortColumn)
 	params.Set("sort_direction", sortDirection)
 
-	resp, err := c.request("GET", fmt.Sprintf("/user-groups?%s", params.Encode()), nil, "")
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/user-groups?%s", params.Encode()), nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1803,16 +2059,21 @@ func (c *Client) ListUserGroups(search string, page, limit int, sortColumn, sort
 	return &userGroups, nil
 }
 
-// CreateUserGroup creates a new user group.
+// ListUserGroups is like ListUserGroupsContext but uses context.Background().
+func (c *Client) ListUserGroups(search string, page, limit int, sortColumn, sortDirection string) (*Paginated[UserGroupDtoWithUserCount], error) {
+	return c.ListUserGroupsContext(context.Background(), search, page, limit, sortColumn, sortDirection)
+}
+
+// CreateUserGroupContext creates a new user group.
 //
 // See https://pocket-id.example.com/user-groups
-func (c *Client) CreateUserGroup(userGroupCreateDto UserGroupCreateDto) (*UserGroupDtoWithUsers, error) {
+func (c *Client) CreateUserGroupContext(ctx context.Context, userGroupCreateDto UserGroupCreateDto) (*UserGroupDtoWithUsers, error) {
 	body, err := json.Marshal(userGroupCreateDto)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("POST", "/user-groups", bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "POST", "/user-groups", bytes.NewBuffer(body), "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -1831,11 +2092,16 @@ func (c *Client) CreateUserGroup(userGroupCreateDto UserGroupCreateDto) (*UserGr
 	return &userGroupDto, nil
 }
 
-// DeleteUserGroup deletes a specific user group by ID.
+// CreateUserGroup is like CreateUserGroupContext but uses context.Background().
+func (c *Client) CreateUserGroup(userGroupCreateDto UserGroupCreateDto) (*UserGroupDtoWithUsers, error) {
+	return c.CreateUserGroupContext(context.Background(), userGroupCreateDto)
+}
+
+// DeleteUserGroupContext deletes a specific user group by ID.
 //
 // See https://pocket-id.example.com/user-groups/{id}
-func (c *Client) DeleteUserGroup(id string) error {
-	resp, err := c.request("DELETE", fmt.Sprintf("/user-groups/%s", id), nil, "")
+func (c *Client) DeleteUserGroupContext(ctx context.Context, id string) error {
+	resp, err := c.request(ctx, "DELETE", fmt.Sprintf("/user-groups/%s", id), nil, "")
 
 	if err != nil {
 		return err
@@ -1849,11 +2115,16 @@ func (c *Client) DeleteUserGroup(id string) error {
 	return nil
 }
 
-// GetUserGroupByID retrieves detailed information about a specific user group, including its users.
+// DeleteUserGroup is like DeleteUserGroupContext but uses context.Background().
+func (c *Client) DeleteUserGroup(id string) error {
+	return c.DeleteUserGroupContext(context.Background(), id)
+}
+
+// GetUserGroupByIDContext retrieves detailed information about a specific user group, including its users.
 //
 // See https://pocket-id.example.com/user-groups/{id}
-func (c *Client) GetUserGroupByID(id string) (*UserGroupDtoWithUsers, error) {
-	resp, err := c.request("GET", fmt.Sprintf("/user-groups/%s", id), nil, "")
+func (c *Client) GetUserGroupByIDContext(ctx context.Context, id string) (*UserGroupDtoWithUsers, error) {
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/user-groups/%s", id), nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1872,16 +2143,21 @@ func (c *Client) GetUserGroupByID(id string) (*UserGroupDtoWithUsers, error) {
 	return &userGroupDto, nil
 }
 
-// UpdateUserGroup updates an existing user group by ID.
+// GetUserGroupByID is like GetUserGroupByIDContext but uses context.Background().
+func (c *Client) GetUserGroupByID(id string) (*UserGroupDtoWithUsers, error) {
+	return c.GetUserGroupByIDContext(context.Background(), id)
+}
+
+// UpdateUserGroupContext updates an existing user group by ID.
 //
 // See https://pocket-id.example.com/user-groups/{id}
-func (c *Client) UpdateUserGroup(id string, userGroupCreateDto UserGroupCreateDto) (*UserGroupDtoWithUsers, error) {
+func (c *Client) UpdateUserGroupContext(ctx context.Context, id string, userGroupCreateDto UserGroupCreateDto) (*UserGroupDtoWithUsers, error) {
 	body, err := json.Marshal(userGroupCreateDto)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("PUT", fmt.Sprintf("/user-groups/%s", id), bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "PUT", fmt.Sprintf("/user-groups/%s", id), bytes.NewBuffer(body), "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -1900,16 +2176,21 @@ func (c *Client) UpdateUserGroup(id string, userGroupCreateDto UserGroupCreateDt
 	return &userGroupDto, nil
 }
 
-// UpdateUsersInGroup updates the list of users belonging to a specific user group.
+// UpdateUserGroup is like UpdateUserGroupContext but uses context.Background().
+func (c *Client) UpdateUserGroup(id string, userGroupCreateDto UserGroupCreateDto) (*UserGroupDtoWithUsers, error) {
+	return c.UpdateUserGroupContext(context.Background(), id, userGroupCreateDto)
+}
+
+// UpdateUsersInGroupContext updates the list of users belonging to a specific user group.
 //
 // See https://pocket-id.example.com/user-groups/{id}/users
-func (c *Client) UpdateUsersInGroup(id string, users UserGroupUpdateUsersDto) (*UserGroupDtoWithUsers, error) {
+func (c *Client) UpdateUsersInGroupContext(ctx context.Context, id string, users UserGroupUpdateUsersDto) (*UserGroupDtoWithUsers, error) {
 	body, err := json.Marshal(users)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.request("PUT", fmt.Sprintf("/user-groups/%s/users", id), bytes.NewBuffer(body), "application/json")
+	resp, err := c.request(ctx, "PUT", fmt.Sprintf("/user-groups/%s/users", id), bytes.NewBuffer(body), "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -1927,3 +2208,8 @@ func (c *Client) UpdateUsersInGroup(id string, users UserGroupUpdateUsersDto) (*
 
 	return &userGroupDto, nil
 }
+
+// UpdateUsersInGroup is like UpdateUsersInGroupContext but uses context.Background().
+func (c *Client) UpdateUsersInGroup(id string, users UserGroupUpdateUsersDto) (*UserGroupDtoWithUsers, error) {
+	return c.UpdateUsersInGroupContext(context.Background(), id, users)
+}
@@ -1,123 +1,281 @@
 package revolt
 
-import "fmt"
-
-// Client cache struct.
-type Cache struct {
-	Users    []*User    `json:"users"`
-	Servers  []*Server  `json:"servers"`
-	Channels []*Channel `json:"channels"`
-	Members  []*Member  `json:"members"`
-}
-
-// Get a channel from cache by Id.
-// Will return an empty channel struct if not found.
-func (c *Cache) GetChannel(id string) *Channel {
-	for _, i := range c.Channels {
-		if i.Id == id {
-			return i
-		}
-	}
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"sync"
+)
+
+var ErrNotCached = errors.New("revolt: not in cache")
+
+// Cache is what the client knows about channels, servers, users and server
+// members. It is filled from the Ready event when the client connects and
+// kept up to date by later events.
+//
+// It also remembers the last message seen in each channel so that messages
+// sent while the client was disconnected can be fetched when it comes back.
+// Caches that persist (such as SQLiteCache) let that work across restarts.
+//
+// Lookups return ErrNotCached if the object isn't in the cache. Removing
+// something that isn't cached is not an error.
+type Cache interface {
+	Channel(ctx context.Context, id string) (*Channel, error)
+	PutChannel(ctx context.Context, ch *Channel) error
+	RemoveChannel(ctx context.Context, id string) error
+
+	Server(ctx context.Context, id string) (*Server, error)
+	PutServer(ctx context.Context, srv *Server) error
+	RemoveServer(ctx context.Context, id string) error
 
-	return &Channel{}
+	User(ctx context.Context, id string) (*User, error)
+	PutUser(ctx context.Context, u *User) error
+	RemoveUser(ctx context.Context, id string) error
+
+	Member(ctx context.Context, serverID, userID string) (*Member, error)
+	PutMember(ctx context.Context, m *Member) error
+	RemoveMember(ctx context.Context, serverID, userID string) error
+
+	// SetLastMessage records that messageID was seen in channelID. IDs
+	// older than the one already recorded are ignored.
+	SetLastMessage(ctx context.Context, channelID, messageID string) error
+
+	// LastMessages returns the last message ID seen in each channel.
+	LastMessages(ctx context.Context) (map[string]string, error)
 }
 
-// Get a server from cache by Id.
-// Will return an empty server struct if not found.
-func (c *Cache) GetServer(id string) *Server {
-	for _, i := range c.Servers {
-		if i.Id == id {
-			return i
-		}
-	}
+var (
+	_ Cache = &MemoryCache{}
+	_ Cache = &SQLiteCache{}
+)
 
-	return &Server{}
+// MemoryCache is a Cache that keeps everything in memory. The zero value is
+// ready to use.
+type MemoryCache struct {
+	lock         sync.RWMutex
+	channels     map[string]*Channel
+	servers      map[string]*Server
+	users        map[string]*User
+	members      map[memberKey]*Member
+	lastMessages map[string]string
 }
 
-// Get an user from cache by Id.
-// Will return an empty user struct if not found.
-func (c *Cache) GetUser(id string) *User {
-	for _, i := range c.Users {
-		if i.Id == id {
-			return i
-		}
+type memberKey struct {
+	server, user string
+}
+
+// NewMemoryCache creates an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{}
+}
+
+func cacheGet[K comparable, V any](mc *MemoryCache, m map[K]*V, k K) (*V, error) {
+	mc.lock.RLock()
+	defer mc.lock.RUnlock()
+
+	v, ok := m[k]
+	if !ok {
+		return nil, ErrNotCached
 	}
 
-	return &User{}
+	// Callers get a copy so that changing it doesn't change the cache.
+	result := *v
+	return &result, nil
 }
 
-// Get a member from cache by Id.
-// Will return an empty member struct if not found.
-func (c *Cache) GetMember(id string) *Member {
-	for _, i := range c.Members {
-		if i.Informations.UserId == id {
-			return i
-		}
+func cachePut[K comparable, V any](mc *MemoryCache, m *map[K]*V, k K, v *V) error {
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+
+	if *m == nil {
+		*m = map[K]*V{}
 	}
 
-	return &Member{}
+	cp := *v
+	(*m)[k] = &cp
+	return nil
 }
 
-// Remove a channel from cache by Id.
-// Will not delete the channel, just deletes the channel from cache.
-// Will change the entire channel cache order!
-func (c *Cache) RemoveChannel(id string) error {
-	for i, v := range c.Channels {
-		if v.Id == id {
-			c.Channels[i] = c.Channels[len(c.Channels)-1]
-			c.Channels = c.Channels[:len(c.Channels)-1]
+func cacheRemove[K comparable, V any](mc *MemoryCache, m map[K]*V, k K) error {
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
 
-			return nil
-		}
+	delete(m, k)
+	return nil
+}
+
+func (mc *MemoryCache) Channel(_ context.Context, id string) (*Channel, error) {
+	return cacheGet(mc, mc.channels, id)
+}
+
+func (mc *MemoryCache) PutChannel(_ context.Context, ch *Channel) error {
+	return cachePut(mc, &mc.channels, ch.Id, ch)
+}
+
+func (mc *MemoryCache) RemoveChannel(_ context.Context, id string) error {
+	return cacheRemove(mc, mc.channels, id)
+}
+
+func (mc *MemoryCache) Server(_ context.Context, id string) (*Server, error) {
+	return cacheGet(mc, mc.servers, id)
+}
+
+func (mc *MemoryCache) PutServer(_ context.Context, srv *Server) error {
+	return cachePut(mc, &mc.servers, srv.Id, srv)
+}
+
+func (mc *MemoryCache) RemoveServer(_ context.Context, id string) error {
+	return cacheRemove(mc, mc.servers, id)
+}
+
+func (mc *MemoryCache) User(_ context.Context, id string) (*User, error) {
+	return cacheGet(mc, mc.users, id)
+}
+
+func (mc *MemoryCache) PutUser(_ context.Context, u *User) error {
+	return cachePut(mc, &mc.users, u.Id, u)
+}
+
+func (mc *MemoryCache) RemoveUser(_ context.Context, id string) error {
+	return cacheRemove(mc, mc.users, id)
+}
+
+func (mc *MemoryCache) Member(_ context.Context, serverID, userID string) (*Member, error) {
+	return cacheGet(mc, mc.members, memberKey{serverID, userID})
+}
+
+func (mc *MemoryCache) PutMember(_ context.Context, m *Member) error {
+	return cachePut(mc, &mc.members, memberKey{m.Informations.ServerId, m.Informations.UserId}, m)
+}
+
+func (mc *MemoryCache) RemoveMember(_ context.Context, serverID, userID string) error {
+	return cacheRemove(mc, mc.members, memberKey{serverID, userID})
+}
+
+func (mc *MemoryCache) SetLastMessage(_ context.Context, channelID, messageID string) error {
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+
+	if mc.lastMessages == nil {
+		mc.lastMessages = map[string]string{}
+	}
+
+	// Message IDs are ULIDs, so newer messages sort after older ones.
+	if messageID > mc.lastMessages[channelID] {
+		mc.lastMessages[channelID] = messageID
 	}
 
-	return fmt.Errorf("channel not found")
+	return nil
 }
 
-// Remove a server from cache by Id.
-// Will not delete the server, just deletes the server from cache.
-// Will change the entire server cache order!
-func (c *Cache) RemoveServer(id string) error {
-	for i, v := range c.Servers {
-		if v.Id == id {
-			c.Servers[i] = c.Servers[len(c.Servers)-1]
-			c.Servers = c.Servers[:len(c.Servers)-1]
+func (mc *MemoryCache) LastMessages(context.Context) (map[string]string, error) {
+	mc.lock.RLock()
+	defer mc.lock.RUnlock()
 
-			return nil
-		}
+	return maps.Clone(mc.lastMessages), nil
+}
+
+// cacheReady fills the cache from the Ready event sent after authenticating.
+func cacheReady(ctx context.Context, cache Cache, data []byte) error {
+	var ready struct {
+		Users    []*User    `json:"users"`
+		Servers  []*Server  `json:"servers"`
+		Channels []*Channel `json:"channels"`
+		Members  []*Member  `json:"members"`
+	}
+	if err := json.Unmarshal(data, &ready); err != nil {
+		return err
 	}
 
-	return fmt.Errorf("server not found")
+	var errs []error
+	for _, u := range ready.Users {
+		errs = append(errs, cache.PutUser(ctx, u))
+	}
+	for _, srv := range ready.Servers {
+		errs = append(errs, cache.PutServer(ctx, srv))
+	}
+	for _, ch := range ready.Channels {
+		errs = append(errs, cache.PutChannel(ctx, ch))
+	}
+	for _, m := range ready.Members {
+		errs = append(errs, cache.PutMember(ctx, m))
+	}
+
+	return errors.Join(errs...)
 }
 
-// Remove an user from cache by Id.
-// Will not delete the user, just deletes the user from cache.
-// Will change the entire user cache order!
-func (c *Cache) RemoveUser(id string) error {
-	for i, v := range c.Users {
-		if v.Id == id {
-			c.Users[i] = c.Users[len(c.Users)-1]
-			c.Users = c.Users[:len(c.Users)-1]
+// patchCached applies the partial object in an update event to the cached
+// copy. Fields named in clear are reset to their zero value by clearField.
+// Nothing happens if the object isn't cached.
+func patchCached[T any](ctx context.Context, get func(context.Context) (*T, error), put func(context.Context, *T) error, data json.RawMessage, clear []string, clearField func(*T, string)) error {
+	v, err := get(ctx)
+	if errors.Is(err, ErrNotCached) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
 
-			return nil
+	if len(data) != 0 {
+		if err := json.Unmarshal(data, v); err != nil {
+			return err
 		}
 	}
 
-	return fmt.Errorf("user not found")
+	for _, field := range clear {
+		clearField(v, field)
+	}
+
+	return put(ctx, v)
 }
 
-// Remove a member from cache by Id.
-// Will not delete the member, just deletes the member from cache.
-// Will change the entire member cache order!
-func (c *Cache) RemoveMember(id string) error {
-	for i, v := range c.Members {
-		if v.Informations.UserId == id {
-			c.Members[i] = c.Members[len(c.Members)-1]
-			c.Members = c.Members[:len(c.Members)-1]
+func clearChannelField(ch *Channel, field string) {
+	switch field {
+	case "Icon":
+		ch.Icon = nil
+	case "Description":
+		ch.Description = ""
+	}
+}
 
-			return nil
-		}
+func clearServerField(srv *Server, field string) {
+	switch field {
+	case "Icon":
+		srv.Icon = nil
+	case "Banner":
+		srv.Banner = nil
+	case "Description":
+		srv.Description = ""
+	case "Categories":
+		srv.Categories = nil
+	case "SystemMessages":
+		srv.SystemMessages = nil
+	}
+}
+
+func clearMemberField(m *Member, field string) {
+	switch field {
+	case "Nickname":
+		m.Nickname = ""
+	case "Avatar":
+		m.Avatar = nil
+	case "Roles":
+		m.Roles = nil
 	}
+}
 
-	return fmt.Errorf("member not found")
+func clearUserField(u *User, field string) {
+	switch field {
+	case "Avatar":
+		u.Avatar = nil
+	case "StatusText":
+		if u.Status != nil {
+			u.Status.Text = ""
+		}
+	case "StatusPresence":
+		if u.Status != nil {
+			u.Status.Presence = ""
+		}
+	}
 }
@@ -0,0 +1,113 @@
+package revolt
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func testCache(t *testing.T, c Cache) {
+	t.Helper()
+	ctx := t.Context()
+
+	if _, err := c.Channel(ctx, "c1"); !errors.Is(err, ErrNotCached) {
+		t.Errorf("want ErrNotCached for an empty cache, got: %v", err)
+	}
+
+	if err := c.PutChannel(ctx, &Channel{Id: "c1", Name: "general"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.PutServer(ctx, &Server{Id: "s1", Name: "Within"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.PutUser(ctx, &User{Id: "u1", Username: "cadey"}); err != nil {
+		t.Fatal(err)
+	}
+	m := &Member{Nickname: "Xe"}
+	m.Informations.ServerId, m.Informations.UserId = "s1", "u1"
+	if err := c.PutMember(ctx, m); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing what was put in doesn't change the cache.
+	m.Nickname = "someone else"
+
+	if ch, err := c.Channel(ctx, "c1"); err != nil || ch.Name != "general" {
+		t.Errorf("Channel: %+v, %v", ch, err)
+	}
+	if srv, err := c.Server(ctx, "s1"); err != nil || srv.Name != "Within" {
+		t.Errorf("Server: %+v, %v", srv, err)
+	}
+	if u, err := c.User(ctx, "u1"); err != nil || u.Username != "cadey" {
+		t.Errorf("User: %+v, %v", u, err)
+	}
+	if m, err := c.Member(ctx, "s1", "u1"); err != nil || m.Nickname != "Xe" {
+		t.Errorf("Member: %+v, %v", m, err)
+	}
+
+	if err := c.RemoveMember(ctx, "s1", "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Member(ctx, "s1", "u1"); !errors.Is(err, ErrNotCached) {
+		t.Errorf("want removed member to be gone, got: %v", err)
+	}
+	if err := c.RemoveChannel(ctx, "nope"); err != nil {
+		t.Errorf("removing something that isn't cached failed: %v", err)
+	}
+
+	for _, id := range []string{"01B", "01C", "01A"} {
+		if err := c.SetLastMessage(ctx, "c1", id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.SetLastMessage(ctx, "c2", "01A"); err != nil {
+		t.Fatal(err)
+	}
+
+	last, err := c.LastMessages(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(last) != 2 || last["c1"] != "01C" || last["c2"] != "01A" {
+		t.Errorf("wrong last messages: %v", last)
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	testCache(t, NewMemoryCache())
+	testCache(t, &MemoryCache{})
+}
+
+func openSQLiteCache(t *testing.T, fname string) *SQLiteCache {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	sc, err := NewSQLiteCache(t.Context(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sc
+}
+
+func TestSQLiteCache(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "revolt.db")
+	testCache(t, openSQLiteCache(t, fname))
+
+	// Everything is still there after reopening the database.
+	sc := openSQLiteCache(t, fname)
+	if ch, err := sc.Channel(t.Context(), "c1"); err != nil || ch.Name != "general" {
+		t.Errorf("channel is gone after reopening: %+v, %v", ch, err)
+	}
+	if last, err := sc.LastMessages(t.Context()); err != nil || last["c1"] != "01C" {
+		t.Errorf("last messages are gone after reopening: %v, %v", last, err)
+	}
+}
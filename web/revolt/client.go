@@ -84,6 +84,7 @@ func New(token string) (*Client, error) {
 		WSURL:    "wss://ws.revolt.chat",
 		Ticker:   time.NewTicker(3 * time.Second),
 		Settings: settings,
+		Cache:    NewMemoryCache(),
 	}, nil
 }
 
@@ -110,6 +111,7 @@ func NewWithEndpoint(token, baseURL, wsURL string) (*Client, error) {
 		WSURL:    wsURL,
 		Ticker:   time.NewTicker(3 * time.Second),
 		Settings: settings,
+		Cache:    NewMemoryCache(),
 	}, nil
 }
 
@@ -119,7 +121,7 @@ type Client struct {
 	Token    string
 	Socket   gowebsocket.Socket
 	HTTP     *http.Client
-	Cache    *Cache
+	Cache    Cache
 	BaseURL  string
 	WSURL    string
 	Ticker   *time.Ticker
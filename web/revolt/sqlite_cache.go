@@ -0,0 +1,157 @@
+package revolt
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+)
+
+// SQLiteCache is a Cache stored in a SQLite database, so that it survives
+// restarts. Objects are stored as JSON.
+type SQLiteCache struct {
+	db *sql.DB
+}
+
+// NewSQLiteCache creates the cache's tables in db if they don't exist. The
+// database can be opened with any SQLite driver.
+func NewSQLiteCache(ctx context.Context, db *sql.DB) (*SQLiteCache, error) {
+	for _, stmt := range []string{
+		`CREATE TABLE IF NOT EXISTS revolt_cache
+			( kind TEXT NOT NULL
+			, id   TEXT NOT NULL
+			, data TEXT NOT NULL
+			, PRIMARY KEY (kind, id)
+			)`,
+		`CREATE TABLE IF NOT EXISTS revolt_last_messages
+			( channel_id TEXT PRIMARY KEY
+			, message_id TEXT NOT NULL
+			)`,
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SQLiteCache{db: db}, nil
+}
+
+const (
+	kindChannel = "channel"
+	kindServer  = "server"
+	kindUser    = "user"
+	kindMember  = "member"
+)
+
+func sqliteGet[T any](ctx context.Context, sc *SQLiteCache, kind, id string) (*T, error) {
+	var data string
+	err := sc.db.QueryRowContext(ctx, "SELECT data FROM revolt_cache WHERE kind = ? AND id = ?", kind, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotCached
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result T
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (sc *SQLiteCache) put(ctx context.Context, kind, id string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = sc.db.ExecContext(ctx, `INSERT INTO revolt_cache (kind, id, data) VALUES (?, ?, ?)
+		ON CONFLICT (kind, id) DO UPDATE SET data = excluded.data`, kind, id, string(data))
+	return err
+}
+
+func (sc *SQLiteCache) remove(ctx context.Context, kind, id string) error {
+	_, err := sc.db.ExecContext(ctx, "DELETE FROM revolt_cache WHERE kind = ? AND id = ?", kind, id)
+	return err
+}
+
+func memberID(serverID, userID string) string {
+	return serverID + "/" + userID
+}
+
+func (sc *SQLiteCache) Channel(ctx context.Context, id string) (*Channel, error) {
+	return sqliteGet[Channel](ctx, sc, kindChannel, id)
+}
+
+func (sc *SQLiteCache) PutChannel(ctx context.Context, ch *Channel) error {
+	return sc.put(ctx, kindChannel, ch.Id, ch)
+}
+
+func (sc *SQLiteCache) RemoveChannel(ctx context.Context, id string) error {
+	return sc.remove(ctx, kindChannel, id)
+}
+
+func (sc *SQLiteCache) Server(ctx context.Context, id string) (*Server, error) {
+	return sqliteGet[Server](ctx, sc, kindServer, id)
+}
+
+func (sc *SQLiteCache) PutServer(ctx context.Context, srv *Server) error {
+	return sc.put(ctx, kindServer, srv.Id, srv)
+}
+
+func (sc *SQLiteCache) RemoveServer(ctx context.Context, id string) error {
+	return sc.remove(ctx, kindServer, id)
+}
+
+func (sc *SQLiteCache) User(ctx context.Context, id string) (*User, error) {
+	return sqliteGet[User](ctx, sc, kindUser, id)
+}
+
+func (sc *SQLiteCache) PutUser(ctx context.Context, u *User) error {
+	return sc.put(ctx, kindUser, u.Id, u)
+}
+
+func (sc *SQLiteCache) RemoveUser(ctx context.Context, id string) error {
+	return sc.remove(ctx, kindUser, id)
+}
+
+func (sc *SQLiteCache) Member(ctx context.Context, serverID, userID string) (*Member, error) {
+	return sqliteGet[Member](ctx, sc, kindMember, memberID(serverID, userID))
+}
+
+func (sc *SQLiteCache) PutMember(ctx context.Context, m *Member) error {
+	return sc.put(ctx, kindMember, memberID(m.Informations.ServerId, m.Informations.UserId), m)
+}
+
+func (sc *SQLiteCache) RemoveMember(ctx context.Context, serverID, userID string) error {
+	return sc.remove(ctx, kindMember, memberID(serverID, userID))
+}
+
+func (sc *SQLiteCache) SetLastMessage(ctx context.Context, channelID, messageID string) error {
+	// Message IDs are ULIDs, so newer messages sort after older ones.
+	_, err := sc.db.ExecContext(ctx, `INSERT INTO revolt_last_messages (channel_id, message_id) VALUES (?, ?)
+		ON CONFLICT (channel_id) DO UPDATE SET message_id = excluded.message_id
+		WHERE excluded.message_id > revolt_last_messages.message_id`, channelID, messageID)
+	return err
+}
+
+func (sc *SQLiteCache) LastMessages(ctx context.Context) (map[string]string, error) {
+	rows, err := sc.db.QueryContext(ctx, "SELECT channel_id, message_id FROM revolt_last_messages")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[string]string{}
+	for rows.Next() {
+		var channelID, messageID string
+		if err := rows.Scan(&channelID, &messageID); err != nil {
+			return nil, err
+		}
+		result[channelID] = messageID
+	}
+
+	return result, rows.Err()
+}
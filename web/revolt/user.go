@@ -10,7 +10,7 @@ import (
 
 // User struct.
 type User struct {
-	Client    *Client
+	Client    *Client `json:"-"`
 	CreatedAt time.Time
 
 	Id             string           `json:"_id"`
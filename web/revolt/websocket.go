@@ -3,119 +3,312 @@ package revolt
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync/atomic"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"nhooyr.io/websocket"
 )
 
+var ErrHeartbeatTimeout = errors.New("revolt: no response to heartbeat")
+
+const (
+	// maxMessageSize is the largest websocket message the client reads.
+	// Ready events carry every server, channel and member the client can
+	// see, so they can be big.
+	maxMessageSize = 16 << 20
+
+	// replayPage and maxReplay are how many missed messages are fetched
+	// per request and per channel after reconnecting.
+	replayPage = 100
+	maxReplay  = 1000
+)
+
+// ConnectOptions changes how the client keeps its websocket connection up.
+type ConnectOptions struct {
+	// InitialBackoff and MaxBackoff bound how long to wait between
+	// reconnects. The wait doubles after each failed connection, with
+	// jitter, and goes back to InitialBackoff once a connection is ready.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// HeartbeatInterval is how often the client pings the server. If the
+	// server doesn't send anything for HeartbeatTimeout, the connection is
+	// assumed to be dead and is replaced.
+	HeartbeatInterval time.Duration
+	HeartbeatTimeout  time.Duration
+}
+
+func (co ConnectOptions) backOff() backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = time.Second
+	bo.MaxInterval = 5 * time.Minute
+	bo.MaxElapsedTime = 0 // never give up
+
+	if co.InitialBackoff != 0 {
+		bo.InitialInterval = co.InitialBackoff
+	}
+	if co.MaxBackoff != 0 {
+		bo.MaxInterval = co.MaxBackoff
+	}
+
+	bo.Reset()
+	return bo
+}
+
+func (co ConnectOptions) heartbeat() (interval, timeout time.Duration) {
+	interval, timeout = 30*time.Second, 90*time.Second
+
+	if co.HeartbeatInterval != 0 {
+		interval = co.HeartbeatInterval
+	}
+	if co.HeartbeatTimeout != 0 {
+		timeout = co.HeartbeatTimeout
+	}
+
+	return interval, timeout
+}
+
+// Connect listens for events in the background and passes them to handler
+// until ctx is cancelled, reconnecting as needed.
 func (c *Client) Connect(ctx context.Context, handler Handler) {
+	c.ConnectWithOptions(ctx, handler, ConnectOptions{})
+}
+
+// ConnectWithOptions is like Connect, but lets you change how reconnects and
+// heartbeats work.
+//
+// Every time the client connects, it refreshes c.Cache from the Ready event.
+// It then fetches the messages sent since the last one it saw in each
+// channel and passes them to handler.MessageCreate, oldest first, before any
+// live events. If c.Cache is nil, a MemoryCache is used.
+func (c *Client) ConnectWithOptions(ctx context.Context, handler Handler, co ConnectOptions) {
+	if c.Cache == nil {
+		c.Cache = NewMemoryCache()
+	}
+
+	go c.run(ctx, handler, co)
+}
+
+func (c *Client) run(ctx context.Context, handler Handler, co ConnectOptions) {
 	lg := slog.Default().With("at", "websocket-client")
+	bo := co.backOff()
+
+	for {
+		r := &replayer{Handler: handler, c: c, replayed: map[string]bool{}}
+		err := c.doWebsocket(ctx, co, r)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if r.ready {
+			bo.Reset()
+		}
 
-	go func(ctx context.Context) {
-		if err := c.doWebsocket(ctx, c.Token, c.WSURL, handler); err != nil {
-			lg.ErrorContext(ctx, "websocket error, retrying", "err", err)
+		wait := bo.NextBackOff()
+		lg.ErrorContext(ctx, "websocket error, reconnecting", "err", err, "wait", wait)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
 		}
+	}
+}
+
+// replayer wraps a Handler for one connection. Once the connection is ready,
+// it passes on the messages that were missed while disconnected and then
+// drops live copies of them.
+type replayer struct {
+	Handler
+	c        *Client
+	ready    bool
+	replayed map[string]bool
+}
+
+func (r *replayer) Ready(ctx context.Context) error {
+	r.ready = true
+	err := r.Handler.Ready(ctx)
+
+	if err := r.c.replay(ctx, r.Handler, r.replayed); err != nil {
+		slog.ErrorContext(ctx, "can't fetch missed messages", "err", err)
+	}
+
+	return err
+}
+
+func (r *replayer) MessageCreate(ctx context.Context, msg *Message) error {
+	if r.replayed[msg.ID] {
+		return nil
+	}
+
+	return r.Handler.MessageCreate(ctx, msg)
+}
+
+// replay fetches the messages sent after the last one seen in each channel
+// and passes them to handler.
+func (c *Client) replay(ctx context.Context, handler Handler, replayed map[string]bool) error {
+	last, err := c.Cache.LastMessages(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, channelID := range slices.Sorted(maps.Keys(last)) {
+		after := last[channelID]
 
-		t := time.NewTicker(30 * time.Second)
-		defer t.Stop()
+		for fetched := 0; fetched < maxReplay; {
+			msgs, err := c.ChannelFetchMessages(ctx, channelID, url.Values{
+				"after": {after},
+				"sort":  {"Oldest"},
+				"limit": {strconv.Itoa(replayPage)},
+			})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("channel %s: %w", channelID, err))
+				break
+			}
+
+			for _, msg := range msgs.Messages {
+				replayed[msg.ID] = true
+				after = msg.ID
 
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-t.C:
-				lg.DebugContext(ctx, "reconnecting")
-				if err := c.doWebsocket(ctx, c.Token, c.WSURL, handler); err != nil {
-					lg.ErrorContext(ctx, "websocket error, retrying", "err", err)
+				cached(ctx, "Message", c.Cache.SetLastMessage(ctx, msg.ChannelId, msg.ID))
+				if err := handler.MessageCreate(ctx, msg); err != nil {
+					slog.ErrorContext(ctx, "error in handler", "call", "Message", "err", err)
 				}
 			}
+
+			fetched += len(msgs.Messages)
+			if len(msgs.Messages) < replayPage {
+				break
+			}
 		}
-	}(ctx)
+	}
+
+	return errors.Join(errs...)
 }
 
-func (c *Client) doWebsocket(ctx context.Context, token, wsURL string, handler Handler) error {
-	conn, _, err := websocket.Dial(ctx, wsURL, &websocket.DialOptions{})
+func (c *Client) doWebsocket(ctx context.Context, co ConnectOptions, handler Handler) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	conn, _, err := websocket.Dial(ctx, c.WSURL, &websocket.DialOptions{})
 	if err != nil {
 		return err
 	}
 	defer conn.Close(websocket.StatusNormalClosure, "doWebsocket function returned")
-	slog.DebugContext(ctx, "connected to websocket", "server", wsURL)
+	conn.SetReadLimit(maxMessageSize)
+	slog.DebugContext(ctx, "connected to websocket", "server", c.WSURL)
 
 	data, err := json.Marshal(struct {
 		Type  string `json:"type"`
 		Token string `json:"token"`
 	}{
 		Type:  "Authenticate",
-		Token: token,
+		Token: c.Token,
 	})
 	if err != nil {
 		return err
 	}
 
-	t := time.NewTicker(30 * time.Second)
-	defer t.Stop()
-	go func(ctx context.Context) {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-t.C:
-				data, err := json.Marshal(struct {
-					Type string `json:"type"`
-					Data int    `json:"data"`
-				}{
-					Type: "Ping",
-					Data: 0,
-				})
-				if err != nil {
-					slog.ErrorContext(ctx, "can't marshal ping message", "err", err)
-					continue
-				}
-				if err := conn.Write(ctx, websocket.MessageText, data); err != nil {
-					slog.ErrorContext(ctx, "can't write ping message", "err", err)
-					continue
-				}
-			}
-		}
-	}(ctx)
-
 	if err := conn.Write(ctx, websocket.MessageText, data); err != nil {
 		return err
 	}
 
-	lastMsgSeen := time.Now()
-	go func(ctx context.Context) {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-t.C:
-				if time.Since(lastMsgSeen) > 5*time.Minute {
-					conn.Close(websocket.StatusNormalClosure, "ping timeout")
-					return
-				}
-			}
-		}
-	}(ctx)
+	// The connection is only considered dead if nothing has been read for
+	// a while and the client isn't busy handling an event, as the server
+	// can't be heard from while events are being handled.
+	var lastSeen atomic.Int64
+	var busy atomic.Bool
+	lastSeen.Store(time.Now().UnixNano())
+	go heartbeat(ctx, cancel, conn, co, &lastSeen, &busy)
 
 	for {
 		typ, data, err := conn.Read(ctx)
 		if err != nil {
+			if cause := context.Cause(ctx); cause != nil {
+				return cause
+			}
 			return err
 		}
 		if typ != websocket.MessageText {
 			return fmt.Errorf("unexpected message type: %v", typ)
 		}
-		lastMsgSeen = time.Now()
 
-		if err := c.handleOneMessage(ctx, data, handler); err != nil {
+		busy.Store(true)
+		err = c.handleOneMessage(ctx, data, handler)
+		lastSeen.Store(time.Now().UnixNano())
+		busy.Store(false)
+
+		if err != nil {
 			return err
 		}
 	}
 }
 
+// heartbeat pings the server until ctx is cancelled, and cancels ctx if the
+// server stops responding.
+func heartbeat(ctx context.Context, cancel context.CancelCauseFunc, conn *websocket.Conn, co ConnectOptions, lastSeen *atomic.Int64, busy *atomic.Bool) {
+	interval, timeout := co.heartbeat()
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for n := 0; ; n++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		if !busy.Load() && time.Since(time.Unix(0, lastSeen.Load())) > timeout {
+			cancel(ErrHeartbeatTimeout)
+			return
+		}
+
+		data, err := json.Marshal(struct {
+			Type string `json:"type"`
+			Data int    `json:"data"`
+		}{
+			Type: "Ping",
+			Data: n,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "can't marshal ping message", "err", err)
+			continue
+		}
+
+		if err := conn.Write(ctx, websocket.MessageText, data); err != nil {
+			cancel(fmt.Errorf("revolt: can't write ping message: %w", err))
+			return
+		}
+	}
+}
+
+// cached logs errors from updating the cache. They don't stop events from
+// being handled.
+func cached(ctx context.Context, event string, err error) {
+	if err != nil {
+		slog.ErrorContext(ctx, "can't update cache", "event", event, "err", err)
+	}
+}
+
+// updateData returns the partial object in an update event.
+func updateData(data []byte) json.RawMessage {
+	var upd struct {
+		Data json.RawMessage `json:"data"`
+	}
+	json.Unmarshal(data, &upd)
+	return upd.Data
+}
+
 func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Handler) error {
 	var msg typeResolver
 	if err := json.Unmarshal(data, &msg); err != nil {
@@ -128,6 +321,7 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
 	case "Ready":
+		cached(ctx, msg.Type, cacheReady(ctx, c.Cache, data))
 		if err := handler.Ready(ctx); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
@@ -156,6 +350,7 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 		if err := json.Unmarshal(data, &msg); err != nil {
 			return err
 		}
+		cached(ctx, "Message", c.Cache.SetLastMessage(ctx, msg.ChannelId, msg.ID))
 		if err := handler.MessageCreate(ctx, &msg); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", "Message", "err", err)
 		}
@@ -243,6 +438,7 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 		if err := json.Unmarshal(data, &ch); err != nil {
 			return err
 		}
+		cached(ctx, msg.Type, c.Cache.PutChannel(ctx, &ch))
 		if err := handler.ChannelCreate(ctx, &ch); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
@@ -256,6 +452,9 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 		if err := json.Unmarshal(data, &ch); err != nil {
 			return err
 		}
+		cached(ctx, msg.Type, patchCached(ctx, func(ctx context.Context) (*Channel, error) {
+			return c.Cache.Channel(ctx, ch.ChannelID)
+		}, c.Cache.PutChannel, updateData(data), ch.Clear, clearChannelField))
 		if err := handler.ChannelUpdate(ctx, ch.ChannelID, &ch.Data, ch.Clear); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
@@ -267,6 +466,7 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 		if err := json.Unmarshal(data, &ch); err != nil {
 			return err
 		}
+		cached(ctx, msg.Type, c.Cache.RemoveChannel(ctx, ch.ChannelID))
 		if err := handler.ChannelDelete(ctx, ch.ChannelID); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
@@ -332,11 +532,20 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
 	case "ServerCreate":
-		var srv Server
+		var srv struct {
+			Type     string     `json:"type"`
+			ServerID string     `json:"id"`
+			Server   Server     `json:"server"`
+			Channels []*Channel `json:"channels"`
+		}
 		if err := json.Unmarshal(data, &srv); err != nil {
 			return err
 		}
-		if err := handler.ServerCreate(ctx, &srv); err != nil {
+		cached(ctx, msg.Type, c.Cache.PutServer(ctx, &srv.Server))
+		for _, ch := range srv.Channels {
+			cached(ctx, msg.Type, c.Cache.PutChannel(ctx, ch))
+		}
+		if err := handler.ServerCreate(ctx, &srv.Server); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
 	case "ServerUpdate":
@@ -349,6 +558,9 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 		if err := json.Unmarshal(data, &srv); err != nil {
 			return err
 		}
+		cached(ctx, msg.Type, patchCached(ctx, func(ctx context.Context) (*Server, error) {
+			return c.Cache.Server(ctx, srv.ServerID)
+		}, c.Cache.PutServer, updateData(data), srv.Clear, clearServerField))
 		if err := handler.ServerUpdate(ctx, srv.ServerID, &srv.Data, srv.Clear); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
@@ -360,6 +572,7 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 		if err := json.Unmarshal(data, &srv); err != nil {
 			return err
 		}
+		cached(ctx, msg.Type, c.Cache.RemoveServer(ctx, srv.ServerID))
 		if err := handler.ServerDelete(ctx, srv.ServerID); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
@@ -376,6 +589,9 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 		if err := json.Unmarshal(data, &srv); err != nil {
 			return err
 		}
+		cached(ctx, msg.Type, patchCached(ctx, func(ctx context.Context) (*Member, error) {
+			return c.Cache.Member(ctx, srv.ID.Server, srv.ID.User)
+		}, c.Cache.PutMember, updateData(data), srv.Clear, clearMemberField))
 		if err := handler.ServerMemberUpdate(ctx, srv.ID.Server, srv.ID.User, &srv.Data, srv.Clear); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
@@ -388,6 +604,9 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 		if err := json.Unmarshal(data, &srv); err != nil {
 			return err
 		}
+		var m Member
+		m.Informations.ServerId, m.Informations.UserId = srv.ServerID, srv.UserID
+		cached(ctx, msg.Type, c.Cache.PutMember(ctx, &m))
 		if err := handler.ServerMemberJoin(ctx, srv.ServerID, srv.UserID); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
@@ -400,6 +619,7 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 		if err := json.Unmarshal(data, &srv); err != nil {
 			return err
 		}
+		cached(ctx, msg.Type, c.Cache.RemoveMember(ctx, srv.ServerID, srv.UserID))
 		if err := handler.ServerMemberLeave(ctx, srv.ServerID, srv.UserID); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
@@ -427,6 +647,9 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 		if err := json.Unmarshal(data, &usr); err != nil {
 			return err
 		}
+		cached(ctx, msg.Type, patchCached(ctx, func(ctx context.Context) (*User, error) {
+			return c.Cache.User(ctx, usr.UserID)
+		}, c.Cache.PutUser, updateData(data), usr.Clear, clearUserField))
 		if err := handler.UserUpdate(ctx, usr.UserID, &usr.Data, usr.Clear); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
@@ -452,6 +675,7 @@ func (c *Client) handleOneMessage(ctx context.Context, data []byte, handler Hand
 		if err := json.Unmarshal(data, &usr); err != nil {
 			return err
 		}
+		cached(ctx, msg.Type, c.Cache.RemoveUser(ctx, usr.UserID))
 		if err := handler.UserPlatformWipe(ctx, usr.UserID, usr.Flags); err != nil {
 			slog.ErrorContext(ctx, "error in handler", "call", msg.Type, "err", err)
 		}
@@ -0,0 +1,329 @@
+package revolt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+const testReady = `{"type":"Ready",
+	"users":[{"_id":"u1","username":"cadey"}],
+	"servers":[{"_id":"s1","name":"Within","channels":["c1"]}],
+	"channels":[{"_id":"c1","name":"general","description":"talk here"}],
+	"members":[{"_id":{"server":"s1","user":"u1"},"nickname":"Xe"}]}`
+
+// fakeRevolt is just enough of a Revolt server to connect to: the settings
+// document, the events websocket and fetching messages from a channel.
+type fakeRevolt struct {
+	*httptest.Server
+	t *testing.T
+
+	// script sends events on connection n, after Ready.
+	script func(ctx context.Context, conn *websocket.Conn, n int)
+	// noPong makes the server ignore pings.
+	noPong atomic.Bool
+
+	lock     sync.Mutex
+	conns    int
+	messages map[string][]*Message
+}
+
+func newFakeRevolt(t *testing.T, script func(ctx context.Context, conn *websocket.Conn, n int)) *fakeRevolt {
+	t.Helper()
+
+	f := &fakeRevolt{t: t, script: script, messages: map[string][]*Message{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(RevoltSettings{Revolt: "0.7.0", Ws: "ws" + strings.TrimPrefix(f.URL, "http") + "/ws"})
+	})
+	mux.HandleFunc("GET /ws", f.websocket)
+	mux.HandleFunc("GET /channels/{id}/messages", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("sort") != "Oldest" {
+			http.Error(w, "want sort=Oldest", http.StatusBadRequest)
+			return
+		}
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		f.lock.Lock()
+		defer f.lock.Unlock()
+
+		result := []*Message{}
+		for _, msg := range f.messages[r.PathValue("id")] {
+			if msg.ID > q.Get("after") && len(result) < limit {
+				result = append(result, msg)
+			}
+		}
+		json.NewEncoder(w).Encode(result)
+	})
+
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func (f *fakeRevolt) websocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		f.t.Errorf("can't accept websocket: %v", err)
+		return
+	}
+	defer conn.CloseNow()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	f.lock.Lock()
+	n := f.conns
+	f.conns++
+	f.lock.Unlock()
+
+	_, data, err := conn.Read(ctx)
+	if err != nil {
+		return
+	}
+	var auth struct {
+		Type  string `json:"type"`
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(data, &auth); err != nil || auth.Type != "Authenticate" || auth.Token != "hunter2" {
+		f.t.Errorf("bad authentication message: %s", data)
+		return
+	}
+
+	go func() {
+		defer cancel()
+		for {
+			_, data, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+			if f.noPong.Load() {
+				continue
+			}
+			var ping struct {
+				Data int `json:"data"`
+			}
+			json.Unmarshal(data, &ping)
+			conn.Write(ctx, websocket.MessageText, []byte(`{"type":"Pong","data":`+strconv.Itoa(ping.Data)+`}`))
+		}
+	}()
+
+	conn.Write(ctx, websocket.MessageText, []byte(`{"type":"Authenticated"}`))
+	conn.Write(ctx, websocket.MessageText, []byte(testReady))
+
+	if f.script != nil {
+		f.script(ctx, conn, n)
+	}
+
+	<-ctx.Done()
+}
+
+func (f *fakeRevolt) connections() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.conns
+}
+
+// post stores a message so that it can be fetched, and returns it as an event.
+func (f *fakeRevolt) post(id, channel string) []byte {
+	msg := &Message{ID: id, ChannelId: channel, AuthorId: "u1", Content: "message " + id}
+
+	f.lock.Lock()
+	f.messages[channel] = append(f.messages[channel], msg)
+	f.lock.Unlock()
+
+	return event(msg)
+}
+
+func event(msg *Message) []byte {
+	data, _ := json.Marshal(msg)
+	return append([]byte(`{"type":"Message",`), data[1:]...)
+}
+
+type recordingHandler struct {
+	NullHandler
+	messages chan *Message
+}
+
+func (rh recordingHandler) MessageCreate(_ context.Context, msg *Message) error {
+	rh.messages <- msg
+	return nil
+}
+
+func newTestClient(t *testing.T, f *fakeRevolt) *Client {
+	t.Helper()
+
+	c, err := NewWithEndpoint("hunter2", f.URL, "ws"+strings.TrimPrefix(f.URL, "http")+"/ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Ticker.Stop()
+	c.Ticker = time.NewTicker(time.Millisecond)
+	t.Cleanup(c.Ticker.Stop)
+
+	return c
+}
+
+var testOptions = ConnectOptions{
+	InitialBackoff:    10 * time.Millisecond,
+	MaxBackoff:        10 * time.Millisecond,
+	HeartbeatInterval: 10 * time.Millisecond,
+	HeartbeatTimeout:  100 * time.Millisecond,
+}
+
+func receive(t *testing.T, messages chan *Message, n int) []string {
+	t.Helper()
+
+	var result []string
+	for range n {
+		select {
+		case msg := <-messages:
+			result = append(result, msg.ID)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for messages, got %v", result)
+		}
+	}
+
+	return result
+}
+
+func TestReconnectReplay(t *testing.T) {
+	var f *fakeRevolt
+	f = newFakeRevolt(t, func(ctx context.Context, conn *websocket.Conn, n int) {
+		switch n {
+		case 0:
+			conn.Write(ctx, websocket.MessageText, f.post("01A", "c1"))
+			conn.Write(ctx, websocket.MessageText, f.post("01B", "c2"))
+
+			// These are sent while the client is disconnected.
+			f.post("01C", "c1")
+			f.post("01D", "c1")
+			f.post("01E", "c2")
+			conn.Close(websocket.StatusGoingAway, "restarting")
+		case 1:
+			// The server sends a message the client has already fetched
+			// before the next new one.
+			conn.Write(ctx, websocket.MessageText, event(&Message{ID: "01D", ChannelId: "c1"}))
+			conn.Write(ctx, websocket.MessageText, f.post("01F", "c1"))
+		}
+	})
+
+	c := newTestClient(t, f)
+	rh := recordingHandler{messages: make(chan *Message, 16)}
+	c.ConnectWithOptions(t.Context(), rh, testOptions)
+
+	// Channels are caught up one at a time, so messages are only in order
+	// within each channel.
+	got := receive(t, rh.messages, 6)
+	if want := []string{"01A", "01B", "01C", "01D", "01E", "01F"}; !slices.Equal(slices.Sorted(slices.Values(got)), want) {
+		t.Errorf("got messages %v, want %v", got, want)
+	}
+
+	c1 := slices.DeleteFunc(slices.Clone(got), func(id string) bool { return id == "01B" || id == "01E" })
+	if !slices.IsSorted(c1) {
+		t.Errorf("messages in c1 are out of order: %v", c1)
+	}
+
+	select {
+	case msg := <-rh.messages:
+		t.Errorf("got extra message %s", msg.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	last, err := c.Cache.LastMessages(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last["c1"] != "01F" || last["c2"] != "01E" {
+		t.Errorf("wrong last messages: %v", last)
+	}
+}
+
+func TestConnectUpdatesCache(t *testing.T) {
+	f := newFakeRevolt(t, func(ctx context.Context, conn *websocket.Conn, n int) {
+		for _, ev := range []string{
+			`{"type":"ChannelUpdate","id":"c1","data":{"name":"off-topic"},"clear":["Description"]}`,
+			`{"type":"ServerMemberUpdate","id":{"server":"s1","user":"u1"},"data":{"roles":["admin"]},"clear":["Nickname"]}`,
+			`{"type":"ServerMemberJoin","id":"s1","user":"u2"}`,
+			`{"type":"ServerCreate","id":"s2","server":{"_id":"s2","name":"Other"},"channels":[{"_id":"c9","name":"lobby"}]}`,
+			`{"type":"UserPlatformWipe","id":"u1","flags":"0"}`,
+			`{"type":"Message","_id":"01Z","channel":"c1"}`,
+		} {
+			conn.Write(ctx, websocket.MessageText, []byte(ev))
+		}
+	})
+
+	c := newTestClient(t, f)
+	rh := recordingHandler{messages: make(chan *Message, 16)}
+	c.ConnectWithOptions(t.Context(), rh, testOptions)
+
+	// Events are handled in order, so the cache is up to date once the
+	// last message comes in.
+	receive(t, rh.messages, 1)
+	ctx := t.Context()
+
+	ch, err := c.Cache.Channel(ctx, "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Name != "off-topic" || ch.Description != "" {
+		t.Errorf("channel update wasn't applied: %+v", ch)
+	}
+
+	m, err := c.Cache.Member(ctx, "s1", "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Nickname != "" || !slices.Equal(m.Roles, []string{"admin"}) {
+		t.Errorf("member update wasn't applied: %+v", m)
+	}
+
+	if _, err := c.Cache.Member(ctx, "s1", "u2"); err != nil {
+		t.Errorf("joined member isn't cached: %v", err)
+	}
+	if srv, err := c.Cache.Server(ctx, "s2"); err != nil || srv.Name != "Other" {
+		t.Errorf("created server isn't cached: %v", err)
+	}
+	if _, err := c.Cache.Channel(ctx, "c9"); err != nil {
+		t.Errorf("created server's channel isn't cached: %v", err)
+	}
+	if _, err := c.Cache.User(ctx, "u1"); !errors.Is(err, ErrNotCached) {
+		t.Errorf("wiped user is still cached: %v", err)
+	}
+}
+
+func TestHeartbeatTimeout(t *testing.T) {
+	f := newFakeRevolt(t, nil)
+	c := newTestClient(t, f)
+	c.ConnectWithOptions(t.Context(), NullHandler{}, testOptions)
+
+	time.Sleep(300 * time.Millisecond)
+	if n := f.connections(); n != 1 {
+		t.Fatalf("connected %d times to a server that answers pings", n)
+	}
+
+	f.noPong.Store(true)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for f.connections() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("client didn't reconnect to a server that stopped answering pings")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
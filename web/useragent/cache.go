@@ -0,0 +1,385 @@
+package useragent
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"within.website/x/store"
+)
+
+const (
+	// maxCachedBody is the largest response body that is cached.
+	maxCachedBody = 8 << 20
+
+	// maxHeuristicFreshness bounds how long a response without explicit
+	// freshness is considered fresh because of its Last-Modified date.
+	maxHeuristicFreshness = 24 * time.Hour
+
+	cacheName = "useragent"
+)
+
+// Cache answers GET requests from responses stored in st when RFC 9111
+// says it can, and stores cacheable responses there. Stale responses with
+// an ETag or Last-Modified date are revalidated with a conditional request.
+// Unsafe requests such as POST invalidate what is stored for their URL.
+//
+// It is a private cache: it stores responses to authenticated requests and
+// ones marked private, so don't share st between users. Responses say how
+// they were answered in a Cache-Status header (RFC 9211).
+func Cache(st store.Interface, rt http.RoundTripper) http.RoundTripper {
+	return &cacheTransport{st: st, rt: rt, now: time.Now}
+}
+
+type cacheTransport struct {
+	st store.Interface
+	rt http.RoundTripper
+
+	// now is time.Now, replaced in tests.
+	now func() time.Time
+}
+
+// cacheEntry is a stored response.
+type cacheEntry struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+
+	// Vary has the values of the request headers that the response
+	// varies on.
+	Vary map[string]string `json:"vary,omitempty"`
+
+	RequestTime  time.Time `json:"request_time"`
+	ResponseTime time.Time `json:"response_time"`
+}
+
+type cacheControl map[string]string
+
+func parseCacheControl(h http.Header) cacheControl {
+	result := cacheControl{}
+
+	for _, line := range h.Values("Cache-Control") {
+		for directive := range strings.SplitSeq(line, ",") {
+			k, v, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if k == "" {
+				continue
+			}
+			result[strings.ToLower(k)] = strings.Trim(v, `"`)
+		}
+	}
+
+	return result
+}
+
+func (cc cacheControl) has(directive string) bool {
+	_, ok := cc[directive]
+	return ok
+}
+
+func (cc cacheControl) seconds(directive string) (time.Duration, bool) {
+	v, ok := cc[directive]
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		// An invalid duration is treated as zero, erring on the side of
+		// not using stored responses.
+		return 0, true
+	}
+
+	return time.Duration(n) * time.Second, true
+}
+
+func cacheKey(r *http.Request) string {
+	sum := sha256.Sum256([]byte(r.URL.String()))
+	return "useragent/httpcache/" + hex.EncodeToString(sum[:])
+}
+
+// heuristicallyCacheable is the status codes that can be cached without
+// explicit freshness information (RFC 9110 section 15.1).
+func heuristicallyCacheable(code int) bool {
+	switch code {
+	case 200, 203, 204, 300, 301, 308, 404, 405, 410, 414, 501:
+		return true
+	}
+	return false
+}
+
+func varyHeaders(h http.Header) []string {
+	var result []string
+	for _, line := range h.Values("Vary") {
+		for name := range strings.SplitSeq(line, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				result = append(result, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	return result
+}
+
+// storable reports whether a response to a GET request can be stored
+// (RFC 9111 section 3).
+func storable(resp *http.Response) bool {
+	cc := parseCacheControl(resp.Header)
+	if cc.has("no-store") || slices.Contains(varyHeaders(resp.Header), "*") {
+		return false
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode == http.StatusPartialContent || resp.StatusCode == http.StatusNotModified {
+		return false
+	}
+
+	return cc.has("max-age") || cc.has("public") || cc.has("private") ||
+		resp.Header.Get("Expires") != "" || heuristicallyCacheable(resp.StatusCode)
+}
+
+// date is when the response was made, according to the server.
+func (e *cacheEntry) date() time.Time {
+	if t, err := http.ParseTime(e.Header.Get("Date")); err == nil {
+		return t
+	}
+	return e.ResponseTime
+}
+
+// freshnessLifetime is how long the response is fresh for after it was
+// made (RFC 9111 section 4.2.1).
+func (e *cacheEntry) freshnessLifetime() time.Duration {
+	if maxAge, ok := parseCacheControl(e.Header).seconds("max-age"); ok {
+		return maxAge
+	}
+
+	if exp := e.Header.Get("Expires"); exp != "" {
+		t, err := http.ParseTime(exp)
+		if err != nil {
+			return 0
+		}
+		return t.Sub(e.date())
+	}
+
+	if lm, err := http.ParseTime(e.Header.Get("Last-Modified")); err == nil && heuristicallyCacheable(e.Status) {
+		return min(e.date().Sub(lm)/10, maxHeuristicFreshness)
+	}
+
+	return 0
+}
+
+// age is how old the response is at now (RFC 9111 section 4.2.3).
+func (e *cacheEntry) age(now time.Time) time.Duration {
+	var ageValue time.Duration
+	if secs, err := strconv.ParseInt(e.Header.Get("Age"), 10, 64); err == nil && secs > 0 {
+		ageValue = time.Duration(secs) * time.Second
+	}
+
+	apparentAge := max(e.ResponseTime.Sub(e.date()), 0)
+	correctedAge := ageValue + e.ResponseTime.Sub(e.RequestTime)
+
+	return max(apparentAge, correctedAge) + now.Sub(e.ResponseTime)
+}
+
+// fresh reports whether the response can be used for a request with the
+// given cache directives without revalidating it.
+func (e *cacheEntry) fresh(now time.Time, reqCC cacheControl) bool {
+	if reqCC.has("no-cache") || parseCacheControl(e.Header).has("no-cache") {
+		return false
+	}
+
+	lifetime, age := e.freshnessLifetime(), e.age(now)
+
+	if maxAge, ok := reqCC.seconds("max-age"); ok && age > maxAge {
+		return false
+	}
+	if minFresh, ok := reqCC.seconds("min-fresh"); ok {
+		lifetime -= minFresh
+	}
+
+	return lifetime > age
+}
+
+func (e *cacheEntry) validators() (etag, lastModified string) {
+	return e.Header.Get("ETag"), e.Header.Get("Last-Modified")
+}
+
+// matches reports whether the response was stored for a request with the
+// same values of the headers the response varies on.
+func (e *cacheEntry) matches(r *http.Request) bool {
+	for name, v := range e.Vary {
+		if r.Header.Get(name) != v {
+			return false
+		}
+	}
+	return true
+}
+
+// response makes a response to r from the entry.
+func (e *cacheEntry) response(r *http.Request, now time.Time, status string) *http.Response {
+	h := e.Header.Clone()
+	h.Set("Age", strconv.Itoa(int(e.age(now).Seconds())))
+	h.Set("Cache-Status", cacheName+"; "+status)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       r,
+	}
+}
+
+func (ct *cacheTransport) load(ctx context.Context, key string, r *http.Request) *cacheEntry {
+	data, err := ct.st.Get(ctx, key)
+	if err != nil {
+		return nil
+	}
+
+	var e cacheEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		slog.DebugContext(ctx, "can't decode cached response", "url", r.URL.String(), "err", err)
+		return nil
+	}
+
+	if !e.matches(r) {
+		return nil
+	}
+
+	return &e
+}
+
+func (ct *cacheTransport) save(ctx context.Context, key string, e *cacheEntry) {
+	data, err := json.Marshal(e)
+	if err == nil {
+		err = ct.st.Set(ctx, key, data)
+	}
+	if err != nil {
+		slog.DebugContext(ctx, "can't store response", "key", key, "err", err)
+	}
+}
+
+func (ct *cacheTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx := r.Context()
+	key := cacheKey(r)
+
+	if r.Method != http.MethodGet {
+		resp, err := ct.rt.RoundTrip(r)
+
+		// Unsafe requests that work invalidate what is stored for their
+		// URL (RFC 9111 section 4.4).
+		if err == nil && r.Method != http.MethodHead && r.Method != http.MethodOptions && resp.StatusCode < 400 {
+			ct.st.Delete(ctx, key)
+		}
+
+		return resp, err
+	}
+
+	reqCC := parseCacheControl(r.Header)
+	if reqCC.has("no-store") || r.Header.Get("Range") != "" ||
+		r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+		cacheResults.WithLabelValues("bypass").Inc()
+		return ct.rt.RoundTrip(r)
+	}
+	if r.Header.Get("Cache-Control") == "" && r.Header.Get("Pragma") == "no-cache" {
+		reqCC["no-cache"] = ""
+	}
+
+	entry := ct.load(ctx, key, r)
+	now := ct.now()
+
+	if entry != nil && entry.fresh(now, reqCC) {
+		cacheResults.WithLabelValues("hit").Inc()
+		return entry.response(r, now, "hit"), nil
+	}
+
+	out := r
+	conditional := false
+	if entry != nil {
+		if etag, lastModified := entry.validators(); etag != "" || lastModified != "" {
+			out = r.Clone(ctx)
+			if etag != "" {
+				out.Header.Set("If-None-Match", etag)
+			}
+			if lastModified != "" {
+				out.Header.Set("If-Modified-Since", lastModified)
+			}
+			conditional = true
+		}
+	}
+
+	requestTime := now
+	resp, err := ct.rt.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	responseTime := ct.now()
+
+	if conditional && resp.StatusCode == http.StatusNotModified {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		// The 304's headers replace the stored ones (RFC 9111 section
+		// 4.3.4), except for the ones that describe its empty body.
+		for k, v := range resp.Header {
+			if k != "Content-Length" {
+				entry.Header[k] = v
+			}
+		}
+		entry.RequestTime, entry.ResponseTime = requestTime, responseTime
+		ct.save(ctx, key, entry)
+
+		cacheResults.WithLabelValues("revalidated").Inc()
+		return entry.response(r, responseTime, "fwd=stale; fwd-status=304"), nil
+	}
+
+	cacheResults.WithLabelValues("miss").Inc()
+
+	if !storable(resp) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBody+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBody {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry = &cacheEntry{
+		Status:       resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+		RequestTime:  requestTime,
+		ResponseTime: responseTime,
+	}
+	for _, name := range varyHeaders(resp.Header) {
+		if entry.Vary == nil {
+			entry.Vary = map[string]string{}
+		}
+		entry.Vary[name] = r.Header.Get(name)
+	}
+	ct.save(ctx, key, entry)
+
+	resp.Header.Set("Cache-Status", cacheName+"; fwd=uri-miss; stored")
+	return resp, nil
+}
@@ -0,0 +1,242 @@
+package useragent
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"within.website/x/store"
+)
+
+// testClock is shared by the cache and the origin, which dates its
+// responses with it.
+type testClock struct {
+	nanos atomic.Int64
+}
+
+func (tc *testClock) Now() time.Time { return time.Unix(0, tc.nanos.Load()) }
+
+func (tc *testClock) Add(d time.Duration) { tc.nanos.Add(int64(d)) }
+
+type fakeOrigin struct {
+	clock       *testClock
+	calls       atomic.Int32
+	conditional atomic.Int32
+	handler     http.HandlerFunc
+}
+
+func (fo *fakeOrigin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fo.calls.Add(1)
+	w.Header().Set("Date", fo.clock.Now().UTC().Format(http.TimeFormat))
+	if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+		fo.conditional.Add(1)
+	}
+	fo.handler(w, r)
+}
+
+func newCacheTest(t *testing.T, h http.HandlerFunc) (*fakeOrigin, *httptest.Server, *cacheTransport, *testClock) {
+	t.Helper()
+
+	st, err := store.NewDirectFile(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := &testClock{}
+	clock.nanos.Store(time.Now().Truncate(time.Second).UnixNano())
+
+	fo := &fakeOrigin{clock: clock, handler: h}
+	srv := httptest.NewServer(fo)
+	t.Cleanup(srv.Close)
+
+	ct := Cache(st, http.DefaultTransport).(*cacheTransport)
+	ct.now = clock.Now
+
+	return fo, srv, ct, clock
+}
+
+func cacheGet(t *testing.T, rt http.RoundTripper, u string, h http.Header) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, u, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range h {
+		req.Header[k] = v
+	}
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp, string(body)
+}
+
+func TestCacheFresh(t *testing.T) {
+	fo, srv, ct, clock := newCacheTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte("hello"))
+	})
+
+	resp, body := cacheGet(t, ct, srv.URL, nil)
+	if body != "hello" || !strings.Contains(resp.Header.Get("Cache-Status"), "stored") {
+		t.Fatalf("first response: %q, Cache-Status: %q", body, resp.Header.Get("Cache-Status"))
+	}
+
+	clock.Add(30 * time.Second)
+	resp, body = cacheGet(t, ct, srv.URL, nil)
+	if body != "hello" || resp.Header.Get("Cache-Status") != "useragent; hit" {
+		t.Errorf("second response: %q, Cache-Status: %q", body, resp.Header.Get("Cache-Status"))
+	}
+	if age := resp.Header.Get("Age"); age != "30" {
+		t.Errorf("got Age %q, want 30", age)
+	}
+	if fo.calls.Load() != 1 {
+		t.Errorf("origin got %d requests, want 1", fo.calls.Load())
+	}
+
+	// Callers can insist on a response that isn't that old.
+	cacheGet(t, ct, srv.URL, http.Header{"Cache-Control": {"max-age=10"}})
+	if fo.calls.Load() != 2 {
+		t.Errorf("origin got %d requests, want 2", fo.calls.Load())
+	}
+
+	clock.Add(2 * time.Minute)
+	cacheGet(t, ct, srv.URL, nil)
+	if fo.calls.Load() != 3 {
+		t.Errorf("stale response was used, origin got %d requests", fo.calls.Load())
+	}
+}
+
+func TestCacheRevalidate(t *testing.T) {
+	fo, srv, ct, clock := newCacheTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=10")
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.Header().Set("X-Revalidated", "yes")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte("hello"))
+	})
+
+	cacheGet(t, ct, srv.URL, nil)
+
+	clock.Add(time.Minute)
+	resp, body := cacheGet(t, ct, srv.URL, nil)
+	if resp.StatusCode != http.StatusOK || body != "hello" {
+		t.Fatalf("revalidated response: %d %q", resp.StatusCode, body)
+	}
+	if resp.Header.Get("X-Revalidated") != "yes" {
+		t.Error("304 headers weren't merged into the stored response")
+	}
+	if fo.conditional.Load() != 1 {
+		t.Errorf("origin got %d conditional requests, want 1", fo.conditional.Load())
+	}
+
+	// Revalidating made the response fresh again.
+	cacheGet(t, ct, srv.URL, nil)
+	if fo.calls.Load() != 2 {
+		t.Errorf("origin got %d requests, want 2", fo.calls.Load())
+	}
+}
+
+func TestCacheNotStored(t *testing.T) {
+	for _, cc := range []string{"no-store", ""} {
+		t.Run(cc, func(t *testing.T) {
+			fo, srv, ct, _ := newCacheTest(t, func(w http.ResponseWriter, r *http.Request) {
+				if cc != "" {
+					w.Header().Set("Cache-Control", cc)
+				} else {
+					// A 201 isn't heuristically cacheable.
+					w.WriteHeader(http.StatusCreated)
+				}
+			})
+
+			cacheGet(t, ct, srv.URL, nil)
+			cacheGet(t, ct, srv.URL, nil)
+			if fo.calls.Load() != 2 {
+				t.Errorf("origin got %d requests, want 2", fo.calls.Load())
+			}
+		})
+	}
+}
+
+func TestCacheVary(t *testing.T) {
+	fo, srv, ct, _ := newCacheTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Vary", "Accept-Language")
+		w.Write([]byte(r.Header.Get("Accept-Language")))
+	})
+
+	en := http.Header{"Accept-Language": {"en"}}
+	fr := http.Header{"Accept-Language": {"fr"}}
+
+	if _, body := cacheGet(t, ct, srv.URL, en); body != "en" {
+		t.Fatalf("got %q", body)
+	}
+	if _, body := cacheGet(t, ct, srv.URL, fr); body != "fr" {
+		t.Errorf("response for en was used for fr: %q", body)
+	}
+	if _, body := cacheGet(t, ct, srv.URL, fr); body != "fr" || fo.calls.Load() != 2 {
+		t.Errorf("got %q after %d requests", body, fo.calls.Load())
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	fo, srv, ct, _ := newCacheTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+	})
+
+	cacheGet(t, ct, srv.URL, nil)
+
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, srv.URL, strings.NewReader("{}"))
+	resp, err := ct.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	cacheGet(t, ct, srv.URL, nil)
+	if fo.calls.Load() != 3 {
+		t.Errorf("origin got %d requests, want 3", fo.calls.Load())
+	}
+}
+
+func TestFreshnessLifetime(t *testing.T) {
+	date := time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name   string
+		status int
+		header http.Header
+		want   time.Duration
+	}{
+		{"max-age", 200, http.Header{"Cache-Control": {"public, max-age=300"}, "Expires": {date.Add(time.Hour).Format(http.TimeFormat)}}, 5 * time.Minute},
+		{"expires", 200, http.Header{"Expires": {date.Add(time.Hour).Format(http.TimeFormat)}}, time.Hour},
+		{"bad expires", 200, http.Header{"Expires": {"0"}}, 0},
+		{"heuristic", 200, http.Header{"Last-Modified": {date.Add(-100 * time.Hour).Format(http.TimeFormat)}}, 10 * time.Hour},
+		{"heuristic cap", 200, http.Header{"Last-Modified": {date.AddDate(-1, 0, 0).Format(http.TimeFormat)}}, maxHeuristicFreshness},
+		{"not heuristic", 201, http.Header{"Last-Modified": {date.Add(-100 * time.Hour).Format(http.TimeFormat)}}, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.header.Set("Date", date.Format(http.TimeFormat))
+			e := &cacheEntry{Status: tt.status, Header: tt.header}
+			if got := e.freshnessLifetime(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package useragent
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"within.website/x/web"
+)
+
+var (
+	requests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "within_website_x",
+		Subsystem: "useragent",
+		Name:      "requests_total",
+		Help:      "Outgoing HTTP requests by client, method and status code.",
+	}, []string{"client", "method", "code"})
+
+	latency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "within_website_x",
+		Subsystem: "useragent",
+		Name:      "request_duration_seconds",
+		Help:      "How long outgoing HTTP requests took until the response headers came back.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"client", "method", "code"})
+
+	inFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "within_website_x",
+		Subsystem: "useragent",
+		Name:      "requests_in_flight",
+		Help:      "Outgoing HTTP requests waiting for a response.",
+	}, []string{"client"})
+
+	retries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "within_website_x",
+		Subsystem: "useragent",
+		Name:      "retries_total",
+		Help:      "Outgoing HTTP requests that were retried, by method and the status code or \"error\".",
+	}, []string{"method", "reason"})
+
+	cacheResults = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "within_website_x",
+		Subsystem: "useragent",
+		Name:      "cache_results_total",
+		Help:      "Outgoing HTTP requests that could be cached, by whether they were answered from the cache.",
+	}, []string{"result"})
+)
+
+// Instrument records Prometheus metrics labelled with name and makes
+// OpenTelemetry client spans for every request, passing the trace context
+// on to the server.
+func Instrument(name string, rt http.RoundTripper) http.RoundTripper {
+	labels := prometheus.Labels{"client": name}
+
+	return promhttp.InstrumentRoundTripperInFlight(inFlight.With(labels),
+		promhttp.InstrumentRoundTripperCounter(requests.MustCurryWith(labels),
+			promhttp.InstrumentRoundTripperDuration(latency.MustCurryWith(labels),
+				otelhttp.NewTransport(rt),
+			),
+		),
+	)
+}
+
+// StatusErrors turns responses that aren't successful into *web.Error
+// errors, so that callers only have to check err. Redirects and 304 Not
+// Modified responses are passed through for http.Client and caches to
+// handle.
+//
+// Like any error from a RoundTripper, http.Client wraps these in a
+// *url.Error, so use errors.As to get at them.
+func StatusErrors(rt http.RoundTripper) http.RoundTripper {
+	return statusErrorTransport{rt: rt}
+}
+
+type statusErrorTransport struct {
+	rt http.RoundTripper
+}
+
+func (set statusErrorTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := set.rt.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, web.NewError(http.StatusOK, resp)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,75 @@
+package useragent
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// limiterSweepInterval is how often idle per-host limiters are dropped.
+const limiterSweepInterval = time.Minute
+
+// RateLimit limits requests to each host to limit per second, allowing
+// bursts of up to burst requests. Requests wait for their turn until their
+// context is cancelled. Hosts that haven't been used for long enough to
+// fill their bucket again are forgotten.
+func RateLimit(limit rate.Limit, burst int, rt http.RoundTripper) http.RoundTripper {
+	return &rateLimitTransport{
+		limit:    limit,
+		burst:    burst,
+		rt:       rt,
+		limiters: map[string]*rate.Limiter{},
+		now:      time.Now,
+	}
+}
+
+type rateLimitTransport struct {
+	limit rate.Limit
+	burst int
+	rt    http.RoundTripper
+
+	lock      sync.Mutex
+	limiters  map[string]*rate.Limiter
+	lastSweep time.Time
+
+	// now is time.Now, replaced in tests.
+	now func() time.Time
+}
+
+func (rlt *rateLimitTransport) limiter(host string) *rate.Limiter {
+	rlt.lock.Lock()
+	defer rlt.lock.Unlock()
+
+	if now := rlt.now(); now.Sub(rlt.lastSweep) >= limiterSweepInterval {
+		rlt.sweep(now)
+		rlt.lastSweep = now
+	}
+
+	l, ok := rlt.limiters[host]
+	if !ok {
+		l = rate.NewLimiter(rlt.limit, rlt.burst)
+		rlt.limiters[host] = l
+	}
+
+	return l
+}
+
+// sweep drops limiters whose buckets are full again. A new limiter starts
+// out full, so forgetting them doesn't let any extra requests through.
+func (rlt *rateLimitTransport) sweep(now time.Time) {
+	for host, l := range rlt.limiters {
+		if l.TokensAt(now) >= float64(rlt.burst) {
+			delete(rlt.limiters, host)
+		}
+	}
+}
+
+func (rlt *rateLimitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if err := rlt.limiter(r.URL.Host).Wait(r.Context()); err != nil {
+		return nil, err
+	}
+
+	return rlt.rt.RoundTrip(r)
+}
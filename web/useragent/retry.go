@@ -0,0 +1,155 @@
+package useragent
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// RetryPolicy controls how Retry retries requests.
+//
+// The wait between attempts grows exponentially from InitialBackoff up to
+// MaxBackoff. If the server says how long to wait with a Retry-After
+// header, that is used instead, unless it is longer than MaxRetryAfter, in
+// which case the response is returned as-is.
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	MaxRetryAfter  time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable RetryPolicy for talking to other
+// people's servers.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	MaxRetryAfter:  time.Minute,
+}
+
+func (rp RetryPolicy) backOff() backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = 0 // MaxRetries limits retries
+
+	if rp.InitialBackoff != 0 {
+		bo.InitialInterval = rp.InitialBackoff
+	}
+	if rp.MaxBackoff != 0 {
+		bo.MaxInterval = rp.MaxBackoff
+	}
+
+	bo.Reset()
+	return bo
+}
+
+// Retry retries idempotent requests that fail to connect or get a 429 or a
+// 5xx response that might go away if tried again. Requests are idempotent
+// if their method is, or if they have an Idempotency-Key header.
+func Retry(rp RetryPolicy, rt http.RoundTripper) http.RoundTripper {
+	return retryTransport{rp: rp, rt: rt}
+}
+
+type retryTransport struct {
+	rp RetryPolicy
+	rt http.RoundTripper
+}
+
+func retryable(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// replayable reports whether r can be sent again: it must be idempotent and
+// its body must be rewindable.
+func replayable(r *http.Request) bool {
+	if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+		return false
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return r.Header.Get("Idempotency-Key") != "" || r.Header.Get("X-Idempotency-Key") != ""
+}
+
+func (rt retryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if !replayable(r) {
+		return rt.rt.RoundTrip(r)
+	}
+
+	ctx := r.Context()
+	bo := rt.rp.backOff()
+
+	for attempt := 0; ; attempt++ {
+		if attempt != 0 && r.GetBody != nil {
+			body, err := r.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = r.Clone(ctx)
+			r.Body = body
+		}
+
+		resp, err := rt.rt.RoundTrip(r)
+		if attempt >= rt.rp.MaxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+
+		wait := bo.NextBackOff()
+
+		switch {
+		case err != nil:
+			retries.WithLabelValues(r.Method, "error").Inc()
+		case retryable(resp.StatusCode):
+			if ra, ok := RetryAfter(resp.Header, time.Now()); ok {
+				if rt.rp.MaxRetryAfter != 0 && ra > rt.rp.MaxRetryAfter {
+					return resp, nil
+				}
+				wait = ra
+			}
+			retries.WithLabelValues(r.Method, strconv.Itoa(resp.StatusCode)).Inc()
+
+			// Reading the body lets the connection be reused.
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// RetryAfter returns how long a response's Retry-After header asks clients
+// to wait. It can be a number of seconds or a date.
+func RetryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	ra := h.Get("Retry-After")
+	if ra == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(ra); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(ra); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,76 @@
+package useragent
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+	"within.website/x/store"
+)
+
+// Options configures the RoundTripper stack made by NewTransport. Every
+// layer but the user agent is optional.
+type Options struct {
+	// Prefix and InfoURL go in the User-Agent header, like with
+	// Transport. Prefix also names the client in metrics.
+	Prefix, InfoURL string
+
+	// RateLimit limits requests to each host per second, with bursts of
+	// up to Burst requests. Zero means no limit.
+	RateLimit rate.Limit
+	Burst     int
+
+	// Retry, if set, retries idempotent requests that fail.
+	Retry *RetryPolicy
+
+	// Cache, if set, stores cacheable responses.
+	Cache store.Interface
+
+	// Instrument records metrics and traces for every request.
+	Instrument bool
+
+	// StatusErrors makes requests that get an unsuccessful response fail
+	// with a *web.Error. Don't use this with clients that look at error
+	// responses themselves.
+	StatusErrors bool
+}
+
+// NewTransport stacks RoundTrippers on top of rt, or http.DefaultTransport
+// if rt is nil. From the outside in, they are: status errors, user agent,
+// cache, retries, rate limiting and instrumentation. This way responses
+// from the cache don't count against rate limits, every retry waits its
+// turn and metrics count what is actually sent.
+func NewTransport(o Options, rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	if o.Instrument {
+		rt = Instrument(o.Prefix, rt)
+	}
+
+	if o.RateLimit != 0 {
+		rt = RateLimit(o.RateLimit, max(o.Burst, 1), rt)
+	}
+
+	if o.Retry != nil {
+		rt = Retry(*o.Retry, rt)
+	}
+
+	if o.Cache != nil {
+		rt = Cache(o.Cache, rt)
+	}
+
+	rt = Transport(o.Prefix, o.InfoURL, rt)
+
+	if o.StatusErrors {
+		rt = StatusErrors(rt)
+	}
+
+	return rt
+}
+
+// NewClient makes a http.Client that uses NewTransport. Pass it to other
+// clients with their WithClient options.
+func NewClient(o Options) *http.Client {
+	return &http.Client{Transport: NewTransport(o, nil)}
+}
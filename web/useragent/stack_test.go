@@ -0,0 +1,224 @@
+package useragent
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+	"within.website/x/web"
+)
+
+var fastRetries = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     10 * time.Millisecond,
+	MaxRetryAfter:  time.Second,
+}
+
+func TestRetry(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == http.MethodPut && string(body) != "hello" {
+			t.Errorf("attempt %d got body %q, want hello", calls.Load()+1, body)
+		}
+
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	cli := &http.Client{Transport: Retry(fastRetries, http.DefaultTransport)}
+
+	for _, method := range []string{http.MethodGet, http.MethodPut} {
+		calls.Store(0)
+
+		req, err := http.NewRequestWithContext(t.Context(), method, srv.URL, strings.NewReader("hello"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := cli.Do(req)
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+			t.Errorf("%s: got status %d after %d calls, want 200 after 3", method, resp.StatusCode, calls.Load())
+		}
+	}
+
+	calls.Store(0)
+	resp, err := cli.Post(srv.URL, "text/plain", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || calls.Load() != 1 {
+		t.Errorf("POST: got status %d after %d calls, want 503 after 1", resp.StatusCode, calls.Load())
+	}
+
+	calls.Store(0)
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, srv.URL, strings.NewReader("hello"))
+	req.Header.Set("Idempotency-Key", "abc")
+	resp, err = cli.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("POST with Idempotency-Key: got status %d after %d calls, want 200 after 3", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	cli := &http.Client{Transport: Retry(fastRetries, http.DefaultTransport)}
+	resp, err := cli.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests || calls.Load() != 1 {
+		t.Errorf("got status %d after %d calls, want 429 after 1", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	} {
+		h := http.Header{}
+		if tt.value != "" {
+			h.Set("Retry-After", tt.value)
+		}
+
+		got, ok := RetryAfter(h, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("RetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer other.Close()
+
+	cli := &http.Client{Transport: RateLimit(10, 1, http.DefaultTransport)}
+
+	get := func(u string) {
+		resp, err := cli.Get(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	start := time.Now()
+	for range 3 {
+		get(srv.URL)
+	}
+	if took := time.Since(start); took < 150*time.Millisecond {
+		t.Errorf("3 requests to one host took %v, want at least 200ms", took)
+	}
+
+	// Each host has its own limit.
+	start = time.Now()
+	get(other.URL)
+	if took := time.Since(start); took > 50*time.Millisecond {
+		t.Errorf("first request to another host took %v", took)
+	}
+}
+
+func TestRateLimitSweep(t *testing.T) {
+	clock := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	rlt := RateLimit(rate.Every(time.Hour), 2, http.DefaultTransport).(*rateLimitTransport)
+	rlt.now = func() time.Time { return clock }
+
+	rlt.limiter("idle.example")
+	if !rlt.limiter("busy.example").AllowN(clock, 2) {
+		t.Fatal("a new limiter should allow a full burst")
+	}
+
+	clock = clock.Add(limiterSweepInterval)
+	rlt.limiter("new.example")
+
+	if _, ok := rlt.limiters["idle.example"]; ok {
+		t.Error("the idle limiter with a full bucket should have been dropped")
+	}
+	if _, ok := rlt.limiters["busy.example"]; !ok {
+		t.Error("the busy limiter should have been kept")
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("User-Agent"), "test/") {
+			t.Errorf("bad user agent: %q", r.Header.Get("User-Agent"))
+		}
+
+		switch r.URL.Path {
+		case "/missing":
+			http.Error(w, "not here", http.StatusNotFound)
+		case "/moved":
+			http.Redirect(w, r, "/", http.StatusFound)
+		}
+	}))
+	defer srv.Close()
+
+	cli := NewClient(Options{
+		Prefix:       "test",
+		InfoURL:      "https://example.com",
+		RateLimit:    100,
+		Retry:        &fastRetries,
+		Instrument:   true,
+		StatusErrors: true,
+	})
+
+	resp, err := cli.Get(srv.URL + "/moved")
+	if err != nil {
+		t.Fatalf("redirects should be followed: %v", err)
+	}
+	resp.Body.Close()
+
+	_, err = cli.Get(srv.URL + "/missing")
+	var werr *web.Error
+	if !errors.As(err, &werr) {
+		t.Fatalf("want a *web.Error, got: %v", err)
+	}
+	if werr.GotStatus != http.StatusNotFound {
+		t.Errorf("got status %d in error, want 404", werr.GotStatus)
+	}
+}
@@ -0,0 +1,141 @@
+package vanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// CacheDir is a Source that serves modules from a directory laid out like a
+// module proxy, such as the go command's module cache in
+// $(go env GOMODCACHE)/cache/download. Fill it with go mod download to
+// keep serving versions when the repository isn't around.
+//
+// Only versions in the directory can be served, so queries other than
+// "latest" must be canonical versions.
+type CacheDir struct {
+	Dir string
+}
+
+var _ Source = CacheDir{}
+
+// file returns the path of a file in a module's @v directory.
+func (c CacheDir) file(modPath, name string) (string, error) {
+	escPath, err := module.EscapePath(modPath)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+
+	return filepath.Join(c.Dir, filepath.FromSlash(escPath), "@v", name), nil
+}
+
+// versionFile returns the path of a version's file with the given
+// extension.
+func (c CacheDir) versionFile(modPath, version, ext string) (string, error) {
+	if !isCanonical(version) {
+		return "", ErrNotFound
+	}
+
+	escVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+
+	return c.file(modPath, escVersion+ext)
+}
+
+func readCached(fname string) ([]byte, error) {
+	data, err := os.ReadFile(fname)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+
+	return data, err
+}
+
+func (c CacheDir) List(ctx context.Context, modPath string) ([]string, error) {
+	fname, err := c.file(modPath, "list")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readCached(fname)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	for line := range strings.Lines(string(data)) {
+		if v := strings.TrimSpace(line); v != "" && !module.IsPseudoVersion(v) {
+			result = append(result, v)
+		}
+	}
+
+	return result, nil
+}
+
+func (c CacheDir) Stat(ctx context.Context, modPath, query string) (*Info, error) {
+	if query == latestQuery {
+		versions, err := c.List(ctx, modPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if query = latest(versions); query == "" {
+			return nil, ErrNotFound
+		}
+	}
+
+	fname, err := c.versionFile(modPath, query, ".info")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readCached(fname)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Info
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("vanity: can't parse %s: %w", fname, err)
+	}
+
+	return &result, nil
+}
+
+func (c CacheDir) GoMod(ctx context.Context, modPath, version string) ([]byte, error) {
+	fname, err := c.versionFile(modPath, version, ".mod")
+	if err != nil {
+		return nil, err
+	}
+
+	return readCached(fname)
+}
+
+func (c CacheDir) Zip(ctx context.Context, modPath, version string, w io.Writer) error {
+	fname, err := c.versionFile(modPath, version, ".zip")
+	if err != nil {
+		return err
+	}
+
+	fin, err := os.Open(fname)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	if err != nil {
+		return err
+	}
+	defer fin.Close()
+
+	_, err = io.Copy(w, fin)
+	return err
+}
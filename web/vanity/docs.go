@@ -0,0 +1,386 @@
+package vanity
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/doc"
+	"go/doc/comment"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Docs is the documentation of the packages in a module, rendered with
+// go/doc from a directory of its source code, such as a GitRepo checkout.
+// The source code is only read by LoadDocs, so restart the server or load
+// it again to pick up changes.
+type Docs struct {
+	modulePath string
+	index      []docIndexEntry
+	pages      map[string]*docPage // by import path
+}
+
+type docIndexEntry struct {
+	ImportPath string
+	Synopsis   string
+	Command    bool
+}
+
+type docDecl struct {
+	Name string
+	Decl string
+	Doc  template.HTML
+}
+
+type docType struct {
+	docDecl
+	Consts, Vars, Funcs, Methods []docDecl
+}
+
+type docPage struct {
+	docIndexEntry
+	Name   string
+	Doc    template.HTML
+	Consts []docDecl
+	Vars   []docDecl
+	Funcs  []docDecl
+	Types  []docType
+}
+
+// LoadDocs reads the packages of the module modulePath from dir. Like the
+// go command, it skips testdata, vendor and hidden directories and nested
+// modules. Packages that can't be parsed are left out.
+func LoadDocs(modulePath, dir string) (*Docs, error) {
+	result := &Docs{
+		modulePath: modulePath,
+		pages:      map[string]*docPage{},
+	}
+
+	err := filepath.WalkDir(dir, func(fpath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, fpath)
+		if err != nil {
+			return err
+		}
+
+		importPath := modulePath
+		if rel != "." {
+			name := d.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(fpath, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+
+			importPath = path.Join(modulePath, filepath.ToSlash(rel))
+		}
+
+		page, err := loadPackage(fpath, modulePath, importPath)
+		switch {
+		case err == nil:
+			result.pages[importPath] = page
+			result.index = append(result.index, page.docIndexEntry)
+		case errors.As(err, new(*build.NoGoError)):
+		default:
+			slog.Warn("can't load package documentation", "pkg", importPath, "err", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(result.index, func(a, b docIndexEntry) int {
+		return strings.Compare(a.ImportPath, b.ImportPath)
+	})
+
+	return result, nil
+}
+
+// Has reports whether there is documentation for the package importPath.
+func (d *Docs) Has(importPath string) bool {
+	_, ok := d.pages[importPath]
+	return ok
+}
+
+func loadPackage(dir, modulePath, importPath string) (*docPage, error) {
+	bp, err := build.Default.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, fname := range slices.Concat(bp.GoFiles, bp.CgoFiles) {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, fname), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	p, err := doc.NewFromFiles(fset, files, importPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDocPage(fset, p, modulePath), nil
+}
+
+// newDocPage renders the parts of a package's documentation. Doc links to
+// packages in the module are rendered relative to docLinkPrefix and fixed
+// up by Handler. Other packages are linked to on pkg.go.dev.
+func newDocPage(fset *token.FileSet, p *doc.Package, modulePath string) *docPage {
+	pr := p.Printer()
+	pr.HeadingLevel = 3
+	pr.DocLinkURL = func(link *comment.DocLink) string {
+		if link.ImportPath == modulePath || strings.HasPrefix(link.ImportPath, modulePath+"/") {
+			return link.DefaultURL(docLinkPrefix)
+		}
+		return link.DefaultURL("https://pkg.go.dev")
+	}
+
+	docHTML := func(text string) template.HTML {
+		return template.HTML(pr.HTML(p.Parser().Parse(text)))
+	}
+
+	decl := func(name string, node ast.Node, text string) docDecl {
+		var buf bytes.Buffer
+		if err := (&printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}).Fprint(&buf, fset, node); err != nil {
+			fmt.Fprintf(&buf, "/* can't print declaration: %v */", err)
+		}
+		return docDecl{Name: name, Decl: buf.String(), Doc: docHTML(text)}
+	}
+
+	values := func(vs []*doc.Value) []docDecl {
+		var result []docDecl
+		for _, v := range vs {
+			gd := *v.Decl
+			gd.Doc = nil
+			result = append(result, decl(strings.Join(v.Names, ", "), &gd, v.Doc))
+		}
+		return result
+	}
+
+	funcs := func(fs []*doc.Func) []docDecl {
+		var result []docDecl
+		for _, f := range fs {
+			fd := *f.Decl
+			fd.Doc, fd.Body = nil, nil
+			result = append(result, decl(f.Name, &fd, f.Doc))
+		}
+		return result
+	}
+
+	result := &docPage{
+		docIndexEntry: docIndexEntry{
+			ImportPath: p.ImportPath,
+			Synopsis:   p.Synopsis(p.Doc),
+			Command:    p.Name == "main",
+		},
+		Name:   p.Name,
+		Doc:    docHTML(p.Doc),
+		Consts: values(p.Consts),
+		Vars:   values(p.Vars),
+		Funcs:  funcs(p.Funcs),
+	}
+
+	for _, t := range p.Types {
+		gd := *t.Decl
+		gd.Doc = nil
+		result.Types = append(result.Types, docType{
+			docDecl: decl(t.Name, &gd, t.Doc),
+			Consts:  values(t.Consts),
+			Vars:    values(t.Vars),
+			Funcs:   funcs(t.Funcs),
+			Methods: funcs(t.Methods),
+		})
+	}
+
+	return result
+}
+
+// docLinkPrefix stands in for the prefix Docs are served at in doc links
+// such as [net/http.Handler], as the prefix isn't known when packages are
+// loaded.
+const docLinkPrefix = "/.vanity-doc-prefix"
+
+var docTemplates = template.Must(template.New("").Funcs(template.FuncMap{
+	"fix": func(prefix string, h template.HTML) template.HTML {
+		return template.HTML(strings.ReplaceAll(string(h), `href="`+docLinkPrefix, `href="`+prefix))
+	},
+	"decls": func(prefix string, decls []docDecl) map[string]any {
+		return map[string]any{"Prefix": prefix, "Decls": decls}
+	},
+}).Parse(`
+{{define "head"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.}}</title>
+<style>
+body { max-width: 80ch; margin: auto; padding: 1em; font-family: sans-serif; line-height: 1.4; }
+pre { overflow-x: auto; padding: 0.5em; background: #f4f4f4; }
+dt { font-family: monospace; margin-top: 0.5em; }
+</style>
+</head>
+<body>
+{{end}}
+
+{{define "index"}}{{template "head" .Title}}
+<h1>{{.Title}}</h1>
+<dl>
+{{range .Packages}}<dt><a href="{{$.Prefix}}/{{.ImportPath}}">{{.ImportPath}}</a>{{if .Command}} (command){{end}}</dt>
+<dd>{{.Synopsis}}</dd>
+{{end}}</dl>
+</body>
+</html>
+{{end}}
+
+{{define "decls"}}{{range .Decls}}<pre>{{.Decl}}</pre>
+{{fix $.Prefix .Doc}}
+{{end}}{{end}}
+
+{{define "page"}}{{$prefix := .Prefix}}{{template "head" .Page.ImportPath}}
+<p><a href="{{.Prefix}}/">Index</a></p>
+<h1>{{if .Page.Command}}Command {{.Page.ImportPath}}{{else}}Package {{.Page.Name}}{{end}}</h1>
+<pre>import "{{.Page.ImportPath}}"</pre>
+{{fix .Prefix .Page.Doc}}
+{{with .Subpackages}}<h2 id="pkg-subdirectories">Directories</h2>
+<dl>
+{{range .}}<dt><a href="{{$prefix}}/{{.ImportPath}}">{{.ImportPath}}</a></dt>
+<dd>{{.Synopsis}}</dd>
+{{end}}</dl>
+{{end}}
+{{with .Page.Consts}}<h2 id="pkg-constants">Constants</h2>
+{{template "decls" (decls $prefix .)}}{{end}}
+{{with .Page.Vars}}<h2 id="pkg-variables">Variables</h2>
+{{template "decls" (decls $prefix .)}}{{end}}
+{{with .Page.Funcs}}<h2 id="pkg-functions">Functions</h2>
+{{range .}}<h3 id="{{.Name}}">func {{.Name}}</h3>
+<pre>{{.Decl}}</pre>
+{{fix $prefix .Doc}}
+{{end}}{{end}}
+{{with .Page.Types}}<h2 id="pkg-types">Types</h2>
+{{range .}}{{$type := .Name}}<h3 id="{{.Name}}">type {{.Name}}</h3>
+<pre>{{.Decl}}</pre>
+{{fix $prefix .Doc}}
+{{template "decls" (decls $prefix .Consts)}}
+{{template "decls" (decls $prefix .Vars)}}
+{{range .Funcs}}<h4 id="{{.Name}}">func {{.Name}}</h4>
+<pre>{{.Decl}}</pre>
+{{fix $prefix .Doc}}
+{{end}}
+{{range .Methods}}<h4 id="{{$type}}.{{.Name}}">func ({{$type}}) {{.Name}}</h4>
+<pre>{{.Decl}}</pre>
+{{fix $prefix .Doc}}
+{{end}}{{end}}{{end}}
+</body>
+</html>
+{{end}}
+`))
+
+// Handler serves an index of the packages at prefix+"/" and each package's
+// documentation at prefix+"/"+importPath. Mount it at prefix+"/" without
+// http.StripPrefix.
+func (d *Docs) Handler(prefix string) http.Handler {
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	var index bytes.Buffer
+	err := docTemplates.ExecuteTemplate(&index, "index", map[string]any{
+		"Title":    d.modulePath,
+		"Prefix":   prefix,
+		"Packages": d.index,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("vanity: can't render package index: %v", err))
+	}
+
+	pages := map[string][]byte{}
+	for importPath, page := range d.pages {
+		var buf bytes.Buffer
+		err := docTemplates.ExecuteTemplate(&buf, "page", map[string]any{
+			"Prefix":      prefix,
+			"Page":        page,
+			"Subpackages": d.subpackages(importPath),
+		})
+		if err != nil {
+			panic(fmt.Sprintf("vanity: can't render documentation for %s: %v", importPath, err))
+		}
+		pages[importPath] = buf.Bytes()
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			status := http.StatusMethodNotAllowed
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+
+		body := index.Bytes()
+		if importPath := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"); importPath != "" {
+			var ok bool
+			if body, ok = pages[importPath]; !ok {
+				http.NotFound(w, r)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Write(body)
+	})
+}
+
+// subpackages returns the packages directly or indirectly under importPath.
+func (d *Docs) subpackages(importPath string) []docIndexEntry {
+	var result []docIndexEntry
+	for _, e := range d.index {
+		if strings.HasPrefix(e.ImportPath, importPath+"/") {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Redirector sends browsers to the documentation served by Handler at
+// prefix, such as "https://within.website/.within/doc". Packages that
+// aren't documented are passed to fallback, or sent to pkg.go.dev if
+// fallback is nil.
+func (d *Docs) Redirector(prefix string, fallback Redirector) Redirector {
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	if fallback == nil {
+		fallback = func(pkg string) string {
+			return "https://pkg.go.dev/" + pkg
+		}
+	}
+
+	return func(pkg string) string {
+		if d.Has(pkg) {
+			return prefix + "/" + pkg
+		}
+		return fallback(pkg)
+	}
+}
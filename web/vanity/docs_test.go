@@ -0,0 +1,66 @@
+package vanity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDocs(t *testing.T) {
+	dir, _ := newGitRepo(t)
+
+	docs, err := LoadDocs(testModule, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !docs.Has("example.com/m/pkg") || docs.Has("example.com/m/sub") {
+		t.Errorf("want docs for pkg and not for the nested module sub")
+	}
+
+	srv := httptest.NewServer(docs.Handler("/doc/"))
+	defer srv.Close()
+
+	for _, tt := range []struct {
+		path   string
+		status int
+		want   []string
+	}{
+		{"/doc/", http.StatusOK, []string{
+			`<a href="/doc/example.com/m">example.com/m</a>`,
+			`<dd>Package m says hello.</dd>`,
+			`<a href="/doc/example.com/m/pkg">example.com/m/pkg</a>`,
+		}},
+		{"/doc/example.com/m", http.StatusOK, []string{
+			`<h1>Package m</h1>`,
+			`<pre>func Hello() string</pre>`,
+			`<p>Hello says hello.`,
+			`<a href="/doc/example.com/m/pkg">example.com/m/pkg</a>`,
+		}},
+		{"/doc/example.com/m/pkg", http.StatusOK, []string{
+			`<pre>const Answer = 42</pre>`,
+			`<a href="/doc/example.com/m">example.com/m</a>`,
+		}},
+		{"/doc/example.com/m/sub", http.StatusNotFound, nil},
+	} {
+		status, body := get(t, srv, tt.path)
+		if status != tt.status {
+			t.Errorf("%s: got status %d, want %d", tt.path, status, tt.status)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(string(body), want) {
+				t.Errorf("%s doesn't have %q:\n%s", tt.path, want, body)
+			}
+		}
+	}
+
+	redir := docs.Redirector("https://example.com/doc", nil)
+	if got := redir("example.com/m/pkg"); got != "https://example.com/doc/example.com/m/pkg" {
+		t.Errorf("got redirect to %s for a documented package", got)
+	}
+	if got := redir("example.com/m/nope"); got != "https://pkg.go.dev/example.com/m/nope" {
+		t.Errorf("got redirect to %s for an undocumented package", got)
+	}
+}
@@ -0,0 +1,344 @@
+package vanity
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"golang.org/x/mod/zip"
+)
+
+// GitRepo is a Source that serves modules from a local git checkout, such
+// as one kept up to date with git fetch. It needs the git command.
+//
+// Versions are git tags. Like with the go command, modules in
+// subdirectories of the repository are tagged with the subdirectory, such
+// as "web/v1.2.3" for the module Root+"/web". Branch names and commit
+// hashes resolve to pseudo-versions.
+type GitRepo struct {
+	Dir  string // the checkout
+	Root string // the module path of the repository's root directory
+}
+
+var _ Source = GitRepo{}
+
+// gitModule is where a module is in the repository.
+type gitModule struct {
+	path      string
+	subdir    string // directory in the repository, "" for the root
+	tagPrefix string // prefix of the module's tags
+	pathMajor string // major version suffix of path, such as "/v2"
+}
+
+func (g GitRepo) module(modPath string) (*gitModule, error) {
+	prefix, pathMajor, ok := module.SplitPathVersion(modPath)
+	if !ok || strings.HasPrefix(pathMajor, ".") {
+		return nil, ErrNotFound
+	}
+
+	var subdir string
+	switch {
+	case prefix == g.Root:
+	case strings.HasPrefix(prefix, g.Root+"/"):
+		subdir = strings.TrimPrefix(prefix, g.Root+"/")
+	default:
+		return nil, ErrNotFound
+	}
+
+	result := &gitModule{path: modPath, subdir: subdir, pathMajor: pathMajor}
+	if subdir != "" {
+		result.tagPrefix = subdir + "/"
+	}
+
+	return result, nil
+}
+
+// git runs a git command in the checkout and returns what it printed.
+func (g GitRepo) git(ctx context.Context, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = g.Dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("vanity: git %s: %w: %s", args[0], err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// goModFile is the path of the module's go.mod file in the repository.
+func (m *gitModule) goModFile() string {
+	if m.subdir == "" {
+		return "go.mod"
+	}
+	return m.subdir + "/go.mod"
+}
+
+// isModule reports whether m is a module at rev. Subdirectories without
+// a go.mod file are part of the module above them.
+func (g GitRepo) isModule(ctx context.Context, m *gitModule, rev string) bool {
+	if m.subdir == "" {
+		return true
+	}
+
+	_, err := g.git(ctx, "cat-file", "-e", rev+":"+m.goModFile())
+	return err == nil
+}
+
+// tagged reports whether tag is a release of m, returning its version.
+func (m *gitModule) tagged(tag string) (string, bool) {
+	v, ok := strings.CutPrefix(tag, m.tagPrefix)
+	if !ok || semver.Canonical(v) != v || module.IsPseudoVersion(v) {
+		return "", false
+	}
+
+	// Tags for v2 and later of a module without a major version suffix
+	// would be +incompatible versions, which aren't supported.
+	if m.pathMajor == "" && semver.Major(v) != "v0" && semver.Major(v) != "v1" {
+		return "", false
+	}
+
+	return v, module.CheckPathMajor(v, m.pathMajor) == nil
+}
+
+func (g GitRepo) List(ctx context.Context, modPath string) ([]string, error) {
+	m, err := g.module(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := g.git(ctx, "tag", "--list", m.tagPrefix+"v*")
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	for tag := range strings.Lines(string(out)) {
+		if v, ok := m.tagged(strings.TrimSpace(tag)); ok {
+			result = append(result, v)
+		}
+	}
+
+	if len(result) == 0 && !g.isModule(ctx, m, "HEAD") {
+		return nil, ErrNotFound
+	}
+
+	return result, nil
+}
+
+// resolve returns the full hash and commit time of rev.
+func (g GitRepo) resolve(ctx context.Context, rev string) (string, time.Time, error) {
+	// Anything that looks like a flag could change what git does.
+	if rev == "" || strings.HasPrefix(rev, "-") || strings.ContainsAny(rev, " \t\n:") {
+		return "", time.Time{}, ErrNotFound
+	}
+
+	out, err := g.git(ctx, "log", "-n1", "--format=%H %cI", rev+"^{commit}", "--")
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+
+	hash, date, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return hash, t.UTC(), nil
+}
+
+// pseudoVersion makes the pseudo-version of the commit hash made at t,
+// based on the newest release tagged before it.
+func (g GitRepo) pseudoVersion(ctx context.Context, m *gitModule, hash string, t time.Time) string {
+	var older string
+	if out, err := g.git(ctx, "describe", "--tags", "--abbrev=0", "--match", m.tagPrefix+"v*", hash); err == nil {
+		older, _ = m.tagged(strings.TrimSpace(string(out)))
+	}
+
+	major := strings.TrimPrefix(m.pathMajor, "/")
+	if older != "" {
+		major = semver.Major(older)
+	}
+
+	return module.PseudoVersion(major, older, t, hash[:12])
+}
+
+func (g GitRepo) Stat(ctx context.Context, modPath, query string) (*Info, error) {
+	m, err := g.module(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case query == latestQuery:
+		versions, err := g.List(ctx, modPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if v := latest(versions); v != "" {
+			return g.Stat(ctx, modPath, v)
+		}
+
+		query = "HEAD"
+	case module.IsPseudoVersion(query):
+		rev, err := module.PseudoVersionRev(query)
+		if err != nil {
+			return nil, ErrNotFound
+		}
+
+		hash, t, err := g.resolve(ctx, rev)
+		if err != nil {
+			return nil, err
+		}
+
+		if !g.isModule(ctx, m, hash) {
+			return nil, ErrNotFound
+		}
+
+		// Only serve the pseudo-version the go command would have made,
+		// not any version that happens to end with a commit hash.
+		if g.pseudoVersion(ctx, m, hash, t) != query {
+			return nil, ErrNotFound
+		}
+
+		return &Info{Version: query, Time: t}, nil
+	case isCanonical(query):
+		if _, ok := m.tagged(m.tagPrefix + query); !ok {
+			return nil, ErrNotFound
+		}
+
+		_, t, err := g.resolve(ctx, "refs/tags/"+m.tagPrefix+query)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Info{Version: query, Time: t}, nil
+	}
+
+	hash, t, err := g.resolve(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if !g.isModule(ctx, m, hash) {
+		return nil, ErrNotFound
+	}
+
+	// Commits that are releases resolve to their release, not a
+	// pseudo-version.
+	out, err := g.git(ctx, "tag", "--points-at", hash, "--list", m.tagPrefix+"v*")
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for tag := range strings.Lines(string(out)) {
+		if v, ok := m.tagged(strings.TrimSpace(tag)); ok {
+			versions = append(versions, v)
+		}
+	}
+
+	if v := latest(versions); v != "" {
+		return &Info{Version: v, Time: t}, nil
+	}
+
+	return &Info{Version: g.pseudoVersion(ctx, m, hash, t), Time: t}, nil
+}
+
+// latest picks the newest release out of versions, or the newest
+// pre-release if there aren't any releases.
+func latest(versions []string) string {
+	semver.Sort(versions)
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		if semver.Prerelease(versions[i]) == "" {
+			return versions[i]
+		}
+	}
+
+	if len(versions) != 0 {
+		return versions[len(versions)-1]
+	}
+
+	return ""
+}
+
+// rev returns the git revision of a version of m that Stat resolved.
+func (g GitRepo) rev(ctx context.Context, m *gitModule, version string) (string, error) {
+	info, err := g.Stat(ctx, m.path, version)
+	if err != nil {
+		return "", err
+	}
+
+	if module.IsPseudoVersion(info.Version) {
+		return module.PseudoVersionRev(info.Version)
+	}
+
+	return "refs/tags/" + m.tagPrefix + info.Version, nil
+}
+
+func (g GitRepo) GoMod(ctx context.Context, modPath, version string) ([]byte, error) {
+	m, err := g.module(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	rev, err := g.rev(ctx, m, version)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := g.git(ctx, "cat-file", "blob", rev+":"+m.goModFile())
+	if err != nil {
+		// Like the go command, make up a go.mod file for versions from
+		// before the module had one.
+		if m.subdir == "" && m.pathMajor == "" {
+			return []byte("module " + modfile.AutoQuote(modPath) + "\n"), nil
+		}
+		return nil, fmt.Errorf("%w: %s has no go.mod: %w", ErrNotFound, version, err)
+	}
+
+	if got := modfile.ModulePath(data); got != modPath {
+		return nil, fmt.Errorf("%w: go.mod of %s is for %q", ErrNotFound, version, got)
+	}
+
+	return data, nil
+}
+
+func (g GitRepo) Zip(ctx context.Context, modPath, version string, w io.Writer) error {
+	m, err := g.module(modPath)
+	if err != nil {
+		return err
+	}
+
+	rev, err := g.rev(ctx, m, version)
+	if err != nil {
+		return err
+	}
+
+	subdir := m.subdir
+	if subdir != "" {
+		subdir += "/"
+	}
+
+	err = zip.CreateFromVCS(w, module.Version{Path: modPath, Version: version}, g.Dir, rev, subdir)
+
+	var uerr *zip.UnrecognizedVCSError
+	if errors.As(err, &uerr) {
+		return fmt.Errorf("%w: %s isn't a git checkout", ErrNotFound, g.Dir)
+	}
+
+	return err
+}
@@ -0,0 +1,261 @@
+package vanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// ErrNotFound is returned by a Source that doesn't have a module or version.
+var ErrNotFound = errors.New("vanity: module version not found")
+
+// Info is what the module proxy protocol says about a version.
+type Info struct {
+	Version string    // canonical version
+	Time    time.Time // commit time
+}
+
+// Source is somewhere a module proxy can get module versions from.
+//
+// Module paths and versions are passed unescaped. Versions passed to GoMod
+// and Zip are canonical versions that Stat returned.
+type Source interface {
+	// List returns the tagged versions of a module, in any order.
+	List(ctx context.Context, modPath string) ([]string, error)
+
+	// Stat resolves a version query: a canonical version, "latest", or
+	// something the source understands such as a branch name.
+	Stat(ctx context.Context, modPath, query string) (*Info, error)
+
+	// GoMod returns the go.mod file of a version.
+	GoMod(ctx context.Context, modPath, version string) ([]byte, error)
+
+	// Zip writes the module zip of a version to w.
+	Zip(ctx context.Context, modPath, version string, w io.Writer) error
+}
+
+// Proxy serves the GOPROXY protocol for modules from sources. Each request
+// tries the sources in order until one of them has the module version, so
+// a git checkout can be backed by a cache directory or the other way
+// around. Lists of versions are merged from every source.
+//
+// Mount it with http.StripPrefix and point the go command at it with
+// WithGoModProxy or GOPROXY.
+func Proxy(sources ...Source) http.Handler {
+	return proxy(sources)
+}
+
+type proxy []Source
+
+// latestQuery is the version query that @latest resolves.
+const latestQuery = "latest"
+
+func (p proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		status := http.StatusMethodNotAllowed
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	escPath, file, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/@v/")
+	if !ok {
+		if escPath, ok = strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/"), "/@latest"); !ok {
+			http.NotFound(w, r)
+			return
+		}
+		file = latestQuery + ".info"
+	}
+
+	modPath, err := module.UnescapePath(escPath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	if file == "list" {
+		p.list(ctx, w, modPath)
+		return
+	}
+
+	ext := file[strings.LastIndexByte(file, '.')+1:]
+	version, err := module.UnescapeVersion(strings.TrimSuffix(file, "."+ext))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch ext {
+	case "info":
+		p.info(ctx, w, modPath, version)
+	case "mod", "zip":
+		if err := module.Check(modPath, version); err != nil || !isCanonical(version) {
+			http.Error(w, "vanity: "+version+" isn't a canonical version", http.StatusNotFound)
+			return
+		}
+
+		if ext == "mod" {
+			p.goMod(ctx, w, modPath, version)
+		} else {
+			p.zip(ctx, w, r, modPath, version)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// isCanonical reports whether v is a canonical version such as v1.2.3 or
+// v2.0.0+incompatible, and not a query.
+func isCanonical(v string) bool {
+	v = strings.TrimSuffix(v, "+incompatible")
+	return semver.Canonical(v) == v
+}
+
+// try calls fn with each source in turn until one of them works. Errors
+// other than ErrNotFound are logged so that a broken source doesn't hide
+// a working one.
+func (p proxy) try(ctx context.Context, modPath string, fn func(Source) error) error {
+	var result error = ErrNotFound
+
+	for _, src := range p {
+		err := fn(src)
+		if err == nil {
+			return nil
+		}
+
+		if !errors.Is(err, ErrNotFound) {
+			slog.ErrorContext(ctx, "can't get module from source", "module", modPath, "source", fmt.Sprintf("%T", src), "err", err)
+			result = err
+		}
+	}
+
+	return result
+}
+
+func proxyError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrNotFound) {
+		// The go command moves on to the next proxy in GOPROXY on 404
+		// and 410.
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	http.Error(w, err.Error(), http.StatusBadGateway)
+}
+
+func (p proxy) list(ctx context.Context, w http.ResponseWriter, modPath string) {
+	seen := map[string]bool{}
+	var versions []string
+	known := false
+
+	err := p.try(ctx, modPath, func(src Source) error {
+		vs, err := src.List(ctx, modPath)
+		if err != nil {
+			return err
+		}
+		known = true
+
+		for _, v := range vs {
+			if !seen[v] {
+				seen[v] = true
+				versions = append(versions, v)
+			}
+		}
+
+		// Keep going to merge the lists of every source.
+		return ErrNotFound
+	})
+	if !known {
+		proxyError(w, err)
+		return
+	}
+
+	semver.Sort(versions)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	for _, v := range versions {
+		fmt.Fprintln(w, v)
+	}
+}
+
+func (p proxy) info(ctx context.Context, w http.ResponseWriter, modPath, query string) {
+	var info *Info
+
+	err := p.try(ctx, modPath, func(src Source) (err error) {
+		info, err = src.Stat(ctx, modPath, query)
+		return err
+	})
+	if err != nil {
+		proxyError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if isCanonical(query) {
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age=60")
+	}
+	json.NewEncoder(w).Encode(info)
+}
+
+func (p proxy) goMod(ctx context.Context, w http.ResponseWriter, modPath, version string) {
+	var data []byte
+
+	err := p.try(ctx, modPath, func(src Source) (err error) {
+		data, err = src.GoMod(ctx, modPath, version)
+		return err
+	})
+	if err != nil {
+		proxyError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Write(data)
+}
+
+func (p proxy) zip(ctx context.Context, w http.ResponseWriter, r *http.Request, modPath, version string) {
+	// Zips are buffered in a temporary file so that a source failing
+	// halfway through can fall back to the next one, and so that range
+	// requests work.
+	fout, err := os.CreateTemp("", "vanity-*.zip")
+	if err != nil {
+		proxyError(w, err)
+		return
+	}
+	defer os.Remove(fout.Name())
+	defer fout.Close()
+
+	err = p.try(ctx, modPath, func(src Source) error {
+		if err := fout.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := fout.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		return src.Zip(ctx, modPath, version, fout)
+	})
+	if err != nil {
+		proxyError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	http.ServeContent(w, r, "", time.Time{}, fout)
+}
@@ -0,0 +1,280 @@
+package vanity
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testModule = "example.com/m"
+
+// newGitRepo makes a git repository with a few tagged versions of
+// testModule, a nested module in sub and a package in pkg. It returns the
+// checkout and the hash of the last commit, which isn't tagged.
+func newGitRepo(t *testing.T) (string, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+
+	dir := t.TempDir()
+
+	git := func(date string, args ...string) string {
+		t.Helper()
+
+		cmd := exec.Command("git", append([]string{
+			"-c", "user.name=Mimi", "-c", "user.email=mimi@example.com",
+			"-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false",
+			"-c", "init.defaultBranch=main",
+		}, args...)...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+		}
+
+		return strings.TrimSpace(string(out))
+	}
+
+	write := func(fname, data string) {
+		t.Helper()
+
+		fname = filepath.Join(dir, fname)
+		if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fname, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	commit := func(date, msg string) {
+		t.Helper()
+		git(date, "add", "-A")
+		git(date, "commit", "-q", "-m", msg)
+	}
+
+	git("2024-01-01T00:00:00Z", "init", "-q")
+
+	write("go.mod", "module example.com/m\n\ngo 1.22\n")
+	write("LICENSE", "Do what you want.\n")
+	write("m.go", "// Package m says hello.\npackage m\n\n// Hello says hello.\nfunc Hello() string { return \"hello\" }\n")
+	commit("2024-01-01T00:00:00Z", "hello")
+	git("2024-01-01T00:00:00Z", "tag", "v1.0.0")
+
+	write("pkg/pkg.go", "// Package pkg is part of [example.com/m].\npackage pkg\n\n// Answer is the answer.\nconst Answer = 42\n")
+	commit("2024-01-02T00:00:00Z", "pkg")
+	git("2024-01-02T00:00:00Z", "tag", "-a", "-m", "v1.1.0", "v1.1.0")
+
+	write("sub/go.mod", "module example.com/m/sub\n\ngo 1.22\n")
+	write("sub/sub.go", "package sub\n")
+	commit("2024-01-03T00:00:00Z", "sub")
+	git("2024-01-03T00:00:00Z", "tag", "sub/v0.1.0")
+
+	write("m.go", "// Package m says hello.\npackage m\n\n// Hello says hello.\nfunc Hello() string { return \"hi\" }\n")
+	commit("2024-01-04T00:00:00Z", "hi")
+
+	return dir, git("2024-01-04T00:00:00Z", "rev-parse", "HEAD")
+}
+
+func get(t *testing.T, srv *httptest.Server, path string) (int, []byte) {
+	t.Helper()
+
+	resp, err := srv.Client().Get(srv.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.StatusCode, body
+}
+
+func zipFiles(t *testing.T, data []byte) []string {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("bad zip: %v", err)
+	}
+
+	var result []string
+	for _, f := range zr.File {
+		result = append(result, f.Name)
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+func TestGitRepo(t *testing.T) {
+	dir, head := newGitRepo(t)
+	srv := httptest.NewServer(Proxy(GitRepo{Dir: dir, Root: testModule}))
+	defer srv.Close()
+
+	pseudo := "v1.1.1-0.20240104000000-" + head[:12]
+
+	for _, tt := range []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/example.com/m/@v/list", http.StatusOK, "v1.0.0\nv1.1.0\n"},
+		{"/example.com/m/sub/@v/list", http.StatusOK, "v0.1.0\n"},
+		{"/example.com/m/pkg/@v/list", http.StatusNotFound, ""},
+		{"/example.com/other/@v/list", http.StatusNotFound, ""},
+		{"/example.com/m/@latest", http.StatusOK, `{"Version":"v1.1.0","Time":"2024-01-02T00:00:00Z"}`},
+		{"/example.com/m/@v/v1.0.0.info", http.StatusOK, `{"Version":"v1.0.0","Time":"2024-01-01T00:00:00Z"}`},
+		{"/example.com/m/@v/main.info", http.StatusOK, `{"Version":"` + pseudo + `","Time":"2024-01-04T00:00:00Z"}`},
+		{"/example.com/m/@v/v1.1.0.mod", http.StatusOK, "module example.com/m\n\ngo 1.22\n"},
+		{"/example.com/m/@v/" + pseudo + ".mod", http.StatusOK, "module example.com/m\n\ngo 1.22\n"},
+		{"/example.com/m/sub/@v/v0.1.0.mod", http.StatusOK, "module example.com/m/sub\n\ngo 1.22\n"},
+		{"/example.com/m/@v/v9.9.9.info", http.StatusNotFound, ""},
+		{"/example.com/m/@v/main.mod", http.StatusNotFound, ""},
+		{"/example.com/m/@v/v0.0.0-20240104000000-" + head[:12] + ".info", http.StatusNotFound, ""},
+		{"/example.com/m/@v/--output=x.info", http.StatusNotFound, ""},
+	} {
+		status, body := get(t, srv, tt.path)
+		if status != tt.status {
+			t.Errorf("%s: got status %d, want %d: %s", tt.path, status, tt.status, body)
+			continue
+		}
+		if tt.body != "" && strings.TrimSpace(string(body)) != strings.TrimSpace(tt.body) {
+			t.Errorf("%s: got:\n%s\nwant:\n%s", tt.path, body, tt.body)
+		}
+	}
+
+	for _, tt := range []struct {
+		path string
+		want []string
+	}{
+		{"/example.com/m/@v/v1.0.0.zip", []string{"example.com/m@v1.0.0/LICENSE", "example.com/m@v1.0.0/go.mod", "example.com/m@v1.0.0/m.go"}},
+		{"/example.com/m/@v/" + pseudo + ".zip", []string{
+			"example.com/m@" + pseudo + "/LICENSE",
+			"example.com/m@" + pseudo + "/go.mod",
+			"example.com/m@" + pseudo + "/m.go",
+			"example.com/m@" + pseudo + "/pkg/pkg.go",
+		}},
+		{"/example.com/m/sub/@v/v0.1.0.zip", []string{"example.com/m/sub@v0.1.0/LICENSE", "example.com/m/sub@v0.1.0/go.mod", "example.com/m/sub@v0.1.0/sub.go"}},
+	} {
+		status, body := get(t, srv, tt.path)
+		if status != http.StatusOK {
+			t.Errorf("%s: got status %d: %s", tt.path, status, body)
+			continue
+		}
+		if got := zipFiles(t, body); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got files %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestGoModDownload(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the go command")
+	}
+
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go isn't installed")
+	}
+
+	dir, _ := newGitRepo(t)
+	srv := httptest.NewServer(Proxy(GitRepo{Dir: dir, Root: testModule}))
+	defer srv.Close()
+
+	cmd := exec.Command(goCmd, "mod", "download", "-json", "example.com/m@latest", "example.com/m/sub@v0.1.0")
+	cmd.Dir = t.TempDir()
+	cmd.Env = append(os.Environ(),
+		"GOPROXY="+srv.URL,
+		"GONOSUMDB=example.com",
+		"GOFLAGS=-modcacherw",
+		"GOMODCACHE="+t.TempDir(),
+	)
+
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("go mod download: %v: %s", err, out)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(out))
+	var got []string
+	for dec.More() {
+		var m struct{ Path, Version, Error string }
+		if err := dec.Decode(&m); err != nil {
+			t.Fatal(err)
+		}
+		if m.Error != "" {
+			t.Errorf("%s: %s", m.Path, m.Error)
+		}
+		got = append(got, m.Path+"@"+m.Version)
+	}
+
+	slices.Sort(got)
+	if want := []string{"example.com/m/sub@v0.1.0", "example.com/m@v1.1.0"}; !slices.Equal(got, want) {
+		t.Errorf("downloaded %v, want %v", got, want)
+	}
+}
+
+func TestCacheDirFallback(t *testing.T) {
+	cache := t.TempDir()
+	vdir := filepath.Join(cache, "example.com", "!other", "@v")
+	if err := os.MkdirAll(vdir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string]string{
+		"list":        "v0.1.0\nv0.2.0-pre\n",
+		"v0.1.0.info": `{"Version":"v0.1.0","Time":"2024-01-01T00:00:00Z"}`,
+		"v0.1.0.mod":  "module example.com/Other\n",
+		"v0.1.0.zip":  "not really a zip",
+	} {
+		if err := os.WriteFile(filepath.Join(vdir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The git repository is broken, so everything comes from the cache.
+	srv := httptest.NewServer(Proxy(
+		GitRepo{Dir: filepath.Join(t.TempDir(), "missing"), Root: "example.com/Other"},
+		CacheDir{Dir: cache},
+	))
+	defer srv.Close()
+
+	for _, tt := range []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/example.com/!other/@v/list", http.StatusOK, "v0.1.0\nv0.2.0-pre\n"},
+		{"/example.com/!other/@latest", http.StatusOK, `{"Version":"v0.1.0","Time":"2024-01-01T00:00:00Z"}`},
+		{"/example.com/!other/@v/v0.1.0.mod", http.StatusOK, "module example.com/Other\n"},
+		{"/example.com/!other/@v/v0.1.0.zip", http.StatusOK, "not really a zip"},
+		{"/example.com/!other/@v/v0.2.0.info", http.StatusNotFound, ""},
+		{"/example.com/!other/@v/main.info", http.StatusNotFound, ""},
+		{"/example.com/nope/@v/list", http.StatusNotFound, ""},
+	} {
+		status, body := get(t, srv, tt.path)
+		if status != tt.status {
+			t.Errorf("%s: got status %d, want %d: %s", tt.path, status, tt.status, body)
+			continue
+		}
+		if tt.body != "" && strings.TrimSpace(string(body)) != strings.TrimSpace(tt.body) {
+			t.Errorf("%s: got:\n%s\nwant:\n%s", tt.path, body, tt.body)
+		}
+	}
+}
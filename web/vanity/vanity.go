@@ -30,7 +30,10 @@ func WithImport(importPath, vcs, vcsRoot string) Option {
 	}
 }
 
-// WithGoModProxy adds a go module proxy to the option chain.
+// WithGoModProxy adds a go module proxy to the option chain. The go command
+// prefers it over other imports of the same importPath, so it can be used
+// with the ones GitHubHandler and GogsHandler add. See Proxy for serving
+// one.
 func WithGoModProxy(importPath, proxyServer string) Option {
 	return WithImport(importPath, "mod", proxyServer)
 }
@@ -168,20 +171,22 @@ func WithGogsStyleSource(importPath, repoPath, ref string) Option {
 }
 
 // Creates a Handler that serves a GitHub repository at a specific importPath.
-func GitHubHandler(importPath, user, repo, gitScheme string) http.Handler {
+// Options such as WithGoModProxy or WithRedirector can be added with opts.
+func GitHubHandler(importPath, user, repo, gitScheme string, opts ...Option) http.Handler {
 	ghImportPath := "github.com/" + user + "/" + repo
-	return Handler(
+	return Handler(append([]Option{
 		WithImport(importPath, "git", gitScheme+"://"+ghImportPath),
 		WithGitHubStyleSource(importPath, "https://"+ghImportPath, "master"),
-	)
+	}, opts...)...)
 }
 
 // Creates a Handler that serves a repository hosted with Gogs at host at a
-// specific importPath.
-func GogsHandler(importPath, host, user, repo, gitScheme string) http.Handler {
+// specific importPath. Options such as WithGoModProxy or WithRedirector can
+// be added with opts.
+func GogsHandler(importPath, host, user, repo, gitScheme string, opts ...Option) http.Handler {
 	gogsImportPath := host + "/" + user + "/" + repo
-	return Handler(
+	return Handler(append([]Option{
 		WithImport(importPath, "git", gitScheme+"://"+gogsImportPath),
 		WithGogsStyleSource(importPath, "https://"+gogsImportPath, "master"),
-	)
+	}, opts...)...)
 }